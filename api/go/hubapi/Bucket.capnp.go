@@ -1944,95 +1944,1206 @@ func (f CapBucketCursor_seek_Results_Future) Struct() (CapBucketCursor_seek_Resu
 	return CapBucketCursor_seek_Results(p.Struct()), err
 }
 
-const schema_893d996fbc85a1c3 = "x\xda\xccV_h\x1c\xd5\x17>\xdf\xbd3;\xbb\xf9" +
-	"e\x7f\xc9\xb0km\xabe5\xe4\xa1\xad6mS[" +
-	"J\xb1d\x93\xda\xd2Z+\xb9[\x11\xfb\xa4\xd3\xec\xb4" +
-	"\xd9&\xd9\xdd\xceL\xa2[\x8cM\xab\x11\x12\x1b\x84\x80" +
-	"H\x1e\x17EQ\x88\xa5(\xf8\xa71(m\x11!\xa0" +
-	"A,\xe6AD0\xd4 \xf4A,\xf8\"#wv" +
-	"gv\x82\x98?\x0f\x1a\x9f\x92;\xf7\x9cs\xbf\xf3\x9d" +
-	"s\xbe\xb3;.\xf0\xb4\xb23\xfe\xbaFL<\xa5F" +
-	"\xdc\x85\xf5\xe5\x91\x8d\x93\x97\x87H4\x03D*\xd3\x88" +
-	"v]\xe1\xa3 $>\xe7\x97\x09\xee^\xf58\xd3\xe2" +
-	"\x9f\\ \xbd\x19D\x8a\xbc?\xa1\\\x04)\xee\x99[" +
-	"\x872\x1b\xd4\xe9\xe1\xd0\xcd\xc1\xca\xcdWb\xe2\x9d\x1b" +
-	"\x87/\xbd\xecG\x85\xbc\xdb\xa9\x8c\xcb\xa8\xed\xca\xb3\x04" +
-	"w\xff\\\xc7{{\x9e~\xe9\x12\x89$\x98{\xad<" +
-	"<U\x98\xd8?R}\xbf\xac4!qE\x06LL" +
-	"*\xb7\x08\xee\xee7\xae\xfe\xf0s\xdb\xba\xf1E(G" +
-	"T/\xde\x84*Q\xbe\xf9{b\xe6\x97\xdb\xa5\xc5\x16" +
-	";#^\x1e\xed\x11i\xd1pr\xa8\xfe\x85\xcf\xee)" +
-	"\x93\x9e\xe4\xb5\x07\x09\xbb\xe6\"MH,D\xa4\xc3|" +
-	"\xe4\x0b$\xfe\xd04\"\xf7\xfa\xab\x89:\xe5\xe1\xb1\xb7" +
-	"B\xc9\xcdk\xa32\xb9X\xcf&gtC\xd3\x87\xd5" +
-	"\xa7\xbc\xabo5\xef\xa5y\xad\x8d\xe0^\xbf6\xb4\xb9" +
-	"\xfd\xd7/\xa7\x17aQ\xa3\x9e\xc5]Q\x89%{\xba" +
-	"{F\xb4\xdc\x9c\x0d\x87\x98\xac\x18|\x1a\x95!J\x87" +
-	"\x8c\xf8x\xe2\xfc\xcd\xca\xeb\x15\xfe\xbe\x8f^\x94\x06\x0b" +
-	"\x9e\xc1\xba)\xf3\x81o^|\xe5\xbbE\x0c\xc7c\x1e" +
-	"#\x9bb\x92\xe1\xf2\xfbSWoOo\x99#\xfd>" +
-	"\xb8\x83c\xf9='\xee|t\xa7\x0af0\xd6\x8a\xc4" +
-	"XL\x12<\xe2\x19\xbf\xbb\xb9n\xfb\x99\xb7\x9d\x9f\xc2" +
-	"\xd9\xc6\xbcR~p\xff\xc0\xfe\x99\xd9\xaf\x7f[\x94\xcc" +
-	"l\xcc\xc3\xfacL&\x13\xc4\xd6\x93\x08U\xd2CT" +
-	"\xaacH\x0c\xd7\xddM\x94\x18\xabk\xa3mnw\xff" +
-	"I\xa3\x98\xdb\xde\xa1\xf6w\xf5\x98NK\x97Q\xcc\x17" +
-	"\xf7\x1d0\x8a\x1d\xde\xf9@\xbfe\x17\xac\x96\xa2e\x0e" +
-	"4gL\xbb\xbf\xd7\xb1\x89D=W\x88\x14\x10\xe9\x07" +
-	"\x9b\x88D\x9aC<\xc6\xa0\x03I\x89H?\xd2J$" +
-	"\x1e\xe1\x10\x9d\x0c`I0\"\xfd\x98\xfcv\x98C<" +
-	"\xc1\xa0\xf5\x98%\xd4\x13C=!5`\xf4\xf6\x9b\x88" +
-	"\x13C\xbcr\xcae\x01b\x00!\xc0\xa6/\x8b\xad\xd3" +
-	"\xb0\x8c>\x9b|\x87\xa5\xec{\x0d\xdbY\x8d}\xde|" +
-	"\xcey\xbc\x9a<l\x11\x0dr\xdf\xb2\x95H4s\x88" +
-	"\x1d\x0c~\xea\xdb\xce\x11\x89\x079\xc4^\x86\x86l\xa1" +
-	"\xcbFc\xad\x1c\x044\x12\xdc\x9cc\xf6\xd9\x19\xb3\x8f" +
-	"\xda\x8c\\>\x97?\xfd\x97ty\x18N\x05\xcbq\xa7" +
-	"`\x99G\xb4\xfc\xa9B' \x1a\x03\x0c\xc6\xa3D\xe2" +
-	"\x19\x0e\xd1[\xc3\x90\xdbG$\xb2\x1c\xa2\xc8\xa03T" +
-	"\xf8\xef\xdbH$\xba9\x84\xc3\xa0s$\xc1\x89\xf4\xb3" +
-	"\x19\"Q\xe4\x10\xcf3\xb8Y\xc31\x8e\xe7\xce\x99D" +
-	"\x04\x95\x18TB\x9b\x99?\x9d\xcb\x9b~\xadx.\xeb" +
-	"\xff\xeb\xe6\xad\x8c\xd9U\xb0\xb2\x04\xdb\xb7^Y+\x9d" +
-	"\xcaY\xb6S\xa3\xf3\xdfn\xa5%\xb1\xd9\xa6\xd9\xb3\x86" +
-	"m\xce\xff\x0e\x9bf\x17,Y\xf7{\xb9\x1a\x12B\xf8" +
-	"\"\xac\xcf\xb6\x12\xd3oh@\xb0\x01\xe0K\x9e\xfe\xf1" +
-	"Vb\xfa\xa4\x06\x16H\x0a|\x05\xd1\xcb\xf2\xee5\x0d" +
-	"<\x90P\xf8\x8bB\x1f\x911\x075(\xc1\xbe\x81\xbf" +
-	"\x9a\xf4\xb3\xd2\xcf\xd4\xa0\x06\xba\x09_\xfe\xf4\x13\xd2\xef" +
-	"\x98\x86H \x99\xf0W\x81\xde.\xfdvk)\xaf\x07" +
-	"\xd2h\x90\xa3\x98F\x83\x9c\xb04R\xde\xa0\xa5\xd1 " +
-	"\x07:\x8d\x94\xfc#\x8f\xb2(itbU\xfdU\x9d" +
-	"oZ\x99Oe\xc4}\x1f\xa1\x04u\x8f\xcbrF9" +
-	"D\x92!e;f\xd1F\x94\x18\xa2+m(Ok" +
-	"\xd6\xae\xa1\x96\xd5\xf4\x7f$io\x8a\xaaq\xc3a3" +
-	"\x1e\x03\x10\xeb\x19\\\xdb4\xac\xae\xee\xa3&!\xc8h" +
-	"5\xa8\xd7L\x8d\x8f\x9a\xa5'%\xf1\xc7\x8cbK\xea" +
-	"`\xde\xb1Jr.C(\x9aj(\x82\xban\x93\x84" +
-	"n\xe6\x10\x0f-U\xc3\xe0\xc1\xff-\xd7\xaa\xab\xdd^" +
-	"\xff\x15I\x0b\x91GBA\xf8\x17\x11Z+l\x86\xfb" +
-	"\xa5\xa3\xda\x86\xcd\x0c\xe7\xcd\xbcc\xe5L\x1b\xff't" +
-	"r\xa0\xb1\xe6J\x90\x1f\xff\x0c\x00\x00\xff\xff\x128\xe0" +
-	"P"
+type CapBucketTx capnp.Client
+
+// CapBucketTx_TypeID is the unique identifier for the type CapBucketTx.
+const CapBucketTx_TypeID = 0xae247b4f99505b69
+
+func (c CapBucketTx) Commit(ctx context.Context, params func(CapBucketTx_commit_Params) error) (CapBucketTx_commit_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xae247b4f99505b69,
+			MethodID:      0,
+			InterfaceName: "hubapi/Bucket.capnp:CapBucketTx",
+			MethodName:    "commit",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 0}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapBucketTx_commit_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapBucketTx_commit_Results_Future{Future: ans.Future()}, release
+}
+func (c CapBucketTx) Delete(ctx context.Context, params func(CapBucketTx_delete_Params) error) (CapBucketTx_delete_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xae247b4f99505b69,
+			MethodID:      1,
+			InterfaceName: "hubapi/Bucket.capnp:CapBucketTx",
+			MethodName:    "delete",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 1}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapBucketTx_delete_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapBucketTx_delete_Results_Future{Future: ans.Future()}, release
+}
+func (c CapBucketTx) Get(ctx context.Context, params func(CapBucketTx_get_Params) error) (CapBucketTx_get_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xae247b4f99505b69,
+			MethodID:      2,
+			InterfaceName: "hubapi/Bucket.capnp:CapBucketTx",
+			MethodName:    "get",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 1}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapBucketTx_get_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapBucketTx_get_Results_Future{Future: ans.Future()}, release
+}
+func (c CapBucketTx) Rollback(ctx context.Context, params func(CapBucketTx_rollback_Params) error) (CapBucketTx_rollback_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xae247b4f99505b69,
+			MethodID:      3,
+			InterfaceName: "hubapi/Bucket.capnp:CapBucketTx",
+			MethodName:    "rollback",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 0}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapBucketTx_rollback_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapBucketTx_rollback_Results_Future{Future: ans.Future()}, release
+}
+func (c CapBucketTx) Set(ctx context.Context, params func(CapBucketTx_set_Params) error) (CapBucketTx_set_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xae247b4f99505b69,
+			MethodID:      4,
+			InterfaceName: "hubapi/Bucket.capnp:CapBucketTx",
+			MethodName:    "set",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 2}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapBucketTx_set_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapBucketTx_set_Results_Future{Future: ans.Future()}, release
+}
+
+// String returns a string that identifies this capability for debugging
+// purposes.  Its format should not be depended on: in particular, it
+// should not be used to compare clients.  Use IsSame to compare clients
+// for equality.
+func (c CapBucketTx) String() string {
+	return fmt.Sprintf("%T(%v)", c, capnp.Client(c))
+}
+
+// AddRef creates a new Client that refers to the same capability as c.
+// If c is nil or has resolved to null, then AddRef returns nil.
+func (c CapBucketTx) AddRef() CapBucketTx {
+	return CapBucketTx(capnp.Client(c).AddRef())
+}
+
+// Release releases a capability reference.  If this is the last
+// reference to the capability, then the underlying resources associated
+// with the capability will be released.
+//
+// Release will panic if c has already been released, but not if c is
+// nil or resolved to null.
+func (c CapBucketTx) Release() {
+	capnp.Client(c).Release()
+}
+
+// Resolve blocks until the capability is fully resolved or the Context
+// expires.
+func (c CapBucketTx) Resolve(ctx context.Context) error {
+	return capnp.Client(c).Resolve(ctx)
+}
+
+func (c CapBucketTx) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Client(c).EncodeAsPtr(seg)
+}
+
+func (CapBucketTx) DecodeFromPtr(p capnp.Ptr) CapBucketTx {
+	return CapBucketTx(capnp.Client{}.DecodeFromPtr(p))
+}
+
+// IsValid reports whether c is a valid reference to a capability.
+// A reference is invalid if it is nil, has resolved to null, or has
+// been released.
+func (c CapBucketTx) IsValid() bool {
+	return capnp.Client(c).IsValid()
+}
+
+// IsSame reports whether c and other refer to a capability created by the
+// same call to NewClient.  This can return false negatives if c or other
+// are not fully resolved: use Resolve if this is an issue.  If either
+// c or other are released, then IsSame panics.
+func (c CapBucketTx) IsSame(other CapBucketTx) bool {
+	return capnp.Client(c).IsSame(capnp.Client(other))
+}
+
+// Update the flowcontrol.FlowLimiter used to manage flow control for
+// this client. This affects all future calls, but not calls already
+// waiting to send. Passing nil sets the value to flowcontrol.NopLimiter,
+// which is also the default.
+func (c CapBucketTx) SetFlowLimiter(lim fc.FlowLimiter) {
+	capnp.Client(c).SetFlowLimiter(lim)
+}
+
+// Get the current flowcontrol.FlowLimiter used to manage flow control
+// for this client.
+func (c CapBucketTx) GetFlowLimiter() fc.FlowLimiter {
+	return capnp.Client(c).GetFlowLimiter()
+} // A CapBucketTx_Server is a CapBucketTx with a local implementation.
+type CapBucketTx_Server interface {
+	Commit(context.Context, CapBucketTx_commit) error
+
+	Delete(context.Context, CapBucketTx_delete) error
+
+	Get(context.Context, CapBucketTx_get) error
+
+	Rollback(context.Context, CapBucketTx_rollback) error
+
+	Set(context.Context, CapBucketTx_set) error
+}
+
+// CapBucketTx_NewServer creates a new Server from an implementation of CapBucketTx_Server.
+func CapBucketTx_NewServer(s CapBucketTx_Server) *server.Server {
+	c, _ := s.(server.Shutdowner)
+	return server.New(CapBucketTx_Methods(nil, s), s, c)
+}
+
+// CapBucketTx_ServerToClient creates a new Client from an implementation of CapBucketTx_Server.
+// The caller is responsible for calling Release on the returned Client.
+func CapBucketTx_ServerToClient(s CapBucketTx_Server) CapBucketTx {
+	return CapBucketTx(capnp.NewClient(CapBucketTx_NewServer(s)))
+}
+
+// CapBucketTx_Methods appends Methods to a slice that invoke the methods on s.
+// This can be used to create a more complicated Server.
+func CapBucketTx_Methods(methods []server.Method, s CapBucketTx_Server) []server.Method {
+	if cap(methods) == 0 {
+		methods = make([]server.Method, 0, 5)
+	}
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xae247b4f99505b69,
+			MethodID:      0,
+			InterfaceName: "hubapi/Bucket.capnp:CapBucketTx",
+			MethodName:    "commit",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Commit(ctx, CapBucketTx_commit{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xae247b4f99505b69,
+			MethodID:      1,
+			InterfaceName: "hubapi/Bucket.capnp:CapBucketTx",
+			MethodName:    "delete",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Delete(ctx, CapBucketTx_delete{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xae247b4f99505b69,
+			MethodID:      2,
+			InterfaceName: "hubapi/Bucket.capnp:CapBucketTx",
+			MethodName:    "get",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Get(ctx, CapBucketTx_get{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xae247b4f99505b69,
+			MethodID:      3,
+			InterfaceName: "hubapi/Bucket.capnp:CapBucketTx",
+			MethodName:    "rollback",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Rollback(ctx, CapBucketTx_rollback{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xae247b4f99505b69,
+			MethodID:      4,
+			InterfaceName: "hubapi/Bucket.capnp:CapBucketTx",
+			MethodName:    "set",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Set(ctx, CapBucketTx_set{call})
+		},
+	})
+
+	return methods
+}
+
+// CapBucketTx_commit holds the state for a server call to CapBucketTx.commit.
+// See server.Call for documentation.
+type CapBucketTx_commit struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapBucketTx_commit) Args() CapBucketTx_commit_Params {
+	return CapBucketTx_commit_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapBucketTx_commit) AllocResults() (CapBucketTx_commit_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_commit_Results(r), err
+}
+
+// CapBucketTx_delete holds the state for a server call to CapBucketTx.delete.
+// See server.Call for documentation.
+type CapBucketTx_delete struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapBucketTx_delete) Args() CapBucketTx_delete_Params {
+	return CapBucketTx_delete_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapBucketTx_delete) AllocResults() (CapBucketTx_delete_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_delete_Results(r), err
+}
+
+// CapBucketTx_get holds the state for a server call to CapBucketTx.get.
+// See server.Call for documentation.
+type CapBucketTx_get struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapBucketTx_get) Args() CapBucketTx_get_Params {
+	return CapBucketTx_get_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapBucketTx_get) AllocResults() (CapBucketTx_get_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return CapBucketTx_get_Results(r), err
+}
+
+// CapBucketTx_rollback holds the state for a server call to CapBucketTx.rollback.
+// See server.Call for documentation.
+type CapBucketTx_rollback struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapBucketTx_rollback) Args() CapBucketTx_rollback_Params {
+	return CapBucketTx_rollback_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapBucketTx_rollback) AllocResults() (CapBucketTx_rollback_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_rollback_Results(r), err
+}
+
+// CapBucketTx_set holds the state for a server call to CapBucketTx.set.
+// See server.Call for documentation.
+type CapBucketTx_set struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapBucketTx_set) Args() CapBucketTx_set_Params {
+	return CapBucketTx_set_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapBucketTx_set) AllocResults() (CapBucketTx_set_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_set_Results(r), err
+}
+
+// CapBucketTx_List is a list of CapBucketTx.
+type CapBucketTx_List = capnp.CapList[CapBucketTx]
+
+// NewCapBucketTx creates a new list of CapBucketTx.
+func NewCapBucketTx_List(s *capnp.Segment, sz int32) (CapBucketTx_List, error) {
+	l, err := capnp.NewPointerList(s, sz)
+	return capnp.CapList[CapBucketTx](l), err
+}
+
+type CapBucketTx_commit_Params capnp.Struct
+
+// CapBucketTx_commit_Params_TypeID is the unique identifier for the type CapBucketTx_commit_Params.
+const CapBucketTx_commit_Params_TypeID = 0xca8a70ecc3d2a4c4
+
+func NewCapBucketTx_commit_Params(s *capnp.Segment) (CapBucketTx_commit_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_commit_Params(st), err
+}
+
+func NewRootCapBucketTx_commit_Params(s *capnp.Segment) (CapBucketTx_commit_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_commit_Params(st), err
+}
+
+func ReadRootCapBucketTx_commit_Params(msg *capnp.Message) (CapBucketTx_commit_Params, error) {
+	root, err := msg.Root()
+	return CapBucketTx_commit_Params(root.Struct()), err
+}
+
+func (s CapBucketTx_commit_Params) String() string {
+	str, _ := text.Marshal(0xca8a70ecc3d2a4c4, capnp.Struct(s))
+	return str
+}
+
+func (s CapBucketTx_commit_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapBucketTx_commit_Params) DecodeFromPtr(p capnp.Ptr) CapBucketTx_commit_Params {
+	return CapBucketTx_commit_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapBucketTx_commit_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapBucketTx_commit_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapBucketTx_commit_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapBucketTx_commit_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapBucketTx_commit_Params_List is a list of CapBucketTx_commit_Params.
+type CapBucketTx_commit_Params_List = capnp.StructList[CapBucketTx_commit_Params]
+
+// NewCapBucketTx_commit_Params creates a new list of CapBucketTx_commit_Params.
+func NewCapBucketTx_commit_Params_List(s *capnp.Segment, sz int32) (CapBucketTx_commit_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapBucketTx_commit_Params](l), err
+}
+
+// CapBucketTx_commit_Params_Future is a wrapper for a CapBucketTx_commit_Params promised by a client call.
+type CapBucketTx_commit_Params_Future struct{ *capnp.Future }
+
+func (f CapBucketTx_commit_Params_Future) Struct() (CapBucketTx_commit_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapBucketTx_commit_Params(p.Struct()), err
+}
+
+type CapBucketTx_commit_Results capnp.Struct
+
+// CapBucketTx_commit_Results_TypeID is the unique identifier for the type CapBucketTx_commit_Results.
+const CapBucketTx_commit_Results_TypeID = 0xc0b713368f3addd7
+
+func NewCapBucketTx_commit_Results(s *capnp.Segment) (CapBucketTx_commit_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_commit_Results(st), err
+}
+
+func NewRootCapBucketTx_commit_Results(s *capnp.Segment) (CapBucketTx_commit_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_commit_Results(st), err
+}
+
+func ReadRootCapBucketTx_commit_Results(msg *capnp.Message) (CapBucketTx_commit_Results, error) {
+	root, err := msg.Root()
+	return CapBucketTx_commit_Results(root.Struct()), err
+}
+
+func (s CapBucketTx_commit_Results) String() string {
+	str, _ := text.Marshal(0xc0b713368f3addd7, capnp.Struct(s))
+	return str
+}
+
+func (s CapBucketTx_commit_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapBucketTx_commit_Results) DecodeFromPtr(p capnp.Ptr) CapBucketTx_commit_Results {
+	return CapBucketTx_commit_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapBucketTx_commit_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapBucketTx_commit_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapBucketTx_commit_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapBucketTx_commit_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapBucketTx_commit_Results_List is a list of CapBucketTx_commit_Results.
+type CapBucketTx_commit_Results_List = capnp.StructList[CapBucketTx_commit_Results]
+
+// NewCapBucketTx_commit_Results creates a new list of CapBucketTx_commit_Results.
+func NewCapBucketTx_commit_Results_List(s *capnp.Segment, sz int32) (CapBucketTx_commit_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapBucketTx_commit_Results](l), err
+}
+
+// CapBucketTx_commit_Results_Future is a wrapper for a CapBucketTx_commit_Results promised by a client call.
+type CapBucketTx_commit_Results_Future struct{ *capnp.Future }
+
+func (f CapBucketTx_commit_Results_Future) Struct() (CapBucketTx_commit_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapBucketTx_commit_Results(p.Struct()), err
+}
+
+type CapBucketTx_delete_Params capnp.Struct
+
+// CapBucketTx_delete_Params_TypeID is the unique identifier for the type CapBucketTx_delete_Params.
+const CapBucketTx_delete_Params_TypeID = 0xaa041ca347909a79
+
+func NewCapBucketTx_delete_Params(s *capnp.Segment) (CapBucketTx_delete_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapBucketTx_delete_Params(st), err
+}
+
+func NewRootCapBucketTx_delete_Params(s *capnp.Segment) (CapBucketTx_delete_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapBucketTx_delete_Params(st), err
+}
+
+func ReadRootCapBucketTx_delete_Params(msg *capnp.Message) (CapBucketTx_delete_Params, error) {
+	root, err := msg.Root()
+	return CapBucketTx_delete_Params(root.Struct()), err
+}
+
+func (s CapBucketTx_delete_Params) String() string {
+	str, _ := text.Marshal(0xaa041ca347909a79, capnp.Struct(s))
+	return str
+}
+
+func (s CapBucketTx_delete_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapBucketTx_delete_Params) DecodeFromPtr(p capnp.Ptr) CapBucketTx_delete_Params {
+	return CapBucketTx_delete_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapBucketTx_delete_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapBucketTx_delete_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapBucketTx_delete_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapBucketTx_delete_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapBucketTx_delete_Params) Key() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapBucketTx_delete_Params) HasKey() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapBucketTx_delete_Params) KeyBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapBucketTx_delete_Params) SetKey(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+// CapBucketTx_delete_Params_List is a list of CapBucketTx_delete_Params.
+type CapBucketTx_delete_Params_List = capnp.StructList[CapBucketTx_delete_Params]
+
+// NewCapBucketTx_delete_Params creates a new list of CapBucketTx_delete_Params.
+func NewCapBucketTx_delete_Params_List(s *capnp.Segment, sz int32) (CapBucketTx_delete_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapBucketTx_delete_Params](l), err
+}
+
+// CapBucketTx_delete_Params_Future is a wrapper for a CapBucketTx_delete_Params promised by a client call.
+type CapBucketTx_delete_Params_Future struct{ *capnp.Future }
+
+func (f CapBucketTx_delete_Params_Future) Struct() (CapBucketTx_delete_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapBucketTx_delete_Params(p.Struct()), err
+}
+
+type CapBucketTx_delete_Results capnp.Struct
+
+// CapBucketTx_delete_Results_TypeID is the unique identifier for the type CapBucketTx_delete_Results.
+const CapBucketTx_delete_Results_TypeID = 0xd445a46c99367956
+
+func NewCapBucketTx_delete_Results(s *capnp.Segment) (CapBucketTx_delete_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_delete_Results(st), err
+}
+
+func NewRootCapBucketTx_delete_Results(s *capnp.Segment) (CapBucketTx_delete_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_delete_Results(st), err
+}
+
+func ReadRootCapBucketTx_delete_Results(msg *capnp.Message) (CapBucketTx_delete_Results, error) {
+	root, err := msg.Root()
+	return CapBucketTx_delete_Results(root.Struct()), err
+}
+
+func (s CapBucketTx_delete_Results) String() string {
+	str, _ := text.Marshal(0xd445a46c99367956, capnp.Struct(s))
+	return str
+}
+
+func (s CapBucketTx_delete_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapBucketTx_delete_Results) DecodeFromPtr(p capnp.Ptr) CapBucketTx_delete_Results {
+	return CapBucketTx_delete_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapBucketTx_delete_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapBucketTx_delete_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapBucketTx_delete_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapBucketTx_delete_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapBucketTx_delete_Results_List is a list of CapBucketTx_delete_Results.
+type CapBucketTx_delete_Results_List = capnp.StructList[CapBucketTx_delete_Results]
+
+// NewCapBucketTx_delete_Results creates a new list of CapBucketTx_delete_Results.
+func NewCapBucketTx_delete_Results_List(s *capnp.Segment, sz int32) (CapBucketTx_delete_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapBucketTx_delete_Results](l), err
+}
+
+// CapBucketTx_delete_Results_Future is a wrapper for a CapBucketTx_delete_Results promised by a client call.
+type CapBucketTx_delete_Results_Future struct{ *capnp.Future }
+
+func (f CapBucketTx_delete_Results_Future) Struct() (CapBucketTx_delete_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapBucketTx_delete_Results(p.Struct()), err
+}
+
+type CapBucketTx_get_Params capnp.Struct
+
+// CapBucketTx_get_Params_TypeID is the unique identifier for the type CapBucketTx_get_Params.
+const CapBucketTx_get_Params_TypeID = 0xe13a99d89d757e8d
+
+func NewCapBucketTx_get_Params(s *capnp.Segment) (CapBucketTx_get_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapBucketTx_get_Params(st), err
+}
+
+func NewRootCapBucketTx_get_Params(s *capnp.Segment) (CapBucketTx_get_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapBucketTx_get_Params(st), err
+}
+
+func ReadRootCapBucketTx_get_Params(msg *capnp.Message) (CapBucketTx_get_Params, error) {
+	root, err := msg.Root()
+	return CapBucketTx_get_Params(root.Struct()), err
+}
+
+func (s CapBucketTx_get_Params) String() string {
+	str, _ := text.Marshal(0xe13a99d89d757e8d, capnp.Struct(s))
+	return str
+}
+
+func (s CapBucketTx_get_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapBucketTx_get_Params) DecodeFromPtr(p capnp.Ptr) CapBucketTx_get_Params {
+	return CapBucketTx_get_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapBucketTx_get_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapBucketTx_get_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapBucketTx_get_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapBucketTx_get_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapBucketTx_get_Params) Key() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapBucketTx_get_Params) HasKey() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapBucketTx_get_Params) KeyBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapBucketTx_get_Params) SetKey(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+// CapBucketTx_get_Params_List is a list of CapBucketTx_get_Params.
+type CapBucketTx_get_Params_List = capnp.StructList[CapBucketTx_get_Params]
+
+// NewCapBucketTx_get_Params creates a new list of CapBucketTx_get_Params.
+func NewCapBucketTx_get_Params_List(s *capnp.Segment, sz int32) (CapBucketTx_get_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapBucketTx_get_Params](l), err
+}
+
+// CapBucketTx_get_Params_Future is a wrapper for a CapBucketTx_get_Params promised by a client call.
+type CapBucketTx_get_Params_Future struct{ *capnp.Future }
+
+func (f CapBucketTx_get_Params_Future) Struct() (CapBucketTx_get_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapBucketTx_get_Params(p.Struct()), err
+}
+
+type CapBucketTx_get_Results capnp.Struct
+
+// CapBucketTx_get_Results_TypeID is the unique identifier for the type CapBucketTx_get_Results.
+const CapBucketTx_get_Results_TypeID = 0xaab6a0e55d8f9bdb
+
+func NewCapBucketTx_get_Results(s *capnp.Segment) (CapBucketTx_get_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return CapBucketTx_get_Results(st), err
+}
+
+func NewRootCapBucketTx_get_Results(s *capnp.Segment) (CapBucketTx_get_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return CapBucketTx_get_Results(st), err
+}
+
+func ReadRootCapBucketTx_get_Results(msg *capnp.Message) (CapBucketTx_get_Results, error) {
+	root, err := msg.Root()
+	return CapBucketTx_get_Results(root.Struct()), err
+}
+
+func (s CapBucketTx_get_Results) String() string {
+	str, _ := text.Marshal(0xaab6a0e55d8f9bdb, capnp.Struct(s))
+	return str
+}
+
+func (s CapBucketTx_get_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapBucketTx_get_Results) DecodeFromPtr(p capnp.Ptr) CapBucketTx_get_Results {
+	return CapBucketTx_get_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapBucketTx_get_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapBucketTx_get_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapBucketTx_get_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapBucketTx_get_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapBucketTx_get_Results) Value() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s CapBucketTx_get_Results) HasValue() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapBucketTx_get_Results) SetValue(v []byte) error {
+	return capnp.Struct(s).SetData(0, v)
+}
+
+func (s CapBucketTx_get_Results) Found() bool {
+	return capnp.Struct(s).Bit(0)
+}
+
+func (s CapBucketTx_get_Results) SetFound(v bool) {
+	capnp.Struct(s).SetBit(0, v)
+}
+
+// CapBucketTx_get_Results_List is a list of CapBucketTx_get_Results.
+type CapBucketTx_get_Results_List = capnp.StructList[CapBucketTx_get_Results]
+
+// NewCapBucketTx_get_Results creates a new list of CapBucketTx_get_Results.
+func NewCapBucketTx_get_Results_List(s *capnp.Segment, sz int32) (CapBucketTx_get_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return capnp.StructList[CapBucketTx_get_Results](l), err
+}
+
+// CapBucketTx_get_Results_Future is a wrapper for a CapBucketTx_get_Results promised by a client call.
+type CapBucketTx_get_Results_Future struct{ *capnp.Future }
+
+func (f CapBucketTx_get_Results_Future) Struct() (CapBucketTx_get_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapBucketTx_get_Results(p.Struct()), err
+}
+
+type CapBucketTx_rollback_Params capnp.Struct
+
+// CapBucketTx_rollback_Params_TypeID is the unique identifier for the type CapBucketTx_rollback_Params.
+const CapBucketTx_rollback_Params_TypeID = 0xfcd3479ac4622a1d
+
+func NewCapBucketTx_rollback_Params(s *capnp.Segment) (CapBucketTx_rollback_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_rollback_Params(st), err
+}
+
+func NewRootCapBucketTx_rollback_Params(s *capnp.Segment) (CapBucketTx_rollback_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_rollback_Params(st), err
+}
+
+func ReadRootCapBucketTx_rollback_Params(msg *capnp.Message) (CapBucketTx_rollback_Params, error) {
+	root, err := msg.Root()
+	return CapBucketTx_rollback_Params(root.Struct()), err
+}
+
+func (s CapBucketTx_rollback_Params) String() string {
+	str, _ := text.Marshal(0xfcd3479ac4622a1d, capnp.Struct(s))
+	return str
+}
+
+func (s CapBucketTx_rollback_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapBucketTx_rollback_Params) DecodeFromPtr(p capnp.Ptr) CapBucketTx_rollback_Params {
+	return CapBucketTx_rollback_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapBucketTx_rollback_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapBucketTx_rollback_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapBucketTx_rollback_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapBucketTx_rollback_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapBucketTx_rollback_Params_List is a list of CapBucketTx_rollback_Params.
+type CapBucketTx_rollback_Params_List = capnp.StructList[CapBucketTx_rollback_Params]
+
+// NewCapBucketTx_rollback_Params creates a new list of CapBucketTx_rollback_Params.
+func NewCapBucketTx_rollback_Params_List(s *capnp.Segment, sz int32) (CapBucketTx_rollback_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapBucketTx_rollback_Params](l), err
+}
+
+// CapBucketTx_rollback_Params_Future is a wrapper for a CapBucketTx_rollback_Params promised by a client call.
+type CapBucketTx_rollback_Params_Future struct{ *capnp.Future }
+
+func (f CapBucketTx_rollback_Params_Future) Struct() (CapBucketTx_rollback_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapBucketTx_rollback_Params(p.Struct()), err
+}
+
+type CapBucketTx_rollback_Results capnp.Struct
+
+// CapBucketTx_rollback_Results_TypeID is the unique identifier for the type CapBucketTx_rollback_Results.
+const CapBucketTx_rollback_Results_TypeID = 0xbca22af07380938b
+
+func NewCapBucketTx_rollback_Results(s *capnp.Segment) (CapBucketTx_rollback_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_rollback_Results(st), err
+}
+
+func NewRootCapBucketTx_rollback_Results(s *capnp.Segment) (CapBucketTx_rollback_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_rollback_Results(st), err
+}
+
+func ReadRootCapBucketTx_rollback_Results(msg *capnp.Message) (CapBucketTx_rollback_Results, error) {
+	root, err := msg.Root()
+	return CapBucketTx_rollback_Results(root.Struct()), err
+}
+
+func (s CapBucketTx_rollback_Results) String() string {
+	str, _ := text.Marshal(0xbca22af07380938b, capnp.Struct(s))
+	return str
+}
+
+func (s CapBucketTx_rollback_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapBucketTx_rollback_Results) DecodeFromPtr(p capnp.Ptr) CapBucketTx_rollback_Results {
+	return CapBucketTx_rollback_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapBucketTx_rollback_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapBucketTx_rollback_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapBucketTx_rollback_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapBucketTx_rollback_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapBucketTx_rollback_Results_List is a list of CapBucketTx_rollback_Results.
+type CapBucketTx_rollback_Results_List = capnp.StructList[CapBucketTx_rollback_Results]
+
+// NewCapBucketTx_rollback_Results creates a new list of CapBucketTx_rollback_Results.
+func NewCapBucketTx_rollback_Results_List(s *capnp.Segment, sz int32) (CapBucketTx_rollback_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapBucketTx_rollback_Results](l), err
+}
+
+// CapBucketTx_rollback_Results_Future is a wrapper for a CapBucketTx_rollback_Results promised by a client call.
+type CapBucketTx_rollback_Results_Future struct{ *capnp.Future }
+
+func (f CapBucketTx_rollback_Results_Future) Struct() (CapBucketTx_rollback_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapBucketTx_rollback_Results(p.Struct()), err
+}
+
+type CapBucketTx_set_Params capnp.Struct
+
+// CapBucketTx_set_Params_TypeID is the unique identifier for the type CapBucketTx_set_Params.
+const CapBucketTx_set_Params_TypeID = 0x8237133b8bb990e3
+
+func NewCapBucketTx_set_Params(s *capnp.Segment) (CapBucketTx_set_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return CapBucketTx_set_Params(st), err
+}
+
+func NewRootCapBucketTx_set_Params(s *capnp.Segment) (CapBucketTx_set_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return CapBucketTx_set_Params(st), err
+}
+
+func ReadRootCapBucketTx_set_Params(msg *capnp.Message) (CapBucketTx_set_Params, error) {
+	root, err := msg.Root()
+	return CapBucketTx_set_Params(root.Struct()), err
+}
+
+func (s CapBucketTx_set_Params) String() string {
+	str, _ := text.Marshal(0x8237133b8bb990e3, capnp.Struct(s))
+	return str
+}
+
+func (s CapBucketTx_set_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapBucketTx_set_Params) DecodeFromPtr(p capnp.Ptr) CapBucketTx_set_Params {
+	return CapBucketTx_set_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapBucketTx_set_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapBucketTx_set_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapBucketTx_set_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapBucketTx_set_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapBucketTx_set_Params) Key() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapBucketTx_set_Params) HasKey() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapBucketTx_set_Params) KeyBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapBucketTx_set_Params) SetKey(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s CapBucketTx_set_Params) Value() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s CapBucketTx_set_Params) HasValue() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s CapBucketTx_set_Params) SetValue(v []byte) error {
+	return capnp.Struct(s).SetData(1, v)
+}
+
+// CapBucketTx_set_Params_List is a list of CapBucketTx_set_Params.
+type CapBucketTx_set_Params_List = capnp.StructList[CapBucketTx_set_Params]
+
+// NewCapBucketTx_set_Params creates a new list of CapBucketTx_set_Params.
+func NewCapBucketTx_set_Params_List(s *capnp.Segment, sz int32) (CapBucketTx_set_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return capnp.StructList[CapBucketTx_set_Params](l), err
+}
+
+// CapBucketTx_set_Params_Future is a wrapper for a CapBucketTx_set_Params promised by a client call.
+type CapBucketTx_set_Params_Future struct{ *capnp.Future }
+
+func (f CapBucketTx_set_Params_Future) Struct() (CapBucketTx_set_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapBucketTx_set_Params(p.Struct()), err
+}
+
+type CapBucketTx_set_Results capnp.Struct
+
+// CapBucketTx_set_Results_TypeID is the unique identifier for the type CapBucketTx_set_Results.
+const CapBucketTx_set_Results_TypeID = 0x9873e4f03aba45cb
+
+func NewCapBucketTx_set_Results(s *capnp.Segment) (CapBucketTx_set_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_set_Results(st), err
+}
+
+func NewRootCapBucketTx_set_Results(s *capnp.Segment) (CapBucketTx_set_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapBucketTx_set_Results(st), err
+}
+
+func ReadRootCapBucketTx_set_Results(msg *capnp.Message) (CapBucketTx_set_Results, error) {
+	root, err := msg.Root()
+	return CapBucketTx_set_Results(root.Struct()), err
+}
+
+func (s CapBucketTx_set_Results) String() string {
+	str, _ := text.Marshal(0x9873e4f03aba45cb, capnp.Struct(s))
+	return str
+}
+
+func (s CapBucketTx_set_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapBucketTx_set_Results) DecodeFromPtr(p capnp.Ptr) CapBucketTx_set_Results {
+	return CapBucketTx_set_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapBucketTx_set_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapBucketTx_set_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapBucketTx_set_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapBucketTx_set_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapBucketTx_set_Results_List is a list of CapBucketTx_set_Results.
+type CapBucketTx_set_Results_List = capnp.StructList[CapBucketTx_set_Results]
+
+// NewCapBucketTx_set_Results creates a new list of CapBucketTx_set_Results.
+func NewCapBucketTx_set_Results_List(s *capnp.Segment, sz int32) (CapBucketTx_set_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapBucketTx_set_Results](l), err
+}
+
+// CapBucketTx_set_Results_Future is a wrapper for a CapBucketTx_set_Results promised by a client call.
+type CapBucketTx_set_Results_Future struct{ *capnp.Future }
+
+func (f CapBucketTx_set_Results_Future) Struct() (CapBucketTx_set_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapBucketTx_set_Results(p.Struct()), err
+}
+
+const schema_893d996fbc85a1c3 = "x\xda\xccWkl\x14U\x14>gfw\xa7\xdd\xdd" +
+	"\xa1\xbd\x99\x0dR\xa4)4KB\xab\x14,\xb6B\xb5" +
+	"\xe9R,P\xb0\xda[\x90\x04\x0d\xc1iwh\x97\xee" +
+	"\x8b\x99Yd\xd1B\xad`B\xa1\xe1aH,\xc6\xc4" +
+	"\x0aj \x01~hD\xa0!$@\x0cJ\x82\x88\"" +
+	"\xc4\x18C\x84\xa0Q\x081\x92h\"Ysgvv" +
+	"\xa7\x16\xdb\xad1\xe2/\xd8\xb9\xe7\xdc\xfb\x9d\xef|\xe7" +
+	"\xd1\x99\xe7\x1d\x01\xc7#\xe2J\x0fpt\x93\xd3\x95\xfa" +
+	"q\xc2\xc0\x96\x89\x07\x0fw\x03\xf5#\x0289\x01`" +
+	"\xd6ng/\x02J\xef:\x0f\x03\xa6f;\x97p\x82" +
+	"x\xec\x15 ~\x04p\xb0\xf3ZW\x0f\x82#\xf5\xfd" +
+	"\x8e\xa3[\x1f\x97\x1e\xeb\x0129\xe3Z\xe6z\x8e\xb9" +
+	"V\xb9^\x04L\xad\xbe1\xbf\xa5\xc8yb\xb3\xcdu" +
+	"\xa7\xe9z\x9e\xf6\xef?\xb3p\xdbk\xd6\xb3\xc8\xce\xba" +
+	"\\\xbb\x98o\x9f\xe1[{\xa5\xfeP\xf5\xcaM\xdb\x80" +
+	"\xfa\x90K\x9d\x1a\xd8<\x18\xeb\xaf\xdd\x92~\xe5\xa6\xab" +
+	"\x14\xa5\xbb.\x01@\xfa\xddu\x030U\xb5\xf7\xf8w" +
+	"?\xd4\x8d\xdf5$\x8c\xaf\x04\xe3\xbe\xeb\x02\x0bc\xdf" +
+	"o\xd2\xb9\x9fn%\x87Zt\xe5\x19\x81\xf6\xe51\x8b" +
+	"\xcf\x1a\x8e\xd5\xdc\xbe\xa6\xbda\x86c\xa0\x9d\x9a\xdf\xca" +
+	"\xd0\x16\xb4v{7\x9c|p\x00\x88\x8f\xcfB\x01\x9c" +
+	"E\xf2KQ\x9a\x92\xcfL\x8b\xf3?A\xa9\xca-\x00" +
+	"\xa4No\x97\xdc\x8e'\xfa\xde\xb3\x85]\xec\xeee\x17" +
+	"%\xf7\xecX\xb0w\x92\xe3@\x9a1#j\xd1\xad2" +
+	"\x0cE\xee:\xc0\xd47on_q\xfd\xed\x8f\x0e\x00" +
+	"\x9d\x9c\xe5e\x8e\xbb\x95Y4\xb8\x19/\xa1\xe7\x9b\xfb" +
+	"\x9fy\xc9\x7fh\x18\x96\x017\x87\xd2A\x06`\xd6~" +
+	"\xf7\x02\x94\x12\x1e\x86%\xbf\xb3X\xef-*=\x92\x0e" +
+	"\xdb\x00\xb3\xc2cD\x1d\xf1\xb0\x17\xb7\xbe\xde\xad\xdd." +
+	"\x7fg\xd0\x16u\x9f\xc7\x00{\xfaT\xf7\xb4\xb9\xbf\x9c" +
+	"=1\x941\xd3\xb7\xcf\xc3\x18\xfb\xfa\xdb\x9a\xed\xd5\xd2" +
+	"\x91\x93v\xc6\xbc\xeb\x0d\xdf}_\x9c\xfa9\xde\xfb\xa9" +
+	"\xed\x84xUv\x12l\xef8G+.]\xb0\x03\xba" +
+	"k^*z\x19\xa0e\xc9\xea\xfe\xf0\xbe\x86/m\xae" +
+	"\xd3\xcdK\x93\xf3eq\x97\xb4\xf1\x92\xc9\xab\xc9M\x91" +
+	"\xb7\x87\xb9N5\\\xc7\x0f*\x0f]|u\xeb\xe5!" +
+	"\xaaj\xf4\x1a*X\xeee\xec\x0d|0x\xfc\xd6\x89" +
+	"\xb2+\xec\xf2TW_\xb4z\xf9\x9d\x8f\xef\xa4C;" +
+	"\xea\xadD\xe9\xac\x97\x89\xea\x8ca\xdc\xb7!\xf1\xd6\xe5" +
+	"\xfe\x9a\xab\xf6lM\x11\x0d}O\x17\xd9{\x07\xa6\xb9" +
+	"g\xac~_\xbffKt\x93h\xe8\xfb\xc3)kk" +
+	"\xcf]\xf8\xfc\xd7!\xdc\xcd\x11\x8d0\x1bE\xc6]\xe6" +
+	"q\xe2C\x9b\xbc\x8dG\xae\x8a\x1cJ7\xc5\x07\x00\xa4" +
+	";\xc6C\xc5\xe5\xad\xa7\xf7,\xb8\xf8\x87\x9d\xe8q=" +
+	"\x083S\x1d\x89V9\x1e\x9aQ\xefL\xb4u*z" +
+	"E\x9b\x1c\x8f\xc6k\xe6\xc9\xf1z\xe3\xf7\xbc\x84\xaa\xc5" +
+	"\xd4\x8a\xb8\xaa\xac\xf5\xb7(Z\"\xack\x00\xd4\xcb;" +
+	"\x00\x1c\x08@\x1aJ\x01h\x80G\xfa\x14\x87\x04\xd1\xc7" +
+	"\xb0\x92\xc6J\x00\xfa$\x8f\xb4\x99C\xe4|\xc8\x01\x90" +
+	"&\xf6m!\x8ft)\x87B\xa7\x92D/p\xe8\x05" +
+	",Y+\x87\x13\x0a\x8a\xc0\xa1h\xfe\x0a\x05\x11\x81C" +
+	"\x04\xcc`s\x8f\x8a\xadYV\xe5\x88\x06\x96\xc3=\xed" +
+	"\x97\xae\xab\xd0\x14\xdd0\xe5#\x1a\xcd\xcb\x04Q\xc6\x82" +
+	"\xf0\xf3Hg\xda\x82\x98\xce\x00O\xe3\x91>:\x12\xe0" +
+	"\x0cD\xcfH\x10\xc3\xb2\xa6\xe7\x041m\x1fU\xd6\xe9" +
+	"O\xa7\xf9\xc6!H\xcb\xb3H3@\xd7\x03\xd0\x87y" +
+	"\xa4\xb39,\x08\xc6\xda4,\xccj\x03\x10\x0bY\xcd" +
+	"\xebJDkQ\"P'\x87\xa2\xa1h\xfb0\x86y" +
+	";\x1c\x13\xcb\x12=\xa6*\x8dBtU\xac\x19\x91\x16" +
+	"f0\xc8\x8b\x00\xe8\x0b<\xd2p\x16C\xa8\x06\x80\x06" +
+	"y\xa4q\x0e\x09\x87f\xca#\x13\x01h\x07\x8fT\xe7" +
+	"\x90\xf0\xe8C\x1e\x80\xaci\x01\xa0q\x1e\xe9\xcb\x1c\xa6" +
+	"\x82\xb2./\x09\xadW\x00\x00\x9d\xc0\xa1\x13\xb0N\x89" +
+	"\xb6\x87\xa2\x8a\xc56\x1f\x0aZ\xffME\xd5\x16\xa5-" +
+	"\xa6\x06\x015\xcb:7\xf5\xae\x0a\xa9\x9a\x9e\xa5\xf3\xbf" +
+	"V\xef\x88\xd84E\xe9\xbc\x8f\x95\xe5\x18\xa9R\x18," +
+	"!\xack\xf7V\x89=\x12A\x8b\xa9L%\x93x\xa7" +
+	"m|\xa15T\xc9\x85J\xe0\xc8\x19\x01\xb3\x13\x1d\xad" +
+	"\xe1@\x8e\x96\x03G\x0e\x0a\xc8e\xba!Z\xcd\x8f\x0c" +
+	"\xb0\xb3\xdd\x02\xf2\x991\x84\xd6\xe0'[\xd8\x9d]\x02" +
+	":2\x0b\x06Z\xbb\x08Y\xc3\xfc\x14\x01\x9d\x99i\x81" +
+	"Vk'\xcb\x99_\x93\x80\xae\xcc8@k\xb4\x93\xb9" +
+	"\xcc\xafJ(1\x14\x13\xc0\x02V\xb8\x01,`\xf5\x18" +
+	"\xc0\x12\xa3,\x03X\xc0:N\x00K\xd8?\xec'K" +
+	"a\x00\x9bqLjLw\x03\x18=\x13A%\xac\xe8" +
+	"\x8a\xbf\xb9\xc4\xf0\xa0\x8e\x8cBD\xa6\x90<\x1e\xa9o" +
+	"h\xe2G\xbf\xb2\xdd\x96\\{w\xa9\xbcGw\xb1\xb5" +
+	"\xc1\xbf(jU,\x11\x0d\x8e\xdcIl\x8f\x02\xd3\x87" +
+	"\xcf\xd4Gz\xb6\xa35\xfe\xc9\xce\x1a\xe0\xc8f\xa6\x0f" +
+	"k\xc1Ak\x8a\x93$;\x8b0}X\xe3\x14\xad%" +
+	"\x87\xc8\xa5\xc0\x91g\x99>\xac\x01\x87\xd6:B\x1a\x17" +
+	"\x01G\xe62}Xk&Z\x0b\x1a\xa9b~eB" +
+	"][,\x12\x09\xe9\x01\xac3I\x0e\xa0\xd0\xae\xe8\x01" +
+	"L\xa9\xb1p\xb8Un\xeb\x04\x80\x00\x0a\x1a\xfb\x96s" +
+	"~\xcd\xe6m\xe5\xd7\x9e\xaf\xcal\xbeJ4]\x89k" +
+	"\x98\x07\x1c\xe6\x8d\xda*\x96\xae\xab\xb0\x00e[En" +
+	"`\x8c\xc9\xf3?l/&\xf1\xfe\x96:\x13Z\xce\x0e" +
+	"\xe9*\xc8}k\xf9\xa7\x99\x18\xad\x1c\x87!\x1f\xbd\xcb" +
+	"\xa7\x81\xd8q\xb4\x189A:\x81\xc3\x94\xa6\xc8j[" +
+	"\xc7b\x05px%\xe7\x10\xe6}\xdb\x16\x16+\xc9e" +
+	"L\x0aMr\xbc\xa2\xa4!\xaa\xabIV\xe9\xff\xeev" +
+	"5b'\xb3\x16\xba1tF\xcfh\x05<\xd6m\xed" +
+	">\xd6\xd8\xdf%\x03\xa8\x03\xed\x7f\xaf`\xa5\x99\x1d;" +
+	"O\xf5i\x9e\xfc\x1cnT\xa2\xba\x1aR4\x1c\x07\xd8" +
+	"\xcc#\x16f]\x01\xd9\xc714\xa94y\x7f\x06\x00" +
+	"\x00\xff\xff\xb92J\xfe"
 
 func init() {
 	schemas.Register(schema_893d996fbc85a1c3,
 		0x80afad1a89a118e9,
 		0x81ba0d0702530538,
+		0x8237133b8bb990e3,
 		0x85be05195246e76a,
 		0x868c48c5a99951cf,
 		0x8c845f36ae42d93d,
 		0x92163fe8dfbba335,
 		0x9279eeebcc13f8a4,
+		0x9873e4f03aba45cb,
 		0xa11bc07e0c80620f,
 		0xa68d3c040a138fc4,
+		0xaa041ca347909a79,
+		0xaab6a0e55d8f9bdb,
+		0xae247b4f99505b69,
 		0xb722198a741d6b09,
+		0xbca22af07380938b,
 		0xbec9f2412880c3c4,
+		0xc0b713368f3addd7,
+		0xca8a70ecc3d2a4c4,
 		0xd1d62e51cc686764,
+		0xd445a46c99367956,
 		0xd67f13920d614679,
 		0xd88b83d32b65bc16,
 		0xd929beeebbbcb3a1,
+		0xe13a99d89d757e8d,
 		0xe474a76a2f0a28aa,
 		0xf4d0d1cc3d7621b4,
-		0xf5b8f559366e8d7d)
+		0xf5b8f559366e8d7d,
+		0xfcd3479ac4622a1d)
 }
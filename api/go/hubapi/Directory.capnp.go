@@ -17,8 +17,145 @@ const (
 	DirectoryServiceName   = "directory"
 	CapNameReadDirectory   = "capReadDirectory"
 	CapNameUpdateDirectory = "capUpdateDirectory"
+	EventNameTDRemoved     = "tdRemoved"
 )
 
+type TDQuery capnp.Struct
+
+// TDQuery_TypeID is the unique identifier for the type TDQuery.
+const TDQuery_TypeID = 0xfe7d3c655e963454
+
+func NewTDQuery(s *capnp.Segment) (TDQuery, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return TDQuery(st), err
+}
+
+func NewRootTDQuery(s *capnp.Segment) (TDQuery, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return TDQuery(st), err
+}
+
+func ReadRootTDQuery(msg *capnp.Message) (TDQuery, error) {
+	root, err := msg.Root()
+	return TDQuery(root.Struct()), err
+}
+
+func (s TDQuery) String() string {
+	str, _ := text.Marshal(0xfe7d3c655e963454, capnp.Struct(s))
+	return str
+}
+
+func (s TDQuery) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (TDQuery) DecodeFromPtr(p capnp.Ptr) TDQuery {
+	return TDQuery(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s TDQuery) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s TDQuery) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s TDQuery) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s TDQuery) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s TDQuery) PublisherID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s TDQuery) HasPublisherID() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s TDQuery) PublisherIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s TDQuery) SetPublisherID(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s TDQuery) DeviceType() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s TDQuery) HasDeviceType() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s TDQuery) DeviceTypeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s TDQuery) SetDeviceType(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s TDQuery) Title() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s TDQuery) HasTitle() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s TDQuery) TitleBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s TDQuery) SetTitle(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s TDQuery) PropertyType() (string, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.Text(), err
+}
+
+func (s TDQuery) HasPropertyType() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s TDQuery) PropertyTypeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s TDQuery) SetPropertyType(v string) error {
+	return capnp.Struct(s).SetText(3, v)
+}
+
+// TDQuery_List is a list of TDQuery.
+type TDQuery_List = capnp.StructList[TDQuery]
+
+// NewTDQuery creates a new list of TDQuery.
+func NewTDQuery_List(s *capnp.Segment, sz int32) (TDQuery_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return capnp.StructList[TDQuery](l), err
+}
+
+// TDQuery_Future is a wrapper for a TDQuery promised by a client call.
+type TDQuery_Future struct{ *capnp.Future }
+
+func (f TDQuery_Future) Struct() (TDQuery, error) {
+	p, err := f.Future.Ptr()
+	return TDQuery(p.Struct()), err
+}
+
 type CapDirectoryService capnp.Client
 
 // CapDirectoryService_TypeID is the unique identifier for the type CapDirectoryService.
@@ -56,6 +193,22 @@ func (c CapDirectoryService) CapUpdateDirectory(ctx context.Context, params func
 	ans, release := capnp.Client(c).SendCall(ctx, s)
 	return CapDirectoryService_capUpdateDirectory_Results_Future{Future: ans.Future()}, release
 }
+func (c CapDirectoryService) Backup(ctx context.Context, params func(CapDirectoryService_backup_Params) error) (CapDirectoryService_backup_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xfafce17e91651c9d,
+			MethodID:      2,
+			InterfaceName: "hubapi/Directory.capnp:CapDirectoryService",
+			MethodName:    "backup",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 1}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapDirectoryService_backup_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapDirectoryService_backup_Results_Future{Future: ans.Future()}, release
+}
 
 // String returns a string that identifies this capability for debugging
 // purposes.  Its format should not be depended on: in particular, it
@@ -127,6 +280,8 @@ type CapDirectoryService_Server interface {
 	CapReadDirectory(context.Context, CapDirectoryService_capReadDirectory) error
 
 	CapUpdateDirectory(context.Context, CapDirectoryService_capUpdateDirectory) error
+
+	Backup(context.Context, CapDirectoryService_backup) error
 }
 
 // CapDirectoryService_NewServer creates a new Server from an implementation of CapDirectoryService_Server.
@@ -145,7 +300,7 @@ func CapDirectoryService_ServerToClient(s CapDirectoryService_Server) CapDirecto
 // This can be used to create a more complicated Server.
 func CapDirectoryService_Methods(methods []server.Method, s CapDirectoryService_Server) []server.Method {
 	if cap(methods) == 0 {
-		methods = make([]server.Method, 0, 2)
+		methods = make([]server.Method, 0, 3)
 	}
 
 	methods = append(methods, server.Method{
@@ -172,6 +327,18 @@ func CapDirectoryService_Methods(methods []server.Method, s CapDirectoryService_
 		},
 	})
 
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xfafce17e91651c9d,
+			MethodID:      2,
+			InterfaceName: "hubapi/Directory.capnp:CapDirectoryService",
+			MethodName:    "backup",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Backup(ctx, CapDirectoryService_backup{call})
+		},
+	})
+
 	return methods
 }
 
@@ -209,6 +376,23 @@ func (c CapDirectoryService_capUpdateDirectory) AllocResults() (CapDirectoryServ
 	return CapDirectoryService_capUpdateDirectory_Results(r), err
 }
 
+// CapDirectoryService_backup holds the state for a server call to CapDirectoryService.backup.
+// See server.Call for documentation.
+type CapDirectoryService_backup struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapDirectoryService_backup) Args() CapDirectoryService_backup_Params {
+	return CapDirectoryService_backup_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapDirectoryService_backup) AllocResults() (CapDirectoryService_backup_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapDirectoryService_backup_Results(r), err
+}
+
 // CapDirectoryService_List is a list of CapDirectoryService.
 type CapDirectoryService_List = capnp.CapList[CapDirectoryService]
 
@@ -552,6 +736,153 @@ func (p CapDirectoryService_capUpdateDirectory_Results_Future) Cap() CapUpdateDi
 	return CapUpdateDirectory(p.Future.Field(0, nil).Client())
 }
 
+type CapDirectoryService_backup_Params capnp.Struct
+
+// CapDirectoryService_backup_Params_TypeID is the unique identifier for the type CapDirectoryService_backup_Params.
+const CapDirectoryService_backup_Params_TypeID = 0xaf1cd82fec3cc115
+
+func NewCapDirectoryService_backup_Params(s *capnp.Segment) (CapDirectoryService_backup_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapDirectoryService_backup_Params(st), err
+}
+
+func NewRootCapDirectoryService_backup_Params(s *capnp.Segment) (CapDirectoryService_backup_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapDirectoryService_backup_Params(st), err
+}
+
+func ReadRootCapDirectoryService_backup_Params(msg *capnp.Message) (CapDirectoryService_backup_Params, error) {
+	root, err := msg.Root()
+	return CapDirectoryService_backup_Params(root.Struct()), err
+}
+
+func (s CapDirectoryService_backup_Params) String() string {
+	str, _ := text.Marshal(0xaf1cd82fec3cc115, capnp.Struct(s))
+	return str
+}
+
+func (s CapDirectoryService_backup_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapDirectoryService_backup_Params) DecodeFromPtr(p capnp.Ptr) CapDirectoryService_backup_Params {
+	return CapDirectoryService_backup_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapDirectoryService_backup_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapDirectoryService_backup_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapDirectoryService_backup_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapDirectoryService_backup_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapDirectoryService_backup_Params) Directory() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapDirectoryService_backup_Params) HasDirectory() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapDirectoryService_backup_Params) DirectoryBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapDirectoryService_backup_Params) SetDirectory(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+// CapDirectoryService_backup_Params_List is a list of CapDirectoryService_backup_Params.
+type CapDirectoryService_backup_Params_List = capnp.StructList[CapDirectoryService_backup_Params]
+
+// NewCapDirectoryService_backup_Params creates a new list of CapDirectoryService_backup_Params.
+func NewCapDirectoryService_backup_Params_List(s *capnp.Segment, sz int32) (CapDirectoryService_backup_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapDirectoryService_backup_Params](l), err
+}
+
+// CapDirectoryService_backup_Params_Future is a wrapper for a CapDirectoryService_backup_Params promised by a client call.
+type CapDirectoryService_backup_Params_Future struct{ *capnp.Future }
+
+func (f CapDirectoryService_backup_Params_Future) Struct() (CapDirectoryService_backup_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapDirectoryService_backup_Params(p.Struct()), err
+}
+
+type CapDirectoryService_backup_Results capnp.Struct
+
+// CapDirectoryService_backup_Results_TypeID is the unique identifier for the type CapDirectoryService_backup_Results.
+const CapDirectoryService_backup_Results_TypeID = 0x860cb47d89c88ac4
+
+func NewCapDirectoryService_backup_Results(s *capnp.Segment) (CapDirectoryService_backup_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapDirectoryService_backup_Results(st), err
+}
+
+func NewRootCapDirectoryService_backup_Results(s *capnp.Segment) (CapDirectoryService_backup_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapDirectoryService_backup_Results(st), err
+}
+
+func ReadRootCapDirectoryService_backup_Results(msg *capnp.Message) (CapDirectoryService_backup_Results, error) {
+	root, err := msg.Root()
+	return CapDirectoryService_backup_Results(root.Struct()), err
+}
+
+func (s CapDirectoryService_backup_Results) String() string {
+	str, _ := text.Marshal(0x860cb47d89c88ac4, capnp.Struct(s))
+	return str
+}
+
+func (s CapDirectoryService_backup_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapDirectoryService_backup_Results) DecodeFromPtr(p capnp.Ptr) CapDirectoryService_backup_Results {
+	return CapDirectoryService_backup_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapDirectoryService_backup_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapDirectoryService_backup_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapDirectoryService_backup_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapDirectoryService_backup_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapDirectoryService_backup_Results_List is a list of CapDirectoryService_backup_Results.
+type CapDirectoryService_backup_Results_List = capnp.StructList[CapDirectoryService_backup_Results]
+
+// NewCapDirectoryService_backup_Results creates a new list of CapDirectoryService_backup_Results.
+func NewCapDirectoryService_backup_Results_List(s *capnp.Segment, sz int32) (CapDirectoryService_backup_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapDirectoryService_backup_Results](l), err
+}
+
+// CapDirectoryService_backup_Results_Future is a wrapper for a CapDirectoryService_backup_Results promised by a client call.
+type CapDirectoryService_backup_Results_Future struct{ *capnp.Future }
+
+func (f CapDirectoryService_backup_Results_Future) Struct() (CapDirectoryService_backup_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapDirectoryService_backup_Results(p.Struct()), err
+}
+
 type CapDirectoryCursor capnp.Client
 
 // CapDirectoryCursor_TypeID is the unique identifier for the type CapDirectoryCursor.
@@ -1330,6 +1661,38 @@ func (c CapReadDirectory) GetTD(ctx context.Context, params func(CapReadDirector
 	ans, release := capnp.Client(c).SendCall(ctx, s)
 	return CapReadDirectory_getTD_Results_Future{Future: ans.Future()}, release
 }
+func (c CapReadDirectory) GetStaleTDs(ctx context.Context, params func(CapReadDirectory_getStaleTDs_Params) error) (CapReadDirectory_getStaleTDs_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xa19ac9e4c3ae910e,
+			MethodID:      2,
+			InterfaceName: "hubapi/Directory.capnp:CapReadDirectory",
+			MethodName:    "getStaleTDs",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 0}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapReadDirectory_getStaleTDs_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapReadDirectory_getStaleTDs_Results_Future{Future: ans.Future()}, release
+}
+func (c CapReadDirectory) QueryTDs(ctx context.Context, params func(CapReadDirectory_queryTDs_Params) error) (CapReadDirectory_queryTDs_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xa19ac9e4c3ae910e,
+			MethodID:      3,
+			InterfaceName: "hubapi/Directory.capnp:CapReadDirectory",
+			MethodName:    "queryTDs",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 8, PointerCount: 1}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapReadDirectory_queryTDs_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapReadDirectory_queryTDs_Results_Future{Future: ans.Future()}, release
+}
 
 // String returns a string that identifies this capability for debugging
 // purposes.  Its format should not be depended on: in particular, it
@@ -1401,6 +1764,10 @@ type CapReadDirectory_Server interface {
 	Cursor(context.Context, CapReadDirectory_cursor) error
 
 	GetTD(context.Context, CapReadDirectory_getTD) error
+
+	GetStaleTDs(context.Context, CapReadDirectory_getStaleTDs) error
+
+	QueryTDs(context.Context, CapReadDirectory_queryTDs) error
 }
 
 // CapReadDirectory_NewServer creates a new Server from an implementation of CapReadDirectory_Server.
@@ -1419,7 +1786,7 @@ func CapReadDirectory_ServerToClient(s CapReadDirectory_Server) CapReadDirectory
 // This can be used to create a more complicated Server.
 func CapReadDirectory_Methods(methods []server.Method, s CapReadDirectory_Server) []server.Method {
 	if cap(methods) == 0 {
-		methods = make([]server.Method, 0, 2)
+		methods = make([]server.Method, 0, 4)
 	}
 
 	methods = append(methods, server.Method{
@@ -1446,6 +1813,30 @@ func CapReadDirectory_Methods(methods []server.Method, s CapReadDirectory_Server
 		},
 	})
 
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xa19ac9e4c3ae910e,
+			MethodID:      2,
+			InterfaceName: "hubapi/Directory.capnp:CapReadDirectory",
+			MethodName:    "getStaleTDs",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.GetStaleTDs(ctx, CapReadDirectory_getStaleTDs{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xa19ac9e4c3ae910e,
+			MethodID:      3,
+			InterfaceName: "hubapi/Directory.capnp:CapReadDirectory",
+			MethodName:    "queryTDs",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.QueryTDs(ctx, CapReadDirectory_queryTDs{call})
+		},
+	})
+
 	return methods
 }
 
@@ -1483,6 +1874,40 @@ func (c CapReadDirectory_getTD) AllocResults() (CapReadDirectory_getTD_Results,
 	return CapReadDirectory_getTD_Results(r), err
 }
 
+// CapReadDirectory_getStaleTDs holds the state for a server call to CapReadDirectory.getStaleTDs.
+// See server.Call for documentation.
+type CapReadDirectory_getStaleTDs struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapReadDirectory_getStaleTDs) Args() CapReadDirectory_getStaleTDs_Params {
+	return CapReadDirectory_getStaleTDs_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapReadDirectory_getStaleTDs) AllocResults() (CapReadDirectory_getStaleTDs_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadDirectory_getStaleTDs_Results(r), err
+}
+
+// CapReadDirectory_queryTDs holds the state for a server call to CapReadDirectory.queryTDs.
+// See server.Call for documentation.
+type CapReadDirectory_queryTDs struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapReadDirectory_queryTDs) Args() CapReadDirectory_queryTDs_Params {
+	return CapReadDirectory_queryTDs_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapReadDirectory_queryTDs) AllocResults() (CapReadDirectory_queryTDs_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadDirectory_queryTDs_Results(r), err
+}
+
 // CapReadDirectory_List is a list of CapReadDirectory.
 type CapReadDirectory_List = capnp.CapList[CapReadDirectory]
 
@@ -1833,6 +2258,354 @@ func (p CapReadDirectory_getTD_Results_Future) Tv() ThingValue_Future {
 	return ThingValue_Future{Future: p.Future.Field(0, nil)}
 }
 
+type CapReadDirectory_getStaleTDs_Params capnp.Struct
+
+// CapReadDirectory_getStaleTDs_Params_TypeID is the unique identifier for the type CapReadDirectory_getStaleTDs_Params.
+const CapReadDirectory_getStaleTDs_Params_TypeID = 0x9ccf560b8e643983
+
+func NewCapReadDirectory_getStaleTDs_Params(s *capnp.Segment) (CapReadDirectory_getStaleTDs_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapReadDirectory_getStaleTDs_Params(st), err
+}
+
+func NewRootCapReadDirectory_getStaleTDs_Params(s *capnp.Segment) (CapReadDirectory_getStaleTDs_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapReadDirectory_getStaleTDs_Params(st), err
+}
+
+func ReadRootCapReadDirectory_getStaleTDs_Params(msg *capnp.Message) (CapReadDirectory_getStaleTDs_Params, error) {
+	root, err := msg.Root()
+	return CapReadDirectory_getStaleTDs_Params(root.Struct()), err
+}
+
+func (s CapReadDirectory_getStaleTDs_Params) String() string {
+	str, _ := text.Marshal(0x9ccf560b8e643983, capnp.Struct(s))
+	return str
+}
+
+func (s CapReadDirectory_getStaleTDs_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapReadDirectory_getStaleTDs_Params) DecodeFromPtr(p capnp.Ptr) CapReadDirectory_getStaleTDs_Params {
+	return CapReadDirectory_getStaleTDs_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapReadDirectory_getStaleTDs_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapReadDirectory_getStaleTDs_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapReadDirectory_getStaleTDs_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapReadDirectory_getStaleTDs_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapReadDirectory_getStaleTDs_Params_List is a list of CapReadDirectory_getStaleTDs_Params.
+type CapReadDirectory_getStaleTDs_Params_List = capnp.StructList[CapReadDirectory_getStaleTDs_Params]
+
+// NewCapReadDirectory_getStaleTDs_Params creates a new list of CapReadDirectory_getStaleTDs_Params.
+func NewCapReadDirectory_getStaleTDs_Params_List(s *capnp.Segment, sz int32) (CapReadDirectory_getStaleTDs_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapReadDirectory_getStaleTDs_Params](l), err
+}
+
+// CapReadDirectory_getStaleTDs_Params_Future is a wrapper for a CapReadDirectory_getStaleTDs_Params promised by a client call.
+type CapReadDirectory_getStaleTDs_Params_Future struct{ *capnp.Future }
+
+func (f CapReadDirectory_getStaleTDs_Params_Future) Struct() (CapReadDirectory_getStaleTDs_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapReadDirectory_getStaleTDs_Params(p.Struct()), err
+}
+
+type CapReadDirectory_getStaleTDs_Results capnp.Struct
+
+// CapReadDirectory_getStaleTDs_Results_TypeID is the unique identifier for the type CapReadDirectory_getStaleTDs_Results.
+const CapReadDirectory_getStaleTDs_Results_TypeID = 0xe9538eb31525c47e
+
+func NewCapReadDirectory_getStaleTDs_Results(s *capnp.Segment) (CapReadDirectory_getStaleTDs_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadDirectory_getStaleTDs_Results(st), err
+}
+
+func NewRootCapReadDirectory_getStaleTDs_Results(s *capnp.Segment) (CapReadDirectory_getStaleTDs_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadDirectory_getStaleTDs_Results(st), err
+}
+
+func ReadRootCapReadDirectory_getStaleTDs_Results(msg *capnp.Message) (CapReadDirectory_getStaleTDs_Results, error) {
+	root, err := msg.Root()
+	return CapReadDirectory_getStaleTDs_Results(root.Struct()), err
+}
+
+func (s CapReadDirectory_getStaleTDs_Results) String() string {
+	str, _ := text.Marshal(0xe9538eb31525c47e, capnp.Struct(s))
+	return str
+}
+
+func (s CapReadDirectory_getStaleTDs_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapReadDirectory_getStaleTDs_Results) DecodeFromPtr(p capnp.Ptr) CapReadDirectory_getStaleTDs_Results {
+	return CapReadDirectory_getStaleTDs_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapReadDirectory_getStaleTDs_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapReadDirectory_getStaleTDs_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapReadDirectory_getStaleTDs_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapReadDirectory_getStaleTDs_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapReadDirectory_getStaleTDs_Results) TvList() (ThingValue_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return ThingValue_List(p.List()), err
+}
+
+func (s CapReadDirectory_getStaleTDs_Results) HasTvList() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapReadDirectory_getStaleTDs_Results) SetTvList(v ThingValue_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewTvList sets the tvList field to a newly
+// allocated ThingValue_List, preferring placement in s's segment.
+func (s CapReadDirectory_getStaleTDs_Results) NewTvList(n int32) (ThingValue_List, error) {
+	l, err := NewThingValue_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return ThingValue_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+
+// CapReadDirectory_getStaleTDs_Results_List is a list of CapReadDirectory_getStaleTDs_Results.
+type CapReadDirectory_getStaleTDs_Results_List = capnp.StructList[CapReadDirectory_getStaleTDs_Results]
+
+// NewCapReadDirectory_getStaleTDs_Results creates a new list of CapReadDirectory_getStaleTDs_Results.
+func NewCapReadDirectory_getStaleTDs_Results_List(s *capnp.Segment, sz int32) (CapReadDirectory_getStaleTDs_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapReadDirectory_getStaleTDs_Results](l), err
+}
+
+// CapReadDirectory_getStaleTDs_Results_Future is a wrapper for a CapReadDirectory_getStaleTDs_Results promised by a client call.
+type CapReadDirectory_getStaleTDs_Results_Future struct{ *capnp.Future }
+
+func (f CapReadDirectory_getStaleTDs_Results_Future) Struct() (CapReadDirectory_getStaleTDs_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapReadDirectory_getStaleTDs_Results(p.Struct()), err
+}
+
+type CapReadDirectory_queryTDs_Params capnp.Struct
+
+// CapReadDirectory_queryTDs_Params_TypeID is the unique identifier for the type CapReadDirectory_queryTDs_Params.
+const CapReadDirectory_queryTDs_Params_TypeID = 0xd11d7a58ad4e91e2
+
+func NewCapReadDirectory_queryTDs_Params(s *capnp.Segment) (CapReadDirectory_queryTDs_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return CapReadDirectory_queryTDs_Params(st), err
+}
+
+func NewRootCapReadDirectory_queryTDs_Params(s *capnp.Segment) (CapReadDirectory_queryTDs_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return CapReadDirectory_queryTDs_Params(st), err
+}
+
+func ReadRootCapReadDirectory_queryTDs_Params(msg *capnp.Message) (CapReadDirectory_queryTDs_Params, error) {
+	root, err := msg.Root()
+	return CapReadDirectory_queryTDs_Params(root.Struct()), err
+}
+
+func (s CapReadDirectory_queryTDs_Params) String() string {
+	str, _ := text.Marshal(0xd11d7a58ad4e91e2, capnp.Struct(s))
+	return str
+}
+
+func (s CapReadDirectory_queryTDs_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapReadDirectory_queryTDs_Params) DecodeFromPtr(p capnp.Ptr) CapReadDirectory_queryTDs_Params {
+	return CapReadDirectory_queryTDs_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapReadDirectory_queryTDs_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapReadDirectory_queryTDs_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapReadDirectory_queryTDs_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapReadDirectory_queryTDs_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapReadDirectory_queryTDs_Params) Query() (TDQuery, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return TDQuery(p.Struct()), err
+}
+
+func (s CapReadDirectory_queryTDs_Params) HasQuery() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapReadDirectory_queryTDs_Params) SetQuery(v TDQuery) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewQuery sets the query field to a newly
+// allocated TDQuery struct, preferring placement in s's segment.
+func (s CapReadDirectory_queryTDs_Params) NewQuery() (TDQuery, error) {
+	ss, err := NewTDQuery(capnp.Struct(s).Segment())
+	if err != nil {
+		return TDQuery{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s CapReadDirectory_queryTDs_Params) Limit() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s CapReadDirectory_queryTDs_Params) SetLimit(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s CapReadDirectory_queryTDs_Params) Offset() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s CapReadDirectory_queryTDs_Params) SetOffset(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+// CapReadDirectory_queryTDs_Params_List is a list of CapReadDirectory_queryTDs_Params.
+type CapReadDirectory_queryTDs_Params_List = capnp.StructList[CapReadDirectory_queryTDs_Params]
+
+// NewCapReadDirectory_queryTDs_Params creates a new list of CapReadDirectory_queryTDs_Params.
+func NewCapReadDirectory_queryTDs_Params_List(s *capnp.Segment, sz int32) (CapReadDirectory_queryTDs_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return capnp.StructList[CapReadDirectory_queryTDs_Params](l), err
+}
+
+// CapReadDirectory_queryTDs_Params_Future is a wrapper for a CapReadDirectory_queryTDs_Params promised by a client call.
+type CapReadDirectory_queryTDs_Params_Future struct{ *capnp.Future }
+
+func (f CapReadDirectory_queryTDs_Params_Future) Struct() (CapReadDirectory_queryTDs_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapReadDirectory_queryTDs_Params(p.Struct()), err
+}
+func (p CapReadDirectory_queryTDs_Params_Future) Query() TDQuery_Future {
+	return TDQuery_Future{Future: p.Future.Field(0, nil)}
+}
+
+type CapReadDirectory_queryTDs_Results capnp.Struct
+
+// CapReadDirectory_queryTDs_Results_TypeID is the unique identifier for the type CapReadDirectory_queryTDs_Results.
+const CapReadDirectory_queryTDs_Results_TypeID = 0xb2f30317de058cff
+
+func NewCapReadDirectory_queryTDs_Results(s *capnp.Segment) (CapReadDirectory_queryTDs_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadDirectory_queryTDs_Results(st), err
+}
+
+func NewRootCapReadDirectory_queryTDs_Results(s *capnp.Segment) (CapReadDirectory_queryTDs_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadDirectory_queryTDs_Results(st), err
+}
+
+func ReadRootCapReadDirectory_queryTDs_Results(msg *capnp.Message) (CapReadDirectory_queryTDs_Results, error) {
+	root, err := msg.Root()
+	return CapReadDirectory_queryTDs_Results(root.Struct()), err
+}
+
+func (s CapReadDirectory_queryTDs_Results) String() string {
+	str, _ := text.Marshal(0xb2f30317de058cff, capnp.Struct(s))
+	return str
+}
+
+func (s CapReadDirectory_queryTDs_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapReadDirectory_queryTDs_Results) DecodeFromPtr(p capnp.Ptr) CapReadDirectory_queryTDs_Results {
+	return CapReadDirectory_queryTDs_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapReadDirectory_queryTDs_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapReadDirectory_queryTDs_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapReadDirectory_queryTDs_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapReadDirectory_queryTDs_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapReadDirectory_queryTDs_Results) TvList() (ThingValue_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return ThingValue_List(p.List()), err
+}
+
+func (s CapReadDirectory_queryTDs_Results) HasTvList() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapReadDirectory_queryTDs_Results) SetTvList(v ThingValue_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewTvList sets the tvList field to a newly
+// allocated ThingValue_List, preferring placement in s's segment.
+func (s CapReadDirectory_queryTDs_Results) NewTvList(n int32) (ThingValue_List, error) {
+	l, err := NewThingValue_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return ThingValue_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+
+// CapReadDirectory_queryTDs_Results_List is a list of CapReadDirectory_queryTDs_Results.
+type CapReadDirectory_queryTDs_Results_List = capnp.StructList[CapReadDirectory_queryTDs_Results]
+
+// NewCapReadDirectory_queryTDs_Results creates a new list of CapReadDirectory_queryTDs_Results.
+func NewCapReadDirectory_queryTDs_Results_List(s *capnp.Segment, sz int32) (CapReadDirectory_queryTDs_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapReadDirectory_queryTDs_Results](l), err
+}
+
+// CapReadDirectory_queryTDs_Results_Future is a wrapper for a CapReadDirectory_queryTDs_Results promised by a client call.
+type CapReadDirectory_queryTDs_Results_Future struct{ *capnp.Future }
+
+func (f CapReadDirectory_queryTDs_Results_Future) Struct() (CapReadDirectory_queryTDs_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapReadDirectory_queryTDs_Results(p.Struct()), err
+}
+
 type CapUpdateDirectory capnp.Client
 
 // CapUpdateDirectory_TypeID is the unique identifier for the type CapUpdateDirectory.
@@ -1864,7 +2637,7 @@ func (c CapUpdateDirectory) UpdateTD(ctx context.Context, params func(CapUpdateD
 		},
 	}
 	if params != nil {
-		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 4}
 		s.PlaceArgs = func(s capnp.Struct) error { return params(CapUpdateDirectory_updateTD_Params(s)) }
 	}
 	ans, release := capnp.Client(c).SendCall(ctx, s)
@@ -2203,12 +2976,12 @@ type CapUpdateDirectory_updateTD_Params capnp.Struct
 const CapUpdateDirectory_updateTD_Params_TypeID = 0x8b29feea8de52fc2
 
 func NewCapUpdateDirectory_updateTD_Params(s *capnp.Segment) (CapUpdateDirectory_updateTD_Params, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
 	return CapUpdateDirectory_updateTD_Params(st), err
 }
 
 func NewRootCapUpdateDirectory_updateTD_Params(s *capnp.Segment) (CapUpdateDirectory_updateTD_Params, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
 	return CapUpdateDirectory_updateTD_Params(st), err
 }
 
@@ -2293,12 +3066,30 @@ func (s CapUpdateDirectory_updateTD_Params) SetTdDoc(v []byte) error {
 	return capnp.Struct(s).SetData(2, v)
 }
 
+func (s CapUpdateDirectory_updateTD_Params) Created() (string, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.Text(), err
+}
+
+func (s CapUpdateDirectory_updateTD_Params) HasCreated() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s CapUpdateDirectory_updateTD_Params) CreatedBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s CapUpdateDirectory_updateTD_Params) SetCreated(v string) error {
+	return capnp.Struct(s).SetText(3, v)
+}
+
 // CapUpdateDirectory_updateTD_Params_List is a list of CapUpdateDirectory_updateTD_Params.
 type CapUpdateDirectory_updateTD_Params_List = capnp.StructList[CapUpdateDirectory_updateTD_Params]
 
 // NewCapUpdateDirectory_updateTD_Params creates a new list of CapUpdateDirectory_updateTD_Params.
 func NewCapUpdateDirectory_updateTD_Params_List(s *capnp.Segment, sz int32) (CapUpdateDirectory_updateTD_Params_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
 	return capnp.StructList[CapUpdateDirectory_updateTD_Params](l), err
 }
 
@@ -2375,121 +3166,161 @@ func (f CapUpdateDirectory_updateTD_Results_Future) Struct() (CapUpdateDirectory
 	return CapUpdateDirectory_updateTD_Results(p.Struct()), err
 }
 
-const schema_c8da54a8b024bd49 = "x\xda\xb4Wk\x8c\x13U\x14>g\xa6\xdd\x996\xed" +
-	"\xc2\xcd,,\x12Ie)\x86]\x97\x85-\x12\x01\x03" +
-	"[\xa0\x18!\xb0v\xda%\x014\xc6\xe9\xec\xb0-\xd9" +
-	"\xee\xd6vva\xa3\xf8\x08\x12\x12\x04\xe3\xca#ZA" +
-	"\xc2\x0f\x121\x08Jb\xa2&Hx\x09\x1a5\x11\x91" +
-	"\x04\x14\x03\x11T\xaa\xc6\x18MT\x04\xc7\xdcio\xa7" +
-	"/\xf6\x15\xfd\xd7\xf6\x9e9\xdf\xb9\xdfw\xcew\xa6\xd3" +
-	"\xbf\xe6\xfd\xb6f\xf7!'p\xf2\x93\xf6*\xe3\xe1\x1f" +
-	"?\xfb*\xdc\xdd\xb8\x01\xe4\x06D\x00\x9b\x000c\xae" +
-	"\xfd}\x04\x94\x96\xd9[\x00\x8d\x83\xd2\x9eo|\xdb\xd3" +
-	"\x1b\x81\xd4\xf2\xc6\xe2#\xde\xb7^o\xbbx\x06\x00g" +
-	"\xc4\xed!\x94\x9e\xb5\x0b\x00\xd2z\xfb&\xe9K\xfa\xc9" +
-	"8>\xed\xda\xd6\xcc?\xf5\xcf\x03i@\x00;O\xd3" +
-	"\x1d\xb1\x9f\xa5\xe9>\xb7\x1f\x024\\\xb5\xa7\xef\xc3+" +
-	"Ol\xcf\xe1\xd9\x91F\xc4\xab\x8e\xd3\x88\xf5Uk\x01" +
-	"\x8d\xa5]5\xb5\x0b&MJ\x03\xb9'\x1fp\xa1\xaa" +
-	"\x8e\x03\x94~\xad\xa2\x15\xcdJ\xcf\x9b\x17n\xf8d\x17" +
-	"\x90Z[QEDH\xa24Y\x10\x00\xc2w\x09<" +
-	"\x86\x1b\x05\x0e\x01\x8c\x03\x19\x97z\xff\x98\x8f_+\x82" +
-	"\xac\x17L\xc8\xd9\x02\x85\xac\xee?x\xe2\xeaG\xe9\xbd" +
-	"ew\xdc',@\xe9\x1d\x9aQz[\xd8$\xa1H" +
-	"\xef\xd8\xbaB}\xe5\xe7c\x07\x0f\x97E_\x17B(" +
-	"\xdd2\xa3\xff\x12NK\x8f\x9b\xd1m\xd7\xd7\xa4\xa7\xff" +
-	"\xf1\xc0\xd1\xb2jW\x8a\x11\x94\xe24&\x1c\x15y\x0c" +
-	"\xeb\xa2Ymj\xa7x\xfc\xa1\x13\x1d'\xb3\x0c\x9az" +
-	"\xc4\xc5\x8b\x086\xe3\x94\xad1v\xed\xdb?\xcf\x14\x9c" +
-	"(\xe2az2\xe9\x8b\xfe=\xcfe\xea\xce\x01\x99\xc2" +
-	"N\xe4\xecI\xdd\xd1k\xd5\\t\xc9\xb9\xa2\xbb\xcf\x17" +
-	"M}e\x91\xde\xfd\xe4\xcc5\x19w\xf4\xd1\x0b\x05Y" +
-	"\xdf\xcc\xe2\xed\xfb\xbe\x15\xc5\xfd\xae\xcb\x85B\xec\x15\xc7" +
-	"S!\xde\x13\xa9\x10\xb6\x17F\xaf\xdb\xf2\xc1c\x97\x0b" +
-	"\x1e\xbdDS\xdb\x8c\xf3\xf3;\xaf\xde\x18\xd7x5\xd7" +
-	"\x06\x1c=\xfaT4\xdb\xe0\x8a\x89\xba\xd5\xe6\xdb\xb8\xe1" +
-	"\x87U\xd7\x0bs\xcfw4\xd0\xdc+\x1d4\xb7:e" +
-	"\xf5O\x99\xbb\x1f\xc9\x94\xd1\xb6\xde\x11A\xa9\xdfAi" +
-	"\xdb\xe2\xe01\xfc\xb2\xc3\xa4mK\xc6\xff\xcb\x87/\xee" +
-	"\xfa-KA6\xe1\x0e\x87y\xcf}f\xc2\xcb\x07\xb6" +
-	"\xd5m\x0b\xdf\xfc\xbd\x10\xf1\x94\xc3G\x11/\x99\x01\xbb" +
-	"\xef\xd4\xfa\x9f\xbar\xf3F\x99\xac\xb7\x1c\xabP\"N" +
-	"*\xab\xdb\xb9I\x8a\xd3O\xc6\x8cc3\xf7\xf7&\xc6" +
-	"\xfe]\x88\xb7\xdcy\x98\xe2iN\x9a\xae\xf7\xec\xb9\xd8" +
-	"\xd8\x0d\x1d\xb7r\x01&\x05\x1b\x9do\xd0\x80\x1d\xce\xb5" +
-	"\xb0\xd0\x88\xf6D\x94DlZ\xc0\x1eKj\xaa\xde\x9d" +
-	"\xeckR\x95DWb\xceB%\x11`?-\xecI" +
-	"\xa6\xba\x93M]\xda:\xbd\xd5\xdb\x12T\x92J<%" +
-	"\xdbx\x1b\x80\x0d\x01\x88\xdb\x07 \x8b<\xca5\x1cz" +
-	"R\xba\x96H\xa1\x08\x1c\x8a\x80\xf9\xec\xb6\xf2\xec\xcb\x13" +
-	"\xed\x8a\xae1\x0c\xec\x0b\"\xca\"o\x07\xc8\xcb\x86\xac" +
-	")H\xf3\x12\xe0H\xbd\x80\x98\x9fld\x0dJ&\xd0" +
-	"\xb31\x82\x91\xd4\xe2\xdd\xbdZ[\x00\x00\xfch\xf4\x98" +
-	"\xd9s\xdf\x82hUR5X%}M\xecY\xafy" +
-	"SL\xc9\xae\xfcU\x17E\x00\xe4\x00\x8fr\x90C\x82" +
-	"XC\xbb\x99,[\x00 ?\xc8\xa3\xdc\xc6!\xe1\xb8" +
-	"\x1a\xe4\x00\x88LIY\xca\xa3\xbc\x82C#\xd1\x13\xe9" +
-	"\x8c\xa5\xa2\x1a\x08\xc9\xc5\x01t\x01\x87.\xc0\xa7\xf5h" +
-	"\xac\xab\xc3\xfa\xee\xd1\xdb\x03\xdd*\xba\x81C7\x0cX" +
-	"p\xa90\xabc\xc9\x94\xee\x0di\xa9\x9eN=E\xa5" +
-	"`\xe5\xd6\x8f\x07\x90\xbd<\xca\xd39d\xd5N\xa5\x85" +
-	"M\xe1Q\xbe\x97C^\xef\xc5\xd1\xc6K\xcf\xec|w" +
-	"\xf7w\xe7_\x05@\x1c\x0d\xe8\xe9U:c\xed\x88\xc0" +
-	"!\x16\xd4!\x0cPGXK\xf6\xc6T\x8d\x9e\x844" +
-	"\xa5=\xff\xbbY\x93\xd0\xa9\x17uK\x9d\xd5-\x82\xaa" +
-	"$\x90X\xc6\x07\x88d\x80\xaeQ\x95D\xab\x12\xd7," +
-	"\xbd<\xe6i\x101\xc7!\x10L\x1bjNQd\x92" +
-	"\xf2\xc9\xbe\xe1\xb0\x99m\xf3Jl\xfarl\xfa-6" +
-	"\xe7\xd2\xdff\xf1(\x078\xf4D\x14]\x8db5`" +
-	"\x90\xc7\x12^\xabo\xcbk\x85\xd1(\xe2\x10\xac\xc1`" +
-	"\x06\x8b\xccfH\xf3\x1c\xe0\xc8d:\x18l\xd0\x91Y" +
-	"\x02\xb9\xc3\x07\x1cq\x0b-\xaay-?z:4\xbd" +
-	"-P<\x0d\xb6\xc1\xe8\xc0$\x85w\x99\xf0\xcch\x91" +
-	"mO\xda\xe4\x1cYD\xe1\xd9V@f\xf5dv\x03" +
-	"pd\xaa\x80\\~\xb9#[\x81d\xa2\xcf\x9cY\x8f" +
-	"\xd9\xb8~\x1cE)\xf7\xa3\xc7d~\xe0\x02\xdbKZ" +
-	"\xaeUP\xe2ZQ\x03\x84\x0c\x16\x03\xd87\xa2\xb17" +
-	"\xa5\xe7\xf5\xd4p\xbd\xd1\x1b\xf4\x98\xd68\xd0sE\xd2" +
-	"6e\xa5\x19\xcas\x15\xf1B-\xd9&\xfd\x9f&~" +
-	"\x08\x9c1\xd3-\xe7lDvA\x0d\x97/\xde-K" +
-	"\x00d\x17\x8f\xf28\x0e\x0d\xb53\xa6u\xe9\x8b\xa9\xa9" +
-	"3\xd3\x1c\x0egY\x9b\xcc\xed\xaf\x11\xdd\x92-\x84\x02" +
-	"\xbe#\x16\xb7\xf9\x85\xd0L\x17B#\x8f\xf2\xac\xa1z" +
-	"\xffpi+\xa9\xd0\x1bTF\x95.\xe5\xa1\x12w\x1b" +
-	"\x93\xb5\x84\x11J-v\xb3\xc1\x84\xc3\xbcI\xc1\xb0\x9b" +
-	">\xdf\xbc\x055\xcf\xb1VC\xce\xb6\x90X/\xba%" +
-	"\xdba\x84T\x854O\x19n\xf9J\xca\xff\xdf\x18d" +
-	"%\x95\x81\xf2\xaaf\x196{wE\xf6o\x824o" +
-	"\xce\xba\xa2\xf5\xee\x89\xec\x95\x90LL\x03G&\x08\x95" +
-	"\xd8\xf5c\xc5\xb5V\xec\x95\x832o.\x80\x8a\x04\x8c" +
-	"\xb7\x08\xa8\xe8\x10\xc3\xc5`\xfd\xf8\x9f\x0f\xca\xbf\x01\x00" +
-	"\x00\xff\xff\x97\xc00\xd2"
+const schema_c8da54a8b024bd49 = "x\xda\xb4X\x7fl\x13\xe7\x19~\xdf;;\xe7\xd8g" +
+	"\x93O\x974\x08\x15Y\x0ba\x82\x0c\x02\x09\x8dFX" +
+	"\xdb\x18p\xa7\x81h\x96\xb3\xcd\xd4\xb1\xa9\xea\xf9|$" +
+	"\xc7\x9c\xc4\xf8\xce\x19\xdeD[\xc4\xd84F\xd1X+" +
+	"\xa4f\xb0\x8a?&\x8d\xa9\xd0\x96m\xd2\x98\xd6uP" +
+	"\x18\xacj7\xad\xa3\xd5\xda\x8d\x0a\xba0JV\xb4I" +
+	"\xdd\xb4\x89\x95\xde\xf4}\xf6\xe7;\xc7I0\xa9\xf8\xcf" +
+	"w\xdfw\xef\xfb|\xcf\xfb\xbc?>\xaf\xec\xf7\xc7|" +
+	"]\xe1\xd10\x08\xea!\x7f\x83\xf3\xa5\xbf\xff\xee\xcf\xc9" +
+	"\xd1e\xbbA\xed@\x04\xf0I\x00\xab\x0e7\xfc\x02\x01" +
+	"\x95c\x0d}\x80\xceq\xe5\x99w\xba\x9f\x1a\xdf\x03\xa4" +
+	"Ut6\xbc\xd8\xfe\xfc\x8fRo\x9f\x07\xc0U\xaf5" +
+	"$P\xb9\xdc \x01(\x17\x1b\xbe\xa5\xf4H\x12\x80s" +
+	"f\xef\xf9o\xef\xfc\xa9\xfcM \x9f\xe2\xd6\x16J\xaf" +
+	"#\xf8\x9c\xd3+\xae<1\xf9\xd1\xd2\xef\x00\xe9@\x00" +
+	"?[\"t\x09\x95\xc5\xd2U@Gn=\xf7i\xbc" +
+	"\xfc\xf5\xa7\xcaH\xfcHw\xdc\x94N\xd3\x1d\xe1\xc0W" +
+	"\x01\x9dM#\xcd\xad\xeb\x16-\x1a/\x19/m0\x03" +
+	"m\x02\xa0\xb2+@\xb1\xae\x1e\xbf\xff\xfed\xc7\xab\x87" +
+	"\x80\xb4\xfa\xaa\xb0\x1e\x09\xe4Q\xf9Y@\x02H>\x1f" +
+	"\x101\xf9\xcb\x80\x80\x00\xce\xee\xde\xcc\xfe\xd0\x17~\x7f" +
+	"\x08\xc8\x12\x8e\xf6d\xe0m\x8a\xf6\xd9IY\xffL\xcb" +
+	"+?\xa8\x02s,\xc0\xc0\xbc\xc8\xc0D\x0e\x1c\x7fy" +
+	"\xe2\xb7\xe3GjxY\xda\xb8\x0e\x95\xdeF\xfaAO" +
+	"\xa3\x84\xcaE\xfa\xd3i9u\xef\xfb+\xfet\xf7s" +
+	"^\xecg\x1b_\xa1\xf6\xdej\xa4\xd8\xfb\x1f\xd2\x9f\xbe" +
+	"~\xea\xf8\x89\x1a{7\x1b\x13\xa8\x90 \xe59\x1c<" +
+	"\xa7\xec\xa1\xbf\x1cg\x9f\xff\x9dV\xf1\x83\x13%\xe4%" +
+	"s\xc3AfnW\x90\x9aK]\xdb6\xbe\xf2?\x9f" +
+	"}\xa9\x86\x8a\x17\x82iT\xceR#\xc9_\x07EL" +
+	"\xbe\x1adTX\x07\x03\xa7?\xff\xf2\xe0\x99Rx\x18" +
+	"\x15g\x83\x8c\x8a\xb3\xbee\xe6\x95\xbf\xfe\xf7\xbcg\xe5" +
+	"d\xf0\x04]y\xf7@\xff\xb1\x87\xbe\xb6\xf0\x0f\xa0." +
+	"qI:\x1ad$\x9d\x0c>\x07\xe8,\xfa\xe3\x81g" +
+	"\xbe1\xd9v\xc1C\xb0\x1ab\xdf\xb6\xbdt%\"\x0c" +
+	"m\xbcPE\xf0\xda\x10\x13\x9e\x1a\xa2\x04\x9f\xe9\xd96" +
+	"\x19\x1ez\xf8-\x8f\xdfc!\x86\xe8\x87W\xfb1p" +
+	"T\xbe\xe4\xe5\xf2Hh\x01\xd5\xc1\xc9\x10=\xbco\x7f" +
+	"\xd3\x8e}\xbfz\xe4\x92\xe7\xd3\x8b\xd4\xb4\xcfY\xdd[" +
+	"\xe8Z78\x7f\xa2\x86\x96\xd7BT\xcd!J\xcb_" +
+	"B\"&\xdf\x0b1Z\xde\\\x9b\x9d\xb81\x7f\xd9D" +
+	"Y\xb5\x025u9\xc4T\xfbo\x86\xf2\xd13\x8b[" +
+	"~\xb2?y\xcd\x1b\x88\xcd\xf2\xbbt\x83)S,O" +
+	"\xf8\xba\xf7\xec~o\xcb5/\xd8\xa7\xe5\x0e\x0a\xf6\x05" +
+	"\xb6A_\xb2\xf5\xfd\xc9O~y\xb2\x06\xd2\x1br\x1a" +
+	"\x95k2\x854!\x8b\x98\xfc\xa7\xcc \xed\x9b\x8c\xfd" +
+	"\xe37\xdf=\xf4\x81\xd7\xe3u\x99\x11w\x93\x19\xbc\xf4" +
+	"\xec\x93mO&?\xfc\x97\xd7\xe3\xc2p7\xf5\xd8\x13" +
+	"\xa6\x1b\x0e\xdfm\x1cx\xf4\xf2\x877j\xa4\xb69\xbc" +
+	"\x05\x153L\xa5f\x84\xcf)\xd7\xe9/g\xd5\xa9\x9e" +
+	"\xa3c\xb9\xbb\xfe\xe7\xf5\xf7F\xf8\x04\xf5w\x85\x99\x1b" +
+	"{\xfd\x82y\xd7\xee\xc1\x9b\xe5\x0d\x8c#\x7f\xe4\xc7t" +
+	"CK\x84r\x94\xba\xe7\xe0\xc3\xc6\xbd;?\x02\xd2\x8a" +
+	"\xae?V\x02\x94B\xe4\x86\xb2+B\x7f\xed\x8c\\\x85" +
+	"\xf5\xceP!\xad\xe5\xcc\x15q\xbf\x997t{4_" +
+	"\xec\xd4\xb5\xdcHn\xcdz-\x17\xe7\xaf\xd6\x17\xf2\xd6" +
+	"h\xbes\xc4\xd8a\xf7\xb7\xf7\x0dhym\xd8R}" +
+	"\xa2\x0f\xc0\x87\x00$\xdc\x0d\xa0\x06DT\x9b\x05\x8cZ" +
+	"\xb6\x91\xb30\x00\x02\x06\x00+\xd6}\xb5\xd67\xe72" +
+	"\x9amp\x1fX\x1c@T\x03\xa2\xdf\xa3\x01\xe4\x8a$" +
+	"]\x1bA K%\xc4JUC\x9e?d!]k" +
+	"\x91\x9c\xbc1<:f\xa4\xe2\x00\x10C\xa7\xc0\xac\x97" +
+	"\x9f\x06\xd0E\xd20\xcb9\x93F~\xcc\xd4\x8d\xce\xb4" +
+	"\xa6\x7f\xa5\x90kO\x18V!k\xa35\xdb\xb7\xd5\xa7" +
+	"(vr\xbf\xed\x8c%\xb4\xd4\xa6\x0aMZ\x1a@}" +
+	"DD5+ Al\xa6iH\xccu\x00jFD" +
+	"5' \x11\x84f\x14\x00\xc80%tHD\xd5\x16" +
+	"\x90\x88b3\x8a\x00d;\xdd\x99\x15Q\xdd!\xa0\x93" +
+	"+\xa4\xb3\xa65d\x80\x94\xdf\x10G\x19\x04\x94\x01\x1f" +
+	"\xb3\x87\xcc\x91A\xf79jg\xe2\xa3:\x86A\xc00" +
+	"\xe0cz\xde\xd0l#\xc3W\xebb\xa4\x1c\xf9\xadf" +
+	"\xde\xb2\xcb|X4\xd6\xfcLK\x17\x00\xa8\xed\"\xaa" +
+	"+\x05\xe4GZN\xd1/\x11Q\xbdG@\xd1\x1e\xc3" +
+	"&\xe7{\x8f\x1f\xfc\xf9\xe1\xbf\xbd\xf9}\x00\xc4&\xc0" +
+	"\xe8\x98\x9653\x88  zpHuDF\xd7r" +
+	"\x09C\xcbT\xde3LR\xd6\xae\x92c\x9b+GI" +
+	"\xd7rH\xdc\xde\x01\x88d\x16Y\xeaZ\xae_\x1b6" +
+	"\xdc\xa0F\xd9\xea\x00b\x995 8\xee\xe8\xe5\xb0#" +
+	"\x8f\xbb\x98/\xce\xc6f\x15\xe2\xceA\xc3N\xdaZ\xd6" +
+	"H\xc5-&\x12q\xd8\xba\x9dP\x94\x92p\xbaPt" +
+	"\x97C\x11sCq\x1f}\xb7ZD5.`4\xad" +
+	"\xd9\xfa\x10F\x00\x07D\x9c\x12\x94\xc8\x8cA\xf1\xdd\xe2" +
+	"8@\xd3\xb6\x89\xa5-\xef=\xc8\x0b&\xd9\xbe\x06\x04" +
+	"b\xd0\xb4\xe5%\x0byq#_\xec\x06\x81<(\xa1" +
+	"P\x19\x0a\x90\x97v\xb26\x0d\x02\xe9\x95P\xact<" +
+	"\xe4\xfd\x97,\xa7\xe9\xbeX\xea\xd3\x19\x1d1\x8c\x0e\x1a" +
+	"v*\x1eC\x87\xf3\x0aR*n\xc5\xd0\xd9^0\xf2" +
+	"\xc5T\xdc\xfa8\x15\xa0T\xea\x00\xbc\xeaJ\x00\xa8\xb2" +
+	"\x88\xea|\x01\x9dL\xf9;\xc0bMZ\xf9n\x15K" +
+	"\xccS\xeed\xc6\x1do\xa0\xc8\x872\xa2R~\x1e\xa0" +
+	"\xdc\xf1y\x00y\x0b'\xbd\x1d \x90\xe5\x94;>M" +
+	"\"\x9f\x9f\xc8'\xbaY9\x8c\xb2\x94\x8d\xe1<\xaa\x97" +
+	"\x18F\x99ln\xc9C\xb5R9\x83\x15\xb5U\xf1\xb0" +
+	"\xa6\x9ce\xed\x02\xf6\xd9c\x9bL\xcb\x9eQ[3r" +
+	"\x92\x99\xc2{\xbf\xa4\x0d\x1bU\xd9\x96\xf0r<\xa7B" +
+	"\xcc\xc0\x8b\xb6u\xbb\x9d\xae} \xca\xa2?\x07\xbe\xca" +
+	"\xb2Q\xe5\x0a[\x0f\xd0D\x8c\x89\xa8nr\x93s\x03" +
+	"}\x17\x17Q\x1d\xa0\xa5\x1fK\xa5\xffAJ\xeb\xe7D" +
+	"TS\x02F\x99Alr\xfby\xb9~f\xcda\xd3" +
+	"F\x1f\x08\xe8\x03\xec\x1b\xdd\xba\xd52*\x8f\xb3\x1d\xb3" +
+	"\x1an)\x83j\x8eY/=\x89\xbe\x92*\xeeP7" +
+	"\xa8#\xc4\xbc\xe3\xd7\x86xN\xad\xa4\\\x8c\xbd\x1a\xdf" +
+	"\xe8\xc9u=k\x1a#\xf6\x06:Q\xd4\xe4\xba\xbf\xde" +
+	"\x16Z\x1e\x9efL\x08c\xcc\x18\xb1i\x0bJ\xc5\x13" +
+	"\xect\x98\x99\x92\x0ev\xa6\xb4\x00\x98\x99\x13W|." +
+	"\xf1D-\xedF\xa82\x97t\xd1ic\x99\x88\xea\xea" +
+	"z\xa7\x8d\xb9u\xc0\xe9\xfa\xf7\\*K\x9d\x11\x9fB" +
+	"K\xfb\x806o\xea0[o\xccg\x98\x1d\xdc\xa3J" +
+	"S'\x87\xbd\x0e\xd7\x1cV\xda'\xdcv\xbeV\xf2n" +
+	"\x1a\xc6\x9a\x05,7F$\xee\x05w\xca\xd03G\xaa" +
+	"\x12F\xb4\xc6o\xed\xa4U\xf9\xf7\xe2\x16\x93V\x8dS" +
+	"Q7\xdcv\xc8/\x9c\xc8\xff\x81 \xea\xde\xd2\xb8\xe0" +
+	"\xde\xef\x90_\xbb\xc8\xdaq\x10\xc8}\xb4\x1d\xf2K?" +
+	"\xf2\xbfEH\xd7\x1a6.L\xc7|\x0c\xa7\x9d\xe4b" +
+	"\xd8W\xea\xfe\xd5\xdd\xd2_\x87\xaaY)\xaaei\x81" +
+	"\xcb\xd2\xb4\x15\xf0v}p\xd1\xde\xb9\x14\x16\xa6\x00I" +
+	"\xf5\xc5U\xda\x8bJ\xc3\xde\xec\x17\x9a-\x9e\xbb\x0b\xbf" +
+	"\xd0l\xefv\xef.\x95\x0bMa\x1b\x80j\x8b\xa8>" +
+	">\x13>'cP5\xa6\x8a \xe6\x0c\xf7Vc\xda" +
+	"\xd9\xca\x93\x93\xcb\x8f\xe6\x8c\xbc]\x84y\xa9\xa2\xbb\xe9" +
+	"\xff\x01\x00\x00\xff\xffF0\xac\x07"
 
 func init() {
 	schemas.Register(schema_c8da54a8b024bd49,
 		0x832c6f53dbceeb5b,
 		0x859a9432de9f13ae,
+		0x860cb47d89c88ac4,
 		0x8b29feea8de52fc2,
 		0x947be10137c7170c,
 		0x9a23234217146e4c,
 		0x9ccc2a533e3e9a38,
+		0x9ccf560b8e643983,
 		0x9eca153b630ceaac,
 		0xa19ac9e4c3ae910e,
+		0xaf1cd82fec3cc115,
 		0xb2aec1ed9963584e,
+		0xb2f30317de058cff,
 		0xbf46f7309a6ae954,
 		0xc467c34fc2089673,
 		0xc8f8e3e5692c04c5,
+		0xd11d7a58ad4e91e2,
 		0xd422ea849f91d323,
 		0xd44a68020ee5bf22,
 		0xd95e680dea6a35c4,
 		0xe00ca908014ee7a5,
 		0xe060be8c78108e04,
+		0xe418674231753938,
 		0xe42c18fae46c41d6,
+		0xe9538eb31525c47e,
 		0xe95ae8838532048d,
 		0xea5c26eaec662863,
 		0xf39c90c6ef40ea8c,
 		0xf4fc53932293ace0,
 		0xfafce17e91651c9d,
 		0xfb167076a935c133,
-		0xfd67831669d4d276)
+		0xfd67831669d4d276,
+		0xfe7d3c655e963454)
 }
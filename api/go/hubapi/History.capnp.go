@@ -10,6 +10,7 @@ import (
 	server "capnproto.org/go/capnp/v3/server"
 	context "context"
 	fmt "fmt"
+	math "math"
 )
 
 // Constants defined in History.capnp.
@@ -18,6 +19,8 @@ const (
 	CapNameAddHistory      = "capAddHistory"
 	CapNameManageRetention = "capManageRetention"
 	CapNameReadHistory     = "capReadHistory"
+	ValueTypeActions       = "actions"
+	ValueTypeEvents        = "events"
 )
 
 type EventRetention capnp.Struct
@@ -26,12 +29,12 @@ type EventRetention capnp.Struct
 const EventRetention_TypeID = 0x82949d8d788d3f1c
 
 func NewEventRetention(s *capnp.Segment) (EventRetention, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 5})
 	return EventRetention(st), err
 }
 
 func NewRootEventRetention(s *capnp.Segment) (EventRetention, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 5})
 	return EventRetention(st), err
 }
 
@@ -162,12 +165,59 @@ func (s EventRetention) SetRetentionDays(v int32) {
 	capnp.Struct(s).SetUint32(0, uint32(v))
 }
 
+func (s EventRetention) Compaction() (CompactionRule_List, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return CompactionRule_List(p.List()), err
+}
+
+func (s EventRetention) HasCompaction() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+func (s EventRetention) SetCompaction(v CompactionRule_List) error {
+	return capnp.Struct(s).SetPtr(4, v.ToPtr())
+}
+
+// NewCompaction sets the compaction field to a newly
+// allocated CompactionRule_List, preferring placement in s's segment.
+func (s EventRetention) NewCompaction(n int32) (CompactionRule_List, error) {
+	l, err := NewCompactionRule_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return CompactionRule_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(4, l.ToPtr())
+	return l, err
+}
+func (s EventRetention) OnlyOnChange() bool {
+	return capnp.Struct(s).Bit(32)
+}
+
+func (s EventRetention) SetOnlyOnChange(v bool) {
+	capnp.Struct(s).SetBit(32, v)
+}
+
+func (s EventRetention) Deadband() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+}
+
+func (s EventRetention) SetDeadband(v float64) {
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+}
+
+func (s EventRetention) HeartbeatSec() int32 {
+	return int32(capnp.Struct(s).Uint32(16))
+}
+
+func (s EventRetention) SetHeartbeatSec(v int32) {
+	capnp.Struct(s).SetUint32(16, uint32(v))
+}
+
 // EventRetention_List is a list of EventRetention.
 type EventRetention_List = capnp.StructList[EventRetention]
 
 // NewEventRetention creates a new list of EventRetention.
 func NewEventRetention_List(s *capnp.Segment, sz int32) (EventRetention_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 5}, sz)
 	return capnp.StructList[EventRetention](l), err
 }
 
@@ -179,6 +229,349 @@ func (f EventRetention_Future) Struct() (EventRetention, error) {
 	return EventRetention(p.Struct()), err
 }
 
+type CompactionRule capnp.Struct
+
+// CompactionRule_TypeID is the unique identifier for the type CompactionRule.
+const CompactionRule_TypeID = 0x94804b0564859b02
+
+func NewCompactionRule(s *capnp.Segment) (CompactionRule, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return CompactionRule(st), err
+}
+
+func NewRootCompactionRule(s *capnp.Segment) (CompactionRule, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return CompactionRule(st), err
+}
+
+func ReadRootCompactionRule(msg *capnp.Message) (CompactionRule, error) {
+	root, err := msg.Root()
+	return CompactionRule(root.Struct()), err
+}
+
+func (s CompactionRule) String() string {
+	str, _ := text.Marshal(0x94804b0564859b02, capnp.Struct(s))
+	return str
+}
+
+func (s CompactionRule) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CompactionRule) DecodeFromPtr(p capnp.Ptr) CompactionRule {
+	return CompactionRule(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CompactionRule) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CompactionRule) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CompactionRule) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CompactionRule) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CompactionRule) AfterDays() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s CompactionRule) SetAfterDays(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s CompactionRule) IntervalSec() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s CompactionRule) SetIntervalSec(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+// CompactionRule_List is a list of CompactionRule.
+type CompactionRule_List = capnp.StructList[CompactionRule]
+
+// NewCompactionRule creates a new list of CompactionRule.
+func NewCompactionRule_List(s *capnp.Segment, sz int32) (CompactionRule_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
+	return capnp.StructList[CompactionRule](l), err
+}
+
+// CompactionRule_Future is a wrapper for a CompactionRule promised by a client call.
+type CompactionRule_Future struct{ *capnp.Future }
+
+func (f CompactionRule_Future) Struct() (CompactionRule, error) {
+	p, err := f.Future.Ptr()
+	return CompactionRule(p.Struct()), err
+}
+
+type HistoryRange capnp.Struct
+
+// HistoryRange_TypeID is the unique identifier for the type HistoryRange.
+const HistoryRange_TypeID = 0xe0ba99ed8f701229
+
+func NewHistoryRange(s *capnp.Segment) (HistoryRange, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return HistoryRange(st), err
+}
+
+func NewRootHistoryRange(s *capnp.Segment) (HistoryRange, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return HistoryRange(st), err
+}
+
+func ReadRootHistoryRange(msg *capnp.Message) (HistoryRange, error) {
+	root, err := msg.Root()
+	return HistoryRange(root.Struct()), err
+}
+
+func (s HistoryRange) String() string {
+	str, _ := text.Marshal(0xe0ba99ed8f701229, capnp.Struct(s))
+	return str
+}
+
+func (s HistoryRange) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (HistoryRange) DecodeFromPtr(p capnp.Ptr) HistoryRange {
+	return HistoryRange(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s HistoryRange) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s HistoryRange) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s HistoryRange) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s HistoryRange) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s HistoryRange) StartTime() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s HistoryRange) HasStartTime() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s HistoryRange) StartTimeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s HistoryRange) SetStartTime(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s HistoryRange) EndTime() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s HistoryRange) HasEndTime() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s HistoryRange) EndTimeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s HistoryRange) SetEndTime(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s HistoryRange) Names() (capnp.TextList, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return capnp.TextList(p.List()), err
+}
+
+func (s HistoryRange) HasNames() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s HistoryRange) SetNames(v capnp.TextList) error {
+	return capnp.Struct(s).SetPtr(2, v.ToPtr())
+}
+
+// NewNames sets the names field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s HistoryRange) NewNames(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, l.ToPtr())
+	return l, err
+}
+func (s HistoryRange) ValueType() (string, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.Text(), err
+}
+
+func (s HistoryRange) HasValueType() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s HistoryRange) ValueTypeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s HistoryRange) SetValueType(v string) error {
+	return capnp.Struct(s).SetText(3, v)
+}
+
+// HistoryRange_List is a list of HistoryRange.
+type HistoryRange_List = capnp.StructList[HistoryRange]
+
+// NewHistoryRange creates a new list of HistoryRange.
+func NewHistoryRange_List(s *capnp.Segment, sz int32) (HistoryRange_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return capnp.StructList[HistoryRange](l), err
+}
+
+// HistoryRange_Future is a wrapper for a HistoryRange promised by a client call.
+type HistoryRange_Future struct{ *capnp.Future }
+
+func (f HistoryRange_Future) Struct() (HistoryRange, error) {
+	p, err := f.Future.Ptr()
+	return HistoryRange(p.Struct()), err
+}
+
+type AggregateValue capnp.Struct
+
+// AggregateValue_TypeID is the unique identifier for the type AggregateValue.
+const AggregateValue_TypeID = 0x929d94d1bcc80d89
+
+func NewAggregateValue(s *capnp.Segment) (AggregateValue, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 1})
+	return AggregateValue(st), err
+}
+
+func NewRootAggregateValue(s *capnp.Segment) (AggregateValue, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 1})
+	return AggregateValue(st), err
+}
+
+func ReadRootAggregateValue(msg *capnp.Message) (AggregateValue, error) {
+	root, err := msg.Root()
+	return AggregateValue(root.Struct()), err
+}
+
+func (s AggregateValue) String() string {
+	str, _ := text.Marshal(0x929d94d1bcc80d89, capnp.Struct(s))
+	return str
+}
+
+func (s AggregateValue) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (AggregateValue) DecodeFromPtr(p capnp.Ptr) AggregateValue {
+	return AggregateValue(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s AggregateValue) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s AggregateValue) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s AggregateValue) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s AggregateValue) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s AggregateValue) StartTime() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s AggregateValue) HasStartTime() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s AggregateValue) StartTimeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s AggregateValue) SetStartTime(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s AggregateValue) Count() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s AggregateValue) SetCount(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s AggregateValue) Min() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(8))
+}
+
+func (s AggregateValue) SetMin(v float64) {
+	capnp.Struct(s).SetUint64(8, math.Float64bits(v))
+}
+
+func (s AggregateValue) Max() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(16))
+}
+
+func (s AggregateValue) SetMax(v float64) {
+	capnp.Struct(s).SetUint64(16, math.Float64bits(v))
+}
+
+func (s AggregateValue) Avg() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(24))
+}
+
+func (s AggregateValue) SetAvg(v float64) {
+	capnp.Struct(s).SetUint64(24, math.Float64bits(v))
+}
+
+func (s AggregateValue) Last() float64 {
+	return math.Float64frombits(capnp.Struct(s).Uint64(32))
+}
+
+func (s AggregateValue) SetLast(v float64) {
+	capnp.Struct(s).SetUint64(32, math.Float64bits(v))
+}
+
+// AggregateValue_List is a list of AggregateValue.
+type AggregateValue_List = capnp.StructList[AggregateValue]
+
+// NewAggregateValue creates a new list of AggregateValue.
+func NewAggregateValue_List(s *capnp.Segment, sz int32) (AggregateValue_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 40, PointerCount: 1}, sz)
+	return capnp.StructList[AggregateValue](l), err
+}
+
+// AggregateValue_Future is a wrapper for a AggregateValue promised by a client call.
+type AggregateValue_Future struct{ *capnp.Future }
+
+func (f AggregateValue_Future) Struct() (AggregateValue, error) {
+	p, err := f.Future.Ptr()
+	return AggregateValue(p.Struct()), err
+}
+
 type CapHistoryService capnp.Client
 
 // CapHistoryService_TypeID is the unique identifier for the type CapHistoryService.
@@ -232,6 +625,22 @@ func (c CapHistoryService) CapReadHistory(ctx context.Context, params func(CapHi
 	ans, release := capnp.Client(c).SendCall(ctx, s)
 	return CapHistoryService_capReadHistory_Results_Future{Future: ans.Future()}, release
 }
+func (c CapHistoryService) Backup(ctx context.Context, params func(CapHistoryService_backup_Params) error) (CapHistoryService_backup_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0x934ac037c7063be0,
+			MethodID:      3,
+			InterfaceName: "hubapi/History.capnp:CapHistoryService",
+			MethodName:    "backup",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 1}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapHistoryService_backup_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapHistoryService_backup_Results_Future{Future: ans.Future()}, release
+}
 
 // String returns a string that identifies this capability for debugging
 // purposes.  Its format should not be depended on: in particular, it
@@ -305,6 +714,8 @@ type CapHistoryService_Server interface {
 	CapManageRetention(context.Context, CapHistoryService_capManageRetention) error
 
 	CapReadHistory(context.Context, CapHistoryService_capReadHistory) error
+
+	Backup(context.Context, CapHistoryService_backup) error
 }
 
 // CapHistoryService_NewServer creates a new Server from an implementation of CapHistoryService_Server.
@@ -323,7 +734,7 @@ func CapHistoryService_ServerToClient(s CapHistoryService_Server) CapHistoryServ
 // This can be used to create a more complicated Server.
 func CapHistoryService_Methods(methods []server.Method, s CapHistoryService_Server) []server.Method {
 	if cap(methods) == 0 {
-		methods = make([]server.Method, 0, 3)
+		methods = make([]server.Method, 0, 4)
 	}
 
 	methods = append(methods, server.Method{
@@ -362,12 +773,24 @@ func CapHistoryService_Methods(methods []server.Method, s CapHistoryService_Serv
 		},
 	})
 
-	return methods
-}
-
-// CapHistoryService_capAddHistory holds the state for a server call to CapHistoryService.capAddHistory.
-// See server.Call for documentation.
-type CapHistoryService_capAddHistory struct {
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0x934ac037c7063be0,
+			MethodID:      3,
+			InterfaceName: "hubapi/History.capnp:CapHistoryService",
+			MethodName:    "backup",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Backup(ctx, CapHistoryService_backup{call})
+		},
+	})
+
+	return methods
+}
+
+// CapHistoryService_capAddHistory holds the state for a server call to CapHistoryService.capAddHistory.
+// See server.Call for documentation.
+type CapHistoryService_capAddHistory struct {
 	*server.Call
 }
 
@@ -416,6 +839,23 @@ func (c CapHistoryService_capReadHistory) AllocResults() (CapHistoryService_capR
 	return CapHistoryService_capReadHistory_Results(r), err
 }
 
+// CapHistoryService_backup holds the state for a server call to CapHistoryService.backup.
+// See server.Call for documentation.
+type CapHistoryService_backup struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapHistoryService_backup) Args() CapHistoryService_backup_Params {
+	return CapHistoryService_backup_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapHistoryService_backup) AllocResults() (CapHistoryService_backup_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapHistoryService_backup_Results(r), err
+}
+
 // CapHistoryService_List is a list of CapHistoryService.
 type CapHistoryService_List = capnp.CapList[CapHistoryService]
 
@@ -934,6 +1374,153 @@ func (p CapHistoryService_capReadHistory_Results_Future) Cap() CapReadHistory {
 	return CapReadHistory(p.Future.Field(0, nil).Client())
 }
 
+type CapHistoryService_backup_Params capnp.Struct
+
+// CapHistoryService_backup_Params_TypeID is the unique identifier for the type CapHistoryService_backup_Params.
+const CapHistoryService_backup_Params_TypeID = 0xc751ee2c1d465840
+
+func NewCapHistoryService_backup_Params(s *capnp.Segment) (CapHistoryService_backup_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapHistoryService_backup_Params(st), err
+}
+
+func NewRootCapHistoryService_backup_Params(s *capnp.Segment) (CapHistoryService_backup_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapHistoryService_backup_Params(st), err
+}
+
+func ReadRootCapHistoryService_backup_Params(msg *capnp.Message) (CapHistoryService_backup_Params, error) {
+	root, err := msg.Root()
+	return CapHistoryService_backup_Params(root.Struct()), err
+}
+
+func (s CapHistoryService_backup_Params) String() string {
+	str, _ := text.Marshal(0xc751ee2c1d465840, capnp.Struct(s))
+	return str
+}
+
+func (s CapHistoryService_backup_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapHistoryService_backup_Params) DecodeFromPtr(p capnp.Ptr) CapHistoryService_backup_Params {
+	return CapHistoryService_backup_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapHistoryService_backup_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapHistoryService_backup_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapHistoryService_backup_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapHistoryService_backup_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapHistoryService_backup_Params) Directory() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapHistoryService_backup_Params) HasDirectory() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapHistoryService_backup_Params) DirectoryBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapHistoryService_backup_Params) SetDirectory(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+// CapHistoryService_backup_Params_List is a list of CapHistoryService_backup_Params.
+type CapHistoryService_backup_Params_List = capnp.StructList[CapHistoryService_backup_Params]
+
+// NewCapHistoryService_backup_Params creates a new list of CapHistoryService_backup_Params.
+func NewCapHistoryService_backup_Params_List(s *capnp.Segment, sz int32) (CapHistoryService_backup_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapHistoryService_backup_Params](l), err
+}
+
+// CapHistoryService_backup_Params_Future is a wrapper for a CapHistoryService_backup_Params promised by a client call.
+type CapHistoryService_backup_Params_Future struct{ *capnp.Future }
+
+func (f CapHistoryService_backup_Params_Future) Struct() (CapHistoryService_backup_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapHistoryService_backup_Params(p.Struct()), err
+}
+
+type CapHistoryService_backup_Results capnp.Struct
+
+// CapHistoryService_backup_Results_TypeID is the unique identifier for the type CapHistoryService_backup_Results.
+const CapHistoryService_backup_Results_TypeID = 0xe41e2a23bd2dec8a
+
+func NewCapHistoryService_backup_Results(s *capnp.Segment) (CapHistoryService_backup_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapHistoryService_backup_Results(st), err
+}
+
+func NewRootCapHistoryService_backup_Results(s *capnp.Segment) (CapHistoryService_backup_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapHistoryService_backup_Results(st), err
+}
+
+func ReadRootCapHistoryService_backup_Results(msg *capnp.Message) (CapHistoryService_backup_Results, error) {
+	root, err := msg.Root()
+	return CapHistoryService_backup_Results(root.Struct()), err
+}
+
+func (s CapHistoryService_backup_Results) String() string {
+	str, _ := text.Marshal(0xe41e2a23bd2dec8a, capnp.Struct(s))
+	return str
+}
+
+func (s CapHistoryService_backup_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapHistoryService_backup_Results) DecodeFromPtr(p capnp.Ptr) CapHistoryService_backup_Results {
+	return CapHistoryService_backup_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapHistoryService_backup_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapHistoryService_backup_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapHistoryService_backup_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapHistoryService_backup_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapHistoryService_backup_Results_List is a list of CapHistoryService_backup_Results.
+type CapHistoryService_backup_Results_List = capnp.StructList[CapHistoryService_backup_Results]
+
+// NewCapHistoryService_backup_Results creates a new list of CapHistoryService_backup_Results.
+func NewCapHistoryService_backup_Results_List(s *capnp.Segment, sz int32) (CapHistoryService_backup_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapHistoryService_backup_Results](l), err
+}
+
+// CapHistoryService_backup_Results_Future is a wrapper for a CapHistoryService_backup_Results promised by a client call.
+type CapHistoryService_backup_Results_Future struct{ *capnp.Future }
+
+func (f CapHistoryService_backup_Results_Future) Struct() (CapHistoryService_backup_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapHistoryService_backup_Results(p.Struct()), err
+}
+
 type CapAddHistory capnp.Client
 
 // CapAddHistory_TypeID is the unique identifier for the type CapAddHistory.
@@ -2814,6 +3401,54 @@ func (c CapReadHistory) GetProperties(ctx context.Context, params func(CapReadHi
 	ans, release := capnp.Client(c).SendCall(ctx, s)
 	return CapReadHistory_getProperties_Results_Future{Future: ans.Future()}, release
 }
+func (c CapReadHistory) GetAggregate(ctx context.Context, params func(CapReadHistory_getAggregate_Params) error) (CapReadHistory_getAggregate_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xadd9881ba4754f20,
+			MethodID:      2,
+			InterfaceName: "hubapi/History.capnp:CapReadHistory",
+			MethodName:    "getAggregate",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 8, PointerCount: 4}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapReadHistory_getAggregate_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapReadHistory_getAggregate_Results_Future{Future: ans.Future()}, release
+}
+func (c CapReadHistory) GetRangeHistory(ctx context.Context, params func(CapReadHistory_getRangeHistory_Params) error) (CapReadHistory_getRangeHistory_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xadd9881ba4754f20,
+			MethodID:      3,
+			InterfaceName: "hubapi/History.capnp:CapReadHistory",
+			MethodName:    "getRangeHistory",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapReadHistory_getRangeHistory_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapReadHistory_getRangeHistory_Results_Future{Future: ans.Future()}, release
+}
+func (c CapReadHistory) FollowHistory(ctx context.Context, params func(CapReadHistory_followHistory_Params) error) (CapReadHistory_followHistory_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xadd9881ba4754f20,
+			MethodID:      4,
+			InterfaceName: "hubapi/History.capnp:CapReadHistory",
+			MethodName:    "followHistory",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 4}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapReadHistory_followHistory_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapReadHistory_followHistory_Results_Future{Future: ans.Future()}, release
+}
 
 // String returns a string that identifies this capability for debugging
 // purposes.  Its format should not be depended on: in particular, it
@@ -2885,6 +3520,12 @@ type CapReadHistory_Server interface {
 	GetEventHistory(context.Context, CapReadHistory_getEventHistory) error
 
 	GetProperties(context.Context, CapReadHistory_getProperties) error
+
+	GetAggregate(context.Context, CapReadHistory_getAggregate) error
+
+	GetRangeHistory(context.Context, CapReadHistory_getRangeHistory) error
+
+	FollowHistory(context.Context, CapReadHistory_followHistory) error
 }
 
 // CapReadHistory_NewServer creates a new Server from an implementation of CapReadHistory_Server.
@@ -2903,7 +3544,7 @@ func CapReadHistory_ServerToClient(s CapReadHistory_Server) CapReadHistory {
 // This can be used to create a more complicated Server.
 func CapReadHistory_Methods(methods []server.Method, s CapReadHistory_Server) []server.Method {
 	if cap(methods) == 0 {
-		methods = make([]server.Method, 0, 2)
+		methods = make([]server.Method, 0, 5)
 	}
 
 	methods = append(methods, server.Method{
@@ -2930,6 +3571,42 @@ func CapReadHistory_Methods(methods []server.Method, s CapReadHistory_Server) []
 		},
 	})
 
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xadd9881ba4754f20,
+			MethodID:      2,
+			InterfaceName: "hubapi/History.capnp:CapReadHistory",
+			MethodName:    "getAggregate",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.GetAggregate(ctx, CapReadHistory_getAggregate{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xadd9881ba4754f20,
+			MethodID:      3,
+			InterfaceName: "hubapi/History.capnp:CapReadHistory",
+			MethodName:    "getRangeHistory",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.GetRangeHistory(ctx, CapReadHistory_getRangeHistory{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xadd9881ba4754f20,
+			MethodID:      4,
+			InterfaceName: "hubapi/History.capnp:CapReadHistory",
+			MethodName:    "followHistory",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.FollowHistory(ctx, CapReadHistory_followHistory{call})
+		},
+	})
+
 	return methods
 }
 
@@ -2967,6 +3644,57 @@ func (c CapReadHistory_getProperties) AllocResults() (CapReadHistory_getProperti
 	return CapReadHistory_getProperties_Results(r), err
 }
 
+// CapReadHistory_getAggregate holds the state for a server call to CapReadHistory.getAggregate.
+// See server.Call for documentation.
+type CapReadHistory_getAggregate struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapReadHistory_getAggregate) Args() CapReadHistory_getAggregate_Params {
+	return CapReadHistory_getAggregate_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapReadHistory_getAggregate) AllocResults() (CapReadHistory_getAggregate_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadHistory_getAggregate_Results(r), err
+}
+
+// CapReadHistory_getRangeHistory holds the state for a server call to CapReadHistory.getRangeHistory.
+// See server.Call for documentation.
+type CapReadHistory_getRangeHistory struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapReadHistory_getRangeHistory) Args() CapReadHistory_getRangeHistory_Params {
+	return CapReadHistory_getRangeHistory_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapReadHistory_getRangeHistory) AllocResults() (CapReadHistory_getRangeHistory_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return CapReadHistory_getRangeHistory_Results(r), err
+}
+
+// CapReadHistory_followHistory holds the state for a server call to CapReadHistory.followHistory.
+// See server.Call for documentation.
+type CapReadHistory_followHistory struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapReadHistory_followHistory) Args() CapReadHistory_followHistory_Params {
+	return CapReadHistory_followHistory_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapReadHistory_followHistory) AllocResults() (CapReadHistory_followHistory_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadHistory_followHistory_Results(r), err
+}
+
 // CapReadHistory_List is a list of CapReadHistory.
 type CapReadHistory_List = capnp.CapList[CapReadHistory]
 
@@ -3391,6 +4119,804 @@ func (f CapReadHistory_getProperties_Results_Future) Struct() (CapReadHistory_ge
 	return CapReadHistory_getProperties_Results(p.Struct()), err
 }
 
+type CapReadHistory_getAggregate_Params capnp.Struct
+
+// CapReadHistory_getAggregate_Params_TypeID is the unique identifier for the type CapReadHistory_getAggregate_Params.
+const CapReadHistory_getAggregate_Params_TypeID = 0xfa5aa37101cf4521
+
+func NewCapReadHistory_getAggregate_Params(s *capnp.Segment) (CapReadHistory_getAggregate_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return CapReadHistory_getAggregate_Params(st), err
+}
+
+func NewRootCapReadHistory_getAggregate_Params(s *capnp.Segment) (CapReadHistory_getAggregate_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return CapReadHistory_getAggregate_Params(st), err
+}
+
+func ReadRootCapReadHistory_getAggregate_Params(msg *capnp.Message) (CapReadHistory_getAggregate_Params, error) {
+	root, err := msg.Root()
+	return CapReadHistory_getAggregate_Params(root.Struct()), err
+}
+
+func (s CapReadHistory_getAggregate_Params) String() string {
+	str, _ := text.Marshal(0xfa5aa37101cf4521, capnp.Struct(s))
+	return str
+}
+
+func (s CapReadHistory_getAggregate_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapReadHistory_getAggregate_Params) DecodeFromPtr(p capnp.Ptr) CapReadHistory_getAggregate_Params {
+	return CapReadHistory_getAggregate_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapReadHistory_getAggregate_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapReadHistory_getAggregate_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapReadHistory_getAggregate_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapReadHistory_getAggregate_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapReadHistory_getAggregate_Params) PublisherID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapReadHistory_getAggregate_Params) HasPublisherID() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapReadHistory_getAggregate_Params) PublisherIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapReadHistory_getAggregate_Params) SetPublisherID(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s CapReadHistory_getAggregate_Params) ThingID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s CapReadHistory_getAggregate_Params) HasThingID() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s CapReadHistory_getAggregate_Params) ThingIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s CapReadHistory_getAggregate_Params) SetThingID(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s CapReadHistory_getAggregate_Params) Name() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s CapReadHistory_getAggregate_Params) HasName() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s CapReadHistory_getAggregate_Params) NameBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s CapReadHistory_getAggregate_Params) SetName(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s CapReadHistory_getAggregate_Params) StartTime() (string, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.Text(), err
+}
+
+func (s CapReadHistory_getAggregate_Params) HasStartTime() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s CapReadHistory_getAggregate_Params) StartTimeBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s CapReadHistory_getAggregate_Params) SetStartTime(v string) error {
+	return capnp.Struct(s).SetText(3, v)
+}
+
+func (s CapReadHistory_getAggregate_Params) Duration() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s CapReadHistory_getAggregate_Params) SetDuration(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+func (s CapReadHistory_getAggregate_Params) Interval() int32 {
+	return int32(capnp.Struct(s).Uint32(4))
+}
+
+func (s CapReadHistory_getAggregate_Params) SetInterval(v int32) {
+	capnp.Struct(s).SetUint32(4, uint32(v))
+}
+
+// CapReadHistory_getAggregate_Params_List is a list of CapReadHistory_getAggregate_Params.
+type CapReadHistory_getAggregate_Params_List = capnp.StructList[CapReadHistory_getAggregate_Params]
+
+// NewCapReadHistory_getAggregate_Params creates a new list of CapReadHistory_getAggregate_Params.
+func NewCapReadHistory_getAggregate_Params_List(s *capnp.Segment, sz int32) (CapReadHistory_getAggregate_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4}, sz)
+	return capnp.StructList[CapReadHistory_getAggregate_Params](l), err
+}
+
+// CapReadHistory_getAggregate_Params_Future is a wrapper for a CapReadHistory_getAggregate_Params promised by a client call.
+type CapReadHistory_getAggregate_Params_Future struct{ *capnp.Future }
+
+func (f CapReadHistory_getAggregate_Params_Future) Struct() (CapReadHistory_getAggregate_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapReadHistory_getAggregate_Params(p.Struct()), err
+}
+
+type CapReadHistory_getAggregate_Results capnp.Struct
+
+// CapReadHistory_getAggregate_Results_TypeID is the unique identifier for the type CapReadHistory_getAggregate_Results.
+const CapReadHistory_getAggregate_Results_TypeID = 0xab3daf6000a44ed3
+
+func NewCapReadHistory_getAggregate_Results(s *capnp.Segment) (CapReadHistory_getAggregate_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadHistory_getAggregate_Results(st), err
+}
+
+func NewRootCapReadHistory_getAggregate_Results(s *capnp.Segment) (CapReadHistory_getAggregate_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadHistory_getAggregate_Results(st), err
+}
+
+func ReadRootCapReadHistory_getAggregate_Results(msg *capnp.Message) (CapReadHistory_getAggregate_Results, error) {
+	root, err := msg.Root()
+	return CapReadHistory_getAggregate_Results(root.Struct()), err
+}
+
+func (s CapReadHistory_getAggregate_Results) String() string {
+	str, _ := text.Marshal(0xab3daf6000a44ed3, capnp.Struct(s))
+	return str
+}
+
+func (s CapReadHistory_getAggregate_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapReadHistory_getAggregate_Results) DecodeFromPtr(p capnp.Ptr) CapReadHistory_getAggregate_Results {
+	return CapReadHistory_getAggregate_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapReadHistory_getAggregate_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapReadHistory_getAggregate_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapReadHistory_getAggregate_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapReadHistory_getAggregate_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapReadHistory_getAggregate_Results) AggList() (AggregateValue_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return AggregateValue_List(p.List()), err
+}
+
+func (s CapReadHistory_getAggregate_Results) HasAggList() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapReadHistory_getAggregate_Results) SetAggList(v AggregateValue_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewAggList sets the aggList field to a newly
+// allocated AggregateValue_List, preferring placement in s's segment.
+func (s CapReadHistory_getAggregate_Results) NewAggList(n int32) (AggregateValue_List, error) {
+	l, err := NewAggregateValue_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return AggregateValue_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+
+// CapReadHistory_getAggregate_Results_List is a list of CapReadHistory_getAggregate_Results.
+type CapReadHistory_getAggregate_Results_List = capnp.StructList[CapReadHistory_getAggregate_Results]
+
+// NewCapReadHistory_getAggregate_Results creates a new list of CapReadHistory_getAggregate_Results.
+func NewCapReadHistory_getAggregate_Results_List(s *capnp.Segment, sz int32) (CapReadHistory_getAggregate_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapReadHistory_getAggregate_Results](l), err
+}
+
+// CapReadHistory_getAggregate_Results_Future is a wrapper for a CapReadHistory_getAggregate_Results promised by a client call.
+type CapReadHistory_getAggregate_Results_Future struct{ *capnp.Future }
+
+func (f CapReadHistory_getAggregate_Results_Future) Struct() (CapReadHistory_getAggregate_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapReadHistory_getAggregate_Results(p.Struct()), err
+}
+
+type CapReadHistory_getRangeHistory_Params capnp.Struct
+
+// CapReadHistory_getRangeHistory_Params_TypeID is the unique identifier for the type CapReadHistory_getRangeHistory_Params.
+const CapReadHistory_getRangeHistory_Params_TypeID = 0xe4275f9fec5abef6
+
+func NewCapReadHistory_getRangeHistory_Params(s *capnp.Segment) (CapReadHistory_getRangeHistory_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return CapReadHistory_getRangeHistory_Params(st), err
+}
+
+func NewRootCapReadHistory_getRangeHistory_Params(s *capnp.Segment) (CapReadHistory_getRangeHistory_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return CapReadHistory_getRangeHistory_Params(st), err
+}
+
+func ReadRootCapReadHistory_getRangeHistory_Params(msg *capnp.Message) (CapReadHistory_getRangeHistory_Params, error) {
+	root, err := msg.Root()
+	return CapReadHistory_getRangeHistory_Params(root.Struct()), err
+}
+
+func (s CapReadHistory_getRangeHistory_Params) String() string {
+	str, _ := text.Marshal(0xe4275f9fec5abef6, capnp.Struct(s))
+	return str
+}
+
+func (s CapReadHistory_getRangeHistory_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapReadHistory_getRangeHistory_Params) DecodeFromPtr(p capnp.Ptr) CapReadHistory_getRangeHistory_Params {
+	return CapReadHistory_getRangeHistory_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapReadHistory_getRangeHistory_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapReadHistory_getRangeHistory_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapReadHistory_getRangeHistory_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapReadHistory_getRangeHistory_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapReadHistory_getRangeHistory_Params) PublisherID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapReadHistory_getRangeHistory_Params) HasPublisherID() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapReadHistory_getRangeHistory_Params) PublisherIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapReadHistory_getRangeHistory_Params) SetPublisherID(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s CapReadHistory_getRangeHistory_Params) ThingID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s CapReadHistory_getRangeHistory_Params) HasThingID() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s CapReadHistory_getRangeHistory_Params) ThingIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s CapReadHistory_getRangeHistory_Params) SetThingID(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s CapReadHistory_getRangeHistory_Params) HistoryRange() (HistoryRange, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return HistoryRange(p.Struct()), err
+}
+
+func (s CapReadHistory_getRangeHistory_Params) HasHistoryRange() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s CapReadHistory_getRangeHistory_Params) SetHistoryRange(v HistoryRange) error {
+	return capnp.Struct(s).SetPtr(2, capnp.Struct(v).ToPtr())
+}
+
+// NewHistoryRange sets the historyRange field to a newly
+// allocated HistoryRange struct, preferring placement in s's segment.
+func (s CapReadHistory_getRangeHistory_Params) NewHistoryRange() (HistoryRange, error) {
+	ss, err := NewHistoryRange(capnp.Struct(s).Segment())
+	if err != nil {
+		return HistoryRange{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+// CapReadHistory_getRangeHistory_Params_List is a list of CapReadHistory_getRangeHistory_Params.
+type CapReadHistory_getRangeHistory_Params_List = capnp.StructList[CapReadHistory_getRangeHistory_Params]
+
+// NewCapReadHistory_getRangeHistory_Params creates a new list of CapReadHistory_getRangeHistory_Params.
+func NewCapReadHistory_getRangeHistory_Params_List(s *capnp.Segment, sz int32) (CapReadHistory_getRangeHistory_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return capnp.StructList[CapReadHistory_getRangeHistory_Params](l), err
+}
+
+// CapReadHistory_getRangeHistory_Params_Future is a wrapper for a CapReadHistory_getRangeHistory_Params promised by a client call.
+type CapReadHistory_getRangeHistory_Params_Future struct{ *capnp.Future }
+
+func (f CapReadHistory_getRangeHistory_Params_Future) Struct() (CapReadHistory_getRangeHistory_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapReadHistory_getRangeHistory_Params(p.Struct()), err
+}
+func (p CapReadHistory_getRangeHistory_Params_Future) HistoryRange() HistoryRange_Future {
+	return HistoryRange_Future{Future: p.Future.Field(2, nil)}
+}
+
+type CapReadHistory_getRangeHistory_Results capnp.Struct
+
+// CapReadHistory_getRangeHistory_Results_TypeID is the unique identifier for the type CapReadHistory_getRangeHistory_Results.
+const CapReadHistory_getRangeHistory_Results_TypeID = 0xc27fbcfe703c0635
+
+func NewCapReadHistory_getRangeHistory_Results(s *capnp.Segment) (CapReadHistory_getRangeHistory_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return CapReadHistory_getRangeHistory_Results(st), err
+}
+
+func NewRootCapReadHistory_getRangeHistory_Results(s *capnp.Segment) (CapReadHistory_getRangeHistory_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return CapReadHistory_getRangeHistory_Results(st), err
+}
+
+func ReadRootCapReadHistory_getRangeHistory_Results(msg *capnp.Message) (CapReadHistory_getRangeHistory_Results, error) {
+	root, err := msg.Root()
+	return CapReadHistory_getRangeHistory_Results(root.Struct()), err
+}
+
+func (s CapReadHistory_getRangeHistory_Results) String() string {
+	str, _ := text.Marshal(0xc27fbcfe703c0635, capnp.Struct(s))
+	return str
+}
+
+func (s CapReadHistory_getRangeHistory_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapReadHistory_getRangeHistory_Results) DecodeFromPtr(p capnp.Ptr) CapReadHistory_getRangeHistory_Results {
+	return CapReadHistory_getRangeHistory_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapReadHistory_getRangeHistory_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapReadHistory_getRangeHistory_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapReadHistory_getRangeHistory_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapReadHistory_getRangeHistory_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapReadHistory_getRangeHistory_Results) Cursor() CapHistoryCursor {
+	p, _ := capnp.Struct(s).Ptr(0)
+	return CapHistoryCursor(p.Interface().Client())
+}
+
+func (s CapReadHistory_getRangeHistory_Results) HasCursor() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapReadHistory_getRangeHistory_Results) SetCursor(v CapHistoryCursor) error {
+	if !v.IsValid() {
+		return capnp.Struct(s).SetPtr(0, capnp.Ptr{})
+	}
+	seg := s.Segment()
+	in := capnp.NewInterface(seg, seg.Message().AddCap(capnp.Client(v)))
+	return capnp.Struct(s).SetPtr(0, in.ToPtr())
+}
+
+func (s CapReadHistory_getRangeHistory_Results) TotalEstimate() int32 {
+	return int32(capnp.Struct(s).Uint32(0))
+}
+
+func (s CapReadHistory_getRangeHistory_Results) SetTotalEstimate(v int32) {
+	capnp.Struct(s).SetUint32(0, uint32(v))
+}
+
+// CapReadHistory_getRangeHistory_Results_List is a list of CapReadHistory_getRangeHistory_Results.
+type CapReadHistory_getRangeHistory_Results_List = capnp.StructList[CapReadHistory_getRangeHistory_Results]
+
+// NewCapReadHistory_getRangeHistory_Results creates a new list of CapReadHistory_getRangeHistory_Results.
+func NewCapReadHistory_getRangeHistory_Results_List(s *capnp.Segment, sz int32) (CapReadHistory_getRangeHistory_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return capnp.StructList[CapReadHistory_getRangeHistory_Results](l), err
+}
+
+// CapReadHistory_getRangeHistory_Results_Future is a wrapper for a CapReadHistory_getRangeHistory_Results promised by a client call.
+type CapReadHistory_getRangeHistory_Results_Future struct{ *capnp.Future }
+
+func (f CapReadHistory_getRangeHistory_Results_Future) Struct() (CapReadHistory_getRangeHistory_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapReadHistory_getRangeHistory_Results(p.Struct()), err
+}
+func (p CapReadHistory_getRangeHistory_Results_Future) Cursor() CapHistoryCursor {
+	return CapHistoryCursor(p.Future.Field(0, nil).Client())
+}
+
+type CapReadHistory_followHistory_Params capnp.Struct
+
+// CapReadHistory_followHistory_Params_TypeID is the unique identifier for the type CapReadHistory_followHistory_Params.
+const CapReadHistory_followHistory_Params_TypeID = 0xa1327f13770c1363
+
+func NewCapReadHistory_followHistory_Params(s *capnp.Segment) (CapReadHistory_followHistory_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return CapReadHistory_followHistory_Params(st), err
+}
+
+func NewRootCapReadHistory_followHistory_Params(s *capnp.Segment) (CapReadHistory_followHistory_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return CapReadHistory_followHistory_Params(st), err
+}
+
+func ReadRootCapReadHistory_followHistory_Params(msg *capnp.Message) (CapReadHistory_followHistory_Params, error) {
+	root, err := msg.Root()
+	return CapReadHistory_followHistory_Params(root.Struct()), err
+}
+
+func (s CapReadHistory_followHistory_Params) String() string {
+	str, _ := text.Marshal(0xa1327f13770c1363, capnp.Struct(s))
+	return str
+}
+
+func (s CapReadHistory_followHistory_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapReadHistory_followHistory_Params) DecodeFromPtr(p capnp.Ptr) CapReadHistory_followHistory_Params {
+	return CapReadHistory_followHistory_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapReadHistory_followHistory_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapReadHistory_followHistory_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapReadHistory_followHistory_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapReadHistory_followHistory_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapReadHistory_followHistory_Params) PublisherID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapReadHistory_followHistory_Params) HasPublisherID() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapReadHistory_followHistory_Params) PublisherIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapReadHistory_followHistory_Params) SetPublisherID(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s CapReadHistory_followHistory_Params) ThingID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s CapReadHistory_followHistory_Params) HasThingID() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s CapReadHistory_followHistory_Params) ThingIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s CapReadHistory_followHistory_Params) SetThingID(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s CapReadHistory_followHistory_Params) HistoryRange() (HistoryRange, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return HistoryRange(p.Struct()), err
+}
+
+func (s CapReadHistory_followHistory_Params) HasHistoryRange() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s CapReadHistory_followHistory_Params) SetHistoryRange(v HistoryRange) error {
+	return capnp.Struct(s).SetPtr(2, capnp.Struct(v).ToPtr())
+}
+
+// NewHistoryRange sets the historyRange field to a newly
+// allocated HistoryRange struct, preferring placement in s's segment.
+func (s CapReadHistory_followHistory_Params) NewHistoryRange() (HistoryRange, error) {
+	ss, err := NewHistoryRange(capnp.Struct(s).Segment())
+	if err != nil {
+		return HistoryRange{}, err
+	}
+	err = capnp.Struct(s).SetPtr(2, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+func (s CapReadHistory_followHistory_Params) Handler() CapSubscriptionHandler {
+	p, _ := capnp.Struct(s).Ptr(3)
+	return CapSubscriptionHandler(p.Interface().Client())
+}
+
+func (s CapReadHistory_followHistory_Params) HasHandler() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s CapReadHistory_followHistory_Params) SetHandler(v CapSubscriptionHandler) error {
+	if !v.IsValid() {
+		return capnp.Struct(s).SetPtr(3, capnp.Ptr{})
+	}
+	seg := s.Segment()
+	in := capnp.NewInterface(seg, seg.Message().AddCap(capnp.Client(v)))
+	return capnp.Struct(s).SetPtr(3, in.ToPtr())
+}
+
+// CapReadHistory_followHistory_Params_List is a list of CapReadHistory_followHistory_Params.
+type CapReadHistory_followHistory_Params_List = capnp.StructList[CapReadHistory_followHistory_Params]
+
+// NewCapReadHistory_followHistory_Params creates a new list of CapReadHistory_followHistory_Params.
+func NewCapReadHistory_followHistory_Params_List(s *capnp.Segment, sz int32) (CapReadHistory_followHistory_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return capnp.StructList[CapReadHistory_followHistory_Params](l), err
+}
+
+// CapReadHistory_followHistory_Params_Future is a wrapper for a CapReadHistory_followHistory_Params promised by a client call.
+type CapReadHistory_followHistory_Params_Future struct{ *capnp.Future }
+
+func (f CapReadHistory_followHistory_Params_Future) Struct() (CapReadHistory_followHistory_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapReadHistory_followHistory_Params(p.Struct()), err
+}
+func (p CapReadHistory_followHistory_Params_Future) HistoryRange() HistoryRange_Future {
+	return HistoryRange_Future{Future: p.Future.Field(2, nil)}
+}
+func (p CapReadHistory_followHistory_Params_Future) Handler() CapSubscriptionHandler {
+	return CapSubscriptionHandler(p.Future.Field(3, nil).Client())
+}
+
+type CapReadHistory_followHistory_Results capnp.Struct
+
+// CapReadHistory_followHistory_Results_TypeID is the unique identifier for the type CapReadHistory_followHistory_Results.
+const CapReadHistory_followHistory_Results_TypeID = 0xb6891805bc3ca7ee
+
+func NewCapReadHistory_followHistory_Results(s *capnp.Segment) (CapReadHistory_followHistory_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadHistory_followHistory_Results(st), err
+}
+
+func NewRootCapReadHistory_followHistory_Results(s *capnp.Segment) (CapReadHistory_followHistory_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapReadHistory_followHistory_Results(st), err
+}
+
+func ReadRootCapReadHistory_followHistory_Results(msg *capnp.Message) (CapReadHistory_followHistory_Results, error) {
+	root, err := msg.Root()
+	return CapReadHistory_followHistory_Results(root.Struct()), err
+}
+
+func (s CapReadHistory_followHistory_Results) String() string {
+	str, _ := text.Marshal(0xb6891805bc3ca7ee, capnp.Struct(s))
+	return str
+}
+
+func (s CapReadHistory_followHistory_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapReadHistory_followHistory_Results) DecodeFromPtr(p capnp.Ptr) CapReadHistory_followHistory_Results {
+	return CapReadHistory_followHistory_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapReadHistory_followHistory_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapReadHistory_followHistory_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapReadHistory_followHistory_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapReadHistory_followHistory_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapReadHistory_followHistory_Results) Follower() CapHistoryFollower {
+	p, _ := capnp.Struct(s).Ptr(0)
+	return CapHistoryFollower(p.Interface().Client())
+}
+
+func (s CapReadHistory_followHistory_Results) HasFollower() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapReadHistory_followHistory_Results) SetFollower(v CapHistoryFollower) error {
+	if !v.IsValid() {
+		return capnp.Struct(s).SetPtr(0, capnp.Ptr{})
+	}
+	seg := s.Segment()
+	in := capnp.NewInterface(seg, seg.Message().AddCap(capnp.Client(v)))
+	return capnp.Struct(s).SetPtr(0, in.ToPtr())
+}
+
+// CapReadHistory_followHistory_Results_List is a list of CapReadHistory_followHistory_Results.
+type CapReadHistory_followHistory_Results_List = capnp.StructList[CapReadHistory_followHistory_Results]
+
+// NewCapReadHistory_followHistory_Results creates a new list of CapReadHistory_followHistory_Results.
+func NewCapReadHistory_followHistory_Results_List(s *capnp.Segment, sz int32) (CapReadHistory_followHistory_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapReadHistory_followHistory_Results](l), err
+}
+
+// CapReadHistory_followHistory_Results_Future is a wrapper for a CapReadHistory_followHistory_Results promised by a client call.
+type CapReadHistory_followHistory_Results_Future struct{ *capnp.Future }
+
+func (f CapReadHistory_followHistory_Results_Future) Struct() (CapReadHistory_followHistory_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapReadHistory_followHistory_Results(p.Struct()), err
+}
+func (p CapReadHistory_followHistory_Results_Future) Follower() CapHistoryFollower {
+	return CapHistoryFollower(p.Future.Field(0, nil).Client())
+}
+
+type CapHistoryFollower capnp.Client
+
+// CapHistoryFollower_TypeID is the unique identifier for the type CapHistoryFollower.
+const CapHistoryFollower_TypeID = 0xc795ab8e17825f88
+
+// String returns a string that identifies this capability for debugging
+// purposes.  Its format should not be depended on: in particular, it
+// should not be used to compare clients.  Use IsSame to compare clients
+// for equality.
+func (c CapHistoryFollower) String() string {
+	return fmt.Sprintf("%T(%v)", c, capnp.Client(c))
+}
+
+// AddRef creates a new Client that refers to the same capability as c.
+// If c is nil or has resolved to null, then AddRef returns nil.
+func (c CapHistoryFollower) AddRef() CapHistoryFollower {
+	return CapHistoryFollower(capnp.Client(c).AddRef())
+}
+
+// Release releases a capability reference.  If this is the last
+// reference to the capability, then the underlying resources associated
+// with the capability will be released.
+//
+// Release will panic if c has already been released, but not if c is
+// nil or resolved to null.
+func (c CapHistoryFollower) Release() {
+	capnp.Client(c).Release()
+}
+
+// Resolve blocks until the capability is fully resolved or the Context
+// expires.
+func (c CapHistoryFollower) Resolve(ctx context.Context) error {
+	return capnp.Client(c).Resolve(ctx)
+}
+
+func (c CapHistoryFollower) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Client(c).EncodeAsPtr(seg)
+}
+
+func (CapHistoryFollower) DecodeFromPtr(p capnp.Ptr) CapHistoryFollower {
+	return CapHistoryFollower(capnp.Client{}.DecodeFromPtr(p))
+}
+
+// IsValid reports whether c is a valid reference to a capability.
+// A reference is invalid if it is nil, has resolved to null, or has
+// been released.
+func (c CapHistoryFollower) IsValid() bool {
+	return capnp.Client(c).IsValid()
+}
+
+// IsSame reports whether c and other refer to a capability created by the
+// same call to NewClient.  This can return false negatives if c or other
+// are not fully resolved: use Resolve if this is an issue.  If either
+// c or other are released, then IsSame panics.
+func (c CapHistoryFollower) IsSame(other CapHistoryFollower) bool {
+	return capnp.Client(c).IsSame(capnp.Client(other))
+}
+
+// Update the flowcontrol.FlowLimiter used to manage flow control for
+// this client. This affects all future calls, but not calls already
+// waiting to send. Passing nil sets the value to flowcontrol.NopLimiter,
+// which is also the default.
+func (c CapHistoryFollower) SetFlowLimiter(lim fc.FlowLimiter) {
+	capnp.Client(c).SetFlowLimiter(lim)
+}
+
+// Get the current flowcontrol.FlowLimiter used to manage flow control
+// for this client.
+func (c CapHistoryFollower) GetFlowLimiter() fc.FlowLimiter {
+	return capnp.Client(c).GetFlowLimiter()
+} // A CapHistoryFollower_Server is a CapHistoryFollower with a local implementation.
+type CapHistoryFollower_Server interface {
+}
+
+// CapHistoryFollower_NewServer creates a new Server from an implementation of CapHistoryFollower_Server.
+func CapHistoryFollower_NewServer(s CapHistoryFollower_Server) *server.Server {
+	c, _ := s.(server.Shutdowner)
+	return server.New(CapHistoryFollower_Methods(nil, s), s, c)
+}
+
+// CapHistoryFollower_ServerToClient creates a new Client from an implementation of CapHistoryFollower_Server.
+// The caller is responsible for calling Release on the returned Client.
+func CapHistoryFollower_ServerToClient(s CapHistoryFollower_Server) CapHistoryFollower {
+	return CapHistoryFollower(capnp.NewClient(CapHistoryFollower_NewServer(s)))
+}
+
+// CapHistoryFollower_Methods appends Methods to a slice that invoke the methods on s.
+// This can be used to create a more complicated Server.
+func CapHistoryFollower_Methods(methods []server.Method, s CapHistoryFollower_Server) []server.Method {
+	if cap(methods) == 0 {
+		methods = make([]server.Method, 0, 0)
+	}
+
+	return methods
+}
+
+// CapHistoryFollower_List is a list of CapHistoryFollower.
+type CapHistoryFollower_List = capnp.CapList[CapHistoryFollower]
+
+// NewCapHistoryFollower creates a new list of CapHistoryFollower.
+func NewCapHistoryFollower_List(s *capnp.Segment, sz int32) (CapHistoryFollower_List, error) {
+	l, err := capnp.NewPointerList(s, sz)
+	return capnp.CapList[CapHistoryFollower](l), err
+}
+
 type CapHistoryCursor capnp.Client
 
 // CapHistoryCursor_TypeID is the unique identifier for the type CapHistoryCursor.
@@ -4996,168 +6522,239 @@ func (p CapHistoryCursor_seek_Results_Future) Tv() ThingValue_Future {
 	return ThingValue_Future{Future: p.Future.Field(0, nil)}
 }
 
-const schema_f1bd301f7c12caab = "x\xda\xccY}pT\xd5\x15?\xe7\xbd\x0d/!\x9b" +
-	"\xdd\xbdy\x9bT\x90\xcc\x02M\x15\x99\x01I\"\xd2\xa4" +
-	"\xeaF\x02cL\x09\xe6%:#\x8eL\xfb\x92<\x93" +
-	"\x07\xc9\xee\xb2o\x13\x82c\xc00 \xc4\x0e\xed@\x09" +
-	"\x95`F\xa0\xb5*H\x05\x0b\xa5`\x19\x85\x8a)\x0c" +
-	"-\x1f\xad:v\xd0:ZTb\x91\x8fi\x84\x16a" +
-	";\xf7\xbe\xbd\xbbo7\x1b6\x81Z\xfbW\x92w\xcf" +
-	"\xbd\xe7\x9c\xdf=\x1f\xbfs3\xa5_*\xb5\x15d\xdd" +
-	"'\x83\xa0|\x986\"\\\xe4^3u\xdb\xfb\xb7w" +
-	"\x00\xc9\xb1\x85\xb7\x1e\xce~\xc23e\xdfy\x00,\xea" +
-	"\xcc\x98\x8e\xf2\xfa\x0c\x09\xa0fm\x86\x885\x1b3\x04" +
-	"\x04\x08\x8f\xf1\xaej[\xd5\xb3v)(9\x88\xb1\x0d" +
-	"i6\x09\xa0\xa8+c<\xca\xcf\xd3=E\x9b2<" +
-	"\x08\x18>\xf0\xa7\x15\x9f=4\xcd\xbb\x0c\xc8\x04\x04H" +
-	"C\xbatz\xe4a\x04\x94\xaf\x8c\xf4\x02\x86\xc7V\x14" +
-	"_]\xec\xeb_\x09\xca-\x18\x95\xc8\xcb\xec\xa6\x12\x93" +
-	"2\x17\x02\x86\xcf\x1d\xba\xc7k\x13\xeex\x1a\xc8\xadQ" +
-	"\x81\xce\xcc\x0bT\xa0'\x93\x1e\xf1\xe1\xf7F\xf4N{" +
-	"\xa3\xe2\xa7@r\xc48\x17\xf6e\x96\xa0\xfc\xc7L\x09" +
-	"@>\x94\xd9++v\x09 \xfc\xf1\x13\xbe\xee\x8e\x0d" +
-	"\xfdkM\x8b\x98\xd9\xc5\xf6\xc3\x08\xb6\xf0\xb4\x1a\xcf\xbb" +
-	"\xdaS9]@\xbe\xcdW&\xd9\xb7\xd0\x95\x13\x8f." +
-	"\xf7?\xbd\xbf\xe4Y\xcbJ\x9e}3]\xe9\x10\xde?" +
-	"\xa5\xbfP\xdb3@w\x96\xbd\x10\xe5<\xaa\xb1h\x94" +
-	"\xbd\x17\xe5\x82,\xaa<\xa7\xd8\xd3\xff\xc8]\xf3{\x80" +
-	"\xe4G}\x19\x95\xf5\x11s6\x8b\xfa2\xed\xe8\xc9\xfc" +
-	"3\xcb\xe4\xe7\xe2\xe0\xa8\xccbp\xcc\xcdZ\x08xv" +
-	"a\xb5\xff\xb9W\xbf\xfa\xa5\xb9\xceL\xd9c.\x1fb" +
-	"\x07\xbc\xf4BI\x9e1\xb6\xef%\xab\x86\xd3Y'\x18" +
-	"\xe0L`\x1e\x19\xedy\xd0?b\x8b\xc5\x97\x02\x07\xf3" +
-	"r\xec\x03-\xbf\xb8y\xe5{\xdb\x06\xf82\xce1\x1e" +
-	"\xe5\x02\x07\xc5q\x92c\x85\xbc\x9c\xfev\xe5\x8bU\x05" +
-	"\x8f^>\xf3\xabD\xd9f\xc7h\x94\xdb\x99\xec\"G" +
-	"\xaf|\x85\xfe\x16\xce\x1au\xe0\xdf\x9b=\xc6\x8e8\xaf" +
-	"N96S\xab\xfa\x1d\xf4\x923\xb6n\x1d\xbd\xd7\xbe" +
-	"\xf9\xd7\xa6U\xa6\x80\xead\x02\x0b\x9c^\xc0\xd7m?" +
-	"\xf8\xc33\xc7^\xddiz%\xb2\x08s\xfe\x95.\xef" +
-	"r\xbe\x02\x18\xbe\xdcV\xb1\xe7\xdc\xfeg~3 \x8c" +
-	"\x15\xd7t\x94U\x17\x0d\xe3G]\"\xd64\xbaX\x18" +
-	"Kg\xfb6\xf6\xcd\xbf\xb8\xd7\x12\x05\xaak\xa2\x00\xb6" +
-	"\xf0\xa7\xcb=\x93\xc7\xcd}\xf75P&D\x11\x9e\xe3" +
-	"b\x006\xbb(\x80\x97\xee\xfb\xfe\xcfz?\xeb\xdb\x07" +
-	"\xe4\x16\xbe\xbe\xda\xb5\x86\x02\xb8sw\xe6\xe1\x1f\x15\x9c" +
-	"\xfd\xbd\xb9b:\xd1A\x97P^\xcd\xb6N}\xf9\xe4" +
-	"\x89\x92\xbc\x1f\xbf\x15\x87\xc3.\x17\xbb\xbe\x83.\x8a\x83" +
-	"\xd1\xa3\xfc\xed\xcb\xf4+oY\x0e\x1fG\xd8\xe1\x0f\x1c" +
-	"v7\xcd\xfa\xf2\xa9C\x96\x15b\xae\xdcy\xc7\x9d\xe5" +
-	"\x05\xbb\xf5#`\x01\x07\x09\xcb\xb1\x1cB\xc1\xd9\xb8\xeb" +
-	"\xea-+\xaf\x8e>j\xd9\xba\x9ft\xd3\xad\x1b\xd7\xb9" +
-	"\xca.\xbe\xf6\xc3\xa3\xd6\xf4\xdcA\xc6\x0b4\x9c\x08\xb5" +
-	"\xb8aJ\xcd\x9122\xfbX\\\xfe\x92\x91T\x00\xb3" +
-	"\xa9\x80\xf7\xf3KK\xb6\xfcd\xe1\x09\xab\xc0\xb8\xecl" +
-	"*P\xcc\x04\xba^\xb9\xa7\xabq]\xe7\x9f\xad7;" +
-	"'\x9b\xb9\xac3\x81\x09\x8fx\x97w\x1d\x98\xf7w\xb0" +
-	"\x84t\xa7)\xb0\x9e\x09|k\xea\xa8\x0f\xfa\x0e\xba>" +
-	"\xb1\x16\x80=\xa6\x8a\xe3L@\xb8u\xb9s\xb1\xb1\xf8" +
-	"SPn\x8d\xc1z>\x9b\xa5U\x9aLa\xbd\xa9\x7f" +
-	"g\xfdC\xd3F\x9e\x1e\x10\x1e\xba\\\x8br\xbbL\xc3" +
-	"\xa3M\x16\xb1f\x99\xcc\xc2\xa3{\xe9\xeb\x8f\xed\xf5|" +
-	"\xd0g\xf5\xaaCfA\xd0%S\x95\xb9W??7" +
-	"\xf3\x94\xfb\x1f\x03\x0e<(\x97\xa0\xfc6;\xf0\x18=" +
-	"\xf0\xa4y\xe0\xe9\xbb\xf6\x1eR\xb7/\xfc\"\xee\xe6\xdf" +
-	"\x96Y\x80\x9fb&N} \xf0\xa2v\xf6\xe7\xe7\xad" +
-	"*\x157CZwS\x95\xc7\x17l0Vh\xe9\x17" +
-	"\x06\xa4g\xa7\x9bVj7+\xc0\xee\xfbPn\xcf\xa1" +
-	"9\xb7)C\x95*g\x1f\xbb`\xc5]\xcba\x0a[" +
-	"r\xe8y\xd2\xc8\x87/\xef\x7f\xd3\xf8g\x9cI]\xa6" +
-	"\xc4\xf39\xd4\xa4\x99\x17z\xdfy\xb8\xf2\xe6~+\xf0" +
-	"\x98\xdb\xc7\x02+\x97\x1e\xb1o\xedG\x97v\xf5\x8d\xb9" +
-	"h\x15(\xcee6+L\xa0}\xec\xef\xd4\xa21\xa7" +
-	"\xffeI\xb3\x96\xdcl\x9af;\xae\xeej\xc8}y" +
-	"\xeeWq\xda\x9bs\xd9\xb5\xb7\xe7R\xed;?\xde\xba" +
-	"}\xeb\xee\xbf\x84\xad\x87\xbfgj?\x93\xeb\x85\xb2p" +
-	"cK\xad\x1a\xd0o/\xb7\xe9F\xc8\x1f\\4\xb9N" +
-	"\x0d\xf8\x02%\x8d\xe6_5Z\xb0U\xaf\xd3f\xab\xcd" +
-	"\x1aT!\xa2\x1d\x04\xb4\x03\x10\x9c\xbe$\"\x11\xdd/" +
-	"\xc6\xed\x9f\xd9\xaa\xf9B\xd5ZH\xf3\x85$\xdd\xef\xab" +
-	"BT\xdc\xa2\x0d\xc0\x86\x00\xa4}\"\x80\xd2&\xa2\xb2" +
-	"L@\x82\xe8\xa6\xa6\x93\x8eG\x00\x94'ET\x9e\x15" +
-	"\x90\x08\x82\x1b\x05\x00\xb2\xbe\x04@Y+\xa2\xb2]@" +
-	"\"\x8an\x14\x01\xc8\xb6\xe9\x00\xca\x8b\"*o\x0a\x88" +
-	"67\xda\x00\xc8\xfe \x80\xf2\x86\x88\xca\x11\x01\x9d>" +
-	"\xb5Y\x8b\x98\x8a\xe1@Km\x93n4j \x06\x0d" +
-	"t\x00V\x89\xa6\x1b\x0e@o\xa8Q\xf75$~]" +
-	"\xa2\xb5\xd55\xb5\xd4k\x09\x9f\xc3A\xe6\x8d\xee\x07\x8f" +
-	"o\x86\xba\xc8@\x1b\x08h\x03\x8c\x020\"\x0e\x802" +
-	"5P\xa9\xfa\xd4\x06\xad:\xb2\xcd79\xa4\x19!\x86" +
-	"K~\x95\x1aT\x9b\x0d\x00\xc5\x16\xc5$k4\x80\x92" +
-	".\xa2\xe2\x16P\x0c\xb5\xa2+\xbc\xe6\xc9u\xbf\xed\xf9" +
-	"\xe4\x9d\x0d\x00\x88.\x8b\x9e\xb4D=\xe5\xe6\x87\xb2\x96" +
-	"\xa0\xe1\x0fN64m~~\xb5f\xb44\x89!C" +
-	"I\x8f*\xb8\x8d*\xc8\x17Q\x99\" \xc7|R!" +
-	"\x802AD\xe5\x8eA\x94zZ\xd5&\xbd\x1e\x11\x04" +
-	"\xc4k\xb9Z\x1e\x17.\xf4{\xb5\xa6\xd6G\xbe\xe6W" +
-	"k\x1e\xa3\xa5)dX\xdd\x1d\x1fsW\xaaS\x03H" +
-	"b\x8d\x13\x10\x89E\x998\x982\xaf\xa9\x8d\xc6\x96]" +
-	"L\x03\x88V0\xe4AO\x94 \x08\xe4~\x09cI" +
-	"\x86\xbc\x0e\x92\xbb\xbbA \xc5\x12\x0a\xd1\x0cEN\x92" +
-	"\xc8\xa4\xc7A \xdf\x91\xc2uj\xe0\xde\xfa\xfar\x1d" +
-	"<Le)\xd2/\xec^\x91_\xac\xe8\xf7\x99\x9f\x99" +
-	"\xc7\xe0\xd5#\x92U8\x8c\xd0h\xd0\xcc\xc80\xa2\xa1" +
-	"1\xf8u3\x83\xccoj}}d[\xb5f8)" +
-	"\xc4\xc3\xda\xc6\xc2D\xb2\xee\x1a\x0c\xeb2\x0f\x0b.\x0a" +
-	"\xf5\x18\x065o\x86\xc8\xeb\x1f9^\x08\x029H\xa1" +
-	"\xe6=\x169\x0d#{&\x82@\xb6Q\xa8y\xdbG" +
-	"^\xb9\xc8&\xba\xd6%\xa1\x18ma\xc8\xcb<\xe9\xa4" +
-	"g\xb6Kh\x8bvt\xe4\xcd\x9f,\xa0\xfb4\x09\xd3" +
-	"8\x97\x8b\xf1#2\x87\xee\xab\x94pD\x94L g" +
-	"\xc8\xe4^\xbao\xaa\xe4yL\x0f\x1a\xa1Rt6\xa9" +
-	"\xec\x87Ok\x0b\x95\xa2\x87\xfe\x98]\x8a\xce@Pk" +
-	"-E\x0f\xfdA\xff\xa4I\x95\xe2J-\x01\x1f\xbd\xce" +
-	"X\x02\x988[\x13\xa0$\x96\x00\xde:\x86.\x92\x18" +
-	"\x11N\xc8\x81\x149O}\xf8\xbas>\x85\x09\x0c)" +
-	"\x16\xbdbs\x9c\x9f\x851?=FH\x0b\x18\x98\x0e" +
-	"\x02\xa6\xc3\xd0\xa1\xac\x0a\xfa\x03Z0\xa4k\x86\xe9c" +
-	"\x08\xe3\x14T\x03(v\x11\x95\x09\x02\x86[\xd5\xa6\x16" +
-	"m\x96n\x00\x86x\xf9\x8e\xf7\xcfq-\x8f\xe2S\xe4" +
-	"\xde:\x9a\x9a\x033K\x1c\xcc\\\xc9\x1f\\D3$" +
-	"\x9df\x08\xa7\xda\x96a\xa5`)\x08\xe46\x9a!\x9c" +
-	"k\"\x9f3H\x1e-T9R\x98G\x0eF\x0c\x81" +
-	"R\x0cs\x08\xc0\xc3@\x88\x8fCq0'D\xd3\x18" +
-	"\xb32r\x1a\x83|n!J5\xaf\x8c\x9cZ\"\x9f" +
-	"\xcf\xc8\xdd\x15,EP\x88\x0e\x14\xc8\xa7:r\x1b\xdd" +
-	"7N\x0as\x80\x00i\xf9\xe3\x15\x05\x00\xac\x7fa\x82" +
-	"\xb1C\x8a\xa1h\xb2X\xe2\xb80\x12\xc7\xa5\xb18\xbe" +
-	"\x9b~\xfb\xae\x88\xca\x0c\x01=\xb5j\xa8\xaeq\xd0\x1b" +
-	"\x1fjD'\xad\xacUjPR\xe3C\x9a\xb7\xea|" +
-	"3\x83R\x06\xda\xb0j\x05O!{T\xdf\xccZ\x00" +
-	"e\x86\x88J\x95\x85.UR\x12T.\xa2\xf2\xa0\x85" +
-	".)\x94X\xcd\x12QyX\xb0\x10\x1f)x\xff\x0c" +
-	"N\x87\x960\xca\x13\xfb;\x9e+%\xe7\x82uj\x80" +
-	"\x12@\x8b\xd1\xf1\\\xf0\xf1\x01\xdd/z\x90\x94\xaa\xf1" +
-	"\x05\xb5f\x7f\xabfa\x8bISn\x18\xd4*Y\x85" +
-	"\xa8\x88T\x88\x9b\x04\xc6\xe2T\xdd\xa7\xd5\x03\xbd\xa4\xe1" +
-	"\x958\xda\x1b\xcc\xfe\x8c\xc6\xb0\xd8Xd\x8f\xd5\xa4y" +
-	"\x16\x93t\xc3\xff\xa0\xde\xac\x19\xe0\x0c\xa9\xcd\x81\x01\x97" +
-	"1\x84\xa4\xf9?\xa8\xfd\xc3\x05\x86\xb5\xac\xc4=\xc3\xe8" +
-	"\x04Q\x0a}]yR\x18\xc9\x93\xc6\xa1\xe6\x89\x87\xe6" +
-	"I\xe2\xa40T_\x19\xd5\xe0\x89}\x83\xb9\x91\xa4\x1c" +
-	"M\x8cu\xd8\xe4\xe9,\x0d\x95~\xc6\xa9\x190\xa1|" +
-	"\x1dz\x92\xa5\xabu4\x08j!t\xc5\x9eKS\x8d" +
-	"B\xc9Hn\x12N\xf2\xdf\x9a\xb5\x18[\xbc>\xd2#" +
-	"\xa5\x1e\xa1\x12\xd0K\x89\x959FE\x1f8\x12(\xe4" +
-	"\x10f\xb6\x18z\xd10\xb3\xd4\x93\x8aX\xed\xe0\xc9U" +
-	"\xb0\x14@\x99\"\xa2r\x97\x80\xe1\xba&]\xf3\x85\xee" +
-	"\x9fA++\x8f\x0c\xbd\xc1\xe7\x0fj\xd5\x1aF|\x18" +
-	"Xs\x93\xb6\x1a\xab\xe7\x91\xe7\x83X\xbb\xe9N:\x83" +
-	"]\xcf\xac\x95\x0c\xd0\xe9\xb1\xde\xbe$\xa8\x85f\xe9\x86" +
-	"\x85IZ\xe3\xd01\xe8\x88\x1aq\x82\xa2\x19\xe9\x87q" +
-	"\xf6\x07\x13\xa7\xca\xe1E\xdb\xff\x8c\x1e\xa5\xccfcH" +
-	"Uc\xe8\xd9lK\xa1\x10\xccW\xa44\xcb\xbf\x1f\x90" +
-	"?1\x92\xd5\x94\x97vR>\xcb\x1f[\x91?\xaa\x92" +
-	"\xf65 \x90E\x94\xcf\xf2\x97Z\xe4/\xd7\xa4y\x0b" +
-	"\x08D\xa7\xe3'\x7f:D\xfe\xdcF\xe6\xd2}s\xe8" +
-	"\xf8\xc9\xff\x01\x83\xfc]\x9bTR}3c\x84\x9dq" +
-	"\xdd\x18}\xe7F3v\xcc\x0b9r\xb0\x9c\xd4\x9dR" +
-	"\x0c\x1bI\xc59\x99\x01\x0c\xa5\xa0\xcfI\x07\x96k\x10" +
-	"\xd6\x1b\xadwf#K\x16\x81\xdf\xdc\xe3R\x95\xea\x0c" +
-	"&\xb8k\xe5{\xc9\xaa\xd2\x0d\xd4\xe0d\x11~\xdd\xea" +
-	"R'\x14\xafP\xc3\xa2\xa8\xdf\xfc\xe3\x9f\xa5\x91p." +
-	"\x7f\xcd\xa6\xc5\xff\x0df\xb6\xac\xff\x04\x00\x00\xff\xff\xc5" +
-	"(\x8d\xba"
+const schema_f1bd301f7c12caab = "x\xda\xc4Z}tT\xe5\x99\x7f\x9e{'\x0cht" +
+	"rs\x13\x98\x84$\x13\xd3T\x81#\x94\x80)\x92\x85" +
+	"\xe6\x03\xa2@\x0d\xe6\xce\xc0.p\xe4\xe8M\xe6\x9a\x0c" +
+	"Nf\x86{o\x02\xf1\x88\x01\x8e\xb4\xe2\xae\xed\xca\x82" +
+	"k\xb1\x1c\xc1\xe2*_\xab\xb8R\x85\xc2vaK)" +
+	"\x9c\x9eU\xb4\xb6\xc7=\xb5k[Q\xa1\xa2\xe21b" +
+	"ke\xf6<\xef\x9d{\xef;\x99\x09\x99\x84\xb6\xfe\xc1" +
+	"!y\xef\xf3\xbe\xcf\xc7\xfb<\xbf\xe7\xe3\xcd\xd4uW" +
+	"7xj\xaeYU\x02Bh\x02\xe6\x8dJN/\xda" +
+	"T\xbb\xef\xcd\xaf\xad\x03\xa9\xd8\x93\xdcs\xaa\xf0\xbe\xc0" +
+	"\xd4#\x17\x00p\xfa\x8e\xfc&\x94\xf7\xe7{\x01B{" +
+	"\xf3E\x0c\xbd\x94/ @\xb2\xac\xfe\xe1\xd5\x0fo\xdb" +
+	"\xbc\x1e\x94b\x14\xdd\x0dyy^\x80\xe9\xfb\xf2\xabP" +
+	">B{\xa6\x1f\xccO\"`\xf2\xd8\xff|\xfb\xbd\xc5" +
+	"3\xea\x1f\x00i\x02\x02\xe4!}\x92|\xa7\x10P\xfe" +
+	"\xaa\xaf\x1e0Y\xb9`\xe6\xa5\xfbc\xfd\x0f\x82r=" +
+	":\x14\xcd\xbe\xadD\xb1\xd8\xb7\x0a0\xf9\xd1\xc9o\xd4" +
+	"{\x84\x9b\x1e\x02\xe9\x06\x87`\xbf\xefc\"8\xce\x8e" +
+	"\xd8x\xcd\xcf\x0e\x9f\xde\xbcm\x13\x89\x94\xc7\x89\xc4(" +
+	"\xcf\xf8\xaaP\xee\xf7\xd1\x8f\x17|\xff@\"\xbd\xf5w" +
+	"\xa3N\xcc\xf8\xaf\x05\xff\x02R\xb1\x98\xa6q\xa3T\x87" +
+	"\xb2\"\x11i\x8b\xe4E\xf9\x0b\xfa1)<\xbe!\x9c" +
+	"\xf7\xcd\xb5\x9b\xe9tt\xe9=\xecp\x89\x0e'2\xf9" +
+	"\x82D\xb2\xfe\xfe\xbe\xd8\xd6u\x8f\xf7o\xb6\xd4e4" +
+	"\x8b\x0bO!x\x923B\x81_i\xdf*\xde\x02\xd2" +
+	"W\xec/\xf3\x0bw\xd3\x97W\xef\xd8\x10\x7f\xe8h\xdd" +
+	"\xf7\xb9/3\x0b\x9f\xa4/\xeb\x847\xcfD\x9en\xdb" +
+	"\x96!\xe9\xc4\xc2i(\xcf,$\xd2\xda\xc2\x13(\xb7" +
+	"\xc8$i\xf1\xcc@\xff\xb2Y\xf7l\x03\xa9\xda1T" +
+	"\xad\xfc;2\xd4|\x99\x0c5\xe3\xe5_W\x9f\x7f@" +
+	"~\"\xcd\xd6\x11\x99\xd9\xbaW&\xf9\xdb\xe5\xfcUr" +
+	"\xdf\xb4\x1d\xa9#\x980\xaf\xcb\xec\xba\xce\xca\xef\x02~" +
+	"\xb8*\x18\x7f\xe2\xf9?\xff\x9bu\x00\xfb|\xbc\x88\xed" +
+	"\x7f\xbd\x888\xecz\xba\xae\xdc\xa8<\xb7\x8b\x17\xa1\xbf" +
+	"\xe8U\"\x18SL\x04+\xa4\xd2\xc0\xa2\xf8\xa8\xdd\x9c" +
+	"\xb2\xb3\x8b\xc9\x0c\x17_[\xb8\xf3\xaegg\xef\xe16" +
+	"N.f\x8c\x1b\xd9\xc6\xca\xdb\xbbw\x8e\x7f\xf0\x8d}" +
+	"\x19\xb6XY\\\x85\xf2\xbab\xda\xb0\xa6\xf8V\x94\x97" +
+	"\x8e\xf5\x02|\xf1\xc1\xc35w|~\xfe\xdf3\xaex" +
+	"l)\xca\x0aQ\xc8-cO\xc8\xa7\xe9\xa7\xe45%" +
+	"\xc7\xfe\xf4d\xc0\xd8\x9ff\x96\x83c\x9f$\xe6'\xc7" +
+	"\x92Y\xc6\xec\xd9Sz(\xff\xc9\xff\xb0\xa4NYv" +
+	"\x1c#h\x1eW\x0f\xf8c\xcf\x9d?{\xec\x95\xe7_" +
+	"\xb0\x84\x17\xe9s\xf7\xb8\xff\xa5\xcf\x1b\xc7=\x0b\x98<" +
+	"\xff\xc9U\xb7\xd4?4\xe6@F\x90}\xd5?\x09\xe5" +
+	"Z?\x05\xd9T\xbf\x88\xa1Y~\x16d\x1f<=\xeb" +
+	"p\x9e\x7f\xe3\x0fy3\xd6\xf8\x99\x19\x9b\xfdd\x8d\xcf" +
+	"W/8\xf8\xd1\xd1\xc7~\x98q`\xc4\xdf\x84r/" +
+	";\xd0\xa4\x03\xd7Z\x07>\xf1\xe8\xbdg\xb7\xff\xf6b" +
+	"&}\xaf\x7f\x1a\xca\x1b\x19\xfd\x03D\xff\xcf\x16\xbd\xf7" +
+	"\xc3s\xdb\xcf\xdds\xf1\x10\xe7\xc7\x1b\xfc\x93\x04\xf0$" +
+	"\xdf\xdd\x10\x98r\xdd\xf2_\xfd\x08\x94\x09\x8e\x0b\xac\xb1" +
+	"D{\x84\x89\xf6\xd9\xad\xdf\xfc\xd7\x13\xef\x9d;\x02\xd2" +
+	"\xf5\xf6\xf7\x03\xfeM\xe4\xce\xb5\xa3f%.\x1d\xee;" +
+	"\x06J\xb5k\xe7\xa7\xfc\xccA\x0f\xfa\xc9\xce/\xbcx" +
+	"\xf5\xa9\x7f\xac\xf9\xf0\xbf\xad\xbd\x16AI\xc9&\"\x98" +
+	"XB\x87\xd7\xee\xfd\xf5\xabu\xe5\xdf\xf9i\xdaU\xb5" +
+	"\x940\x0f\\^BG\x18\xdb\x94\xff\xfbt\xf4\x17?" +
+	"\xe5\xd8\x1f,a\xec\x1b\x96\xdcR~\xe3\x07\xca\x09\x1e" +
+	"Gv\x95\xecf\xdc\xd9\xe1\x0f\xde\xb9~\xdcw\xf6l" +
+	"9\x91\xe1bo\x964\xa1|\xbe\x84\xbc\xe6l\x89\x97" +
+	"\xfe\x01$o?U\x14\xbd\xed\xd3o\x9d\xe4\x18\x9d\xb6" +
+	"\x18}\xfd\xa6\xaf\xcf\xaby1\xf2s\xe0\xdc\xe1h\x09" +
+	"\xf3\xe5\xd7K\xc8\x1d\xb6\x1f\xb8t\xfd\x83\x97J_\xe6" +
+	"\xb6\xae+\xddJ[\xb7?Z0\xe7\xe2\x8f\xeez\x99" +
+	"\x87\xcb\x95\xa5U\x02yR)\xc9\xd815\xf4\xf39" +
+	"\xd2\xc2Wx\x82]\xa5W\x11\xc1QFP\xff\x87\xcf" +
+	"\xfav\x7fw\xd5\xab<\xc1oK\x0b\x89\xe0\x8f\x8c`" +
+	"\xcb\xb3\xdf\xd8\xd2\xf9\xe8\xc6\xd7x_.\x19\xcf,8" +
+	"q<\x11L,L|\xf7\xfc\xf7\x0e\xbd\x05R1\x87" +
+	"w\x16\x16\xcc\x1f_\x88\xf2\xd2\xf1d\x89\xc5\xe3\xdf\x05" +
+	"L>\xf4\xfe\xe4#_\x99T\xf1\xb6eTF\xd3X" +
+	"\xf6<\xa9\xf2\xe9\x7f.{\xff\x89;ox\x9b\xb7B" +
+	"M\x19\x0b\x8a\xe62\xb2\xc2\x84e\xf5\x1b\xb6\x1c[\xf1" +
+	"6p`r\xa6\x8c\x09\xd2_F\x82\x8c\xab-\xf9\xcd" +
+	"\xb9\xe3\x05\xef\xf0\x17V\\\xceT\x99\\N\x04\xc2\x0d" +
+	"\x1b|\xf7\x1b\xf7\xbf\x0b\xca\x0d\x9c7\x943\x87R\xcb" +
+	"\xc9\x1b\xfc\xfd/\x84\x17\xcf\xb8\xeal\x86\xdf\x1f/o" +
+	"C\xf9\x8dr\xf2\xfb_\x94\x8b\x18z\xab\x9c\xf9\xfd\xd6" +
+	"\xf5?\xbe\xfbP\xe07\xe7x\xeb\xbdY\xce\xbc\xfb\x02" +
+	"c9\xf6\xd2\x1f>j>S\xf4~\xc6\x81\xd7U\xd4" +
+	"\xa1\\SA\x07\xdeX!b\xe8\xe6\x0av\xe0\xd9Y" +
+	"\x87N\xaa\xcf\xad\xfa \xcdak*\x18t4V\x90" +
+	"\x88\xb5\xb7'\x9e\xd1>\xfc\xc1\x05\x9e\xe5\xbe\x0av\xa3" +
+	"\xc7+\x88\xe5\xe9\x95\x8f\x1b\xdf\xd6F\x7f\x9c\xe1\x96g" +
+	"*\x9aP\xee\xaf`\xa9\xad\xe2V\x94\xdf\x08\x90_\xee" +
+	"\x18\xa3z[\x16\xbe\xf21\x7f\xbfG\x03\x8c\xe1\xe9\x00" +
+	"\x9d\xe7\xbdj\xc9\xe7G\x7fb|\x92&\xd2\x05\x8b\x02" +
+	"+I\xa4\xe6\x8fO\xfcrI\xcb\xf8~\xde\xf0\xcb+" +
+	"\xcf\x11\xc1\xcaJ:\xe2\xc8\xe6\xdf}v\xe0\\\xd9E" +
+	"\x9e`K%\x93y\x1f#XSyX\x9d^v\xf6" +
+	"\x8f\x1c~\x9c\xae,$\xfc\xb8\xae\xf9e\\\xf9\x83e" +
+	"\x7f\xb2A\x80};Yy\x0c\x01\xa7\xbfQ\xc9\x12\xf4" +
+	"\xfeK\x07:\xc6\xee]\xfe\xe74\x01k\xab\x98g4" +
+	"W1\x9c\xf8\xfd\x9e\xe7\xf6\xbc\xf8\x8b$\xcf\x7fG\x15" +
+	"\x13\xf0@U=\xc4\x93\x9d\xddmj\"\xf2\xb5y\x9e" +
+	"\x88a\xc6\xf5\xde)\xedj\"\x96\xa8\xeb\xb4~\x0bi" +
+	"zO\xa4][\xa8vi\xd0\x8a\x88\xf9 `>\x80" +
+	"\x84M})\x0ag\xbf\x98\xb6\xbf\xb9G\x8b\x99A\xcd" +
+	"\xd4b\xa67\x12\x8f\xb5\"*\xd5\xa2\x07\xc0\x83\x00\xd2" +
+	"\xf9I\x00\xca{\"*\x9f\x08(!\x16\x91\xe8\xd2\x85" +
+	"e\x00\xcaG\"\x86\xf2Q@I\x10\x8aP\x00\x90\xc7" +
+	"`\x1d@\xc8\x83\"\x86*i]\x14\x8bP\x04\x90\xcb" +
+	"\xb1\x09 \xe4\xa7\xf5\x9bP@\xf4\x14\xa1\x07@\xaeA" +
+	"\x9d\xf2\x03-\xcf\"\xf2<O\x11\xe6\x01\xc83q\x19" +
+	"@\xe8fZ_D\xeb\xa3*\x8bp\x14\x80\xac\xe0\x0a" +
+	"\x80P+\xad\xdfA\xeb^,\"\x0b\xc9Kq\x01@" +
+	"h\x09\xad\x87i}\xb4\xa7\x08G\x03\xc8*\xa3\xbf\x8b" +
+	"\xd6\xa3(\xa0/\xa6vi)\xa3`2\xd1\xdd\x16\x8d" +
+	"\x18\x9d\x1a\x88\xba\x81\xd7\x02\xb6\x8a\x96\xc1\xae\x05\xac7" +
+	";#\xb1\x8e\x81\xab}\xda\xea\xf6hwX\x1b\xb0\x9c" +
+	"\xd4\x99\xdd\"q\x08\xc4\xe6\xaa\xbd\x06z@@\x0f\x95" +
+	"\x1c\xf1\xae\x84\xdanF@\x8c\xc7\xec=\x05n\xd5\x05" +
+	"\xc8v\xc7c\xd1\xde\xdbcs:\xc1\xa7\xc6:4D" +
+	"\x10\x90\\%\xac\xa9\xe165\x16\x06\x00\xbc\x1a\x04\xbc" +
+	"\x1a0\xd9\xa9\xa9\xba\xd9\xa6\xa9\xe03CZ\xbb\xc3\xc7" +
+	"\xbe\xd2QiW:GM\xb4\xa81\xb5C\x0b\xa6\xc4" +
+	"\x8bM15\xc3d7]\xdd\xaa\xeaj\x97\x01\xa0x" +
+	"\x9c[\xbe\xa6\x14@\x19-\xa2R$\xa0h\xf6`A" +
+	"r\xd3\xdaG_\xda\xf6\xce/\x1f'I\x0b8>y" +
+	"\x03\xf9\xcc\xb3\x16\xe6t\xebF\\\x9fbh\xda=\xd5" +
+	"A\xcd\xe8\x8e\x8a\xa6\xa1\x8cv\x18L$\x06\xd5\"*" +
+	"S\x05\xb4\xbdh\xf24\x00e\x82\x88\xcaM\x830\x0d" +
+	"\xf4\xa8\xd1H\xd8\xb1\xca\xa0\xaa\xceK\x0b\x00Z\x0fj" +
+	"j8\xb5Z\x1d\xd4\x02Fw\xd44xu\xab\\u" +
+	"\xbd\xedj\x02%\xb7\x10\x03D\x89c\x96\x1e*\x8d\x1d" +
+	"\x1d\xba\xd6\xa1\x9a\xda\xdf\xab\xdeh\xb7F\xa1\xe2wN" +
+	"\xfd^\x10@yLDe\xa7\xab\xe3\x0e\xd2\xf1\xfb\"" +
+	"*\xcfP\xa0 \x0b\x14\xe9)b\xbf]De/E" +
+	"\x89\xc0\xa2D\xdaE\x8b;ET\x9e\x13P\xf2\x88," +
+	"F\xa4}\xb4\xf8\x8c\x88\xca\x0bn\x80H\xfb)$\xf7" +
+	"\x8a\xa8\xbc$`\xd20U\xdd\\\x14\xe9\x02t\x9c;" +
+	"\xd0\x1e\xef\x8e\x99\xb6\x87x\xbb\"1\xdb\x89\xbc]\xea" +
+	"j\xe7g\xb5\xa7\xc3\xfe\xd9\x17U\x0d\xd3\xf5\xb4\xac\xaa" +
+	"sv\xae\xb7\x0cM\xda\x17\x88y\xd4L\xa42\x16\xda" +
+	"\x08&\xad\xd4A\x90\"^tA\x15\xed\xbc'-\xdf" +
+	"\x0a\x82\xb4\xd4\x8b\x82\x83\xc8h7CR\xcb\xbd H" +
+	"\xcd^\x14\x9d\xba\x06\xed\\,\xcd\xac\x03A\x9a\xecM" +
+	"\xb6\xab\x89\xc6px^\x04\x02L\x9c\x06\xa4\x15\xe6\xee" +
+	"h\xfb\xbb\x18\x8fY\xcb\xcc\x11\xa0>\x92\xa2\xacoS" +
+	"\xdb\xef\xe9N4`+\x0e\xaag*p\xe3\xb1\xa0\xb7" +
+	";\xca\x94\xe4\xdc8\xe8\xba\xac\x83\x865m\x00\xcaT" +
+	"\x11\x95Y\x02&\xd5\xbbMM\x9f\xab\xf6\x02\xbaX\x10" +
+	"\x89\x99\x9a\xde\xa3F\xc1;\xa2\xc8\xed\xd0\xac\xc05\x9c" +
+	"\xc8\x1d<\x1a\x99a\xac55\x1cNm\x0bj\x86\x8f" +
+	"\"`X\xdbX\x14{\xf9]\x83\xf9\xc3\x9c\x00\x8b}" +
+	"\xb2T\x19s\x07\xbb\x10D;'K\xa7\xa7\x81 \x1d" +
+	"'w\xb0\xebK\xb4\xbb6\xe9\xe0$\x10\xa4}\xe4\x0e" +
+	"v\x8d\x8dv\xaa\x94v\xd0\xb7-\xe4\x0evY\x85v" +
+	"\xe9!m\xa43\xd7x\xd1\xe3\x14\xc7h\xd7\xd1\xd2J" +
+	"\xda\xa7y1\xcf\xee\xec\xdcnHZJ\xfbZ\xbc8" +
+	"\xca\xa9\xcb\xd1\xee\xd6\xa5F\xdaW\xeb\x0d\xdc\x1d\xd1\x0d" +
+	"\xb3\xc1\x8a\x8b\x06\xf4\xc5\xb4\xd5f\x03\x06\xe8\xbf\x85\x0d" +
+	"\xe8K\xe8ZO\x03\x06\xe8?\xfa\x950/\xdd\xa32" +
+	"\xae\x94\xc3#\xe7:]|\xb2\xec\xcc\xe3S\x9d\x8bO" +
+	"\xf5\xed\xcc\xba(\xb9}\xf3\x00\x88\x1a\x02\x92I\x87\xbf" +
+	"9$\xf3\x0a\xdf\x1d\x8fF\xe3\xablu\x9d\xecS\xe0" +
+	"H\xa2R\x00\xdd%\xa2\x12\xe5\xa2*\xd2\x04\xa0\x84E" +
+	"T\x12n\x89!u\xad\x00P\xa2\"*\xab\xdd\xfaB" +
+	"\xea&\xca\x84\x88\xca}\x02\x97\xd8\xbd\xfa\xfc\xb96\"" +
+	"\xf6\xb1\x94\xee\xfe\x9eL\x15D\xc1T\xde-p\xbb\x03" +
+	"K\xcd\xbeN5\x16\x8ejd\xf5\xfe\xf5\x9f\xbc3e" +
+	"\xf6\x9c\xb5\xc3\xb4:s\x0e\xa6\xac\xd8\x95v\xb5\xd3\xdc" +
+	"\xab\x0d\x18\xa6\x960p4\x088\x1ar\xf7\x9eV=" +
+	"\x9e\xd0t3\xa2\x19\xd6\xb5\x9a\x98\xc6\x80 *_D" +
+	"e\x82\x80\xc9\x1e5\xda\xad\xdd\x161\x00M\xb7\x08\xe1" +
+	"\xaf\xf4\xda\xcbi\x94\x8e\x0a\x8d\x0c\x143\xc1d(q" +
+	"\x9d\x9c\x99\x92v@\xe5\xd1\x94\xb2G\xb5\x80}jG" +
+	"\xc7m\x11\x83\x13\xd5\x99\x81\x0d\x10U\x1c\x8c\xa77\xae" +
+	"\xf7\x12\x10\x15\x11\x10\xd9\xf3\x0bn\x84\xf4\xc8z\x10\xa4" +
+	"\x8d\x04Dv;\x8b\xf6pGZC9\xab\x9b\x80\xc8" +
+	"\xae\xe6\xf1\xb5\x85;\x81\x8do\"+@\x90T\x02\"" +
+	"\xbb\x01D\xbb\xef\x97\x16\xaf\xb7\x00\xc5\xe3\xcc\x99\xd0\x9e" +
+	"tH\x8dt\xe6Lo\xd2\x0ezL\x19\x06\x1a0i" +
+	"_%\x04\xd8eZ+\xccZ\xe0#{Y\x0bA\xe6" +
+	"\xa1\xdc6;\x9c\x9c\xf4w\x99\\\xe6\xde\xa1h\xd9%" +
+	"\x9f\x01\xb4\xddL\xa1=\xb7\x92\x94 \x08\xd2|\xb2\x8b" +
+	"\xddH\xa3=\xc0\x93f/`\xa0\x88\x8230B{" +
+	"\xecG\x09Q\x90\xae\xf3&m\xff\x00\xa4\xc4k\xe7\x10" +
+	"\x00\xe0\x7fC#]\xd8\x9cB\xc8\x81G\x0e\xb9\xa6\xa5" +
+	"\x90\xab\xc1E\xae\xd9\xb4v\xb3\x88\xca\\\x01\x03m\xaa" +
+	"\xd9\xde9\xa8\xc3\x0f\x82a\xb9\xe5\xd2VU\xf7\xaa\xe9" +
+	"\x11]\xeaz0a\xe6\x90q6\xac\xec`#H\xbe" +
+	"\xc3\xaf\x99\xd0r\xae\x88J+\x87\x96-\x14F\xf3D" +
+	"T\x16qh\xa9P\xa1x\x9b\x88\xca\x92\\\x811\xbd" +
+	"I\xca\xeeT\x0cS\x16\xf5&\xb4\xe6\x1e\x1f\x99$\xad" +
+	"\xd7\xac\xab\xd7\x98\x9dF\x92\x19\xb2\x81\xd9\x82\x14\x98\xf9" +
+	"\x05\xdb\xf15\x9d\xba\"\xc9\x1dk\x0d\xc0\xe5\xf4\xde\xb8" +
+	"]MPC\xcc1M\xef\x8d\xef\xcd(\x12\x87\xd2\xba" +
+	"\xb1=@8h\x0ch\xb1\xad\x92\xd1\xd5\xdb;TU" +
+	"\xa7k]\xf1\x1e\x8d\xeb\xbds\x03\xd7\xc1\xdb\xba\xa1\xcc" +
+	"\xa7k\xa6\x1a\x89i\xac\xa9\x1c\xd2\xf7\xd3#\x91\x0a\x1f" +
+	"+qc\xee\xb8\xcf\x80+\xa3\xc8\xe1\xa2\xb8.K\xfd" +
+	"\xa1\x03(7\x8a\xa8\xdc|\xb9\xc2\xc7\x8c\x9bj\xb4\xd9" +
+	"0!\x10\xe9RM-\xa3\xa2\xce\xa5GMi\xc3\x1b" +
+	"k\x05g\xac\x88\x11_\x14\xe9\xd2\x0c\xf0\x99jW\"" +
+	"#\"r@\xae\xbfv\xc9\x95\x8b\x08\x03\xaf,o\x88" +
+	"\xce\xd9\xea\x8e\xaa[U\x9f>\x00\xe3\x82\x9cm\xc2\x11" +
+	"]k7\xe3:`o\x86a<\x83p\xb8\xc5\x8e\xdd" +
+	"V\xc4V1o\x18Y\x80\x95\xaf\xc3\xf5=\xaeDr" +
+	"\xea\xcd\x11!\xe8\xb4\x14\x82v\xe6\x8a\xa0\x01B\xd0\x81" +
+	"\xc3\xa3\\uem\x87\x0d\xf9W\x08%Y\x12\xd5$" +
+	"\xb7\xf4\xcc\x0e\xf4\xde\\[\xd146\x19\xc3\xa4\xbf\x06" +
+	"\x9fl\xe8\xc6Oqt\xcd\xc4\x02\xf7\x19w\xa8\xa9U" +
+	"\xb6\x867K\xb1\x9e\xf3X,=Y\xa4\x8e&\x04\xc4" +
+	"\xd4\x98\xc4\xe9u\x829\xf7:\xe4{\x9d\"*\x0fp" +
+	"\xbd\xce:\xda\xbeVD\xe5\x9f\xb2\xcf~\xfa\xb4X\x98" +
+	"\x80\xeb\xf2\xeeh'3n\xe3p1\xc2\x9e\xaa\x8d," +
+	"\x1d\\QUC8\xdd*\xa2r\xc7_\xac\xdd\x1bN" +
+	"\x1e\x1caS\xe7\x1dzh9 \x08\x86tykp" +
+	"\xe9\xbc\xa3\x0c\xa8\x83r\x98\x92\xbaA\xe0\xa0\x05\x97\xab" +
+	"\x16\xb8y\xc9\x99t\xad\xe7&]\xed\xd1\x88\x163\xe7" +
+	"\xcf\xa5z\xc26t\xa4#\x16\xd7\xb5\xa0\x86)\x1d2" +
+	"+\x8d\xac\xe5\x19\xafy\xea\x09\xc2\xad\xad\xb6f\x1d\xef" +
+	"\x8dd|\x96\xcd\xa0|\xfb\xa9kfz\xfb\xc9\xc3\xc9" +
+	"\xe0\xedgJ\x09\xb2f\xaa\x86L\x93_\x1f8\xb0\x1c" +
+	"\x9e\xb7\xfd\xcd\xfa\x9f!A\xd9\xc8\x09\xfcs\x07e\xcf" +
+	"\x10\x0c\xc1i\xe4\x9d?@A\xfb%Sz$h7" +
+	"\xf2\xf6\xdb1\xdao\xc4\xd2\x9aM H\xbd\xd4\xb0\xda" +
+	"\x0f\xcfh\xbf\xfcK]\xbb\xad\xc1\xb4\xe8\xbcP\xa2\xfd" +
+	"\xaa'-\xdfd\x0d\xa6=\xce\xdf\xf7\xa0\xfdw\x01R" +
+	"K\x90\x0d\xa6\x9dF\x9e5\xb3n[o\x0b\xcd\xda_" +
+	";\x1f\xa3m,\x1f\xa9\xd3\x80I#+\xb9]\xc2\x03" +
+	"\x9aC\xf4\xc7Y\x072\x97\xe9H\xaf\xf45\xc7\xaaG" +
+	"\xb2y\xe0\x97\xf7\x9c\x93\xa58\xe5\xbb\x9cl\xa8t\x05" +
+	"\x18\x9c\xcd\xc3G\xccn\xe8\x80\xb2\x11j\x04\xc3\xb5\x94" +
+	"\xa4\xfc{T\x9b\xfb\x1e\xe5\xa4\xd4\x1dM\xfc\x83T*" +
+	"\xa5>5\x89\x7f\x90J\x95\x1a\xbb\x82\xee\xdbS\xea\xcd" +
+	"V\xdaO\xba?'\xa2rX@)\x0f\xad\xf7\xa8\x83" +
+	"\xb4\xf8\x92\x88\xcaOF6f\xc8R\xc6$\xc3\xdd\xba" +
+	"\xca0\x00 \xf3\x19\x85[\x1bN\x03\xfb\xe5?Kr" +
+	"\x09\xd7\xee\xf4/\x9b\xdc\xed\xbf\xf7\xb2R\xfb\xff\x07\x00" +
+	"\x00\xff\xffN\x19\x83\x1b"
 
 func init() {
 	schemas.Register(schema_f1bd301f7c12caab,
@@ -5166,28 +6763,38 @@ func init() {
 		0x843f3755e887cec2,
 		0x88f56e7efe394a20,
 		0x8a3402043f3ec9f0,
+		0x929d94d1bcc80d89,
 		0x934ac037c7063be0,
+		0x94804b0564859b02,
 		0x94f59b819a6e7ce3,
 		0x95158665d71f5337,
 		0x9c3ac18a6f855cd2,
 		0x9d62a769e5dd0281,
 		0x9d6b3c5af51f3915,
 		0x9f1384ed24dccf37,
+		0xa1327f13770c1363,
 		0xa6fcb2009f6f5277,
 		0xa9ea20731d3aa7a9,
 		0xaa066f541f1a116a,
+		0xab3daf6000a44ed3,
 		0xadd9881ba4754f20,
 		0xaeedfb5c318d00ee,
 		0xb1731fa2fac2190d,
 		0xb3a20cba1aabab09,
 		0xb400b2d098c85f04,
+		0xb5098a3f460af4ed,
+		0xb6891805bc3ca7ee,
 		0xb698c1f0b94a78fb,
+		0xb6f7e1a0e97a969f,
 		0xbaf76beaa0eaef07,
 		0xbbd75d212e1f85e7,
 		0xbdeae8c7974b47f8,
+		0xc27fbcfe703c0635,
 		0xc3ef318bca0bb7b4,
 		0xc68e1d3ad2dcac35,
 		0xc6fd08f6df519d73,
+		0xc751ee2c1d465840,
+		0xc795ab8e17825f88,
 		0xc986f64c6c14ca4f,
 		0xcc69b73148363436,
 		0xcf1afe8826feb5a0,
@@ -5195,6 +6802,9 @@ func init() {
 		0xd04e1143cc533067,
 		0xd2778faa7ff8eb3f,
 		0xd3899668953eaf95,
+		0xe0ba99ed8f701229,
+		0xe41e2a23bd2dec8a,
+		0xe4275f9fec5abef6,
 		0xe46ac295853f5a28,
 		0xe610c5eade193517,
 		0xe77e737e0f852702,
@@ -5209,6 +6819,7 @@ func init() {
 		0xf51b4d58d6c7f245,
 		0xf71ceab5f8e294bd,
 		0xf9e91c3361bc207d,
+		0xfa5aa37101cf4521,
 		0xfc5dac1667b5feb1,
 		0xffd4b7abb0abe3b4)
 }
@@ -14,18 +14,336 @@ import (
 
 // Constants defined in PubSub.capnp.
 const (
-	PubsubServiceName    = "pubsub"
-	CapNameDevicePubSub  = "capDevicePubSub"
-	CapNameServicePubSub = "capServicePubSub"
-	CapNameUserPubSub    = "capUserPubSub"
-	ThingsPrefix         = "things"
-	MessageTypeAction    = "action"
-	MessageTypeEvent     = "event"
-	EventNameProperties  = "properties"
-	EventNameTD          = "td"
-	ActionNameConfig     = "configuration"
+	PubsubServiceName     = "pubsub"
+	CapNameDevicePubSub   = "capDevicePubSub"
+	CapNameServicePubSub  = "capServicePubSub"
+	CapNameUserPubSub     = "capUserPubSub"
+	ThingsPrefix          = "things"
+	MessageTypeAction     = "action"
+	MessageTypeEvent      = "event"
+	EventNameProperties   = "properties"
+	EventNameTD           = "td"
+	ActionNameConfig      = "configuration"
+	ActionStatusPending   = "pending"
+	ActionStatusDelivered = "delivered"
+	ActionStatusCompleted = "completed"
+	ActionStatusFailed    = "failed"
 )
 
+type ActionStatus capnp.Struct
+
+// ActionStatus_TypeID is the unique identifier for the type ActionStatus.
+const ActionStatus_TypeID = 0xef317bd3400242db
+
+func NewActionStatus(s *capnp.Segment) (ActionStatus, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 6})
+	return ActionStatus(st), err
+}
+
+func NewRootActionStatus(s *capnp.Segment) (ActionStatus, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 6})
+	return ActionStatus(st), err
+}
+
+func ReadRootActionStatus(msg *capnp.Message) (ActionStatus, error) {
+	root, err := msg.Root()
+	return ActionStatus(root.Struct()), err
+}
+
+func (s ActionStatus) String() string {
+	str, _ := text.Marshal(0xef317bd3400242db, capnp.Struct(s))
+	return str
+}
+
+func (s ActionStatus) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (ActionStatus) DecodeFromPtr(p capnp.Ptr) ActionStatus {
+	return ActionStatus(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s ActionStatus) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s ActionStatus) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s ActionStatus) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s ActionStatus) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s ActionStatus) RequestID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s ActionStatus) HasRequestID() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s ActionStatus) RequestIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s ActionStatus) SetRequestID(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s ActionStatus) PublisherID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s ActionStatus) HasPublisherID() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s ActionStatus) PublisherIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s ActionStatus) SetPublisherID(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s ActionStatus) ThingID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s ActionStatus) HasThingID() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s ActionStatus) ThingIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s ActionStatus) SetThingID(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s ActionStatus) ActionID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.Text(), err
+}
+
+func (s ActionStatus) HasActionID() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s ActionStatus) ActionIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s ActionStatus) SetActionID(v string) error {
+	return capnp.Struct(s).SetText(3, v)
+}
+
+func (s ActionStatus) Status() (string, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return p.Text(), err
+}
+
+func (s ActionStatus) HasStatus() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+func (s ActionStatus) StatusBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s ActionStatus) SetStatus(v string) error {
+	return capnp.Struct(s).SetText(4, v)
+}
+
+func (s ActionStatus) Error() (string, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return p.Text(), err
+}
+
+func (s ActionStatus) HasError() bool {
+	return capnp.Struct(s).HasPtr(5)
+}
+
+func (s ActionStatus) ErrorBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s ActionStatus) SetError(v string) error {
+	return capnp.Struct(s).SetText(5, v)
+}
+
+// ActionStatus_List is a list of ActionStatus.
+type ActionStatus_List = capnp.StructList[ActionStatus]
+
+// NewActionStatus creates a new list of ActionStatus.
+func NewActionStatus_List(s *capnp.Segment, sz int32) (ActionStatus_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 6}, sz)
+	return capnp.StructList[ActionStatus](l), err
+}
+
+// ActionStatus_Future is a wrapper for a ActionStatus promised by a client call.
+type ActionStatus_Future struct{ *capnp.Future }
+
+func (f ActionStatus_Future) Struct() (ActionStatus, error) {
+	p, err := f.Future.Ptr()
+	return ActionStatus(p.Struct()), err
+}
+
+type SubscriptionStats capnp.Struct
+
+// SubscriptionStats_TypeID is the unique identifier for the type SubscriptionStats.
+const SubscriptionStats_TypeID = 0xd7cdc69e66967850
+
+func NewSubscriptionStats(s *capnp.Segment) (SubscriptionStats, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return SubscriptionStats(st), err
+}
+
+func NewRootSubscriptionStats(s *capnp.Segment) (SubscriptionStats, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return SubscriptionStats(st), err
+}
+
+func ReadRootSubscriptionStats(msg *capnp.Message) (SubscriptionStats, error) {
+	root, err := msg.Root()
+	return SubscriptionStats(root.Struct()), err
+}
+
+func (s SubscriptionStats) String() string {
+	str, _ := text.Marshal(0xd7cdc69e66967850, capnp.Struct(s))
+	return str
+}
+
+func (s SubscriptionStats) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (SubscriptionStats) DecodeFromPtr(p capnp.Ptr) SubscriptionStats {
+	return SubscriptionStats(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s SubscriptionStats) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s SubscriptionStats) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s SubscriptionStats) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s SubscriptionStats) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s SubscriptionStats) SubscriptionID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s SubscriptionStats) HasSubscriptionID() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s SubscriptionStats) SubscriptionIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SubscriptionStats) SetSubscriptionID(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s SubscriptionStats) SubscriberID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s SubscriptionStats) HasSubscriberID() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s SubscriptionStats) SubscriberIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SubscriptionStats) SetSubscriberID(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s SubscriptionStats) Topic() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s SubscriptionStats) HasTopic() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s SubscriptionStats) TopicBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s SubscriptionStats) SetTopic(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s SubscriptionStats) Pending() int64 {
+	return int64(capnp.Struct(s).Uint64(0))
+}
+
+func (s SubscriptionStats) SetPending(v int64) {
+	capnp.Struct(s).SetUint64(0, uint64(v))
+}
+
+func (s SubscriptionStats) Delivered() uint64 {
+	return capnp.Struct(s).Uint64(8)
+}
+
+func (s SubscriptionStats) SetDelivered(v uint64) {
+	capnp.Struct(s).SetUint64(8, v)
+}
+
+func (s SubscriptionStats) Dropped() uint64 {
+	return capnp.Struct(s).Uint64(16)
+}
+
+func (s SubscriptionStats) SetDropped(v uint64) {
+	capnp.Struct(s).SetUint64(16, v)
+}
+
+// SubscriptionStats_List is a list of SubscriptionStats.
+type SubscriptionStats_List = capnp.StructList[SubscriptionStats]
+
+// NewSubscriptionStats creates a new list of SubscriptionStats.
+func NewSubscriptionStats_List(s *capnp.Segment, sz int32) (SubscriptionStats_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3}, sz)
+	return capnp.StructList[SubscriptionStats](l), err
+}
+
+// SubscriptionStats_Future is a wrapper for a SubscriptionStats promised by a client call.
+type SubscriptionStats_Future struct{ *capnp.Future }
+
+func (f SubscriptionStats_Future) Struct() (SubscriptionStats, error) {
+	p, err := f.Future.Ptr()
+	return SubscriptionStats(p.Struct()), err
+}
+
 type CapPubSubService capnp.Client
 
 // CapPubSubService_TypeID is the unique identifier for the type CapPubSubService.
@@ -79,6 +397,22 @@ func (c CapPubSubService) CapUserPubSub(ctx context.Context, params func(CapPubS
 	ans, release := capnp.Client(c).SendCall(ctx, s)
 	return CapPubSubService_capUserPubSub_Results_Future{Future: ans.Future()}, release
 }
+func (c CapPubSubService) GetSubscriptionStats(ctx context.Context, params func(CapPubSubService_getSubscriptionStats_Params) error) (CapPubSubService_getSubscriptionStats_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xe4a8e3bfe7c72fdf,
+			MethodID:      3,
+			InterfaceName: "hubapi/PubSub.capnp:CapPubSubService",
+			MethodName:    "getSubscriptionStats",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 0}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapPubSubService_getSubscriptionStats_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapPubSubService_getSubscriptionStats_Results_Future{Future: ans.Future()}, release
+}
 
 // String returns a string that identifies this capability for debugging
 // purposes.  Its format should not be depended on: in particular, it
@@ -152,6 +486,8 @@ type CapPubSubService_Server interface {
 	CapServicePubSub(context.Context, CapPubSubService_capServicePubSub) error
 
 	CapUserPubSub(context.Context, CapPubSubService_capUserPubSub) error
+
+	GetSubscriptionStats(context.Context, CapPubSubService_getSubscriptionStats) error
 }
 
 // CapPubSubService_NewServer creates a new Server from an implementation of CapPubSubService_Server.
@@ -170,7 +506,7 @@ func CapPubSubService_ServerToClient(s CapPubSubService_Server) CapPubSubService
 // This can be used to create a more complicated Server.
 func CapPubSubService_Methods(methods []server.Method, s CapPubSubService_Server) []server.Method {
 	if cap(methods) == 0 {
-		methods = make([]server.Method, 0, 3)
+		methods = make([]server.Method, 0, 4)
 	}
 
 	methods = append(methods, server.Method{
@@ -209,6 +545,18 @@ func CapPubSubService_Methods(methods []server.Method, s CapPubSubService_Server
 		},
 	})
 
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xe4a8e3bfe7c72fdf,
+			MethodID:      3,
+			InterfaceName: "hubapi/PubSub.capnp:CapPubSubService",
+			MethodName:    "getSubscriptionStats",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.GetSubscriptionStats(ctx, CapPubSubService_getSubscriptionStats{call})
+		},
+	})
+
 	return methods
 }
 
@@ -263,6 +611,23 @@ func (c CapPubSubService_capUserPubSub) AllocResults() (CapPubSubService_capUser
 	return CapPubSubService_capUserPubSub_Results(r), err
 }
 
+// CapPubSubService_getSubscriptionStats holds the state for a server call to CapPubSubService.getSubscriptionStats.
+// See server.Call for documentation.
+type CapPubSubService_getSubscriptionStats struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapPubSubService_getSubscriptionStats) Args() CapPubSubService_getSubscriptionStats_Params {
+	return CapPubSubService_getSubscriptionStats_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapPubSubService_getSubscriptionStats) AllocResults() (CapPubSubService_getSubscriptionStats_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapPubSubService_getSubscriptionStats_Results(r), err
+}
+
 // CapPubSubService_List is a list of CapPubSubService.
 type CapPubSubService_List = capnp.CapList[CapPubSubService]
 
@@ -773,35 +1138,188 @@ func (p CapPubSubService_capUserPubSub_Results_Future) Cap() CapUserPubSub {
 	return CapUserPubSub(p.Future.Field(0, nil).Client())
 }
 
-type CapDevicePubSub capnp.Client
+type CapPubSubService_getSubscriptionStats_Params capnp.Struct
 
-// CapDevicePubSub_TypeID is the unique identifier for the type CapDevicePubSub.
-const CapDevicePubSub_TypeID = 0xdfb8a690e8697e4a
+// CapPubSubService_getSubscriptionStats_Params_TypeID is the unique identifier for the type CapPubSubService_getSubscriptionStats_Params.
+const CapPubSubService_getSubscriptionStats_Params_TypeID = 0xed10aa3b59cbdceb
 
-func (c CapDevicePubSub) PubEvent(ctx context.Context, params func(CapDevicePubSub_pubEvent_Params) error) (CapDevicePubSub_pubEvent_Results_Future, capnp.ReleaseFunc) {
-	s := capnp.Send{
-		Method: capnp.Method{
-			InterfaceID:   0xdfb8a690e8697e4a,
-			MethodID:      0,
-			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
-			MethodName:    "pubEvent",
-		},
-	}
-	if params != nil {
-		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
-		s.PlaceArgs = func(s capnp.Struct) error { return params(CapDevicePubSub_pubEvent_Params(s)) }
-	}
-	ans, release := capnp.Client(c).SendCall(ctx, s)
-	return CapDevicePubSub_pubEvent_Results_Future{Future: ans.Future()}, release
+func NewCapPubSubService_getSubscriptionStats_Params(s *capnp.Segment) (CapPubSubService_getSubscriptionStats_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapPubSubService_getSubscriptionStats_Params(st), err
 }
-func (c CapDevicePubSub) SubAction(ctx context.Context, params func(CapDevicePubSub_subAction_Params) error) (CapDevicePubSub_subAction_Results_Future, capnp.ReleaseFunc) {
-	s := capnp.Send{
-		Method: capnp.Method{
-			InterfaceID:   0xdfb8a690e8697e4a,
-			MethodID:      1,
-			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
-			MethodName:    "subAction",
-		},
+
+func NewRootCapPubSubService_getSubscriptionStats_Params(s *capnp.Segment) (CapPubSubService_getSubscriptionStats_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapPubSubService_getSubscriptionStats_Params(st), err
+}
+
+func ReadRootCapPubSubService_getSubscriptionStats_Params(msg *capnp.Message) (CapPubSubService_getSubscriptionStats_Params, error) {
+	root, err := msg.Root()
+	return CapPubSubService_getSubscriptionStats_Params(root.Struct()), err
+}
+
+func (s CapPubSubService_getSubscriptionStats_Params) String() string {
+	str, _ := text.Marshal(0xed10aa3b59cbdceb, capnp.Struct(s))
+	return str
+}
+
+func (s CapPubSubService_getSubscriptionStats_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapPubSubService_getSubscriptionStats_Params) DecodeFromPtr(p capnp.Ptr) CapPubSubService_getSubscriptionStats_Params {
+	return CapPubSubService_getSubscriptionStats_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapPubSubService_getSubscriptionStats_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapPubSubService_getSubscriptionStats_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapPubSubService_getSubscriptionStats_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapPubSubService_getSubscriptionStats_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapPubSubService_getSubscriptionStats_Params_List is a list of CapPubSubService_getSubscriptionStats_Params.
+type CapPubSubService_getSubscriptionStats_Params_List = capnp.StructList[CapPubSubService_getSubscriptionStats_Params]
+
+// NewCapPubSubService_getSubscriptionStats_Params creates a new list of CapPubSubService_getSubscriptionStats_Params.
+func NewCapPubSubService_getSubscriptionStats_Params_List(s *capnp.Segment, sz int32) (CapPubSubService_getSubscriptionStats_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapPubSubService_getSubscriptionStats_Params](l), err
+}
+
+// CapPubSubService_getSubscriptionStats_Params_Future is a wrapper for a CapPubSubService_getSubscriptionStats_Params promised by a client call.
+type CapPubSubService_getSubscriptionStats_Params_Future struct{ *capnp.Future }
+
+func (f CapPubSubService_getSubscriptionStats_Params_Future) Struct() (CapPubSubService_getSubscriptionStats_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapPubSubService_getSubscriptionStats_Params(p.Struct()), err
+}
+
+type CapPubSubService_getSubscriptionStats_Results capnp.Struct
+
+// CapPubSubService_getSubscriptionStats_Results_TypeID is the unique identifier for the type CapPubSubService_getSubscriptionStats_Results.
+const CapPubSubService_getSubscriptionStats_Results_TypeID = 0xef9ab0dc345bd87b
+
+func NewCapPubSubService_getSubscriptionStats_Results(s *capnp.Segment) (CapPubSubService_getSubscriptionStats_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapPubSubService_getSubscriptionStats_Results(st), err
+}
+
+func NewRootCapPubSubService_getSubscriptionStats_Results(s *capnp.Segment) (CapPubSubService_getSubscriptionStats_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapPubSubService_getSubscriptionStats_Results(st), err
+}
+
+func ReadRootCapPubSubService_getSubscriptionStats_Results(msg *capnp.Message) (CapPubSubService_getSubscriptionStats_Results, error) {
+	root, err := msg.Root()
+	return CapPubSubService_getSubscriptionStats_Results(root.Struct()), err
+}
+
+func (s CapPubSubService_getSubscriptionStats_Results) String() string {
+	str, _ := text.Marshal(0xef9ab0dc345bd87b, capnp.Struct(s))
+	return str
+}
+
+func (s CapPubSubService_getSubscriptionStats_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapPubSubService_getSubscriptionStats_Results) DecodeFromPtr(p capnp.Ptr) CapPubSubService_getSubscriptionStats_Results {
+	return CapPubSubService_getSubscriptionStats_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapPubSubService_getSubscriptionStats_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapPubSubService_getSubscriptionStats_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapPubSubService_getSubscriptionStats_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapPubSubService_getSubscriptionStats_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapPubSubService_getSubscriptionStats_Results) Stats() (SubscriptionStats_List, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return SubscriptionStats_List(p.List()), err
+}
+
+func (s CapPubSubService_getSubscriptionStats_Results) HasStats() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapPubSubService_getSubscriptionStats_Results) SetStats(v SubscriptionStats_List) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewStats sets the stats field to a newly
+// allocated SubscriptionStats_List, preferring placement in s's segment.
+func (s CapPubSubService_getSubscriptionStats_Results) NewStats(n int32) (SubscriptionStats_List, error) {
+	l, err := NewSubscriptionStats_List(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return SubscriptionStats_List{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+
+// CapPubSubService_getSubscriptionStats_Results_List is a list of CapPubSubService_getSubscriptionStats_Results.
+type CapPubSubService_getSubscriptionStats_Results_List = capnp.StructList[CapPubSubService_getSubscriptionStats_Results]
+
+// NewCapPubSubService_getSubscriptionStats_Results creates a new list of CapPubSubService_getSubscriptionStats_Results.
+func NewCapPubSubService_getSubscriptionStats_Results_List(s *capnp.Segment, sz int32) (CapPubSubService_getSubscriptionStats_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapPubSubService_getSubscriptionStats_Results](l), err
+}
+
+// CapPubSubService_getSubscriptionStats_Results_Future is a wrapper for a CapPubSubService_getSubscriptionStats_Results promised by a client call.
+type CapPubSubService_getSubscriptionStats_Results_Future struct{ *capnp.Future }
+
+func (f CapPubSubService_getSubscriptionStats_Results_Future) Struct() (CapPubSubService_getSubscriptionStats_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapPubSubService_getSubscriptionStats_Results(p.Struct()), err
+}
+
+type CapDevicePubSub capnp.Client
+
+// CapDevicePubSub_TypeID is the unique identifier for the type CapDevicePubSub.
+const CapDevicePubSub_TypeID = 0xdfb8a690e8697e4a
+
+func (c CapDevicePubSub) PubEvent(ctx context.Context, params func(CapDevicePubSub_pubEvent_Params) error) (CapDevicePubSub_pubEvent_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xdfb8a690e8697e4a,
+			MethodID:      0,
+			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
+			MethodName:    "pubEvent",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapDevicePubSub_pubEvent_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapDevicePubSub_pubEvent_Results_Future{Future: ans.Future()}, release
+}
+func (c CapDevicePubSub) SubAction(ctx context.Context, params func(CapDevicePubSub_subAction_Params) error) (CapDevicePubSub_subAction_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xdfb8a690e8697e4a,
+			MethodID:      1,
+			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
+			MethodName:    "subAction",
+		},
 	}
 	if params != nil {
 		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
@@ -810,6 +1328,22 @@ func (c CapDevicePubSub) SubAction(ctx context.Context, params func(CapDevicePub
 	ans, release := capnp.Client(c).SendCall(ctx, s)
 	return CapDevicePubSub_subAction_Results_Future{Future: ans.Future()}, release
 }
+func (c CapDevicePubSub) PubActionStatus(ctx context.Context, params func(CapDevicePubSub_pubActionStatus_Params) error) (CapDevicePubSub_pubActionStatus_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xdfb8a690e8697e4a,
+			MethodID:      2,
+			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
+			MethodName:    "pubActionStatus",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapDevicePubSub_pubActionStatus_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapDevicePubSub_pubActionStatus_Results_Future{Future: ans.Future()}, release
+}
 
 // String returns a string that identifies this capability for debugging
 // purposes.  Its format should not be depended on: in particular, it
@@ -881,6 +1415,8 @@ type CapDevicePubSub_Server interface {
 	PubEvent(context.Context, CapDevicePubSub_pubEvent) error
 
 	SubAction(context.Context, CapDevicePubSub_subAction) error
+
+	PubActionStatus(context.Context, CapDevicePubSub_pubActionStatus) error
 }
 
 // CapDevicePubSub_NewServer creates a new Server from an implementation of CapDevicePubSub_Server.
@@ -899,7 +1435,7 @@ func CapDevicePubSub_ServerToClient(s CapDevicePubSub_Server) CapDevicePubSub {
 // This can be used to create a more complicated Server.
 func CapDevicePubSub_Methods(methods []server.Method, s CapDevicePubSub_Server) []server.Method {
 	if cap(methods) == 0 {
-		methods = make([]server.Method, 0, 2)
+		methods = make([]server.Method, 0, 3)
 	}
 
 	methods = append(methods, server.Method{
@@ -926,6 +1462,18 @@ func CapDevicePubSub_Methods(methods []server.Method, s CapDevicePubSub_Server)
 		},
 	})
 
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xdfb8a690e8697e4a,
+			MethodID:      2,
+			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
+			MethodName:    "pubActionStatus",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.PubActionStatus(ctx, CapDevicePubSub_pubActionStatus{call})
+		},
+	})
+
 	return methods
 }
 
@@ -963,6 +1511,23 @@ func (c CapDevicePubSub_subAction) AllocResults() (CapDevicePubSub_subAction_Res
 	return CapDevicePubSub_subAction_Results(r), err
 }
 
+// CapDevicePubSub_pubActionStatus holds the state for a server call to CapDevicePubSub.pubActionStatus.
+// See server.Call for documentation.
+type CapDevicePubSub_pubActionStatus struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapDevicePubSub_pubActionStatus) Args() CapDevicePubSub_pubActionStatus_Params {
+	return CapDevicePubSub_pubActionStatus_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapDevicePubSub_pubActionStatus) AllocResults() (CapDevicePubSub_pubActionStatus_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapDevicePubSub_pubActionStatus_Results(r), err
+}
+
 // CapDevicePubSub_List is a list of CapDevicePubSub.
 type CapDevicePubSub_List = capnp.CapList[CapDevicePubSub]
 
@@ -1336,114 +1901,345 @@ func (f CapDevicePubSub_subAction_Results_Future) Struct() (CapDevicePubSub_subA
 	return CapDevicePubSub_subAction_Results(p.Struct()), err
 }
 
-type CapServicePubSub capnp.Client
+type CapDevicePubSub_pubActionStatus_Params capnp.Struct
 
-// CapServicePubSub_TypeID is the unique identifier for the type CapServicePubSub.
-const CapServicePubSub_TypeID = 0xf9bfff17720dccba
+// CapDevicePubSub_pubActionStatus_Params_TypeID is the unique identifier for the type CapDevicePubSub_pubActionStatus_Params.
+const CapDevicePubSub_pubActionStatus_Params_TypeID = 0xf42f5607f8b83318
 
-func (c CapServicePubSub) SubActions(ctx context.Context, params func(CapServicePubSub_subActions_Params) error) (CapServicePubSub_subActions_Results_Future, capnp.ReleaseFunc) {
-	s := capnp.Send{
-		Method: capnp.Method{
-			InterfaceID:   0xf9bfff17720dccba,
-			MethodID:      0,
-			InterfaceName: "hubapi/PubSub.capnp:CapServicePubSub",
-			MethodName:    "subActions",
-		},
-	}
-	if params != nil {
-		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 4}
-		s.PlaceArgs = func(s capnp.Struct) error { return params(CapServicePubSub_subActions_Params(s)) }
-	}
-	ans, release := capnp.Client(c).SendCall(ctx, s)
-	return CapServicePubSub_subActions_Results_Future{Future: ans.Future()}, release
-}
-func (c CapServicePubSub) SubEvents(ctx context.Context, params func(CapServicePubSub_subEvents_Params) error) (CapServicePubSub_subEvents_Results_Future, capnp.ReleaseFunc) {
-	s := capnp.Send{
-		Method: capnp.Method{
-			InterfaceID:   0xf9bfff17720dccba,
-			MethodID:      1,
-			InterfaceName: "hubapi/PubSub.capnp:CapServicePubSub",
-			MethodName:    "subEvents",
-		},
-	}
-	if params != nil {
-		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 4}
-		s.PlaceArgs = func(s capnp.Struct) error { return params(CapServicePubSub_subEvents_Params(s)) }
-	}
-	ans, release := capnp.Client(c).SendCall(ctx, s)
-	return CapServicePubSub_subEvents_Results_Future{Future: ans.Future()}, release
+func NewCapDevicePubSub_pubActionStatus_Params(s *capnp.Segment) (CapDevicePubSub_pubActionStatus_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return CapDevicePubSub_pubActionStatus_Params(st), err
 }
-func (c CapServicePubSub) PubEvent(ctx context.Context, params func(CapDevicePubSub_pubEvent_Params) error) (CapDevicePubSub_pubEvent_Results_Future, capnp.ReleaseFunc) {
-	s := capnp.Send{
-		Method: capnp.Method{
-			InterfaceID:   0xdfb8a690e8697e4a,
-			MethodID:      0,
-			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
-			MethodName:    "pubEvent",
-		},
-	}
-	if params != nil {
-		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
-		s.PlaceArgs = func(s capnp.Struct) error { return params(CapDevicePubSub_pubEvent_Params(s)) }
-	}
-	ans, release := capnp.Client(c).SendCall(ctx, s)
-	return CapDevicePubSub_pubEvent_Results_Future{Future: ans.Future()}, release
+
+func NewRootCapDevicePubSub_pubActionStatus_Params(s *capnp.Segment) (CapDevicePubSub_pubActionStatus_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return CapDevicePubSub_pubActionStatus_Params(st), err
 }
-func (c CapServicePubSub) SubAction(ctx context.Context, params func(CapDevicePubSub_subAction_Params) error) (CapDevicePubSub_subAction_Results_Future, capnp.ReleaseFunc) {
-	s := capnp.Send{
-		Method: capnp.Method{
-			InterfaceID:   0xdfb8a690e8697e4a,
-			MethodID:      1,
-			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
-			MethodName:    "subAction",
-		},
-	}
-	if params != nil {
-		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
-		s.PlaceArgs = func(s capnp.Struct) error { return params(CapDevicePubSub_subAction_Params(s)) }
-	}
-	ans, release := capnp.Client(c).SendCall(ctx, s)
-	return CapDevicePubSub_subAction_Results_Future{Future: ans.Future()}, release
+
+func ReadRootCapDevicePubSub_pubActionStatus_Params(msg *capnp.Message) (CapDevicePubSub_pubActionStatus_Params, error) {
+	root, err := msg.Root()
+	return CapDevicePubSub_pubActionStatus_Params(root.Struct()), err
 }
-func (c CapServicePubSub) PubAction(ctx context.Context, params func(CapUserPubSub_pubAction_Params) error) (CapUserPubSub_pubAction_Results_Future, capnp.ReleaseFunc) {
-	s := capnp.Send{
-		Method: capnp.Method{
-			InterfaceID:   0xad556cb9c8905a7b,
-			MethodID:      0,
-			InterfaceName: "hubapi/PubSub.capnp:CapUserPubSub",
-			MethodName:    "pubAction",
-		},
-	}
-	if params != nil {
-		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 4}
-		s.PlaceArgs = func(s capnp.Struct) error { return params(CapUserPubSub_pubAction_Params(s)) }
-	}
-	ans, release := capnp.Client(c).SendCall(ctx, s)
-	return CapUserPubSub_pubAction_Results_Future{Future: ans.Future()}, release
+
+func (s CapDevicePubSub_pubActionStatus_Params) String() string {
+	str, _ := text.Marshal(0xf42f5607f8b83318, capnp.Struct(s))
+	return str
 }
-func (c CapServicePubSub) SubEvent(ctx context.Context, params func(CapUserPubSub_subEvent_Params) error) (CapUserPubSub_subEvent_Results_Future, capnp.ReleaseFunc) {
-	s := capnp.Send{
-		Method: capnp.Method{
-			InterfaceID:   0xad556cb9c8905a7b,
-			MethodID:      1,
-			InterfaceName: "hubapi/PubSub.capnp:CapUserPubSub",
-			MethodName:    "subEvent",
-		},
-	}
-	if params != nil {
-		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 4}
-		s.PlaceArgs = func(s capnp.Struct) error { return params(CapUserPubSub_subEvent_Params(s)) }
-	}
-	ans, release := capnp.Client(c).SendCall(ctx, s)
-	return CapUserPubSub_subEvent_Results_Future{Future: ans.Future()}, release
+
+func (s CapDevicePubSub_pubActionStatus_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
 }
 
-// String returns a string that identifies this capability for debugging
-// purposes.  Its format should not be depended on: in particular, it
-// should not be used to compare clients.  Use IsSame to compare clients
-// for equality.
-func (c CapServicePubSub) String() string {
-	return fmt.Sprintf("%T(%v)", c, capnp.Client(c))
+func (CapDevicePubSub_pubActionStatus_Params) DecodeFromPtr(p capnp.Ptr) CapDevicePubSub_pubActionStatus_Params {
+	return CapDevicePubSub_pubActionStatus_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapDevicePubSub_pubActionStatus_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapDevicePubSub_pubActionStatus_Params) RequestID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) HasRequestID() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) RequestIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) SetRequestID(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) Status() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) HasStatus() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) StatusBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) SetStatus(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) Error() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) HasError() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) ErrorBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s CapDevicePubSub_pubActionStatus_Params) SetError(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+// CapDevicePubSub_pubActionStatus_Params_List is a list of CapDevicePubSub_pubActionStatus_Params.
+type CapDevicePubSub_pubActionStatus_Params_List = capnp.StructList[CapDevicePubSub_pubActionStatus_Params]
+
+// NewCapDevicePubSub_pubActionStatus_Params creates a new list of CapDevicePubSub_pubActionStatus_Params.
+func NewCapDevicePubSub_pubActionStatus_Params_List(s *capnp.Segment, sz int32) (CapDevicePubSub_pubActionStatus_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return capnp.StructList[CapDevicePubSub_pubActionStatus_Params](l), err
+}
+
+// CapDevicePubSub_pubActionStatus_Params_Future is a wrapper for a CapDevicePubSub_pubActionStatus_Params promised by a client call.
+type CapDevicePubSub_pubActionStatus_Params_Future struct{ *capnp.Future }
+
+func (f CapDevicePubSub_pubActionStatus_Params_Future) Struct() (CapDevicePubSub_pubActionStatus_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapDevicePubSub_pubActionStatus_Params(p.Struct()), err
+}
+
+type CapDevicePubSub_pubActionStatus_Results capnp.Struct
+
+// CapDevicePubSub_pubActionStatus_Results_TypeID is the unique identifier for the type CapDevicePubSub_pubActionStatus_Results.
+const CapDevicePubSub_pubActionStatus_Results_TypeID = 0x8a5bb4a2615ad89f
+
+func NewCapDevicePubSub_pubActionStatus_Results(s *capnp.Segment) (CapDevicePubSub_pubActionStatus_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapDevicePubSub_pubActionStatus_Results(st), err
+}
+
+func NewRootCapDevicePubSub_pubActionStatus_Results(s *capnp.Segment) (CapDevicePubSub_pubActionStatus_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapDevicePubSub_pubActionStatus_Results(st), err
+}
+
+func ReadRootCapDevicePubSub_pubActionStatus_Results(msg *capnp.Message) (CapDevicePubSub_pubActionStatus_Results, error) {
+	root, err := msg.Root()
+	return CapDevicePubSub_pubActionStatus_Results(root.Struct()), err
+}
+
+func (s CapDevicePubSub_pubActionStatus_Results) String() string {
+	str, _ := text.Marshal(0x8a5bb4a2615ad89f, capnp.Struct(s))
+	return str
+}
+
+func (s CapDevicePubSub_pubActionStatus_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapDevicePubSub_pubActionStatus_Results) DecodeFromPtr(p capnp.Ptr) CapDevicePubSub_pubActionStatus_Results {
+	return CapDevicePubSub_pubActionStatus_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapDevicePubSub_pubActionStatus_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapDevicePubSub_pubActionStatus_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapDevicePubSub_pubActionStatus_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapDevicePubSub_pubActionStatus_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapDevicePubSub_pubActionStatus_Results_List is a list of CapDevicePubSub_pubActionStatus_Results.
+type CapDevicePubSub_pubActionStatus_Results_List = capnp.StructList[CapDevicePubSub_pubActionStatus_Results]
+
+// NewCapDevicePubSub_pubActionStatus_Results creates a new list of CapDevicePubSub_pubActionStatus_Results.
+func NewCapDevicePubSub_pubActionStatus_Results_List(s *capnp.Segment, sz int32) (CapDevicePubSub_pubActionStatus_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapDevicePubSub_pubActionStatus_Results](l), err
+}
+
+// CapDevicePubSub_pubActionStatus_Results_Future is a wrapper for a CapDevicePubSub_pubActionStatus_Results promised by a client call.
+type CapDevicePubSub_pubActionStatus_Results_Future struct{ *capnp.Future }
+
+func (f CapDevicePubSub_pubActionStatus_Results_Future) Struct() (CapDevicePubSub_pubActionStatus_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapDevicePubSub_pubActionStatus_Results(p.Struct()), err
+}
+
+type CapServicePubSub capnp.Client
+
+// CapServicePubSub_TypeID is the unique identifier for the type CapServicePubSub.
+const CapServicePubSub_TypeID = 0xf9bfff17720dccba
+
+func (c CapServicePubSub) SubActions(ctx context.Context, params func(CapServicePubSub_subActions_Params) error) (CapServicePubSub_subActions_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xf9bfff17720dccba,
+			MethodID:      0,
+			InterfaceName: "hubapi/PubSub.capnp:CapServicePubSub",
+			MethodName:    "subActions",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 4}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapServicePubSub_subActions_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapServicePubSub_subActions_Results_Future{Future: ans.Future()}, release
+}
+func (c CapServicePubSub) SubEvents(ctx context.Context, params func(CapServicePubSub_subEvents_Params) error) (CapServicePubSub_subEvents_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xf9bfff17720dccba,
+			MethodID:      1,
+			InterfaceName: "hubapi/PubSub.capnp:CapServicePubSub",
+			MethodName:    "subEvents",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 4}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapServicePubSub_subEvents_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapServicePubSub_subEvents_Results_Future{Future: ans.Future()}, release
+}
+func (c CapServicePubSub) ClearRetained(ctx context.Context, params func(CapServicePubSub_clearRetained_Params) error) (CapServicePubSub_clearRetained_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xf9bfff17720dccba,
+			MethodID:      2,
+			InterfaceName: "hubapi/PubSub.capnp:CapServicePubSub",
+			MethodName:    "clearRetained",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapServicePubSub_clearRetained_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapServicePubSub_clearRetained_Results_Future{Future: ans.Future()}, release
+}
+func (c CapServicePubSub) PubEvent(ctx context.Context, params func(CapDevicePubSub_pubEvent_Params) error) (CapDevicePubSub_pubEvent_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xdfb8a690e8697e4a,
+			MethodID:      0,
+			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
+			MethodName:    "pubEvent",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapDevicePubSub_pubEvent_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapDevicePubSub_pubEvent_Results_Future{Future: ans.Future()}, release
+}
+func (c CapServicePubSub) SubAction(ctx context.Context, params func(CapDevicePubSub_subAction_Params) error) (CapDevicePubSub_subAction_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xdfb8a690e8697e4a,
+			MethodID:      1,
+			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
+			MethodName:    "subAction",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapDevicePubSub_subAction_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapDevicePubSub_subAction_Results_Future{Future: ans.Future()}, release
+}
+func (c CapServicePubSub) PubActionStatus(ctx context.Context, params func(CapDevicePubSub_pubActionStatus_Params) error) (CapDevicePubSub_pubActionStatus_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xdfb8a690e8697e4a,
+			MethodID:      2,
+			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
+			MethodName:    "pubActionStatus",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 3}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapDevicePubSub_pubActionStatus_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapDevicePubSub_pubActionStatus_Results_Future{Future: ans.Future()}, release
+}
+func (c CapServicePubSub) PubAction(ctx context.Context, params func(CapUserPubSub_pubAction_Params) error) (CapUserPubSub_pubAction_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xad556cb9c8905a7b,
+			MethodID:      0,
+			InterfaceName: "hubapi/PubSub.capnp:CapUserPubSub",
+			MethodName:    "pubAction",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 4}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapUserPubSub_pubAction_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapUserPubSub_pubAction_Results_Future{Future: ans.Future()}, release
+}
+func (c CapServicePubSub) SubEvent(ctx context.Context, params func(CapUserPubSub_subEvent_Params) error) (CapUserPubSub_subEvent_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xad556cb9c8905a7b,
+			MethodID:      1,
+			InterfaceName: "hubapi/PubSub.capnp:CapUserPubSub",
+			MethodName:    "subEvent",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 4}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapUserPubSub_subEvent_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapUserPubSub_subEvent_Results_Future{Future: ans.Future()}, release
+}
+func (c CapServicePubSub) PubActionRequest(ctx context.Context, params func(CapUserPubSub_pubActionRequest_Params) error) (CapUserPubSub_pubActionRequest_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xad556cb9c8905a7b,
+			MethodID:      2,
+			InterfaceName: "hubapi/PubSub.capnp:CapUserPubSub",
+			MethodName:    "pubActionRequest",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 8, PointerCount: 5}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapUserPubSub_pubActionRequest_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapUserPubSub_pubActionRequest_Results_Future{Future: ans.Future()}, release
+}
+
+// String returns a string that identifies this capability for debugging
+// purposes.  Its format should not be depended on: in particular, it
+// should not be used to compare clients.  Use IsSame to compare clients
+// for equality.
+func (c CapServicePubSub) String() string {
+	return fmt.Sprintf("%T(%v)", c, capnp.Client(c))
 }
 
 // AddRef creates a new Client that refers to the same capability as c.
@@ -1509,13 +2305,19 @@ type CapServicePubSub_Server interface {
 
 	SubEvents(context.Context, CapServicePubSub_subEvents) error
 
+	ClearRetained(context.Context, CapServicePubSub_clearRetained) error
+
 	PubEvent(context.Context, CapDevicePubSub_pubEvent) error
 
 	SubAction(context.Context, CapDevicePubSub_subAction) error
 
+	PubActionStatus(context.Context, CapDevicePubSub_pubActionStatus) error
+
 	PubAction(context.Context, CapUserPubSub_pubAction) error
 
 	SubEvent(context.Context, CapUserPubSub_subEvent) error
+
+	PubActionRequest(context.Context, CapUserPubSub_pubActionRequest) error
 }
 
 // CapServicePubSub_NewServer creates a new Server from an implementation of CapServicePubSub_Server.
@@ -1534,7 +2336,7 @@ func CapServicePubSub_ServerToClient(s CapServicePubSub_Server) CapServicePubSub
 // This can be used to create a more complicated Server.
 func CapServicePubSub_Methods(methods []server.Method, s CapServicePubSub_Server) []server.Method {
 	if cap(methods) == 0 {
-		methods = make([]server.Method, 0, 6)
+		methods = make([]server.Method, 0, 9)
 	}
 
 	methods = append(methods, server.Method{
@@ -1561,6 +2363,18 @@ func CapServicePubSub_Methods(methods []server.Method, s CapServicePubSub_Server
 		},
 	})
 
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xf9bfff17720dccba,
+			MethodID:      2,
+			InterfaceName: "hubapi/PubSub.capnp:CapServicePubSub",
+			MethodName:    "clearRetained",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.ClearRetained(ctx, CapServicePubSub_clearRetained{call})
+		},
+	})
+
 	methods = append(methods, server.Method{
 		Method: capnp.Method{
 			InterfaceID:   0xdfb8a690e8697e4a,
@@ -1585,6 +2399,18 @@ func CapServicePubSub_Methods(methods []server.Method, s CapServicePubSub_Server
 		},
 	})
 
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xdfb8a690e8697e4a,
+			MethodID:      2,
+			InterfaceName: "hubapi/PubSub.capnp:CapDevicePubSub",
+			MethodName:    "pubActionStatus",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.PubActionStatus(ctx, CapDevicePubSub_pubActionStatus{call})
+		},
+	})
+
 	methods = append(methods, server.Method{
 		Method: capnp.Method{
 			InterfaceID:   0xad556cb9c8905a7b,
@@ -1609,6 +2435,18 @@ func CapServicePubSub_Methods(methods []server.Method, s CapServicePubSub_Server
 		},
 	})
 
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xad556cb9c8905a7b,
+			MethodID:      2,
+			InterfaceName: "hubapi/PubSub.capnp:CapUserPubSub",
+			MethodName:    "pubActionRequest",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.PubActionRequest(ctx, CapUserPubSub_pubActionRequest{call})
+		},
+	})
+
 	return methods
 }
 
@@ -1646,6 +2484,23 @@ func (c CapServicePubSub_subEvents) AllocResults() (CapServicePubSub_subEvents_R
 	return CapServicePubSub_subEvents_Results(r), err
 }
 
+// CapServicePubSub_clearRetained holds the state for a server call to CapServicePubSub.clearRetained.
+// See server.Call for documentation.
+type CapServicePubSub_clearRetained struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapServicePubSub_clearRetained) Args() CapServicePubSub_clearRetained_Params {
+	return CapServicePubSub_clearRetained_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapServicePubSub_clearRetained) AllocResults() (CapServicePubSub_clearRetained_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapServicePubSub_clearRetained_Results(r), err
+}
+
 // CapServicePubSub_List is a list of CapServicePubSub.
 type CapServicePubSub_List = capnp.CapList[CapServicePubSub]
 
@@ -2063,6 +2918,189 @@ func (f CapServicePubSub_subEvents_Results_Future) Struct() (CapServicePubSub_su
 	return CapServicePubSub_subEvents_Results(p.Struct()), err
 }
 
+type CapServicePubSub_clearRetained_Params capnp.Struct
+
+// CapServicePubSub_clearRetained_Params_TypeID is the unique identifier for the type CapServicePubSub_clearRetained_Params.
+const CapServicePubSub_clearRetained_Params_TypeID = 0x8d553e4dce3e28b6
+
+func NewCapServicePubSub_clearRetained_Params(s *capnp.Segment) (CapServicePubSub_clearRetained_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return CapServicePubSub_clearRetained_Params(st), err
+}
+
+func NewRootCapServicePubSub_clearRetained_Params(s *capnp.Segment) (CapServicePubSub_clearRetained_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return CapServicePubSub_clearRetained_Params(st), err
+}
+
+func ReadRootCapServicePubSub_clearRetained_Params(msg *capnp.Message) (CapServicePubSub_clearRetained_Params, error) {
+	root, err := msg.Root()
+	return CapServicePubSub_clearRetained_Params(root.Struct()), err
+}
+
+func (s CapServicePubSub_clearRetained_Params) String() string {
+	str, _ := text.Marshal(0x8d553e4dce3e28b6, capnp.Struct(s))
+	return str
+}
+
+func (s CapServicePubSub_clearRetained_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapServicePubSub_clearRetained_Params) DecodeFromPtr(p capnp.Ptr) CapServicePubSub_clearRetained_Params {
+	return CapServicePubSub_clearRetained_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapServicePubSub_clearRetained_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapServicePubSub_clearRetained_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapServicePubSub_clearRetained_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapServicePubSub_clearRetained_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapServicePubSub_clearRetained_Params) PublisherID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapServicePubSub_clearRetained_Params) HasPublisherID() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapServicePubSub_clearRetained_Params) PublisherIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapServicePubSub_clearRetained_Params) SetPublisherID(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s CapServicePubSub_clearRetained_Params) ThingID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s CapServicePubSub_clearRetained_Params) HasThingID() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s CapServicePubSub_clearRetained_Params) ThingIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s CapServicePubSub_clearRetained_Params) SetThingID(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s CapServicePubSub_clearRetained_Params) EventID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s CapServicePubSub_clearRetained_Params) HasEventID() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s CapServicePubSub_clearRetained_Params) EventIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s CapServicePubSub_clearRetained_Params) SetEventID(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+// CapServicePubSub_clearRetained_Params_List is a list of CapServicePubSub_clearRetained_Params.
+type CapServicePubSub_clearRetained_Params_List = capnp.StructList[CapServicePubSub_clearRetained_Params]
+
+// NewCapServicePubSub_clearRetained_Params creates a new list of CapServicePubSub_clearRetained_Params.
+func NewCapServicePubSub_clearRetained_Params_List(s *capnp.Segment, sz int32) (CapServicePubSub_clearRetained_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return capnp.StructList[CapServicePubSub_clearRetained_Params](l), err
+}
+
+// CapServicePubSub_clearRetained_Params_Future is a wrapper for a CapServicePubSub_clearRetained_Params promised by a client call.
+type CapServicePubSub_clearRetained_Params_Future struct{ *capnp.Future }
+
+func (f CapServicePubSub_clearRetained_Params_Future) Struct() (CapServicePubSub_clearRetained_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapServicePubSub_clearRetained_Params(p.Struct()), err
+}
+
+type CapServicePubSub_clearRetained_Results capnp.Struct
+
+// CapServicePubSub_clearRetained_Results_TypeID is the unique identifier for the type CapServicePubSub_clearRetained_Results.
+const CapServicePubSub_clearRetained_Results_TypeID = 0xf86efd767ffc693b
+
+func NewCapServicePubSub_clearRetained_Results(s *capnp.Segment) (CapServicePubSub_clearRetained_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapServicePubSub_clearRetained_Results(st), err
+}
+
+func NewRootCapServicePubSub_clearRetained_Results(s *capnp.Segment) (CapServicePubSub_clearRetained_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapServicePubSub_clearRetained_Results(st), err
+}
+
+func ReadRootCapServicePubSub_clearRetained_Results(msg *capnp.Message) (CapServicePubSub_clearRetained_Results, error) {
+	root, err := msg.Root()
+	return CapServicePubSub_clearRetained_Results(root.Struct()), err
+}
+
+func (s CapServicePubSub_clearRetained_Results) String() string {
+	str, _ := text.Marshal(0xf86efd767ffc693b, capnp.Struct(s))
+	return str
+}
+
+func (s CapServicePubSub_clearRetained_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapServicePubSub_clearRetained_Results) DecodeFromPtr(p capnp.Ptr) CapServicePubSub_clearRetained_Results {
+	return CapServicePubSub_clearRetained_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapServicePubSub_clearRetained_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapServicePubSub_clearRetained_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapServicePubSub_clearRetained_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapServicePubSub_clearRetained_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapServicePubSub_clearRetained_Results_List is a list of CapServicePubSub_clearRetained_Results.
+type CapServicePubSub_clearRetained_Results_List = capnp.StructList[CapServicePubSub_clearRetained_Results]
+
+// NewCapServicePubSub_clearRetained_Results creates a new list of CapServicePubSub_clearRetained_Results.
+func NewCapServicePubSub_clearRetained_Results_List(s *capnp.Segment, sz int32) (CapServicePubSub_clearRetained_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapServicePubSub_clearRetained_Results](l), err
+}
+
+// CapServicePubSub_clearRetained_Results_Future is a wrapper for a CapServicePubSub_clearRetained_Results promised by a client call.
+type CapServicePubSub_clearRetained_Results_Future struct{ *capnp.Future }
+
+func (f CapServicePubSub_clearRetained_Results_Future) Struct() (CapServicePubSub_clearRetained_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapServicePubSub_clearRetained_Results(p.Struct()), err
+}
+
 type CapUserPubSub capnp.Client
 
 // CapUserPubSub_TypeID is the unique identifier for the type CapUserPubSub.
@@ -2100,6 +3138,22 @@ func (c CapUserPubSub) SubEvent(ctx context.Context, params func(CapUserPubSub_s
 	ans, release := capnp.Client(c).SendCall(ctx, s)
 	return CapUserPubSub_subEvent_Results_Future{Future: ans.Future()}, release
 }
+func (c CapUserPubSub) PubActionRequest(ctx context.Context, params func(CapUserPubSub_pubActionRequest_Params) error) (CapUserPubSub_pubActionRequest_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xad556cb9c8905a7b,
+			MethodID:      2,
+			InterfaceName: "hubapi/PubSub.capnp:CapUserPubSub",
+			MethodName:    "pubActionRequest",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 8, PointerCount: 5}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapUserPubSub_pubActionRequest_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapUserPubSub_pubActionRequest_Results_Future{Future: ans.Future()}, release
+}
 
 // String returns a string that identifies this capability for debugging
 // purposes.  Its format should not be depended on: in particular, it
@@ -2171,6 +3225,8 @@ type CapUserPubSub_Server interface {
 	PubAction(context.Context, CapUserPubSub_pubAction) error
 
 	SubEvent(context.Context, CapUserPubSub_subEvent) error
+
+	PubActionRequest(context.Context, CapUserPubSub_pubActionRequest) error
 }
 
 // CapUserPubSub_NewServer creates a new Server from an implementation of CapUserPubSub_Server.
@@ -2189,7 +3245,7 @@ func CapUserPubSub_ServerToClient(s CapUserPubSub_Server) CapUserPubSub {
 // This can be used to create a more complicated Server.
 func CapUserPubSub_Methods(methods []server.Method, s CapUserPubSub_Server) []server.Method {
 	if cap(methods) == 0 {
-		methods = make([]server.Method, 0, 2)
+		methods = make([]server.Method, 0, 3)
 	}
 
 	methods = append(methods, server.Method{
@@ -2216,6 +3272,18 @@ func CapUserPubSub_Methods(methods []server.Method, s CapUserPubSub_Server) []se
 		},
 	})
 
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xad556cb9c8905a7b,
+			MethodID:      2,
+			InterfaceName: "hubapi/PubSub.capnp:CapUserPubSub",
+			MethodName:    "pubActionRequest",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.PubActionRequest(ctx, CapUserPubSub_pubActionRequest{call})
+		},
+	})
+
 	return methods
 }
 
@@ -2253,6 +3321,23 @@ func (c CapUserPubSub_subEvent) AllocResults() (CapUserPubSub_subEvent_Results,
 	return CapUserPubSub_subEvent_Results(r), err
 }
 
+// CapUserPubSub_pubActionRequest holds the state for a server call to CapUserPubSub.pubActionRequest.
+// See server.Call for documentation.
+type CapUserPubSub_pubActionRequest struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapUserPubSub_pubActionRequest) Args() CapUserPubSub_pubActionRequest_Params {
+	return CapUserPubSub_pubActionRequest_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapUserPubSub_pubActionRequest) AllocResults() (CapUserPubSub_pubActionRequest_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapUserPubSub_pubActionRequest_Results(r), err
+}
+
 // CapUserPubSub_List is a list of CapUserPubSub.
 type CapUserPubSub_List = capnp.CapList[CapUserPubSub]
 
@@ -2662,6 +3747,565 @@ func (f CapUserPubSub_subEvent_Results_Future) Struct() (CapUserPubSub_subEvent_
 	return CapUserPubSub_subEvent_Results(p.Struct()), err
 }
 
+type CapUserPubSub_pubActionRequest_Params capnp.Struct
+
+// CapUserPubSub_pubActionRequest_Params_TypeID is the unique identifier for the type CapUserPubSub_pubActionRequest_Params.
+const CapUserPubSub_pubActionRequest_Params_TypeID = 0xa1fcad99b548f00c
+
+func NewCapUserPubSub_pubActionRequest_Params(s *capnp.Segment) (CapUserPubSub_pubActionRequest_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5})
+	return CapUserPubSub_pubActionRequest_Params(st), err
+}
+
+func NewRootCapUserPubSub_pubActionRequest_Params(s *capnp.Segment) (CapUserPubSub_pubActionRequest_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5})
+	return CapUserPubSub_pubActionRequest_Params(st), err
+}
+
+func ReadRootCapUserPubSub_pubActionRequest_Params(msg *capnp.Message) (CapUserPubSub_pubActionRequest_Params, error) {
+	root, err := msg.Root()
+	return CapUserPubSub_pubActionRequest_Params(root.Struct()), err
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) String() string {
+	str, _ := text.Marshal(0xa1fcad99b548f00c, capnp.Struct(s))
+	return str
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapUserPubSub_pubActionRequest_Params) DecodeFromPtr(p capnp.Ptr) CapUserPubSub_pubActionRequest_Params {
+	return CapUserPubSub_pubActionRequest_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapUserPubSub_pubActionRequest_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapUserPubSub_pubActionRequest_Params) PublisherID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) HasPublisherID() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) PublisherIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) SetPublisherID(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) ThingID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) HasThingID() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) ThingIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) SetThingID(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) ActionID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.Text(), err
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) HasActionID() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) ActionIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) SetActionID(v string) error {
+	return capnp.Struct(s).SetText(2, v)
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) Value() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(3)
+	return []byte(p.Data()), err
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) HasValue() bool {
+	return capnp.Struct(s).HasPtr(3)
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) SetValue(v []byte) error {
+	return capnp.Struct(s).SetData(3, v)
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) Expiry() int64 {
+	return int64(capnp.Struct(s).Uint64(0))
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) SetExpiry(v int64) {
+	capnp.Struct(s).SetUint64(0, uint64(v))
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) Handler() CapActionStatusHandler {
+	p, _ := capnp.Struct(s).Ptr(4)
+	return CapActionStatusHandler(p.Interface().Client())
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) HasHandler() bool {
+	return capnp.Struct(s).HasPtr(4)
+}
+
+func (s CapUserPubSub_pubActionRequest_Params) SetHandler(v CapActionStatusHandler) error {
+	if !v.IsValid() {
+		return capnp.Struct(s).SetPtr(4, capnp.Ptr{})
+	}
+	seg := s.Segment()
+	in := capnp.NewInterface(seg, seg.Message().AddCap(capnp.Client(v)))
+	return capnp.Struct(s).SetPtr(4, in.ToPtr())
+}
+
+// CapUserPubSub_pubActionRequest_Params_List is a list of CapUserPubSub_pubActionRequest_Params.
+type CapUserPubSub_pubActionRequest_Params_List = capnp.StructList[CapUserPubSub_pubActionRequest_Params]
+
+// NewCapUserPubSub_pubActionRequest_Params creates a new list of CapUserPubSub_pubActionRequest_Params.
+func NewCapUserPubSub_pubActionRequest_Params_List(s *capnp.Segment, sz int32) (CapUserPubSub_pubActionRequest_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5}, sz)
+	return capnp.StructList[CapUserPubSub_pubActionRequest_Params](l), err
+}
+
+// CapUserPubSub_pubActionRequest_Params_Future is a wrapper for a CapUserPubSub_pubActionRequest_Params promised by a client call.
+type CapUserPubSub_pubActionRequest_Params_Future struct{ *capnp.Future }
+
+func (f CapUserPubSub_pubActionRequest_Params_Future) Struct() (CapUserPubSub_pubActionRequest_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapUserPubSub_pubActionRequest_Params(p.Struct()), err
+}
+func (p CapUserPubSub_pubActionRequest_Params_Future) Handler() CapActionStatusHandler {
+	return CapActionStatusHandler(p.Future.Field(4, nil).Client())
+}
+
+type CapUserPubSub_pubActionRequest_Results capnp.Struct
+
+// CapUserPubSub_pubActionRequest_Results_TypeID is the unique identifier for the type CapUserPubSub_pubActionRequest_Results.
+const CapUserPubSub_pubActionRequest_Results_TypeID = 0x8f93aea5ec1f82bf
+
+func NewCapUserPubSub_pubActionRequest_Results(s *capnp.Segment) (CapUserPubSub_pubActionRequest_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapUserPubSub_pubActionRequest_Results(st), err
+}
+
+func NewRootCapUserPubSub_pubActionRequest_Results(s *capnp.Segment) (CapUserPubSub_pubActionRequest_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapUserPubSub_pubActionRequest_Results(st), err
+}
+
+func ReadRootCapUserPubSub_pubActionRequest_Results(msg *capnp.Message) (CapUserPubSub_pubActionRequest_Results, error) {
+	root, err := msg.Root()
+	return CapUserPubSub_pubActionRequest_Results(root.Struct()), err
+}
+
+func (s CapUserPubSub_pubActionRequest_Results) String() string {
+	str, _ := text.Marshal(0x8f93aea5ec1f82bf, capnp.Struct(s))
+	return str
+}
+
+func (s CapUserPubSub_pubActionRequest_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapUserPubSub_pubActionRequest_Results) DecodeFromPtr(p capnp.Ptr) CapUserPubSub_pubActionRequest_Results {
+	return CapUserPubSub_pubActionRequest_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapUserPubSub_pubActionRequest_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapUserPubSub_pubActionRequest_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapUserPubSub_pubActionRequest_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapUserPubSub_pubActionRequest_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapUserPubSub_pubActionRequest_Results) Status() (ActionStatus, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return ActionStatus(p.Struct()), err
+}
+
+func (s CapUserPubSub_pubActionRequest_Results) HasStatus() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapUserPubSub_pubActionRequest_Results) SetStatus(v ActionStatus) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewStatus sets the status field to a newly
+// allocated ActionStatus struct, preferring placement in s's segment.
+func (s CapUserPubSub_pubActionRequest_Results) NewStatus() (ActionStatus, error) {
+	ss, err := NewActionStatus(capnp.Struct(s).Segment())
+	if err != nil {
+		return ActionStatus{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+// CapUserPubSub_pubActionRequest_Results_List is a list of CapUserPubSub_pubActionRequest_Results.
+type CapUserPubSub_pubActionRequest_Results_List = capnp.StructList[CapUserPubSub_pubActionRequest_Results]
+
+// NewCapUserPubSub_pubActionRequest_Results creates a new list of CapUserPubSub_pubActionRequest_Results.
+func NewCapUserPubSub_pubActionRequest_Results_List(s *capnp.Segment, sz int32) (CapUserPubSub_pubActionRequest_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapUserPubSub_pubActionRequest_Results](l), err
+}
+
+// CapUserPubSub_pubActionRequest_Results_Future is a wrapper for a CapUserPubSub_pubActionRequest_Results promised by a client call.
+type CapUserPubSub_pubActionRequest_Results_Future struct{ *capnp.Future }
+
+func (f CapUserPubSub_pubActionRequest_Results_Future) Struct() (CapUserPubSub_pubActionRequest_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapUserPubSub_pubActionRequest_Results(p.Struct()), err
+}
+func (p CapUserPubSub_pubActionRequest_Results_Future) Status() ActionStatus_Future {
+	return ActionStatus_Future{Future: p.Future.Field(0, nil)}
+}
+
+type CapActionStatusHandler capnp.Client
+
+// CapActionStatusHandler_TypeID is the unique identifier for the type CapActionStatusHandler.
+const CapActionStatusHandler_TypeID = 0xed5b053fbccce7e4
+
+func (c CapActionStatusHandler) HandleStatus(ctx context.Context, params func(CapActionStatusHandler_handleStatus_Params) error) (CapActionStatusHandler_handleStatus_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xed5b053fbccce7e4,
+			MethodID:      0,
+			InterfaceName: "hubapi/PubSub.capnp:CapActionStatusHandler",
+			MethodName:    "handleStatus",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 1}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapActionStatusHandler_handleStatus_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapActionStatusHandler_handleStatus_Results_Future{Future: ans.Future()}, release
+}
+
+// String returns a string that identifies this capability for debugging
+// purposes.  Its format should not be depended on: in particular, it
+// should not be used to compare clients.  Use IsSame to compare clients
+// for equality.
+func (c CapActionStatusHandler) String() string {
+	return fmt.Sprintf("%T(%v)", c, capnp.Client(c))
+}
+
+// AddRef creates a new Client that refers to the same capability as c.
+// If c is nil or has resolved to null, then AddRef returns nil.
+func (c CapActionStatusHandler) AddRef() CapActionStatusHandler {
+	return CapActionStatusHandler(capnp.Client(c).AddRef())
+}
+
+// Release releases a capability reference.  If this is the last
+// reference to the capability, then the underlying resources associated
+// with the capability will be released.
+//
+// Release will panic if c has already been released, but not if c is
+// nil or resolved to null.
+func (c CapActionStatusHandler) Release() {
+	capnp.Client(c).Release()
+}
+
+// Resolve blocks until the capability is fully resolved or the Context
+// expires.
+func (c CapActionStatusHandler) Resolve(ctx context.Context) error {
+	return capnp.Client(c).Resolve(ctx)
+}
+
+func (c CapActionStatusHandler) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Client(c).EncodeAsPtr(seg)
+}
+
+func (CapActionStatusHandler) DecodeFromPtr(p capnp.Ptr) CapActionStatusHandler {
+	return CapActionStatusHandler(capnp.Client{}.DecodeFromPtr(p))
+}
+
+// IsValid reports whether c is a valid reference to a capability.
+// A reference is invalid if it is nil, has resolved to null, or has
+// been released.
+func (c CapActionStatusHandler) IsValid() bool {
+	return capnp.Client(c).IsValid()
+}
+
+// IsSame reports whether c and other refer to a capability created by the
+// same call to NewClient.  This can return false negatives if c or other
+// are not fully resolved: use Resolve if this is an issue.  If either
+// c or other are released, then IsSame panics.
+func (c CapActionStatusHandler) IsSame(other CapActionStatusHandler) bool {
+	return capnp.Client(c).IsSame(capnp.Client(other))
+}
+
+// Update the flowcontrol.FlowLimiter used to manage flow control for
+// this client. This affects all future calls, but not calls already
+// waiting to send. Passing nil sets the value to flowcontrol.NopLimiter,
+// which is also the default.
+func (c CapActionStatusHandler) SetFlowLimiter(lim fc.FlowLimiter) {
+	capnp.Client(c).SetFlowLimiter(lim)
+}
+
+// Get the current flowcontrol.FlowLimiter used to manage flow control
+// for this client.
+func (c CapActionStatusHandler) GetFlowLimiter() fc.FlowLimiter {
+	return capnp.Client(c).GetFlowLimiter()
+} // A CapActionStatusHandler_Server is a CapActionStatusHandler with a local implementation.
+type CapActionStatusHandler_Server interface {
+	HandleStatus(context.Context, CapActionStatusHandler_handleStatus) error
+}
+
+// CapActionStatusHandler_NewServer creates a new Server from an implementation of CapActionStatusHandler_Server.
+func CapActionStatusHandler_NewServer(s CapActionStatusHandler_Server) *server.Server {
+	c, _ := s.(server.Shutdowner)
+	return server.New(CapActionStatusHandler_Methods(nil, s), s, c)
+}
+
+// CapActionStatusHandler_ServerToClient creates a new Client from an implementation of CapActionStatusHandler_Server.
+// The caller is responsible for calling Release on the returned Client.
+func CapActionStatusHandler_ServerToClient(s CapActionStatusHandler_Server) CapActionStatusHandler {
+	return CapActionStatusHandler(capnp.NewClient(CapActionStatusHandler_NewServer(s)))
+}
+
+// CapActionStatusHandler_Methods appends Methods to a slice that invoke the methods on s.
+// This can be used to create a more complicated Server.
+func CapActionStatusHandler_Methods(methods []server.Method, s CapActionStatusHandler_Server) []server.Method {
+	if cap(methods) == 0 {
+		methods = make([]server.Method, 0, 1)
+	}
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xed5b053fbccce7e4,
+			MethodID:      0,
+			InterfaceName: "hubapi/PubSub.capnp:CapActionStatusHandler",
+			MethodName:    "handleStatus",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.HandleStatus(ctx, CapActionStatusHandler_handleStatus{call})
+		},
+	})
+
+	return methods
+}
+
+// CapActionStatusHandler_handleStatus holds the state for a server call to CapActionStatusHandler.handleStatus.
+// See server.Call for documentation.
+type CapActionStatusHandler_handleStatus struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapActionStatusHandler_handleStatus) Args() CapActionStatusHandler_handleStatus_Params {
+	return CapActionStatusHandler_handleStatus_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapActionStatusHandler_handleStatus) AllocResults() (CapActionStatusHandler_handleStatus_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapActionStatusHandler_handleStatus_Results(r), err
+}
+
+// CapActionStatusHandler_List is a list of CapActionStatusHandler.
+type CapActionStatusHandler_List = capnp.CapList[CapActionStatusHandler]
+
+// NewCapActionStatusHandler creates a new list of CapActionStatusHandler.
+func NewCapActionStatusHandler_List(s *capnp.Segment, sz int32) (CapActionStatusHandler_List, error) {
+	l, err := capnp.NewPointerList(s, sz)
+	return capnp.CapList[CapActionStatusHandler](l), err
+}
+
+type CapActionStatusHandler_handleStatus_Params capnp.Struct
+
+// CapActionStatusHandler_handleStatus_Params_TypeID is the unique identifier for the type CapActionStatusHandler_handleStatus_Params.
+const CapActionStatusHandler_handleStatus_Params_TypeID = 0xe7e437619eb494e4
+
+func NewCapActionStatusHandler_handleStatus_Params(s *capnp.Segment) (CapActionStatusHandler_handleStatus_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapActionStatusHandler_handleStatus_Params(st), err
+}
+
+func NewRootCapActionStatusHandler_handleStatus_Params(s *capnp.Segment) (CapActionStatusHandler_handleStatus_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapActionStatusHandler_handleStatus_Params(st), err
+}
+
+func ReadRootCapActionStatusHandler_handleStatus_Params(msg *capnp.Message) (CapActionStatusHandler_handleStatus_Params, error) {
+	root, err := msg.Root()
+	return CapActionStatusHandler_handleStatus_Params(root.Struct()), err
+}
+
+func (s CapActionStatusHandler_handleStatus_Params) String() string {
+	str, _ := text.Marshal(0xe7e437619eb494e4, capnp.Struct(s))
+	return str
+}
+
+func (s CapActionStatusHandler_handleStatus_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapActionStatusHandler_handleStatus_Params) DecodeFromPtr(p capnp.Ptr) CapActionStatusHandler_handleStatus_Params {
+	return CapActionStatusHandler_handleStatus_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapActionStatusHandler_handleStatus_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapActionStatusHandler_handleStatus_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapActionStatusHandler_handleStatus_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapActionStatusHandler_handleStatus_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapActionStatusHandler_handleStatus_Params) Status() (ActionStatus, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return ActionStatus(p.Struct()), err
+}
+
+func (s CapActionStatusHandler_handleStatus_Params) HasStatus() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapActionStatusHandler_handleStatus_Params) SetStatus(v ActionStatus) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewStatus sets the status field to a newly
+// allocated ActionStatus struct, preferring placement in s's segment.
+func (s CapActionStatusHandler_handleStatus_Params) NewStatus() (ActionStatus, error) {
+	ss, err := NewActionStatus(capnp.Struct(s).Segment())
+	if err != nil {
+		return ActionStatus{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+// CapActionStatusHandler_handleStatus_Params_List is a list of CapActionStatusHandler_handleStatus_Params.
+type CapActionStatusHandler_handleStatus_Params_List = capnp.StructList[CapActionStatusHandler_handleStatus_Params]
+
+// NewCapActionStatusHandler_handleStatus_Params creates a new list of CapActionStatusHandler_handleStatus_Params.
+func NewCapActionStatusHandler_handleStatus_Params_List(s *capnp.Segment, sz int32) (CapActionStatusHandler_handleStatus_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapActionStatusHandler_handleStatus_Params](l), err
+}
+
+// CapActionStatusHandler_handleStatus_Params_Future is a wrapper for a CapActionStatusHandler_handleStatus_Params promised by a client call.
+type CapActionStatusHandler_handleStatus_Params_Future struct{ *capnp.Future }
+
+func (f CapActionStatusHandler_handleStatus_Params_Future) Struct() (CapActionStatusHandler_handleStatus_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapActionStatusHandler_handleStatus_Params(p.Struct()), err
+}
+func (p CapActionStatusHandler_handleStatus_Params_Future) Status() ActionStatus_Future {
+	return ActionStatus_Future{Future: p.Future.Field(0, nil)}
+}
+
+type CapActionStatusHandler_handleStatus_Results capnp.Struct
+
+// CapActionStatusHandler_handleStatus_Results_TypeID is the unique identifier for the type CapActionStatusHandler_handleStatus_Results.
+const CapActionStatusHandler_handleStatus_Results_TypeID = 0x83d16dbcd5814aaf
+
+func NewCapActionStatusHandler_handleStatus_Results(s *capnp.Segment) (CapActionStatusHandler_handleStatus_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapActionStatusHandler_handleStatus_Results(st), err
+}
+
+func NewRootCapActionStatusHandler_handleStatus_Results(s *capnp.Segment) (CapActionStatusHandler_handleStatus_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapActionStatusHandler_handleStatus_Results(st), err
+}
+
+func ReadRootCapActionStatusHandler_handleStatus_Results(msg *capnp.Message) (CapActionStatusHandler_handleStatus_Results, error) {
+	root, err := msg.Root()
+	return CapActionStatusHandler_handleStatus_Results(root.Struct()), err
+}
+
+func (s CapActionStatusHandler_handleStatus_Results) String() string {
+	str, _ := text.Marshal(0x83d16dbcd5814aaf, capnp.Struct(s))
+	return str
+}
+
+func (s CapActionStatusHandler_handleStatus_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapActionStatusHandler_handleStatus_Results) DecodeFromPtr(p capnp.Ptr) CapActionStatusHandler_handleStatus_Results {
+	return CapActionStatusHandler_handleStatus_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapActionStatusHandler_handleStatus_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapActionStatusHandler_handleStatus_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapActionStatusHandler_handleStatus_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapActionStatusHandler_handleStatus_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapActionStatusHandler_handleStatus_Results_List is a list of CapActionStatusHandler_handleStatus_Results.
+type CapActionStatusHandler_handleStatus_Results_List = capnp.StructList[CapActionStatusHandler_handleStatus_Results]
+
+// NewCapActionStatusHandler_handleStatus_Results creates a new list of CapActionStatusHandler_handleStatus_Results.
+func NewCapActionStatusHandler_handleStatus_Results_List(s *capnp.Segment, sz int32) (CapActionStatusHandler_handleStatus_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapActionStatusHandler_handleStatus_Results](l), err
+}
+
+// CapActionStatusHandler_handleStatus_Results_Future is a wrapper for a CapActionStatusHandler_handleStatus_Results promised by a client call.
+type CapActionStatusHandler_handleStatus_Results_Future struct{ *capnp.Future }
+
+func (f CapActionStatusHandler_handleStatus_Results_Future) Struct() (CapActionStatusHandler_handleStatus_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapActionStatusHandler_handleStatus_Results(p.Struct()), err
+}
+
 type CapSubscriptionHandler capnp.Client
 
 // CapSubscriptionHandler_TypeID is the unique identifier for the type CapSubscriptionHandler.
@@ -2970,137 +4614,205 @@ func (f CapSubscriptionHandler_handleValue_Results_Future) Struct() (CapSubscrip
 	return CapSubscriptionHandler_handleValue_Results(p.Struct()), err
 }
 
-const schema_f33c8b5943a21269 = "x\xda\xd4X\x7fp\x14\xd5\x1d\xff~w\xef\xd8l\xee" +
-	"\x17\xcf\x8dc\xc1\xa6'L\xd0H[08v \x16" +
-	"\x13B\x98\x92\x94\xda\xdb\\\xe8Tf\x98\xe9\xdee!" +
-	"7s\x17\xce\xdd\xdb(\xa3\x08b%\xa8\xd0!Q\x98" +
-	"\x02b+:\xa5\x05DA\x9d\"B\xa9X+3e" +
-	":\xd4\x0e\x8c\xd3V\xb0Z\x08\xed\x1f8\xd52\xb4\xa6" +
-	"u;\xefm\xde\xde\xbb\xbb\x10\x12\xda\x7f\xfc/y\xfb" +
-	"y\xdf\xef\xe7}\x7f|\xde\xf7\xddm\x9d\xc1\xe6@C" +
-	"$\x1e\x05I\x7f>8\xc1\xbd\xb4\xf6\x1f\xe7g\xcc\x9d" +
-	"\xbf\x06H\x8d\xecf\xae\xdb9\xff\x9e'\xbe\xfe\x09\x00" +
-	"\xde~i\xc2\x12\xd4TE\x01\xd0\x82\xca7\xb4\x06\xfa" +
-	"\x97{C\xdd\xd0\xa3\xd2{\x9d\xeb\x80\xd4!@PV" +
-	"\x00n\x9f\xa4\xecF@\xedV\xe5E@w\xc3}'" +
-	"?[\x968\xd1'\x02\xdeRvR\xc0)\x06\xb0\xbb" +
-	"\xdc\xfa}U\xb3\xfb\x81L\xa5\x80\x00\x05\xe4\xaa\xb6Q" +
-	"\xc0\xaa\xaaA@w\xb6:\xd88pv\xd3\x00\x90/" +
-	"S\x00R\xc0=\xea\xc7\x14\x90S\x9b\x00\xdd=\xf5\xee" +
-	"\xc0G\xaf\xbf\xfe\x14\x90\x9a@\x09\xe3\x8d\xea,\xd4v" +
-	"\xa8\x0a@\xf2\x87\xaa\x8c\xc9\xe7U\x09\x01\xdc\xc9sw" +
-	"\xd4\xc77\xffr\xb3\xe7\x919\xdc\xaanC\x08\xb8'" +
-	"_\x9a\xd9\xbbw\xe1\xec-\x1eY\xf6\xe51\xf5\x00\xfd" +
-	"\xd2\xb6\xef\xa5#\xb7\x1c{`+\x90i>\x89\x95\xea" +
-	"\x07\x94\xc4FF\xe2\x98v\xf2\xc2\xb7\x89\xb2\xbb\x82\xc4" +
-	"~J\xe2\x0dF\xe20%q\xdc#\xf1\xf4S\x97_" +
-	"}\x7fO\xc3\x9e\x0a\xfc\x11\xb5\x1d\xb5\xdf1\xfc\x09\x8a" +
-	"\x7f\xd7\xc3?\xb0d\xd3\xf1\xd7\xb2\x8b_\xa8H\xcb)" +
-	"\xf5:\xd4\xceQ\xbc\xf6g\xb5O\x9bSM\xd3\xb2\xed" +
-	"7\xfbv\xdc\xf4\xe1\x83\xfb\xc4\xa0N\xa9\x1e\xa0t\x1b" +
-	"\xaaiP\x03[\x1eY\xfa\xc4\xdc/\xbdX\xe1^\x0d" +
-	"MGmR\x88\xba\xaf\x09\xc9\x98\xbc)\xc4\xdc\xff\xfc" +
-	"\x9d\xbbc\xdbo\xde\xfc\x8aw~foR\xe8\x18\x8d" +
-	"\xcc\xe4\x1f\xf7\xc9\xbb\xaa#\x87\xc5\xc8\xa8\xa1\xbfQW" +
-	"\xb5!\x1a\x99\xc2\x0f\xfe\xf9\xa7\xfb\x06k\x8e\x08[\xe7" +
-	"\x85\x0e\xd1\xad\xeb\x8f\xae\xdbb\xd4\x1fzSHDC" +
-	"h'\xfd2\xf4\xea\xe5)\xb3\xd3\xbb\xde\x12\x8dN\x09" +
-	"\xbdC\x8d\xde\xc1\x8c~\xe5\x85\xa6E\xc6\x81]\xbf\xf6" +
-	"\x8a\x82m]\x1c\xfa\x94n\xbd\xf3\xb6w\xef\x88\xfd\xfb" +
-	"\xe6\xdf\x8b[\xdbB\xac\\\x96\xb2\xadw\x1e_\xf4\xda" +
-	"\x93\xcf\xbcyJ\x04\xac\x0a\xfd\x81\x02\xfa\x19 \xbd\xfd" +
-	"\x17/\xd7\xbb\x7f=-T\xc1\xfe\xd0nj\xbb\xb6\x7f" +
-	"\xef\x93\xeb~\xf6\xc1\x1f+\xa2\xf6l\xa8\x05\xb5\xfd," +
-	"j{i\xd4\x0ezQk\x7f(sa\xd3O\x0e\x9e" +
-	"\xadH\xda\xfe\xd0T\xd4\xde\xa0x\xedH\xa8O\xbb>" +
-	"L\x93vv\xe6\xdb\x83G?\xfc\xe9_*\xd0\xff\xa1" +
-	"9\x89P\x8c\xa6\x86\xdf\xd6\xfa\x19z\xda\xfa\xaf=g" +
-	"\xbf2p\xae\x82\xcb\xca\xf0,\xd4\x1e\xa3\x98\xe4\xf7\xc3" +
-	"2&7\x85\x19\x97_\xe5.\xdc\xf5\xc8s\x87\xcf\x0f" +
-	"\x1f\xdb+\xee\xf0\x01z\xec\xadaZ\x12\x17\x1f\xba\xf1" +
-	"\xb7\xab&\xdeuQ\x04\xdc\x1b9D\x01\x0fG(`" +
-	"\xe3\x8d\x9f\xceY\xffl\xef\xc5\x0a\x8fK\xa3\xd5\xa8\xe5" +
-	"\xa2\xd4cwT\xc6d!\xca<\x16\x06\xbf\xf9\xcc\xfb" +
-	"\x97?\xfe\xa8\x02\x9f\x89\xb6\xa0\xb6\x92\xe1\x0b\x14\xbff" +
-	"\x18\xbf\xe1L\xcb\xd0\x99\xdaK\x95'\x8aJ\xa8=\xca" +
-	"\xf0k(~\x83\x87?t\"b\xdd\xe0\x1e\xfdWE" +
-	"\xbc\x1e\x8eNG\xad\x9f\xe2\xb5\x8d\xd1>\xed\\\xf4\x16" +
-	"\x00\xf7\xcc'\xa7\xb66\x85\xe5!1\xed\xefEY\x9d" +
-	"\xfe=J\xd3\xfe\xf4\xe9\x1f\xbd\xfc\xd9\xf6\xc2P\x85{" +
-	"\x12\x9b\x8e\xda\x94\x18u\xff\xc5\x98\x8c\xc9\xfa\x98\x84\xd0" +
-	"\xe2v;)#\x9f\x99\x99\x088\xa9\xa4\x93\x9a\x916" +
-	"\xf2=\xf9\xc6\xf9F>\xe9\xa4\xec\xb4\x95\xc9\x172+" +
-	"z\x16\x1a=]Y\xd9\xb4\x12\x88z@\x0e\x02\xf8j" +
-	"\x86\xbc\x82\x09I\x81DT\xc5\xed\xa6X\xf3;\x06(" +
-	"Y\xc7l\xc6\x04\xa2\xef\"X\xe6\xa2\xd5\xec\xcd\xa4\xcd" +
-	"\x84\xb7j;\xa9yi\xea\xac.a\xc4,#g\xeb" +
-	"a9\x00\x10@\x00\xb2\xa0\x05@o\x96Q_$!" +
-	"A\xacA\xba\xd8\xd6\x0e\xa0/\x94Q\xef\x94\x90HR" +
-	"\x0dJ\x00D\xa7\xc8E2\xea\xdf\x95pu\xa1;\xd3" +
-	"\xb3\xbc\xad\x15\xc3 a\x18\xd05\x98\xfd\xb6V\x00\xe0" +
-	"k\xab=\xba\x16\x92\xe2\x8d\x01\x88\x04\xc6\xc8:\xef\xa4" +
-	"\x16\xf4\x9a=\x85\xba\x84a)c!M\x17[e\xd4" +
-	"\x13\x02\xe9o\xcd*\x9e\xa4\x9c\xf4j\x93\x9a/\xfe\x1f" +
-	"\xef5\xb2\x8e\x89\x11\x9002\x0a\xc9\xc5\xb6i\x15)" +
-	"\xfa\x81\xb5\x0c9g\xeb\x13}\x8eF\x0a@\xff\x9e\x8c" +
-	"zV\xe0\x98\xa1\x1c\xbbd\xd4\xf3\x02\xc7\x1c\x8dvV" +
-	"F\xfd~\x09\x89,\xd7\xa0\x0c@\x1cJ</\xa3\xfe" +
-	"\xa0\x84n\xdeIe3v\xb7\x09\x8a%\xd0\x1fC\x0e" +
-	"\xaep\xa4\x09W+H\xd3\x9a\xc1\x8b-\xeb\x98u\x89" +
-	"\xb8\xc1\xca&\xe0\x9f.B\xe9U\xc9\xa8\xd7H\xdc\xc9" +
-	"Dw`\xcd\x96\x83;\xce\x9f\xdeN\xd3<Qp'" +
-	"\x8b\xee\xf2N\xcavRI\xd3\xea\xcd\xa4\xe3\xe6\xddF" +
-	"\xceL \x0e\xf3\x05\x82\x8dM\x1e`,\xe1\xb7y\x85" +
-	"t\x98\xb6\x93\x95\x0b\xf6x\xdb\xa1\xc3\x8c\xdbNV\xd8" +
-	"W\x1e\x18o\x87G\xd6\xa4\xcb\xa2!\xbf0\x85\xb0\xd0" +
-	"T\x86e\xd4\xbf \xa1\xdb\xc5\xb0%\xf9\x189$9" +
-	"\xd3\xb6\x8d\xe5f\xe7\xca\xbc9/\xce\x98\x95\x85\xc4\xcb" +
-	"\xec\xc8z\x926\xf24\x88\xc3\x1c=f\x00%\x06\x1e" +
-	"w\xd3F\x9e\x01\xb0\x88\x18\x99\x8a\x18`LQQ\xaa" +
-	"b\xa2\xc4g0\xe472i\xe8\x00\x89\xdc\xaa \xfa" +
-	"\xa3\x04\xf2\xb1\x89\xd4\xb6\x83D\xaeW\\\xde\x1f\x80=" +
-	"\xcd\xe8\xf2t\x01\xc0\xe8\xe25R\x8ai\x83\xe5\xf0\x1a" +
-	"\x1b\x8c.v\xcb\xa8\x17\x84\x06\xbb\xb7\xa5\xd8uck" +
-	"\xb0r\xbd\x18\x83\xc0\x95\xc4\xd6\xcb\"M\xd6\xfc\x15\xb1" +
-	"\x9ee\x99\xe5%I\xb2\xdc\xf4\x0a\xba\xe8X\x107J" +
-	"\xd2\x1d)\xefV1\xd5\xc5j\xb6\xbd6(\xd8\xc07" +
-	"^\xad\x98K\x0c\xf9w\x83P\xcd\x1dB5\xdb\x1e\xb8" +
-	"\x0d\xb0\xb5\xa2\x9a\x83Wc\xc8rh\xd7u4\x99\xa5" +
-	"\xfd6&m\xa5\xc7R\xc6\xd5\xa5E3~\xdd\x08\xa7" +
-	"j,JW\x93c\x9bB\xc6\xafY +\x0e6a" +
-	"|\xb1\xe7B$\xd2\x9cZ\xa4\xa9\xa4\x8d<\x92\xe28" +
-	"SVe\xe3\x89\x07\x97\xca\xd1=\xf9o\x89k\xba\xb0" +
-	";L;V\x12\x8d\x12\xc1b]\xc4t\xdfZ\x917" +
-	"\xadB\xc6\xb4K\xe5j\x89\x9b\x1f\xfe\x02\xb2i_Q" +
-	"\xa7|\xdfJ\xd2\x11\x94\x8a?'\x91\x0f\xe9\xa4\xa1\x9d" +
-	"+\x15\x7f\x8b\"\x7f\xc6\x91\xda\x0e_\xa9\x8a\xda\xe4\xda" +
-	"\xa2n\x89J%_)\xd21\xc6\x05Q\x0f3\x12\xfc" +
-	"1\x88|\xa6$\xfaZ\x90H\x1b%\xc1\x9fC\xc8\xdf" +
-	"!d\xee\xe3 \x919\x0aJ\xfe\xab\x06\xf9\x13\x84|" +
-	"\xd5\x02\x89LS\\~\xf7 \x17\xf0f\x1cI\xd5\xbd" +
-	"U\x96o\x88\xb3\xb5Q\x0e0|u0\xb4\x07.W" +
-	"\xa4RS\xe3\xeew>7|\xbeD;8V\xbdm" +
-	"J\xfc\x0f\xc7\x1bq\xe8k\xf9\x7f\x0c}\xe3\xbd\x97\x98" +
-	"I;a\x99\xcb2\xf7\x97M\x0e\x8dM\xde\xc7QG" +
-	"\x0fQ\x04J\xb7\xaf\xf5\xcb6\xc8\xbf\x8ft7\xf9\x8a" +
-	"\xd0\xd9Z\xba\x7f\xb2\\\xe8\xbab\xef\xf9i\x89\xa5J" +
-	"\x04\x80\xbfR\x91\xff\"A\x1a\x96p\x01\xe0O\\\xe4" +
-	"?9\xf8\x02\xe0\xb7\xbc\xdcc\x8b\xb3\x0a\xda\xcd\xa8W" +
-	"!\x16_\xea\xe2O-\xc2\x185\xbe\xd1\x91K\xe4\xe8" +
-	"2\\\xf49J\xfa\x84\xe9qA\x8cr.\x09\xe1\xac" +
-	"8\x8b\xee\x7f\x03\x00\x00\xff\xff\xdf.\xb4~"
+const schema_f33c8b5943a21269 = "x\xda\xd4Y}t\x14\xd5\x15\x7fwf\x97%!\x9b" +
+	"\xdd\x97\xd9\x9c\x04=v+'(\xd2V$\x94\x8a\xa1" +
+	"\x90\xb0\x84\x0a)\xd8\x9d]P\xc1zNgw\x07X" +
+	"\xbb\xd9,3\xbb\x11\x8a\x88\xe0Q\x11\xb5U\x14N\x01" +
+	"AE\xa9\x80\x04SMZ@,\xd6/\xf4\x88\x1e\xd4" +
+	"\x82_\xf8\x81R\x12\xadJQ9\x80\xa8\xd3\xf3\xde\xec" +
+	"\xdb}\x93\xd9|\x90\xc3?\xfdow\xe6\xbew\xbf~" +
+	"\xef\xfe\xee\xbbs\xc9\xaa\x81u\x8e\x91\xee\x13\x12\x12\xe4" +
+	"N\xe7\x00\xe3\xf8\xd2o\x8e\\<n\xe2M\x08\xfbD" +
+	"#^\xb6a\xe2\xcc;~\xf95B0\xeaP\xd1," +
+	"\x90\x8e\x17\xb9\x10\x92\x8e\x15].\x9dW\xecB\xc8x" +
+	"\xbca\xc9\xfe]\x8d\xaf\xdf\x8c\xf0O\x00!\x87\x0b\xa1" +
+	"Q\xcebA@\x0e\xa3\xa2\xea\xf4-\xc2\xfb\xd3oE" +
+	"\xb8\x0a\x10r\x8a\xe4\xd5\xa9\xa2-\x80@**~\x1c" +
+	"\x81q\xe7\xf5\xfb~\x98\x1d\xdc{\x1b/\xb0\xb1x\x03" +
+	"\x11h\xa7\x02\x0f\xbc=K\xd9\xd0v\xcdrS\x80\xee" +
+	"=m\xd0\xc7\x80\x1c\xc6\xdf\x86\x8d\x7fm\xda\xf8\x19w" +
+	"!<4\xb7t\xc2\xa07\xc8\xd2\x19\x83\xc8\xd2\xddK" +
+	"\xfd\x9fo\xdcv\xef\x9f\x10\x1eB\x04\x80\x08\x1c\x1b\xf4" +
+	".\x11p\x96\xd4\"0\xf4\x981l\xdb\xc01\xf7d" +
+	"\x05\xe8\xe6CK\xd6\x10\x81\xd1%\x1d\x08\x8c1E\x1d" +
+	"5+>\xbc{\x85\xe9\x98\xb9\x03v\x7fE\x04\x86\xba" +
+	"\xc9\x0e\x8f\x0d3V\x1c}\xea\xa9\xfb\x10\xf69,q" +
+	"\x9a\xe4\xae\x06i\x86\xdb\x85P8\xe8\x16!\xfc[\xb7" +
+	"\x00\x08\x19\xe7\x8c[7\xcc\xbf\xf2\x99\x95\xa6F\xaaP" +
+	"v\xaf!\xde\xeck\x1d\xd1\xbcu\xf2\x98U\x9c\x9f\x13" +
+	"\xdcO\x907S\xb6\xb5>}\xe1\xb3\x0bWg\xfd\xa4" +
+	"F\x8ct\x7fL\x8c\x98D\x8d(\xf9\xef\xe4\xf6\xd5-" +
+	"\xdf=\x84\xe4!@$\x9cD\"\xee&\x91\x18\xb5\xc8" +
+	"}\x15 0\x9e\x95\xf6u\xfe\x06\xbb\xb6\xd8\xec|\xbd" +
+	"\xb4\x1a\xa4C\xa5\xc4\xce\x83\xa5\"\x84;K\xa9\x9d\xf7" +
+	"\xdfw\xa2\xfd\xa3\xc7F>f\x93\x7f\xbf\xb4\x01\xa4c" +
+	"T\xfes\"\x7f\xd2\x94_8\xeb\xee\x97v$f\xb4" +
+	"\xd8\xf0r\xbc\xb4\x0c$\xa7\x87\xe0\x05<{\xa4\xdb\xc9" +
+	"/c\xcd+\xdb\xd6\xfd\xf8\x93\x1b\xb6\xf1q\x9f\xe7Y" +
+	"A<Z\xe2!qw\xac\xba\xf9\xda;\xc6\xfd\xe8q" +
+	"\x9b\xfa\x99\xde\xe1 \xc5\xbdD}\xcc+B8\xe5\xa5" +
+	"\xea\xff\xfe\xc6\x15\x9e\xb5\x17\xacl3CD\xf7\x8b{" +
+	"\x9f%\xc1;\xe7\xc1\xdb\xc4G\x8b\xdd\xbb\xf8\xe0\xcd\xf4" +
+	"~FT5zI\xf0\xd2\x7f<y\xf0\xfa\x0e\xdf\xd3" +
+	"\xdc\xd2{\xbc;\xc9\xd2e\xbbo]\xa5\x0c\xdb\xf9\x1c" +
+	"\x97\xab%\xde\x0d\xe4\xcd\xe9\xf6\x13\xe7\x8f\x89>\xfa\x02" +
+	"\xbf\xe9</E\xde-t\xd3\x9f\xb6\xd4NU\x9ex" +
+	"\xf4E\xee@l\xf4~K\x96\xce\xbft\xf9\xe8\x05W" +
+	"\xaf\x7f\xcd\xe6\xd9jo\x08\xa4\x16\xea\xd9&\xe2Y\x9b" +
+	"\xe9\xd9\xd8K\xde\x1e\xed\xf9\xee\x827yU-^\x8a" +
+	"\xc0\x7fRUc_\x9a\xba\xe3\xde\xf5\xcf\xed\xe7\x05\x0e" +
+	"y)\xc8\x8fS\x81\xe8\xda\x7f<9\xcc\xf8\xf4\x00\x07" +
+	"\xacr\xbc\x85\xd8\x12\x9c\xbfj\xf6\xfa\x17_}\x0b\xc9" +
+	">\xe0\xb2f\x9e$'\xae\x06\xa9\x1cS\xc4c\x8a\xa1" +
+	"\xf3\xee\xd9z\xef\xad\x9b?~\xcffz\xbc,\x00\xd2" +
+	"\x822bz\xbaL\x84\xf0Me\xd4\xf4\x86\x1b\xe3\x9d" +
+	"w\xffe\xfb\x876L,(\x1b\x02\xd2\xedD^\xba" +
+	"\xa5l\x8fT$\x11L|8bO\xc7\xeeO6\x1d" +
+	"\xb6I\x1f+\x1b\x0e\x12\x10\x99Q\xdf\x97\xb9@j\xa7" +
+	"\xe2C\x97\xfd\xe2a\xbdm\xc5\xbfm\xc6\xac\x93\xaaA" +
+	"j\x91h\x1c%\x12G\x89\x1a\xf3|c\xe7\xf8\x9b\x1f" +
+	"\xdeu$\x1b&\x1a\x86\x16\xe9\x09\x12\xa6\xa7%\x02\xb9" +
+	"\xc3\xf7\xb5\xadW.=\xdc\xc1\x1f\xf5\x95\xbeo\x89\xc0" +
+	"f\x1f\x89c\xa7\xeb\xc4\x84\xab\x0e\x8f\xfd\xcc\xa6\xf1e" +
+	"_\x00\xa4w|D\xe3\xbf|\"\x84?\xf2Q\x8d\xff" +
+	"9\xf8\xca\xcc\xb1[\xbc_p\xc0\xda\xef+&E\xf1" +
+	"p\xc7\xde]\xb5\xcek\xbe\xb0\xb9\xfa\xaao\x16H\x87" +
+	"\xc8N\xd2\xfb\xbe\xcb\xa5\xa2r\xe2\xe9\x977\x9e\xfb\xda" +
+	"\"\xef\xf8/y\xcb\x8f\xf9v\x12\xc3\xa0\x9cX~\xd7" +
+	"\xb9\xdf^\xb6\xec\xa1\xe6/\xedg\xb5\xbc\x18\xa4/\xc8" +
+	"&\xe1\xcer\x11\xc2\xdf\x94S\xc3\xde\x0b\x08uo." +
+	"\x1cy\x14a\x1fpY\x1f@6\xfe\x94,9E\x96" +
+	"\x8c:^N\xb3\xbe\xf0\xedk~~\xb0u\xcdQ\x1e" +
+	"_\xd3*\xca\x04\x04\x92ZA\xe2\xb2\xf0\xf7\x81\xe4\x91" +
+	"\xb7>9jS\xbf\xb2\"\x04\xd2\xe6\x0a\xa2\xfe\x91\x0a" +
+	"\x11\xc2\xad\x15T}\xba\xe3\xd7\xeb?:\xf1\x95]~" +
+	"sE\x00\xa4\x1dT\xbe\x8d\xc8?c\xca_\xb7\xb7\xb5" +
+	"*t\xa0\xfak\x9b\xfc\x8e\x8a\x1a\x90^\xa6\xf2\xcf\x13" +
+	"\xf9}\xa6|\xe5\xa8\xed']W\x8e\xf8\x86g\x94\x17" +
+	"*\xe8\x81x\xa7\x82\xd0B\xfa\xce\x0f\x02\xa7?8\xef" +
+	"\xb8m\xc3L\xa5\x00\xd2\x92J\xb2\xe1\x0d\x95\"\x84\x97" +
+	"U\x9aG0\xfe\xdd\xe2\xe6\xef\x93'\xb9D.\xaa|" +
+	"\x97\x1c\xa0\x9d{\xddZ\x85\xb1\xfb\x94-\x91\xf3*\x87" +
+	"gw\x92\x16U\xee\x91`\xf0\x85\x08\x19\x1f|\xbd\x7f" +
+	"um\x89x\x9a\x8f\xe4\xa9JZ\x8a\xdc\x83I$\xef" +
+	"?\xf0\xc0\x93?\xacM\x9f\xb6\x19\xf6\xb3\xc1\xc3A\x1a" +
+	"7\x98\x186f\xb0\x08\xe1\xfa\xc1\x02\xa0\x8017\x13" +
+	"QR\xf1\x11AG&\x12\xceD.\x8e*\xa9d\xaa" +
+	"f\xa2\x92\x0ag\"zT\x8b\xa7\xd2\xf1\xa6\xe4d%" +
+	"\x19K\x88\xaa\x16\x04\x90\x1d\xa2\x13\xa1\x1c\xa7\x01+R" +
+	"\x18G\x90\x80\x8b\\\xc6\\\"\xab^\xa9 W\"\xa3" +
+	"\xd6A\x10 \xa7\xc2\xddE\xc5\x84(\xd9<\x9cV\xd2" +
+	"\x19\x9d\xaaP\xb5\x8b\xcd\xe5\xe6\xb3\xaa\x90\xaag\x12i" +
+	"\x1d\xb1\x0d\xba\xac\xafW\x9b\xe3Q5\xfbT\xcfD\xcc" +
+	"\xfd\xaa\x82\x8aGS\x1au\xb9Dt \xe4\x00\x84\xf0" +
+	"\xa4\x00Br\x9d\x08\xf2T\x010\x80\x8f\x10\x1c\x9e\xd2" +
+	"\x80\x90<Y\x04y\xba\x00X\x10|  \x84e\"" +
+	"9U\x04\xf9j\x01\x16\xa7\xe7\xc6\x93s\xa6\xd4C\x09" +
+	"\x12\xa0\x04\x81\xa1\xd0\xfd\xa7\xd4#\x84\xd8\xb3\xc5\xa6\xbd" +
+	"\x1a\xe0|\xb7\x83\x000\xca{\xed\xec\xc9\xeaT&2" +
+	"\xa9YM\xa6\xab\x82\x8a\xe6\xea\x8b\xd1\xe4a\xbd\x08r" +
+	"\x903zZu\xde\x93\xaeF/V\xc9\xf6\xf9\xff\xfe" +
+	"f%\x91Q\xc1\x8d\x04psF\x0e\xe8\xc5H>U" +
+	"4-\xaeDZ\xefvuX\xd5\xb8\xe5\xd1\x84\xaah" +
+	"!5\xad\xc4\x93j\x8c8\xaa4\x82\xc5\xd1\x08\xe7\x13" +
+	"stZ\xa0\x97\xec\x18\xa9L$\x11\xd7\xe7\xaa\xc8\xa5" +
+	"q\xee\xf6\xe2~\xb7&\xcf\xd0U\xad\xab\xbb!u^" +
+	"F\xd5\xd3&\x0c\xc5\xb4.;r6\xbbk\x10\x92\x07" +
+	"\x8a \xfb\x04\xa8\xd5iX\xc0\x9b/\x8a\x08\xc0\xdb\x03" +
+	"\x04\x0a)\xa3\x81\x11\x1bu\xd9\x9bS\xa2\x90\xc0\xfcN" +
+	"\x049\xc1\x05&Nb\x10\x13ANq\x81i$X" +
+	"N\x88 \xcf\x17\x00\x8b\xa2\x0fD\x84p\x86\xc0\"%" +
+	"\x82|C_\xa3U\x08\xe1}\x04\x8c\xad\\\xe4\xce\xf2" +
+	"\x95d}U\xd0\xaf\xd0C\xc9\x85\xb0:\x1f\xc2\xac\x12" +
+	"\xaf\xb1\xe2\xa6U\xdb\xd7\x1d9\xb0\xb6k\x04E^]" +
+	"*\x13\xd13\x11\x13d~\xf5\x0a\xa5Q\x0d\x02d\xed" +
+	"E\x18jjM\x81\xbe\x84_g\xe7\x8f\xe5\xb8o\xc7" +
+	"6_lB\xaa\x9f\xd6\xa8n\x03c\xae\xc8\x9e\x08\xf2" +
+	"\x98\xdf(w\xec\xb9\xb0\x90T\x96\x88 W\x0a`\xc4" +
+	"\xa8\xac%\x1f\xfdB0;t\x9595\xab\x09\xb6\xfe" +
+	",\x82\xfc\x08\x87\xad\x87\x08\xb6\xee\x17A\xde\xc4ak" +
+	"#1\xe8\x11\x11\xe4V\x0e[-$y\x9bD\x90\xdb" +
+	"\x04\x00\x87\x0f\x1c\x08\xe1\xbf\x923\xb1U\x04y\xbb\x00" +
+	"\xd8\xe9\xf0\x81\x13!\xdcN\xb6l\x15A\xdeu\xf6@" +
+	"X\xab\xceO\xc5\xb5\x05\xe0D\x028-58\xd7\x14" +
+	"u\xa9\xc1\x16\xf84\xaa\xba\xae\xccQ\xa7/H\xa9\x13" +
+	"\xfcTW\x17\xf8\x98\x06\x14f\xc6\xa8\x92\"\x80\xb3T" +
+	"8\x84,\x1b,7\xa2\xd9\x12\x08y\x89\xc2\xa6\xf0i" +
+	"\x83\x08\xa1\xd7\x12J\xaf\xecN\x09\xec\xfa\x80\xe5\x10\x12" +
+	"\xf0\x14\x17@\xee\xde\x03\xec\x1a\x88\xc75 \x01\x8fv" +
+	"\x81\x90\xbb\xc4\x01\xbb\xb5\xe2\x8b\x96#\x01\x0fu\x19\x0c" +
+	"\x12\x08\x92u`0\xd8#\x84\xea \xf7\x0e\x18^\xc8" +
+	"S\x9e\xb8\xfbr\x80\x18\xc4\xfaU\xbe\xc8\xc3\xb9\"\xc8" +
+	"i\x0eb\xf3\x02\xf9\x9a\xd6\xbfb\xdf\x07r\xb6d\xc3" +
+	"\xcc;I\xef\xc4&Orv|\x8e%\xad\x9a\x11m" +
+	"\"\x0f3\x1a\xf2+\x16\x80\xb8{\xa4\xbf\\\xad\xe8\xb5" +
+	"\x9f\xb1\x95\x0a\xcbF\xb9\xbe\x86\xab\x15!\xaeV\xe8\xa6" +
+	"\xf0\x14\x04v\xb6s\xf6f!\xcd\xa1^\x15\xaaU\xad" +
+	"\xd5\xacO\xcc\xd5k?`s,\xbfM\x0e7\xddp" +
+	"kFW\xb5>\xd0wo\xf4cs\xccaO\xbc\xd9" +
+	"\xddLljL%\xd4\xb4\x0a1K\xf6CF4\xfb" +
+	"\x02A\xac\xef\x8eZ3\xc8\xc8\x82wvH\xdeYW" +
+	"TI\x01\xce_\x08\xba`\xf5L\xa2Z\xa8e\xb1k" +
+	"\xca\x0d\\\xfa\xd5\xb2\x86T\xddc\x89\xa9\xe50\xf1\x19" +
+	"\xf1\x93\xd0\xea\xa4\xbcq\x04\xf4\x87B\x04t\x1dB\xf2" +
+	"\x83\"\xc8[\xb9\xea\xb0\xb9:O@\xc0\xf8'\x90\xe7" +
+	"\x1f\xec\x80,\x01\x85\xf2\\\x83\x9d\x82I@;\x88d" +
+	"\x9b\x08\xf23\x02\xad{\xd4(TkRL\x0eW\xd9" +
+	"\x17\x11\xe4\xe1\xe1\xe6O7\xa5\xe2\xd1\\9I\xa9\xc9" +
+	"X<9\x87\xf1\x8e\x11S\x13\xf1fU#\x88\x80\"" +
+	"$@\x11\x82\xc51\xad)\x95Rs\xff\x0b\xe3\x8d\xd6" +
+	")\xda\xb7hM)UK\xc7U\xddJ!\xb3\x8cT" +
+	"\xf6\x0d\x12U\xbd[\xee\xc8\xe5\xc5\x15\xcep\xec\xc1\xc6" +
+	"\xa1\xc0\xa66Xn`\xec\xc1f\xa9\xc0F\x85x\\" +
+	"\x88\xb1\x07\xbb\xf4\x02\x1b\x97\xe2\x8b\x96\"\x01\x9fO\xd9" +
+	"\x83\xe3\x0b\xdd\xc2%y\xf60\x0fP\x17\xee\x10\xbbC" +
+	"\xad\x87\xda\x0e {\xa9\xd1l@\x09\xec\x86\x8b\xe7\x11" +
+	"\xe5qb4\x9b\xbf\x01\x1bd\xe1k\x09\xad\xcd$F" +
+	"\xb31\x1a\xb0\x19\x16\x9e\xa6!\x01Or\x81\x98\x9b\x9e" +
+	"\x00\x9b?\xe0\xcb\xb6Pg\x0d\xd6\x87\x01#\xe8:(" +
+	"\xc4\xda\xe6Sz\xae\x90\x9f>\xab\x03c\x8e\x9a\xa6\xe0" +
+	"\x86,\xba\xc3\x1e\x82\xee\x1e\xbc\xce\xf6\x0ct\x1bs\x97" +
+	"\xae\xc4b\xd5q\xc6e\x9b5\xd7\xff_\xdc;\xe0L" +
+	"\xc7\x01\xb5A\xdb\x1d\xe2\x8c\xaea\xdd\xd6\xfc\xa0y\xb0" +
+	"\xadg0\xc0\xce{n\xbd\xab\xc7\x02\xcc`\xc1PA" +
+	"@a\xb2\x9b\xce\xb5\x7f\x8e\xde\x9c\xb6\x8cY\xd8<\x11" +
+	"\xd8\xc7\x11\x8c\xaf\xe3\xc7,\xe14\xf2\x90\x95=\xb7k" +
+	"\xdd\xf6#,\xa0g\xef\xca\x198\x1bW\xce3\xed\xdb" +
+	"\xe8\x96zPSg\xc7\xe7w\xe9\xc5kj\xcd\x97\x85" +
+	"\x17\xf2\xc1\xa7\x0by~\x0a\x15\xe2\xa7H!~\x0a\x14" +
+	"\xba 5p\x97!G\xf6\x86\xd4^\xc3\x13\x943K" +
+	"P\xd5\x1cAif\x03ni\xe2\xfa\x1dGv\"\x18" +
+	"\x9b\xa9\x9a\xd6\xa4\xd9Z\xa9~\xa0\xda\xecc\xa1\xe0m" +
+	"\xbeJ\x00?\xd1\xabC)\x82\xa0\x08\xe0\xcd\x7f\"@" +
+	"@\x1e\xf6~\x1e\xeb\xb3\xc4j\xeb\xc18\xc6\xed\xf1z" +
+	"\xc67,V@,\xcd\x95~'{_\xa8\x1b\xe7\xad" +
+	"\xa9\xfd\x95\x12O\xa8\xb1.\xb8\x9aM\x1f\xf6o\x80\xc6" +
+	"F=\xdc\x0c,Th\x06VSh\x06V\xcd\xcd\xc0" +
+	"\x0a\xc0\xa5oI\x17\x0b\xb6#\xd3\xeb\xad\xc1:GL" +
+	"\xc7\xfa5\xe4\xb3MS\xc4\xee\x16{\"\x96\xd6\x85}" +
+	"\xa7\x00\xf61\x0e\xcb\xb3X\xeb\xc2\xbe\xbe\x00\xfb\xda\xc6" +
+	"\xb5.\xec3.\xb09;\xbeH3[\x97\\\xb3\"" +
+	"&u\xfe\xe6\x0b\xe4\x1f\xb3\x1a\xf9\xa9\xddu \x0f\x04" +
+	"\xc8\x7fs\xe2\xbfIr5\xfc\xccF<\xacM\xee\xb9" +
+	"\x15\xcf\xeb\xec\xdb\xe4b\x92\x87xa\xc9V\xb5\x9f&" +
+	"\xf2\x7f\x01\x00\x00\xff\xffa\x08\x0a\xb4"
 
 func init() {
 	schemas.Register(schema_f33c8b5943a21269,
 		0x80433d2ee6f482f5,
+		0x83d16dbcd5814aaf,
 		0x8654dd0285fb2417,
 		0x87cc5066fed0778c,
+		0x8a5bb4a2615ad89f,
+		0x8d553e4dce3e28b6,
+		0x8f93aea5ec1f82bf,
 		0x913808ae28ff6473,
 		0x9290df923ae70938,
 		0x94bbbbef92ff28ab,
 		0x95c0951f289d3d1a,
 		0x963848ac762fb0d0,
 		0x997bc227bdb0ae49,
+		0xa1fcad99b548f00c,
 		0xaa07114fe8d013c2,
 		0xab31abe0b5f7949c,
 		0xad556cb9c8905a7b,
@@ -3112,18 +4824,30 @@ func init() {
 		0xc3ba28619686bf88,
 		0xc5a7633821f7b5fb,
 		0xc6a7b2614c3fad2c,
+		0xce9e5879358a3778,
 		0xd326fc0f35d8303b,
 		0xd5c39e93b94cc83b,
 		0xd6e9ff28b3be9b63,
+		0xd7cdc69e66967850,
 		0xdbe2a98693ac911d,
 		0xdfb8a690e8697e4a,
 		0xe4a8e3bfe7c72fdf,
 		0xe592b473a3368825,
 		0xe6bca3833ee86dc4,
+		0xe7e437619eb494e4,
+		0xea3be45741f707e8,
+		0xed10aa3b59cbdceb,
+		0xed5b053fbccce7e4,
 		0xee3e107dce1b7eee,
 		0xee76a18839fa1b8d,
+		0xef317bd3400242db,
+		0xef9ab0dc345bd87b,
+		0xefe3d7e66e426b7b,
 		0xeff2f7e09e4be774,
+		0xf332d65224b0cc6a,
+		0xf42f5607f8b83318,
 		0xf51ddefb42de8c74,
+		0xf86efd767ffc693b,
 		0xf9bfff17720dccba,
 		0xfb030c3f99d5f3de,
 		0xfb749bfeb39fd69c)
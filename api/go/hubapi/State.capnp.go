@@ -39,6 +39,22 @@ func (c CapState) CapClientState(ctx context.Context, params func(CapState_capCl
 	ans, release := capnp.Client(c).SendCall(ctx, s)
 	return CapState_capClientState_Results_Future{Future: ans.Future()}, release
 }
+func (c CapState) Backup(ctx context.Context, params func(CapState_backup_Params) error) (CapState_backup_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xd11db16b4eb22eec,
+			MethodID:      1,
+			InterfaceName: "hubapi/State.capnp:CapState",
+			MethodName:    "backup",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 1}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapState_backup_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapState_backup_Results_Future{Future: ans.Future()}, release
+}
 
 // String returns a string that identifies this capability for debugging
 // purposes.  Its format should not be depended on: in particular, it
@@ -108,6 +124,8 @@ func (c CapState) GetFlowLimiter() fc.FlowLimiter {
 } // A CapState_Server is a CapState with a local implementation.
 type CapState_Server interface {
 	CapClientState(context.Context, CapState_capClientState) error
+
+	Backup(context.Context, CapState_backup) error
 }
 
 // CapState_NewServer creates a new Server from an implementation of CapState_Server.
@@ -126,7 +144,7 @@ func CapState_ServerToClient(s CapState_Server) CapState {
 // This can be used to create a more complicated Server.
 func CapState_Methods(methods []server.Method, s CapState_Server) []server.Method {
 	if cap(methods) == 0 {
-		methods = make([]server.Method, 0, 1)
+		methods = make([]server.Method, 0, 2)
 	}
 
 	methods = append(methods, server.Method{
@@ -141,6 +159,18 @@ func CapState_Methods(methods []server.Method, s CapState_Server) []server.Metho
 		},
 	})
 
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xd11db16b4eb22eec,
+			MethodID:      1,
+			InterfaceName: "hubapi/State.capnp:CapState",
+			MethodName:    "backup",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Backup(ctx, CapState_backup{call})
+		},
+	})
+
 	return methods
 }
 
@@ -161,6 +191,23 @@ func (c CapState_capClientState) AllocResults() (CapState_capClientState_Results
 	return CapState_capClientState_Results(r), err
 }
 
+// CapState_backup holds the state for a server call to CapState.backup.
+// See server.Call for documentation.
+type CapState_backup struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapState_backup) Args() CapState_backup_Params {
+	return CapState_backup_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapState_backup) AllocResults() (CapState_backup_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapState_backup_Results(r), err
+}
+
 // CapState_List is a list of CapState.
 type CapState_List = capnp.CapList[CapState]
 
@@ -355,6 +402,153 @@ func (p CapState_capClientState_Results_Future) Cap() CapClientState {
 	return CapClientState(p.Future.Field(0, nil).Client())
 }
 
+type CapState_backup_Params capnp.Struct
+
+// CapState_backup_Params_TypeID is the unique identifier for the type CapState_backup_Params.
+const CapState_backup_Params_TypeID = 0xedf53bb8be824ca1
+
+func NewCapState_backup_Params(s *capnp.Segment) (CapState_backup_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapState_backup_Params(st), err
+}
+
+func NewRootCapState_backup_Params(s *capnp.Segment) (CapState_backup_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapState_backup_Params(st), err
+}
+
+func ReadRootCapState_backup_Params(msg *capnp.Message) (CapState_backup_Params, error) {
+	root, err := msg.Root()
+	return CapState_backup_Params(root.Struct()), err
+}
+
+func (s CapState_backup_Params) String() string {
+	str, _ := text.Marshal(0xedf53bb8be824ca1, capnp.Struct(s))
+	return str
+}
+
+func (s CapState_backup_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapState_backup_Params) DecodeFromPtr(p capnp.Ptr) CapState_backup_Params {
+	return CapState_backup_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapState_backup_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapState_backup_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapState_backup_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapState_backup_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapState_backup_Params) Directory() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapState_backup_Params) HasDirectory() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapState_backup_Params) DirectoryBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapState_backup_Params) SetDirectory(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+// CapState_backup_Params_List is a list of CapState_backup_Params.
+type CapState_backup_Params_List = capnp.StructList[CapState_backup_Params]
+
+// NewCapState_backup_Params creates a new list of CapState_backup_Params.
+func NewCapState_backup_Params_List(s *capnp.Segment, sz int32) (CapState_backup_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapState_backup_Params](l), err
+}
+
+// CapState_backup_Params_Future is a wrapper for a CapState_backup_Params promised by a client call.
+type CapState_backup_Params_Future struct{ *capnp.Future }
+
+func (f CapState_backup_Params_Future) Struct() (CapState_backup_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapState_backup_Params(p.Struct()), err
+}
+
+type CapState_backup_Results capnp.Struct
+
+// CapState_backup_Results_TypeID is the unique identifier for the type CapState_backup_Results.
+const CapState_backup_Results_TypeID = 0xf16aa8ea798f0f72
+
+func NewCapState_backup_Results(s *capnp.Segment) (CapState_backup_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapState_backup_Results(st), err
+}
+
+func NewRootCapState_backup_Results(s *capnp.Segment) (CapState_backup_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapState_backup_Results(st), err
+}
+
+func ReadRootCapState_backup_Results(msg *capnp.Message) (CapState_backup_Results, error) {
+	root, err := msg.Root()
+	return CapState_backup_Results(root.Struct()), err
+}
+
+func (s CapState_backup_Results) String() string {
+	str, _ := text.Marshal(0xf16aa8ea798f0f72, capnp.Struct(s))
+	return str
+}
+
+func (s CapState_backup_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapState_backup_Results) DecodeFromPtr(p capnp.Ptr) CapState_backup_Results {
+	return CapState_backup_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapState_backup_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapState_backup_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapState_backup_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapState_backup_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapState_backup_Results_List is a list of CapState_backup_Results.
+type CapState_backup_Results_List = capnp.StructList[CapState_backup_Results]
+
+// NewCapState_backup_Results creates a new list of CapState_backup_Results.
+func NewCapState_backup_Results_List(s *capnp.Segment, sz int32) (CapState_backup_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapState_backup_Results](l), err
+}
+
+// CapState_backup_Results_Future is a wrapper for a CapState_backup_Results promised by a client call.
+type CapState_backup_Results_Future struct{ *capnp.Future }
+
+func (f CapState_backup_Results_Future) Struct() (CapState_backup_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapState_backup_Results(p.Struct()), err
+}
+
 type CapClientState capnp.Client
 
 // CapClientState_TypeID is the unique identifier for the type CapClientState.
@@ -456,6 +650,118 @@ func (c CapClientState) SetMultiple(ctx context.Context, params func(CapClientSt
 	ans, release := capnp.Client(c).SendCall(ctx, s)
 	return CapClientState_setMultiple_Results_Future{Future: ans.Future()}, release
 }
+func (c CapClientState) PrefixCursor(ctx context.Context, params func(CapClientState_prefixCursor_Params) error) (CapClientState_prefixCursor_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      6,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "prefixCursor",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 1}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapClientState_prefixCursor_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapClientState_prefixCursor_Results_Future{Future: ans.Future()}, release
+}
+func (c CapClientState) RangeCursor(ctx context.Context, params func(CapClientState_rangeCursor_Params) error) (CapClientState_rangeCursor_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      7,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "rangeCursor",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 2}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapClientState_rangeCursor_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapClientState_rangeCursor_Results_Future{Future: ans.Future()}, release
+}
+func (c CapClientState) ListBuckets(ctx context.Context, params func(CapClientState_listBuckets_Params) error) (CapClientState_listBuckets_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      8,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "listBuckets",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 0}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapClientState_listBuckets_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapClientState_listBuckets_Results_Future{Future: ans.Future()}, release
+}
+func (c CapClientState) DeleteBucket(ctx context.Context, params func(CapClientState_deleteBucket_Params) error) (CapClientState_deleteBucket_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      9,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "deleteBucket",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 1}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapClientState_deleteBucket_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapClientState_deleteBucket_Results_Future{Future: ans.Future()}, release
+}
+func (c CapClientState) Info(ctx context.Context, params func(CapClientState_info_Params) error) (CapClientState_info_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      10,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "info",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 0}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapClientState_info_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapClientState_info_Results_Future{Future: ans.Future()}, release
+}
+func (c CapClientState) Begin(ctx context.Context, params func(CapClientState_begin_Params) error) (CapClientState_begin_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      11,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "begin",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 0, PointerCount: 0}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapClientState_begin_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapClientState_begin_Results_Future{Future: ans.Future()}, release
+}
+func (c CapClientState) CompareAndSet(ctx context.Context, params func(CapClientState_compareAndSet_Params) error) (CapClientState_compareAndSet_Results_Future, capnp.ReleaseFunc) {
+	s := capnp.Send{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      12,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "compareAndSet",
+		},
+	}
+	if params != nil {
+		s.ArgsSize = capnp.ObjectSize{DataSize: 8, PointerCount: 3}
+		s.PlaceArgs = func(s capnp.Struct) error { return params(CapClientState_compareAndSet_Params(s)) }
+	}
+	ans, release := capnp.Client(c).SendCall(ctx, s)
+	return CapClientState_compareAndSet_Results_Future{Future: ans.Future()}, release
+}
 
 // String returns a string that identifies this capability for debugging
 // purposes.  Its format should not be depended on: in particular, it
@@ -535,6 +841,20 @@ type CapClientState_Server interface {
 	Set(context.Context, CapClientState_set) error
 
 	SetMultiple(context.Context, CapClientState_setMultiple) error
+
+	PrefixCursor(context.Context, CapClientState_prefixCursor) error
+
+	RangeCursor(context.Context, CapClientState_rangeCursor) error
+
+	ListBuckets(context.Context, CapClientState_listBuckets) error
+
+	DeleteBucket(context.Context, CapClientState_deleteBucket) error
+
+	Info(context.Context, CapClientState_info) error
+
+	Begin(context.Context, CapClientState_begin) error
+
+	CompareAndSet(context.Context, CapClientState_compareAndSet) error
 }
 
 // CapClientState_NewServer creates a new Server from an implementation of CapClientState_Server.
@@ -553,7 +873,7 @@ func CapClientState_ServerToClient(s CapClientState_Server) CapClientState {
 // This can be used to create a more complicated Server.
 func CapClientState_Methods(methods []server.Method, s CapClientState_Server) []server.Method {
 	if cap(methods) == 0 {
-		methods = make([]server.Method, 0, 6)
+		methods = make([]server.Method, 0, 13)
 	}
 
 	methods = append(methods, server.Method{
@@ -628,28 +948,112 @@ func CapClientState_Methods(methods []server.Method, s CapClientState_Server) []
 		},
 	})
 
-	return methods
-}
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      6,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "prefixCursor",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.PrefixCursor(ctx, CapClientState_prefixCursor{call})
+		},
+	})
 
-// CapClientState_delete holds the state for a server call to CapClientState.delete.
-// See server.Call for documentation.
-type CapClientState_delete struct {
-	*server.Call
-}
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      7,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "rangeCursor",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.RangeCursor(ctx, CapClientState_rangeCursor{call})
+		},
+	})
 
-// Args returns the call's arguments.
-func (c CapClientState_delete) Args() CapClientState_delete_Params {
-	return CapClientState_delete_Params(c.Call.Args())
-}
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      8,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "listBuckets",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.ListBuckets(ctx, CapClientState_listBuckets{call})
+		},
+	})
 
-// AllocResults allocates the results struct.
-func (c CapClientState_delete) AllocResults() (CapClientState_delete_Results, error) {
-	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
-	return CapClientState_delete_Results(r), err
-}
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      9,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "deleteBucket",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.DeleteBucket(ctx, CapClientState_deleteBucket{call})
+		},
+	})
 
-// CapClientState_get holds the state for a server call to CapClientState.get.
-// See server.Call for documentation.
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      10,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "info",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Info(ctx, CapClientState_info{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      11,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "begin",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.Begin(ctx, CapClientState_begin{call})
+		},
+	})
+
+	methods = append(methods, server.Method{
+		Method: capnp.Method{
+			InterfaceID:   0xf78da3a18a6bdd8f,
+			MethodID:      12,
+			InterfaceName: "hubapi/State.capnp:CapClientState",
+			MethodName:    "compareAndSet",
+		},
+		Impl: func(ctx context.Context, call *server.Call) error {
+			return s.CompareAndSet(ctx, CapClientState_compareAndSet{call})
+		},
+	})
+
+	return methods
+}
+
+// CapClientState_delete holds the state for a server call to CapClientState.delete.
+// See server.Call for documentation.
+type CapClientState_delete struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapClientState_delete) Args() CapClientState_delete_Params {
+	return CapClientState_delete_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapClientState_delete) AllocResults() (CapClientState_delete_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapClientState_delete_Results(r), err
+}
+
+// CapClientState_get holds the state for a server call to CapClientState.get.
+// See server.Call for documentation.
 type CapClientState_get struct {
 	*server.Call
 }
@@ -733,6 +1137,125 @@ func (c CapClientState_setMultiple) AllocResults() (CapClientState_setMultiple_R
 	return CapClientState_setMultiple_Results(r), err
 }
 
+// CapClientState_prefixCursor holds the state for a server call to CapClientState.prefixCursor.
+// See server.Call for documentation.
+type CapClientState_prefixCursor struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapClientState_prefixCursor) Args() CapClientState_prefixCursor_Params {
+	return CapClientState_prefixCursor_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapClientState_prefixCursor) AllocResults() (CapClientState_prefixCursor_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_prefixCursor_Results(r), err
+}
+
+// CapClientState_rangeCursor holds the state for a server call to CapClientState.rangeCursor.
+// See server.Call for documentation.
+type CapClientState_rangeCursor struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapClientState_rangeCursor) Args() CapClientState_rangeCursor_Params {
+	return CapClientState_rangeCursor_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapClientState_rangeCursor) AllocResults() (CapClientState_rangeCursor_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_rangeCursor_Results(r), err
+}
+
+// CapClientState_listBuckets holds the state for a server call to CapClientState.listBuckets.
+// See server.Call for documentation.
+type CapClientState_listBuckets struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapClientState_listBuckets) Args() CapClientState_listBuckets_Params {
+	return CapClientState_listBuckets_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapClientState_listBuckets) AllocResults() (CapClientState_listBuckets_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_listBuckets_Results(r), err
+}
+
+// CapClientState_deleteBucket holds the state for a server call to CapClientState.deleteBucket.
+// See server.Call for documentation.
+type CapClientState_deleteBucket struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapClientState_deleteBucket) Args() CapClientState_deleteBucket_Params {
+	return CapClientState_deleteBucket_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapClientState_deleteBucket) AllocResults() (CapClientState_deleteBucket_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapClientState_deleteBucket_Results(r), err
+}
+
+// CapClientState_info holds the state for a server call to CapClientState.info.
+// See server.Call for documentation.
+type CapClientState_info struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapClientState_info) Args() CapClientState_info_Params {
+	return CapClientState_info_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapClientState_info) AllocResults() (CapClientState_info_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_info_Results(r), err
+}
+
+// CapClientState_begin holds the state for a server call to CapClientState.begin.
+// See server.Call for documentation.
+type CapClientState_begin struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapClientState_begin) Args() CapClientState_begin_Params {
+	return CapClientState_begin_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapClientState_begin) AllocResults() (CapClientState_begin_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_begin_Results(r), err
+}
+
+// CapClientState_compareAndSet holds the state for a server call to CapClientState.compareAndSet.
+// See server.Call for documentation.
+type CapClientState_compareAndSet struct {
+	*server.Call
+}
+
+// Args returns the call's arguments.
+func (c CapClientState_compareAndSet) Args() CapClientState_compareAndSet_Params {
+	return CapClientState_compareAndSet_Params(c.Call.Args())
+}
+
+// AllocResults allocates the results struct.
+func (c CapClientState_compareAndSet) AllocResults() (CapClientState_compareAndSet_Results, error) {
+	r, err := c.Call.AllocResults(capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return CapClientState_compareAndSet_Results(r), err
+}
+
 // CapClientState_List is a list of CapClientState.
 type CapClientState_List = capnp.CapList[CapClientState]
 
@@ -1693,89 +2216,1302 @@ func (f CapClientState_setMultiple_Results_Future) Struct() (CapClientState_setM
 	return CapClientState_setMultiple_Results(p.Struct()), err
 }
 
-const schema_9a80401eba6f7fe3 = "x\xda\xacVmh[U\x18~\xdf{ornF" +
-	"\xb3\xecx'\xb38\xad\x96\xcc\x8f\xa1\xeb\xd6\xda1\xa7" +
-	"\xa5\x91\x15F\x85\x95\xa4!?\xfc3\xbd\xcd\x0e56" +
-	"i\xefro&\x1d\xb8\xcd*\x0c*A,\x8a6L" +
-	"\\\xd1M\xdd\x0f];\xa6\xb6P&N\xd0L\x06\xce" +
-	"\x0fPd*\x1b\x03\xc1/P\xa6\xf4\x87^9'\xbd" +
-	"7\xa7\x1f\xd9R\xf0_r\xdf\xe7}\xce\xf3\xbe\xcf9" +
-	"\xe7=\x9b{\xd5\x98\xb6%\xfcI\x18\x94\xc4\xd1@\xd0" +
-	"=q*\xf7\xc5\xd7;W=\x03\xb4\x19\x01\x02H\x00" +
-	"\xda\xca\xc1Q\x044\xbe\x0dv\x02\xba\xb4|\xdb\x91/" +
-	"\x0f\x93ge\xc0\\p\x84\x03\x02\x84\x03\xbe\x7f\xf7\xe5" +
-	"\x0b\x9f\xfe9\xf6\x12\xd0\xf5>`\x03)q@\xbb\x00" +
-	"\xbcW:\xf3~\xf1H\xdb+@\x0d\xcd\xbd|ph" +
-	"\xe6\xd6\xd8\xa1\x12\x00\xb6\xa5H3\x1a\x8c\x10\x80\xe4c" +
-	"D\xc5d\x96(\x08\xe0vM\x9dyp\xddszI" +
-	"^\xd1$'8\xe1^A\xf8\xda\x03\x07N>\x1a<" +
-	"=!\x03^ y\x0exU\x00\xc6\xfey\xf3\xb7\xd4" +
-	"O\xe3'e\xc0,\x99\xe2\x80\xf3\x02\xb0\xf5\x87\xc8\xef" +
-	"\xf7\xa7\xd4I\x19\xf0+y\x82\x03\xe6\x04 0\x979" +
-	"\x1e\x99m\xf9\xa8\x02\xd0x\xbcQ\x9fB\xd0\xdc\xf4\x9d" +
-	"\xb3\x7f\x9dM&\xcarjH\x17\xea\x1au\x9ez\xf1" +
-	"\xe8x\xe9\xdc\xa9K\x9f\xcd\x03\x14\x0e\xe8\xd6\x05wJ" +
-	"\x7f\x12\xd0\xfde\xd3T\xcf\xc0\xe4-\x17\x80\x1a\xaa\xdc" +
-	"\x0fcR\xbfd\xcc\xea\x04\xc0\x98\xd6w\x1aW\xf8/" +
-	"\xd7\xd8\x95~\xeb\xdf\x8e\x17\xbf\x92\x84\x9c\xd7G\xb8\x90" +
-	"o\xdeyh\xef\x1b\xbb\xdf\xfeqI[\xa7\xf5V4" +
-	"\xca<;\xf9\xb1\xaeb\xf2s]\xb4\xf5\xbb\x99\x83\xc5" +
-	"\xf6\x91u\x97%\xa6\xb2>\xca\x99\xee\xe8\xc0s\xbb\xad" +
-	"\xb3W\xa4\xc8\xb4\x9e\xe7\x91\x96\xf5\xdb\x8e\xa77\xce\xfc" +
-	"<\xef\xad\xa8\xe5\x98>\xc6k9-jy\xfe\xe2\xc0" +
-	"\xe8\xc4\xeb\xc5\xbf\x17\xd7\xd2vc\xe8\x0646\x84x" +
-	"\xc2\xed\xa1\xc3h4\xae\"\xf0\x87\xfbx\xa1\xcf\xb42" +
-	"-\xc9\x80c:lS\xda\xb4\x06\xad\xed;LkG" +
-	"6\xc3\x06\x9dd\xe5c!o\x0f\xe5\xa3\xbd\xcc.d" +
-	"\x1d\x1b \xa1\xa9\x1a\x80\x86\x004\xdc\x0c\x90\xd0UL" +
-	"\xacU\x90\xa4M\x0b\xa9\x1b\xe9;\xd4p\xe0\xc3\x9b'" +
-	"\x00\x10)`=\x0b\xecaY\xe6\xb0h\xdc\xcc\x9b9" +
-	"\xbb&\xfd\x00\x1b\xc6\x06P\xb0\xa16i\xd2\xfb+\xb1" +
-	"Wt\xab\x8e}m\xd9~\xdb\x16\xc9V\xa5\x15l\xfe" +
-	";\xc9\xf2\xfb2i\xd6C\xcc\x1c\x8b#\xceK\x02\x8a" +
-	"\xadM\"^O\xc16sv\x15\xb2N\xc6\xca\xf2\xaa" +
-	"#\xbclY\xdd\xc6\xaa\xba\xc8\x9e\xa1\xb4\x8dk\xdc\xa7" +
-	"\x8a\x83[\x1f\xb9\xfa\xc1U.o\x8d$O\xab\xbdH" +
-	"?s\xa2\xbd\xacI\x98&\xb3\xb7V\xd9\x9b\xf6\x99\xd9" +
-	"\x02\xc30(\x18\xae\xcf\xaa~I\xf9r\xe4\xff\x9f\xf4" +
-	"e\xfa\xb2\xc2\xddP\xab\xe3\x9e\xee\x95\xd6{\x0d\xa7\xa2" +
-	"\x0aF\x06\xd8\xb0\x8d\xab\x01\xe3jeS\xac\xae\xafV" +
-	"[\xaaU\xf7\x99\xef\xe6\xb5FULlV\x90\"\xae" +
-	"E\xfe\xf1^n\xdd]*&\xee[\xd8\x80\x1a6*" +
-	"\x8b\x0f\x071\x1d\xbee\x13\x9a\x1a\x00\xf0o\x12\xf4\xc6" +
-	"\x05\xa5\xfbA\xa1!\xe2z\x07\x08:+\x1ac\x18\xc7" +
-	"*m\xe8\xba7\xc5\xfcA\xf6\x12$|\xda\xb4z\xcc" +
-	"\x1c\xab\xe44\x89\xc8\x823\xb4\x7f\xc9\xda+\xb8?\xfc" +
-	"\x0b\xaa\xde\xb6_o#,{\x9d\x88\xe2p\x81W\x0f" +
-	"Wm\xf1\xbd\xda\xc2\xbd\xbaG\xc5\xc46\x05\xdd\xb4\xc8" +
-	"\xef\xee\x02\x00\xdf3\xd3\xb2\xba\xbb\x96la\xb5\x96b" +
-	"\x14\xce\xdd$\x9c\xf3\x1e\x00\xe8\x0d\x10:\xb9\x1d\x14z" +
-	"\x8c`u\x8e\xa27\x92\xe9x3(\xb4HP\xf1\x07" +
-	"%z\xd3\x98>\xdd\x07\x0a\x1d&\xa8\xfaS\x0d\xbd\xe7" +
-	"\x07\xcdqN\x93\xa0\xe6\xcfO\xf4\xc6\x12Mq\xcen" +
-	"\x82\x01\xffi\x80\xde|\xa6\x1d\x9c\xb3\x9dtV\\\x89" +
-	"!\xe9gN\x0c]\xef$\x01\xb1\xb2,\x86\x9d\x95\xbd" +
-	"\x12Cb\x8b\xb0\xbd0\x1cG\xfc/\x00\x00\xff\xff\xa8" +
-	"\x0f\x9fF"
+type CapClientState_prefixCursor_Params capnp.Struct
+
+// CapClientState_prefixCursor_Params_TypeID is the unique identifier for the type CapClientState_prefixCursor_Params.
+const CapClientState_prefixCursor_Params_TypeID = 0x967726674806a7f6
+
+func NewCapClientState_prefixCursor_Params(s *capnp.Segment) (CapClientState_prefixCursor_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_prefixCursor_Params(st), err
+}
+
+func NewRootCapClientState_prefixCursor_Params(s *capnp.Segment) (CapClientState_prefixCursor_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_prefixCursor_Params(st), err
+}
+
+func ReadRootCapClientState_prefixCursor_Params(msg *capnp.Message) (CapClientState_prefixCursor_Params, error) {
+	root, err := msg.Root()
+	return CapClientState_prefixCursor_Params(root.Struct()), err
+}
+
+func (s CapClientState_prefixCursor_Params) String() string {
+	str, _ := text.Marshal(0x967726674806a7f6, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_prefixCursor_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_prefixCursor_Params) DecodeFromPtr(p capnp.Ptr) CapClientState_prefixCursor_Params {
+	return CapClientState_prefixCursor_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_prefixCursor_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_prefixCursor_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_prefixCursor_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_prefixCursor_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapClientState_prefixCursor_Params) Prefix() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapClientState_prefixCursor_Params) HasPrefix() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapClientState_prefixCursor_Params) PrefixBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapClientState_prefixCursor_Params) SetPrefix(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+// CapClientState_prefixCursor_Params_List is a list of CapClientState_prefixCursor_Params.
+type CapClientState_prefixCursor_Params_List = capnp.StructList[CapClientState_prefixCursor_Params]
+
+// NewCapClientState_prefixCursor_Params creates a new list of CapClientState_prefixCursor_Params.
+func NewCapClientState_prefixCursor_Params_List(s *capnp.Segment, sz int32) (CapClientState_prefixCursor_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapClientState_prefixCursor_Params](l), err
+}
+
+// CapClientState_prefixCursor_Params_Future is a wrapper for a CapClientState_prefixCursor_Params promised by a client call.
+type CapClientState_prefixCursor_Params_Future struct{ *capnp.Future }
+
+func (f CapClientState_prefixCursor_Params_Future) Struct() (CapClientState_prefixCursor_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_prefixCursor_Params(p.Struct()), err
+}
+
+type CapClientState_prefixCursor_Results capnp.Struct
+
+// CapClientState_prefixCursor_Results_TypeID is the unique identifier for the type CapClientState_prefixCursor_Results.
+const CapClientState_prefixCursor_Results_TypeID = 0xd776cf419d0d2ca5
+
+func NewCapClientState_prefixCursor_Results(s *capnp.Segment) (CapClientState_prefixCursor_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_prefixCursor_Results(st), err
+}
+
+func NewRootCapClientState_prefixCursor_Results(s *capnp.Segment) (CapClientState_prefixCursor_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_prefixCursor_Results(st), err
+}
+
+func ReadRootCapClientState_prefixCursor_Results(msg *capnp.Message) (CapClientState_prefixCursor_Results, error) {
+	root, err := msg.Root()
+	return CapClientState_prefixCursor_Results(root.Struct()), err
+}
+
+func (s CapClientState_prefixCursor_Results) String() string {
+	str, _ := text.Marshal(0xd776cf419d0d2ca5, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_prefixCursor_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_prefixCursor_Results) DecodeFromPtr(p capnp.Ptr) CapClientState_prefixCursor_Results {
+	return CapClientState_prefixCursor_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_prefixCursor_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_prefixCursor_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_prefixCursor_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_prefixCursor_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapClientState_prefixCursor_Results) Cap() CapBucketCursor {
+	p, _ := capnp.Struct(s).Ptr(0)
+	return CapBucketCursor(p.Interface().Client())
+}
+
+func (s CapClientState_prefixCursor_Results) HasCap() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapClientState_prefixCursor_Results) SetCap(v CapBucketCursor) error {
+	if !v.IsValid() {
+		return capnp.Struct(s).SetPtr(0, capnp.Ptr{})
+	}
+	seg := s.Segment()
+	in := capnp.NewInterface(seg, seg.Message().AddCap(capnp.Client(v)))
+	return capnp.Struct(s).SetPtr(0, in.ToPtr())
+}
+
+// CapClientState_prefixCursor_Results_List is a list of CapClientState_prefixCursor_Results.
+type CapClientState_prefixCursor_Results_List = capnp.StructList[CapClientState_prefixCursor_Results]
+
+// NewCapClientState_prefixCursor_Results creates a new list of CapClientState_prefixCursor_Results.
+func NewCapClientState_prefixCursor_Results_List(s *capnp.Segment, sz int32) (CapClientState_prefixCursor_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapClientState_prefixCursor_Results](l), err
+}
+
+// CapClientState_prefixCursor_Results_Future is a wrapper for a CapClientState_prefixCursor_Results promised by a client call.
+type CapClientState_prefixCursor_Results_Future struct{ *capnp.Future }
+
+func (f CapClientState_prefixCursor_Results_Future) Struct() (CapClientState_prefixCursor_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_prefixCursor_Results(p.Struct()), err
+}
+func (p CapClientState_prefixCursor_Results_Future) Cap() CapBucketCursor {
+	return CapBucketCursor(p.Future.Field(0, nil).Client())
+}
+
+type CapClientState_rangeCursor_Params capnp.Struct
+
+// CapClientState_rangeCursor_Params_TypeID is the unique identifier for the type CapClientState_rangeCursor_Params.
+const CapClientState_rangeCursor_Params_TypeID = 0xd4d2313b48cf8678
+
+func NewCapClientState_rangeCursor_Params(s *capnp.Segment) (CapClientState_rangeCursor_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return CapClientState_rangeCursor_Params(st), err
+}
+
+func NewRootCapClientState_rangeCursor_Params(s *capnp.Segment) (CapClientState_rangeCursor_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return CapClientState_rangeCursor_Params(st), err
+}
+
+func ReadRootCapClientState_rangeCursor_Params(msg *capnp.Message) (CapClientState_rangeCursor_Params, error) {
+	root, err := msg.Root()
+	return CapClientState_rangeCursor_Params(root.Struct()), err
+}
+
+func (s CapClientState_rangeCursor_Params) String() string {
+	str, _ := text.Marshal(0xd4d2313b48cf8678, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_rangeCursor_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_rangeCursor_Params) DecodeFromPtr(p capnp.Ptr) CapClientState_rangeCursor_Params {
+	return CapClientState_rangeCursor_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_rangeCursor_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_rangeCursor_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_rangeCursor_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_rangeCursor_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapClientState_rangeCursor_Params) StartKey() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapClientState_rangeCursor_Params) HasStartKey() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapClientState_rangeCursor_Params) StartKeyBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapClientState_rangeCursor_Params) SetStartKey(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s CapClientState_rangeCursor_Params) EndKey() (string, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.Text(), err
+}
+
+func (s CapClientState_rangeCursor_Params) HasEndKey() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s CapClientState_rangeCursor_Params) EndKeyBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s CapClientState_rangeCursor_Params) SetEndKey(v string) error {
+	return capnp.Struct(s).SetText(1, v)
+}
+
+// CapClientState_rangeCursor_Params_List is a list of CapClientState_rangeCursor_Params.
+type CapClientState_rangeCursor_Params_List = capnp.StructList[CapClientState_rangeCursor_Params]
+
+// NewCapClientState_rangeCursor_Params creates a new list of CapClientState_rangeCursor_Params.
+func NewCapClientState_rangeCursor_Params_List(s *capnp.Segment, sz int32) (CapClientState_rangeCursor_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return capnp.StructList[CapClientState_rangeCursor_Params](l), err
+}
+
+// CapClientState_rangeCursor_Params_Future is a wrapper for a CapClientState_rangeCursor_Params promised by a client call.
+type CapClientState_rangeCursor_Params_Future struct{ *capnp.Future }
+
+func (f CapClientState_rangeCursor_Params_Future) Struct() (CapClientState_rangeCursor_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_rangeCursor_Params(p.Struct()), err
+}
+
+type CapClientState_rangeCursor_Results capnp.Struct
+
+// CapClientState_rangeCursor_Results_TypeID is the unique identifier for the type CapClientState_rangeCursor_Results.
+const CapClientState_rangeCursor_Results_TypeID = 0xab6b1d93aaed2686
+
+func NewCapClientState_rangeCursor_Results(s *capnp.Segment) (CapClientState_rangeCursor_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_rangeCursor_Results(st), err
+}
+
+func NewRootCapClientState_rangeCursor_Results(s *capnp.Segment) (CapClientState_rangeCursor_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_rangeCursor_Results(st), err
+}
+
+func ReadRootCapClientState_rangeCursor_Results(msg *capnp.Message) (CapClientState_rangeCursor_Results, error) {
+	root, err := msg.Root()
+	return CapClientState_rangeCursor_Results(root.Struct()), err
+}
+
+func (s CapClientState_rangeCursor_Results) String() string {
+	str, _ := text.Marshal(0xab6b1d93aaed2686, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_rangeCursor_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_rangeCursor_Results) DecodeFromPtr(p capnp.Ptr) CapClientState_rangeCursor_Results {
+	return CapClientState_rangeCursor_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_rangeCursor_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_rangeCursor_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_rangeCursor_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_rangeCursor_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapClientState_rangeCursor_Results) Cap() CapBucketCursor {
+	p, _ := capnp.Struct(s).Ptr(0)
+	return CapBucketCursor(p.Interface().Client())
+}
+
+func (s CapClientState_rangeCursor_Results) HasCap() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapClientState_rangeCursor_Results) SetCap(v CapBucketCursor) error {
+	if !v.IsValid() {
+		return capnp.Struct(s).SetPtr(0, capnp.Ptr{})
+	}
+	seg := s.Segment()
+	in := capnp.NewInterface(seg, seg.Message().AddCap(capnp.Client(v)))
+	return capnp.Struct(s).SetPtr(0, in.ToPtr())
+}
+
+// CapClientState_rangeCursor_Results_List is a list of CapClientState_rangeCursor_Results.
+type CapClientState_rangeCursor_Results_List = capnp.StructList[CapClientState_rangeCursor_Results]
+
+// NewCapClientState_rangeCursor_Results creates a new list of CapClientState_rangeCursor_Results.
+func NewCapClientState_rangeCursor_Results_List(s *capnp.Segment, sz int32) (CapClientState_rangeCursor_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapClientState_rangeCursor_Results](l), err
+}
+
+// CapClientState_rangeCursor_Results_Future is a wrapper for a CapClientState_rangeCursor_Results promised by a client call.
+type CapClientState_rangeCursor_Results_Future struct{ *capnp.Future }
+
+func (f CapClientState_rangeCursor_Results_Future) Struct() (CapClientState_rangeCursor_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_rangeCursor_Results(p.Struct()), err
+}
+func (p CapClientState_rangeCursor_Results_Future) Cap() CapBucketCursor {
+	return CapBucketCursor(p.Future.Field(0, nil).Client())
+}
+
+type CapClientState_listBuckets_Params capnp.Struct
+
+// CapClientState_listBuckets_Params_TypeID is the unique identifier for the type CapClientState_listBuckets_Params.
+const CapClientState_listBuckets_Params_TypeID = 0x99bc0cff9b45871a
+
+func NewCapClientState_listBuckets_Params(s *capnp.Segment) (CapClientState_listBuckets_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapClientState_listBuckets_Params(st), err
+}
+
+func NewRootCapClientState_listBuckets_Params(s *capnp.Segment) (CapClientState_listBuckets_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapClientState_listBuckets_Params(st), err
+}
+
+func ReadRootCapClientState_listBuckets_Params(msg *capnp.Message) (CapClientState_listBuckets_Params, error) {
+	root, err := msg.Root()
+	return CapClientState_listBuckets_Params(root.Struct()), err
+}
+
+func (s CapClientState_listBuckets_Params) String() string {
+	str, _ := text.Marshal(0x99bc0cff9b45871a, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_listBuckets_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_listBuckets_Params) DecodeFromPtr(p capnp.Ptr) CapClientState_listBuckets_Params {
+	return CapClientState_listBuckets_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_listBuckets_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_listBuckets_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_listBuckets_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_listBuckets_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapClientState_listBuckets_Params_List is a list of CapClientState_listBuckets_Params.
+type CapClientState_listBuckets_Params_List = capnp.StructList[CapClientState_listBuckets_Params]
+
+// NewCapClientState_listBuckets_Params creates a new list of CapClientState_listBuckets_Params.
+func NewCapClientState_listBuckets_Params_List(s *capnp.Segment, sz int32) (CapClientState_listBuckets_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapClientState_listBuckets_Params](l), err
+}
+
+// CapClientState_listBuckets_Params_Future is a wrapper for a CapClientState_listBuckets_Params promised by a client call.
+type CapClientState_listBuckets_Params_Future struct{ *capnp.Future }
+
+func (f CapClientState_listBuckets_Params_Future) Struct() (CapClientState_listBuckets_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_listBuckets_Params(p.Struct()), err
+}
+
+type CapClientState_listBuckets_Results capnp.Struct
+
+// CapClientState_listBuckets_Results_TypeID is the unique identifier for the type CapClientState_listBuckets_Results.
+const CapClientState_listBuckets_Results_TypeID = 0xdf21ae5704532ff2
+
+func NewCapClientState_listBuckets_Results(s *capnp.Segment) (CapClientState_listBuckets_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_listBuckets_Results(st), err
+}
+
+func NewRootCapClientState_listBuckets_Results(s *capnp.Segment) (CapClientState_listBuckets_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_listBuckets_Results(st), err
+}
+
+func ReadRootCapClientState_listBuckets_Results(msg *capnp.Message) (CapClientState_listBuckets_Results, error) {
+	root, err := msg.Root()
+	return CapClientState_listBuckets_Results(root.Struct()), err
+}
+
+func (s CapClientState_listBuckets_Results) String() string {
+	str, _ := text.Marshal(0xdf21ae5704532ff2, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_listBuckets_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_listBuckets_Results) DecodeFromPtr(p capnp.Ptr) CapClientState_listBuckets_Results {
+	return CapClientState_listBuckets_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_listBuckets_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_listBuckets_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_listBuckets_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_listBuckets_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapClientState_listBuckets_Results) BucketIDs() (capnp.TextList, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return capnp.TextList(p.List()), err
+}
+
+func (s CapClientState_listBuckets_Results) HasBucketIDs() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapClientState_listBuckets_Results) SetBucketIDs(v capnp.TextList) error {
+	return capnp.Struct(s).SetPtr(0, v.ToPtr())
+}
+
+// NewBucketIDs sets the bucketIDs field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s CapClientState_listBuckets_Results) NewBucketIDs(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(capnp.Struct(s).Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, l.ToPtr())
+	return l, err
+}
+
+// CapClientState_listBuckets_Results_List is a list of CapClientState_listBuckets_Results.
+type CapClientState_listBuckets_Results_List = capnp.StructList[CapClientState_listBuckets_Results]
+
+// NewCapClientState_listBuckets_Results creates a new list of CapClientState_listBuckets_Results.
+func NewCapClientState_listBuckets_Results_List(s *capnp.Segment, sz int32) (CapClientState_listBuckets_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapClientState_listBuckets_Results](l), err
+}
+
+// CapClientState_listBuckets_Results_Future is a wrapper for a CapClientState_listBuckets_Results promised by a client call.
+type CapClientState_listBuckets_Results_Future struct{ *capnp.Future }
+
+func (f CapClientState_listBuckets_Results_Future) Struct() (CapClientState_listBuckets_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_listBuckets_Results(p.Struct()), err
+}
+
+type CapClientState_deleteBucket_Params capnp.Struct
+
+// CapClientState_deleteBucket_Params_TypeID is the unique identifier for the type CapClientState_deleteBucket_Params.
+const CapClientState_deleteBucket_Params_TypeID = 0xb29869d3364d6f41
+
+func NewCapClientState_deleteBucket_Params(s *capnp.Segment) (CapClientState_deleteBucket_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_deleteBucket_Params(st), err
+}
+
+func NewRootCapClientState_deleteBucket_Params(s *capnp.Segment) (CapClientState_deleteBucket_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_deleteBucket_Params(st), err
+}
+
+func ReadRootCapClientState_deleteBucket_Params(msg *capnp.Message) (CapClientState_deleteBucket_Params, error) {
+	root, err := msg.Root()
+	return CapClientState_deleteBucket_Params(root.Struct()), err
+}
+
+func (s CapClientState_deleteBucket_Params) String() string {
+	str, _ := text.Marshal(0xb29869d3364d6f41, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_deleteBucket_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_deleteBucket_Params) DecodeFromPtr(p capnp.Ptr) CapClientState_deleteBucket_Params {
+	return CapClientState_deleteBucket_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_deleteBucket_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_deleteBucket_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_deleteBucket_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_deleteBucket_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapClientState_deleteBucket_Params) BucketID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapClientState_deleteBucket_Params) HasBucketID() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapClientState_deleteBucket_Params) BucketIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapClientState_deleteBucket_Params) SetBucketID(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+// CapClientState_deleteBucket_Params_List is a list of CapClientState_deleteBucket_Params.
+type CapClientState_deleteBucket_Params_List = capnp.StructList[CapClientState_deleteBucket_Params]
+
+// NewCapClientState_deleteBucket_Params creates a new list of CapClientState_deleteBucket_Params.
+func NewCapClientState_deleteBucket_Params_List(s *capnp.Segment, sz int32) (CapClientState_deleteBucket_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapClientState_deleteBucket_Params](l), err
+}
+
+// CapClientState_deleteBucket_Params_Future is a wrapper for a CapClientState_deleteBucket_Params promised by a client call.
+type CapClientState_deleteBucket_Params_Future struct{ *capnp.Future }
+
+func (f CapClientState_deleteBucket_Params_Future) Struct() (CapClientState_deleteBucket_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_deleteBucket_Params(p.Struct()), err
+}
+
+type CapClientState_deleteBucket_Results capnp.Struct
+
+// CapClientState_deleteBucket_Results_TypeID is the unique identifier for the type CapClientState_deleteBucket_Results.
+const CapClientState_deleteBucket_Results_TypeID = 0x838659ffc8cefad3
+
+func NewCapClientState_deleteBucket_Results(s *capnp.Segment) (CapClientState_deleteBucket_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapClientState_deleteBucket_Results(st), err
+}
+
+func NewRootCapClientState_deleteBucket_Results(s *capnp.Segment) (CapClientState_deleteBucket_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapClientState_deleteBucket_Results(st), err
+}
+
+func ReadRootCapClientState_deleteBucket_Results(msg *capnp.Message) (CapClientState_deleteBucket_Results, error) {
+	root, err := msg.Root()
+	return CapClientState_deleteBucket_Results(root.Struct()), err
+}
+
+func (s CapClientState_deleteBucket_Results) String() string {
+	str, _ := text.Marshal(0x838659ffc8cefad3, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_deleteBucket_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_deleteBucket_Results) DecodeFromPtr(p capnp.Ptr) CapClientState_deleteBucket_Results {
+	return CapClientState_deleteBucket_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_deleteBucket_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_deleteBucket_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_deleteBucket_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_deleteBucket_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapClientState_deleteBucket_Results_List is a list of CapClientState_deleteBucket_Results.
+type CapClientState_deleteBucket_Results_List = capnp.StructList[CapClientState_deleteBucket_Results]
+
+// NewCapClientState_deleteBucket_Results creates a new list of CapClientState_deleteBucket_Results.
+func NewCapClientState_deleteBucket_Results_List(s *capnp.Segment, sz int32) (CapClientState_deleteBucket_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapClientState_deleteBucket_Results](l), err
+}
+
+// CapClientState_deleteBucket_Results_Future is a wrapper for a CapClientState_deleteBucket_Results promised by a client call.
+type CapClientState_deleteBucket_Results_Future struct{ *capnp.Future }
+
+func (f CapClientState_deleteBucket_Results_Future) Struct() (CapClientState_deleteBucket_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_deleteBucket_Results(p.Struct()), err
+}
+
+type CapClientState_info_Params capnp.Struct
+
+// CapClientState_info_Params_TypeID is the unique identifier for the type CapClientState_info_Params.
+const CapClientState_info_Params_TypeID = 0x94d80e97b5f0ed28
+
+func NewCapClientState_info_Params(s *capnp.Segment) (CapClientState_info_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapClientState_info_Params(st), err
+}
+
+func NewRootCapClientState_info_Params(s *capnp.Segment) (CapClientState_info_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapClientState_info_Params(st), err
+}
+
+func ReadRootCapClientState_info_Params(msg *capnp.Message) (CapClientState_info_Params, error) {
+	root, err := msg.Root()
+	return CapClientState_info_Params(root.Struct()), err
+}
+
+func (s CapClientState_info_Params) String() string {
+	str, _ := text.Marshal(0x94d80e97b5f0ed28, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_info_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_info_Params) DecodeFromPtr(p capnp.Ptr) CapClientState_info_Params {
+	return CapClientState_info_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_info_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_info_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_info_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_info_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapClientState_info_Params_List is a list of CapClientState_info_Params.
+type CapClientState_info_Params_List = capnp.StructList[CapClientState_info_Params]
+
+// NewCapClientState_info_Params creates a new list of CapClientState_info_Params.
+func NewCapClientState_info_Params_List(s *capnp.Segment, sz int32) (CapClientState_info_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapClientState_info_Params](l), err
+}
+
+// CapClientState_info_Params_Future is a wrapper for a CapClientState_info_Params promised by a client call.
+type CapClientState_info_Params_Future struct{ *capnp.Future }
+
+func (f CapClientState_info_Params_Future) Struct() (CapClientState_info_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_info_Params(p.Struct()), err
+}
+
+type CapClientState_info_Results capnp.Struct
+
+// CapClientState_info_Results_TypeID is the unique identifier for the type CapClientState_info_Results.
+const CapClientState_info_Results_TypeID = 0xb30169ab3d741def
+
+func NewCapClientState_info_Results(s *capnp.Segment) (CapClientState_info_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_info_Results(st), err
+}
+
+func NewRootCapClientState_info_Results(s *capnp.Segment) (CapClientState_info_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_info_Results(st), err
+}
+
+func ReadRootCapClientState_info_Results(msg *capnp.Message) (CapClientState_info_Results, error) {
+	root, err := msg.Root()
+	return CapClientState_info_Results(root.Struct()), err
+}
+
+func (s CapClientState_info_Results) String() string {
+	str, _ := text.Marshal(0xb30169ab3d741def, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_info_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_info_Results) DecodeFromPtr(p capnp.Ptr) CapClientState_info_Results {
+	return CapClientState_info_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_info_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_info_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_info_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_info_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapClientState_info_Results) Info() (BucketStoreInfo, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return BucketStoreInfo(p.Struct()), err
+}
+
+func (s CapClientState_info_Results) HasInfo() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapClientState_info_Results) SetInfo(v BucketStoreInfo) error {
+	return capnp.Struct(s).SetPtr(0, capnp.Struct(v).ToPtr())
+}
+
+// NewInfo sets the info field to a newly
+// allocated BucketStoreInfo struct, preferring placement in s's segment.
+func (s CapClientState_info_Results) NewInfo() (BucketStoreInfo, error) {
+	ss, err := NewBucketStoreInfo(capnp.Struct(s).Segment())
+	if err != nil {
+		return BucketStoreInfo{}, err
+	}
+	err = capnp.Struct(s).SetPtr(0, capnp.Struct(ss).ToPtr())
+	return ss, err
+}
+
+// CapClientState_info_Results_List is a list of CapClientState_info_Results.
+type CapClientState_info_Results_List = capnp.StructList[CapClientState_info_Results]
+
+// NewCapClientState_info_Results creates a new list of CapClientState_info_Results.
+func NewCapClientState_info_Results_List(s *capnp.Segment, sz int32) (CapClientState_info_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapClientState_info_Results](l), err
+}
+
+// CapClientState_info_Results_Future is a wrapper for a CapClientState_info_Results promised by a client call.
+type CapClientState_info_Results_Future struct{ *capnp.Future }
+
+func (f CapClientState_info_Results_Future) Struct() (CapClientState_info_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_info_Results(p.Struct()), err
+}
+func (p CapClientState_info_Results_Future) Info() BucketStoreInfo_Future {
+	return BucketStoreInfo_Future{Future: p.Future.Field(0, nil)}
+}
+
+type CapClientState_begin_Params capnp.Struct
+
+// CapClientState_begin_Params_TypeID is the unique identifier for the type CapClientState_begin_Params.
+const CapClientState_begin_Params_TypeID = 0xa70cdc2cb3241986
+
+func NewCapClientState_begin_Params(s *capnp.Segment) (CapClientState_begin_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapClientState_begin_Params(st), err
+}
+
+func NewRootCapClientState_begin_Params(s *capnp.Segment) (CapClientState_begin_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0})
+	return CapClientState_begin_Params(st), err
+}
+
+func ReadRootCapClientState_begin_Params(msg *capnp.Message) (CapClientState_begin_Params, error) {
+	root, err := msg.Root()
+	return CapClientState_begin_Params(root.Struct()), err
+}
+
+func (s CapClientState_begin_Params) String() string {
+	str, _ := text.Marshal(0xa70cdc2cb3241986, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_begin_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_begin_Params) DecodeFromPtr(p capnp.Ptr) CapClientState_begin_Params {
+	return CapClientState_begin_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_begin_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_begin_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_begin_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_begin_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+
+// CapClientState_begin_Params_List is a list of CapClientState_begin_Params.
+type CapClientState_begin_Params_List = capnp.StructList[CapClientState_begin_Params]
+
+// NewCapClientState_begin_Params creates a new list of CapClientState_begin_Params.
+func NewCapClientState_begin_Params_List(s *capnp.Segment, sz int32) (CapClientState_begin_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 0}, sz)
+	return capnp.StructList[CapClientState_begin_Params](l), err
+}
+
+// CapClientState_begin_Params_Future is a wrapper for a CapClientState_begin_Params promised by a client call.
+type CapClientState_begin_Params_Future struct{ *capnp.Future }
+
+func (f CapClientState_begin_Params_Future) Struct() (CapClientState_begin_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_begin_Params(p.Struct()), err
+}
+
+type CapClientState_begin_Results capnp.Struct
+
+// CapClientState_begin_Results_TypeID is the unique identifier for the type CapClientState_begin_Results.
+const CapClientState_begin_Results_TypeID = 0xedd17939d75074d3
+
+func NewCapClientState_begin_Results(s *capnp.Segment) (CapClientState_begin_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_begin_Results(st), err
+}
+
+func NewRootCapClientState_begin_Results(s *capnp.Segment) (CapClientState_begin_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return CapClientState_begin_Results(st), err
+}
+
+func ReadRootCapClientState_begin_Results(msg *capnp.Message) (CapClientState_begin_Results, error) {
+	root, err := msg.Root()
+	return CapClientState_begin_Results(root.Struct()), err
+}
+
+func (s CapClientState_begin_Results) String() string {
+	str, _ := text.Marshal(0xedd17939d75074d3, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_begin_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_begin_Results) DecodeFromPtr(p capnp.Ptr) CapClientState_begin_Results {
+	return CapClientState_begin_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_begin_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_begin_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_begin_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_begin_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapClientState_begin_Results) Cap() CapBucketTx {
+	p, _ := capnp.Struct(s).Ptr(0)
+	return CapBucketTx(p.Interface().Client())
+}
+
+func (s CapClientState_begin_Results) HasCap() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapClientState_begin_Results) SetCap(v CapBucketTx) error {
+	if !v.IsValid() {
+		return capnp.Struct(s).SetPtr(0, capnp.Ptr{})
+	}
+	seg := s.Segment()
+	in := capnp.NewInterface(seg, seg.Message().AddCap(capnp.Client(v)))
+	return capnp.Struct(s).SetPtr(0, in.ToPtr())
+}
+
+// CapClientState_begin_Results_List is a list of CapClientState_begin_Results.
+type CapClientState_begin_Results_List = capnp.StructList[CapClientState_begin_Results]
+
+// NewCapClientState_begin_Results creates a new list of CapClientState_begin_Results.
+func NewCapClientState_begin_Results_List(s *capnp.Segment, sz int32) (CapClientState_begin_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return capnp.StructList[CapClientState_begin_Results](l), err
+}
+
+// CapClientState_begin_Results_Future is a wrapper for a CapClientState_begin_Results promised by a client call.
+type CapClientState_begin_Results_Future struct{ *capnp.Future }
+
+func (f CapClientState_begin_Results_Future) Struct() (CapClientState_begin_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_begin_Results(p.Struct()), err
+}
+func (p CapClientState_begin_Results_Future) Cap() CapBucketTx {
+	return CapBucketTx(p.Future.Field(0, nil).Client())
+}
+
+type CapClientState_compareAndSet_Params capnp.Struct
+
+// CapClientState_compareAndSet_Params_TypeID is the unique identifier for the type CapClientState_compareAndSet_Params.
+const CapClientState_compareAndSet_Params_TypeID = 0xa24f499ecac50ff0
+
+func NewCapClientState_compareAndSet_Params(s *capnp.Segment) (CapClientState_compareAndSet_Params, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return CapClientState_compareAndSet_Params(st), err
+}
+
+func NewRootCapClientState_compareAndSet_Params(s *capnp.Segment) (CapClientState_compareAndSet_Params, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return CapClientState_compareAndSet_Params(st), err
+}
+
+func ReadRootCapClientState_compareAndSet_Params(msg *capnp.Message) (CapClientState_compareAndSet_Params, error) {
+	root, err := msg.Root()
+	return CapClientState_compareAndSet_Params(root.Struct()), err
+}
+
+func (s CapClientState_compareAndSet_Params) String() string {
+	str, _ := text.Marshal(0xa24f499ecac50ff0, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_compareAndSet_Params) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_compareAndSet_Params) DecodeFromPtr(p capnp.Ptr) CapClientState_compareAndSet_Params {
+	return CapClientState_compareAndSet_Params(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_compareAndSet_Params) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_compareAndSet_Params) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_compareAndSet_Params) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_compareAndSet_Params) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapClientState_compareAndSet_Params) Key() (string, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.Text(), err
+}
+
+func (s CapClientState_compareAndSet_Params) HasKey() bool {
+	return capnp.Struct(s).HasPtr(0)
+}
+
+func (s CapClientState_compareAndSet_Params) KeyBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CapClientState_compareAndSet_Params) SetKey(v string) error {
+	return capnp.Struct(s).SetText(0, v)
+}
+
+func (s CapClientState_compareAndSet_Params) OldValue() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(1)
+	return []byte(p.Data()), err
+}
+
+func (s CapClientState_compareAndSet_Params) HasOldValue() bool {
+	return capnp.Struct(s).HasPtr(1)
+}
+
+func (s CapClientState_compareAndSet_Params) SetOldValue(v []byte) error {
+	return capnp.Struct(s).SetData(1, v)
+}
+
+func (s CapClientState_compareAndSet_Params) OldValueExists() bool {
+	return capnp.Struct(s).Bit(0)
+}
+
+func (s CapClientState_compareAndSet_Params) SetOldValueExists(v bool) {
+	capnp.Struct(s).SetBit(0, v)
+}
+
+func (s CapClientState_compareAndSet_Params) NewValue() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s CapClientState_compareAndSet_Params) HasNewValue() bool {
+	return capnp.Struct(s).HasPtr(2)
+}
+
+func (s CapClientState_compareAndSet_Params) SetNewValue(v []byte) error {
+	return capnp.Struct(s).SetData(2, v)
+}
+
+// CapClientState_compareAndSet_Params_List is a list of CapClientState_compareAndSet_Params.
+type CapClientState_compareAndSet_Params_List = capnp.StructList[CapClientState_compareAndSet_Params]
+
+// NewCapClientState_compareAndSet_Params creates a new list of CapClientState_compareAndSet_Params.
+func NewCapClientState_compareAndSet_Params_List(s *capnp.Segment, sz int32) (CapClientState_compareAndSet_Params_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
+	return capnp.StructList[CapClientState_compareAndSet_Params](l), err
+}
+
+// CapClientState_compareAndSet_Params_Future is a wrapper for a CapClientState_compareAndSet_Params promised by a client call.
+type CapClientState_compareAndSet_Params_Future struct{ *capnp.Future }
+
+func (f CapClientState_compareAndSet_Params_Future) Struct() (CapClientState_compareAndSet_Params, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_compareAndSet_Params(p.Struct()), err
+}
+
+type CapClientState_compareAndSet_Results capnp.Struct
+
+// CapClientState_compareAndSet_Results_TypeID is the unique identifier for the type CapClientState_compareAndSet_Results.
+const CapClientState_compareAndSet_Results_TypeID = 0xfc2461b1f586c568
+
+func NewCapClientState_compareAndSet_Results(s *capnp.Segment) (CapClientState_compareAndSet_Results, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return CapClientState_compareAndSet_Results(st), err
+}
+
+func NewRootCapClientState_compareAndSet_Results(s *capnp.Segment) (CapClientState_compareAndSet_Results, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0})
+	return CapClientState_compareAndSet_Results(st), err
+}
+
+func ReadRootCapClientState_compareAndSet_Results(msg *capnp.Message) (CapClientState_compareAndSet_Results, error) {
+	root, err := msg.Root()
+	return CapClientState_compareAndSet_Results(root.Struct()), err
+}
+
+func (s CapClientState_compareAndSet_Results) String() string {
+	str, _ := text.Marshal(0xfc2461b1f586c568, capnp.Struct(s))
+	return str
+}
+
+func (s CapClientState_compareAndSet_Results) EncodeAsPtr(seg *capnp.Segment) capnp.Ptr {
+	return capnp.Struct(s).EncodeAsPtr(seg)
+}
+
+func (CapClientState_compareAndSet_Results) DecodeFromPtr(p capnp.Ptr) CapClientState_compareAndSet_Results {
+	return CapClientState_compareAndSet_Results(capnp.Struct{}.DecodeFromPtr(p))
+}
+
+func (s CapClientState_compareAndSet_Results) ToPtr() capnp.Ptr {
+	return capnp.Struct(s).ToPtr()
+}
+func (s CapClientState_compareAndSet_Results) IsValid() bool {
+	return capnp.Struct(s).IsValid()
+}
+
+func (s CapClientState_compareAndSet_Results) Message() *capnp.Message {
+	return capnp.Struct(s).Message()
+}
+
+func (s CapClientState_compareAndSet_Results) Segment() *capnp.Segment {
+	return capnp.Struct(s).Segment()
+}
+func (s CapClientState_compareAndSet_Results) Swapped() bool {
+	return capnp.Struct(s).Bit(0)
+}
+
+func (s CapClientState_compareAndSet_Results) SetSwapped(v bool) {
+	capnp.Struct(s).SetBit(0, v)
+}
+
+// CapClientState_compareAndSet_Results_List is a list of CapClientState_compareAndSet_Results.
+type CapClientState_compareAndSet_Results_List = capnp.StructList[CapClientState_compareAndSet_Results]
+
+// NewCapClientState_compareAndSet_Results creates a new list of CapClientState_compareAndSet_Results.
+func NewCapClientState_compareAndSet_Results_List(s *capnp.Segment, sz int32) (CapClientState_compareAndSet_Results_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 0}, sz)
+	return capnp.StructList[CapClientState_compareAndSet_Results](l), err
+}
+
+// CapClientState_compareAndSet_Results_Future is a wrapper for a CapClientState_compareAndSet_Results promised by a client call.
+type CapClientState_compareAndSet_Results_Future struct{ *capnp.Future }
+
+func (f CapClientState_compareAndSet_Results_Future) Struct() (CapClientState_compareAndSet_Results, error) {
+	p, err := f.Future.Ptr()
+	return CapClientState_compareAndSet_Results(p.Struct()), err
+}
+
+const schema_9a80401eba6f7fe3 = "x\xda\xacX{l\x1c\xc5\x19\xff\xbe\xdd\xb3\xd7\xe0\xbd" +
+	"\xdb\xdb\xac\xab\x94\x16\xd7\xaduM\x93(!\xd8\x86\x08" +
+	"\x92Xw\xceC$\xd0\x04oN\xb4J\x1f\xa0\xf5y" +
+	"p.>\xdfmo\xf7\xe2\xd8UIH\xc1Q\xd2Z" +
+	"\x88\x94\x14\xec\xd2\x87\xdbB \x88\x948JK\xacR" +
+	"*\x90%\x92\xaaE&T\x80ZJ\x01\xa1\x96\xb6." +
+	"\xa4\x95\xa9\xa8\x9a.\x9a\xd9\x9b\xf5\x9c}\xb6\xcfR\xfe" +
+	"\xb3o~\xdfc\xbe\xe7\xfc\xf6\xda\x0d\xd5\x89PS\xb8" +
+	"k)H\xc9(VU{'N\xf7\xbc\xf4\xfb\x9b\xae" +
+	"\xfc&\xe8\x8d\x08P\x85\x0a@\xcbP\xed\x11\x044\x1e" +
+	"\xaf\x8d\x03z\x17\xfe\xfb\xbb\x17\xbc]\x03E@\x88\x9e" +
+	"\x9f\xab\x1dC\x08y\xfa\xb9O?|\xe1\x90r\x8f(" +
+	"z\xb6\xf6 \x15\x1dg\xa2\xcb'\xdf?\xf3`\xe4\xd5" +
+	"\x07\x04\xd1wj\xf3T\xf4O?{p\xe2\x85\x7f\x1f" +
+	"=\x06\xfa\xd5\x81\xe8D\xed0\x15}\x93\x89~p\xbc" +
+	"zk\xd7\xb2\xde\xef\x8a\xbaQ\x1d\xa5\x00]\xa5\x80\x9f" +
+	"\x0f?\xfb\x8b\xc1\x87[\x1e\x02\xdd\x08yo\xef\xcf\x8d" +
+	"}*q`\x18\x00[\x9a\xd4F4\xdaT\x05 \xb9" +
+	"A\x951\xb9U\x95\x10\xc0\xfb\xc4\xa1-\xdf\xf3\xd4_" +
+	"\x0e\x09\xbe\xb4\xaa'\xa8/\x9bG\x9f\xdd\xb0\xf4[5" +
+	"\xc3\xa2\xa9\xd5\xf4\x08\x8dVf\xea\x87\xeb\xefz\xea\x8e" +
+	"\xea3#\"\xe0\xabj\x9e\x02\xd2\x0c\xf0\xbe6~\xfe" +
+	"\x07\xdbn\xfd1\x98\x8dH\x112E\x1cV\xc7(b" +
+	"H\xfd\x0b\xa07pU\xec\xf4\xaa?\xaa\xc7\x05\xeb}" +
+	"\xe1~j}`\xd9\xe4\x89\xef\xd4w?!*'a" +
+	"v\xd1B\x98*?z\xe9\xf8?o\xfb\xeb\xd0S\"" +
+	"\xe0\x98\x0fx\x84\x01\xd6\xbe\xa1\xbdw\xe3m\xf2)\x11" +
+	"0\x1e\xdeC\x01\x13\x0c\xd0\x96\xdb\xbe\xf6B\xfa\xa1Q" +
+	"\x11p\xd1\xd7\x80\x11\x0ax\xaf\xdem}\"\x8d\xa7E" +
+	"\xc0g#\xfd\x14\xd0\xc4\x00U\x1f\xa6\x1f\xd5\x9eY\xf3" +
+	"\x9c\xe0\xbe\x19\x19\xa5\xee\xa7>\xf7\xcc\x07\xcf'\xcds" +
+	"\xa2h[\x84\x05\xcfd\xa2\xaf\xffhh\xf8\xfc\xe9\xb7" +
+	"~S\x04H\x14pw\x84y7\x18\xe9\x05\xf4\xfeq" +
+	"\xcd\xe8\x8e\xeeS\xf5\x13\xa0\x1b\xb2\x98H\xe3\xdd\xc8[" +
+	"\xc6TD\x010.F\x0e\x19[4\x05\xc03\xb6\xa7" +
+	"\x1e\xfb\x7f\xeb\x03/\x0b\x8e\xac\xd6\x0eRG\xf6\x0d\xbc" +
+	"\xb8u}\xd3K/\x8bv\xae\xd2\x98#+4j\xe7" +
+	"\x91U\xe1\xef\xb7\xbd\xb8\xf7\x15\xd1\xd3\xc3\x9a\x9f#\x8d" +
+	"z\xfa\xaf5\xc9\xd0\x17O~\xe6\x8d\x92r\xd6X\x98" +
+	"\xce1\xc0\xab'\xdb\xbe\xf6\xd3\xdb\x1f\xff\xf3\xac\x92\xbb" +
+	"\xa45\xa3\x11\x8e\xd2\x92\xab\x89\xca\x98\xac\x8b\xb2\x92\xfb" +
+	"\xc3\xd8\xfe\xc1\xeb\x0f.}[p6\x1c=B\x9d]" +
+	"\xd6\x8a\xe7o\xb7\x9f\x7fG8\xb9\xa4\xb1\xc6Xs\xf5" +
+	"\x0d\x8f\xa6V\x8e\xfd\xbd\xd8\x18\xec\x1a\xefjG\xa9\x13" +
+	"\x1f\xb2k\\p\xdb_\xb9\xb1obR\xf4rW\x94" +
+	"5\x1d\x89R/G>\x7f\xf0WO\xaf\x9f\x9a\x14[" +
+	"\xeb\xde\xe8N\x0a\xb8\x9f\x01\xf2\xda}}\x7f{l\xcf" +
+	"E\x1f\xc0\x8c\x9f\x89~\x89\x1a\xbf\xef\xf5\xee##?" +
+	"\x19\xfc\xcf\xccT\xb4\x8cD\x97\xa0q\x8a^\xb0\xe5\xc9" +
+	"\xe8M\xb21\xb1\x84&c\xf7\xf8\xc0\xd4)+\xf6\xbf" +
+	"b\xd93Mg\x97<\xc7\x02\xb6$\x0e\x1e\xf4z\xbb" +
+	"\x0b\x1d\x96\x9d^\x93\xacr-\x97\\\x93\xb2\xec\xac\xbd" +
+	"n\x93eo\xca\xa4I\xd6M\xfa?\x16\xf2N.\x1f" +
+	"\xdbI\x9cB\xc6u\x00\xcc\x90\x1c\x02\x08!\x80\x1en" +
+	"\x040kd4\xeb$TR\x96\x8d\xba\xa7u\x1cP" +
+	"\xef\xfa\xf5'G\x00\x10u\xc0J\x0ct\x92\x0cq\xc9" +
+	"\xc6B\xaa\x9b\xb8\xb1\x9dq\xdfN\xe5\x82\xb1v+o" +
+	"\xf58s\xfa\xd5M\xfaP\x05\x09U\xc1\x9b\xd0\xdcJ" +
+	"\xd3\xd9;s\xb1\xf6\x06\xa6s.'\x92\xfc_A\xd0" +
+	"\x0f\x90\xec:\xf3\xc7'Ha\xe5\xf1\xb1\xf3\xe4\xce\xf4" +
+	"\xbeM~\x1a\x8a\x9e\x89F\xd6M\x1b\x89\xfb\xd8Y\xf7" +
+	"\x95\x05\xed\x0e\xfd;I\xf2{\xd3)\xb2C\xb1zH" +
+	";bQ\x00tln`\xe7\x95\xf8\x95I;\xae\x9f" +
+	"5'\xd6ni\xf3\x05L\x14s\x88\xbb\xbd\x90q\xd3" +
+	"v\x86p1\xf16+\xa7o\xa3u\xe6R\x0eF\xbd" +
+	"o\x0cf\xd7\xee\x9azz\x8a\xc6,ZY\x16\xbbh" +
+	")\x91\x06VJ\xa2\xf6\xe6i\xed\x0d{\xadL\x81`" +
+	"\x18$\x0cW\x96\x88T\xae\xc7\xb6\xf2\xa4-\xdb\x99$" +
+	"n,\xee\xd7\x9d\x19\x0d\xb4[4\xdd_\x91\xd1\xdc-" +
+	"\xa1\x8eXG\x9bN'7\x03\x98\x9d2\x9a\xb6\x84(" +
+	"\xd5\xa1\x04\xa0\xf7\xf4\x03\x98\x19\x19\xcd}\x12\xea\xb2T" +
+	"\x872\x80^\xa0@WF\xf3\xc0\x8c\xa2\xcde:\xbf" +
+	"@]\x05\x80\xc0\xdb\xe0\xb7\xf8\x96}i\xc7u\x10A" +
+	"B\x04\xf4\xb2\xa4w\x16\xb8\x82xu\x90\xaet\x96_" +
+	"\xa9\x92X\xe4\xadl\x17\xd9\xc4G\xc3\xec@_\x96\xc9" +
+	"\xd0%\x94J9#\x97\xafV\xca\x14\xe2B3\xa4\xd2" +
+	"\x89V\xa6ci\xa6U\x19\xcd\x8fK\xe8u0\xd4\xb6" +
+	"\xcd4a\x8b\x1dS|X\xce\x15\x13\x0a\xc2\xa8\xd7\xfa" +
+	"\xda\xc6\x93k\xef\xb8\xe7\xdb3cRa\x93\xf2\xc8/" +
+	"6c\xf34wLB\xad\x9b\xf49\x18\x01l\x97\xfd" +
+	"\xf1\x13\xa9\xec\xe2\x8e\x90\xad\x9a@\xf3\x0a\x9a\xad\x98\x8c" +
+	"\xe6\xb5B\xeb\xad\xa6\xdd\xbe\\F\xf3\xba\xd2\x14\xce\xd1" +
+	"\xf9\xd2\xcc!\xafX.\x1d\x8ef\x8d\\\x05\x10l~" +
+	"\xe4oc\xbd\xa9\x1f$}\x85\x82\xd3;\x1d\xf9\xee\xd6" +
+	"\xeb\xd7\x81\xa4\xeb\x8a\xc7\x97\x04\xc4}\xff\x13\x18\xef\xb0" +
+	"R\xdd\x05;\x81\xed8m\xbbv\xc1\xfd[\xdcr\\" +
+	"\xa0\xb2\xce,\x13\xa8\x9b\xa7c\x12\x04\xaa\x89\xae\x90U" +
+	"2\x9a7H\xe89\xae\x95wo!}BA\xc6I" +
+	"\xb6\xf3\x96\xc5\xb5@\xc9\xd2*W\xa7\x97e@\x88+" +
+	"\xa8\xdc\x80\xd8Yl\xb4\xe5b\xa3\xe1\x9ce'\xae\xc9" +
+	"\x94e\xef\xb0z\x88o\xad\x81\x99+Y\x94\xfd\xb3R" +
+	"\xbb\x88'K\xf0\x98\xaa\xb4\xe2\x17\xea\xc1\xb2/\x12V" +
+	"2\xb8p\xf6\x9b\x85\xec\xa7\x98|\xc98j\xb0l{" +
+	"\xdb\xe6\xc5$\xdf\xdf&\xfc\x92\xf3g=\xfd\xe5\xf6\xa1" +
+	"[\xbf\x1e;93\xeb\xa1r\x17\xf4['\xb8X\x99" +
+	"L\xd3\x91\xda\x99\xce\x93\x94\x9b\xcb\x03.\xf8\xf2+Q" +
+	"\xcb_pe\xeb\xa1\xe4\x8a\xc8\xe6\xc2ul.p\x96" +
+	"\x8d\x9cN\x18\xf7\xe3:\x90\x8c{\x91N\x06N\xfe\x90" +
+	"\x93T\xa3\x0f\x1bA2zPA)`g\xc8I\xa4" +
+	"aa\x07H\xc6.TP\x0e\xc8\x14\xf2o\x00\xc6v" +
+	"\xa6\xb9\x0d\x15\x0c\x05\xc4\x0d9Y1\xaeg\x9aW\xa0" +
+	"\x82U\x01iFN\x0d\x8dz\xa6\xf9c\xa8`u\xc0" +
+	"\xde\x91\x93.\xe3\x0a\xdc\x03\x92\x81\xa8\xa0\x12P5\xe4" +
+	"\xdcW\x9f\xea\x00I\x9fT\xb0&\xa0\xe9\xc8\xd9\x98\xfe" +
+	"&={M\xc1+\x02\x1e\x8b\xfc\x93\x84\xfe\xdb= " +
+	"\xe9\xe3\x0a^\x19|j@\xcee\xf5\xb3+A\xd2\x9f" +
+	"T\xb06 \xdf\xc8\xb9\x93>\xd2\x0c\x92~LA5" +
+	"\xa0\xee\xc8\xc9\x8c~8\x0f\x92~\xb7\x12\xf7[)\x81" +
+	"J\x17q\x13\xe8\xf1\xcd\x03\x8a\x9d\xa1S\xd6\x1f\x9b\x09" +
+	"T\x1cv\xec\x94\x1e{|>\x81\xe6\xc3<>6A" +
+	"\xf1\xff\xe7\xa3\x05\x14\xe2:\x09\xf4\xf8N\x07\x8d\x8e\x91" +
+	"\x84\xbf\\\x13\xd8\xc0j=\x81\x1e\x7f\x1cB\x03{\x1e" +
+	"\x96\x8e\xf7\xeaJ\x1f\x95\xe5zf\xe3t\xcf\xecwz" +
+	"-\xdb&\x9d\xfc\xb9\xf7Q\x00\x00\x00\xff\xff\x7f\xcc7" +
+	"\xe7"
 
 func init() {
 	schemas.Register(schema_9a80401eba6f7fe3,
 		0x830a47d5d26db3aa,
+		0x838659ffc8cefad3,
 		0x840787d39c20c911,
+		0x94d80e97b5f0ed28,
 		0x9592f3c8d197afde,
+		0x967726674806a7f6,
 		0x98339c8db7bf9ab6,
+		0x99bc0cff9b45871a,
 		0x9a088b173cbfb244,
 		0xa1b5065fb07e3b9f,
+		0xa24f499ecac50ff0,
+		0xa70cdc2cb3241986,
+		0xab6b1d93aaed2686,
 		0xb099e855eea7fd92,
 		0xb1035539ef0fdf36,
+		0xb29869d3364d6f41,
+		0xb30169ab3d741def,
 		0xc22fbd0fa669f905,
 		0xc95153c3f6bd2763,
 		0xcbe2b3ca9a99a0dd,
 		0xd11db16b4eb22eec,
 		0xd4943dfea8634d13,
+		0xd4d2313b48cf8678,
+		0xd776cf419d0d2ca5,
+		0xdf21ae5704532ff2,
 		0xe0a95ea47141aed8,
 		0xe31782358d7fbadb,
 		0xe5c3705eca013d26,
 		0xebba2a63a6381c2f,
-		0xf78da3a18a6bdd8f)
+		0xedd17939d75074d3,
+		0xedf53bb8be824ca1,
+		0xf16aa8ea798f0f72,
+		0xf78da3a18a6bdd8f,
+		0xfc2461b1f586c568)
 }
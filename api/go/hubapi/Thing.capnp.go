@@ -19,12 +19,12 @@ type ThingValue capnp.Struct
 const ThingValue_TypeID = 0x9bd6e69db8968092
 
 func NewThingValue(s *capnp.Segment) (ThingValue, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 5})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6})
 	return ThingValue(st), err
 }
 
 func NewRootThingValue(s *capnp.Segment) (ThingValue, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 5})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6})
 	return ThingValue(st), err
 }
 
@@ -145,12 +145,38 @@ func (s ThingValue) SetCreated(v string) error {
 	return capnp.Struct(s).SetText(4, v)
 }
 
+func (s ThingValue) Expiry() int64 {
+	return int64(capnp.Struct(s).Uint64(0))
+}
+
+func (s ThingValue) SetExpiry(v int64) {
+	capnp.Struct(s).SetUint64(0, uint64(v))
+}
+
+func (s ThingValue) RequestID() (string, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return p.Text(), err
+}
+
+func (s ThingValue) HasRequestID() bool {
+	return capnp.Struct(s).HasPtr(5)
+}
+
+func (s ThingValue) RequestIDBytes() ([]byte, error) {
+	p, err := capnp.Struct(s).Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s ThingValue) SetRequestID(v string) error {
+	return capnp.Struct(s).SetText(5, v)
+}
+
 // ThingValue_List is a list of ThingValue.
 type ThingValue_List = capnp.StructList[ThingValue]
 
 // NewThingValue creates a new list of ThingValue.
 func NewThingValue_List(s *capnp.Segment, sz int32) (ThingValue_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 5}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6}, sz)
 	return capnp.StructList[ThingValue](l), err
 }
 
@@ -359,38 +385,42 @@ func (p ThingValueMap_Entry_Future) Value() ThingValue_Future {
 	return ThingValue_Future{Future: p.Future.Field(1, nil)}
 }
 
-const schema_bb31fb6e03b18e9a = "x\xda\x84\x92\xcdkSA\x14\xc5\xcf\xb9\xf3b\x8a4" +
-	"\xb6\xc3\x0b\x08n\xa2RE\x85\xd4I\xfa\xa1dS\x11" +
-	"\x15\xba(vhu\xe7\xe2\xa5}4\xc1\xf65\xa6/" +
-	"JAI\x05\x0b\x15\xeaW\x11\x11\xc5\xa5\xbap\xa3;" +
-	"\xc1?@\x04\xdd\xb8\x10\x17\xba\x11u\xe1F\x10\\\x89" +
-	"<\x99`\x93*\x88\xbb{\xef\xdc{\xce\xfd\xcd\x8cQ" +
-	"<\xe4\x152\xcf\x05b\xb7\xa76%kK\xb7\x9e\xde" +
-	"\xfb\xfc\xe6.\xb4\xcf\xe4\xce\xd5'*\xfaQx\x86T" +
-	"*\x0d\xf8\xa3\xfc\xe6\x9f`\x1a\x18\xb0\xcc\x11L\x1e\xbc" +
-	"\x1bh\xae\xbd>\xf5\x10z\x07\x93\xdd\x83\xfe\xd8\xc5k" +
-	"\x8f^\"%\xae%\x94\x12\xfd\x86\x0b\xfd3r\x0e\xfc" +
-	"\xba\xeb\xfe\xe3\xf4\x87\xe5\x17\xda\xf7:\xba\xe0\xc0+\xd9" +
-	"F\xff\xbd\xeb\x9bx+\x8a\x13\x1fE\x08t\xd4\xfe\xdc" +
-	"\xa3\xe5\xfeI6\xd3\xff.[\x01\xff\xa7\x8c \x9fT" +
-	"\x1a\xe5\xa0V\xdd?)\x95j4\xd3?\x15\xd4\xa2Z" +
-	"i\xd2\xc5\xb9\x93\xc1l#\x1c'mVy\x80G@" +
-	"_(\x03\xf6\xbc\xa2]\x11j2KW\\>\x0c\xd8" +
-	"%E\xbb*\xd4\"Y\x0a\xa0/\xef\x03\xec%E{" +
-	"]\xa8\x95\xcaR\x01\xfa\x8a+\xae(\xda\x9bB\xedy" +
-	"Yz\x80\xbe\xe1\xc6W\x15\xedmaRk\x94g\xab" +
-	"\x0b\x95\x10\xe9\xfa\xe8\x11vC\xd8\x0d6c\xb7O'" +
-	"\xef\x89\x82\xb9\xb0\x9dL\x07q\xc0\x0c\x84\x19\xb09U" +
-	"\x0f\x838\x9c^?l\xd3\xa9\xbf\xe9ZpcAm" +
-	"\xa4\xffh\x14\xd7\x17\x1dfW\x1bs\xefN\xc0\xf6)" +
-	"Z\xb3\x013_\x04\xec\x1eE;(L\x9f\x0e\x17\xd7" +
-	"=rg\x9d\x14{;\xcf\x0f\xb2\xf7\x1f\xde3\xf3\xa3" +
-	"\x13\xc7\x0f\x0e\x9b\xc2\xb1\xf9\xfa\x9c\x0a\xe2q\xf2\xb7\x0e" +
-	"4\xbf$Ec\x86\xf3\xa6\x90\x17S\x9c,\x0c\x95\xcc" +
-	"`\xc9\x0c\xf5\x1bc\xf2=\xe6\x801\xff\xa7\x01\xac\xc7" +
-	"\x8d\x7f\x8b\xc5\\\x8b\xcfzm\xb8\x8c\xbb\xef.E\xdb" +
-	"'l\x86Q\\\xaf\x86\x0b\xdc\x02\x8e+\xb2\xb73\x0a" +
-	"\xba\xe2\xaf\x00\x00\x00\xff\xff\xf4c\xb6I"
+const schema_bb31fb6e03b18e9a = "x\xda\x84\x92Oh\x13[\x14\xc6\xbf\xef\x9e\x99\xa4<" +
+	"\x9a\xd7^&\xf0\xe0!TK\x15\x15Ro\xd2?J" +
+	"6\x15Q!\x8bb\xaf\x8d\x82\x0b\xc1i;\xb4\xc16" +
+	"M\xd3\x89\x9aUt\xe1\xd6B\x17.\x14\x97\xea\xc2\x8d" +
+	"\x82BA\x85\x0a\x15[\xa8PE\\u\xa3\x0b\x17\x82" +
+	"\x88n]\x8cL\xb1I\x11\xc4\xdd9g\xe6|\xe7|" +
+	"\xbf{L7\x8f:\xd9\xd4+\x05ew\xbb\x89h\xf1" +
+	"\xea\xcd\xa5;\x9f\xde\xdf\x86\xf5\xc8\xe8\xd6\x8dGR\xfe" +
+	"\x91}\x0a7\x91\x04\xbc\x02\xbf{g\x98\x04\xfa,\x17" +
+	"\x08F\xf76\xfb\x1a\x8bo\xcf\xdf\x87\xde\xc3h_\xbf" +
+	"7|m\xe1\xc1:\\\x15\xff\xf2A\xe5\xe9}\x8bC" +
+	"\xef\x8b\xba\x0c~\xdd{\xf7a\xf2\xe3\xf55\xed9-" +
+	"]\xb0\xaf \xff\xd3;'I`\xb4(\xc2\xd1\x0b\xa2" +
+	"\x08\xb4\xd4\xb4\xb7s\x8f\xad\xe9\xbe\xfcCoN\xfe\x03" +
+	"\xbc\xba\x0c!\x13M\xd5\xc6\xfcJ\xe9PQM\x95\xca" +
+	"\x93\xbd\xe3~\xa5\\\xc9\x17\xe3\xb8\xeb\xac?]\x0bF" +
+	"H\xbbK\x1c\xc0!\xa0\x9f\x8c\x01\xf6\xb1\xd0.+j" +
+	"2\xcd\xb8\xf8\xfc\x18`\x97\x84vEQ+\x95\xa6\x02" +
+	"\xf4\x8b\x83\x80}&\xb4\xab\x8aZ$M\x01\xf4\xcb\xb8" +
+	"\xb8,\xb4\xeb\x8a\xdaq\xd2t\x00\xbd\x16\xb7\xaf\x08\xed" +
+	"\x86\"\xdd4]@\xbf\xce\x03vUh\xdf)\xea\x84" +
+	"\x9bf\x02\xd0oN\x03vCh7\x15\xa3Jml" +
+	"\xba4?\x15 Y-\x1cg;\x14\xdb\xc1F\x18/" +
+	"\xde\xca;\xca\xfeL\xd0L&\xfc\xd0g\x0a\x8a)\xb0" +
+	"1^\x0d\xfc0\x98\xd8\xfe8\x14\\\xa9\x94\xaau\xba" +
+	"Pt\xc1\xa8\x1a\xcc\xd5\x82\xf9\xb0\x006\xc5\x9a\xa4\xe4" +
+	"wR[\xa0\x86\xfd\xcaP\xef\x89rX\xad\xc7\xc8\xda" +
+	"\x9a\xc8\x0et\x03\xb6Gh\xcd\x0ed\x99\x1c`\xf7\x0b" +
+	"m\xbfb\xf2bP\xdf\x9e\xd1u)\x96bg\xeb\x96" +
+	"@v\xfea\xf6\xe4la\xf4\xd4\x91A\x93=9[" +
+	"\x9d\x11?\x1c!\x7f\xe9@\xf3s\x943f0c\xb2" +
+	"\x19er\xc5\xec@\xde\xf4\xe7\xcd@\xaf1&\xd3a" +
+	"\x0e\x1b\xf3w7\x80u\xb8\xf3N\x99\xeb\xda\xf2g\x9d" +
+	"\xa6\xb9T\xfcvmB\xdb\xa3\xd8\x08\xcaa\xb5\x14\xcc" +
+	"\xf3_pD\xc8\xceV+\x18\x17\x7f\x06\x00\x00\xff\xff" +
+	"\x13H\xc7("
 
 func init() {
 	schemas.Register(schema_bb31fb6e03b18e9a,
@@ -0,0 +1,220 @@
+package backupcli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hiveot/hub/lib/hubclient"
+	"github.com/hiveot/hub/lib/svcconfig"
+	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/directory"
+	dircapnpclient "github.com/hiveot/hub/pkg/directory/capnpclient"
+	dirservice "github.com/hiveot/hub/pkg/directory/service"
+	"github.com/hiveot/hub/pkg/history"
+	histcapnpclient "github.com/hiveot/hub/pkg/history/capnpclient"
+	histconfig "github.com/hiveot/hub/pkg/history/config"
+	histservice "github.com/hiveot/hub/pkg/history/service"
+	"github.com/hiveot/hub/pkg/state"
+	statecapnpclient "github.com/hiveot/hub/pkg/state/capnpclient"
+	stateconfig "github.com/hiveot/hub/pkg/state/config"
+	stateservice "github.com/hiveot/hub/pkg/state/service"
+)
+
+// BackupServices are the services whose stores are included in a backup.
+// Each service writes its snapshot in a subdirectory with the service name.
+var BackupServices = []string{state.ServiceName, directory.ServiceName, history.ServiceName}
+
+// BackupCommand creates a backup of the service stores while the services are running
+func BackupCommand(ctx context.Context, runFolder *string) *cli.Command {
+	return &cli.Command{
+		Name:      "backup",
+		Category:  "backup",
+		Usage:     "Backup the state, directory and history stores to a directory or a .tar file",
+		ArgsUsage: "<directory|file.tar>",
+		Action: func(cCtx *cli.Context) error {
+			if cCtx.NArg() != 1 {
+				return fmt.Errorf("backup directory or tar file expected")
+			}
+			err := HandleBackup(ctx, *runFolder, cCtx.Args().First())
+			return err
+		},
+	}
+}
+
+// RestoreCommand restores the service stores from a backup while the services are stopped
+func RestoreCommand(ctx context.Context, homeFolder *string) *cli.Command {
+	return &cli.Command{
+		Name:      "restore",
+		Category:  "backup",
+		Usage:     "Restore the state, directory and history stores from a backup. The services must be stopped.",
+		ArgsUsage: "<directory|file.tar>",
+		Action: func(cCtx *cli.Context) error {
+			if cCtx.NArg() != 1 {
+				return fmt.Errorf("backup directory or tar file expected")
+			}
+			err := HandleRestore(ctx, *homeFolder, cCtx.Args().First())
+			return err
+		},
+	}
+}
+
+// HandleBackup asks the state, directory and history services to write a snapshot of their store.
+// The snapshots are written to a subdirectory of the backup directory for each service.
+// If the target is a .tar file then the snapshots are written to a temporary directory
+// and combined in the tar file.
+//
+//	runFolder is the folder with the service sockets
+//	target is the backup directory or tar file
+func HandleBackup(ctx context.Context, runFolder string, target string) error {
+	// the services run in their own working directory
+	target, err := filepath.Abs(target)
+	if err != nil {
+		return err
+	}
+	backupDir := target
+	if isTarFile(target) {
+		backupDir, err = os.MkdirTemp("", "hubbackup-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(backupDir)
+	}
+	for _, serviceName := range BackupServices {
+		serviceDir := path.Join(backupDir, serviceName)
+		fmt.Printf("Backup of service '%s' to '%s'\n", serviceName, serviceDir)
+		err = backupService(ctx, runFolder, serviceName, serviceDir)
+		if err != nil {
+			return fmt.Errorf("backup of service '%s' failed: %w", serviceName, err)
+		}
+	}
+	if isTarFile(target) {
+		var fp *os.File
+		fp, err = os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		err = bucketstore.WriteTar(fp, backupDir)
+		err2 := fp.Close()
+		if err == nil {
+			err = err2
+		}
+	}
+	if err == nil {
+		fmt.Printf("Backup written to '%s'\n", target)
+	}
+	return err
+}
+
+// backupService asks the service to write a snapshot of its store into the service directory
+func backupService(ctx context.Context, runFolder string, serviceName string, serviceDir string) error {
+	capClient, err := hubclient.ConnectWithCapnpUDS(serviceName, runFolder)
+	if err != nil {
+		return err
+	}
+	defer capClient.Release()
+	switch serviceName {
+	case state.ServiceName:
+		svc := statecapnpclient.NewStateCapnpClient(capClient)
+		err = svc.Backup(ctx, serviceDir)
+	case directory.ServiceName:
+		svc := dircapnpclient.NewDirectoryCapnpClient(capClient)
+		err = svc.Backup(ctx, serviceDir)
+	case history.ServiceName:
+		svc := histcapnpclient.NewHistoryCapnpClient(capClient)
+		err = svc.Backup(ctx, serviceDir)
+	default:
+		err = fmt.Errorf("service '%s' has no backup", serviceName)
+	}
+	return err
+}
+
+// HandleRestore restores the state, directory and history stores from a backup made with HandleBackup.
+// The services must be stopped as their stores are replaced.
+// Services that are not in the backup are skipped.
+//
+//	homeFolder is the hub home folder used to locate the service configuration and stores
+//	source is the backup directory or tar file
+func HandleRestore(_ context.Context, homeFolder string, source string) (err error) {
+	f := svcconfig.GetFolders(homeFolder, false)
+	backupDir := source
+	if isTarFile(source) {
+		var fp *os.File
+		fp, err = os.Open(source)
+		if err != nil {
+			return err
+		}
+		backupDir, err = os.MkdirTemp("", "hubrestore-")
+		if err == nil {
+			defer os.RemoveAll(backupDir)
+			err = bucketstore.ReadTar(fp, backupDir)
+		}
+		_ = fp.Close()
+		if err != nil {
+			return err
+		}
+	}
+	// first check all services before restoring any of them
+	for _, serviceName := range BackupServices {
+		if isServiceRunning(f.Run, serviceName) {
+			return fmt.Errorf("service '%s' is running. Stop it first", serviceName)
+		}
+	}
+	for _, serviceName := range BackupServices {
+		serviceDir := path.Join(backupDir, serviceName)
+		if _, err2 := os.Stat(serviceDir); err2 != nil {
+			fmt.Printf("Service '%s' is not in the backup. Skipped.\n", serviceName)
+			continue
+		}
+		fmt.Printf("Restore of service '%s' from '%s'\n", serviceName, serviceDir)
+		f.ConfigFile = path.Join(f.Config, serviceName+".yaml")
+		err = restoreService(f, serviceName, serviceDir)
+		if err != nil {
+			return fmt.Errorf("restore of service '%s' failed: %w", serviceName, err)
+		}
+	}
+	fmt.Printf("Restore from '%s' completed\n", source)
+	return nil
+}
+
+// restoreService restores the store of a stopped service using its configuration
+func restoreService(f svcconfig.AppFolders, serviceName string, serviceDir string) (err error) {
+	switch serviceName {
+	case state.ServiceName:
+		cfg := stateconfig.NewStateConfig(f.Stores)
+		_ = f.LoadConfig(&cfg)
+		err = stateservice.NewStateStoreService(cfg).Restore(serviceDir)
+	case directory.ServiceName:
+		err = dirservice.NewDirectoryStore(f.Stores, "").Restore(serviceDir)
+	case history.ServiceName:
+		cfg := histconfig.NewHistoryConfig(f.Stores)
+		_ = f.LoadConfig(&cfg)
+		err = histservice.NewHistoryBucketStore(&cfg).Restore(serviceDir)
+	default:
+		err = fmt.Errorf("service '%s' has no restore", serviceName)
+	}
+	return err
+}
+
+// isServiceRunning returns true if the service accepts connections on its socket
+func isServiceRunning(runFolder string, serviceName string) bool {
+	socketPath := path.Join(runFolder, serviceName+".socket")
+	conn, err := net.DialTimeout("unix", socketPath, time.Second)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+// isTarFile returns true if the backup target is a tar file instead of a directory
+func isTarFile(target string) bool {
+	return strings.HasSuffix(target, ".tar")
+}
@@ -16,14 +16,15 @@ import (
 )
 
 func DirectoryListCommand(ctx context.Context, runFolder *string) *cli.Command {
-	var limit = 100
+	var limit = directory.DefaultQueryLimit
 	var offset = 0
 	var verbose = false
+	var query = directory.TDQuery{}
 	return &cli.Command{
 		Name:      "ld",
 		Category:  "directory",
 		Usage:     "List directory of Things or selected Thing",
-		ArgsUsage: "[<publisherID> <thingID>]",
+		ArgsUsage: "[<publisherID> [<thingID>]]",
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:        "v",
@@ -31,11 +32,41 @@ func DirectoryListCommand(ctx context.Context, runFolder *string) *cli.Command {
 				Value:       false,
 				Destination: &verbose,
 			},
+			&cli.StringFlag{
+				Name:        "type",
+				Usage:       "Only list Things of this device `type`",
+				Destination: &query.DeviceType,
+			},
+			&cli.StringFlag{
+				Name:        "title",
+				Usage:       "Only list Things whose title contains this `text`",
+				Destination: &query.Title,
+			},
+			&cli.StringFlag{
+				Name:        "proptype",
+				Usage:       "Only list Things that have a property of this `type`",
+				Destination: &query.PropertyType,
+			},
+			&cli.IntFlag{
+				Name:        "limit",
+				Usage:       "Maximum number of Things to list",
+				Value:       limit,
+				Destination: &limit,
+			},
+			&cli.IntFlag{
+				Name:        "offset",
+				Usage:       "Number of matching Things to skip",
+				Value:       offset,
+				Destination: &offset,
+			},
 		},
 		Action: func(cCtx *cli.Context) error {
-			var err = fmt.Errorf("expected 0 or 2 parameters")
+			var err = fmt.Errorf("expected 0, 1 or 2 parameters")
 			if cCtx.NArg() == 0 {
-				err = HandleListDirectory(ctx, *runFolder, limit, offset)
+				err = HandleListDirectory(ctx, *runFolder, query, limit, offset)
+			} else if cCtx.NArg() == 1 {
+				query.PublisherID = cCtx.Args().First()
+				err = HandleListDirectory(ctx, *runFolder, query, limit, offset)
 			} else if cCtx.NArg() == 2 {
 				if !verbose {
 					err = HandleListThing(ctx, *runFolder, cCtx.Args().First(), cCtx.Args().Get(1))
@@ -48,8 +79,24 @@ func DirectoryListCommand(ctx context.Context, runFolder *string) *cli.Command {
 	}
 }
 
-// HandleListDirectory lists the directory content
-func HandleListDirectory(ctx context.Context, runFolder string, limit int, offset int) error {
+func DirectoryListStaleCommand(ctx context.Context, runFolder *string) *cli.Command {
+	return &cli.Command{
+		Name:     "lstale",
+		Category: "directory",
+		Usage:    "List Things whose TD has not been updated for longer than the stale age",
+		Action: func(cCtx *cli.Context) error {
+			if cCtx.NArg() != 0 {
+				return fmt.Errorf("no arguments expected")
+			}
+			err := HandleListStaleTDs(ctx, *runFolder)
+			return err
+		},
+	}
+}
+
+// HandleListDirectory lists the directory content that matches the query
+func HandleListDirectory(
+	ctx context.Context, runFolder string, query directory.TDQuery, limit int, offset int) error {
 	var dir directory.IDirectory
 	var rd directory.IReadDirectory
 
@@ -62,16 +109,13 @@ func HandleListDirectory(ctx context.Context, runFolder string, limit int, offse
 		return err
 	}
 
-	cursor := rd.Cursor(ctx)
+	tvList, err := rd.QueryTDs(ctx, query, limit, offset)
+	if err != nil {
+		return err
+	}
 	fmt.Printf("Publisher ID    Thing ID             Device Type          Title                                #props  #events #actions   Modified         \n")
 	fmt.Printf("-------------   -------------------  -------------------  -----------------------------------  ------  ------- --------   --------------------------\n")
-	i := 0
-	tv, valid := cursor.First()
-	if offset > 0 {
-		// TODO, skip
-		//tv, valid = cursor.Skip(offset)
-	}
-	for ; valid && i < limit; tv, valid = cursor.Next() {
+	for _, tv := range tvList {
 		var tdDoc thing.TD
 		err = json.Unmarshal(tv.Data, &tdDoc)
 		var utime time.Time
@@ -97,6 +141,42 @@ func HandleListDirectory(ctx context.Context, runFolder string, limit int, offse
 	return nil
 }
 
+// HandleListStaleTDs lists the Things with a stale TD
+func HandleListStaleTDs(ctx context.Context, runFolder string) error {
+	var dir directory.IDirectory
+	var rd directory.IReadDirectory
+
+	capClient, err := hubclient.ConnectWithCapnpUDS(directory.ServiceName, runFolder)
+	if err == nil {
+		dir = capnpclient.NewDirectoryCapnpClient(capClient)
+		rd, err = dir.CapReadDirectory(ctx, "hubcli")
+	}
+	if err != nil {
+		return err
+	}
+	tvList, err := rd.GetStaleTDs(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Publisher ID    Thing ID             Title                                Last Updated\n")
+	fmt.Printf("-------------   -------------------  -----------------------------------  --------------------------\n")
+	for _, tv := range tvList {
+		var tdDoc thing.TD
+		_ = json.Unmarshal(tv.Data, &tdDoc)
+		utime, _ := dateparse.ParseAny(tv.Created)
+		timeStr := utime.In(time.Local).Format("02 Jan 2006 15:04:05 -0700")
+
+		fmt.Printf("%-15s %-20s %-35.35s  %-30s\n",
+			tv.PublisherID,
+			tv.ThingID,
+			tdDoc.Title,
+			timeStr,
+		)
+	}
+	fmt.Println()
+	return nil
+}
+
 // HandleListThing lists details of a Thing in the directory
 func HandleListThing(ctx context.Context, runFolder string, pubID, thingID string) error {
 	var dir directory.IDirectory
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/araddon/dateparse"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 
+	"github.com/hiveot/hub/api/go/vocab"
 	"github.com/hiveot/hub/lib/hubclient"
 	"github.com/hiveot/hub/pkg/history"
 	"github.com/hiveot/hub/pkg/history/capnpclient"
@@ -46,6 +49,39 @@ func HistoryListCommand(ctx context.Context, runFolder *string) *cli.Command {
 	}
 }
 
+func HistoryAggregateCommand(ctx context.Context, runFolder *string) *cli.Command {
+	var hours = 24
+	var interval = 3600
+	return &cli.Command{
+		Name:      "lagg",
+		Usage:     "List the min/max/avg of a thing event per interval",
+		ArgsUsage: "<pubID> <thingID> <event>",
+		Category:  "history",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:        "hours",
+				Usage:       "Number of hours ago to start the aggregate",
+				Value:       hours,
+				Destination: &hours,
+			},
+			&cli.IntFlag{
+				Name:        "interval",
+				Usage:       "Interval of each aggregate in seconds",
+				Value:       interval,
+				Destination: &interval,
+			},
+		},
+		Action: func(cCtx *cli.Context) error {
+			if cCtx.NArg() != 3 {
+				return fmt.Errorf("publisherID, thingID and event name expected")
+			}
+			err := HandleListAggregate(ctx, *runFolder,
+				cCtx.Args().First(), cCtx.Args().Get(1), cCtx.Args().Get(2), hours, interval)
+			return err
+		},
+	}
+}
+
 func HistoryLatestCommand(ctx context.Context, runFolder *string) *cli.Command {
 	return &cli.Command{
 		Name:      "lla",
@@ -138,6 +174,42 @@ func HandleListEvents(ctx context.Context, runFolder string, publisherID, thingI
 	return err
 }
 
+// HandleListAggregate lists the aggregate of an event per interval over the last hours
+func HandleListAggregate(ctx context.Context, runFolder string,
+	publisherID, thingID string, name string, hours int, interval int) error {
+	var hist history.IHistoryService
+	var rd history.IReadHistory
+
+	capClient, err := hubclient.ConnectWithCapnpUDS(history.ServiceName, runFolder)
+	if err == nil {
+		hist = capnpclient.NewHistoryCapnpClient(capClient)
+		rd, err = hist.CapReadHistory(ctx, "hubcli")
+	}
+	if err != nil {
+		return err
+	}
+	defer rd.Release()
+	startTime := time.Now().Add(-time.Duration(hours) * time.Hour).Format(vocab.ISO8601Format)
+	aggList, err := rd.GetAggregate(ctx, publisherID, thingID, name, startTime, hours*3600, interval)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Start Time                   Count        Min        Max        Avg       Last")
+	fmt.Println("----------                   -----        ---        ---        ---       ----")
+	for _, agg := range aggList {
+		utime, _ := dateparse.ParseAny(agg.StartTime)
+		fmt.Printf("%-28s %5d %10.2f %10.2f %10.2f %10.2f\n",
+			utime.Format("02 Jan 2006 15:04:05 MST"),
+			agg.Count,
+			agg.Min,
+			agg.Max,
+			agg.Avg,
+			agg.Last,
+		)
+	}
+	return nil
+}
+
 // HandleListRetainedEvents lists the events that are retained
 func HandleListRetainedEvents(ctx context.Context, runFolder string) error {
 
@@ -157,16 +229,31 @@ func HandleListRetainedEvents(ctx context.Context, runFolder string) error {
 		return evList[i].Name < evList[j].Name
 	})
 
-	fmt.Printf("Events (%2d)      days     publishers                     Things                         Excluded\n", len(evList))
-	fmt.Println("----------       ----     ----------                     ------                         -------- ")
+	fmt.Printf("Events (%2d)      days     publishers                     Things                         Excluded                       Change filter    Compaction\n", len(evList))
+	fmt.Println("----------       ----     ----------                     ------                         --------                       -------------    ----------")
 	for _, evRet := range evList {
+		compaction := make([]string, 0, len(evRet.Compaction))
+		for _, rule := range evRet.Compaction {
+			compaction = append(compaction, fmt.Sprintf("%dd:%ds", rule.AfterDays, rule.IntervalSec))
+		}
+		changeFilter := ""
+		if evRet.Deadband > 0 {
+			changeFilter = fmt.Sprintf("db:%g", evRet.Deadband)
+		} else if evRet.OnlyOnChange {
+			changeFilter = "change"
+		}
+		if changeFilter != "" && evRet.HeartbeatSec > 0 {
+			changeFilter += fmt.Sprintf(",hb:%ds", evRet.HeartbeatSec)
+		}
 
-		fmt.Printf("%-16.16s %-8d %-30.30s %-30.30s %-30.30s\n",
+		fmt.Printf("%-16.16s %-8d %-30.30s %-30.30s %-30.30s %-16.16s %s\n",
 			evRet.Name,
 			evRet.RetentionDays,
 			fmt.Sprintf("%s", evRet.Publishers),
 			fmt.Sprintf("%s", evRet.Things),
 			fmt.Sprintf("%s", evRet.Exclude),
+			changeFilter,
+			strings.Join(compaction, ","),
 		)
 	}
 	mngRet.Release()
@@ -0,0 +1,217 @@
+package historycli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hiveot/hub/lib/hubclient"
+	"github.com/hiveot/hub/pkg/directory"
+	dircapnpclient "github.com/hiveot/hub/pkg/directory/capnpclient"
+	"github.com/hiveot/hub/pkg/history"
+	"github.com/hiveot/hub/pkg/history/capnpclient"
+	"github.com/hiveot/hub/pkg/history/exporter"
+)
+
+// HistoryExportImportCommands holds the 'hist export' and 'hist import' commands
+func HistoryExportImportCommands(ctx context.Context, runFolder *string) *cli.Command {
+	var format = exporter.FormatCSV
+	var historyRange = history.HistoryRange{}
+	var names = ""
+	var outFile = ""
+	return &cli.Command{
+		Name:     "hist",
+		Usage:    "Export or import the event history",
+		Category: "history",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "export",
+				Usage:     "Export the event history of Things to a file or stdout",
+				ArgsUsage: "[<pubID> [<thingID>]]",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "format",
+						Usage:       "Export `format`, csv or ndjson",
+						Value:       format,
+						Destination: &format,
+					},
+					&cli.StringFlag{
+						Name:        "out",
+						Usage:       "Write the export to this `file`. Default is stdout.",
+						Destination: &outFile,
+					},
+					&cli.StringFlag{
+						Name:        "start",
+						Usage:       "ISO8601 `time` of the first event to export. Default is the oldest event.",
+						Destination: &historyRange.StartTime,
+					},
+					&cli.StringFlag{
+						Name:        "end",
+						Usage:       "ISO8601 `time` after the last event to export. Default is the latest event.",
+						Destination: &historyRange.EndTime,
+					},
+					&cli.StringFlag{
+						Name:        "names",
+						Usage:       "Comma separated `list` of event names to export. Default is all events.",
+						Destination: &names,
+					},
+				},
+				Action: func(cCtx *cli.Context) error {
+					if cCtx.NArg() > 2 {
+						return fmt.Errorf("optional publisherID and thingID expected")
+					}
+					if names != "" {
+						historyRange.Names = strings.Split(names, ",")
+					}
+					return HandleExportHistory(ctx, *runFolder,
+						cCtx.Args().First(), cCtx.Args().Get(1), historyRange, format, outFile)
+				},
+			},
+			{
+				Name:      "import",
+				Usage:     "Import the event history from a file or stdin",
+				ArgsUsage: "[<file>]",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "format",
+						Usage:       "Import `format`, csv or ndjson",
+						Value:       format,
+						Destination: &format,
+					},
+				},
+				Action: func(cCtx *cli.Context) error {
+					if cCtx.NArg() > 1 {
+						return fmt.Errorf("at most one file expected")
+					}
+					return HandleImportHistory(ctx, *runFolder, cCtx.Args().First(), format)
+				},
+			},
+		},
+	}
+}
+
+// getThingAddrs returns the addresses of the Things in the directory.
+//
+//	publisherID to limit the Things to those of the publisher, or "" for all publishers
+func getThingAddrs(ctx context.Context, runFolder string, publisherID string) ([]string, error) {
+	var dir directory.IDirectory
+	var rd directory.IReadDirectory
+
+	capClient, err := hubclient.ConnectWithCapnpUDS(directory.ServiceName, runFolder)
+	if err == nil {
+		dir = dircapnpclient.NewDirectoryCapnpClient(capClient)
+		rd, err = dir.CapReadDirectory(ctx, "hubcli")
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rd.Release()
+
+	thingAddrs := make([]string, 0)
+	query := directory.TDQuery{PublisherID: publisherID}
+	for offset := 0; ; offset += directory.DefaultQueryLimit {
+		tvList, err := rd.QueryTDs(ctx, query, directory.DefaultQueryLimit, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, tv := range tvList {
+			thingAddrs = append(thingAddrs, tv.PublisherID+"/"+tv.ThingID)
+		}
+		if len(tvList) < directory.DefaultQueryLimit {
+			break
+		}
+	}
+	return thingAddrs, nil
+}
+
+// HandleExportHistory exports the event history of one or more Things.
+// Without thingID, the Things are obtained from the directory.
+//
+//	publisherID of the Things to export, or "" for all Things
+//	thingID of the Thing to export, or "" for all Things of the publisher
+//	historyRange with the time range and event names to export
+//	format is exporter.FormatCSV or exporter.FormatNDJSON
+//	file to write to or "" for stdout
+func HandleExportHistory(ctx context.Context, runFolder string,
+	publisherID, thingID string, historyRange history.HistoryRange, format string, file string) error {
+
+	var hist history.IHistoryService
+	var rd history.IReadHistory
+	var thingAddrs []string
+	var out io.Writer = os.Stdout
+
+	err := exporter.ValidateFormat(format)
+	if err != nil {
+		return err
+	}
+	if thingID != "" {
+		thingAddrs = []string{publisherID + "/" + thingID}
+	} else {
+		thingAddrs, err = getThingAddrs(ctx, runFolder, publisherID)
+		if err != nil {
+			return err
+		}
+	}
+	capClient, err := hubclient.ConnectWithCapnpUDS(history.ServiceName, runFolder)
+	if err == nil {
+		hist = capnpclient.NewHistoryCapnpClient(capClient)
+		rd, err = hist.CapReadHistory(ctx, "hubcli")
+	}
+	if err != nil {
+		return err
+	}
+	defer rd.Release()
+	if file != "" {
+		fp, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer fp.Close()
+		out = fp
+	}
+	count, err := exporter.ExportHistory(ctx, rd, out, format, thingAddrs, historyRange)
+	// stdout holds the export so report on stderr
+	fmt.Fprintf(os.Stderr, "Exported %d events of %d things\n", count, len(thingAddrs))
+	return err
+}
+
+// HandleImportHistory imports the event history from a file or stdin.
+// Retention rules, including their change filter, are ignored. Invalid events are skipped
+// by the history service.
+//
+//	file to read from or "" for stdin
+//	format is exporter.FormatCSV or exporter.FormatNDJSON
+func HandleImportHistory(ctx context.Context, runFolder string, file string, format string) error {
+	var hist history.IHistoryService
+	var addHist history.IAddHistory
+	var in io.Reader = os.Stdin
+
+	err := exporter.ValidateFormat(format)
+	if err != nil {
+		return err
+	}
+	if file != "" {
+		fp, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer fp.Close()
+		in = fp
+	}
+	capClient, err := hubclient.ConnectWithCapnpUDS(history.ServiceName, runFolder)
+	if err == nil {
+		hist = capnpclient.NewHistoryCapnpClient(capClient)
+		addHist, err = hist.CapAddHistory(ctx, "hubcli", true)
+	}
+	if err != nil {
+		return err
+	}
+	defer addHist.Release()
+	nrRead, err := exporter.ImportHistory(ctx, addHist, in, format)
+	fmt.Printf("Read %d events and passed them to the history service\n", nrRead)
+	return err
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/hiveot/hub/cmd/hubcli/authncli"
 	"github.com/hiveot/hub/cmd/hubcli/authzcli"
+	"github.com/hiveot/hub/cmd/hubcli/backupcli"
 	"github.com/hiveot/hub/cmd/hubcli/certscli"
 	"github.com/hiveot/hub/cmd/hubcli/directorycli"
 	"github.com/hiveot/hub/cmd/hubcli/gatewaycli"
@@ -20,6 +21,7 @@ import (
 	"github.com/hiveot/hub/cmd/hubcli/launchercli"
 	"github.com/hiveot/hub/cmd/hubcli/provcli"
 	"github.com/hiveot/hub/cmd/hubcli/pubsubcli"
+	"github.com/hiveot/hub/cmd/hubcli/storecli"
 )
 
 const Version = `0.5-alpha`
@@ -96,14 +98,18 @@ func main() {
 			pubsubcli.SubTDCommand(ctx, &runFolder),
 			pubsubcli.SubEventsCommand(ctx, &runFolder),
 			pubsubcli.PubActionCommand(ctx, &runFolder),
+			pubsubcli.SubStatsCommand(ctx, &runFolder),
 
 			directorycli.DirectoryListCommand(ctx, &runFolder),
+			directorycli.DirectoryListStaleCommand(ctx, &runFolder),
 
 			//historycli.HistoryCommands(ctx, &runFolder),
 			//historycli.HistoryInfoCommand(ctx, &runFolder),
 			historycli.HistoryListCommand(ctx, &runFolder),
 			historycli.HistoryLatestCommand(ctx, &runFolder),
+			historycli.HistoryAggregateCommand(ctx, &runFolder),
 			historycli.HistoryRetainCommand(ctx, &runFolder),
+			historycli.HistoryExportImportCommands(ctx, &runFolder),
 
 			provcli.ProvisionAddOOBSecretsCommand(ctx, &runFolder),
 			provcli.ProvisionApproveRequestCommand(ctx, &runFolder),
@@ -111,6 +117,11 @@ func main() {
 			provcli.ProvisionGetApprovedRequestsCommand(ctx, &runFolder),
 
 			gatewaycli.GatewayListCommand(ctx, &certsFolder, &configFolder),
+
+			backupcli.BackupCommand(ctx, &runFolder),
+			backupcli.RestoreCommand(ctx, &homeFolder),
+			storecli.MigrateStoreCommand(ctx, &homeFolder),
+			storecli.RotateKeyCommand(ctx, &homeFolder),
 		},
 	}
 
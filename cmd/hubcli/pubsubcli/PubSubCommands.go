@@ -66,6 +66,22 @@ func SubEventsCommand(ctx context.Context, runFolder *string) *cli.Command {
 	}
 }
 
+// SubStatsCommand shows the delivery statistics of active subscriptions
+func SubStatsCommand(ctx context.Context, runFolder *string) *cli.Command {
+	return &cli.Command{
+		Name:     "substats",
+		Usage:    "Show delivery statistics of active subscriptions",
+		Category: "pubsub",
+		Action: func(cCtx *cli.Context) error {
+			if cCtx.NArg() != 0 {
+				return fmt.Errorf("no arguments expected")
+			}
+			err := HandleSubscriptionStats(ctx, *runFolder)
+			return err
+		},
+	}
+}
+
 func HandlePubActions(ctx context.Context, runFolder string, pubID string, thingID string, action string, args string) error {
 	var pubSubSvc pubsub.IPubSubService
 
@@ -99,7 +115,7 @@ func HandleSubTD(ctx context.Context, runFolder string) error {
 	err = pubSubUser.SubEvent(ctx, "", "", hubapi.EventNameTD, func(event thing.ThingValue) {
 		var td thing.TD
 		//fmt.Printf("%s\n", event.ValueJSON)
-		err = json.Unmarshal(event.Data, &td)
+		_ = json.Unmarshal(event.Data, &td)
 
 		modifiedTime, _ := dateparse.ParseAny(td.Modified)                  // can be in any TZ
 		timeStr := modifiedTime.In(time.Local).Format("15:04:05.000 -0700") // want local time
@@ -155,3 +171,33 @@ func HandleSubEvents(ctx context.Context, runFolder string) error {
 	time.Sleep(time.Hour * 24)
 	return nil
 }
+
+// HandleSubscriptionStats prints the delivery statistics of active subscriptions
+func HandleSubscriptionStats(ctx context.Context, runFolder string) error {
+	var pubSubSvc pubsub.IPubSubService
+
+	capClient, err := hubclient.ConnectWithCapnpUDS(pubsub.ServiceName, runFolder)
+	if err == nil {
+		pubSubSvc = capnpclient.NewPubSubCapnpClient(capClient)
+	}
+	if err != nil {
+		return err
+	}
+	statsList, err := pubSubSvc.GetSubscriptionStats(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Subscriber           Topic                                     Pending   Delivered   Dropped\n")
+	fmt.Printf("-------------------  ----------------------------------------  -------  ----------  --------\n")
+	for _, stats := range statsList {
+		fmt.Printf("%-20s %-40.40s  %7d  %10d  %8d\n",
+			stats.SubscriberID,
+			stats.Topic,
+			stats.Pending,
+			stats.Delivered,
+			stats.Dropped,
+		)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,132 @@
+package storecli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hiveot/hub/lib/svcconfig"
+	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/bucketstore/cmd"
+)
+
+// MigrateStoreCommand copies a bucket store to a store with a different backend
+func MigrateStoreCommand(ctx context.Context, homeFolder *string) *cli.Command {
+	var srcBackend = bucketstore.BackendPebble
+	var dstBackend = bucketstore.BackendBBolt
+	var srcDir = ""
+	var dstDir = ""
+	var verifyOnly = false
+	return &cli.Command{
+		Name:      "migrate",
+		Category:  "stores",
+		Usage:     "Migrate the bucket store of a stopped service to another backend",
+		ArgsUsage: "<clientID>",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "from",
+				Usage:       "Source `backend`, kvbtree, bbolt or pebble",
+				Value:       srcBackend,
+				Destination: &srcBackend,
+			},
+			&cli.StringFlag{
+				Name:        "to",
+				Usage:       "Destination `backend`, kvbtree, bbolt or pebble",
+				Value:       dstBackend,
+				Destination: &dstBackend,
+			},
+			&cli.StringFlag{
+				Name:        "src",
+				Usage:       "Source store `directory`. Default is the stores folder.",
+				Destination: &srcDir,
+			},
+			&cli.StringFlag{
+				Name:        "dst",
+				Usage:       "Destination store `directory`. Default is the source directory.",
+				Destination: &dstDir,
+			},
+			&cli.BoolFlag{
+				Name:        "verify",
+				Usage:       "Only verify that the destination holds the same records as the source",
+				Destination: &verifyOnly,
+			},
+		},
+		Action: func(cCtx *cli.Context) error {
+			if cCtx.NArg() != 1 {
+				return fmt.Errorf("clientID of the store expected")
+			}
+			if srcDir == "" {
+				srcDir = svcconfig.GetFolders(*homeFolder, false).Stores
+			}
+			if dstDir == "" {
+				dstDir = srcDir
+			}
+			err := HandleMigrateStore(ctx, cCtx.Args().First(),
+				srcBackend, srcDir, dstBackend, dstDir, verifyOnly)
+			return err
+		},
+	}
+}
+
+// HandleMigrateStore copies all buckets of a store to a new store with a different backend
+// and verifies the record counts and checksums of both stores.
+// The service that uses the store must be stopped.
+//
+//	clientID is the ID of the store, eg the service name
+//	srcBackend and srcDir are the backend type and directory of the existing store
+//	dstBackend and dstDir are the backend type and directory of the new store
+//	verifyOnly skips the migration and only compares the stores
+func HandleMigrateStore(_ context.Context, clientID string,
+	srcBackend string, srcDir string, dstBackend string, dstDir string, verifyOnly bool) error {
+
+	if srcBackend == dstBackend && srcDir == dstDir {
+		return fmt.Errorf("source and destination are the same store")
+	}
+	srcStore := cmd.NewBucketStore(srcDir, clientID, srcBackend)
+	dstStore := cmd.NewBucketStore(dstDir, clientID, dstBackend)
+	if srcStore == nil || dstStore == nil {
+		return fmt.Errorf("unknown backend. Use one of kvbtree, bbolt or pebble")
+	}
+	err := srcStore.Open()
+	if err != nil {
+		return fmt.Errorf("unable to open source store '%s': %w", clientID, err)
+	}
+	defer srcStore.Close()
+	err = dstStore.Open()
+	if err != nil {
+		return fmt.Errorf("unable to open destination store '%s': %w", clientID, err)
+	}
+	defer dstStore.Close()
+
+	if !verifyOnly {
+		fmt.Printf("Migrating store '%s' from %s in '%s' to %s in '%s'\n",
+			clientID, srcBackend, srcDir, dstBackend, dstDir)
+		totalRecords, err := bucketstore.MigrateStore(srcStore, dstStore,
+			func(progress bucketstore.MigrateProgress) {
+				if progress.Done {
+					fmt.Printf("\rBucket %d/%d '%s': %d records migrated\n",
+						progress.BucketNr, progress.NrBuckets, progress.BucketID, progress.BucketRecords)
+				} else {
+					fmt.Printf("\rBucket %d/%d '%s': %d records",
+						progress.BucketNr, progress.NrBuckets, progress.BucketID, progress.BucketRecords)
+				}
+			})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Migrated %d records\n", totalRecords)
+	}
+
+	fmt.Printf("Verifying store '%s'\n", clientID)
+	checksums, err := bucketstore.VerifyStore(srcStore, dstStore)
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+	fmt.Printf("%-30s %10s  %s\n", "Bucket", "Records", "Checksum")
+	for _, sum := range checksums {
+		fmt.Printf("%-30s %10d  %s\n", sum.BucketID, sum.NrRecords, sum.Checksum)
+	}
+	fmt.Printf("Verified %d buckets\n", len(checksums))
+	return nil
+}
@@ -0,0 +1,219 @@
+package storecli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/hiveot/hub/lib/svcconfig"
+	"github.com/hiveot/hub/pkg/authz"
+	authzconfig "github.com/hiveot/hub/pkg/authz/config"
+	"github.com/hiveot/hub/pkg/authz/service/aclstore"
+	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
+	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
+	"github.com/hiveot/hub/pkg/history"
+	histconfig "github.com/hiveot/hub/pkg/history/config"
+	histservice "github.com/hiveot/hub/pkg/history/service"
+	"github.com/hiveot/hub/pkg/state"
+	stateconfig "github.com/hiveot/hub/pkg/state/config"
+)
+
+// NoSecret is the secret file name to use for a plaintext store
+const NoSecret = "none"
+
+// RotateKeyCommand re-encrypts the store of a stopped service with a new secret
+func RotateKeyCommand(ctx context.Context, homeFolder *string) *cli.Command {
+	var oldSecret = NoSecret
+	var newSecret = ""
+	var encryptKeys = false
+	var generate = false
+	return &cli.Command{
+		Name:     "rotatekey",
+		Category: "stores",
+		Usage: "Encrypt, re-encrypt or decrypt the store of a stopped service. " +
+			"Update the encryption settings in the service config afterwards.",
+		ArgsUsage: "state | history | authz",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name: "old",
+				Usage: "Secret `file` the store is encrypted with, relative to the certs folder, " +
+					"or 'none' if the store is plaintext",
+				Value:       oldSecret,
+				Destination: &oldSecret,
+			},
+			&cli.StringFlag{
+				Name: "new",
+				Usage: "Secret `file` to encrypt the store with, relative to the certs folder, " +
+					"or 'none' to decrypt the store. Default is the CA key.",
+				Destination: &newSecret,
+			},
+			&cli.BoolFlag{
+				Name:        "keys",
+				Usage:       "Also encrypt the keys of the store. Only supported by state.",
+				Destination: &encryptKeys,
+			},
+			&cli.BoolFlag{
+				Name:        "generate",
+				Usage:       "Generate a new secret in the new secret file if it doesn't exist",
+				Destination: &generate,
+			},
+		},
+		Action: func(cCtx *cli.Context) error {
+			if cCtx.NArg() != 1 {
+				return fmt.Errorf("service name expected")
+			}
+			f := svcconfig.GetFolders(*homeFolder, false)
+			err := HandleRotateKey(ctx, f, cCtx.Args().First(),
+				oldSecret, newSecret, encryptKeys, generate)
+			return err
+		},
+	}
+}
+
+// HandleRotateKey re-encrypts the store of a service with a new secret.
+// This can also encrypt a plaintext store or decrypt an encrypted store.
+// The service must be stopped. If the rotation is interrupted it can be repeated.
+//
+//	f are the application folders
+//	serviceName is the service whose store to rotate, state, history or authz
+//	oldSecret is the secret file the store is encrypted with, or NoSecret if it is plaintext
+//	newSecret is the secret file to encrypt with, NoSecret to decrypt, or "" for the CA key
+//	encryptKeys also encrypts the keys of bucket stores
+//	generate creates the new secret file if it doesn't exist
+func HandleRotateKey(_ context.Context, f svcconfig.AppFolders, serviceName string,
+	oldSecret string, newSecret string, encryptKeys bool, generate bool) (err error) {
+
+	if oldSecret == newSecret {
+		return fmt.Errorf("the old and new secret are the same")
+	}
+	if serviceRunning(f.Run, serviceName) {
+		return fmt.Errorf("service '%s' is running. Stop it first", serviceName)
+	}
+	newSecretCfg := encrypted.EncryptionConfig{SecretFile: newSecret}
+	newSecretCfg.ResolveSecretFile(f.Certs)
+	if generate && newSecret != NoSecret {
+		if _, err2 := os.Stat(newSecretCfg.SecretFile); os.IsNotExist(err2) {
+			err = encrypted.GenerateSecretFile(newSecretCfg.SecretFile)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Generated a new secret in '%s'\n", newSecretCfg.SecretFile)
+		}
+	}
+	f.ConfigFile = path.Join(f.Config, serviceName+".yaml")
+
+	var nrRecords int64
+	switch serviceName {
+	case state.ServiceName:
+		cfg := stateconfig.NewStateConfig(f.Stores)
+		_ = f.LoadConfig(&cfg)
+		oldCipher, newCipher, err2 := loadCiphers(f, oldSecret, newSecret, state.ServiceName)
+		if err2 != nil {
+			return err2
+		}
+		storeFiles, _ := filepath.Glob(path.Join(cfg.StoreDirectory, "*.json"))
+		for _, storeFile := range storeFiles {
+			clientID := strings.TrimSuffix(path.Base(storeFile), ".json")
+			fmt.Printf("Rotating the key of state store '%s'\n", clientID)
+			n, err2 := rotateStore(kvbtree.NewKVStore(clientID, storeFile), oldCipher, newCipher, encryptKeys)
+			nrRecords += n
+			if err2 != nil {
+				return err2
+			}
+		}
+	case history.ServiceName:
+		if encryptKeys {
+			return fmt.Errorf("the history store doesn't support encrypted keys")
+		}
+		cfg := histconfig.NewHistoryConfig(f.Stores)
+		_ = f.LoadConfig(&cfg)
+		storeID := cfg.ServiceID
+		if storeID == "" {
+			storeID = history.ServiceName
+		}
+		oldCipher, newCipher, err2 := loadCiphers(f, oldSecret, newSecret, storeID)
+		if err2 != nil {
+			return err2
+		}
+		// rotate the store itself and not its encrypted wrapper
+		cfg.Encryption.Enabled = false
+		nrRecords, err = rotateStore(histservice.NewHistoryBucketStore(&cfg), oldCipher, newCipher, encryptKeys)
+	case authz.ServiceName:
+		cfg := authzconfig.NewAuthzConfig(f.Stores)
+		_ = f.LoadConfig(&cfg)
+		oldCipher, newCipher, err2 := loadCiphers(f, oldSecret, newSecret, authz.ServiceName)
+		if err2 != nil {
+			return err2
+		}
+		fmt.Printf("Rotating the key of ACL file '%s'\n", cfg.AclFile)
+		err = encrypted.RotateFileKey(cfg.AclFile, oldCipher, newCipher, []byte(aclstore.AclEncryptionAD))
+	default:
+		return fmt.Errorf("service '%s' doesn't support encryption. Use state, history or authz", serviceName)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Rotated the key of %d records of service '%s'.\n", nrRecords, serviceName)
+	if newSecret == NoSecret {
+		fmt.Printf("Disable encryption in '%s' before starting the service.\n", f.ConfigFile)
+	} else {
+		fmt.Printf("Set the encryption secretFile in '%s' to '%s' before starting the service.\n",
+			f.ConfigFile, newSecretCfg.SecretFile)
+	}
+	return nil
+}
+
+// loadCiphers loads the old and new cipher of a store from their secret files
+// A secret of NoSecret returns a nil cipher.
+func loadCiphers(f svcconfig.AppFolders, oldSecret string, newSecret string, storeID string) (
+	oldCipher *encrypted.StoreCipher, newCipher *encrypted.StoreCipher, err error) {
+
+	loadCipher := func(secret string) (*encrypted.StoreCipher, error) {
+		if secret == NoSecret {
+			return nil, nil
+		}
+		cfg := encrypted.EncryptionConfig{SecretFile: secret}
+		cfg.ResolveSecretFile(f.Certs)
+		return encrypted.LoadStoreCipher(cfg.SecretFile, storeID)
+	}
+	oldCipher, err = loadCipher(oldSecret)
+	if err == nil {
+		newCipher, err = loadCipher(newSecret)
+	}
+	return oldCipher, newCipher, err
+}
+
+// rotateStore opens the store and re-encrypts its records
+func rotateStore(store bucketstore.IBucketStore, oldCipher *encrypted.StoreCipher,
+	newCipher *encrypted.StoreCipher, encryptKeys bool) (nrRecords int64, err error) {
+
+	err = store.Open()
+	if err != nil {
+		return 0, err
+	}
+	nrRecords, err = encrypted.RotateStoreKey(store, oldCipher, newCipher, encryptKeys)
+	err2 := store.Close()
+	if err == nil {
+		err = err2
+	}
+	return nrRecords, err
+}
+
+// serviceRunning returns true if the service socket accepts connections
+func serviceRunning(runFolder string, serviceName string) bool {
+	socketPath := path.Join(runFolder, serviceName+".socket")
+	conn, err := net.DialTimeout("unix", socketPath, time.Second)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
@@ -19,6 +19,8 @@ func UnmarshalThingValue(capValue hubapi.ThingValue) thing.ThingValue {
 	thingValue.ID, _ = capValue.Name()
 	thingValue.Data = Clone(vj) // copy the buffer
 	thingValue.Created, _ = capValue.Created()
+	thingValue.Expiry = capValue.Expiry()
+	thingValue.RequestID, _ = capValue.RequestID()
 
 	return thingValue
 }
@@ -103,6 +105,8 @@ func MarshalThingValue(thingValue thing.ThingValue) hubapi.ThingValue {
 		_ = capValue.SetName(thingValue.ID)
 		_ = capValue.SetData(thingValue.Data)
 		_ = capValue.SetCreated(thingValue.Created)
+		capValue.SetExpiry(thingValue.Expiry)
+		_ = capValue.SetRequestID(thingValue.RequestID)
 	}
 	return capValue
 }
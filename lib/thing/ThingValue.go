@@ -29,7 +29,11 @@ type ThingValue struct {
 	// Expiry time of the value in seconds since epoc.
 	// Events expire based on their update interval.
 	// Actions expiry is used for queueing. 0 means the action expires immediately after receiving it and is not queued.
-	//Expiry int64
+	Expiry int64 `json:"expiry,omitempty"`
+
+	// RequestID of an action request. Used by the device to reply with the action status.
+	// Empty if no status reply is expected.
+	RequestID string `json:"requestID,omitempty"`
 
 	// Sequence of the message from its creator. Intended to prevent replay attacks.
 	//Sequence int64
@@ -11,6 +11,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hiveot/hub/lib/logging"
 	"github.com/hiveot/hub/pkg/authz"
@@ -18,6 +19,7 @@ import (
 	"github.com/hiveot/hub/pkg/authz/capnpserver"
 	"github.com/hiveot/hub/pkg/authz/capserializer"
 	"github.com/hiveot/hub/pkg/authz/service"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
 )
 
 const testUseCapnp = true
@@ -460,3 +462,49 @@ func TestClientPermissions(t *testing.T) {
 	assert.NotContains(t, perms, authz.PermWriteProperty)
 
 }
+
+func TestEncryptedAclStore(t *testing.T) {
+	logrus.Infof("---TestEncryptedAclStore---")
+	const client1ID = "client1"
+	const group1ID = "group1"
+	ctx := context.Background()
+	secretFile := path.Join(testFolder, "acl.secret")
+	_ = os.Remove(secretFile)
+	_ = os.Remove(aclFilePath)
+	err := encrypted.GenerateSecretFile(secretFile)
+	require.NoError(t, err)
+	cipher, err := encrypted.LoadStoreCipher(secretFile, authz.ServiceName)
+	require.NoError(t, err)
+
+	svc := service.NewAuthzService(aclFilePath)
+	svc.SetCipher(cipher)
+	err = svc.Start(ctx)
+	require.NoError(t, err)
+	manageAuthz, _ := svc.CapManageAuthz(ctx, "admin")
+	err = manageAuthz.SetClientRole(ctx, client1ID, group1ID, authz.ClientRoleOperator)
+	require.NoError(t, err)
+	svc.Stop()
+
+	// the ACL file is encrypted
+	data, err := os.ReadFile(aclFilePath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), client1ID)
+
+	// reopen with the cipher
+	svc = service.NewAuthzService(aclFilePath)
+	svc.SetCipher(cipher)
+	err = svc.Start(ctx)
+	require.NoError(t, err)
+	manageAuthz, _ = svc.CapManageAuthz(ctx, "admin")
+	roles, err := manageAuthz.GetGroupRoles(ctx, client1ID)
+	require.NoError(t, err)
+	assert.Equal(t, authz.ClientRoleOperator, roles[group1ID])
+	svc.Stop()
+
+	// the encrypted ACL file can't be read without the cipher
+	svc = service.NewAuthzService(aclFilePath)
+	err = svc.Start(ctx)
+	assert.Error(t, err)
+	svc.Stop()
+	_ = os.Remove(aclFilePath)
+}
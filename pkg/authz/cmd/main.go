@@ -6,23 +6,33 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/hiveot/hub/lib/listener"
 	"github.com/hiveot/hub/lib/svcconfig"
 	"github.com/hiveot/hub/pkg/authz"
 	"github.com/hiveot/hub/pkg/authz/capnpserver"
+	"github.com/hiveot/hub/pkg/authz/config"
 	"github.com/hiveot/hub/pkg/authz/service"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
 )
 
-const aclStoreFile = "authz.acl"
-
 // main entry point to start the authorization service
 func main() {
 	f, _, _ := svcconfig.SetupFolderConfig(authz.ServiceName)
-	aclStoreFolder := filepath.Join(f.Stores, authz.ServiceName)
-	aclStorePath := filepath.Join(aclStoreFolder, aclStoreFile)
-	_ = os.Mkdir(aclStoreFolder, 0700)
+	cfg := config.NewAuthzConfig(f.Stores)
+	_ = f.LoadConfig(&cfg)
+	_ = os.Mkdir(filepath.Dir(cfg.AclFile), 0700)
 
-	svc := service.NewAuthzService(aclStorePath)
+	svc := service.NewAuthzService(cfg.AclFile)
+	if cfg.Encryption.Enabled {
+		cfg.Encryption.ResolveSecretFile(f.Certs)
+		cipher, err := encrypted.LoadStoreCipher(cfg.Encryption.SecretFile, authz.ServiceName)
+		if err != nil {
+			logrus.Panicf("can't load the ACL store encryption key: %s", err)
+		}
+		svc.SetCipher(cipher)
+	}
 
 	listener.RunService(authz.ServiceName, f.SocketPath,
 		func(ctx context.Context, lis net.Listener) error {
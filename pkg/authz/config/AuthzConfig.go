@@ -0,0 +1,31 @@
+package config
+
+import (
+	"path"
+
+	"github.com/hiveot/hub/pkg/authz"
+	"github.com/hiveot/hub/pkg/authz/service/aclstore"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
+)
+
+// AuthzConfig contains the authz service configuration
+type AuthzConfig struct {
+
+	// AclFile to read the access control lists from.
+	// Default is 'aclstore.DefaultAclFilename' in the authz stores folder.
+	AclFile string `yaml:"aclFile"`
+
+	// Encryption at rest of the ACL file. Default is disabled.
+	// Only the enabled and secretFile settings apply.
+	Encryption encrypted.EncryptionConfig `yaml:"encryption"`
+}
+
+// NewAuthzConfig returns a new instance of authz service configuration with defaults
+//
+//	storeFolder is the default directory for the stores
+func NewAuthzConfig(storeFolder string) AuthzConfig {
+	cfg := AuthzConfig{
+		AclFile: path.Join(storeFolder, authz.ServiceName, aclstore.DefaultAclFilename),
+	}
+	return cfg
+}
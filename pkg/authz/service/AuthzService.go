@@ -7,6 +7,7 @@ import (
 
 	"github.com/hiveot/hub/pkg/authz"
 	"github.com/hiveot/hub/pkg/authz/service/aclstore"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
 )
 
 // AuthzService handles client management and authorization for access to Things.
@@ -44,6 +45,12 @@ func (authzService *AuthzService) CapVerifyAuthz(
 	return verifyAuthz, nil
 }
 
+// SetCipher enables encryption at rest of the ACL store using the given cipher.
+// This must be called before Start.
+func (authzService *AuthzService) SetCipher(cipher *encrypted.StoreCipher) {
+	authzService.aclStore.SetCipher(cipher)
+}
+
 // Stop closes the service and release resources
 func (authzService *AuthzService) Stop() {
 	authzService.aclStore.Close()
@@ -12,9 +12,16 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/hiveot/hub/pkg/authz"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
 	"github.com/hiveot/hub/pkg/state"
 )
 
+// DefaultAclFilename is the recommended name of the ACL file in the service store folder
+const DefaultAclFilename = "authz.acl"
+
+// AclEncryptionAD is the additional data used when encrypting the ACL file
+const AclEncryptionAD = "hiveot-authz-acl"
+
 // AclFileStore is an in-memory ACL store based on the state store
 type AclFileStore struct {
 	serviceID string
@@ -26,6 +33,8 @@ type AclFileStore struct {
 	// state store
 	store     state.IClientState
 	storePath string
+	// optional cipher to encrypt the ACL file at rest
+	cipher *encrypted.StoreCipher
 
 	// index of clients and their group roles. Updated on load.
 	// intended for fast lookup of roles
@@ -192,6 +201,13 @@ func (aclStore *AclFileStore) Reload(ctx context.Context) error {
 		logrus.Errorf("AclFileStore.Reload serviceID='%s'. File '%s': Error opening the ACL file: %s", aclStore.serviceID, aclStore.storePath, err)
 		return err
 	}
+	if aclStore.cipher != nil && len(raw) > 0 {
+		raw, err = aclStore.cipher.Decrypt(raw, []byte(AclEncryptionAD))
+		if err != nil {
+			logrus.Errorf("AclFileStore.Reload serviceID='%s'. File '%s': Error decrypting the ACL file: %s", aclStore.serviceID, aclStore.storePath, err)
+			return err
+		}
+	}
 	aclStore.mutex.Lock()
 	defer aclStore.mutex.Unlock()
 
@@ -307,6 +323,9 @@ func (aclStore *AclFileStore) Save() error {
 	var err error
 
 	yamlData, err := yaml.Marshal(aclStore.groups)
+	if err == nil && aclStore.cipher != nil {
+		yamlData, err = aclStore.cipher.Encrypt(yamlData, []byte(AclEncryptionAD))
+	}
 	if err == nil {
 		folder := path.Dir(aclStore.storePath)
 		file, err = os.CreateTemp(folder, "authz-aclfilestore")
@@ -333,6 +352,13 @@ func (aclStore *AclFileStore) Save() error {
 	return nil
 }
 
+// SetCipher sets the cipher to encrypt the ACL file with.
+// This must be called before Open. An existing plaintext ACL file must be encrypted first
+// with 'hubcli rotatekey authz'.
+func (aclStore *AclFileStore) SetCipher(cipher *encrypted.StoreCipher) {
+	aclStore.cipher = cipher
+}
+
 // NewAclFileStore creates an instance of a file based ACL store
 //
 //	filepath is the location of the store. See also DefaultAclFilename for the recommended name.
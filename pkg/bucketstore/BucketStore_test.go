@@ -1,10 +1,13 @@
 package bucketstore_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"math/rand"
 	"os"
+	"path"
 	"testing"
 	"time"
 
@@ -19,6 +22,8 @@ import (
 
 	"github.com/hiveot/hub/pkg/bucketstore"
 	"github.com/hiveot/hub/pkg/bucketstore/cmd"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
+	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
 )
 
 var testBucketID = "default"
@@ -583,3 +588,1035 @@ func TestPrevNextN(t *testing.T) {
 //	require.NoError(t, err)
 //	assert.Equal(t, 10, len(resp))
 //}
+
+func TestSetWithTTL(t *testing.T) {
+	const key1 = "key1"
+	const key2 = "key2"
+	backends := []string{bucketstore.BackendKVBTree, bucketstore.BackendBBolt, bucketstore.BackendPebble}
+
+	for _, backendType := range backends {
+		logrus.Infof("--- testing TTL of backend '%s'", backendType)
+		_ = os.RemoveAll(testBackendDirectory)
+		store := cmd.NewBucketStore(testBackendDirectory, testClientID, backendType)
+		reaper, ok := store.(interface{ SetReapInterval(time.Duration) })
+		require.True(t, ok)
+		reaper.SetReapInterval(time.Millisecond * 50)
+		err := store.Open()
+		require.NoError(t, err)
+
+		bucket := store.GetBucket(testBucketID)
+		err = bucket.SetWithTTL(key1, doc1, time.Millisecond*100)
+		require.NoError(t, err)
+		err = bucket.SetWithTTL(key2, doc2, time.Millisecond*100)
+		require.NoError(t, err)
+		// invalid ttl and key
+		err = bucket.SetWithTTL(key1, doc1, 0)
+		assert.Error(t, err)
+		err = bucket.SetWithTTL("", doc1, time.Second)
+		assert.Error(t, err)
+
+		// the documents are available before they expire
+		val, err := bucket.Get(key1)
+		assert.NoError(t, err)
+		assert.Equal(t, doc1, val)
+		// setting a document without TTL clears its expiry
+		err = bucket.Set(key2, doc2)
+		require.NoError(t, err)
+
+		// an expired document is never returned, even before it is removed
+		time.Sleep(time.Millisecond * 110)
+		val, _ = bucket.Get(key1)
+		assert.Nil(t, val)
+		docs, err := bucket.GetMultiple([]string{key1, key2})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(docs))
+		assert.Equal(t, doc2, docs[key2])
+
+		// the reaper removes the expired document
+		time.Sleep(time.Millisecond * 100)
+		cursor := bucket.Cursor()
+		k, _, valid := cursor.Seek(key1)
+		assert.True(t, valid)
+		assert.Equal(t, key2, k)
+		cursor.Release()
+		_ = bucket.Close()
+
+		err = store.Close()
+		assert.NoError(t, err)
+	}
+}
+
+func TestTTLPersists(t *testing.T) {
+	const key1 = "key1"
+	store, err := openNewStore()
+	require.NoError(t, err)
+	bucket := store.GetBucket(testBucketID)
+	err = bucket.SetWithTTL(key1, doc1, time.Millisecond*100)
+	require.NoError(t, err)
+	_ = bucket.Close()
+	err = store.Close()
+	require.NoError(t, err)
+
+	// the expiry time is restored on reopen
+	store = cmd.NewBucketStore(testBackendDirectory, testClientID, testBackendType)
+	err = store.Open()
+	require.NoError(t, err)
+	bucket = store.GetBucket(testBucketID)
+	val, err := bucket.Get(key1)
+	assert.NoError(t, err)
+	assert.Equal(t, doc1, val)
+	time.Sleep(time.Millisecond * 110)
+	val, _ = bucket.Get(key1)
+	assert.Nil(t, val)
+	_ = bucket.Close()
+	err = store.Close()
+	assert.NoError(t, err)
+}
+
+func TestPrefixRangeCursor(t *testing.T) {
+	backends := []string{bucketstore.BackendKVBTree, bucketstore.BackendBBolt, bucketstore.BackendPebble}
+	docs := map[string][]byte{
+		"a1": doc1, "b1": doc1, "b2": doc2, "b3": doc1, "c1": doc2,
+	}
+
+	for _, backendType := range backends {
+		logrus.Infof("--- testing prefix and range cursors of backend '%s'", backendType)
+		_ = os.RemoveAll(testBackendDirectory)
+		store := cmd.NewBucketStore(testBackendDirectory, testClientID, backendType)
+		err := store.Open()
+		require.NoError(t, err)
+		bucket := store.GetBucket(testBucketID)
+		err = bucket.SetMultiple(docs)
+		require.NoError(t, err)
+		// keys in another bucket are not included
+		bucket2 := store.GetBucket(testBucketID + "2")
+		err = bucket2.Set("b4", doc1)
+		require.NoError(t, err)
+		_ = bucket2.Close()
+
+		cursor := bucket.PrefixCursor("b")
+		k, _, valid := cursor.First()
+		assert.True(t, valid)
+		assert.Equal(t, "b1", k)
+		k, _, valid = cursor.Last()
+		assert.True(t, valid)
+		assert.Equal(t, "b3", k)
+		k, _, valid = cursor.Next()
+		assert.False(t, valid)
+		// seek before the prefix starts at the prefix
+		k, _, valid = cursor.Seek("a")
+		assert.True(t, valid)
+		assert.Equal(t, "b1", k)
+		kv, itemsRemaining := cursor.NextN(10)
+		assert.False(t, itemsRemaining)
+		assert.Equal(t, 2, len(kv))
+		cursor.Release()
+
+		// range excludes the end key
+		cursor = bucket.RangeCursor("a1", "b3")
+		k, _, valid = cursor.Last()
+		assert.True(t, valid)
+		assert.Equal(t, "b2", k)
+		kv, _ = cursor.PrevN(10)
+		assert.Equal(t, 2, len(kv))
+		k, _, valid = cursor.Seek("b3")
+		assert.False(t, valid)
+		cursor.Release()
+
+		// open ended range
+		cursor = bucket.RangeCursor("b3", "")
+		k, _, valid = cursor.First()
+		assert.True(t, valid)
+		assert.Equal(t, "b3", k)
+		kv, _ = cursor.NextN(10)
+		assert.Equal(t, 1, len(kv), backendType)
+		cursor.Release()
+
+		cursor = bucket.PrefixCursor("d")
+		_, _, valid = cursor.First()
+		assert.False(t, valid)
+		cursor.Release()
+
+		_ = bucket.Close()
+		err = store.Close()
+		assert.NoError(t, err)
+	}
+}
+
+func TestListDeleteBuckets(t *testing.T) {
+	backends := []string{bucketstore.BackendKVBTree, bucketstore.BackendBBolt, bucketstore.BackendPebble}
+	bucketIDs := []string{"bucket1", "bucket1/a", "bucket2"}
+
+	for _, backendType := range backends {
+		logrus.Infof("--- testing list and delete buckets of backend '%s'", backendType)
+		_ = os.RemoveAll(testBackendDirectory)
+		store := cmd.NewBucketStore(testBackendDirectory, testClientID, backendType)
+		err := store.Open()
+		require.NoError(t, err)
+		for _, bucketID := range bucketIDs {
+			bucket := store.GetBucket(bucketID)
+			err = bucket.Set(doc1ID, doc1)
+			require.NoError(t, err)
+			err = bucket.Set(doc2ID, doc2)
+			require.NoError(t, err)
+			_ = bucket.Close()
+		}
+		// expiry doesn't show up as a bucket
+		bucket := store.GetBucket("bucket2")
+		err = bucket.SetWithTTL("doc3", doc1, time.Minute)
+		require.NoError(t, err)
+		_ = bucket.Close()
+
+		names, err := store.ListBuckets()
+		require.NoError(t, err)
+		assert.Equal(t, bucketIDs, names)
+
+		info := store.Info()
+		assert.Equal(t, backendType, info.Engine)
+		assert.Equal(t, testClientID, info.Id)
+		if info.NrRecords >= 0 {
+			assert.Equal(t, int64(7), info.NrRecords)
+		}
+
+		err = store.DeleteBucket("bucket2")
+		assert.NoError(t, err)
+		// deleting a non-existing bucket is not an error
+		err = store.DeleteBucket("not-a-bucket")
+		assert.NoError(t, err)
+		names, err = store.ListBuckets()
+		require.NoError(t, err)
+		assert.Equal(t, bucketIDs[:2], names)
+
+		bucket = store.GetBucket("bucket2")
+		val, _ := bucket.Get("doc3")
+		assert.Nil(t, val)
+		_ = bucket.Close()
+
+		err = store.Close()
+		assert.NoError(t, err)
+	}
+}
+
+// countKeys returns the number of keys in a bucket
+func countKeys(store bucketstore.IBucketStore, bucketID string) int {
+	bucket := store.GetBucket(bucketID)
+	cursor := bucket.Cursor()
+	n := 0
+	for _, _, valid := cursor.First(); valid; _, _, valid = cursor.Next() {
+		n++
+	}
+	cursor.Release()
+	_ = bucket.Close()
+	return n
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	backends := []string{bucketstore.BackendKVBTree, bucketstore.BackendBBolt, bucketstore.BackendPebble}
+	snapshotDir := path.Join(testBackendDirectory, "snapshot")
+	tarDir := path.Join(testBackendDirectory, "fromtar")
+	const count = 100
+
+	for _, backendType := range backends {
+		logrus.Infof("--- testing snapshot and restore of backend '%s'", backendType)
+		_ = os.RemoveAll(testBackendDirectory)
+		store := cmd.NewBucketStore(testBackendDirectory, testClientID, backendType)
+		err := store.Open()
+		require.NoError(t, err)
+		err = addDocs(store, testBucketID, count)
+		require.NoError(t, err)
+		nrKeys := countKeys(store, testBucketID)
+		require.Greater(t, nrKeys, 0)
+
+		// writes continue while the snapshot is made
+		done := make(chan bool)
+		go func() {
+			bucket := store.GetBucket("writer")
+			for i := 0; i < 100; i++ {
+				_ = bucket.Set(fmt.Sprintf("key-%d", i), doc2)
+			}
+			_ = bucket.Close()
+			close(done)
+		}()
+		err = store.Snapshot(snapshotDir)
+		require.NoError(t, err)
+		<-done
+
+		// changes after the snapshot are undone by the restore
+		bucket := store.GetBucket(testBucketID)
+		err = bucket.Set("after-snapshot", doc1)
+		require.NoError(t, err)
+		_ = bucket.Close()
+
+		// restore requires a closed store
+		err = store.Restore(snapshotDir)
+		assert.Error(t, err)
+		err = store.Close()
+		require.NoError(t, err)
+		err = store.Restore(snapshotDir)
+		require.NoError(t, err)
+		err = store.Open()
+		require.NoError(t, err)
+		bucket = store.GetBucket(testBucketID)
+		val, _ := bucket.Get("after-snapshot")
+		assert.Nil(t, val, backendType)
+		_ = bucket.Close()
+		assert.Equal(t, nrKeys, countKeys(store, testBucketID), backendType)
+		err = store.Close()
+		assert.NoError(t, err)
+
+		// a snapshot can be transferred as a tar stream and opened as a store
+		buf := bytes.Buffer{}
+		err = bucketstore.WriteTar(&buf, snapshotDir)
+		require.NoError(t, err)
+		err = bucketstore.ReadTar(&buf, tarDir)
+		require.NoError(t, err)
+		store2 := cmd.NewBucketStore(tarDir, testClientID, backendType)
+		err = store2.Open()
+		require.NoError(t, err)
+		assert.Equal(t, nrKeys, countKeys(store2, testBucketID), backendType)
+		err = store2.Close()
+		assert.NoError(t, err)
+	}
+}
+
+func TestRestoreNoSnapshot(t *testing.T) {
+	_ = os.RemoveAll(testBackendDirectory)
+	store := cmd.NewBucketStore(testBackendDirectory, testClientID, testBackendType)
+	err := store.Restore(path.Join(testBackendDirectory, "not-a-snapshot"))
+	assert.Error(t, err)
+}
+
+func TestReadTarOutsideDirectory(t *testing.T) {
+	buf := bytes.Buffer{}
+	tw := tar.NewWriter(&buf)
+	err := tw.WriteHeader(&tar.Header{Name: "../escape", Mode: 0600, Size: 1, Typeflag: tar.TypeReg})
+	require.NoError(t, err)
+	_, _ = tw.Write([]byte("x"))
+	_ = tw.Close()
+	err = bucketstore.ReadTar(&buf, path.Join(testBackendDirectory, "fromtar"))
+	assert.Error(t, err)
+}
+
+func TestMigrateStore(t *testing.T) {
+	backends := []string{bucketstore.BackendKVBTree, bucketstore.BackendBBolt, bucketstore.BackendPebble}
+	const count = 2500
+	srcDir := path.Join(testBackendDirectory, "src")
+	dstDir := path.Join(testBackendDirectory, "dst")
+
+	for _, srcBackend := range backends {
+		for _, dstBackend := range backends {
+			if srcBackend == dstBackend {
+				continue
+			}
+			logrus.Infof("--- testing migration from '%s' to '%s'", srcBackend, dstBackend)
+			_ = os.RemoveAll(testBackendDirectory)
+			_ = os.MkdirAll(srcDir, 0700)
+			_ = os.MkdirAll(dstDir, 0700)
+			srcStore := cmd.NewBucketStore(srcDir, testClientID, srcBackend)
+			err := srcStore.Open()
+			require.NoError(t, err)
+			err = addDocs(srcStore, testBucketID, count)
+			require.NoError(t, err)
+			err = addDocs(srcStore, "bucket2", 10)
+			require.NoError(t, err)
+			// expired keys are not migrated
+			bucket := srcStore.GetBucket("bucket2")
+			err = bucket.SetWithTTL("expired", doc1, time.Millisecond)
+			require.NoError(t, err)
+			// keys with a time-to-live keep their expiry
+			err = bucket.SetWithTTL("ttl", doc1, time.Hour)
+			require.NoError(t, err)
+			time.Sleep(time.Millisecond * 2)
+			srcExpiry, err := bucket.GetExpiry([]string{"ttl", "expired", doc1ID})
+			require.NoError(t, err)
+			require.Equal(t, 1, len(srcExpiry))
+			_ = bucket.Close()
+			nrKeys := countKeys(srcStore, testBucketID)
+
+			dstStore := cmd.NewBucketStore(dstDir, testClientID, dstBackend)
+			err = dstStore.Open()
+			require.NoError(t, err)
+			lastProgress := bucketstore.MigrateProgress{}
+			total, err := bucketstore.MigrateStore(srcStore, dstStore, func(progress bucketstore.MigrateProgress) {
+				lastProgress = progress
+			})
+			require.NoError(t, err)
+			assert.Equal(t, nrKeys+countKeys(dstStore, "bucket2"), int(total))
+			assert.Equal(t, 2, lastProgress.NrBuckets)
+			assert.True(t, lastProgress.Done)
+			assert.Equal(t, total, lastProgress.TotalRecords)
+			assert.Equal(t, nrKeys, countKeys(dstStore, testBucketID))
+			bucket = dstStore.GetBucket("bucket2")
+			dstExpiry, err := bucket.GetExpiry([]string{"ttl", "expired"})
+			_ = bucket.Close()
+			require.NoError(t, err)
+			require.Equal(t, 1, len(dstExpiry))
+			assert.WithinDuration(t, srcExpiry["ttl"], dstExpiry["ttl"], time.Second)
+
+			checksums, err := bucketstore.VerifyStore(srcStore, dstStore)
+			require.NoError(t, err)
+			assert.Equal(t, 2, len(checksums))
+
+			// a non-empty destination is refused
+			_, err = bucketstore.MigrateStore(srcStore, dstStore, nil)
+			assert.Error(t, err)
+
+			// verification detects a changed value
+			bucket = dstStore.GetBucket(testBucketID)
+			err = bucket.Set(doc1ID, doc2)
+			require.NoError(t, err)
+			_ = bucket.Close()
+			_, err = bucketstore.VerifyStore(srcStore, dstStore)
+			assert.Error(t, err)
+
+			_ = srcStore.Close()
+			_ = dstStore.Close()
+		}
+	}
+}
+
+func TestTransactions(t *testing.T) {
+	backends := []string{bucketstore.BackendKVBTree, bucketstore.BackendBBolt, bucketstore.BackendPebble}
+
+	for _, backendType := range backends {
+		logrus.Infof("--- testing transactions of backend '%s'", backendType)
+		_ = os.RemoveAll(testBackendDirectory)
+		store := cmd.NewBucketStore(testBackendDirectory, testClientID, backendType)
+		err := store.Open()
+		require.NoError(t, err)
+		bucket := store.GetBucket(testBucketID)
+		err = bucket.Set(doc1ID, doc1)
+		require.NoError(t, err)
+		err = bucket.SetWithTTL(doc2ID, doc2, time.Minute)
+		require.NoError(t, err)
+
+		// changes are visible in the transaction but not outside until committed
+		tx, err := bucket.Begin()
+		require.NoError(t, err)
+		err = tx.Set("doc3", doc1)
+		require.NoError(t, err)
+		err = tx.Delete(doc1ID)
+		require.NoError(t, err)
+		err = tx.Set(doc2ID, doc1)
+		require.NoError(t, err)
+		val, err := tx.Get("doc3")
+		require.NoError(t, err)
+		assert.Equal(t, doc1, val)
+		val, err = tx.Get(doc1ID)
+		require.NoError(t, err)
+		assert.Nil(t, val)
+		err = tx.Commit()
+		require.NoError(t, err)
+
+		// a transaction can't be used after it has ended
+		err = tx.Set("doc4", doc1)
+		assert.ErrorIs(t, err, bucketstore.ErrTxEnded)
+		err = tx.Commit()
+		assert.Error(t, err)
+		err = tx.Rollback()
+		assert.NoError(t, err)
+
+		val, _ = bucket.Get("doc3")
+		assert.Equal(t, doc1, val)
+		val, _ = bucket.Get(doc1ID)
+		assert.Nil(t, val)
+		val, _ = bucket.Get(doc2ID)
+		assert.Equal(t, doc1, val)
+
+		// rollback discards all changes
+		tx, err = bucket.Begin()
+		require.NoError(t, err)
+		err = tx.Set(doc1ID, doc2)
+		require.NoError(t, err)
+		err = tx.Delete("doc3")
+		require.NoError(t, err)
+		err = tx.Rollback()
+		require.NoError(t, err)
+		val, _ = bucket.Get(doc1ID)
+		assert.Nil(t, val)
+		val, _ = bucket.Get("doc3")
+		assert.Equal(t, doc1, val)
+
+		// writes wait until the transaction has ended
+		tx, err = bucket.Begin()
+		require.NoError(t, err)
+		err = tx.Set(doc1ID, doc1)
+		require.NoError(t, err)
+		writeDone := make(chan bool)
+		go func() {
+			_ = bucket.Set(doc1ID, doc2)
+			close(writeDone)
+		}()
+		select {
+		case <-writeDone:
+			assert.Fail(t, "write didn't wait for the transaction")
+		case <-time.After(time.Millisecond * 100):
+		}
+		err = tx.Commit()
+		require.NoError(t, err)
+		<-writeDone
+		val, _ = bucket.Get(doc1ID)
+		assert.Equal(t, doc2, val)
+
+		// the ttl was cleared by the transaction and the change persists
+		_ = bucket.Close()
+		err = store.Close()
+		require.NoError(t, err)
+		err = store.Open()
+		require.NoError(t, err)
+		bucket = store.GetBucket(testBucketID)
+		val, _ = bucket.Get(doc2ID)
+		assert.Equal(t, doc1, val)
+		val, _ = bucket.Get("doc3")
+		assert.Equal(t, doc1, val)
+		_ = bucket.Close()
+		err = store.Close()
+		assert.NoError(t, err)
+	}
+}
+
+func TestCompareAndSet(t *testing.T) {
+	const counterKey = "counter"
+	const nrWriters = 5
+	const nrIncrements = 20
+	backends := []string{bucketstore.BackendKVBTree, bucketstore.BackendBBolt, bucketstore.BackendPebble}
+
+	for _, backendType := range backends {
+		logrus.Infof("--- testing compare-and-set of backend '%s'", backendType)
+		_ = os.RemoveAll(testBackendDirectory)
+		store := cmd.NewBucketStore(testBackendDirectory, testClientID, backendType)
+		err := store.Open()
+		require.NoError(t, err)
+		bucket := store.GetBucket(testBucketID)
+
+		// a nil old value only sets the key if it doesn't exist
+		swapped, err := bucket.CompareAndSet(counterKey, nil, []byte("0"))
+		require.NoError(t, err)
+		assert.True(t, swapped)
+		swapped, err = bucket.CompareAndSet(counterKey, nil, []byte("1"))
+		require.NoError(t, err)
+		assert.False(t, swapped)
+		swapped, err = bucket.CompareAndSet(counterKey, []byte("1"), []byte("2"))
+		require.NoError(t, err)
+		assert.False(t, swapped)
+
+		// concurrent increments don't lose updates
+		writersDone := make(chan bool)
+		for i := 0; i < nrWriters; i++ {
+			go func() {
+				for n := 0; n < nrIncrements; {
+					oldValue, _ := bucket.Get(counterKey)
+					var counter int
+					_, _ = fmt.Sscanf(string(oldValue), "%d", &counter)
+					newValue := []byte(fmt.Sprint(counter + 1))
+					swapped2, err2 := bucket.CompareAndSet(counterKey, oldValue, newValue)
+					if err2 != nil {
+						break
+					} else if swapped2 {
+						n++
+					}
+				}
+				writersDone <- true
+			}()
+		}
+		for i := 0; i < nrWriters; i++ {
+			<-writersDone
+		}
+		val, err := bucket.Get(counterKey)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprint(nrWriters*nrIncrements), string(val))
+
+		_ = bucket.Close()
+		err = store.Close()
+		assert.NoError(t, err)
+	}
+}
+
+// openKVBTreeWithWAL opens a kvbtree store that doesn't compact during the test, so changes
+// are only in its write-ahead log
+func openKVBTreeWithWAL(t *testing.T, storePath string, syncPolicy string) *kvbtree.KVBTreeStore {
+	store := kvbtree.NewKVStore(testClientID, storePath)
+	store.SetSyncPolicy(syncPolicy, time.Millisecond*10)
+	store.SetWriteDelay(time.Hour)
+	err := store.Open()
+	require.NoError(t, err)
+	return store
+}
+
+// crashCopy copies the store file and write-ahead log of an open store to another directory.
+// The copy is what remains on disk if the service crashes.
+func crashCopy(t *testing.T, storePath string, crashDir string) string {
+	_ = os.RemoveAll(crashDir)
+	err := os.MkdirAll(crashDir, 0700)
+	require.NoError(t, err)
+	crashPath := path.Join(crashDir, path.Base(storePath))
+	err = bucketstore.CopyFile(storePath, crashPath)
+	require.NoError(t, err)
+	err = bucketstore.CopyFile(storePath+kvbtree.WALFileSuffix, crashPath+kvbtree.WALFileSuffix)
+	require.NoError(t, err)
+	return crashPath
+}
+
+func TestWALReplay(t *testing.T) {
+	storePath := path.Join(testBackendDirectory, "wal.json")
+	crashDir := path.Join(testBackendDirectory, "crash")
+	policies := []string{kvbtree.WALSyncAlways, kvbtree.WALSyncInterval, kvbtree.WALSyncNever}
+
+	for _, policy := range policies {
+		logrus.Infof("--- testing write-ahead log replay with sync policy '%s'", policy)
+		_ = os.RemoveAll(testBackendDirectory)
+		err := os.MkdirAll(testBackendDirectory, 0700)
+		require.NoError(t, err)
+		store := openKVBTreeWithWAL(t, storePath, policy)
+		bucket := store.GetBucket(testBucketID)
+		err = bucket.Set(doc1ID, doc1)
+		require.NoError(t, err)
+		err = bucket.SetWithTTL(doc2ID, doc2, time.Minute)
+		require.NoError(t, err)
+		err = bucket.SetMultiple(map[string][]byte{"doc3": doc1, "doc4": doc2})
+		require.NoError(t, err)
+		err = bucket.Delete("doc4")
+		require.NoError(t, err)
+		tx, err := bucket.Begin()
+		require.NoError(t, err)
+		_ = tx.Set("doc5", doc1)
+		_ = tx.Delete("doc3")
+		err = tx.Commit()
+		require.NoError(t, err)
+		// changes of an uncommitted transaction are not logged
+		tx, err = bucket.Begin()
+		require.NoError(t, err)
+		_ = tx.Set("doc6", doc1)
+		_ = tx.Rollback()
+		_ = bucket.Close()
+		bucket2 := store.GetBucket("bucket2")
+		err = bucket2.Set(doc1ID, doc1)
+		require.NoError(t, err)
+		_ = bucket2.Close()
+		err = store.DeleteBucket("bucket2")
+		require.NoError(t, err)
+
+		// the store file hasn't been written yet so the changes must come from the log
+		crashPath := crashCopy(t, storePath, crashDir)
+		err = store.Close()
+		require.NoError(t, err)
+		_, err = os.Stat(storePath + kvbtree.WALFileSuffix)
+		assert.True(t, os.IsNotExist(err), "log should be removed on close")
+
+		store2 := kvbtree.NewKVStore(testClientID, crashPath)
+		err = store2.Open()
+		require.NoError(t, err)
+		bucketIDs, _ := store2.ListBuckets()
+		assert.Equal(t, []string{testBucketID}, bucketIDs)
+		bucket = store2.GetBucket(testBucketID)
+		docs, err := bucket.GetMultiple([]string{doc1ID, doc2ID, "doc3", "doc4", "doc5", "doc6"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{doc1ID: doc1, doc2ID: doc2, "doc5": doc1}, docs)
+		_ = bucket.Close()
+
+		// the replayed changes are compacted into the store file
+		err = store2.Close()
+		require.NoError(t, err)
+		store2 = kvbtree.NewKVStore(testClientID, crashPath)
+		err = store2.Open()
+		require.NoError(t, err)
+		assert.Equal(t, 3, countKeys(store2, testBucketID))
+		err = store2.Close()
+		assert.NoError(t, err)
+	}
+}
+
+func TestWALTruncated(t *testing.T) {
+	storePath := path.Join(testBackendDirectory, "wal.json")
+	crashDir := path.Join(testBackendDirectory, "crash")
+	_ = os.RemoveAll(testBackendDirectory)
+	err := os.MkdirAll(testBackendDirectory, 0700)
+	require.NoError(t, err)
+
+	store := openKVBTreeWithWAL(t, storePath, kvbtree.WALSyncAlways)
+	bucket := store.GetBucket(testBucketID)
+	_ = bucket.Set("doc1", doc1)
+	_ = bucket.Set("doc2", doc2)
+	_ = bucket.Set("doc3", doc1)
+	_ = bucket.Close()
+	crashPath := crashCopy(t, storePath, crashDir)
+	err = store.Close()
+	require.NoError(t, err)
+
+	// a crash while writing the last record leaves a partial record
+	walPath := crashPath + kvbtree.WALFileSuffix
+	walInfo, err := os.Stat(walPath)
+	require.NoError(t, err)
+	err = os.Truncate(walPath, walInfo.Size()-3)
+	require.NoError(t, err)
+
+	store2 := kvbtree.NewKVStore(testClientID, crashPath)
+	err = store2.Open()
+	require.NoError(t, err)
+	bucket = store2.GetBucket(testBucketID)
+	docs, _ := bucket.GetMultiple([]string{"doc1", "doc2", "doc3"})
+	assert.Equal(t, map[string][]byte{"doc1": doc1, "doc2": doc2}, docs)
+
+	// the log continues after the last valid record
+	err = bucket.Set("doc4", doc2)
+	require.NoError(t, err)
+	_ = bucket.Close()
+	crashPath2 := crashCopy(t, crashPath, path.Join(testBackendDirectory, "crash2"))
+	err = store2.Close()
+	require.NoError(t, err)
+	store3 := kvbtree.NewKVStore(testClientID, crashPath2)
+	err = store3.Open()
+	require.NoError(t, err)
+	bucket = store3.GetBucket(testBucketID)
+	docs, _ = bucket.GetMultiple([]string{"doc1", "doc2", "doc3", "doc4"})
+	assert.Equal(t, map[string][]byte{"doc1": doc1, "doc2": doc2, "doc4": doc2}, docs)
+	_ = bucket.Close()
+	err = store3.Close()
+	assert.NoError(t, err)
+}
+
+func TestWALCorrupted(t *testing.T) {
+	storePath := path.Join(testBackendDirectory, "wal.json")
+	crashDir := path.Join(testBackendDirectory, "crash")
+	_ = os.RemoveAll(testBackendDirectory)
+	err := os.MkdirAll(testBackendDirectory, 0700)
+	require.NoError(t, err)
+
+	store := openKVBTreeWithWAL(t, storePath, kvbtree.WALSyncAlways)
+	bucket := store.GetBucket(testBucketID)
+	_ = bucket.Set("doc1", doc1)
+	walInfo, err := os.Stat(storePath + kvbtree.WALFileSuffix)
+	require.NoError(t, err)
+	firstRecordSize := walInfo.Size()
+	_ = bucket.Set("doc2", doc2)
+	_ = bucket.Set("doc3", doc1)
+	_ = bucket.Close()
+	crashPath := crashCopy(t, storePath, crashDir)
+	err = store.Close()
+	require.NoError(t, err)
+
+	// corrupt a byte in the second record
+	walPath := crashPath + kvbtree.WALFileSuffix
+	walData, err := os.ReadFile(walPath)
+	require.NoError(t, err)
+	walData[firstRecordSize+12] ^= 0xFF
+	err = os.WriteFile(walPath, walData, 0600)
+	require.NoError(t, err)
+
+	// the records after the corruption can't be trusted
+	store2 := kvbtree.NewKVStore(testClientID, crashPath)
+	err = store2.Open()
+	require.NoError(t, err)
+	bucket = store2.GetBucket(testBucketID)
+	docs, _ := bucket.GetMultiple([]string{"doc1", "doc2", "doc3"})
+	assert.Equal(t, map[string][]byte{"doc1": doc1}, docs)
+	_ = bucket.Close()
+	err = store2.Close()
+	require.NoError(t, err)
+
+	// a log with a corrupted header is ignored
+	err = os.WriteFile(walPath, []byte{0xFF, 0xFF, 0xFF, 0xFF, 1, 2, 3, 4, 5}, 0600)
+	require.NoError(t, err)
+	store2 = kvbtree.NewKVStore(testClientID, crashPath)
+	err = store2.Open()
+	require.NoError(t, err)
+	assert.Equal(t, 1, countKeys(store2, testBucketID))
+	err = store2.Close()
+	assert.NoError(t, err)
+}
+
+func TestWALCompaction(t *testing.T) {
+	storePath := path.Join(testBackendDirectory, "wal.json")
+	walPath := storePath + kvbtree.WALFileSuffix
+	_ = os.RemoveAll(testBackendDirectory)
+	err := os.MkdirAll(testBackendDirectory, 0700)
+	require.NoError(t, err)
+
+	store := kvbtree.NewKVStore(testClientID, storePath)
+	store.SetWriteDelay(time.Millisecond * 50)
+	err = store.Open()
+	require.NoError(t, err)
+	bucket := store.GetBucket(testBucketID)
+	err = bucket.Set(doc1ID, doc1)
+	require.NoError(t, err)
+	walInfo, err := os.Stat(walPath)
+	require.NoError(t, err)
+	assert.Greater(t, walInfo.Size(), int64(0))
+
+	// compaction writes the store file and starts a new log
+	time.Sleep(time.Millisecond * 200)
+	walInfo, err = os.Stat(walPath)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), walInfo.Size())
+	err = bucket.Set(doc2ID, doc2)
+	require.NoError(t, err)
+
+	// the store file holds the compacted changes and the log the rest
+	crashPath := crashCopy(t, storePath, path.Join(testBackendDirectory, "crash"))
+	_ = bucket.Close()
+	err = store.Close()
+	require.NoError(t, err)
+	store2 := kvbtree.NewKVStore(testClientID, crashPath)
+	err = store2.Open()
+	require.NoError(t, err)
+	bucket = store2.GetBucket(testBucketID)
+	docs, _ := bucket.GetMultiple([]string{doc1ID, doc2ID})
+	assert.Equal(t, map[string][]byte{doc1ID: doc1, doc2ID: doc2}, docs)
+	_ = bucket.Close()
+	err = store2.Close()
+	assert.NoError(t, err)
+
+	// a restored store doesn't replay the log of the replaced store
+	err = os.WriteFile(walPath, []byte("stale log"), 0600)
+	require.NoError(t, err)
+	snapshotDir := path.Join(testBackendDirectory, "snapshot")
+	_ = os.MkdirAll(snapshotDir, 0700)
+	err = bucketstore.CopyFile(storePath, path.Join(snapshotDir, path.Base(storePath)))
+	require.NoError(t, err)
+	err = store.Restore(snapshotDir)
+	require.NoError(t, err)
+	_, err = os.Stat(walPath)
+	assert.True(t, os.IsNotExist(err))
+
+	// unknown sync policies are refused
+	store3 := kvbtree.NewKVStore(testClientID, storePath)
+	store3.SetSyncPolicy("sometimes", 0)
+	err = store3.Open()
+	assert.Error(t, err)
+}
+
+// create a secret file and return its cipher for the test store
+func newTestCipher(t *testing.T, secretFile string) *encrypted.StoreCipher {
+	_ = os.MkdirAll(path.Dir(secretFile), 0700)
+	err := encrypted.GenerateSecretFile(secretFile)
+	require.NoError(t, err)
+	sc, err := encrypted.LoadStoreCipher(secretFile, testClientID)
+	require.NoError(t, err)
+	return sc
+}
+
+func TestEncryptedStore(t *testing.T) {
+	backends := []string{bucketstore.BackendKVBTree, bucketstore.BackendBBolt, bucketstore.BackendPebble}
+	docs := map[string][]byte{
+		"a1": doc1, "b1": doc1, "b2": doc2, "b3": doc1, "c1": doc2,
+	}
+	secretDir := "/tmp/test-bucketstore-secret"
+
+	for _, backendType := range backends {
+		for _, encryptKeys := range []bool{false, true} {
+			logrus.Infof("--- testing encryption of backend '%s', encryptKeys=%v", backendType, encryptKeys)
+			_ = os.RemoveAll(testBackendDirectory)
+			_ = os.RemoveAll(secretDir)
+			secretFile := path.Join(secretDir, "secret")
+			sc := newTestCipher(t, secretFile)
+			rawStore := cmd.NewBucketStore(testBackendDirectory, testClientID, backendType)
+			store := encrypted.NewEncryptedStore(rawStore, sc, encryptKeys)
+			err := store.Open()
+			require.NoError(t, err)
+			bucket := store.GetBucket(testBucketID)
+			err = bucket.SetMultiple(docs)
+			require.NoError(t, err)
+			err = bucket.Set("empty", []byte{})
+			require.NoError(t, err)
+			val, err := bucket.Get("b2")
+			require.NoError(t, err)
+			assert.Equal(t, doc2, val)
+			val, err = bucket.Get("empty")
+			require.NoError(t, err)
+			assert.Equal(t, 0, len(val))
+			kv, err := bucket.GetMultiple([]string{"a1", "c1", "notakey"})
+			require.NoError(t, err)
+			assert.Equal(t, 2, len(kv))
+			assert.Equal(t, doc2, kv["c1"])
+			err = bucket.SetWithTTL("ttl", doc1, time.Hour)
+			require.NoError(t, err)
+			expiry, err := bucket.GetExpiry([]string{"ttl", "a1"})
+			require.NoError(t, err)
+			assert.Equal(t, 1, len(expiry))
+			assert.False(t, expiry["ttl"].IsZero())
+			err = bucket.Delete("ttl")
+			require.NoError(t, err)
+
+			// the stored values, and optionally keys, are encrypted
+			rawBucket := rawStore.GetBucket(testBucketID)
+			rawCursor := rawBucket.Cursor()
+			nrRaw := 0
+			for k, v, valid := rawCursor.First(); valid; k, v, valid = rawCursor.Next() {
+				_, isKey := docs[k]
+				assert.Equal(t, !encryptKeys, isKey || k == "empty")
+				assert.NotContains(t, string(v), "Title of doc")
+				nrRaw++
+			}
+			rawCursor.Release()
+			_ = rawBucket.Close()
+			assert.Equal(t, len(docs)+1, nrRaw)
+
+			// prefix cursors return the plaintext keys in the prefix
+			cursor := bucket.PrefixCursor("b")
+			k, v, valid := cursor.First()
+			require.True(t, valid)
+			assert.Equal(t, "b", k[:1])
+			assert.Equal(t, docs[k], v)
+			kv, _ = cursor.NextN(10)
+			assert.Equal(t, 2, len(kv))
+			cursor.Release()
+			cursor = bucket.RangeCursor("a1", "b2")
+			n := 0
+			for k, _, valid = cursor.First(); valid; k, _, valid = cursor.Next() {
+				assert.True(t, k == "a1" || k == "b1")
+				n++
+			}
+			assert.Equal(t, 2, n)
+			cursor.Release()
+
+			// transactions and compare-and-set compare the plaintext
+			swapped, err := bucket.CompareAndSet("a1", doc1, doc2)
+			require.NoError(t, err)
+			assert.True(t, swapped)
+			swapped, err = bucket.CompareAndSet("a1", doc1, doc2)
+			require.NoError(t, err)
+			assert.False(t, swapped)
+			tx, err := bucket.Begin()
+			require.NoError(t, err)
+			err = tx.Set("d1", doc1)
+			require.NoError(t, err)
+			val, err = tx.Get("d1")
+			require.NoError(t, err)
+			assert.Equal(t, doc1, val)
+			err = tx.Delete("c1")
+			require.NoError(t, err)
+			err = tx.Commit()
+			require.NoError(t, err)
+			val, err = bucket.Get("d1")
+			require.NoError(t, err)
+			assert.Equal(t, doc1, val)
+			val, _ = bucket.Get("c1")
+			assert.Nil(t, val)
+			_ = bucket.Close()
+			err = store.Close()
+			require.NoError(t, err)
+
+			// a store opened with a different secret can't decrypt the values
+			otherCipher := newTestCipher(t, path.Join(secretDir, "other"))
+			rawStore = cmd.NewBucketStore(testBackendDirectory, testClientID, backendType)
+			store = encrypted.NewEncryptedStore(rawStore, otherCipher, false)
+			err = store.Open()
+			require.NoError(t, err)
+			bucket = store.GetBucket(testBucketID)
+			if !encryptKeys {
+				_, err = bucket.Get("b2")
+				assert.Error(t, err)
+			}
+			cursor = bucket.Cursor()
+			_, _, valid = cursor.First()
+			assert.False(t, valid)
+			cursor.Release()
+			_ = bucket.Close()
+			_ = store.Close()
+		}
+	}
+	// the secret file of a store from config must exist
+	cfg := encrypted.EncryptionConfig{Enabled: true, SecretFile: "notafile"}
+	cfg.ResolveSecretFile(secretDir)
+	assert.Equal(t, path.Join(secretDir, "notafile"), cfg.SecretFile)
+	rawStore := cmd.NewBucketStore(testBackendDirectory, testClientID, testBackendType)
+	store := encrypted.NewStoreFromConfig(rawStore, cfg, testClientID)
+	err := store.Open()
+	assert.Error(t, err)
+	cfg.Enabled = false
+	store = encrypted.NewStoreFromConfig(rawStore, cfg, testClientID)
+	assert.Equal(t, rawStore, store)
+	_ = os.RemoveAll(secretDir)
+}
+
+func TestRotateStoreKey(t *testing.T) {
+	backends := []string{bucketstore.BackendKVBTree, bucketstore.BackendBBolt, bucketstore.BackendPebble}
+	const count = 1200
+	secretDir := "/tmp/test-bucketstore-secret"
+
+	for _, backendType := range backends {
+		logrus.Infof("--- testing key rotation of backend '%s'", backendType)
+		_ = os.RemoveAll(testBackendDirectory)
+		_ = os.RemoveAll(secretDir)
+		oldCipher := newTestCipher(t, path.Join(secretDir, "old"))
+		newCipher := newTestCipher(t, path.Join(secretDir, "new"))
+		store := cmd.NewBucketStore(testBackendDirectory, testClientID, backendType)
+		err := store.Open()
+		require.NoError(t, err)
+		err = addDocs(store, testBucketID, count)
+		require.NoError(t, err)
+		// a record with a time-to-live keeps its expiry
+		rawBucket := store.GetBucket(testBucketID)
+		err = rawBucket.SetWithTTL("ttl", doc1, time.Hour)
+		require.NoError(t, err)
+		expiry, err := rawBucket.GetExpiry([]string{"ttl"})
+		require.NoError(t, err)
+		ttlExpiry := expiry["ttl"]
+		require.False(t, ttlExpiry.IsZero())
+		_ = rawBucket.Close()
+		nrKeys := countKeys(store, testBucketID)
+		plainSum, err := bucketstore.GetBucketChecksum(store, testBucketID)
+		require.NoError(t, err)
+
+		// encrypt the plaintext store with encrypted keys
+		total, err := encrypted.RotateStoreKey(store, nil, oldCipher, true)
+		require.NoError(t, err)
+		assert.Equal(t, nrKeys, int(total))
+		assert.Equal(t, nrKeys, countKeys(store, testBucketID))
+		// rotate to the new secret, twice as if the first rotation was interrupted
+		_, err = encrypted.RotateStoreKey(store, oldCipher, newCipher, true)
+		require.NoError(t, err)
+		total, err = encrypted.RotateStoreKey(store, oldCipher, newCipher, true)
+		require.NoError(t, err)
+		assert.Equal(t, nrKeys, int(total))
+
+		encStore := encrypted.NewEncryptedStore(store, newCipher, true)
+		bucket := encStore.GetBucket(testBucketID)
+		val, err := bucket.Get(doc1ID)
+		require.NoError(t, err)
+		assert.Equal(t, doc1, val)
+		expiry, err = bucket.GetExpiry([]string{"ttl", doc1ID})
+		require.NoError(t, err)
+		assert.WithinDuration(t, ttlExpiry, expiry["ttl"], time.Second)
+		assert.NotContains(t, expiry, doc1ID)
+		_ = bucket.Close()
+
+		// the old secret no longer works
+		_, err = encrypted.RotateStoreKey(store, oldCipher, nil, false)
+		assert.Error(t, err)
+
+		// decrypt the store again
+		total, err = encrypted.RotateStoreKey(store, newCipher, nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, nrKeys, int(total))
+		sum, err := bucketstore.GetBucketChecksum(store, testBucketID)
+		require.NoError(t, err)
+		assert.Equal(t, plainSum, sum)
+		rawBucket = store.GetBucket(testBucketID)
+		expiry, err = rawBucket.GetExpiry([]string{"ttl"})
+		require.NoError(t, err)
+		assert.WithinDuration(t, ttlExpiry, expiry["ttl"], time.Second)
+		_ = rawBucket.Close()
+		_, err = encrypted.RotateStoreKey(store, nil, nil, false)
+		assert.Error(t, err)
+		_ = store.Close()
+	}
+
+	// rotate the key of an encrypted file
+	oldCipher := newTestCipher(t, path.Join(secretDir, "oldfile"))
+	newCipher := newTestCipher(t, path.Join(secretDir, "newfile"))
+	filePath := path.Join(secretDir, "file.acl")
+	err := os.WriteFile(filePath, doc1, 0600)
+	require.NoError(t, err)
+	err = encrypted.RotateFileKey(filePath, nil, oldCipher, []byte("test"))
+	require.NoError(t, err)
+	err = encrypted.RotateFileKey(filePath, oldCipher, newCipher, []byte("test"))
+	require.NoError(t, err)
+	data, _ := os.ReadFile(filePath)
+	plaintext, err := newCipher.Decrypt(data, []byte("test"))
+	require.NoError(t, err)
+	assert.Equal(t, doc1, plaintext)
+	_, err = oldCipher.Decrypt(data, []byte("test"))
+	assert.Error(t, err)
+	_ = os.RemoveAll(secretDir)
+}
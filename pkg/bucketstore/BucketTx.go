@@ -0,0 +1,31 @@
+package bucketstore
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ErrTxEnded is returned when a transaction is used after Commit or Rollback
+var ErrTxEnded = fmt.Errorf("transaction has already ended")
+
+// CompareAndSetWithTx implements CompareAndSet of a bucket using a bucket transaction.
+// Use nil for oldValue to only set the key if it doesn't exist.
+func CompareAndSetWithTx(bucket IBucket, key string, oldValue []byte, newValue []byte) (swapped bool, err error) {
+	tx, err := bucket.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+	currentValue, err := tx.Get(key)
+	if err != nil {
+		return false, err
+	}
+	if (currentValue == nil) != (oldValue == nil) || !bytes.Equal(currentValue, oldValue) {
+		return false, nil
+	}
+	err = tx.Set(key, newValue)
+	if err == nil {
+		err = tx.Commit()
+	}
+	return err == nil, err
+}
@@ -0,0 +1,35 @@
+package bucketstore
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// ExpiryBucketPrefix is the prefix of the bucket that holds the expiry time of the keys of a bucket
+// that are set with a TTL. The expiry bucket of a bucket is named {ExpiryBucketPrefix}{bucketID}.
+// Its keys are the keys of the bucket with an expiry and its values are the encoded expiry times.
+const ExpiryBucketPrefix = "$expiry/"
+
+// DefaultReapInterval is the default interval in which expired keys are removed from the store
+const DefaultReapInterval = time.Minute
+
+// EncodeExpiry encodes the expiry time of a key as msec since epoch in big-endian order
+func EncodeExpiry(expiry time.Time) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(expiry.UnixMilli()))
+}
+
+// DecodeExpiry returns the expiry time in msec since epoch of an encoded expiry time.
+// This returns false if the encoded expiry time is invalid.
+func DecodeExpiry(encodedExpiry []byte) (expiryMsec int64, valid bool) {
+	if len(encodedExpiry) != 8 {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(encodedExpiry)), true
+}
+
+// IsExpired returns true if the encoded expiry time is before or at the given time.
+// Invalid expiry times are considered expired.
+func IsExpired(encodedExpiry []byte, now time.Time) bool {
+	expiryMsec, valid := DecodeExpiry(encodedExpiry)
+	return !valid || expiryMsec <= now.UnixMilli()
+}
@@ -0,0 +1,36 @@
+package bucketstore
+
+import (
+	"os"
+)
+
+// WriteFileSync writes the data to a file and syncs it to disk
+func WriteFileSync(filePath string, data []byte) error {
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	err2 := file.Close()
+	if err == nil {
+		err = err2
+	}
+	return err
+}
+
+// SyncDir syncs a directory to disk, making the creation, removal and renaming of its files durable
+func SyncDir(dirPath string) error {
+	dir, err := os.Open(dirPath)
+	if err != nil {
+		return err
+	}
+	err = dir.Sync()
+	err2 := dir.Close()
+	if err == nil {
+		err = err2
+	}
+	return err
+}
@@ -3,6 +3,8 @@
 // This package defines an API to use the store with several implementations.
 package bucketstore
 
+import "time"
+
 // Available embedded bucket store implementations with low memory overhead
 const (
 	BackendKVBTree = "kvbtree" // fastest and best for small to medium amounts of data (dependent on available memory)
@@ -43,6 +45,11 @@ type BucketStoreInfo struct {
 //
 // TODO: add refcount for multiple consumers of the store so it can be closed when done.
 type IBucketStore interface {
+	// DeleteBucket removes the bucket with all its keys and their expiry from the store.
+	// Returns nil if the bucket is deleted or doesn't exist.
+	// Buckets that are in use must be closed first.
+	DeleteBucket(bucketID string) error
+
 	// GetBucket returns a bucket to use.
 	// This creates the bucket if it doesn't exist.
 	// Use bucket.Close() to close the bucket and release its resources.
@@ -55,32 +62,92 @@ type IBucketStore interface {
 	Open() error
 
 	// Info returns bucket store information
-	//Info() *BucketStoreInfo
+	// Id is the client ID and NrRecords the total number of records in all buckets.
+	Info() *BucketStoreInfo
+
+	// ListBuckets returns the IDs of the buckets in the store in ascending order
+	// Buckets that have been created but not yet written to might not be included.
+	ListBuckets() (bucketIDs []string, err error)
+
+	// Restore replaces the content of the store with a snapshot that was made with Snapshot.
+	// The store must be closed. Open the store after the restore to use it.
+	//  directory is the directory that holds the snapshot
+	Restore(directory string) error
+
+	// Snapshot writes a point-in-time copy of the store into the given directory.
+	// Writes to the store can continue while the snapshot is made.
+	// The snapshot has the same layout as the store directory, so it can be opened as a store
+	// or restored with Restore. An existing snapshot of the store in the directory is replaced.
+	//  directory is created if it doesn't exist
+	Snapshot(directory string) error
+}
+
+// IBucketTx is a transaction on a bucket for atomic multi-key updates.
+// Get returns the values as changed by the transaction. Changes are not visible to other
+// readers until Commit. The transaction must end with Commit or Rollback.
+type IBucketTx interface {
+
+	// Commit writes the changes made in the transaction to the bucket, all or nothing,
+	// and ends the transaction.
+	// Returns an error if the transaction has already ended or can't be written.
+	Commit() error
+
+	// Delete removes the key and its expiry in the transaction
+	Delete(key string) error
+
+	// Get returns the value of a key in the transaction
+	// Returns nil without error if the key doesn't exist or has expired.
+	Get(key string) (value []byte, err error)
+
+	// Rollback discards the changes made in the transaction and ends the transaction.
+	// This does nothing if the transaction has already ended, so it can be deferred.
+	Rollback() error
+
+	// Set sets the value of a key in the transaction and clears its expiry
+	// An error is returned if the key is empty.
+	Set(key string, value []byte) error
 }
 
 // IBucket defines the interface to a store key-value bucket
 type IBucket interface {
 
+	// Begin starts a transaction for atomic updates of multiple keys in the bucket.
+	// The transaction holds the write lock of the bucket or store. Other writes wait until the
+	// transaction ends with Commit or Rollback, so keep transactions short and don't write
+	// to the store outside the transaction while it is open.
+	Begin() (tx IBucketTx, err error)
+
 	// Close the bucket and release its resources
 	// If commit is true and transactions are support then this commits the transaction.
 	// use false to rollback the transaction. For readonly buckets commit returns an error
 	Close() error
 
+	// CompareAndSet atomically replaces the value of a key if its current value equals oldValue.
+	// Use nil for oldValue to only set the key if it doesn't exist or has expired.
+	// This clears the expiry of the key, just like Set.
+	// Returns true if the value is replaced, or false if the current value differs.
+	CompareAndSet(key string, oldValue []byte, newValue []byte) (swapped bool, err error)
+
 	// Cursor creates a new bucket cursor for iterating the bucket
 	// cursor.Close must be called after use to release any read transactions
 	Cursor() (cursor IBucketCursor)
 
-	// Delete removes the key-value pair from the bucket store
+	// Delete removes the key-value pair and its expiry from the bucket store
 	// Returns nil if the key is deleted or doesn't exist.
 	// Returns an error if the key cannot be deleted.
 	Delete(key string) (err error)
 
 	// Get returns the document for the given key
+	// Expired documents are not returned.
 	// Returns nil and an error if the key isn't found in the bucket or the database cannot be read
 	Get(key string) (value []byte, err error)
 
+	// GetExpiry returns the expiry time of keys that are set with a TTL and have not expired.
+	// Keys that don't exist or have no expiry are not included in the result.
+	GetExpiry(keys []string) (expiry map[string]time.Time, err error)
+
 	// GetMultiple returns a batch of documents with existing keys
-	// if a key does not exist it will not be included in the result.
+	// if a key does not exist or is expired it will not be included in the result.
 	// An error is return if the database cannot be read.
 	GetMultiple(keys []string) (keyValues map[string][]byte, err error)
 
@@ -90,13 +157,28 @@ type IBucket interface {
 	// Info returns the bucket information, when available
 	Info() *BucketStoreInfo
 
+	// PrefixCursor creates a new cursor for iterating the keys that start with the given prefix
+	// cursor.Release must be called after use to release any read transactions
+	PrefixCursor(prefix string) (cursor IBucketCursor)
+
+	// RangeCursor creates a new cursor for iterating the keys in the range [startKey, endKey)
+	// Use "" for startKey to start at the first key and "" for endKey to end at the last key.
+	// cursor.Release must be called after use to release any read transactions
+	RangeCursor(startKey string, endKey string) (cursor IBucketCursor)
+
 	// Set sets a document with the given key
-	// This stores a copy of value.
+	// This stores a copy of value and clears the expiry of the key, if any.
 	// An error is returned if either the bucketID or the key is empty
 	Set(key string, value []byte) error
 
+	// SetWithTTL sets a document with the given key that expires after the time-to-live.
+	// Expired documents are not returned by Get and GetMultiple. A background reaper
+	// periodically removes them from the store. Until then cursors can still return them.
+	// An error is returned if the key is empty or ttl is not positive.
+	SetWithTTL(key string, value []byte, ttl time.Duration) error
+
 	// SetMultiple sets multiple documents in a batch update
-	// This stores a copy of docs.
+	// This stores a copy of docs and clears their expiry, if any.
 	// If the transaction fails an error is returned and no changes are made.
 	SetMultiple(docs map[string][]byte) (err error)
 
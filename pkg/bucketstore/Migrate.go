@@ -0,0 +1,241 @@
+package bucketstore
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+	"time"
+)
+
+// MigrateBatchSize is the number of records that are read and written in a single batch
+const MigrateBatchSize = 1000
+
+// MigrateProgress holds the progress of a store migration
+type MigrateProgress struct {
+	// ID of the bucket that is being migrated
+	BucketID string
+	// Number of the bucket that is being migrated, starting at 1
+	BucketNr int
+	// Total number of buckets to migrate
+	NrBuckets int
+	// Number of records migrated of the bucket
+	BucketRecords int64
+	// Number of records migrated of all buckets
+	TotalRecords int64
+	// The bucket migration has completed
+	Done bool
+}
+
+// BucketChecksum holds the record count and checksum of the content of a bucket
+type BucketChecksum struct {
+	BucketID  string
+	NrRecords int64
+	// hex encoded sha256 of the keys and values in key order
+	Checksum string
+}
+
+// iterateBucket passes the keys and values of a bucket to the handler in batches, in key order.
+// Expired keys that have not yet been removed by the reaper are skipped.
+func iterateBucket(bucket IBucket, handler func(keys []string, docs map[string][]byte) error) error {
+	cursor := bucket.Cursor()
+	if cursor == nil {
+		return fmt.Errorf("unable to iterate bucket '%s'", bucket.ID())
+	}
+	defer cursor.Release()
+	keys := make([]string, 0, MigrateBatchSize)
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		// GetMultiple doesn't return expired keys
+		docs, err := bucket.GetMultiple(keys)
+		if err == nil {
+			err = handler(keys, docs)
+		}
+		keys = keys[:0]
+		return err
+	}
+	for key, _, valid := cursor.First(); valid; key, _, valid = cursor.Next() {
+		keys = append(keys, key)
+		if len(keys) == MigrateBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
+
+// hashRecord adds a key-value record to the checksum.
+// The lengths are included to avoid that different records produce the same checksum.
+func hashRecord(h hash.Hash, key string, value []byte) {
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(key)))
+	h.Write(lenBuf[:])
+	h.Write([]byte(key))
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(value)))
+	h.Write(lenBuf[:])
+	h.Write(value)
+}
+
+// GetBucketChecksum returns the number of records and the checksum of a bucket in a store.
+// The checksum does not depend on the backend so it can be used to compare the content of stores.
+func GetBucketChecksum(store IBucketStore, bucketID string) (checksum BucketChecksum, err error) {
+	bucket := store.GetBucket(bucketID)
+	defer bucket.Close()
+	h := sha256.New()
+	checksum.BucketID = bucketID
+	err = iterateBucket(bucket, func(keys []string, docs map[string][]byte) error {
+		for _, key := range keys {
+			value, found := docs[key]
+			if found {
+				hashRecord(h, key, value)
+				checksum.NrRecords++
+			}
+		}
+		return nil
+	})
+	checksum.Checksum = hex.EncodeToString(h.Sum(nil))
+	return checksum, err
+}
+
+// migrateBatch writes a batch of records to the destination bucket.
+// Records with an expiry time in the source bucket are written with the remaining time-to-live.
+// This returns the number of records that were written.
+func migrateBatch(srcBucket IBucket, dstBucket IBucket,
+	keys []string, docs map[string][]byte) (nrRecords int64, err error) {
+
+	expiry, err := srcBucket.GetExpiry(keys)
+	if err != nil {
+		return 0, err
+	}
+	permanentDocs := docs
+	if len(expiry) > 0 {
+		permanentDocs = make(map[string][]byte, len(docs))
+		for key, value := range docs {
+			expiryTime, hasExpiry := expiry[key]
+			if !hasExpiry {
+				permanentDocs[key] = value
+				continue
+			}
+			ttl := time.Until(expiryTime)
+			if ttl <= 0 {
+				// expired since it was read
+				continue
+			}
+			err = dstBucket.SetWithTTL(key, value, ttl)
+			if err != nil {
+				return nrRecords, err
+			}
+			nrRecords++
+		}
+	}
+	err = dstBucket.SetMultiple(permanentDocs)
+	if err != nil {
+		return nrRecords, err
+	}
+	nrRecords += int64(len(permanentDocs))
+	return nrRecords, nil
+}
+
+// MigrateStore copies all buckets and their keys from the source store to the destination store.
+// The destination store must be empty. The stores can use different backends.
+// Keys that have expired are skipped. Keys with a time-to-live are stored with the same expiry
+// time, using SetWithTTL.
+//
+//	srcStore is the open store to copy from
+//	dstStore is the open empty store to copy to
+//	onProgress is an optional callback invoked after each batch and after each bucket completes
+//
+// This returns the total number of migrated records.
+func MigrateStore(srcStore IBucketStore, dstStore IBucketStore,
+	onProgress func(progress MigrateProgress)) (totalRecords int64, err error) {
+
+	dstBuckets, err := dstStore.ListBuckets()
+	if err != nil {
+		return 0, err
+	} else if len(dstBuckets) > 0 {
+		return 0, fmt.Errorf("destination store is not empty. It has %d buckets", len(dstBuckets))
+	}
+	bucketIDs, err := srcStore.ListBuckets()
+	if err != nil {
+		return 0, err
+	}
+	for i, bucketID := range bucketIDs {
+		progress := MigrateProgress{
+			BucketID:     bucketID,
+			BucketNr:     i + 1,
+			NrBuckets:    len(bucketIDs),
+			TotalRecords: totalRecords,
+		}
+		srcBucket := srcStore.GetBucket(bucketID)
+		dstBucket := dstStore.GetBucket(bucketID)
+		err = iterateBucket(srcBucket, func(keys []string, docs map[string][]byte) error {
+			nrRecords, err2 := migrateBatch(srcBucket, dstBucket, keys, docs)
+			if err2 == nil {
+				progress.BucketRecords += nrRecords
+				progress.TotalRecords += nrRecords
+				if onProgress != nil {
+					onProgress(progress)
+				}
+			}
+			return err2
+		})
+		_ = srcBucket.Close()
+		err2 := dstBucket.Close()
+		if err == nil {
+			err = err2
+		}
+		if err != nil {
+			return totalRecords, fmt.Errorf("migration of bucket '%s' failed: %w", bucketID, err)
+		}
+		totalRecords = progress.TotalRecords
+		progress.Done = true
+		if onProgress != nil {
+			onProgress(progress)
+		}
+	}
+	return totalRecords, nil
+}
+
+// VerifyStore compares the record counts and checksums of the buckets of two stores.
+// Empty buckets are treated the same as missing buckets, as not all backends keep empty buckets.
+// This returns the checksums of the buckets, or an error describing the first difference.
+func VerifyStore(srcStore IBucketStore, dstStore IBucketStore) (checksums []BucketChecksum, err error) {
+	srcBuckets, err := srcStore.ListBuckets()
+	if err != nil {
+		return nil, err
+	}
+	dstBuckets, err := dstStore.ListBuckets()
+	if err != nil {
+		return nil, err
+	}
+	// compare the buckets of both stores
+	bucketIDs := append(srcBuckets, dstBuckets...)
+	sort.Strings(bucketIDs)
+	checksums = make([]BucketChecksum, 0, len(bucketIDs))
+	for i, bucketID := range bucketIDs {
+		if i > 0 && bucketIDs[i-1] == bucketID {
+			continue
+		}
+		srcSum, err := GetBucketChecksum(srcStore, bucketID)
+		if err != nil {
+			return checksums, err
+		}
+		dstSum, err := GetBucketChecksum(dstStore, bucketID)
+		if err != nil {
+			return checksums, err
+		}
+		if srcSum.NrRecords != dstSum.NrRecords {
+			return checksums, fmt.Errorf("bucket '%s' has %d records in the source and %d in the destination",
+				bucketID, srcSum.NrRecords, dstSum.NrRecords)
+		} else if srcSum.Checksum != dstSum.Checksum {
+			return checksums, fmt.Errorf("bucket '%s' has a different checksum in the destination", bucketID)
+		}
+		checksums = append(checksums, srcSum)
+	}
+	return checksums, nil
+}
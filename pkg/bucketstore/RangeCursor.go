@@ -0,0 +1,116 @@
+package bucketstore
+
+// RangeCursor limits the iteration of a bucket cursor to the keys in the range [startKey, endKey).
+// This is used by backends that don't support iterator bounds natively.
+// This implements the IBucketCursor API
+type RangeCursor struct {
+	cursor IBucketCursor
+	// first key of the range, "" to start at the beginning of the bucket
+	startKey string
+	// key after the end of the range, "" to end at the end of the bucket
+	endKey string
+}
+
+// inRange returns the key-value if the key is valid and falls within the range
+func (rc *RangeCursor) inRange(key string, value []byte, valid bool) (string, []byte, bool) {
+	if !valid || key < rc.startKey || (rc.endKey != "" && key >= rc.endKey) {
+		return "", nil, false
+	}
+	return key, value, true
+}
+
+// First moves the cursor to the first key in the range
+func (rc *RangeCursor) First() (key string, value []byte, valid bool) {
+	if rc.startKey == "" {
+		return rc.inRange(rc.cursor.First())
+	}
+	return rc.inRange(rc.cursor.Seek(rc.startKey))
+}
+
+// Last moves the cursor to the last key in the range
+func (rc *RangeCursor) Last() (key string, value []byte, valid bool) {
+	if rc.endKey == "" {
+		return rc.inRange(rc.cursor.Last())
+	}
+	// the key before the end of the range is the last key in the range
+	_, _, valid = rc.cursor.Seek(rc.endKey)
+	if valid {
+		return rc.inRange(rc.cursor.Prev())
+	}
+	return rc.inRange(rc.cursor.Last())
+}
+
+// Next moves the cursor to the next key in the range
+func (rc *RangeCursor) Next() (key string, value []byte, valid bool) {
+	return rc.inRange(rc.cursor.Next())
+}
+
+// NextN moves the cursor to the next N keys in the range and returns the key-value pairs
+func (rc *RangeCursor) NextN(steps uint) (docs map[string][]byte, itemsRemaining bool) {
+	docs = make(map[string][]byte)
+	for i := uint(0); i < steps; i++ {
+		key, value, valid := rc.Next()
+		if !valid {
+			return docs, false
+		}
+		docs[key] = value
+	}
+	return docs, true
+}
+
+// Prev moves the cursor to the previous key in the range
+func (rc *RangeCursor) Prev() (key string, value []byte, valid bool) {
+	return rc.inRange(rc.cursor.Prev())
+}
+
+// PrevN moves the cursor back N keys in the range and returns the key-value pairs
+func (rc *RangeCursor) PrevN(steps uint) (docs map[string][]byte, itemsRemaining bool) {
+	docs = make(map[string][]byte)
+	for i := uint(0); i < steps; i++ {
+		key, value, valid := rc.Prev()
+		if !valid {
+			return docs, false
+		}
+		docs[key] = value
+	}
+	return docs, true
+}
+
+// Release the cursor and its underlying cursor
+func (rc *RangeCursor) Release() {
+	rc.cursor.Release()
+}
+
+// Seek positions the cursor at the given searchKey or the next key in the range.
+// A searchKey before the start of the range seeks the start of the range.
+func (rc *RangeCursor) Seek(searchKey string) (key string, value []byte, valid bool) {
+	if searchKey < rc.startKey {
+		searchKey = rc.startKey
+	}
+	return rc.inRange(rc.cursor.Seek(searchKey))
+}
+
+// PrefixRangeEnd returns the first key after all keys that start with the given prefix.
+// This returns "" if there is no such key, eg the prefix is empty or consists of 0xff bytes.
+func PrefixRangeEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	return ""
+}
+
+// NewRangeCursor returns a cursor that iterates the keys of the given cursor within a range.
+// The range includes startKey and excludes endKey. Use "" for an open start or end.
+// Releasing the range cursor releases the given cursor.
+func NewRangeCursor(cursor IBucketCursor, startKey string, endKey string) *RangeCursor {
+	rc := &RangeCursor{
+		cursor:   cursor,
+		startKey: startKey,
+		endKey:   endKey,
+	}
+	return rc
+}
@@ -0,0 +1,148 @@
+package bucketstore
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CopyFile copies a file to the destination path.
+// The file is first written to a temporary file that is renamed when complete, so an existing
+// destination file is only replaced when the copy succeeds.
+func CopyFile(srcPath string, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	tmpPath := dstPath + ".tmp"
+	dst, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	if err == nil {
+		err = dst.Sync()
+	}
+	err2 := dst.Close()
+	if err == nil {
+		err = err2
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, dstPath)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+	return err
+}
+
+// CopyDir copies the files of a directory tree to the destination directory.
+// The destination directory is created if it doesn't exist. Existing files are replaced.
+func CopyDir(srcDir string, dstDir string) error {
+	return filepath.WalkDir(srcDir, func(srcPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(srcDir, srcPath)
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(dstDir, relPath)
+		if d.IsDir() {
+			return os.MkdirAll(dstPath, 0700)
+		} else if !d.Type().IsRegular() {
+			return fmt.Errorf("'%s' is not a regular file", srcPath)
+		}
+		return CopyFile(srcPath, dstPath)
+	})
+}
+
+// WriteTar writes the files of a snapshot directory to a tar stream.
+// File names in the stream are relative to the directory.
+func WriteTar(w io.Writer, directory string) error {
+	tw := tar.NewWriter(w)
+	err := filepath.WalkDir(directory, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(directory, filePath)
+		if err != nil || relPath == "." {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(relPath)
+		if d.IsDir() {
+			hdr.Name += "/"
+			return tw.WriteHeader(hdr)
+		} else if !d.Type().IsRegular() {
+			return fmt.Errorf("'%s' is not a regular file", filePath)
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		fp, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(tw, fp)
+		_ = fp.Close()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// ReadTar extracts a tar stream written with WriteTar into a directory.
+// The directory is created if it doesn't exist.
+// This returns an error if the stream contains entries outside the directory or that aren't
+// regular files or directories.
+func ReadTar(r io.Reader, directory string) error {
+	tr := tar.NewReader(r)
+	err := os.MkdirAll(directory, 0700)
+	for err == nil {
+		var hdr *tar.Header
+		hdr, err = tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			break
+		}
+		filePath := filepath.Join(directory, filepath.FromSlash(hdr.Name))
+		if !strings.HasPrefix(filePath, filepath.Clean(directory)+string(os.PathSeparator)) {
+			err = fmt.Errorf("tar entry '%s' is outside the directory", hdr.Name)
+			break
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(filePath, 0700)
+		case tar.TypeReg:
+			err = os.MkdirAll(filepath.Dir(filePath), 0700)
+			if err == nil {
+				var fp *os.File
+				fp, err = os.OpenFile(filePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+				if err == nil {
+					_, err = io.Copy(fp, tr)
+					_ = fp.Close()
+				}
+			}
+		default:
+			err = fmt.Errorf("tar entry '%s' is not a regular file or directory", hdr.Name)
+		}
+	}
+	return err
+}
@@ -1,8 +1,8 @@
 package bolts
 
 import (
-	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.etcd.io/bbolt"
@@ -57,6 +57,42 @@ func (bb *BoltBucket) bucketTransaction(writable bool, cb func(bucket *bbolt.Buc
 	return err
 }
 
+// expiryBucket returns the bbolt bucket with the expiry times of the keys of this bucket
+// This returns nil if no keys of this bucket have an expiry time.
+func (bb *BoltBucket) expiryBucket(tx *bbolt.Tx) *bbolt.Bucket {
+	return tx.Bucket([]byte(bucketstore.ExpiryBucketPrefix + bb.bucketID))
+}
+
+// clearExpiry removes the expiry time of the given keys, if any
+func (bb *BoltBucket) clearExpiry(tx *bbolt.Tx, keys ...string) error {
+	expBucket := bb.expiryBucket(tx)
+	if expBucket == nil {
+		return nil
+	}
+	for _, key := range keys {
+		if err := expBucket.Delete([]byte(key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isExpired returns true if the key has an expiry time that has passed
+func (bb *BoltBucket) isExpired(tx *bbolt.Tx, key string, now time.Time) bool {
+	expBucket := bb.expiryBucket(tx)
+	if expBucket == nil {
+		return false
+	}
+	encodedExpiry := expBucket.Get([]byte(key))
+	return encodedExpiry != nil && bucketstore.IsExpired(encodedExpiry, now)
+}
+
+// Begin starts a new write transaction on the bucket
+// The transaction MUST be committed or rolled back, as it blocks other writes to the database.
+func (bb *BoltBucket) Begin() (bucketstore.IBucketTx, error) {
+	return NewBoltTx(bb)
+}
+
 // Close the bucket
 func (bb *BoltBucket) Close() (err error) {
 	//logrus.Infof("Closing bucket '%s' of client '%s", bb.bucketID, bb.clientID)
@@ -64,6 +100,11 @@ func (bb *BoltBucket) Close() (err error) {
 	return err
 }
 
+// CompareAndSet replaces the value of the key if its current value equals oldValue
+func (bb *BoltBucket) CompareAndSet(key string, oldValue []byte, newValue []byte) (bool, error) {
+	return bucketstore.CompareAndSetWithTx(bb, key, oldValue, newValue)
+}
+
 // Cursor returns a new cursor for iterating the bucket.
 // This creates a read-only bbolt bucket for iteration.
 // The cursor MUST be closed after use to release the bbolt bucket.
@@ -95,7 +136,11 @@ func (bb *BoltBucket) Delete(key string) (err error) {
 
 	//
 	err = bb.bucketTransaction(true, func(bboltBucket *bbolt.Bucket) error {
-		return bboltBucket.Delete([]byte(key))
+		err2 := bboltBucket.Delete([]byte(key))
+		if err2 == nil {
+			err2 = bb.clearExpiry(bboltBucket.Tx(), key)
+		}
+		return err2
 	})
 
 	return err
@@ -107,27 +152,52 @@ func (bb *BoltBucket) Get(key string) (val []byte, err error) {
 	var byteValue []byte
 	err = bb.bucketTransaction(false, func(bboltBucket *bbolt.Bucket) error {
 		v := bboltBucket.Get([]byte(key))
-		if v != nil {
-			byteValue = bytes.NewBuffer(v).Bytes() //copy the buffer
+		if v != nil && !bb.isExpired(bboltBucket.Tx(), key, time.Now()) {
+			// v is only valid within the transaction
+			byteValue = make([]byte, len(v))
+			copy(byteValue, v)
 		}
 		return nil
 	})
 	return byteValue, err
 }
 
+// GetExpiry returns the expiry time of keys that are set with a TTL and have not expired
+func (bb *BoltBucket) GetExpiry(keys []string) (expiry map[string]time.Time, err error) {
+	expiry = make(map[string]time.Time)
+
+	err = bb.bucketTransaction(false, func(bboltBucket *bbolt.Bucket) error {
+		expBucket := bb.expiryBucket(bboltBucket.Tx())
+		if expBucket == nil {
+			return nil
+		}
+		nowMsec := time.Now().UnixMilli()
+		for _, key := range keys {
+			expiryMsec, valid := bucketstore.DecodeExpiry(expBucket.Get([]byte(key)))
+			if valid && expiryMsec > nowMsec && bboltBucket.Get([]byte(key)) != nil {
+				expiry[key] = time.UnixMilli(expiryMsec)
+			}
+		}
+		return nil
+	})
+	return expiry, err
+}
+
 // GetMultiple returns a batch of documents with existing keys
 func (bb *BoltBucket) GetMultiple(keys []string) (docs map[string][]byte, err error) {
 	docs = make(map[string][]byte)
 
 	err = bb.bucketTransaction(false, func(bboltBucket *bbolt.Bucket) error {
+		now := time.Now()
 		for _, key := range keys {
 			byteValue := bboltBucket.Get([]byte(key))
-			// simply ignore non existing keys and log as info
-			if byteValue == nil {
+			// simply ignore non existing or expired keys and log as info
+			if byteValue == nil || bb.isExpired(bboltBucket.Tx(), key, now) {
 				//logrus.Infof("key '%s' in bucket '%s' for client '%s' doesn't exist", key, bb.bucketID, bb.clientID)
 			} else {
 				// byteValue is only valid within the transaction
-				val := bytes.NewBuffer(byteValue).Bytes()
+				val := make([]byte, len(byteValue))
+				copy(val, byteValue)
 				docs[key] = val
 			}
 		}
@@ -151,24 +221,63 @@ func (bb *BoltBucket) Info() (info *bucketstore.BucketStoreInfo) {
 	info.NrRecords = -1
 	if err == nil {
 		bucket := tx.Bucket([]byte(bb.bucketID))
-		bucketStats := bucket.Stats()
-		info.NrRecords = int64(bucketStats.KeyN)
-		// not sure if this is correct
-		info.DataSize = int64(bucketStats.LeafInuse)
+		if bucket != nil {
+			bucketStats := bucket.Stats()
+			info.NrRecords = int64(bucketStats.KeyN)
+			// not sure if this is correct
+			info.DataSize = int64(bucketStats.LeafInuse)
+		}
 		_ = tx.Rollback()
 	}
 	return
 }
 
+// PrefixCursor returns a new cursor for iterating the keys that start with the given prefix
+// The cursor MUST be released after use to end its read transaction.
+func (bb *BoltBucket) PrefixCursor(prefix string) (cursor bucketstore.IBucketCursor) {
+	return bb.RangeCursor(prefix, bucketstore.PrefixRangeEnd(prefix))
+}
+
+// RangeCursor returns a new cursor for iterating the keys in the range [startKey, endKey)
+// The cursor MUST be released after use to end its read transaction.
+func (bb *BoltBucket) RangeCursor(startKey string, endKey string) (cursor bucketstore.IBucketCursor) {
+	return bucketstore.NewRangeCursor(bb.Cursor(), startKey, endKey)
+}
+
 // Set writes a document with the given key
 func (bb *BoltBucket) Set(key string, value []byte) (err error) {
 	err = bb.bucketTransaction(true, func(bboltBucket *bbolt.Bucket) error {
 		err = bboltBucket.Put([]byte(key), value)
+		if err == nil {
+			err = bb.clearExpiry(bboltBucket.Tx(), key)
+		}
 		return err
 	})
 	return err
 }
 
+// SetWithTTL writes a document with the given key that expires after the time-to-live.
+// The document and its expiry time are written in a single transaction.
+func (bb *BoltBucket) SetWithTTL(key string, value []byte, ttl time.Duration) (err error) {
+	if ttl <= 0 {
+		return fmt.Errorf("ttl of key '%s' must be positive", key)
+	}
+	expiry := bucketstore.EncodeExpiry(time.Now().Add(ttl))
+	err = bb.bucketTransaction(true, func(bboltBucket *bbolt.Bucket) error {
+		err2 := bboltBucket.Put([]byte(key), value)
+		if err2 != nil {
+			return err2
+		}
+		expBucket, err2 := bboltBucket.Tx().CreateBucketIfNotExists(
+			[]byte(bucketstore.ExpiryBucketPrefix + bb.bucketID))
+		if err2 == nil {
+			err2 = expBucket.Put([]byte(key), expiry)
+		}
+		return err2
+	})
+	return err
+}
+
 // SetMultiple writes a multiple documents in a single transaction
 // This returns an error as soon as an invalid key is encountered.
 // Cancel this bucket with Close(false) if this returns an error.
@@ -183,6 +292,10 @@ func (bb *BoltBucket) SetMultiple(docs map[string][]byte) (err error) {
 				//_ = bb.bucket.Tx().Rollback()
 				return err
 			}
+			err = bb.clearExpiry(bboltBucket.Tx(), key)
+			if err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -1,8 +1,11 @@
 package bolts
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -51,6 +54,11 @@ type BoltStore struct {
 	storePath string
 	// for preventing deadlocks when closing the store. panic instead
 	bucketRefCount int32
+	// interval of removing expired keys
+	reapInterval time.Duration
+	// stop the reaper and wait until it has ended
+	reaperStop  chan bool
+	reaperEnded chan bool
 }
 
 // Close the store and flush changes to disk
@@ -59,6 +67,11 @@ type BoltStore struct {
 func (store *BoltStore) Close() (err error) {
 	br := atomic.LoadInt32(&store.bucketRefCount)
 	logrus.Infof("closing store for client '%s'. Refcnt=%d", store.clientID, br)
+	if store.reaperStop != nil {
+		close(store.reaperStop)
+		<-store.reaperEnded
+		store.reaperStop = nil
+	}
 	//close with wait until all transactions are completed ...
 	// so it might hang forever if not all transactions are released.
 	//err = store.boltDB.Close()
@@ -75,6 +88,21 @@ func (store *BoltStore) Close() (err error) {
 	return err
 }
 
+// DeleteBucket removes the bucket and the expiry of its keys in a single transaction
+func (store *BoltStore) DeleteBucket(bucketID string) (err error) {
+	logrus.Infof("deleting bucket '%s' of client '%s'", bucketID, store.clientID)
+	err = store.boltDB.Update(func(tx *bbolt.Tx) error {
+		for _, name := range []string{bucketID, bucketstore.ExpiryBucketPrefix + bucketID} {
+			err2 := tx.DeleteBucket([]byte(name))
+			if err2 != nil && !errors.Is(err2, bbolt.ErrBucketNotFound) {
+				return err2
+			}
+		}
+		return nil
+	})
+	return err
+}
+
 // GetBucket returns a bucket to use for writing to storage.
 // This does not yet create the bucket in the database until an operation takes place on the bucket.
 func (store *BoltStore) GetBucket(bucketID string) (bucket bucketstore.IBucket) {
@@ -91,6 +119,43 @@ func (store *BoltStore) onBucketReleased(bucket bucketstore.IBucket) {
 	atomic.AddInt32(&store.bucketRefCount, -1)
 }
 
+// Info returns the store information
+// The data size is the size of the database file.
+func (store *BoltStore) Info() *bucketstore.BucketStoreInfo {
+	info := &bucketstore.BucketStoreInfo{
+		DataSize:  -1,
+		Engine:    bucketstore.BackendBBolt,
+		Id:        store.clientID,
+		NrRecords: -1,
+	}
+	_ = store.boltDB.View(func(tx *bbolt.Tx) error {
+		info.DataSize = tx.Size()
+		info.NrRecords = 0
+		return tx.ForEach(func(name []byte, bboltBucket *bbolt.Bucket) error {
+			if !strings.HasPrefix(string(name), bucketstore.ExpiryBucketPrefix) {
+				info.NrRecords += int64(bboltBucket.Stats().KeyN)
+			}
+			return nil
+		})
+	})
+	return info
+}
+
+// ListBuckets returns the IDs of the buckets in the store in ascending order
+// Buckets are created when first written to.
+func (store *BoltStore) ListBuckets() (bucketIDs []string, err error) {
+	bucketIDs = make([]string, 0)
+	err = store.boltDB.View(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(name []byte, _ *bbolt.Bucket) error {
+			if !strings.HasPrefix(string(name), bucketstore.ExpiryBucketPrefix) {
+				bucketIDs = append(bucketIDs, string(name))
+			}
+			return nil
+		})
+	})
+	return bucketIDs, err
+}
+
 // Open the store
 func (store *BoltStore) Open() (err error) {
 	logrus.Infof("Opening bboltDB store for client %s", store.clientID)
@@ -112,7 +177,112 @@ func (store *BoltStore) Open() (err error) {
 
 	if err != nil {
 		logrus.Errorf("Error opening bboltDB for client %s: %s", store.clientID, err)
+	} else {
+		store.reaperStop = make(chan bool)
+		store.reaperEnded = make(chan bool)
+		go store.reapLoop()
+	}
+	return err
+}
+
+// reap removes the expired keys from all buckets in a single transaction
+func (store *BoltStore) reap() (nrRemoved int, err error) {
+	now := time.Now()
+	err = store.boltDB.Update(func(tx *bbolt.Tx) error {
+		expired := make(map[string][][]byte)
+		err2 := tx.ForEach(func(name []byte, expBucket *bbolt.Bucket) error {
+			if !strings.HasPrefix(string(name), bucketstore.ExpiryBucketPrefix) {
+				return nil
+			}
+			bucketID := strings.TrimPrefix(string(name), bucketstore.ExpiryBucketPrefix)
+			return expBucket.ForEach(func(k, v []byte) error {
+				if bucketstore.IsExpired(v, now) {
+					// keys are only valid during the transaction
+					expired[bucketID] = append(expired[bucketID], append([]byte(nil), k...))
+				}
+				return nil
+			})
+		})
+		if err2 != nil {
+			return err2
+		}
+		for bucketID, keys := range expired {
+			bboltBucket := tx.Bucket([]byte(bucketID))
+			expBucket := tx.Bucket([]byte(bucketstore.ExpiryBucketPrefix + bucketID))
+			for _, key := range keys {
+				if bboltBucket != nil {
+					if err2 = bboltBucket.Delete(key); err2 != nil {
+						return err2
+					}
+				}
+				if err2 = expBucket.Delete(key); err2 != nil {
+					return err2
+				}
+				nrRemoved++
+			}
+		}
+		return nil
+	})
+	return nrRemoved, err
+}
+
+// reapLoop periodically removes expired keys until the store is closed
+func (store *BoltStore) reapLoop() {
+	defer close(store.reaperEnded)
+	ticker := time.NewTicker(store.reapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-store.reaperStop:
+			return
+		case <-ticker.C:
+			nrRemoved, err := store.reap()
+			if err != nil {
+				logrus.Errorf("failed removing expired keys of client '%s': %s", store.clientID, err)
+			} else if nrRemoved > 0 {
+				logrus.Infof("removed %d expired keys from store of client '%s'", nrRemoved, store.clientID)
+			}
+		}
+	}
+}
+
+// Restore replaces the database file with the snapshot in the given directory.
+// The store must be closed.
+func (store *BoltStore) Restore(directory string) error {
+	// the reaper runs while the store is open
+	if store.reaperStop != nil {
+		return fmt.Errorf("store '%s' must be closed before it can be restored", store.clientID)
+	}
+	snapshotPath := path.Join(directory, path.Base(store.storePath))
+	if _, err := os.Stat(snapshotPath); err != nil {
+		return fmt.Errorf("no snapshot of store '%s' in '%s': %w", store.clientID, directory, err)
+	}
+	logrus.Infof("restoring store '%s' from '%s'", store.clientID, snapshotPath)
+	err := os.MkdirAll(path.Dir(store.storePath), 0700)
+	if err == nil {
+		err = bucketstore.CopyFile(snapshotPath, store.storePath)
+	}
+	return err
+}
+
+// SetReapInterval sets the interval in which expired keys are removed.
+// This must be called before Open.
+func (store *BoltStore) SetReapInterval(interval time.Duration) {
+	store.reapInterval = interval
+}
+
+// Snapshot copies the database into the given directory using a read transaction.
+// The read transaction provides a consistent view while writes continue.
+func (store *BoltStore) Snapshot(directory string) error {
+	err := os.MkdirAll(directory, 0700)
+	if err != nil {
+		return err
 	}
+	snapshotPath := path.Join(directory, path.Base(store.storePath))
+	logrus.Infof("writing snapshot of store '%s' to '%s'", store.clientID, snapshotPath)
+	err = store.boltDB.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(snapshotPath, 0600)
+	})
 	return err
 }
 
@@ -121,8 +291,9 @@ func (store *BoltStore) Open() (err error) {
 //	storePath is the file holding the database
 func NewBoltStore(clientID, storePath string) *BoltStore {
 	srv := &BoltStore{
-		clientID:  clientID,
-		storePath: storePath,
+		clientID:     clientID,
+		storePath:    storePath,
+		reapInterval: bucketstore.DefaultReapInterval,
 	}
 	return srv
 }
@@ -0,0 +1,104 @@
+package bolts
+
+import (
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// BoltTx is a read-write transaction on a BoltBucket that uses a native bbolt transaction.
+// bbolt allows a single write transaction at a time so other writes to the database wait
+// until the transaction has ended.
+// This implements the IBucketTx interface
+type BoltTx struct {
+	bb          *BoltBucket
+	tx          *bbolt.Tx
+	bboltBucket *bbolt.Bucket
+	ended       bool
+}
+
+// Commit the changes and end the transaction
+func (btx *BoltTx) Commit() error {
+	if btx.ended {
+		return bucketstore.ErrTxEnded
+	}
+	btx.ended = true
+	return btx.tx.Commit()
+}
+
+// Delete removes the key and its expiry time in the transaction
+func (btx *BoltTx) Delete(key string) error {
+	if btx.ended {
+		return bucketstore.ErrTxEnded
+	}
+	err := btx.bboltBucket.Delete([]byte(key))
+	if err == nil {
+		err = btx.bb.clearExpiry(btx.tx, key)
+	}
+	return err
+}
+
+// Get returns the value of the key as changed by the transaction
+func (btx *BoltTx) Get(key string) (value []byte, err error) {
+	if btx.ended {
+		return nil, bucketstore.ErrTxEnded
+	}
+	v := btx.bboltBucket.Get([]byte(key))
+	if v != nil && !btx.bb.isExpired(btx.tx, key, time.Now()) {
+		// v is only valid within the transaction
+		value = make([]byte, len(v))
+		copy(value, v)
+	}
+	return value, nil
+}
+
+// Rollback discards the changes and ends the transaction
+func (btx *BoltTx) Rollback() error {
+	if btx.ended {
+		return nil
+	}
+	btx.ended = true
+	return btx.tx.Rollback()
+}
+
+// Set the value of the key and removes its expiry time in the transaction
+// This stores a copy of value.
+func (btx *BoltTx) Set(key string, value []byte) error {
+	if btx.ended {
+		return bucketstore.ErrTxEnded
+	} else if key == "" {
+		return fmt.Errorf("missing key")
+	}
+	// bbolt requires the value to remain valid for the life of the transaction
+	valueCopy := make([]byte, len(value))
+	copy(valueCopy, value)
+	err := btx.bboltBucket.Put([]byte(key), valueCopy)
+	if err == nil {
+		err = btx.bb.clearExpiry(btx.tx, key)
+	}
+	return err
+}
+
+// NewBoltTx starts a new write transaction on the bucket
+// This waits until other write transactions on the database have ended.
+func NewBoltTx(bb *BoltBucket) (*BoltTx, error) {
+	tx, err := bb.db.Begin(true)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction for bucket '%s' of client '%s': %w",
+			bb.bucketID, bb.clientID, err)
+	}
+	bboltBucket, err := tx.CreateBucketIfNotExists([]byte(bb.bucketID))
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	btx := &BoltTx{
+		bb:          bb,
+		tx:          tx,
+		bboltBucket: bboltBucket,
+	}
+	return btx, nil
+}
@@ -0,0 +1,118 @@
+package capnpclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/lib/caphelp"
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// BucketTxCapnpClient provides a capnp RPC client of a bucket transaction
+// The capability is released when the transaction is committed or rolled back.
+// This implements the IBucketTx interface
+type BucketTxCapnpClient struct {
+	capability hubapi.CapBucketTx // capnp transaction
+	ended      bool
+}
+
+// end the transaction and release the capability
+func (cl *BucketTxCapnpClient) end() {
+	cl.ended = true
+	cl.capability.Release()
+}
+
+// Commit the changes and end the transaction
+func (cl *BucketTxCapnpClient) Commit() error {
+	if cl.ended {
+		return bucketstore.ErrTxEnded
+	}
+	ctx := context.Background()
+	method, release := cl.capability.Commit(ctx, nil)
+	_, err := method.Struct()
+	release()
+	cl.end()
+	return err
+}
+
+// Delete removes the key in the transaction
+func (cl *BucketTxCapnpClient) Delete(key string) error {
+	if cl.ended {
+		return bucketstore.ErrTxEnded
+	}
+	ctx := context.Background()
+	method, release := cl.capability.Delete(ctx,
+		func(params hubapi.CapBucketTx_delete_Params) error {
+			return params.SetKey(key)
+		})
+	defer release()
+	_, err := method.Struct()
+	return err
+}
+
+// Get returns the value of the key as changed by the transaction
+// This returns nil if the key doesn't exist
+func (cl *BucketTxCapnpClient) Get(key string) (value []byte, err error) {
+	if cl.ended {
+		return nil, bucketstore.ErrTxEnded
+	}
+	ctx := context.Background()
+	method, release := cl.capability.Get(ctx,
+		func(params hubapi.CapBucketTx_get_Params) error {
+			return params.SetKey(key)
+		})
+	defer release()
+	resp, err := method.Struct()
+	if err == nil && resp.Found() {
+		value, _ = resp.Value()
+		// clone value as the capnp buffer is reused
+		value = caphelp.Clone(value)
+		if value == nil {
+			value = []byte{}
+		}
+	}
+	return value, err
+}
+
+// Rollback discards the changes and ends the transaction
+func (cl *BucketTxCapnpClient) Rollback() error {
+	if cl.ended {
+		return nil
+	}
+	ctx := context.Background()
+	method, release := cl.capability.Rollback(ctx, nil)
+	_, err := method.Struct()
+	release()
+	cl.end()
+	return err
+}
+
+// Set the value of the key in the transaction
+func (cl *BucketTxCapnpClient) Set(key string, value []byte) error {
+	if cl.ended {
+		return bucketstore.ErrTxEnded
+	} else if key == "" {
+		return fmt.Errorf("missing key")
+	}
+	ctx := context.Background()
+	method, release := cl.capability.Set(ctx,
+		func(params hubapi.CapBucketTx_set_Params) error {
+			err2 := params.SetKey(key)
+			if err2 == nil {
+				err2 = params.SetValue(value)
+			}
+			return err2
+		})
+	defer release()
+	_, err := method.Struct()
+	return err
+}
+
+// NewBucketTxCapnpClient returns the capability of a bucket transaction
+func NewBucketTxCapnpClient(capability hubapi.CapBucketTx) *BucketTxCapnpClient {
+	cl := &BucketTxCapnpClient{
+		capability: capability,
+	}
+	return cl
+}
@@ -52,7 +52,7 @@ func (srv BucketCursorCapnpServer) NextN(
 	steps := args.Steps()
 	docs, itemsRemaining := srv.cursor.NextN(uint(steps))
 	res, err := call.AllocResults()
-	if err != nil {
+	if err == nil {
 		docsCap := caphelp.MarshalKeyValueMap(docs)
 		_ = res.SetDocs(docsCap)
 		res.SetItemsRemaining(itemsRemaining)
@@ -0,0 +1,69 @@
+package capnpserver
+
+import (
+	"context"
+
+	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// BucketTxCapnpServer provides the capnp RPC server for a bucket transaction.
+// This implements the capnproto generated interface CapBucketTx_Server
+type BucketTxCapnpServer struct {
+	tx bucketstore.IBucketTx
+}
+
+func (capsrv *BucketTxCapnpServer) Commit(
+	_ context.Context, _ hubapi.CapBucketTx_commit) error {
+	return capsrv.tx.Commit()
+}
+
+func (capsrv *BucketTxCapnpServer) Delete(
+	_ context.Context, call hubapi.CapBucketTx_delete) error {
+	args := call.Args()
+	key, _ := args.Key()
+	return capsrv.tx.Delete(key)
+}
+
+func (capsrv *BucketTxCapnpServer) Get(
+	_ context.Context, call hubapi.CapBucketTx_get) error {
+	args := call.Args()
+	key, _ := args.Key()
+	value, err := capsrv.tx.Get(key)
+	if err == nil {
+		res, err2 := call.AllocResults()
+		err = err2
+		if err == nil {
+			_ = res.SetValue(value)
+			res.SetFound(value != nil)
+		}
+	}
+	return err
+}
+
+func (capsrv *BucketTxCapnpServer) Rollback(
+	_ context.Context, _ hubapi.CapBucketTx_rollback) error {
+	return capsrv.tx.Rollback()
+}
+
+func (capsrv *BucketTxCapnpServer) Set(
+	_ context.Context, call hubapi.CapBucketTx_set) error {
+	args := call.Args()
+	key, _ := args.Key()
+	value, _ := args.Value()
+	// value points to the capnp buffer. Transactions keep a copy until commit.
+	return capsrv.tx.Set(key, value)
+}
+
+func (capsrv *BucketTxCapnpServer) Shutdown() {
+	// Release on the client calls capnp Shutdown.
+	// A transaction that hasn't ended is rolled back to release the bucket lock.
+	_ = capsrv.tx.Rollback()
+}
+
+func NewBucketTxCapnpServer(tx bucketstore.IBucketTx) *BucketTxCapnpServer {
+	txCapnpServer := &BucketTxCapnpServer{
+		tx: tx,
+	}
+	return txCapnpServer
+}
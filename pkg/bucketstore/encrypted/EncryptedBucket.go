@@ -0,0 +1,217 @@
+package encrypted
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// EncryptedBucket encrypts the values, and optionally keys, of the bucket it wraps
+// This implements the IBucket interface
+type EncryptedBucket struct {
+	bucket      bucketstore.IBucket
+	cipher      *StoreCipher
+	encryptKeys bool
+}
+
+// Begin starts a transaction that encrypts the values set in the transaction
+func (eb *EncryptedBucket) Begin() (bucketstore.IBucketTx, error) {
+	tx, err := eb.bucket.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedTx{tx: tx, bucket: eb}, nil
+}
+
+// Close the bucket
+func (eb *EncryptedBucket) Close() error {
+	return eb.bucket.Close()
+}
+
+// CompareAndSet replaces the value of a key if its current value equals oldValue.
+// Encrypted values use a random nonce so the plaintext is compared in a transaction.
+func (eb *EncryptedBucket) CompareAndSet(key string, oldValue []byte, newValue []byte) (bool, error) {
+	return bucketstore.CompareAndSetWithTx(eb, key, oldValue, newValue)
+}
+
+// Cursor returns a cursor that decrypts the keys and values of the bucket
+func (eb *EncryptedBucket) Cursor() bucketstore.IBucketCursor {
+	cursor := eb.bucket.Cursor()
+	if cursor == nil {
+		return nil
+	}
+	return NewEncryptedCursor(eb, cursor, "", "")
+}
+
+// decryptKey returns the plaintext key of a key stored in the wrapped bucket
+func (eb *EncryptedBucket) decryptKey(storeKey string) (string, error) {
+	if !eb.encryptKeys {
+		return storeKey, nil
+	}
+	return eb.cipher.DecryptKey(eb.bucket.ID(), storeKey)
+}
+
+// Delete removes the key
+func (eb *EncryptedBucket) Delete(key string) error {
+	return eb.bucket.Delete(eb.storeKey(key))
+}
+
+// encryptDocs returns the documents with encrypted keys and values for storing
+func (eb *EncryptedBucket) encryptDocs(docs map[string][]byte) (map[string][]byte, error) {
+	encDocs := make(map[string][]byte, len(docs))
+	for key, value := range docs {
+		if key == "" {
+			return nil, fmt.Errorf("empty key in bucket '%s'", eb.bucket.ID())
+		}
+		encValue, err := eb.cipher.EncryptValue(eb.bucket.ID(), key, value)
+		if err != nil {
+			return nil, err
+		}
+		encDocs[eb.storeKey(key)] = encValue
+	}
+	return encDocs, nil
+}
+
+// Get returns the decrypted value of the key
+// This returns an error if the value can't be decrypted.
+func (eb *EncryptedBucket) Get(key string) ([]byte, error) {
+	encValue, err := eb.bucket.Get(eb.storeKey(key))
+	if err != nil || encValue == nil {
+		return nil, err
+	}
+	return eb.cipher.DecryptValue(eb.bucket.ID(), key, encValue)
+}
+
+// GetExpiry returns the expiry time of keys that are set with a TTL and have not expired
+func (eb *EncryptedBucket) GetExpiry(keys []string) (map[string]time.Time, error) {
+	// map of stored key to plaintext key
+	storeKeys := make(map[string]string, len(keys))
+	keyList := make([]string, 0, len(keys))
+	for _, key := range keys {
+		storeKey := eb.storeKey(key)
+		storeKeys[storeKey] = key
+		keyList = append(keyList, storeKey)
+	}
+	storeExpiry, err := eb.bucket.GetExpiry(keyList)
+	if err != nil {
+		return nil, err
+	}
+	expiry := make(map[string]time.Time, len(storeExpiry))
+	for storeKey, expiryTime := range storeExpiry {
+		expiry[storeKeys[storeKey]] = expiryTime
+	}
+	return expiry, nil
+}
+
+// GetMultiple returns the decrypted values of the keys that exist
+func (eb *EncryptedBucket) GetMultiple(keys []string) (map[string][]byte, error) {
+	// map of stored key to plaintext key
+	storeKeys := make(map[string]string, len(keys))
+	keyList := make([]string, 0, len(keys))
+	for _, key := range keys {
+		storeKey := eb.storeKey(key)
+		storeKeys[storeKey] = key
+		keyList = append(keyList, storeKey)
+	}
+	encDocs, err := eb.bucket.GetMultiple(keyList)
+	if err != nil {
+		return nil, err
+	}
+	docs := make(map[string][]byte, len(encDocs))
+	for storeKey, encValue := range encDocs {
+		key := storeKeys[storeKey]
+		docs[key], err = eb.cipher.DecryptValue(eb.bucket.ID(), key, encValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+// ID returns the bucket ID
+func (eb *EncryptedBucket) ID() string {
+	return eb.bucket.ID()
+}
+
+// Info returns the information of the wrapped bucket
+func (eb *EncryptedBucket) Info() *bucketstore.BucketStoreInfo {
+	return eb.bucket.Info()
+}
+
+// PrefixCursor returns a cursor for the keys that start with the prefix
+// With encrypted keys this scans the whole bucket.
+func (eb *EncryptedBucket) PrefixCursor(prefix string) bucketstore.IBucketCursor {
+	return eb.RangeCursor(prefix, bucketstore.PrefixRangeEnd(prefix))
+}
+
+// RangeCursor returns a cursor for the keys in the range [startKey, endKey)
+// With encrypted keys this scans the whole bucket.
+func (eb *EncryptedBucket) RangeCursor(startKey string, endKey string) bucketstore.IBucketCursor {
+	var cursor bucketstore.IBucketCursor
+	if eb.encryptKeys {
+		// the order of encrypted keys differs from the plaintext keys
+		cursor = eb.bucket.Cursor()
+	} else {
+		cursor = eb.bucket.RangeCursor(startKey, endKey)
+	}
+	if cursor == nil {
+		return nil
+	}
+	return NewEncryptedCursor(eb, cursor, startKey, endKey)
+}
+
+// Set encrypts and sets the value of the key
+func (eb *EncryptedBucket) Set(key string, value []byte) error {
+	if key == "" {
+		return fmt.Errorf("empty key for bucket '%s'", eb.bucket.ID())
+	}
+	encValue, err := eb.cipher.EncryptValue(eb.bucket.ID(), key, value)
+	if err != nil {
+		return err
+	}
+	return eb.bucket.Set(eb.storeKey(key), encValue)
+}
+
+// SetWithTTL encrypts and sets the value of the key that expires after the time-to-live
+func (eb *EncryptedBucket) SetWithTTL(key string, value []byte, ttl time.Duration) error {
+	if key == "" {
+		return fmt.Errorf("empty key for bucket '%s'", eb.bucket.ID())
+	}
+	encValue, err := eb.cipher.EncryptValue(eb.bucket.ID(), key, value)
+	if err != nil {
+		return err
+	}
+	return eb.bucket.SetWithTTL(eb.storeKey(key), encValue, ttl)
+}
+
+// SetMultiple encrypts and sets multiple documents in a batch update
+func (eb *EncryptedBucket) SetMultiple(docs map[string][]byte) error {
+	encDocs, err := eb.encryptDocs(docs)
+	if err != nil {
+		return err
+	}
+	return eb.bucket.SetMultiple(encDocs)
+}
+
+// storeKey returns the key as stored in the wrapped bucket
+func (eb *EncryptedBucket) storeKey(key string) string {
+	if !eb.encryptKeys || key == "" {
+		return key
+	}
+	return eb.cipher.EncryptKey(eb.bucket.ID(), key)
+}
+
+// NewEncryptedBucket returns a bucket that encrypts the values of the given bucket
+//
+//	bucket is the bucket to wrap
+//	cipher encrypts the keys and values
+//	encryptKeys also encrypts the keys
+func NewEncryptedBucket(bucket bucketstore.IBucket, cipher *StoreCipher, encryptKeys bool) *EncryptedBucket {
+	eb := &EncryptedBucket{
+		bucket:      bucket,
+		cipher:      cipher,
+		encryptKeys: encryptKeys,
+	}
+	return eb
+}
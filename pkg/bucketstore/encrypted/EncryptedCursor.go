@@ -0,0 +1,143 @@
+package encrypted
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// EncryptedCursor decrypts the keys and values of the cursor it wraps.
+// Records that can't be decrypted are logged and skipped.
+//
+// With encrypted keys the cursor iterates in the order of the encrypted keys. A range is then
+// applied by skipping the keys outside the range, and Seek positions at the search key if
+// it exists, or at the next encrypted key if it doesn't.
+// This implements the IBucketCursor interface
+type EncryptedCursor struct {
+	bucket *EncryptedBucket
+	cursor bucketstore.IBucketCursor
+	// range to filter when keys are encrypted
+	startKey string
+	endKey   string
+}
+
+// decrypt returns the plaintext key and value of a record of the wrapped cursor
+// This returns ok false if the record isn't valid or can't be decrypted.
+func (ec *EncryptedCursor) decrypt(storeKey string, encValue []byte, valid bool) (
+	key string, value []byte, ok bool) {
+
+	if !valid {
+		return "", nil, false
+	}
+	key, err := ec.bucket.decryptKey(storeKey)
+	if err == nil {
+		value, err = ec.bucket.cipher.DecryptValue(ec.bucket.ID(), key, encValue)
+	}
+	if err != nil {
+		logrus.Errorf("skipping record: %s", err)
+		return "", nil, false
+	}
+	return key, value, true
+}
+
+// inRange returns true if the key lies within the range of the cursor
+// Without encrypted keys the wrapped cursor applies the range itself.
+func (ec *EncryptedCursor) inRange(key string) bool {
+	if !ec.bucket.encryptKeys {
+		return true
+	}
+	return key >= ec.startKey && (ec.endKey == "" || key < ec.endKey)
+}
+
+// move returns the first decrypted record in range, starting with the given record and
+// moving the cursor with step until one is found or the cursor is no longer valid.
+func (ec *EncryptedCursor) move(
+	step func() (string, []byte, bool), storeKey string, encValue []byte, valid bool) (
+	key string, value []byte, ok bool) {
+
+	for ; valid; storeKey, encValue, valid = step() {
+		key, value, ok = ec.decrypt(storeKey, encValue, valid)
+		if ok && ec.inRange(key) {
+			return key, value, true
+		}
+	}
+	return "", nil, false
+}
+
+// First moves the cursor to the first record
+func (ec *EncryptedCursor) First() (key string, value []byte, valid bool) {
+	k, v, valid := ec.cursor.First()
+	return ec.move(ec.cursor.Next, k, v, valid)
+}
+
+// Last moves the cursor to the last record
+func (ec *EncryptedCursor) Last() (key string, value []byte, valid bool) {
+	k, v, valid := ec.cursor.Last()
+	return ec.move(ec.cursor.Prev, k, v, valid)
+}
+
+// Next moves the cursor to the next record
+func (ec *EncryptedCursor) Next() (key string, value []byte, valid bool) {
+	k, v, valid := ec.cursor.Next()
+	return ec.move(ec.cursor.Next, k, v, valid)
+}
+
+// NextN moves the cursor to the next N records and returns the decrypted key-value pairs
+func (ec *EncryptedCursor) NextN(steps uint) (docs map[string][]byte, itemsRemaining bool) {
+	docs = make(map[string][]byte)
+	for i := uint(0); i < steps; i++ {
+		key, value, valid := ec.Next()
+		if !valid {
+			return docs, false
+		}
+		docs[key] = value
+	}
+	return docs, true
+}
+
+// Prev moves the cursor to the previous record
+func (ec *EncryptedCursor) Prev() (key string, value []byte, valid bool) {
+	k, v, valid := ec.cursor.Prev()
+	return ec.move(ec.cursor.Prev, k, v, valid)
+}
+
+// PrevN moves the cursor back N records and returns the decrypted key-value pairs
+func (ec *EncryptedCursor) PrevN(steps uint) (docs map[string][]byte, itemsRemaining bool) {
+	docs = make(map[string][]byte)
+	for i := uint(0); i < steps; i++ {
+		key, value, valid := ec.Prev()
+		if !valid {
+			return docs, false
+		}
+		docs[key] = value
+	}
+	return docs, true
+}
+
+// Release the wrapped cursor
+func (ec *EncryptedCursor) Release() {
+	ec.cursor.Release()
+}
+
+// Seek positions the cursor at the search key
+func (ec *EncryptedCursor) Seek(searchKey string) (key string, value []byte, valid bool) {
+	k, v, valid := ec.cursor.Seek(ec.bucket.storeKey(searchKey))
+	return ec.move(ec.cursor.Next, k, v, valid)
+}
+
+// NewEncryptedCursor returns a cursor that decrypts the records of the given cursor
+//
+//	bucket is the encrypted bucket the cursor belongs to
+//	cursor is the cursor of the wrapped bucket
+//	startKey and endKey limit the range of keys when keys are encrypted. "" for an open range.
+func NewEncryptedCursor(bucket *EncryptedBucket, cursor bucketstore.IBucketCursor,
+	startKey string, endKey string) *EncryptedCursor {
+
+	ec := &EncryptedCursor{
+		bucket:   bucket,
+		cursor:   cursor,
+		startKey: startKey,
+		endKey:   endKey,
+	}
+	return ec
+}
@@ -0,0 +1,128 @@
+// Package encrypted provides encryption at rest for bucket stores.
+// The EncryptedStore wraps any bucket store backend and encrypts values, and optionally keys,
+// with AES-256-GCM before they are written to the backend.
+package encrypted
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// EncryptedStore is a bucket store that encrypts the values, and optionally keys, of the
+// bucket store it wraps. Bucket IDs are not encrypted.
+//
+// Encrypted keys are not ordered. Cursors iterate in the order of the encrypted keys, prefix and
+// range cursors filter a scan of the whole bucket, and Seek only positions at an existing key,
+// after which Next continues in the order of the encrypted keys. Stores whose readers rely on
+// iterating in key order, such as the history store, can't use encrypted keys.
+//
+// Snapshot and Restore pass through to the wrapped store, so backups remain encrypted.
+// This implements the IBucketStore interface
+type EncryptedStore struct {
+	store       bucketstore.IBucketStore
+	cipher      *StoreCipher
+	encryptKeys bool
+	// the cipher is loaded from the secret file on open if not provided
+	secretFile string
+	storeID    string
+}
+
+// Close the store
+func (es *EncryptedStore) Close() error {
+	return es.store.Close()
+}
+
+// DeleteBucket removes the bucket from the wrapped store
+func (es *EncryptedStore) DeleteBucket(bucketID string) error {
+	return es.store.DeleteBucket(bucketID)
+}
+
+// GetBucket returns a bucket that encrypts the values of the bucket of the wrapped store
+// This returns nil if the store isn't open.
+func (es *EncryptedStore) GetBucket(bucketID string) bucketstore.IBucket {
+	if es.cipher == nil {
+		logrus.Errorf("encrypted store '%s' isn't open", es.storeID)
+		return nil
+	}
+	bucket := es.store.GetBucket(bucketID)
+	if bucket == nil {
+		return nil
+	}
+	return NewEncryptedBucket(bucket, es.cipher, es.encryptKeys)
+}
+
+// Info returns the information of the wrapped store
+func (es *EncryptedStore) Info() *bucketstore.BucketStoreInfo {
+	return es.store.Info()
+}
+
+// KeysEncrypted returns true if the keys of the store are encrypted
+func (es *EncryptedStore) KeysEncrypted() bool {
+	return es.encryptKeys
+}
+
+// ListBuckets returns the IDs of the buckets in the wrapped store
+func (es *EncryptedStore) ListBuckets() ([]string, error) {
+	return es.store.ListBuckets()
+}
+
+// Open loads the cipher from the secret file, if needed, and opens the wrapped store
+// This fails if the secret can't be read.
+func (es *EncryptedStore) Open() (err error) {
+	if es.cipher == nil {
+		es.cipher, err = LoadStoreCipher(es.secretFile, es.storeID)
+		if err != nil {
+			err = fmt.Errorf("unable to open encrypted store '%s': %w", es.storeID, err)
+			logrus.Error(err)
+			return err
+		}
+	}
+	return es.store.Open()
+}
+
+// Restore the wrapped store from a snapshot made with Snapshot
+// The snapshot must be encrypted with the same secret.
+func (es *EncryptedStore) Restore(directory string) error {
+	return es.store.Restore(directory)
+}
+
+// Snapshot writes an encrypted snapshot of the wrapped store
+func (es *EncryptedStore) Snapshot(directory string) error {
+	return es.store.Snapshot(directory)
+}
+
+// NewEncryptedStore returns a store that encrypts the values of the given store
+//
+//	store is the store to wrap. The wrapper opens and closes it.
+//	cipher encrypts the keys and values
+//	encryptKeys also encrypts the keys
+func NewEncryptedStore(store bucketstore.IBucketStore, cipher *StoreCipher, encryptKeys bool) *EncryptedStore {
+	es := &EncryptedStore{
+		store:       store,
+		cipher:      cipher,
+		encryptKeys: encryptKeys,
+	}
+	return es
+}
+
+// NewStoreFromConfig returns the store wrapped in an EncryptedStore if encryption is enabled
+// in the configuration, or the store itself if it isn't.
+// The cipher is loaded from the configured secret file when the store is opened.
+//
+//	store is the store to wrap
+//	cfg is the encryption configuration of the service. Its secret file must be resolved.
+//	storeID identifies the store to derive the encryption keys for, eg the service name
+func NewStoreFromConfig(
+	store bucketstore.IBucketStore, cfg EncryptionConfig, storeID string) bucketstore.IBucketStore {
+
+	if !cfg.Enabled {
+		return store
+	}
+	es := NewEncryptedStore(store, nil, cfg.EncryptKeys)
+	es.secretFile = cfg.SecretFile
+	es.storeID = storeID
+	return es
+}
@@ -0,0 +1,50 @@
+package encrypted
+
+import (
+	"fmt"
+
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// EncryptedTx encrypts the values set in the transaction it wraps
+// This implements the IBucketTx interface
+type EncryptedTx struct {
+	tx     bucketstore.IBucketTx
+	bucket *EncryptedBucket
+}
+
+// Commit the wrapped transaction
+func (etx *EncryptedTx) Commit() error {
+	return etx.tx.Commit()
+}
+
+// Delete removes the key in the transaction
+func (etx *EncryptedTx) Delete(key string) error {
+	return etx.tx.Delete(etx.bucket.storeKey(key))
+}
+
+// Get returns the decrypted value of the key in the transaction
+func (etx *EncryptedTx) Get(key string) ([]byte, error) {
+	encValue, err := etx.tx.Get(etx.bucket.storeKey(key))
+	if err != nil || encValue == nil {
+		return nil, err
+	}
+	return etx.bucket.cipher.DecryptValue(etx.bucket.ID(), key, encValue)
+}
+
+// Rollback the wrapped transaction
+func (etx *EncryptedTx) Rollback() error {
+	return etx.tx.Rollback()
+}
+
+// Set encrypts and sets the value of the key in the transaction
+func (etx *EncryptedTx) Set(key string, value []byte) error {
+	if key == "" {
+		return fmt.Errorf("empty key for bucket '%s'", etx.bucket.ID())
+	}
+	encValue, err := etx.bucket.cipher.EncryptValue(etx.bucket.ID(), key, value)
+	if err != nil {
+		return err
+	}
+	return etx.tx.Set(etx.bucket.storeKey(key), encValue)
+}
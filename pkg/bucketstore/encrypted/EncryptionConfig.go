@@ -0,0 +1,33 @@
+package encrypted
+
+import (
+	"path"
+
+	"github.com/hiveot/hub/api/go/hubapi"
+)
+
+// EncryptionConfig holds the configuration of the encryption at rest of a service store
+type EncryptionConfig struct {
+	// Encrypt the values of the store. Default is false.
+	// Existing plaintext stores must be encrypted with 'hubcli rotatekey' before enabling this.
+	Enabled bool `yaml:"enabled"`
+
+	// Also encrypt the keys of the store. Default is false.
+	// Cursors of encrypted keys don't iterate in key order, so this can't be used by stores whose
+	// readers rely on key order, such as the history store.
+	EncryptKeys bool `yaml:"encryptKeys"`
+
+	// File with the secret to derive the encryption keys from.
+	// Default is the hub CA private key. A relative path is relative to the certs folder.
+	SecretFile string `yaml:"secretFile"`
+}
+
+// ResolveSecretFile sets the secret file to the CA key in the certs folder if not set,
+// and makes a relative secret file path relative to the certs folder.
+func (cfg *EncryptionConfig) ResolveSecretFile(certsFolder string) {
+	if cfg.SecretFile == "" {
+		cfg.SecretFile = path.Join(certsFolder, hubapi.DefaultCaKeyFile)
+	} else if !path.IsAbs(cfg.SecretFile) {
+		cfg.SecretFile = path.Join(certsFolder, cfg.SecretFile)
+	}
+}
@@ -0,0 +1,221 @@
+package encrypted
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// decryptRecord returns the plaintext key and value of a stored record.
+// Records that are already encrypted with the new cipher are accepted, so an interrupted
+// rotation can be resumed. Keys that can't be decrypted are considered plaintext.
+// Without old cipher, records that can't be decrypted with the new cipher are plaintext.
+func decryptRecord(bucketID string, storeKey string, storeValue []byte,
+	oldCipher *StoreCipher, newCipher *StoreCipher) (key string, value []byte, err error) {
+
+	for _, sc := range []*StoreCipher{newCipher, oldCipher} {
+		if sc == nil {
+			continue
+		}
+		key = storeKey
+		if plainKey, err2 := sc.DecryptKey(bucketID, storeKey); err2 == nil {
+			key = plainKey
+		}
+		value, err = sc.DecryptValue(bucketID, key, storeValue)
+		if err == nil {
+			return key, value, nil
+		}
+	}
+	if oldCipher == nil {
+		return storeKey, storeValue, nil
+	}
+	return "", nil, fmt.Errorf("unable to decrypt with the old or new secret: %w", err)
+}
+
+// rotateBucket re-encrypts the records of a bucket.
+// Records with a time-to-live keep their expiry time. Expired records are removed.
+func rotateBucket(bucket bucketstore.IBucket, oldCipher *StoreCipher, newCipher *StoreCipher,
+	encryptKeys bool) (nrRecords int64, err error) {
+
+	bucketID := bucket.ID()
+	// collect the keys first as the records are rewritten, and possibly renamed
+	cursor := bucket.Cursor()
+	if cursor == nil {
+		return 0, fmt.Errorf("unable to iterate bucket '%s'", bucketID)
+	}
+	storeKeys := make([]string, 0)
+	for k, _, valid := cursor.First(); valid; k, _, valid = cursor.Next() {
+		storeKeys = append(storeKeys, k)
+	}
+	cursor.Release()
+
+	for start := 0; start < len(storeKeys); start += bucketstore.MigrateBatchSize {
+		end := start + bucketstore.MigrateBatchSize
+		if end > len(storeKeys) {
+			end = len(storeKeys)
+		}
+		storeDocs, err := bucket.GetMultiple(storeKeys[start:end])
+		if err != nil {
+			return nrRecords, err
+		}
+		expiry, err := bucket.GetExpiry(storeKeys[start:end])
+		if err != nil {
+			return nrRecords, err
+		}
+		newDocs := make(map[string][]byte, len(storeDocs))
+		ttlDocs := make(map[string][]byte)
+		ttls := make(map[string]time.Duration)
+		oldKeys := make([]string, 0)
+		for storeKey, storeValue := range storeDocs {
+			var ttl time.Duration
+			expiryTime, hasExpiry := expiry[storeKey]
+			if hasExpiry {
+				ttl = time.Until(expiryTime)
+				if ttl <= 0 {
+					// expired since it was read
+					oldKeys = append(oldKeys, storeKey)
+					continue
+				}
+			}
+			key, value, err := decryptRecord(bucketID, storeKey, storeValue, oldCipher, newCipher)
+			if err != nil {
+				return nrRecords, fmt.Errorf("key '%s' in bucket '%s': %w", storeKey, bucketID, err)
+			}
+			newKey := key
+			newValue := value
+			if newCipher != nil {
+				if encryptKeys {
+					newKey = newCipher.EncryptKey(bucketID, key)
+				}
+				newValue, err = newCipher.EncryptValue(bucketID, key, value)
+				if err != nil {
+					return nrRecords, err
+				}
+			}
+			if hasExpiry {
+				ttlDocs[newKey] = newValue
+				ttls[newKey] = ttl
+			} else {
+				newDocs[newKey] = newValue
+			}
+			if newKey != storeKey {
+				oldKeys = append(oldKeys, storeKey)
+			}
+		}
+		// write the new records before removing the old ones so a crash doesn't lose records
+		err = bucket.SetMultiple(newDocs)
+		for newKey, newValue := range ttlDocs {
+			if err == nil {
+				err = bucket.SetWithTTL(newKey, newValue, ttls[newKey])
+			}
+		}
+		for i := 0; err == nil && i < len(oldKeys); i++ {
+			err = bucket.Delete(oldKeys[i])
+		}
+		if err != nil {
+			return nrRecords, err
+		}
+		nrRecords += int64(len(newDocs) + len(ttlDocs))
+	}
+	return nrRecords, nil
+}
+
+// RotateFileKey re-encrypts a file that was encrypted with StoreCipher.Encrypt
+// The file is replaced atomically. A file that is already encrypted with the new cipher is
+// re-encrypted, so an interrupted rotation can be repeated.
+//
+//	filePath is the file to re-encrypt. A missing file is not an error.
+//	oldCipher is the cipher the file is encrypted with, or nil if the file is plaintext
+//	newCipher is the cipher to encrypt the file with, or nil to decrypt the file
+//	additionalData is the additional data used to encrypt the file
+func RotateFileKey(filePath string, oldCipher *StoreCipher, newCipher *StoreCipher,
+	additionalData []byte) error {
+
+	data, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	} else if len(data) == 0 {
+		return nil
+	}
+	var plaintext []byte
+	err = fmt.Errorf("unable to decrypt '%s' with the old or new secret", filePath)
+	for _, sc := range []*StoreCipher{newCipher, oldCipher} {
+		if sc != nil && err != nil {
+			plaintext, err = sc.Decrypt(data, additionalData)
+		}
+	}
+	if err != nil && oldCipher == nil {
+		plaintext, err = data, nil
+	}
+	if err != nil {
+		return err
+	}
+	if newCipher != nil {
+		data, err = newCipher.Encrypt(plaintext, additionalData)
+	} else {
+		data = plaintext
+	}
+	if err != nil {
+		return err
+	}
+	// the temp file and its directory entry are synced before the rename, and the rename
+	// is synced after, so a crash leaves either the old or the new file intact
+	tmpPath := filePath + ".tmp"
+	err = bucketstore.WriteFileSync(tmpPath, data)
+	if err == nil {
+		err = bucketstore.SyncDir(path.Dir(filePath))
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, filePath)
+	}
+	if err == nil {
+		err = bucketstore.SyncDir(path.Dir(filePath))
+	}
+	return err
+}
+
+// RotateStoreKey re-encrypts the content of a store with a new secret.
+// This can also encrypt a plaintext store, or decrypt an encrypted store.
+//
+// The store must not be in use and must not be wrapped in an EncryptedStore. Records are
+// re-encrypted in place in batches. If the rotation is interrupted it can be resumed by
+// repeating it, as records that are already encrypted with the new cipher are accepted.
+// Decrypting a store can't be resumed, so make a backup first.
+// Records with a time-to-live keep their expiry time. Expired records are removed.
+//
+//	store is the open store to rotate
+//	oldCipher is the cipher the store is encrypted with, or nil if the store is plaintext
+//	newCipher is the cipher to encrypt the store with, or nil to decrypt the store
+//	encryptKeys encrypts the keys with the new cipher. Encrypted keys are decrypted when false.
+//
+// This returns the number of re-encrypted records.
+func RotateStoreKey(store bucketstore.IBucketStore, oldCipher *StoreCipher, newCipher *StoreCipher,
+	encryptKeys bool) (nrRecords int64, err error) {
+
+	if oldCipher == nil && newCipher == nil {
+		return 0, fmt.Errorf("no old or new cipher to rotate the store with")
+	}
+	bucketIDs, err := store.ListBuckets()
+	if err != nil {
+		return 0, err
+	}
+	for _, bucketID := range bucketIDs {
+		bucket := store.GetBucket(bucketID)
+		n, err := rotateBucket(bucket, oldCipher, newCipher, encryptKeys)
+		_ = bucket.Close()
+		nrRecords += n
+		if err != nil {
+			logrus.Errorf("rotating the key of bucket '%s' failed after %d records: %s", bucketID, nrRecords, err)
+			return nrRecords, err
+		}
+		logrus.Infof("rotated the key of bucket '%s'. %d records total", bucketID, nrRecords)
+	}
+	return nrRecords, nil
+}
@@ -0,0 +1,188 @@
+package encrypted
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// cipherVersion identifies the format of encrypted values: [version][nonce][ciphertext]
+const cipherVersion = 1
+
+// keyDerivationInfo is the HKDF context prefix of the keys derived from the secret
+const keyDerivationInfo = "hiveot-bucketstore/"
+
+// SecretSize is the size in bytes of a generated hub secret
+const SecretSize = 32
+
+// StoreCipher encrypts and decrypts the keys and values of a store using AES-256-GCM.
+// The encryption keys are derived from a secret, such as the hub CA private key or a
+// generated hub secret, and the ID of the store, so each service uses its own keys.
+//
+// Values are encrypted with a random nonce. The bucket and key of a value are used as
+// additional data, so a value can't be moved to another key without being detected.
+// Keys are encrypted with a nonce that is derived from the bucket and key, so the same key
+// always encrypts to the same string and can still be used to look up the value.
+type StoreCipher struct {
+	valueAEAD cipher.AEAD
+	keyAEAD   cipher.AEAD
+	// key of the HMAC that derives the nonce of encrypted keys
+	nonceKey []byte
+}
+
+// Decrypt returns the plaintext of data that was encrypted with Encrypt using the same
+// additional data. This fails if the data was encrypted with a different key or was modified.
+func (sc *StoreCipher) Decrypt(data []byte, additionalData []byte) ([]byte, error) {
+	nonceSize := sc.valueAEAD.NonceSize()
+	if len(data) < 1+nonceSize+sc.valueAEAD.Overhead() || data[0] != cipherVersion {
+		return nil, fmt.Errorf("data is not encrypted or has an unknown format")
+	}
+	nonce := data[1 : 1+nonceSize]
+	plaintext, err := sc.valueAEAD.Open(nil, nonce, data[1+nonceSize:], additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt data: %w", err)
+	}
+	if plaintext == nil {
+		plaintext = []byte{}
+	}
+	return plaintext, nil
+}
+
+// DecryptKey returns the plaintext key of a key encrypted with EncryptKey
+func (sc *StoreCipher) DecryptKey(bucketID string, encKey string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encKey)
+	nonceSize := sc.keyAEAD.NonceSize()
+	if err != nil || len(data) < nonceSize+sc.keyAEAD.Overhead() {
+		return "", fmt.Errorf("key '%s' in bucket '%s' is not encrypted", encKey, bucketID)
+	}
+	plaintext, err := sc.keyAEAD.Open(nil, data[:nonceSize], data[nonceSize:], []byte(bucketID))
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt key '%s' in bucket '%s': %w", encKey, bucketID, err)
+	}
+	return string(plaintext), nil
+}
+
+// DecryptValue returns the plaintext of a value encrypted with EncryptValue
+func (sc *StoreCipher) DecryptValue(bucketID string, key string, data []byte) ([]byte, error) {
+	plaintext, err := sc.Decrypt(data, valueAD(bucketID, key))
+	if err != nil {
+		return nil, fmt.Errorf("value of key '%s' in bucket '%s': %w", key, bucketID, err)
+	}
+	return plaintext, nil
+}
+
+// Encrypt the plaintext using a random nonce.
+// The additional data is authenticated but not encrypted. It must be provided to decrypt.
+func (sc *StoreCipher) Encrypt(plaintext []byte, additionalData []byte) ([]byte, error) {
+	nonceSize := sc.valueAEAD.NonceSize()
+	data := make([]byte, 1+nonceSize, 1+nonceSize+len(plaintext)+sc.valueAEAD.Overhead())
+	data[0] = cipherVersion
+	if _, err := io.ReadFull(rand.Reader, data[1:]); err != nil {
+		return nil, err
+	}
+	return sc.valueAEAD.Seal(data, data[1:], plaintext, additionalData), nil
+}
+
+// EncryptKey returns the encrypted key as a url safe base64 string.
+// The same bucket and key always result in the same encrypted key.
+func (sc *StoreCipher) EncryptKey(bucketID string, key string) string {
+	mac := hmac.New(sha256.New, sc.nonceKey)
+	mac.Write(valueAD(bucketID, key))
+	nonce := mac.Sum(nil)[:sc.keyAEAD.NonceSize()]
+	data := sc.keyAEAD.Seal(nonce, nonce, []byte(key), []byte(bucketID))
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// EncryptValue encrypts the value of a key in a bucket
+func (sc *StoreCipher) EncryptValue(bucketID string, key string, value []byte) ([]byte, error) {
+	return sc.Encrypt(value, valueAD(bucketID, key))
+}
+
+// valueAD returns the additional data that binds a value to its bucket and key
+func valueAD(bucketID string, key string) []byte {
+	ad := make([]byte, 0, len(bucketID)+1+len(key))
+	ad = append(ad, bucketID...)
+	ad = append(ad, 0)
+	return append(ad, key...)
+}
+
+// GenerateSecretFile writes a new random hub secret to the given file.
+// An existing file is not overwritten.
+func GenerateSecretFile(secretFile string) error {
+	secret := make([]byte, SecretSize)
+	if _, err := io.ReadFull(rand.Reader, secret); err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(secret) + "\n"
+	f, err := os.OpenFile(secretFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0400)
+	if err != nil {
+		return fmt.Errorf("unable to create secret file '%s': %w", secretFile, err)
+	}
+	_, err = f.WriteString(encoded)
+	err2 := f.Close()
+	if err == nil {
+		err = err2
+	}
+	return err
+}
+
+// LoadStoreCipher reads the secret from a file and returns the cipher for the given store.
+// The file can be the hub CA private key or a secret created with GenerateSecretFile.
+//
+//	secretFile is the path of the file with the secret
+//	storeID identifies the store whose keys to derive, eg the service name
+func LoadStoreCipher(secretFile string, storeID string) (*StoreCipher, error) {
+	secret, err := os.ReadFile(secretFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read encryption secret: %w", err)
+	} else if len(secret) < SecretSize {
+		return nil, fmt.Errorf("encryption secret in '%s' is too short", secretFile)
+	}
+	return NewStoreCipher(secret, storeID)
+}
+
+// NewStoreCipher derives the encryption keys of a store from a secret using HKDF-SHA256
+//
+//	secret is the secret to derive the keys from. At least SecretSize bytes.
+//	storeID identifies the store whose keys to derive, eg the service name
+func NewStoreCipher(secret []byte, storeID string) (*StoreCipher, error) {
+	if len(secret) < SecretSize {
+		return nil, fmt.Errorf("encryption secret must be at least %d bytes", SecretSize)
+	}
+	kdf := hkdf.New(sha256.New, secret, nil, []byte(keyDerivationInfo+storeID))
+	keys := make([]byte, 3*32)
+	if _, err := io.ReadFull(kdf, keys); err != nil {
+		return nil, err
+	}
+	valueAEAD, err := newAEAD(keys[0:32])
+	if err != nil {
+		return nil, err
+	}
+	keyAEAD, err := newAEAD(keys[32:64])
+	if err != nil {
+		return nil, err
+	}
+	sc := &StoreCipher{
+		valueAEAD: valueAEAD,
+		keyAEAD:   keyAEAD,
+		nonceKey:  keys[64:96],
+	}
+	return sc, nil
+}
+
+// newAEAD returns the AES-256-GCM cipher for the key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
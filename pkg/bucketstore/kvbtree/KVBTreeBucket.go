@@ -2,9 +2,9 @@
 package kvbtree
 
 import (
-	"bytes"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/tidwall/btree"
@@ -19,13 +19,64 @@ type KVBTreeBucket struct {
 	ClientID string `json:"clientID"`
 	refCount int    // simple ref count for error detection
 	kvtree   btree.Map[string, []byte]
+	// expiry time in msec since epoch of keys that are set with a TTL
+	expiry map[string]int64
 
 	mutex sync.RWMutex
+	// writes wait for the transaction that holds this lock
+	txMutex sync.Mutex
 	// cache for parsed json strings for faster query
 	//queryCache map[string]interface{}
 
 	// update handler callback to notify bucket owner
 	updated func(bucket *KVBTreeBucket)
+	// write-ahead log of the store or nil for in-memory stores
+	wal *KVBTreeWAL
+}
+
+// applyOps applies the changes of a log record to the bucket
+// This must be called while locked.
+func (bucket *KVBTreeBucket) applyOps(ops []walOp) {
+	for _, op := range ops {
+		if op.Deleted {
+			bucket.kvtree.Delete(op.Key)
+			delete(bucket.expiry, op.Key)
+			continue
+		}
+		bucket.kvtree.Set(op.Key, op.Value)
+		if op.Expiry > 0 {
+			bucket.expiry[op.Key] = op.Expiry
+		} else {
+			delete(bucket.expiry, op.Key)
+		}
+	}
+}
+
+// writeOps writes the changes to the write-ahead log, if any, and applies them to the bucket.
+// The changes are logged as a single record so they are replayed together.
+// The values of the changes must be owned by the bucket.
+func (bucket *KVBTreeBucket) writeOps(ops []walOp) error {
+	wal := bucket.wal
+	if wal != nil {
+		wal.compactMux.RLock()
+		defer wal.compactMux.RUnlock()
+	}
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+	if wal != nil {
+		err := wal.Append(&walRecord{BucketID: bucket.BucketID, Ops: ops})
+		if err != nil {
+			return err
+		}
+	}
+	bucket.applyOps(ops)
+	bucket.updated(bucket)
+	return nil
+}
+
+// Begin starts an emulated transaction on the bucket
+func (bucket *KVBTreeBucket) Begin() (bucketstore.IBucketTx, error) {
+	return NewKVBTreeTx(bucket), nil
 }
 
 // Close the bucket and release its resources
@@ -46,6 +97,11 @@ func (bucket *KVBTreeBucket) Close() (err error) {
 	return err
 }
 
+// CompareAndSet replaces the value of the key if its current value equals oldValue
+func (bucket *KVBTreeBucket) CompareAndSet(key string, oldValue []byte, newValue []byte) (bool, error) {
+	return bucketstore.CompareAndSetWithTx(bucket, key, oldValue, newValue)
+}
+
 // Cursor returns a new cursor for iterating the bucket.
 // The cursor MUST be closed after use to release its memory.
 //
@@ -68,13 +124,11 @@ func (bucket *KVBTreeBucket) Cursor() (cursor bucketstore.IBucketCursor) {
 // Delete a document from the bucket
 // Also succeeds if the document doesn't exist
 func (bucket *KVBTreeBucket) Delete(key string) error {
-	bucket.mutex.Lock()
-	defer bucket.mutex.Unlock()
+	bucket.txMutex.Lock()
+	defer bucket.txMutex.Unlock()
 
 	logrus.Infof("Deleting key '%s' from bucket '%s'", key, bucket.BucketID)
-	bucket.kvtree.Delete(key)
-	bucket.updated(bucket)
-	return nil
+	return bucket.writeOps([]walOp{{Key: key, Deleted: true}})
 }
 
 // Export returns a shallow copy of the bucket content
@@ -94,6 +148,25 @@ func (bucket *KVBTreeBucket) Export() map[string][]byte {
 	return exportedCopy
 }
 
+// ExportExpiry returns a copy of the encoded expiry times of keys that are set with a TTL
+func (bucket *KVBTreeBucket) ExportExpiry() map[string][]byte {
+	bucket.mutex.RLock()
+	defer bucket.mutex.RUnlock()
+
+	exportedCopy := make(map[string][]byte, len(bucket.expiry))
+	for key, expiryMsec := range bucket.expiry {
+		exportedCopy[key] = bucketstore.EncodeExpiry(time.UnixMilli(expiryMsec))
+	}
+	return exportedCopy
+}
+
+// isExpired returns true if the key has an expiry time that has passed
+// This must be called while locked.
+func (bucket *KVBTreeBucket) isExpired(key string, nowMsec int64) bool {
+	expiryMsec, found := bucket.expiry[key]
+	return found && expiryMsec <= nowMsec
+}
+
 // Get an object by its ID
 // returns an error if the key does not exist.
 func (bucket *KVBTreeBucket) Get(key string) (val []byte, err error) {
@@ -102,12 +175,29 @@ func (bucket *KVBTreeBucket) Get(key string) (val []byte, err error) {
 	defer bucket.mutex.RUnlock()
 
 	val, found = bucket.kvtree.Get(key)
-	if !found {
+	if !found || bucket.isExpired(key, time.Now().UnixMilli()) {
+		val = nil
 		err = fmt.Errorf("key '%s' not found in map", key)
 	}
 	return val, err
 }
 
+// GetExpiry returns the expiry time of keys that are set with a TTL and have not expired
+func (bucket *KVBTreeBucket) GetExpiry(keys []string) (expiry map[string]time.Time, err error) {
+	bucket.mutex.RLock()
+	defer bucket.mutex.RUnlock()
+	expiry = make(map[string]time.Time)
+	nowMsec := time.Now().UnixMilli()
+
+	for _, key := range keys {
+		expiryMsec, found := bucket.expiry[key]
+		if found && expiryMsec > nowMsec {
+			expiry[key] = time.UnixMilli(expiryMsec)
+		}
+	}
+	return expiry, nil
+}
+
 // GetMultiple returns a batch of documents for the given key
 // The document can be any text.
 func (bucket *KVBTreeBucket) GetMultiple(keys []string) (docs map[string][]byte, err error) {
@@ -115,10 +205,11 @@ func (bucket *KVBTreeBucket) GetMultiple(keys []string) (docs map[string][]byte,
 	bucket.mutex.RLock()
 	defer bucket.mutex.RUnlock()
 	docs = make(map[string][]byte)
+	nowMsec := time.Now().UnixMilli()
 
 	for _, key := range keys {
 		val, found := bucket.kvtree.Get(key)
-		if found {
+		if found && !bucket.isExpired(key, nowMsec) {
 			docs[key] = val
 		}
 	}
@@ -239,7 +330,9 @@ func (bucket *KVBTreeBucket) incrRefCounter() {
 func (bucket *KVBTreeBucket) Info() (info *bucketstore.BucketStoreInfo) {
 	info = &bucketstore.BucketStoreInfo{}
 	// are these are full store sizes
+	bucket.mutex.RLock()
 	info.NrRecords = int64(bucket.kvtree.Len())
+	bucket.mutex.RUnlock()
 	info.DataSize = -1
 	//
 	info.Engine = bucketstore.BackendKVBTree
@@ -247,6 +340,36 @@ func (bucket *KVBTreeBucket) Info() (info *bucketstore.BucketStoreInfo) {
 	return
 }
 
+// PrefixCursor returns a new cursor for iterating the keys that start with the given prefix
+func (bucket *KVBTreeBucket) PrefixCursor(prefix string) (cursor bucketstore.IBucketCursor) {
+	return bucket.RangeCursor(prefix, bucketstore.PrefixRangeEnd(prefix))
+}
+
+// RangeCursor returns a new cursor for iterating the keys in the range [startKey, endKey)
+func (bucket *KVBTreeBucket) RangeCursor(startKey string, endKey string) (cursor bucketstore.IBucketCursor) {
+	return bucketstore.NewRangeCursor(bucket.Cursor(), startKey, endKey)
+}
+
+// Reap removes the keys whose expiry time has passed
+// This returns the number of removed keys.
+func (bucket *KVBTreeBucket) Reap(now time.Time) (nrRemoved int) {
+	bucket.mutex.Lock()
+	defer bucket.mutex.Unlock()
+
+	nowMsec := now.UnixMilli()
+	for key, expiryMsec := range bucket.expiry {
+		if expiryMsec <= nowMsec {
+			bucket.kvtree.Delete(key)
+			delete(bucket.expiry, key)
+			nrRemoved++
+		}
+	}
+	if nrRemoved > 0 {
+		bucket.updated(bucket)
+	}
+	return nrRemoved
+}
+
 // Set writes a document to the store. If the document exists it is replaced.
 // This will store a copy of doc
 //
@@ -262,11 +385,34 @@ func (bucket *KVBTreeBucket) Set(key string, doc []byte) error {
 	//docCopy := bytes.NewBuffer(doc).Bytes()
 	//docCopy := []byte(string(doc))
 	// store the document and object
+	bucket.txMutex.Lock()
+	defer bucket.txMutex.Unlock()
+	return bucket.writeOps([]walOp{{Key: key, Value: caphelp.Clone(doc)}})
+}
+
+// SetExpiry sets the encoded expiry times of keys. Intended for loading a saved store.
+func (bucket *KVBTreeBucket) SetExpiry(expiry map[string][]byte) {
 	bucket.mutex.Lock()
 	defer bucket.mutex.Unlock()
-	bucket.kvtree.Set(key, caphelp.Clone(doc))
-	bucket.updated(bucket)
-	return nil
+	for key, encodedExpiry := range expiry {
+		if expiryMsec, valid := bucketstore.DecodeExpiry(encodedExpiry); valid {
+			bucket.expiry[key] = expiryMsec
+		}
+	}
+}
+
+// SetWithTTL writes a document to the store that expires after the given time-to-live.
+// This will store a copy of doc
+func (bucket *KVBTreeBucket) SetWithTTL(key string, doc []byte, ttl time.Duration) error {
+	if key == "" {
+		return fmt.Errorf("missing key")
+	} else if ttl <= 0 {
+		return fmt.Errorf("ttl of key '%s' must be positive", key)
+	}
+	bucket.txMutex.Lock()
+	defer bucket.txMutex.Unlock()
+	expiry := time.Now().Add(ttl).UnixMilli()
+	return bucket.writeOps([]walOp{{Key: key, Value: caphelp.Clone(doc), Expiry: expiry}})
 }
 
 func (bucket *KVBTreeBucket) setUpdateHandler(handler func(bucket *KVBTreeBucket)) {
@@ -277,13 +423,15 @@ func (bucket *KVBTreeBucket) setUpdateHandler(handler func(bucket *KVBTreeBucket
 // Values are copied
 func (bucket *KVBTreeBucket) SetMultiple(docs map[string][]byte) (err error) {
 	// store the document and object
-	bucket.mutex.Lock()
-	defer bucket.mutex.Unlock()
+	bucket.txMutex.Lock()
+	defer bucket.txMutex.Unlock()
+	ops := make([]walOp, 0, len(docs))
 	for k, v := range docs {
-		bucket.kvtree.Set(k, bytes.NewBuffer(v).Bytes())
+		val := make([]byte, len(v))
+		copy(val, v)
+		ops = append(ops, walOp{Key: k, Value: val})
 	}
-	bucket.updated(bucket)
-	return nil
+	return bucket.writeOps(ops)
 }
 
 func NewKVMemBucket(clientID, bucketID string) *KVBTreeBucket {
@@ -291,6 +439,7 @@ func NewKVMemBucket(clientID, bucketID string) *KVBTreeBucket {
 		BucketID: bucketID,
 		ClientID: clientID,
 		refCount: 0,
+		expiry:   make(map[string]int64),
 		mutex:    sync.RWMutex{},
 		updated:  nil,
 	}
@@ -305,6 +454,7 @@ func NewKVMemBucketFromMap(clientID, bucketID string, data map[string][]byte) *K
 		BucketID: bucketID,
 		ClientID: clientID,
 		refCount: 0,
+		expiry:   make(map[string]int64),
 		mutex:    sync.RWMutex{},
 		updated:  nil,
 	}
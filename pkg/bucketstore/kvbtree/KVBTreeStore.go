@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -20,14 +22,14 @@ import (
 // Interestingly, this simple brute-force store using maps is faster than anything else I've tested and even
 // scales up to 1M records. Pretty much all you need for basic databases.
 //
-// Changes are periodically persisted to file in the background.
+// Changes are appended to a write-ahead log before they are applied, and periodically
+// compacted into the store file in the background. On open, the log is replayed on top of the
+// store file so a crash or power cut doesn't lose the logged changes. How often the log is
+// synced to disk is set with the sync policy.
 //
 // Limitations:
-//   - No transaction support (basic usage, remember)
-//   - Changes are periodically (default 3 second) written to disk
-//
-// Improvements for future considerations:
-//   - Append-only to reduce disk writes for larger databases
+//   - Transactions are emulated and block other writes to the bucket
+//   - The store file is rewritten in full on each compaction (default 3 seconds after a change)
 //
 // --- about jsonpath ---
 // This was experimental because of the W3C WoT recommendation, and seems to work well.
@@ -57,11 +59,19 @@ type KVBTreeStore struct {
 	updateCount          int32        // nr of updates since last save
 	backgroundLoopEnded  chan bool
 	backgroundLoopEnding chan bool
-	writeDelay           time.Duration // delay before writing changes
+	writeDelay           time.Duration // delay before compacting changes into the store file
+	reapInterval         time.Duration // interval of removing expired keys
+	// write-ahead log of the store, or nil for in-memory stores
+	wal          *KVBTreeWAL
+	syncPolicy   string        // sync policy of the write-ahead log
+	syncInterval time.Duration // interval of syncing the log with WALSyncInterval
 	// cache for parsed json strings for faster query
 	//jsonCache map[string]interface{}
 }
 
+// DefaultSyncInterval is the default interval of syncing the write-ahead log with WALSyncInterval
+const DefaultSyncInterval = time.Second
+
 // importStoreFile loads the store content into a map and converts it to a map of buckets
 // returns an error if the file does not exist
 // not concurrent safe
@@ -72,9 +82,21 @@ func importStoreFile(clientID, storePath string) (docs map[string]*KVBTreeBucket
 		return nil, err
 	}
 	for bucketID, bucketData := range imported {
+		if strings.HasPrefix(bucketID, bucketstore.ExpiryBucketPrefix) {
+			continue
+		}
 		bucket := NewKVMemBucketFromMap(clientID, bucketID, bucketData)
 		docs[bucketID] = bucket
 	}
+	// the expiry times of keys with a TTL are stored in a separate bucket
+	for bucketID, bucketData := range imported {
+		if strings.HasPrefix(bucketID, bucketstore.ExpiryBucketPrefix) {
+			bucket, found := docs[strings.TrimPrefix(bucketID, bucketstore.ExpiryBucketPrefix)]
+			if found {
+				bucket.SetExpiry(bucketData)
+			}
+		}
+	}
 	// if the store didn't exist it must be writable successfully in order to continue
 	return docs, err
 }
@@ -137,10 +159,11 @@ func writeStoreFile(storePath string, docs map[string]map[string][]byte) error {
 		// yeah this is pretty fatal too
 		logrus.Panicf("Unable to marshal documents while saving store to %s: %s", storePath, err)
 	}
-	// First write content to temp file
+	// First write content to temp file and sync it to disk, so the rename below can't
+	// replace the store file with a file whose content isn't on disk yet.
 	// The temp file is opened with 0600 permissions
 	tmpName := storePath + ".tmp"
-	err = os.WriteFile(tmpName, rawData, 0600)
+	err = bucketstore.WriteFileSync(tmpName, rawData)
 	if err != nil {
 		// ouch, wth?
 		err := fmt.Errorf("error while creating tempfile for jsonstore: %s", err)
@@ -151,6 +174,10 @@ func writeStoreFile(storePath string, docs map[string]map[string][]byte) error {
 	// move the temp file to the final store file.
 	// this replaces the file if it already exists
 	err = os.Rename(tmpName, storePath)
+	if err == nil {
+		// the rename is only durable after the directory is synced
+		err = bucketstore.SyncDir(storeFolder)
+	}
 	if err != nil {
 		err := fmt.Errorf("error while moving tempfile to jsonstore '%s': %s", storePath, err)
 		logrus.Error(err)
@@ -159,30 +186,34 @@ func writeStoreFile(storePath string, docs map[string]map[string][]byte) error {
 	return nil
 }
 
-// autoSaveLoop periodically saves changes to the store
+// autoSaveLoop periodically compacts changes into the store file, syncs the write-ahead log
+// and removes expired keys
 func (store *KVBTreeStore) autoSaveLoop() {
 	logrus.Infof("auto-save loop started")
 
 	defer close(store.backgroundLoopEnded)
+	reapTicker := time.NewTicker(store.reapInterval)
+	defer reapTicker.Stop()
+	syncTicker := time.NewTicker(store.syncInterval)
+	defer syncTicker.Stop()
 
 	for {
 		select {
 		case <-store.backgroundLoopEnding:
 			logrus.Infof("Autosave loop ended")
 			return
+		case <-reapTicker.C:
+			store.reap()
+		case <-syncTicker.C:
+			if store.wal != nil && store.syncPolicy == WALSyncInterval {
+				if err := store.wal.Sync(); err != nil {
+					logrus.Errorf("failed syncing the write-ahead log of store '%s': %s", store.clientID, err)
+				}
+			}
 		case <-time.After(store.writeDelay):
-			//store.mutex.Lock()
 			if atomic.LoadInt32(&store.updateCount) > int32(0) {
-				// make a shallow copy for writing to avoid a lock during write to disk
-				exportedCopy := store.Export()
-				atomic.StoreInt32(&store.updateCount, 0)
-				//store.mutex.Unlock()
-
 				// nothing we can do here. error is already logged
-				// FIXME: use separate write lock
-				_ = writeStoreFile(store.storePath, exportedCopy)
-			} else {
-				//store.mutex.Unlock()
+				_ = store.compact()
 			}
 		}
 	}
@@ -205,25 +236,100 @@ func (store *KVBTreeStore) Close() error {
 
 	// flush any remaining changes
 	if atomic.LoadInt32(&store.updateCount) > int32(0) {
-		// note Export does an rlock
-		exportedCopy := store.Export()
-		err = writeStoreFile(store.storePath, exportedCopy)
+		err = store.compact()
+	}
+	if store.wal != nil {
+		err2 := store.wal.Close()
+		if err == nil {
+			err = err2
+		}
+		// the store file holds all changes so the log is no longer needed
+		if err == nil {
+			_ = os.Remove(store.wal.walPath)
+		}
+		store.wal = nil
 	}
 	store.buckets = nil
 	logrus.Infof("Store '%s' close completed. Background loop ended", store.clientID)
 	return err
 }
 
+// compact writes the store content to the store file and starts a new write-ahead log.
+// Writes wait while the copy of the store is taken and the log is rotated, but not while
+// the store file is written.
+func (store *KVBTreeStore) compact() error {
+	wal := store.wal
+	if wal != nil {
+		wal.compactMux.Lock()
+	}
+	// make a shallow copy for writing to avoid a lock during write to disk
+	exportedCopy := store.Export()
+	atomic.StoreInt32(&store.updateCount, 0)
+	var err error
+	if wal != nil {
+		err = wal.Rotate()
+		wal.compactMux.Unlock()
+	}
+	if err == nil {
+		err = writeStoreFile(store.storePath, exportedCopy)
+	}
+	// the store file is synced to disk so the log that is set aside is no longer needed
+	if err == nil && wal != nil {
+		err = wal.RemovePrev()
+	}
+	if err != nil {
+		logrus.Errorf("failed compacting store '%s': %s", store.clientID, err)
+		// try again later
+		atomic.AddInt32(&store.updateCount, 1)
+	}
+	return err
+}
+
+// DeleteBucket removes the bucket and the expiry of its keys from the store
+func (store *KVBTreeStore) DeleteBucket(bucketID string) error {
+	if store.buckets == nil {
+		return fmt.Errorf("store is not open")
+	}
+	if store.wal != nil {
+		store.wal.compactMux.RLock()
+		defer store.wal.compactMux.RUnlock()
+	}
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if _, found := store.buckets[bucketID]; found {
+		logrus.Infof("deleting bucket '%s' of client '%s'", bucketID, store.clientID)
+		if store.wal != nil {
+			err := store.wal.Append(&walRecord{BucketID: bucketID, DeleteBucket: true})
+			if err != nil {
+				return err
+			}
+		}
+		delete(store.buckets, bucketID)
+		atomic.AddInt32(&store.updateCount, 1)
+	}
+	return nil
+}
+
 // export returns a map of the given bucket
 // this copies the keys and values into a new map
 func (store *KVBTreeStore) Export() map[string]map[string][]byte {
 	store.mutex.RLock()
 	defer store.mutex.RUnlock()
+	return store.exportBuckets()
+}
+
+// exportBuckets returns a shallow copy of the buckets and their expiry times
+// This must be called while locked.
+func (store *KVBTreeStore) exportBuckets() map[string]map[string][]byte {
 	var exportedCopy = make(map[string]map[string][]byte)
 
 	for bucketID, bucket := range store.buckets {
 		bucketExport := bucket.Export()
 		exportedCopy[bucketID] = bucketExport
+		expiryExport := bucket.ExportExpiry()
+		if len(expiryExport) > 0 {
+			exportedCopy[bucketstore.ExpiryBucketPrefix+bucketID] = expiryExport
+		}
 	}
 	return exportedCopy
 }
@@ -239,6 +345,7 @@ func (store *KVBTreeStore) GetBucket(bucketID string) (bucket bucketstore.IBucke
 	if kvBucket == nil {
 		kvBucket = NewKVMemBucket(store.clientID, bucketID)
 		kvBucket.setUpdateHandler(store.onBucketUpdated)
+		kvBucket.wal = store.wal
 		store.buckets[bucketID] = kvBucket
 		bucket = kvBucket
 	}
@@ -249,6 +356,41 @@ func (store *KVBTreeStore) GetBucket(bucketID string) (bucket bucketstore.IBucke
 	return kvBucket
 }
 
+// Info returns the store information
+// The data size is the size of the store file as last written.
+func (store *KVBTreeStore) Info() *bucketstore.BucketStoreInfo {
+	info := &bucketstore.BucketStoreInfo{
+		DataSize:  -1,
+		Engine:    bucketstore.BackendKVBTree,
+		Id:        store.clientID,
+		NrRecords: 0,
+	}
+	store.mutex.RLock()
+	for _, bucket := range store.buckets {
+		info.NrRecords += bucket.Info().NrRecords
+	}
+	store.mutex.RUnlock()
+	if fileInfo, err := os.Stat(store.storePath); err == nil {
+		info.DataSize = fileInfo.Size()
+	}
+	return info
+}
+
+// ListBuckets returns the IDs of the buckets in the store in ascending order
+func (store *KVBTreeStore) ListBuckets() (bucketIDs []string, err error) {
+	if store.buckets == nil {
+		return nil, fmt.Errorf("store is not open")
+	}
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+	bucketIDs = make([]string, 0, len(store.buckets))
+	for bucketID := range store.buckets {
+		bucketIDs = append(bucketIDs, bucketID)
+	}
+	sort.Strings(bucketIDs)
+	return bucketIDs, nil
+}
+
 // callback handler for notification that a bucket has been modified
 func (store *KVBTreeStore) onBucketUpdated(bucket *KVBTreeBucket) {
 	// at this point we don't need the bucket but this might change with more fine grained update tracking
@@ -256,7 +398,60 @@ func (store *KVBTreeStore) onBucketUpdated(bucket *KVBTreeBucket) {
 	atomic.AddInt32(&store.updateCount, 1)
 }
 
-// Open the store and start the background loop for saving changes
+// openWAL replays the write-ahead log on top of the loaded store and starts a new log.
+// Replayed changes are written to the store file before the log is discarded.
+// This must be called while locked.
+func (store *KVBTreeStore) openWAL() error {
+	wal := NewKVBTreeWAL(store.storePath+WALFileSuffix, store.syncPolicy)
+	nrRecords, err := wal.Replay(store.replayRecord)
+	if err == nil && nrRecords > 0 {
+		logrus.Infof("replayed %d records of the write-ahead log of store '%s'", nrRecords, store.clientID)
+		err = writeStoreFile(store.storePath, store.exportBuckets())
+	}
+	if err == nil {
+		err = wal.Open()
+	}
+	if err != nil {
+		return fmt.Errorf("failed opening the write-ahead log of store '%s': %w", store.clientID, err)
+	}
+	store.wal = wal
+	return nil
+}
+
+// replayRecord applies a record of the write-ahead log to the store during open
+func (store *KVBTreeStore) replayRecord(record *walRecord) {
+	if record.DeleteBucket {
+		delete(store.buckets, record.BucketID)
+		return
+	}
+	bucket, found := store.buckets[record.BucketID]
+	if !found {
+		bucket = NewKVMemBucket(store.clientID, record.BucketID)
+		store.buckets[record.BucketID] = bucket
+	}
+	bucket.applyOps(record.Ops)
+}
+
+// reap removes the expired keys from all buckets
+func (store *KVBTreeStore) reap() {
+	store.mutex.RLock()
+	buckets := make([]*KVBTreeBucket, 0, len(store.buckets))
+	for _, bucket := range store.buckets {
+		buckets = append(buckets, bucket)
+	}
+	store.mutex.RUnlock()
+
+	now := time.Now()
+	nrRemoved := 0
+	for _, bucket := range buckets {
+		nrRemoved += bucket.Reap(now)
+	}
+	if nrRemoved > 0 {
+		logrus.Infof("removed %d expired keys from store '%s'", nrRemoved, store.clientID)
+	}
+}
+
+// Open the store, replay the write-ahead log and start the background loop for saving changes
 func (store *KVBTreeStore) Open() error {
 	logrus.Infof("Opening store from '%s'", store.storePath)
 	var err error
@@ -265,6 +460,9 @@ func (store *KVBTreeStore) Open() error {
 
 	if store.buckets != nil {
 		return fmt.Errorf("store already open")
+	} else if store.syncPolicy != WALSyncAlways && store.syncPolicy != WALSyncInterval &&
+		store.syncPolicy != WALSyncNever {
+		return fmt.Errorf("unknown sync policy '%s' for store '%s'", store.syncPolicy, store.clientID)
 	}
 	store.buckets, err = importStoreFile(store.clientID, store.storePath)
 	// recover from bad file. Missing file is okay.
@@ -283,9 +481,17 @@ func (store *KVBTreeStore) Open() error {
 			return fmt.Errorf("failed creating store file: '%w'", err)
 		}
 	}
+	if store.storePath != "" {
+		err = store.openWAL()
+		if err != nil {
+			store.buckets = nil
+			return err
+		}
+	}
 	// after loading set the handler for all buckets
 	for _, kvBucket := range store.buckets {
 		kvBucket.setUpdateHandler(store.onBucketUpdated)
+		kvBucket.wal = store.wal
 	}
 
 	store.backgroundLoopEnding = make(chan bool)
@@ -309,7 +515,72 @@ func (store *KVBTreeStore) Open() error {
 //	return res, nil
 //}
 
-// SetWriteDelay sets the delay for writing after a change
+// Restore replaces the store file with the snapshot in the given directory.
+// The store must be closed.
+func (store *KVBTreeStore) Restore(directory string) error {
+	if store.buckets != nil {
+		return fmt.Errorf("store '%s' must be closed before it can be restored", store.clientID)
+	} else if store.storePath == "" {
+		return fmt.Errorf("in-memory store '%s' can't be restored", store.clientID)
+	}
+	snapshotPath := path.Join(directory, path.Base(store.storePath))
+	if _, err := os.Stat(snapshotPath); err != nil {
+		return fmt.Errorf("no snapshot of store '%s' in '%s': %w", store.clientID, directory, err)
+	}
+	logrus.Infof("restoring store '%s' from '%s'", store.clientID, snapshotPath)
+	err := os.MkdirAll(path.Dir(store.storePath), 0700)
+	if err == nil {
+		err = bucketstore.CopyFile(snapshotPath, store.storePath)
+	}
+	// the changes in the log of the replaced store must not be replayed
+	if err == nil {
+		walPath := store.storePath + WALFileSuffix
+		for _, logPath := range []string{walPath, walPath + walPrevSuffix} {
+			if err2 := os.Remove(logPath); err2 != nil && !os.IsNotExist(err2) {
+				err = err2
+			}
+		}
+	}
+	return err
+}
+
+// SetReapInterval sets the interval in which expired keys are removed.
+// This must be called before Open.
+func (store *KVBTreeStore) SetReapInterval(interval time.Duration) {
+	store.reapInterval = interval
+}
+
+// Snapshot writes a point-in-time copy of the store to a store file in the given directory.
+// The copy is taken the same way as the background save, so it doesn't block writes while writing to disk.
+func (store *KVBTreeStore) Snapshot(directory string) error {
+	if store.buckets == nil {
+		return fmt.Errorf("store '%s' is not open", store.clientID)
+	} else if store.storePath == "" {
+		return fmt.Errorf("in-memory store '%s' has no snapshot", store.clientID)
+	}
+	err := os.MkdirAll(directory, 0700)
+	if err != nil {
+		return err
+	}
+	exportedCopy := store.Export()
+	snapshotPath := path.Join(directory, path.Base(store.storePath))
+	logrus.Infof("writing snapshot of store '%s' to '%s'", store.clientID, snapshotPath)
+	return writeStoreFile(snapshotPath, exportedCopy)
+}
+
+// SetSyncPolicy sets the policy for syncing the write-ahead log to disk.
+// This must be called before Open.
+//
+//	policy is one of WALSyncAlways, WALSyncInterval (default) or WALSyncNever
+//	interval is the interval of syncing the log with WALSyncInterval
+func (store *KVBTreeStore) SetSyncPolicy(policy string, interval time.Duration) {
+	store.syncPolicy = policy
+	if interval > 0 {
+		store.syncInterval = interval
+	}
+}
+
+// SetWriteDelay sets the delay for compacting changes into the store file after a change
 func (store *KVBTreeStore) SetWriteDelay(delay time.Duration) {
 	store.writeDelay = delay
 }
@@ -330,6 +601,9 @@ func NewKVStore(clientID, storePath string) (store *KVBTreeStore) {
 		backgroundLoopEnded:  nil,
 		mutex:                sync.RWMutex{},
 		writeDelay:           writeDelay,
+		reapInterval:         bucketstore.DefaultReapInterval,
+		syncPolicy:           WALSyncInterval,
+		syncInterval:         DefaultSyncInterval,
 		//jsonCache:            make(map[string]interface{}),
 	}
 	return store
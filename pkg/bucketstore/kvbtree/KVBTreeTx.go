@@ -0,0 +1,108 @@
+package kvbtree
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hiveot/hub/lib/caphelp"
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// txChange is a change of a key made in a transaction
+type txChange struct {
+	value   []byte
+	deleted bool
+}
+
+// KVBTreeTx is an emulated transaction on a KVBTreeBucket.
+// Changes are kept in the transaction until commit, when they are logged and applied to
+// the bucket in a single update. The transaction holds the bucket's transaction lock until it ends,
+// so other writes to the bucket wait.
+// This implements the IBucketTx interface
+type KVBTreeTx struct {
+	bucket  *KVBTreeBucket
+	changes map[string]txChange
+	ended   bool
+}
+
+// Commit applies the changes to the bucket and ends the transaction
+func (tx *KVBTreeTx) Commit() error {
+	if tx.ended {
+		return bucketstore.ErrTxEnded
+	}
+	tx.ended = true
+	defer tx.bucket.txMutex.Unlock()
+	if len(tx.changes) == 0 {
+		return nil
+	}
+	ops := make([]walOp, 0, len(tx.changes))
+	for key, change := range tx.changes {
+		ops = append(ops, walOp{Key: key, Value: change.value, Deleted: change.deleted})
+	}
+	// the changes are logged as a single record
+	return tx.bucket.writeOps(ops)
+}
+
+// Delete removes the key in the transaction
+func (tx *KVBTreeTx) Delete(key string) error {
+	if tx.ended {
+		return bucketstore.ErrTxEnded
+	}
+	tx.changes[key] = txChange{deleted: true}
+	return nil
+}
+
+// Get returns the value of the key as changed by the transaction
+func (tx *KVBTreeTx) Get(key string) (value []byte, err error) {
+	if tx.ended {
+		return nil, bucketstore.ErrTxEnded
+	}
+	if change, found := tx.changes[key]; found {
+		return change.value, nil
+	}
+	bucket := tx.bucket
+	bucket.mutex.RLock()
+	defer bucket.mutex.RUnlock()
+	value, found := bucket.kvtree.Get(key)
+	if !found || bucket.isExpired(key, time.Now().UnixMilli()) {
+		value = nil
+	}
+	return value, nil
+}
+
+// Rollback discards the changes and ends the transaction
+func (tx *KVBTreeTx) Rollback() error {
+	if !tx.ended {
+		tx.ended = true
+		tx.changes = nil
+		tx.bucket.txMutex.Unlock()
+	}
+	return nil
+}
+
+// Set the value of the key in the transaction. This stores a copy of value.
+func (tx *KVBTreeTx) Set(key string, value []byte) error {
+	if tx.ended {
+		return bucketstore.ErrTxEnded
+	} else if key == "" {
+		return fmt.Errorf("missing key")
+	}
+	// nil values are stored as empty to distinguish them from missing keys
+	valueCopy := caphelp.Clone(value)
+	if valueCopy == nil {
+		valueCopy = []byte{}
+	}
+	tx.changes[key] = txChange{value: valueCopy}
+	return nil
+}
+
+// NewKVBTreeTx starts a new transaction on the bucket
+// This waits until other transactions on the bucket have ended.
+func NewKVBTreeTx(bucket *KVBTreeBucket) *KVBTreeTx {
+	bucket.txMutex.Lock()
+	tx := &KVBTreeTx{
+		bucket:  bucket,
+		changes: make(map[string]txChange),
+	}
+	return tx
+}
@@ -0,0 +1,278 @@
+package kvbtree
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// Sync policies of the write-ahead log
+const (
+	// WALSyncAlways syncs the log to disk after each write. Safest and slowest.
+	WALSyncAlways = "always"
+	// WALSyncInterval syncs the log to disk periodically. A power cut can lose the last interval of writes.
+	WALSyncInterval = "interval"
+	// WALSyncNever leaves syncing the log to the OS. A crash of the service doesn't lose writes
+	// but a power cut can.
+	WALSyncNever = "never"
+)
+
+// WALFileSuffix is appended to the store file name to get the write-ahead log file name
+const WALFileSuffix = ".wal"
+
+// walPrevSuffix is appended to the log file name while the log is being compacted
+const walPrevSuffix = ".prev"
+
+// walHeaderSize is the size of the record header containing the payload length and checksum
+const walHeaderSize = 8
+
+// walMaxRecordSize is the maximum size of a log record. Larger sizes indicate a corrupted log.
+const walMaxRecordSize = 256 * 1024 * 1024
+
+// walOp is a single change of a key in a log record
+type walOp struct {
+	Key     string `json:"k"`
+	Value   []byte `json:"v,omitempty"`
+	Deleted bool   `json:"d,omitempty"`
+	// expiry time in msec since epoch, or 0 if the key doesn't expire
+	Expiry int64 `json:"e,omitempty"`
+}
+
+// walRecord is a log record with the changes of a bucket that are applied together
+type walRecord struct {
+	BucketID string `json:"b"`
+	// the bucket is deleted
+	DeleteBucket bool    `json:"db,omitempty"`
+	Ops          []walOp `json:"o,omitempty"`
+}
+
+// KVBTreeWAL is the append-only write-ahead log of a KVBTreeStore.
+// Each record holds the changes of a single write or transaction and is framed with its length
+// and crc32 checksum, so a truncated or corrupted tail is detected on replay.
+//
+// The log is compacted by writing the store file and starting a new log. Writers hold
+// compactMux for reading while logging and applying a change, so a compaction never
+// separates a logged change from its effect in the store.
+type KVBTreeWAL struct {
+	walPath    string
+	syncPolicy string
+	file       *os.File
+	// serializes writes to the log file
+	mux sync.Mutex
+	// held for writing during compaction
+	compactMux sync.RWMutex
+	// the log has writes that haven't been synced to disk
+	dirty bool
+}
+
+// Append writes a record to the log and syncs it to disk if the sync policy is always
+func (wal *KVBTreeWAL) Append(record *walRecord) error {
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	frame := make([]byte, walHeaderSize+len(payload))
+	binary.BigEndian.PutUint32(frame[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(frame[4:8], crc32.ChecksumIEEE(payload))
+	copy(frame[walHeaderSize:], payload)
+
+	wal.mux.Lock()
+	defer wal.mux.Unlock()
+	if wal.file == nil {
+		return fmt.Errorf("write-ahead log '%s' is closed", wal.walPath)
+	}
+	_, err = wal.file.Write(frame)
+	if err != nil {
+		return fmt.Errorf("failed writing to write-ahead log '%s': %w", wal.walPath, err)
+	}
+	if wal.syncPolicy == WALSyncAlways {
+		err = wal.file.Sync()
+	} else {
+		wal.dirty = true
+	}
+	return err
+}
+
+// Close the log file. The log is kept for replay on the next open.
+func (wal *KVBTreeWAL) Close() (err error) {
+	wal.mux.Lock()
+	defer wal.mux.Unlock()
+	if wal.file != nil {
+		if wal.syncPolicy != WALSyncNever {
+			_ = wal.file.Sync()
+		}
+		err = wal.file.Close()
+		wal.file = nil
+	}
+	return err
+}
+
+// Open the log file for appending and discard its previous content.
+// Only call this after the previous content has been replayed and written to the store file.
+func (wal *KVBTreeWAL) Open() (err error) {
+	wal.mux.Lock()
+	defer wal.mux.Unlock()
+	wal.file, err = os.OpenFile(wal.walPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	wal.dirty = false
+	if err == nil {
+		err = os.Remove(wal.walPath + walPrevSuffix)
+		if os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	return err
+}
+
+// RemovePrev removes the log that was set aside by Rotate after the store file is written
+func (wal *KVBTreeWAL) RemovePrev() error {
+	err := os.Remove(wal.walPath + walPrevSuffix)
+	if os.IsNotExist(err) {
+		err = nil
+	}
+	return err
+}
+
+// Replay reads the records of the previous and the current log and passes them to the handler.
+// Replay stops at the first truncated or corrupted record of a log, as the records that
+// follow it can't be trusted.
+// This returns the number of replayed records.
+func (wal *KVBTreeWAL) Replay(handler func(record *walRecord)) (nrRecords int, err error) {
+	for _, logPath := range []string{wal.walPath + walPrevSuffix, wal.walPath} {
+		n, err := replayLog(logPath, handler)
+		if err != nil {
+			return nrRecords, err
+		}
+		nrRecords += n
+	}
+	return nrRecords, nil
+}
+
+// Rotate sets the current log aside and starts a new log.
+// The set aside log is replayed on open until it is removed with RemovePrev.
+// If a set aside log already exists, because writing the store file failed, then the
+// current log is appended to it.
+func (wal *KVBTreeWAL) Rotate() error {
+	wal.mux.Lock()
+	defer wal.mux.Unlock()
+	if wal.file == nil {
+		return fmt.Errorf("write-ahead log '%s' is closed", wal.walPath)
+	}
+	prevPath := wal.walPath + walPrevSuffix
+	_ = wal.file.Sync()
+	err := wal.file.Close()
+	wal.file = nil
+	if err != nil {
+		return err
+	}
+	if _, err2 := os.Stat(prevPath); err2 == nil {
+		err = appendFile(wal.walPath, prevPath)
+	} else {
+		err = os.Rename(wal.walPath, prevPath)
+	}
+	if err == nil {
+		// make the rename durable before the new log is created, so a power cut can't leave
+		// an empty log in place of the set aside log
+		err = bucketstore.SyncDir(path.Dir(wal.walPath))
+	}
+	if err != nil {
+		return fmt.Errorf("failed rotating write-ahead log '%s': %w", wal.walPath, err)
+	}
+	wal.file, err = os.OpenFile(wal.walPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	wal.dirty = false
+	return err
+}
+
+// Sync writes the log to disk if it has unsynced writes
+func (wal *KVBTreeWAL) Sync() (err error) {
+	wal.mux.Lock()
+	defer wal.mux.Unlock()
+	if wal.file != nil && wal.dirty {
+		err = wal.file.Sync()
+		wal.dirty = false
+	}
+	return err
+}
+
+// appendFile appends the content of the source file to the destination file and syncs it
+func appendFile(srcPath string, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(dstPath, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(dst, src)
+	if err == nil {
+		err = dst.Sync()
+	}
+	err2 := dst.Close()
+	if err == nil {
+		err = err2
+	}
+	return err
+}
+
+// replayLog reads the records of a log file and passes them to the handler
+// A missing log file is not an error.
+func replayLog(logPath string, handler func(record *walRecord)) (nrRecords int, err error) {
+	data, err := os.ReadFile(logPath)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("unable to read write-ahead log '%s': %w", logPath, err)
+	}
+	offset := 0
+	for offset < len(data) {
+		if len(data)-offset < walHeaderSize {
+			logrus.Warningf("write-ahead log '%s' is truncated at offset %d. Ignoring the partial record.",
+				logPath, offset)
+			break
+		}
+		size := int(binary.BigEndian.Uint32(data[offset : offset+4]))
+		checksum := binary.BigEndian.Uint32(data[offset+4 : offset+8])
+		if size > walMaxRecordSize {
+			logrus.Warningf("write-ahead log '%s' is corrupted at offset %d. Ignoring the rest of the log.",
+				logPath, offset)
+			break
+		} else if len(data)-offset-walHeaderSize < size {
+			logrus.Warningf("write-ahead log '%s' is truncated at offset %d. Ignoring the partial record.",
+				logPath, offset)
+			break
+		}
+		payload := data[offset+walHeaderSize : offset+walHeaderSize+size]
+		record := &walRecord{}
+		if crc32.ChecksumIEEE(payload) != checksum || json.Unmarshal(payload, record) != nil {
+			logrus.Warningf("write-ahead log '%s' is corrupted at offset %d. Ignoring the rest of the log.",
+				logPath, offset)
+			break
+		}
+		handler(record)
+		nrRecords++
+		offset += walHeaderSize + size
+	}
+	return nrRecords, nil
+}
+
+// NewKVBTreeWAL creates the write-ahead log of a store
+//
+//	walPath is the path of the log file
+//	syncPolicy is one of WALSyncAlways, WALSyncInterval or WALSyncNever
+func NewKVBTreeWAL(walPath string, syncPolicy string) *KVBTreeWAL {
+	wal := &KVBTreeWAL{
+		walPath:    walPath,
+		syncPolicy: syncPolicy,
+	}
+	return wal
+}
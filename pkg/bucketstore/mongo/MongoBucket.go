@@ -3,6 +3,7 @@ package mongohs
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -17,12 +18,22 @@ type MongoBucket struct {
 	collection *mongo.Collection
 }
 
+// Begin a transaction. Not supported.
+func (bucket *MongoBucket) Begin() (bucketstore.IBucketTx, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 // Close the bucket and release its resources
 func (bucket *MongoBucket) Close() (err error) {
 
 	return fmt.Errorf("not implemented")
 }
 
+// CompareAndSet is not supported
+func (bucket *MongoBucket) CompareAndSet(key string, oldValue []byte, newValue []byte) (bool, error) {
+	return false, fmt.Errorf("not implemented")
+}
+
 func (bucket *MongoBucket) Cursor() (cursor bucketstore.IBucketCursor) {
 	//var mongoCursor = nil
 	//ctx := context.Background()
@@ -37,6 +48,14 @@ func (bucket *MongoBucket) Cursor() (cursor bucketstore.IBucketCursor) {
 	return nil
 }
 
+func (bucket *MongoBucket) PrefixCursor(prefix string) (cursor bucketstore.IBucketCursor) {
+	return nil
+}
+
+func (bucket *MongoBucket) RangeCursor(startKey string, endKey string) (cursor bucketstore.IBucketCursor) {
+	return nil
+}
+
 func (bucket *MongoBucket) Delete(key string) error {
 	ctx := context.Background()
 	filter := bson.D{{"key", key}}
@@ -51,6 +70,12 @@ func (bucket *MongoBucket) Get(key string) (val []byte, err error) {
 	err = res.Decode(&val)
 	return val, err
 }
+
+// GetExpiry returns no expiry times as TTLs are not supported by the mongo bucket
+func (bucket *MongoBucket) GetExpiry(keys []string) (expiry map[string]time.Time, err error) {
+	return make(map[string]time.Time), nil
+}
+
 func (bucket *MongoBucket) GetMultiple(keys []string) (docs map[string][]byte, err error) {
 	return nil, fmt.Errorf("not implemented")
 }
@@ -90,6 +115,10 @@ func (bucket *MongoBucket) Set(key string, doc []byte) error {
 
 }
 
+func (bucket *MongoBucket) SetWithTTL(key string, doc []byte, ttl time.Duration) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (bucket *MongoBucket) SetMultiple(docs map[string][]byte) (err error) {
 	return fmt.Errorf("not implemented")
 }
@@ -5,6 +5,7 @@ package mongohs
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
@@ -50,6 +51,13 @@ type MongoBucketStore struct {
 	//startTime time.Time
 }
 
+// DeleteBucket drops the collection of the bucket
+func (srv *MongoBucketStore) DeleteBucket(bucketID string) error {
+	ctx := context.Background()
+	err := srv.storeDB.Collection(bucketID).Drop(ctx)
+	return err
+}
+
 // GetBucket returns a bucket to use
 // This creates a new collection if it doesn't yet exist.
 func (srv *MongoBucketStore) GetBucket(bucketID string) bucketstore.IBucket {
@@ -116,6 +124,35 @@ func (srv *MongoBucketStore) createBucketCollection(bucketID string) (err error)
 	return err
 }
 
+// Info returns the store information from the database statistics
+func (srv *MongoBucketStore) Info() *bucketstore.BucketStoreInfo {
+	info := &bucketstore.BucketStoreInfo{
+		DataSize:  -1,
+		Engine:    bucketstore.BackendMongoDB,
+		Id:        srv.clientID,
+		NrRecords: -1,
+	}
+	var stats struct {
+		DataSize int64 `bson:"dataSize"`
+		Objects  int64 `bson:"objects"`
+	}
+	ctx := context.Background()
+	res := srv.storeDB.RunCommand(ctx, bson.D{{Key: "dbStats", Value: 1}})
+	if err := res.Decode(&stats); err == nil {
+		info.DataSize = stats.DataSize
+		info.NrRecords = stats.Objects
+	}
+	return info
+}
+
+// ListBuckets returns the names of the bucket collections in ascending order
+func (srv *MongoBucketStore) ListBuckets() (bucketIDs []string, err error) {
+	ctx := context.Background()
+	bucketIDs, err = srv.storeDB.ListCollectionNames(ctx, bson.D{})
+	sort.Strings(bucketIDs)
+	return bucketIDs, err
+}
+
 // Open connects to the DB server.
 // This will setup the database if the collections haven't been created yet.
 // Connect must be called before any other method, including Setup or Delete
@@ -156,6 +193,16 @@ func (srv *MongoBucketStore) Close() error {
 	return err
 }
 
+// Restore is not supported. Use the mongodb tools instead.
+func (srv *MongoBucketStore) Restore(directory string) error {
+	return fmt.Errorf("restore is not supported by the mongodb backend")
+}
+
+// Snapshot is not supported. Use the mongodb tools instead.
+func (srv *MongoBucketStore) Snapshot(directory string) error {
+	return fmt.Errorf("snapshot is not supported by the mongodb backend")
+}
+
 // NewMongoBucketStore creates a bucket store with the MongoDB backend.
 // This is intended for basic key-value storage use.
 //
@@ -1,9 +1,11 @@
 package pebble
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/sirupsen/logrus"
@@ -22,6 +24,36 @@ type PebbleBucket struct {
 	bucketID   string
 	clientID   string
 	closed     bool
+	// the store has keys with an expiry time. Used to skip expiry lookups if TTLs aren't used.
+	hasExpiry *atomic.Bool
+	// write lock shared with the store. Held by transactions until they end.
+	writeMux *sync.Mutex
+}
+
+// expiryKey returns the key that holds the expiry time of a bucket key
+// The expiry keys are stored as {ExpiryBucketPrefix}{bucketID}${key}
+func expiryKey(bucketKey string) []byte {
+	return []byte(bucketstore.ExpiryBucketPrefix + bucketKey)
+}
+
+// isExpired returns true if the bucket key has an expiry time that has passed
+func (bucket *PebbleBucket) isExpired(reader pebble.Reader, bucketKey string, now time.Time) bool {
+	if !bucket.hasExpiry.Load() {
+		return false
+	}
+	encodedExpiry, closer, err := reader.Get(expiryKey(bucketKey))
+	if err != nil {
+		return false
+	}
+	isExpired := bucketstore.IsExpired(encodedExpiry, now)
+	_ = closer.Close()
+	return isExpired
+}
+
+// Begin starts a new transaction on the bucket using an indexed batch
+// The transaction MUST be committed or rolled back, as it blocks other writes to the store.
+func (bucket *PebbleBucket) Begin() (bucketstore.IBucketTx, error) {
+	return NewPebbleTx(bucket), nil
 }
 
 // Close the bucket
@@ -46,34 +78,31 @@ func (bucket *PebbleBucket) Close() (err error) {
 //	return err
 //}
 
+// CompareAndSet replaces the value of the key if its current value equals oldValue
+func (bucket *PebbleBucket) CompareAndSet(key string, oldValue []byte, newValue []byte) (bool, error) {
+	return bucketstore.CompareAndSetWithTx(bucket, key, oldValue, newValue)
+}
+
 // Cursor provides an iterator for the bucket using a pebble iterator with prefix bounds
 func (bucket *PebbleBucket) Cursor() bucketstore.IBucketCursor {
-	// bucket prefix is {bucketID}$
-	// range bounds end at {bucketID}@
-	opts := &pebble.IterOptions{
-		LowerBound:      []byte(bucket.bucketID + "$"),
-		UpperBound:      []byte(bucket.bucketID + "@"), // this key never exists
-		TableFilter:     nil,
-		PointKeyFilters: nil,
-		RangeKeyFilters: nil,
-		KeyTypes:        0,
-		RangeKeyMasking: pebble.RangeKeyMasking{
-			Suffix: nil,
-			Filter: nil,
-		},
-		OnlyReadGuaranteedDurable: false,
-		UseL6Filters:              false,
-	}
-	bucketIterator := bucket.db.NewIter(opts)
-	cursor := NewPebbleCursor(bucket.clientID, bucket.bucketID, bucket.rangeStart, bucketIterator)
-	return cursor
+	return bucket.RangeCursor("", "")
 }
 
 // Delete removes the key-value pair from the bucket store
 func (bucket *PebbleBucket) Delete(key string) (err error) {
 	bucketKey := bucket.rangeStart + key
 	opts := &pebble.WriteOptions{}
-	err = bucket.db.Delete([]byte(bucketKey), opts)
+	bucket.writeMux.Lock()
+	defer bucket.writeMux.Unlock()
+	if !bucket.hasExpiry.Load() {
+		err = bucket.db.Delete([]byte(bucketKey), opts)
+		return err
+	}
+	batch := bucket.db.NewBatch()
+	_ = batch.Delete([]byte(bucketKey), opts)
+	_ = batch.Delete(expiryKey(bucketKey), opts)
+	err = bucket.db.Apply(batch, opts)
+	_ = batch.Close()
 	return err
 }
 
@@ -85,8 +114,13 @@ func (bucket *PebbleBucket) Get(key string) (doc []byte, err error) {
 	}
 	byteValue, closer, err := bucket.db.Get([]byte(bucketKey))
 	if err == nil {
-		doc = bytes.NewBuffer(byteValue).Bytes()
+		// the value is only valid until the closer is closed
+		doc = make([]byte, len(byteValue))
+		copy(doc, byteValue)
 		err = closer.Close()
+		if bucket.isExpired(bucket.db, bucketKey, time.Now()) {
+			doc = nil
+		}
 	} else if errors.Is(err, pebble.ErrNotFound) {
 		// return doc nil if not found
 		err = nil
@@ -95,16 +129,43 @@ func (bucket *PebbleBucket) Get(key string) (doc []byte, err error) {
 	return doc, err
 }
 
+// GetExpiry returns the expiry time of keys that are set with a TTL and have not expired
+func (bucket *PebbleBucket) GetExpiry(keys []string) (expiry map[string]time.Time, err error) {
+	expiry = make(map[string]time.Time)
+	if !bucket.hasExpiry.Load() {
+		return expiry, nil
+	}
+	nowMsec := time.Now().UnixMilli()
+	for _, key := range keys {
+		bucketKey := bucket.rangeStart + key
+		encodedExpiry, closer, err2 := bucket.db.Get(expiryKey(bucketKey))
+		if err2 != nil {
+			continue
+		}
+		expiryMsec, valid := bucketstore.DecodeExpiry(encodedExpiry)
+		_ = closer.Close()
+		if valid && expiryMsec > nowMsec {
+			expiry[key] = time.UnixMilli(expiryMsec)
+		}
+	}
+	return expiry, nil
+}
+
 // GetMultiple returns a batch of documents with existing keys
 func (bucket *PebbleBucket) GetMultiple(keys []string) (docs map[string][]byte, err error) {
 
 	docs = make(map[string][]byte)
 	batch := bucket.db.NewIndexedBatch()
+	now := time.Now()
 	for _, key := range keys {
 		bucketKey := bucket.rangeStart + key
 		value, closer, err2 := batch.Get([]byte(bucketKey))
 		if err2 == nil {
-			docs[key] = bytes.NewBuffer(value).Bytes()
+			if !bucket.isExpired(batch, bucketKey, now) {
+				doc := make([]byte, len(value))
+				copy(doc, value)
+				docs[key] = doc
+			}
 			err = closer.Close()
 		}
 	}
@@ -146,6 +207,39 @@ func (bucket *PebbleBucket) Info() (info *bucketstore.BucketStoreInfo) {
 	return info
 }
 
+// PrefixCursor provides an iterator for the keys that start with the given prefix
+func (bucket *PebbleBucket) PrefixCursor(prefix string) bucketstore.IBucketCursor {
+	return bucket.RangeCursor(prefix, bucketstore.PrefixRangeEnd(prefix))
+}
+
+// RangeCursor provides an iterator for the keys in the range [startKey, endKey)
+// This uses the pebble iterator bounds, which also limit Seek to the range.
+func (bucket *PebbleBucket) RangeCursor(startKey string, endKey string) bucketstore.IBucketCursor {
+	// bucket prefix is {bucketID}$
+	// range bounds end at {bucketID}%
+	upperBound := bucket.rangeEnd // this key never exists
+	if endKey != "" {
+		upperBound = bucket.rangeStart + endKey
+	}
+	opts := &pebble.IterOptions{
+		LowerBound:      []byte(bucket.rangeStart + startKey),
+		UpperBound:      []byte(upperBound),
+		TableFilter:     nil,
+		PointKeyFilters: nil,
+		RangeKeyFilters: nil,
+		KeyTypes:        0,
+		RangeKeyMasking: pebble.RangeKeyMasking{
+			Suffix: nil,
+			Filter: nil,
+		},
+		OnlyReadGuaranteedDurable: false,
+		UseL6Filters:              false,
+	}
+	bucketIterator := bucket.db.NewIter(opts)
+	cursor := NewPebbleCursor(bucket.clientID, bucket.bucketID, bucket.rangeStart, bucketIterator)
+	return cursor
+}
+
 // Set sets a document with the given key
 func (bucket *PebbleBucket) Set(key string, doc []byte) error {
 	if key == "" {
@@ -155,19 +249,57 @@ func (bucket *PebbleBucket) Set(key string, doc []byte) error {
 	}
 	bucketKey := bucket.rangeStart + key
 	opts := &pebble.WriteOptions{}
-	err := bucket.db.Set([]byte(bucketKey), doc, opts)
+	bucket.writeMux.Lock()
+	defer bucket.writeMux.Unlock()
+	if !bucket.hasExpiry.Load() {
+		err := bucket.db.Set([]byte(bucketKey), doc, opts)
+		return err
+	}
+	// clear the expiry of the key, if any
+	batch := bucket.db.NewBatch()
+	_ = batch.Set([]byte(bucketKey), doc, opts)
+	_ = batch.Delete(expiryKey(bucketKey), opts)
+	err := bucket.db.Apply(batch, opts)
+	_ = batch.Close()
 	return err
+}
 
+// SetWithTTL sets a document with the given key that expires after the time-to-live
+// The document and its expiry time are written in a single batch.
+func (bucket *PebbleBucket) SetWithTTL(key string, doc []byte, ttl time.Duration) error {
+	if key == "" {
+		err := fmt.Errorf("empty key '%s' for bucket '%s' and client '%s'",
+			key, bucket.bucketID, bucket.clientID)
+		return err
+	} else if ttl <= 0 {
+		return fmt.Errorf("ttl of key '%s' must be positive", key)
+	}
+	bucket.writeMux.Lock()
+	defer bucket.writeMux.Unlock()
+	bucket.hasExpiry.Store(true)
+	bucketKey := bucket.rangeStart + key
+	opts := &pebble.WriteOptions{}
+	batch := bucket.db.NewBatch()
+	_ = batch.Set([]byte(bucketKey), doc, opts)
+	_ = batch.Set(expiryKey(bucketKey), bucketstore.EncodeExpiry(time.Now().Add(ttl)), opts)
+	err := bucket.db.Apply(batch, opts)
+	_ = batch.Close()
+	return err
 }
 
 // SetMultiple sets multiple documents in a batch update
 func (bucket *PebbleBucket) SetMultiple(docs map[string][]byte) (err error) {
-
+	bucket.writeMux.Lock()
+	defer bucket.writeMux.Unlock()
 	batch := bucket.db.NewBatch()
+	hasExpiry := bucket.hasExpiry.Load()
 	for key, value := range docs {
 		bucketKey := bucket.rangeStart + key
 		opts := &pebble.WriteOptions{}
 		err = batch.Set([]byte(bucketKey), value, opts)
+		if err == nil && hasExpiry {
+			err = batch.Delete(expiryKey(bucketKey), opts)
+		}
 		if err != nil {
 			logrus.Errorf("failed set multiple for client '%s: %s", bucket.clientID, err)
 			_ = batch.Close()
@@ -180,7 +312,11 @@ func (bucket *PebbleBucket) SetMultiple(docs map[string][]byte) (err error) {
 }
 
 // NewPebbleBucket creates a new bucket
-func NewPebbleBucket(clientID, bucketID string, pebbleDB *pebble.DB) *PebbleBucket {
+//
+//	hasExpiry is shared with the store and set when keys with an expiry time exist
+//	writeMux is the write lock shared with the store
+func NewPebbleBucket(clientID, bucketID string, pebbleDB *pebble.DB,
+	hasExpiry *atomic.Bool, writeMux *sync.Mutex) *PebbleBucket {
 	if pebbleDB == nil {
 		logrus.Panicf("clientID='%s', bucketID='%s'. pebbleDB is nil", clientID, bucketID)
 	}
@@ -189,7 +325,9 @@ func NewPebbleBucket(clientID, bucketID string, pebbleDB *pebble.DB) *PebbleBuck
 		bucketID:   bucketID,
 		db:         pebbleDB,
 		rangeStart: bucketID + "$",
-		rangeEnd:   bucketID + "@",
+		rangeEnd:   bucketID + "%", // '%' follows '$' so this excludes buckets that extend bucketID
+		hasExpiry:  hasExpiry,
+		writeMux:   writeMux,
 	}
 	return srv
 }
@@ -4,6 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/sirupsen/logrus"
@@ -53,20 +58,87 @@ type PebbleStore struct {
 	clientID       string
 	storeDirectory string
 	db             *pebble.DB
+	// the store has keys with an expiry time
+	hasExpiry atomic.Bool
+	// writes wait for the transaction that holds this lock
+	writeMux sync.Mutex
+	// interval of removing expired keys
+	reapInterval time.Duration
+	// stop the reaper and wait until it has ended
+	reaperStop  chan bool
+	reaperEnded chan bool
 }
 
 func (store *PebbleStore) Close() error {
+	if store.reaperStop != nil {
+		close(store.reaperStop)
+		<-store.reaperEnded
+		store.reaperStop = nil
+	}
 	err := store.db.Close()
 	return err
 }
 
+// DeleteBucket removes the bucket and the expiry of its keys in a single batch
+// Buckets are simulated with key prefixes so this deletes the bucket's key range.
+func (store *PebbleStore) DeleteBucket(bucketID string) (err error) {
+	logrus.Infof("deleting bucket '%s' of client '%s'", bucketID, store.clientID)
+	rangeStart := bucketID + "$"
+	rangeEnd := bucketID + "%"
+	store.writeMux.Lock()
+	defer store.writeMux.Unlock()
+	batch := store.db.NewBatch()
+	_ = batch.DeleteRange([]byte(rangeStart), []byte(rangeEnd), nil)
+	_ = batch.DeleteRange(expiryKey(rangeStart), expiryKey(rangeEnd), nil)
+	err = store.db.Apply(batch, &pebble.WriteOptions{})
+	_ = batch.Close()
+	return err
+}
+
 // GetBucket returns a bucket with the given ID.
 // If the bucket doesn't yet exist it will be created.
 func (store *PebbleStore) GetBucket(bucketID string) (bucket bucketstore.IBucket) {
-	pb := NewPebbleBucket(store.clientID, bucketID, store.db)
+	pb := NewPebbleBucket(store.clientID, bucketID, store.db, &store.hasExpiry, &store.writeMux)
 	return pb
 }
 
+// Info returns the store information
+// The data size is the disk space used by the store. The number of records is not available.
+func (store *PebbleStore) Info() *bucketstore.BucketStoreInfo {
+	info := &bucketstore.BucketStoreInfo{
+		DataSize:  int64(store.db.Metrics().DiskSpaceUsage()),
+		Engine:    bucketstore.BackendPebble,
+		Id:        store.clientID,
+		NrRecords: -1,
+	}
+	return info
+}
+
+// ListBuckets returns the IDs of the buckets in the store in ascending order
+// Buckets are simulated with key prefixes, so this seeks from bucket to bucket.
+func (store *PebbleStore) ListBuckets() (bucketIDs []string, err error) {
+	bucketIDs = make([]string, 0)
+	iter := store.db.NewIter(&pebble.IterOptions{})
+	for valid := iter.First(); valid; {
+		key := string(iter.Key())
+		sepIndex := strings.IndexByte(key, '$')
+		if strings.HasPrefix(key, bucketstore.ExpiryBucketPrefix) {
+			// skip the expiry keys
+			valid = iter.SeekGE(store.expiryRange().UpperBound)
+		} else if sepIndex < 0 {
+			logrus.Warningf("key '%s' in store of client '%s' has no bucket", key, store.clientID)
+			valid = iter.Next()
+		} else {
+			bucketID := key[:sepIndex]
+			bucketIDs = append(bucketIDs, bucketID)
+			// seek past the keys of this bucket
+			valid = iter.SeekGE([]byte(bucketstore.PrefixRangeEnd(bucketID + "$")))
+		}
+	}
+	err = iter.Close()
+	return bucketIDs, err
+}
+
 // Open the store
 func (store *PebbleStore) Open() (err error) {
 	options := &pebble.Options{}
@@ -90,6 +162,128 @@ func (store *PebbleStore) Open() (err error) {
 	} else {
 		logrus.Error(err)
 	}
+	if err == nil {
+		// skip expiry lookups until keys with an expiry time are added
+		iter := store.db.NewIter(store.expiryRange())
+		store.hasExpiry.Store(iter.First())
+		_ = iter.Close()
+		store.reaperStop = make(chan bool)
+		store.reaperEnded = make(chan bool)
+		go store.reapLoop()
+	}
+	return err
+}
+
+// expiryRange returns the iterator options for iterating the expiry keys of all buckets
+func (store *PebbleStore) expiryRange() *pebble.IterOptions {
+	return &pebble.IterOptions{
+		LowerBound: []byte(bucketstore.ExpiryBucketPrefix),
+		// '0' follows '/' and this key never exists
+		UpperBound: []byte(strings.TrimSuffix(bucketstore.ExpiryBucketPrefix, "/") + "0"),
+	}
+}
+
+// reap removes the expired keys from all buckets in a single batch
+// The expiry keys hold the bucket key after the expiry prefix.
+func (store *PebbleStore) reap() (nrRemoved int, err error) {
+	if !store.hasExpiry.Load() {
+		return 0, nil
+	}
+	store.writeMux.Lock()
+	defer store.writeMux.Unlock()
+	now := time.Now()
+	batch := store.db.NewBatch()
+	iter := store.db.NewIter(store.expiryRange())
+	for valid := iter.First(); valid; valid = iter.Next() {
+		if bucketstore.IsExpired(iter.Value(), now) {
+			expiryKey := iter.Key()
+			bucketKey := expiryKey[len(bucketstore.ExpiryBucketPrefix):]
+			_ = batch.Delete(bucketKey, nil)
+			_ = batch.Delete(expiryKey, nil)
+			nrRemoved++
+		}
+	}
+	_ = iter.Close()
+	if nrRemoved > 0 {
+		err = store.db.Apply(batch, &pebble.WriteOptions{})
+	}
+	_ = batch.Close()
+	return nrRemoved, err
+}
+
+// reapLoop periodically removes expired keys until the store is closed
+func (store *PebbleStore) reapLoop() {
+	defer close(store.reaperEnded)
+	ticker := time.NewTicker(store.reapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-store.reaperStop:
+			return
+		case <-ticker.C:
+			nrRemoved, err := store.reap()
+			if err != nil {
+				logrus.Errorf("failed removing expired keys of client '%s': %s", store.clientID, err)
+			} else if nrRemoved > 0 {
+				logrus.Infof("removed %d expired keys from store of client '%s'", nrRemoved, store.clientID)
+			}
+		}
+	}
+}
+
+// Restore replaces the store directory with the snapshot in the given directory.
+// The snapshot is first copied next to the store directory, so a failed copy leaves the store intact.
+// The store must be closed.
+func (store *PebbleStore) Restore(directory string) error {
+	// the reaper runs while the store is open
+	if store.reaperStop != nil {
+		return fmt.Errorf("store '%s' must be closed before it can be restored", store.clientID)
+	}
+	snapshotDir := path.Join(directory, path.Base(store.storeDirectory))
+	if stat, err := os.Stat(snapshotDir); err != nil || !stat.IsDir() {
+		return fmt.Errorf("no snapshot of store '%s' in '%s'", store.clientID, directory)
+	}
+	logrus.Infof("restoring store '%s' from '%s'", store.clientID, snapshotDir)
+	tmpDir := store.storeDirectory + ".restore"
+	_ = os.RemoveAll(tmpDir)
+	err := bucketstore.CopyDir(snapshotDir, tmpDir)
+	if err == nil {
+		err = os.RemoveAll(store.storeDirectory)
+	}
+	if err == nil {
+		err = os.Rename(tmpDir, store.storeDirectory)
+	}
+	if err != nil {
+		_ = os.RemoveAll(tmpDir)
+	}
+	return err
+}
+
+// SetReapInterval sets the interval in which expired keys are removed.
+// This must be called before Open.
+func (store *PebbleStore) SetReapInterval(interval time.Duration) {
+	store.reapInterval = interval
+}
+
+// Snapshot writes a pebble checkpoint of the store into the given directory.
+// A checkpoint is a consistent point-in-time copy that hard-links the immutable data files
+// where possible, so it is fast and writes can continue.
+func (store *PebbleStore) Snapshot(directory string) error {
+	// the reaper runs while the store is open
+	if store.reaperStop == nil {
+		return fmt.Errorf("store '%s' is not open", store.clientID)
+	}
+	err := os.MkdirAll(directory, 0700)
+	if err != nil {
+		return err
+	}
+	snapshotDir := path.Join(directory, path.Base(store.storeDirectory))
+	logrus.Infof("writing snapshot of store '%s' to '%s'", store.clientID, snapshotDir)
+	// pebble requires that the checkpoint directory doesn't exist
+	err = os.RemoveAll(snapshotDir)
+	if err == nil {
+		err = store.db.Checkpoint(snapshotDir, pebble.WithFlushedWAL())
+	}
 	return err
 }
 
@@ -101,6 +295,7 @@ func NewPebbleStore(clientID, storeDirectory string) *PebbleStore {
 	srv := &PebbleStore{
 		clientID:       clientID,
 		storeDirectory: storeDirectory,
+		reapInterval:   bucketstore.DefaultReapInterval,
 	}
 	return srv
 }
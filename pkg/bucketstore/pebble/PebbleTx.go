@@ -0,0 +1,105 @@
+package pebble
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// PebbleTx is a transaction on a PebbleBucket using a pebble indexed batch.
+// Reads in the transaction include its own changes. The changes are written atomically on commit.
+// The transaction holds the store's write lock until it ends, so other writes wait.
+// This implements the IBucketTx interface
+type PebbleTx struct {
+	bucket *PebbleBucket
+	batch  *pebble.Batch
+	ended  bool
+}
+
+// Commit writes the changes to the store and ends the transaction
+func (tx *PebbleTx) Commit() error {
+	if tx.ended {
+		return bucketstore.ErrTxEnded
+	}
+	tx.ended = true
+	err := tx.batch.Commit(pebble.Sync)
+	_ = tx.batch.Close()
+	tx.bucket.writeMux.Unlock()
+	return err
+}
+
+// Delete removes the key and its expiry time in the transaction
+func (tx *PebbleTx) Delete(key string) error {
+	if tx.ended {
+		return bucketstore.ErrTxEnded
+	}
+	bucketKey := tx.bucket.rangeStart + key
+	err := tx.batch.Delete([]byte(bucketKey), nil)
+	if err == nil && tx.bucket.hasExpiry.Load() {
+		err = tx.batch.Delete(expiryKey(bucketKey), nil)
+	}
+	return err
+}
+
+// Get returns the value of the key as changed by the transaction
+func (tx *PebbleTx) Get(key string) (doc []byte, err error) {
+	if tx.ended {
+		return nil, bucketstore.ErrTxEnded
+	}
+	bucketKey := tx.bucket.rangeStart + key
+	byteValue, closer, err := tx.batch.Get([]byte(bucketKey))
+	if err == nil {
+		// the value is only valid until the closer is closed
+		doc = make([]byte, len(byteValue))
+		copy(doc, byteValue)
+		err = closer.Close()
+		if tx.bucket.isExpired(tx.batch, bucketKey, time.Now()) {
+			doc = nil
+		}
+	} else if errors.Is(err, pebble.ErrNotFound) {
+		err = nil
+	}
+	return doc, err
+}
+
+// Rollback discards the changes and ends the transaction
+func (tx *PebbleTx) Rollback() error {
+	if tx.ended {
+		return nil
+	}
+	tx.ended = true
+	err := tx.batch.Close()
+	tx.bucket.writeMux.Unlock()
+	return err
+}
+
+// Set the value of the key and removes its expiry time in the transaction
+func (tx *PebbleTx) Set(key string, doc []byte) error {
+	if tx.ended {
+		return bucketstore.ErrTxEnded
+	} else if key == "" {
+		return fmt.Errorf("empty key for bucket '%s' and client '%s'",
+			tx.bucket.bucketID, tx.bucket.clientID)
+	}
+	bucketKey := tx.bucket.rangeStart + key
+	err := tx.batch.Set([]byte(bucketKey), doc, nil)
+	if err == nil && tx.bucket.hasExpiry.Load() {
+		err = tx.batch.Delete(expiryKey(bucketKey), nil)
+	}
+	return err
+}
+
+// NewPebbleTx starts a new transaction on the bucket
+// This waits until other writes to the store have completed.
+func NewPebbleTx(bucket *PebbleBucket) *PebbleTx {
+	bucket.writeMux.Lock()
+	tx := &PebbleTx{
+		bucket: bucket,
+		batch:  bucket.db.NewIndexedBatch(),
+	}
+	return tx
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/api/go/vocab"
 	"github.com/hiveot/hub/lib/hubclient"
 	"github.com/hiveot/hub/lib/resolver"
 	"net"
 	"os"
 	"path"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,12 +22,14 @@ import (
 	"github.com/hiveot/hub/lib/thing"
 	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
 	"github.com/hiveot/hub/pkg/pubsub"
+	"github.com/hiveot/hub/pkg/pubsub/config"
 	service2 "github.com/hiveot/hub/pkg/pubsub/service"
 
 	"github.com/hiveot/hub/lib/logging"
 	"github.com/hiveot/hub/pkg/directory"
 	"github.com/hiveot/hub/pkg/directory/capnpclient"
 	"github.com/hiveot/hub/pkg/directory/capnpserver"
+	config2 "github.com/hiveot/hub/pkg/directory/config"
 	"github.com/hiveot/hub/pkg/directory/service"
 )
 
@@ -45,7 +49,8 @@ func startDirectory(useCapnp bool, svcPubSub pubsub.IServicePubSub) (dir directo
 	if err != nil {
 		panic("unable to open directory store")
 	}
-	svc := service.NewDirectoryService("", store, svcPubSub)
+	cfg := config2.NewDirectoryConfig()
+	svc := service.NewDirectoryService(&cfg, store, svcPubSub)
 	err = svc.Start()
 	if err != nil {
 		panic("service fails to start")
@@ -124,7 +129,7 @@ func TestAddRemoveTD(t *testing.T) {
 	require.NotNil(t, updateCap)
 
 	tdDoc1 := createTDDoc(thing1ID, title1)
-	err = updateCap.UpdateTD(ctx, publisherID, thing1ID, tdDoc1)
+	err = updateCap.UpdateTD(ctx, publisherID, thing1ID, tdDoc1, "")
 	assert.NoError(t, err)
 
 	tv2, err := readCap.GetTD(ctx, publisherID, thing1ID)
@@ -145,6 +150,48 @@ func TestAddRemoveTD(t *testing.T) {
 	logrus.Infof("--- TestRemoveTD end ---")
 }
 
+func TestBackup(t *testing.T) {
+	logrus.Infof("--- TestBackup start ---")
+	_ = os.Remove(testStoreFile)
+	const publisherID = "urn:test"
+	const thing1ID = "urn:thing1"
+	const title1 = "title1"
+	var backupDir = path.Join(testFolder, "backup")
+	var restorePath = path.Join(testFolder, "restored", path.Base(testStoreFile))
+	ctx := context.Background()
+	svc, stopFunc := startDirectory(testUseCapnp, nil)
+	defer stopFunc()
+
+	updateCap, err := svc.CapUpdateDirectory(ctx, thing1ID)
+	require.NoError(t, err)
+	tdDoc1 := createTDDoc(thing1ID, title1)
+	err = updateCap.UpdateTD(ctx, publisherID, thing1ID, tdDoc1, "")
+	assert.NoError(t, err)
+	err = svc.Backup(ctx, backupDir)
+	require.NoError(t, err)
+	// changes after the backup are not included
+	err = updateCap.RemoveTD(ctx, publisherID, thing1ID)
+	assert.NoError(t, err)
+	updateCap.Release()
+
+	// restore the snapshot into a new store and read the TD
+	restoreStore := kvbtree.NewKVStore(directory.ServiceName, restorePath)
+	err = restoreStore.Restore(backupDir)
+	require.NoError(t, err)
+	err = restoreStore.Open()
+	require.NoError(t, err)
+	defer restoreStore.Close()
+	restoreCfg := config2.NewDirectoryConfig()
+	restoreSvc := service.NewDirectoryService(&restoreCfg, restoreStore, nil)
+	readCap, err := restoreSvc.CapReadDirectory(ctx, thing1ID)
+	require.NoError(t, err)
+	tv, err := readCap.GetTD(ctx, publisherID, thing1ID)
+	assert.NoError(t, err)
+	assert.Equal(t, tdDoc1, tv.Data)
+	readCap.Release()
+	logrus.Infof("--- TestBackup end ---")
+}
+
 //func TestListTDs(t *testing.T) {
 //	logrus.Infof("--- TestListTDs start ---")
 //	_ = os.Remove(dirStoreFile)
@@ -192,7 +239,7 @@ func TestCursor(t *testing.T) {
 
 	// add 1 doc. the service itself also has a doc
 	tdDoc1 := createTDDoc(thing1ID, title1)
-	err = updateCap.UpdateTD(ctx, publisherID, thing1ID, tdDoc1)
+	err = updateCap.UpdateTD(ctx, publisherID, thing1ID, tdDoc1, "")
 	require.NoError(t, err)
 
 	// expect 2 docs, the service itself and the one just added
@@ -231,7 +278,7 @@ func TestPubSub(t *testing.T) {
 	ctx := context.Background()
 
 	// use an in-memory version of the pubsub service
-	pubSubSvc := service2.NewPubSubService()
+	pubSubSvc := service2.NewPubSubService(config.NewPubSubConfig(), nil)
 	resolver.RegisterService[pubsub.IPubSubService](pubSubSvc)
 	//cap := resolver.GetCapability[directory.IDirectory]()
 	//cap.Release()
@@ -266,36 +313,254 @@ func TestPubSub(t *testing.T) {
 	svcPubSub.Release()
 }
 
-//func TestQueryTDs(t *testing.T) {
-//	logrus.Infof("--- TestQueryTDs start ---")
-//	_ = os.Remove(dirStoreFile)
-//	const thing1ID = "thing1"
-//	const title1 = "title1"
-//
-//	ctx := context.Background()
-//	store, cancelFunc, err := startDirectory(testUseCapnp)
-//	defer cancelFunc()
-//	require.NoError(t, err)
-//	readCap := store.CapReadDirectory(ctx)
-//	defer readCap.Release()
-//	updateCap := store.CapUpdateDirectory(ctx)
-//	defer updateCap.Release()
-//
-//	tdDoc1 := createTDDoc(thing1ID, title1)
-//	err = updateCap.UpdateTD(ctx, thing1ID, tdDoc1)
-//	require.NoError(t, err)
-//
-//	jsonPathQuery := `$[?(@.id=="thing1")]`
-//	tdList, err := readCap.QueryTDs(ctx, jsonPathQuery, 0, 0)
-//	require.NoError(t, err)
-//	assert.NotNil(t, tdList)
-//	assert.True(t, len(tdList) > 0)
-//	el0 := thing.ThingDescription{}
-//	json.Unmarshal([]byte(tdList[0]), &el0)
-//	assert.Equal(t, thing1ID, el0.ID)
-//	assert.Equal(t, title1, el0.Title)
-//	logrus.Infof("--- TestQueryTDs end ---")
-//}
+func TestStaleTDs(t *testing.T) {
+	logrus.Infof("--- TestStaleTDs start ---")
+	_ = os.Remove(testStoreFile)
+	const publisherID = "urn:test"
+	const thing1ID = "urn:thing1"
+	const thing2ID = "urn:thing2"
+	var removed = make([]thing.ThingValue, 0)
+	var rxMux sync.Mutex
+	ctx := context.Background()
+
+	pubSubSvc := service2.NewPubSubService(config.NewPubSubConfig(), nil)
+	err := pubSubSvc.Start()
+	require.NoError(t, err)
+	defer pubSubSvc.Stop()
+	svcPubSub, _ := pubSubSvc.CapServicePubSub(ctx, directory.ServiceName)
+	userPubSub, _ := pubSubSvc.CapUserPubSub(ctx, "user1")
+	defer userPubSub.Release()
+	err = userPubSub.SubEvent(ctx, directory.ServiceName, directory.ServiceName, directory.EventNameTDRemoved,
+		func(ev thing.ThingValue) {
+			tv := thing.ThingValue{}
+			_ = json.Unmarshal(ev.Data, &tv)
+			rxMux.Lock()
+			removed = append(removed, tv)
+			rxMux.Unlock()
+		})
+	require.NoError(t, err)
+
+	store := kvbtree.NewKVStore(directory.ServiceName, testStoreFile)
+	err = store.Open()
+	require.NoError(t, err)
+	defer store.Close()
+	cfg := config2.NewDirectoryConfig()
+	cfg.StaleAgeSec = 1
+	svc := service.NewDirectoryService(&cfg, store, svcPubSub)
+
+	readCap, _ := svc.CapReadDirectory(ctx, "user1")
+	defer readCap.Release()
+	updateCap, _ := svc.CapUpdateDirectory(ctx, "user1")
+	defer updateCap.Release()
+
+	_ = updateCap.UpdateTD(ctx, publisherID, thing1ID, createTDDoc(thing1ID, "title1"), "")
+	tdList, err := readCap.GetStaleTDs(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, tdList)
+
+	// the service TD and thing2 are updated after thing1 has become stale
+	time.Sleep(time.Millisecond * 1100)
+	err = svc.Start()
+	require.NoError(t, err)
+	defer svc.Stop()
+	_ = updateCap.UpdateTD(ctx, publisherID, thing2ID, createTDDoc(thing2ID, "title2"), "")
+	tdList, err = readCap.GetStaleTDs(ctx)
+	assert.NoError(t, err)
+	require.Equal(t, 1, len(tdList))
+	assert.Equal(t, thing1ID, tdList[0].ThingID)
+
+	// removal publishes a td removed event without the TD document
+	nrRemoved, err := svc.RemoveStaleTDs(time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, nrRemoved)
+	_, err = readCap.GetTD(ctx, publisherID, thing1ID)
+	assert.Error(t, err)
+	_, err = readCap.GetTD(ctx, publisherID, thing2ID)
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 10)
+	rxMux.Lock()
+	require.Equal(t, 1, len(removed))
+	assert.Equal(t, publisherID, removed[0].PublisherID)
+	assert.Equal(t, thing1ID, removed[0].ThingID)
+	assert.Empty(t, removed[0].Data)
+	rxMux.Unlock()
+
+	// the TD retained by pubsub is cleared when the thing is removed
+	const thing3ID = "urn:thing3"
+	devicePubSub, _ := pubSubSvc.CapDevicePubSub(ctx, publisherID)
+	defer devicePubSub.Release()
+	err = devicePubSub.PubEvent(ctx, thing3ID, hubapi.EventNameTD, createTDDoc(thing3ID, "title3"))
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	err = updateCap.RemoveTD(ctx, publisherID, thing3ID)
+	require.NoError(t, err)
+	nrRetained := 0
+	err = userPubSub.SubEvent(ctx, publisherID, thing3ID, hubapi.EventNameTD,
+		func(ev thing.ThingValue) {
+			rxMux.Lock()
+			nrRetained++
+			rxMux.Unlock()
+		})
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	rxMux.Lock()
+	assert.Equal(t, 0, nrRetained)
+	rxMux.Unlock()
+
+	// the service TD is never stale
+	nrRemoved, err = svc.RemoveStaleTDs(time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, nrRemoved)
+	_, err = readCap.GetTD(ctx, directory.ServiceName, directory.ServiceName)
+	assert.NoError(t, err)
+	logrus.Infof("--- TestStaleTDs end ---")
+}
+
+// A TD that is retained by pubsub is replayed to the directory when it restarts. This
+// must not reset the age of the TD.
+func TestRetainedTDAge(t *testing.T) {
+	logrus.Infof("--- TestRetainedTDAge start ---")
+	_ = os.Remove(testStoreFile)
+	const publisherID = "urn:test"
+	const thing1ID = "urn:thing1"
+	ctx := context.Background()
+
+	pubSubSvc := service2.NewPubSubService(config.NewPubSubConfig(), nil)
+	err := pubSubSvc.Start()
+	require.NoError(t, err)
+	defer pubSubSvc.Stop()
+	devicePubSub, _ := pubSubSvc.CapDevicePubSub(ctx, publisherID)
+	defer devicePubSub.Release()
+	err = devicePubSub.PubEvent(ctx, thing1ID, hubapi.EventNameTD, createTDDoc(thing1ID, "title1"))
+	require.NoError(t, err)
+
+	store := kvbtree.NewKVStore(directory.ServiceName, testStoreFile)
+	err = store.Open()
+	require.NoError(t, err)
+	defer store.Close()
+	cfg := config2.NewDirectoryConfig()
+	cfg.StaleAgeSec = 1
+
+	// the first start receives the retained TD
+	svcPubSub, _ := pubSubSvc.CapServicePubSub(ctx, directory.ServiceName)
+	svc := service.NewDirectoryService(&cfg, store, svcPubSub)
+	err = svc.Start()
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	readCap, _ := svc.CapReadDirectory(ctx, "user1")
+	tv, err := readCap.GetTD(ctx, publisherID, thing1ID)
+	require.NoError(t, err)
+	readCap.Release()
+	_ = svc.Stop()
+
+	// the restart after the TD has become stale receives the retained TD again
+	time.Sleep(time.Millisecond * 1100)
+	svcPubSub, _ = pubSubSvc.CapServicePubSub(ctx, directory.ServiceName)
+	svc = service.NewDirectoryService(&cfg, store, svcPubSub)
+	err = svc.Start()
+	require.NoError(t, err)
+	defer svc.Stop()
+	time.Sleep(time.Millisecond * 10)
+
+	readCap, _ = svc.CapReadDirectory(ctx, "user1")
+	defer readCap.Release()
+	tv2, err := readCap.GetTD(ctx, publisherID, thing1ID)
+	require.NoError(t, err)
+	assert.Equal(t, tv.Created, tv2.Created)
+	tdList, err := readCap.GetStaleTDs(ctx)
+	assert.NoError(t, err)
+	require.Equal(t, 1, len(tdList))
+	assert.Equal(t, thing1ID, tdList[0].ThingID)
+	logrus.Infof("--- TestRetainedTDAge end ---")
+}
+
+func TestQueryTDs(t *testing.T) {
+	logrus.Infof("--- TestQueryTDs start ---")
+	_ = os.Remove(testStoreFile)
+	const publisher1ID = "urn:pub1"
+	const publisher2ID = "urn:pub2"
+	const thing1ID = "urn:thing1"
+	const thing2ID = "urn:thing2"
+	const thing3ID = "urn:thing3"
+	ctx := context.Background()
+
+	store, stopFunc := startDirectory(testUseCapnp, nil)
+	defer stopFunc()
+	readCap, _ := store.CapReadDirectory(ctx, "test")
+	defer readCap.Release()
+	updateCap, _ := store.CapUpdateDirectory(ctx, "test")
+	defer updateCap.Release()
+
+	td1 := thing.NewTD(thing1ID, "Living room Sensor", vocab.DeviceTypeSensor)
+	td1.AddProperty("temp", vocab.VocabTemperature, "Temperature", vocab.WoTDataTypeNumber, "")
+	td2 := thing.NewTD(thing2ID, "Kitchen sensor", vocab.DeviceTypeSensor)
+	td3 := thing.NewTD(thing3ID, "Kitchen light", vocab.DeviceTypeBinarySwitch)
+	td3.AddProperty("onoff", vocab.VocabSwitch, "On/Off", vocab.WoTDataTypeBool, "")
+	for _, td := range []*thing.TD{td1, td2} {
+		tdDoc, _ := json.Marshal(td)
+		err := updateCap.UpdateTD(ctx, publisher1ID, td.ID, tdDoc, "")
+		require.NoError(t, err)
+	}
+	tdDoc3, _ := json.Marshal(td3)
+	err := updateCap.UpdateTD(ctx, publisher2ID, thing3ID, tdDoc3, "")
+	require.NoError(t, err)
+
+	// an empty query returns all TDs, including that of the directory service itself
+	tvList, err := readCap.QueryTDs(ctx, directory.TDQuery{}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 4, len(tvList))
+
+	// filter on publisher
+	tvList, err = readCap.QueryTDs(ctx, directory.TDQuery{PublisherID: publisher1ID}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(tvList))
+	tvList, err = readCap.QueryTDs(ctx, directory.TDQuery{PublisherID: "urn:pub"}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(tvList))
+
+	// filter on device type and title, case-insensitive
+	tvList, err = readCap.QueryTDs(ctx, directory.TDQuery{DeviceType: vocab.DeviceTypeSensor}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(tvList))
+	tvList, err = readCap.QueryTDs(ctx, directory.TDQuery{Title: "kitchen"}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(tvList))
+	tvList, err = readCap.QueryTDs(ctx,
+		directory.TDQuery{DeviceType: vocab.DeviceTypeSensor, Title: "SENSOR"}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(tvList))
+
+	// filter on property type
+	tvList, err = readCap.QueryTDs(ctx, directory.TDQuery{PropertyType: vocab.VocabTemperature}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(tvList))
+	assert.Equal(t, thing1ID, tvList[0].ThingID)
+
+	// @type can also be an array
+	tdDoc4 := []byte(`{"id":"urn:thing4","title":"Multi sensor","@type":["` + vocab.DeviceTypeSensor +
+		`","custom"],"properties":{"hum":{"@type":["` + vocab.VocabHumidity + `"]}}}`)
+	err = updateCap.UpdateTD(ctx, publisher2ID, "urn:thing4", tdDoc4, "")
+	require.NoError(t, err)
+	tvList, err = readCap.QueryTDs(ctx, directory.TDQuery{DeviceType: vocab.DeviceTypeSensor}, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(tvList))
+	tvList, err = readCap.QueryTDs(ctx, directory.TDQuery{PropertyType: vocab.VocabHumidity}, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(tvList))
+	assert.Equal(t, "urn:thing4", tvList[0].ThingID)
+
+	// limit and offset
+	tvList, err = readCap.QueryTDs(ctx, directory.TDQuery{}, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(tvList))
+	tvList, err = readCap.QueryTDs(ctx, directory.TDQuery{PublisherID: publisher1ID}, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(tvList))
+	assert.Equal(t, thing2ID, tvList[0].ThingID)
+
+	logrus.Infof("--- TestQueryTDs end ---")
+}
 
 // simple performance test update/read, comparing direct vs capnp access
 // TODO: turn into bench test
@@ -319,7 +584,7 @@ func TestPerf(t *testing.T) {
 	t1 := time.Now()
 	for i := 0; i < count; i++ {
 		tdDoc1 := createTDDoc(thing1ID, title1)
-		err := updateCap.UpdateTD(ctx, publisherID, thing1ID, tdDoc1)
+		err := updateCap.UpdateTD(ctx, publisherID, thing1ID, tdDoc1, "")
 		require.NoError(t, err)
 	}
 	d1 := time.Now().Sub(t1)
@@ -12,9 +12,36 @@ import (
 const ServiceName = hubapi.DirectoryServiceName
 const TDBucketName = "td"
 
+// EventNameTDRemoved is the event published by the directory service when a TD is removed.
+// The event value contains the JSON serialized ThingValue of the removed TD without the TD document.
+const EventNameTDRemoved = hubapi.EventNameTDRemoved
+
+// DefaultQueryLimit is the max nr of TDs returned by a query if no limit is given
+const DefaultQueryLimit = 100
+
+// TDQuery holds the filter of a TD query.
+// All filters that are provided must match. An empty query matches all TDs.
+type TDQuery struct {
+	// Only match Things of this publisher, or "" for all publishers
+	PublisherID string `json:"publisherID,omitempty"`
+
+	// Match TDs whose @type is the given device type, eg vocab.DeviceTypeSensor
+	DeviceType string `json:"deviceType,omitempty"`
+
+	// Match TDs whose title contains this text, case-insensitive
+	Title string `json:"title,omitempty"`
+
+	// Match TDs that have a property whose @type is the given type, eg vocab.VocabTemperature
+	PropertyType string `json:"propertyType,omitempty"`
+}
+
 // IDirectory defines the capability to use the thing directory
 type IDirectory interface {
 
+	// Backup writes a point-in-time snapshot of the directory store into the given directory.
+	// The directory is located on the hub. The directory can be used during the backup.
+	Backup(ctx context.Context, directory string) error
+
 	// CapReadDirectory provides the capability to read and query the thing directory
 	CapReadDirectory(ctx context.Context, clientID string) (IReadDirectory, error)
 
@@ -54,10 +81,18 @@ type IReadDirectory interface {
 	// or nil if the publisherID/thingID doesn't exist and an error if the store is not reachable.
 	GetTD(ctx context.Context, publisherID, thingID string) (tv thing.ThingValue, err error)
 
-	// QueryTDs returns the TD's filtered using JSONpath on the TD content
-	// See 'docs/query-tds.md' for examples
-	// disabled as this is not used
-	//QueryTDs(ctx context.Context, jsonPath string, limit int, offset int) (tds []string, err error)
+	// GetStaleTDs returns the TD documents that have not been updated or re-published for
+	// longer than the configured stale age. The Created field holds the time of the last update.
+	// Returns an empty list if no stale age is configured.
+	GetStaleTDs(ctx context.Context) (tvList []thing.ThingValue, err error)
+
+	// QueryTDs returns the TD documents that match the query, in order of publisherID/thingID.
+	// See 'docs/query-tds.md' for examples.
+	//
+	//  query with the filter to apply. An empty query matches all TDs.
+	//  limit is the max nr of TDs to return. 0 for DefaultQueryLimit.
+	//  offset is the nr of matching TDs to skip, for paging through the results.
+	QueryTDs(ctx context.Context, query TDQuery, limit int, offset int) (tvList []thing.ThingValue, err error)
 
 	// Release this capability and allocated resources after its use
 	Release()
@@ -67,12 +102,14 @@ type IReadDirectory interface {
 type IUpdateDirectory interface {
 
 	// RemoveTD removes a TD document from the store
+	// The directory publishes the EventNameTDRemoved event after the TD is removed.
 	RemoveTD(ctx context.Context, publisherID, thingID string) (err error)
 
 	// UpdateTD updates the TD document in the directory
-	// If the TD doesn't exist it will be added.
-	//  tv is a ThingValue object containing the JSON serialized TD document
-	UpdateTD(ctx context.Context, publisherID, thingID string, tdDoc []byte) (err error)
+	// If the TD doesn't exist it will be added. The created time is used to determine if the TD is stale.
+	//  tdDoc is the JSON serialized TD document
+	//  created is the ISO8601 time the TD was published, or "" to use the current time
+	UpdateTD(ctx context.Context, publisherID, thingID string, tdDoc []byte, created string) (err error)
 
 	// Release this capability and allocated resources after its use
 	Release()
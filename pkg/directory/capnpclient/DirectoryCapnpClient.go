@@ -17,6 +17,18 @@ type DirectoryCapnpClient struct {
 	capability hubapi.CapDirectoryService // capnp client of the directory
 }
 
+// Backup writes a snapshot of the directory store into a directory on the hub
+func (cl *DirectoryCapnpClient) Backup(ctx context.Context, directory string) error {
+	method, release := cl.capability.Backup(ctx,
+		func(params hubapi.CapDirectoryService_backup_Params) error {
+			err2 := params.SetDirectory(directory)
+			return err2
+		})
+	defer release()
+	_, err := method.Struct()
+	return err
+}
+
 // CapReadDirectory returns the capability to read the directory
 // The returned release function must be called after the capability is no longer needed.
 func (cl *DirectoryCapnpClient) CapReadDirectory(
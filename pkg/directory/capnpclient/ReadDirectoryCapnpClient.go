@@ -9,6 +9,7 @@ import (
 	"github.com/hiveot/hub/api/go/hubapi"
 	"github.com/hiveot/hub/lib/caphelp"
 	"github.com/hiveot/hub/pkg/directory"
+	"github.com/hiveot/hub/pkg/directory/capserializer"
 )
 
 // ReadDirectoryCapnpClient is the POGS client to reading a directory
@@ -50,6 +51,43 @@ func (cl *ReadDirectoryCapnpClient) GetTD(
 	return tv, err
 }
 
+// GetStaleTDs returns the thing values with TD documents that have not been updated for longer
+// than the configured stale age.
+func (cl *ReadDirectoryCapnpClient) GetStaleTDs(
+	ctx context.Context) (tvList []thing.ThingValue, err error) {
+
+	method, release := cl.capability.GetStaleTDs(ctx, nil)
+	defer release()
+
+	resp, err := method.Struct()
+	if err == nil {
+		tvListCapnp, _ := resp.TvList()
+		tvList = caphelp.UnmarshalThingValueList(tvListCapnp)
+	}
+	return tvList, err
+}
+
+// QueryTDs returns the thing values with TD documents that match the query
+func (cl *ReadDirectoryCapnpClient) QueryTDs(
+	ctx context.Context, query directory.TDQuery, limit int, offset int) (tvList []thing.ThingValue, err error) {
+
+	method, release := cl.capability.QueryTDs(ctx,
+		func(params hubapi.CapReadDirectory_queryTDs_Params) error {
+			params.SetLimit(int32(limit))
+			params.SetOffset(int32(offset))
+			err2 := params.SetQuery(capserializer.MarshalTDQuery(query))
+			return err2
+		})
+	defer release()
+
+	resp, err := method.Struct()
+	if err == nil {
+		tvListCapnp, _ := resp.TvList()
+		tvList = caphelp.UnmarshalThingValueList(tvListCapnp)
+	}
+	return tvList, err
+}
+
 // ListTDReceiver implements the capnp 'server' for receiving callbacks.
 // This is a capnp server for the client side.
 // This implements the CapListCallback interface
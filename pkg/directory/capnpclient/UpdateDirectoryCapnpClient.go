@@ -33,12 +33,15 @@ func (cl *UpdateDirectoryCapnpClient) RemoveTD(ctx context.Context, publisherID,
 
 // UpdateTD updates the TD document in the directory
 // If the TD with the given ID doesn't exist it will be added.
-func (cl *UpdateDirectoryCapnpClient) UpdateTD(ctx context.Context, publisherID, thingID string, tdDoc []byte) (err error) {
+func (cl *UpdateDirectoryCapnpClient) UpdateTD(
+	ctx context.Context, publisherID, thingID string, tdDoc []byte, created string) (err error) {
+
 	method, release := cl.capability.UpdateTD(ctx,
 		func(params hubapi.CapUpdateDirectory_updateTD_Params) error {
 			_ = params.SetPublisherID(publisherID)
 			err2 := params.SetThingID(thingID)
 			_ = params.SetTdDoc(tdDoc)
+			_ = params.SetCreated(created)
 			return err2
 		})
 	defer release()
@@ -17,6 +17,15 @@ type DirectoryServiceCapnpServer struct {
 	svc directory.IDirectory
 }
 
+// Backup writes a snapshot of the directory store into a directory on the hub
+func (capsrv *DirectoryServiceCapnpServer) Backup(
+	ctx context.Context, call hubapi.CapDirectoryService_backup) error {
+
+	directory, _ := call.Args().Directory()
+	err := capsrv.svc.Backup(ctx, directory)
+	return err
+}
+
 func (capsrv *DirectoryServiceCapnpServer) CapReadDirectory(
 	ctx context.Context, call hubapi.CapDirectoryService_capReadDirectory) error {
 
@@ -8,6 +8,7 @@ import (
 	"github.com/hiveot/hub/api/go/hubapi"
 	"github.com/hiveot/hub/lib/caphelp"
 	"github.com/hiveot/hub/pkg/directory"
+	"github.com/hiveot/hub/pkg/directory/capserializer"
 )
 
 // ReadDirectoryCapnpServer provides the capnp RPC server for reading the directory
@@ -45,6 +46,19 @@ func (capsrv *ReadDirectoryCapnpServer) GetTD(ctx context.Context, call hubapi.C
 	return err
 }
 
+func (capsrv *ReadDirectoryCapnpServer) GetStaleTDs(
+	ctx context.Context, call hubapi.CapReadDirectory_getStaleTDs) (err error) {
+
+	tvList, err := capsrv.srv.GetStaleTDs(ctx)
+	if err == nil {
+		res, err2 := call.AllocResults()
+		err = err2
+		tvListCapnp := caphelp.MarshalThingValueList(tvList)
+		_ = res.SetTvList(tvListCapnp)
+	}
+	return err
+}
+
 //func (capsrv *ReadDirectoryCapnpServer) ListTDs(ctx context.Context, call hubapi.CapReadDirectory_listTDs) (err error) {
 //	var tdList []string
 //
@@ -86,26 +100,23 @@ func (capsrv *ReadDirectoryCapnpServer) GetTD(ctx context.Context, call hubapi.C
 //	return err
 //}
 
-//func (capsrv *ReadDirectoryCapnpServer) QueryTDs(ctx context.Context, call hubapi.CapReadDirectory_queryTDs) (err error) {
-//	var jsonPath string
-//	var tdList []string
-//
-//	args := call.Args()
-//	limit := args.Limit()
-//	offset := args.Offset()
-//	jsonPath, err = args.JsonPath()
-//	if err == nil {
-//		tdList, err = capsrv.svc.QueryTDs(ctx, jsonPath, int(limit), int(offset))
-//	}
-//	if err == nil {
-//		res, _ := call.AllocResults()
-//		textList, _ := res.NewTds(int32(len(tdList)))
-//		for i := 0; i < len(tdList); i++ {
-//			textList.Set(i, tdList[i])
-//		}
-//	}
-//	return err
-//}
+func (capsrv *ReadDirectoryCapnpServer) QueryTDs(
+	ctx context.Context, call hubapi.CapReadDirectory_queryTDs) (err error) {
+
+	args := call.Args()
+	queryCapnp, _ := args.Query()
+	query := capserializer.UnmarshalTDQuery(queryCapnp)
+	limit := args.Limit()
+	offset := args.Offset()
+	tvList, err := capsrv.srv.QueryTDs(ctx, query, int(limit), int(offset))
+	if err == nil {
+		res, err2 := call.AllocResults()
+		err = err2
+		tvListCapnp := caphelp.MarshalThingValueList(tvList)
+		_ = res.SetTvList(tvListCapnp)
+	}
+	return err
+}
 
 func (capsrv *ReadDirectoryCapnpServer) Shutdown() {
 	// Release on the client calls capnp Shutdown.
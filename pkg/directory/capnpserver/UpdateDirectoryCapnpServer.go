@@ -37,6 +37,7 @@ func (capsrv *UpdateDirectoryCapnpServer) UpdateTD(
 	publisherID, _ := args.PublisherID()
 	thingID, _ := args.ThingID()
 	tdDoc, _ := args.TdDoc()
-	err = capsrv.srv.UpdateTD(ctx, publisherID, thingID, tdDoc)
+	created, _ := args.Created()
+	err = capsrv.srv.UpdateTD(ctx, publisherID, thingID, tdDoc, created)
 	return err
 }
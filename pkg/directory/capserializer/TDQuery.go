@@ -0,0 +1,32 @@
+package capserializer
+
+import (
+	"capnproto.org/go/capnp/v3"
+
+	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/pkg/directory"
+)
+
+// UnmarshalTDQuery deserializes a TDQuery object from a capnp message
+func UnmarshalTDQuery(queryCapnp hubapi.TDQuery) directory.TDQuery {
+	// errors are ignored. If these fails then there are bigger problems
+	queryPOGS := directory.TDQuery{}
+	queryPOGS.PublisherID, _ = queryCapnp.PublisherID()
+	queryPOGS.DeviceType, _ = queryCapnp.DeviceType()
+	queryPOGS.Title, _ = queryCapnp.Title()
+	queryPOGS.PropertyType, _ = queryCapnp.PropertyType()
+	return queryPOGS
+}
+
+// MarshalTDQuery serializes a TDQuery object to a capnp message
+func MarshalTDQuery(queryPOGS directory.TDQuery) hubapi.TDQuery {
+	// errors are ignored. If these fail then there are bigger problems
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	queryCapnp, _ := hubapi.NewTDQuery(seg)
+
+	_ = queryCapnp.SetPublisherID(queryPOGS.PublisherID)
+	_ = queryCapnp.SetDeviceType(queryPOGS.DeviceType)
+	_ = queryCapnp.SetTitle(queryPOGS.Title)
+	_ = queryCapnp.SetPropertyType(queryPOGS.PropertyType)
+	return queryCapnp
+}
@@ -5,30 +5,24 @@ import (
 	"context"
 	"github.com/hiveot/hub/lib/hubclient"
 	"net"
-	"path/filepath"
 
 	"github.com/hiveot/hub/lib/listener"
 	"github.com/hiveot/hub/lib/svcconfig"
-	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
 	"github.com/hiveot/hub/pkg/directory"
 	"github.com/hiveot/hub/pkg/directory/capnpserver"
+	"github.com/hiveot/hub/pkg/directory/config"
 	"github.com/hiveot/hub/pkg/directory/service"
 	"github.com/hiveot/hub/pkg/pubsub/capnpclient"
 )
 
-// name of the storage file
-const storeFile = "directorystore.json"
-
 // Connect the service
 func main() {
 	var fullUrl = "" // TODO, from config
 
 	ctx := context.Background()
-	serviceID := directory.ServiceName
 	f, clientCert, caCert := svcconfig.SetupFolderConfig(directory.ServiceName)
-
-	// the service uses the bucket store to store directory entries
-	storePath := filepath.Join(f.Stores, directory.ServiceName, storeFile)
+	cfg := config.NewDirectoryConfig()
+	_ = f.LoadConfig(&cfg)
 
 	// Initialize the resolver client and marshallers to access the certificate and pubsub services
 	// This allows them to live anywhere.
@@ -41,14 +35,15 @@ func main() {
 	if pubSubClient == nil {
 		panic("can't connect to pubsub")
 	}
-	svcPubSub, err := pubSubClient.CapServicePubSub(ctx, serviceID)
+	svcPubSub, err := pubSubClient.CapServicePubSub(ctx, cfg.ServiceID)
 
-	store := kvbtree.NewKVStore(directory.ServiceName, storePath)
+	// the service uses the bucket store to store directory entries
+	store := service.NewDirectoryStore(f.Stores, cfg.SyncPolicy)
 	err = store.Open()
 	if err != nil {
 		panic("unable to open the directory store")
 	}
-	svc := service.NewDirectoryService(serviceID, store, svcPubSub)
+	svc := service.NewDirectoryService(&cfg, store, svcPubSub)
 
 	listener.RunService(directory.ServiceName, f.SocketPath,
 		func(ctx context.Context, lis net.Listener) error {
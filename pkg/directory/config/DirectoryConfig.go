@@ -0,0 +1,45 @@
+package config
+
+import (
+	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
+	"github.com/hiveot/hub/pkg/directory"
+)
+
+// DefaultStaleAgeSec is the default age in seconds after which a TD that isn't updated is stale
+const DefaultStaleAgeSec = 30 * 24 * 3600
+
+// DefaultSweepIntervalSec is the default interval in seconds between removal of stale TDs
+const DefaultSweepIntervalSec = 3600
+
+// DirectoryConfig with the directory service configuration
+type DirectoryConfig struct {
+	// instance ID of the service, eg: "directory".
+	ServiceID string `yaml:"serviceID"`
+
+	// Age in seconds since the last update after which a TD is considered stale.
+	// 0 to never flag TDs as stale. Default is DefaultStaleAgeSec. TDs of services are never stale.
+	StaleAgeSec int `yaml:"staleAge"`
+
+	// Remove stale TDs from the directory. Default is false.
+	RemoveStale bool `yaml:"removeStale"`
+
+	// Interval in seconds between removal of stale TDs, when enabled.
+	// Default is DefaultSweepIntervalSec.
+	SweepIntervalSec int `yaml:"sweepIntervalSec"`
+
+	// Policy for syncing the write-ahead log of the store to disk: 'always', 'interval' (default)
+	// or 'never'. See kvbtree.WALSyncAlways for details.
+	SyncPolicy string `yaml:"syncPolicy"`
+}
+
+// NewDirectoryConfig creates a new config with default values
+func NewDirectoryConfig() DirectoryConfig {
+	cfg := DirectoryConfig{
+		ServiceID:        directory.ServiceName,
+		StaleAgeSec:      DefaultStaleAgeSec,
+		RemoveStale:      false,
+		SweepIntervalSec: DefaultSweepIntervalSec,
+		SyncPolicy:       kvbtree.WALSyncInterval,
+	}
+	return cfg
+}
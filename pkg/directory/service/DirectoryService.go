@@ -3,6 +3,10 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"path/filepath"
+	"sync"
+	"time"
+
 	"github.com/hiveot/hub/api/go/hubapi"
 
 	"github.com/sirupsen/logrus"
@@ -10,11 +14,16 @@ import (
 	"github.com/hiveot/hub/api/go/vocab"
 	"github.com/hiveot/hub/lib/thing"
 	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
 	"github.com/hiveot/hub/pkg/pubsub"
 
 	"github.com/hiveot/hub/pkg/directory"
+	"github.com/hiveot/hub/pkg/directory/config"
 )
 
+// DirectoryStoreFile is the name of the directory storage file
+const DirectoryStoreFile = "directorystore.json"
+
 // DirectoryService is a wrapper around the internal bucket store
 // This implements the IDirectory interface
 type DirectoryService struct {
@@ -22,6 +31,27 @@ type DirectoryService struct {
 	store         bucketstore.IBucketStore
 	serviceID     string // thingID of the service instance
 	tdBucketName  string
+	// age after which a TD that isn't updated is stale. 0 to never flag TDs as stale
+	staleAge time.Duration
+	// remove stale TDs in the background
+	removeStale bool
+	// interval between removal of stale TDs
+	sweepInterval time.Duration
+
+	// stop the background job
+	stopChan chan bool
+	// wait for the background job to end
+	jobWG sync.WaitGroup
+}
+
+// Backup writes a snapshot of the directory store into the given directory
+func (svc *DirectoryService) Backup(_ context.Context, directory string) error {
+	logrus.Infof("writing backup of the directory store to '%s'", directory)
+	err := svc.store.Snapshot(directory)
+	if err != nil {
+		logrus.Errorf("backup failed: %s", err)
+	}
+	return err
 }
 
 // CapReadDirectory provides the service to read the directory
@@ -30,7 +60,7 @@ func (svc *DirectoryService) CapReadDirectory(
 
 	logrus.Infof("clientID=%s", clientID)
 	bucket := svc.store.GetBucket(svc.tdBucketName)
-	rd := NewReadDirectory(clientID, bucket)
+	rd := NewReadDirectory(clientID, bucket, svc.staleAge)
 	return rd, nil
 }
 
@@ -39,7 +69,7 @@ func (svc *DirectoryService) CapUpdateDirectory(
 	_ context.Context, clientID string) (directory.IUpdateDirectory, error) {
 	logrus.Infof("clientID=%s", clientID)
 	bucket := svc.store.GetBucket(svc.tdBucketName)
-	ud := NewUpdateDirectory(clientID, bucket)
+	ud := NewUpdateDirectory(clientID, bucket, svc.handleTDRemoved)
 	return ud, nil
 }
 
@@ -53,15 +83,73 @@ func (svc *DirectoryService) createServiceTD() *thing.TD {
 }
 
 func (svc *DirectoryService) handleTDEvent(event thing.ThingValue) {
+	if event.ID == pubsub.EventNameSubscriptionClosed {
+		logrus.Errorf("the pubsub service closed the TD subscription: %s", event.Data)
+		return
+	}
 	ctx := context.Background()
 	// TODO: reserve a capability for this instead of create/release
 	ud, err := svc.CapUpdateDirectory(ctx, directory.ServiceName)
 	if err == nil {
-		err = ud.UpdateTD(ctx, event.PublisherID, event.ThingID, event.Data)
+		err = ud.UpdateTD(ctx, event.PublisherID, event.ThingID, event.Data, event.Created)
 		ud.Release()
 	}
 }
 
+// handleTDRemoved clears the retained TD of the Thing in the pubsub service, so new subscribers
+// don't receive it, and publishes the EventNameTDRemoved event with the removed TD address.
+// The TD document itself is not included.
+func (svc *DirectoryService) handleTDRemoved(tv thing.ThingValue) {
+	logrus.Infof("removed TD of %s/%s", tv.PublisherID, tv.ThingID)
+	if svc.servicePubSub == nil {
+		return
+	}
+	err := svc.servicePubSub.ClearRetained(context.Background(), tv.PublisherID, tv.ThingID, hubapi.EventNameTD)
+	if err != nil {
+		logrus.Errorf("failed clearing the retained TD of %s/%s: %s", tv.PublisherID, tv.ThingID, err)
+	}
+	tv.Data = nil
+	tvJSON, _ := json.Marshal(tv)
+	err = svc.servicePubSub.PubEvent(context.Background(), svc.serviceID, directory.EventNameTDRemoved, tvJSON)
+	if err != nil {
+		logrus.Errorf("failed publishing removal of TD %s/%s: %s", tv.PublisherID, tv.ThingID, err)
+	}
+}
+
+// RemoveStaleTDs removes the TDs that have not been updated for longer than the stale age.
+// A TDRemoved event is published for each removed TD. TDs of services are never stale.
+//
+//	now is the reference time used to determine the age of the TDs
+//
+// This returns the number of removed TDs.
+func (svc *DirectoryService) RemoveStaleTDs(now time.Time) (nrRemoved int, err error) {
+	if svc.staleAge <= 0 {
+		return 0, nil
+	}
+	ctx := context.Background()
+	bucket := svc.store.GetBucket(svc.tdBucketName)
+	staleTDs := findStaleTDs(bucket, now.Add(-svc.staleAge))
+	_ = bucket.Close()
+	if len(staleTDs) == 0 {
+		return 0, nil
+	}
+	ud, err := svc.CapUpdateDirectory(ctx, svc.serviceID)
+	if err != nil {
+		return 0, err
+	}
+	defer ud.Release()
+	for _, tv := range staleTDs {
+		err2 := ud.RemoveTD(ctx, tv.PublisherID, tv.ThingID)
+		if err2 != nil {
+			err = err2
+		} else {
+			nrRemoved++
+		}
+	}
+	logrus.Infof("removed %d stale TDs", nrRemoved)
+	return nrRemoved, err
+}
+
 // Start the directory service and publish the service's own TD
 // This subscribes to pubsub TD events and updates the directory.
 func (svc *DirectoryService) Start() (err error) {
@@ -83,17 +171,44 @@ func (svc *DirectoryService) Start() (err error) {
 			ud, err2 := svc.CapUpdateDirectory(ctx, directory.ServiceName)
 			err = err2
 			if err == nil {
-				err = ud.UpdateTD(ctx, svc.serviceID, myTD.ID, myTDJSON)
+				err = ud.UpdateTD(ctx, svc.serviceID, myTD.ID, myTDJSON, "")
 				ud.Release()
 			}
 		}
 	}
 
+	if err == nil && svc.removeStale && svc.staleAge > 0 && svc.sweepInterval > 0 {
+		svc.startSweeper()
+	}
 	return err
 }
 
+// startSweeper starts the background job that periodically removes stale TDs
+func (svc *DirectoryService) startSweeper() {
+	svc.stopChan = make(chan bool)
+	svc.jobWG.Add(1)
+	go func() {
+		defer svc.jobWG.Done()
+		ticker := time.NewTicker(svc.sweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-svc.stopChan:
+				return
+			case now := <-ticker.C:
+				_, _ = svc.RemoveStaleTDs(now)
+			}
+		}
+	}()
+}
+
 // Stop the service
 func (svc *DirectoryService) Stop() error {
+	if svc.stopChan != nil {
+		close(svc.stopChan)
+		svc.jobWG.Wait()
+		svc.stopChan = nil
+	}
 	if svc.servicePubSub != nil {
 		svc.servicePubSub.Release()
 	}
@@ -102,15 +217,31 @@ func (svc *DirectoryService) Stop() error {
 
 func (svc *DirectoryService) Release() {}
 
+// NewDirectoryStore returns the bucket store used by the directory service
+// The store is not yet opened. It is also used to restore the store from a backup.
+//
+//	storesFolder is the folder that holds the stores of all services
+//	syncPolicy is the policy for syncing the write-ahead log, or "" for the default
+func NewDirectoryStore(storesFolder string, syncPolicy string) bucketstore.IBucketStore {
+	storePath := filepath.Join(storesFolder, directory.ServiceName, DirectoryStoreFile)
+	store := kvbtree.NewKVStore(directory.ServiceName, storePath)
+	if syncPolicy != "" {
+		store.SetSyncPolicy(syncPolicy, 0)
+	}
+	return store
+}
+
 // NewDirectoryService creates a service to access TD documents
 // The servicePubSub is optional and ignored when nil. It is used to subscribe to directory events and
 // will be released on Stop.
 //
-//	serviceID is the instance ID of the service. Default ("") is the directory service name
+//	cfg is the service configuration with the serviceID and stale TD settings.
+//	 The default serviceID ("") is the directory service name.
 //	store is an open bucket store for persisting the directory data.
 //	servicePubSub is the pubsub service
 func NewDirectoryService(
-	serviceID string, store bucketstore.IBucketStore, servicePubSub pubsub.IServicePubSub) *DirectoryService {
+	cfg *config.DirectoryConfig, store bucketstore.IBucketStore, servicePubSub pubsub.IServicePubSub) *DirectoryService {
+	serviceID := cfg.ServiceID
 	if serviceID == "" {
 		serviceID = directory.ServiceName
 	}
@@ -120,6 +251,9 @@ func NewDirectoryService(
 		store:         store,
 		serviceID:     serviceID,
 		tdBucketName:  directory.TDBucketName,
+		staleAge:      time.Duration(cfg.StaleAgeSec) * time.Second,
+		removeStale:   cfg.RemoveStale,
+		sweepInterval: time.Duration(cfg.SweepIntervalSec) * time.Second,
 	}
 	return svc
 }
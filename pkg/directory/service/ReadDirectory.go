@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"strings"
+	"time"
 
+	"github.com/hiveot/hub/api/go/vocab"
 	"github.com/hiveot/hub/lib/thing"
 	"github.com/hiveot/hub/pkg/bucketstore"
 	"github.com/hiveot/hub/pkg/directory"
@@ -16,6 +19,95 @@ type ReadDirectory struct {
 	clientID string
 	// read bucket that holds the TD documents
 	bucket bucketstore.IBucket
+	// age after which a TD that isn't updated is stale. 0 to never flag TDs as stale
+	staleAge time.Duration
+}
+
+// atTypes holds the values of a JSON-LD @type field, which is either a string or an array of strings
+type atTypes []string
+
+// UnmarshalJSON accepts both a string and an array of strings
+func (types *atTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if json.Unmarshal(data, &single) == nil {
+		*types = atTypes{single}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(types))
+}
+
+// has returns true if the given type is one of the types
+func (types atTypes) has(atType string) bool {
+	for _, t := range types {
+		if t == atType {
+			return true
+		}
+	}
+	return false
+}
+
+// tdQueryFields holds the TD fields used in queries.
+// Unmarshalling only these fields is faster than unmarshalling the whole TD.
+type tdQueryFields struct {
+	DeviceType atTypes `json:"@type"`
+	Title      string  `json:"title"`
+	Properties map[string]struct {
+		AtType atTypes `json:"@type"`
+	} `json:"properties"`
+}
+
+// matchTDQuery returns true if the TD document matches the query.
+// The title of the query must be in lower case.
+func matchTDQuery(query directory.TDQuery, tdDoc []byte) bool {
+	if query.DeviceType == "" && query.Title == "" && query.PropertyType == "" {
+		return true
+	}
+	td := tdQueryFields{}
+	err := json.Unmarshal(tdDoc, &td)
+	if err != nil {
+		return false
+	}
+	if query.DeviceType != "" && !td.DeviceType.has(query.DeviceType) {
+		return false
+	}
+	if query.Title != "" && !strings.Contains(strings.ToLower(td.Title), query.Title) {
+		return false
+	}
+	if query.PropertyType != "" {
+		for _, prop := range td.Properties {
+			if prop.AtType.has(query.PropertyType) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// findStaleTDs returns the TDs in the bucket that were last updated before the given time.
+// TDs whose update time cannot be determined are considered stale.
+// TDs of services are not stale, as services publish their TD once when they start.
+func findStaleTDs(bucket bucketstore.IBucket, updatedBefore time.Time) []thing.ThingValue {
+	staleTDs := make([]thing.ThingValue, 0)
+	cursor := bucket.Cursor()
+	defer cursor.Release()
+	for _, raw, valid := cursor.First(); valid; _, raw, valid = cursor.Next() {
+		tv := thing.ThingValue{}
+		err := json.Unmarshal(raw, &tv)
+		if err != nil {
+			continue
+		}
+		updated, err := time.Parse(vocab.ISO8601Format, tv.Created)
+		if err == nil && !updated.Before(updatedBefore) {
+			continue
+		}
+		td := tdQueryFields{}
+		if json.Unmarshal(tv.Data, &td) == nil && td.DeviceType.has(vocab.DeviceTypeService) {
+			continue
+		}
+		staleTDs = append(staleTDs, tv)
+	}
+	return staleTDs
 }
 
 // GetTD returns the TD document for the given Thing ID in JSON format
@@ -38,6 +130,58 @@ func (svc *ReadDirectory) Cursor(_ context.Context) (cursor directory.IDirectory
 
 }
 
+// QueryTDs returns the TDs that match the query.
+// The directory is iterated in order of publisherID/thingID until the limit is reached.
+func (svc *ReadDirectory) QueryTDs(
+	_ context.Context, query directory.TDQuery, limit int, offset int) (tvList []thing.ThingValue, err error) {
+
+	if limit <= 0 {
+		limit = directory.DefaultQueryLimit
+	}
+	query.Title = strings.ToLower(query.Title)
+	tvList = make([]thing.ThingValue, 0)
+	cursor := svc.bucket.Cursor()
+	defer cursor.Release()
+
+	// bucket keys are made of the publisherID / thingID
+	prefix := ""
+	var k string
+	var raw []byte
+	var valid bool
+	if query.PublisherID != "" {
+		prefix = query.PublisherID + "/"
+		k, raw, valid = cursor.Seek(prefix)
+	} else {
+		k, raw, valid = cursor.First()
+	}
+	for ; valid && len(tvList) < limit; k, raw, valid = cursor.Next() {
+		if !strings.HasPrefix(k, prefix) {
+			// past the last Thing of the publisher
+			break
+		}
+		tv := thing.ThingValue{}
+		err = json.Unmarshal(raw, &tv)
+		if err != nil || !matchTDQuery(query, tv.Data) {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		tvList = append(tvList, tv)
+	}
+	return tvList, nil
+}
+
+// GetStaleTDs returns the TDs that have not been updated for longer than the stale age
+func (svc *ReadDirectory) GetStaleTDs(_ context.Context) (tvList []thing.ThingValue, err error) {
+	if svc.staleAge <= 0 {
+		return make([]thing.ThingValue, 0), nil
+	}
+	tvList = findStaleTDs(svc.bucket, time.Now().Add(-svc.staleAge))
+	return tvList, nil
+}
+
 //// ListTDs returns an array of TD documents in JSON text
 //func (srv *DirectoryKVStoreServer) ListTDs(_ context.Context, limit int, offset int) ([]string, error) {
 //	res := make([]string, 0)
@@ -102,12 +246,16 @@ func (svc *ReadDirectory) Release() {
 }
 
 // NewReadDirectory returns the capability to read the directory
-// bucket with the TD documents. Will be closed when done.
-func NewReadDirectory(clientID string, bucket bucketstore.IBucket) directory.IReadDirectory {
+//
+//	clientID is the ID of the client reading the directory
+//	bucket with the TD documents. Will be closed when done.
+//	staleAge is the age after which a TD that isn't updated is stale, 0 to never flag TDs as stale
+func NewReadDirectory(clientID string, bucket bucketstore.IBucket, staleAge time.Duration) directory.IReadDirectory {
 	// logrus.Infof("NewReadDirectory for bucket: ", bucket.ID())
 	svc := &ReadDirectory{
 		clientID: clientID,
 		bucket:   bucket,
+		staleAge: staleAge,
 	}
 	return svc
 }
@@ -24,24 +24,40 @@ type UpdateDirectory struct {
 	clientID string
 	// bucket that holds the TD documents
 	bucket bucketstore.IBucket
+	// handler invoked after a TD is removed, or nil to ignore
+	onRemoved func(tv thing.ThingValue)
 }
 
+// RemoveTD removes the TD from the directory and notifies the removal handler if it existed.
 func (svc *UpdateDirectory) RemoveTD(_ context.Context, publisherID, thingID string) error {
 	logrus.Infof("clientID=%s, thingID=%s", svc.clientID, thingID)
 	thingAddr := publisherID + "/" + thingID
+	// the removed value is passed to the handler. An error means it doesn't exist.
+	raw, _ := svc.bucket.Get(thingAddr)
 	err := svc.bucket.Delete(thingAddr)
+	if err == nil && raw != nil && svc.onRemoved != nil {
+		tv := thing.ThingValue{}
+		_ = json.Unmarshal(raw, &tv)
+		svc.onRemoved(tv)
+	}
 	return err
 }
 
-func (svc *UpdateDirectory) UpdateTD(_ context.Context, publisherID, thingID string, td []byte) error {
+// UpdateTD stores the TD with its publication time.
+// The publication time is kept so a TD that is redelivered by the pubsub service, as it is
+// retained, doesn't reset its stale age.
+func (svc *UpdateDirectory) UpdateTD(_ context.Context, publisherID, thingID string, td []byte, created string) error {
 	//logrus.Infof("clientID=%s, thingID=%s", svc.clientID, thingID)
 
+	if created == "" {
+		created = time.Now().Format(vocab.ISO8601Format)
+	}
 	bucketValue := &thing.ThingValue{
 		PublisherID: publisherID,
 		ThingID:     thingID,
 		ID:          hubapi.EventNameTD,
 		Data:        td,
-		Created:     time.Now().Format(vocab.ISO8601Format),
+		Created:     created,
 	}
 	bucketData, _ := json.Marshal(bucketValue)
 	thingAddr := publisherID + "/" + thingID
@@ -54,11 +70,16 @@ func (svc *UpdateDirectory) Release() {
 }
 
 // NewUpdateDirectory returns the capability to update the directory
-// bucket with the TD documents. Will be closed when done.
-func NewUpdateDirectory(clientID string, bucket bucketstore.IBucket) directory.IUpdateDirectory {
+//
+//	clientID is the ID of the client updating the directory
+//	bucket with the TD documents. Will be closed when done.
+//	onRemoved is invoked with the stored value after a TD is removed, or nil to ignore
+func NewUpdateDirectory(clientID string, bucket bucketstore.IBucket,
+	onRemoved func(tv thing.ThingValue)) directory.IUpdateDirectory {
 	svc := &UpdateDirectory{
-		clientID: clientID,
-		bucket:   bucket,
+		clientID:  clientID,
+		bucket:    bucket,
+		onRemoved: onRemoved,
 	}
 	return svc
 }
@@ -1,6 +1,7 @@
 package history_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,6 +11,8 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
@@ -17,16 +20,23 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/hiveot/hub/api/go/vocab"
 	"github.com/hiveot/hub/pkg/bucketstore"
 	"github.com/hiveot/hub/pkg/bucketstore/cmd"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
 	"github.com/hiveot/hub/pkg/history"
 	"github.com/hiveot/hub/pkg/history/capnpclient"
 	"github.com/hiveot/hub/pkg/history/capnpserver"
 	"github.com/hiveot/hub/pkg/history/config"
+	"github.com/hiveot/hub/pkg/history/exporter"
 	"github.com/hiveot/hub/pkg/history/service"
 	"github.com/hiveot/hub/pkg/pubsub"
+	config2 "github.com/hiveot/hub/pkg/pubsub/config"
+	"github.com/hiveot/hub/pkg/pubsub/core"
 	service2 "github.com/hiveot/hub/pkg/pubsub/service"
 
 	"github.com/hiveot/hub/lib/logging"
@@ -605,7 +615,7 @@ func TestPubSub(t *testing.T) {
 		{Name: vocab.VocabTemperature},
 		{Name: vocab.VocabBatteryLevel},
 	}
-	pubSubSvc := service2.NewPubSubService()
+	pubSubSvc := service2.NewPubSubService(config2.NewPubSubConfig(), nil)
 	err := pubSubSvc.Start()
 	require.NoError(t, err)
 	// get the pubsub client for the history service
@@ -726,3 +736,1246 @@ func TestManageRetention(t *testing.T) {
 	mr.Release()
 	stopFn()
 }
+
+func TestPurgeExpired(t *testing.T) {
+	logrus.Info("--- TestPurgeExpired ---")
+	const publisherID = "device1"
+	const thing0ID = thingIDPrefix + "0"
+	ctx := context.Background()
+
+	svcConfig := config.NewHistoryConfig(testFolder)
+	svcConfig.PurgeIntervalSec = 0
+	svcConfig.Retention = []history.EventRetention{
+		{Name: vocab.VocabTemperature, RetentionDays: 1},
+		{Name: vocab.VocabHumidity},
+	}
+	_ = os.RemoveAll(svcConfig.Directory)
+	store := cmd.NewBucketStore(testFolder, testClientID, HistoryStoreBackend)
+	err := store.Open()
+	require.NoError(t, err)
+	svc := service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+
+	// add an expired and a recent temperature, and an old humidity that doesn't expire
+	oldTime := time.Now().Add(-48 * time.Hour).Format(vocab.ISO8601Format)
+	oldTemp := thing.NewThingValue(publisherID, thing0ID, vocab.VocabTemperature, []byte("10"))
+	oldTemp.Created = oldTime
+	newTemp := thing.NewThingValue(publisherID, thing0ID, vocab.VocabTemperature, []byte("20"))
+	oldHum := thing.NewThingValue(publisherID, thing0ID, vocab.VocabHumidity, []byte("50"))
+	oldHum.Created = oldTime
+
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, false)
+	err = addHist.AddEvents(ctx, []thing.ThingValue{oldTemp, newTemp, oldHum})
+	require.NoError(t, err)
+	addHist.Release()
+
+	// only the old temperature should be removed
+	nrRemoved, err := svc.PurgeExpired()
+	require.NoError(t, err)
+	assert.Equal(t, 1, nrRemoved)
+
+	readHistory, _ := svc.CapReadHistory(ctx, testClientID)
+	cursor := readHistory.GetEventHistory(ctx, publisherID, thing0ID, vocab.VocabTemperature)
+	histEv, valid := cursor.First()
+	require.True(t, valid)
+	assert.Equal(t, newTemp.Data, histEv.Data)
+	_, valid = cursor.Next()
+	assert.False(t, valid)
+	cursor.Release()
+
+	cursor = readHistory.GetEventHistory(ctx, publisherID, thing0ID, vocab.VocabHumidity)
+	_, valid = cursor.First()
+	assert.True(t, valid)
+	cursor.Release()
+	readHistory.Release()
+
+	// a second purge has nothing left to remove
+	nrRemoved, err = svc.PurgeExpired()
+	require.NoError(t, err)
+	assert.Equal(t, 0, nrRemoved)
+
+	err = svc.Stop()
+	assert.NoError(t, err)
+	err = store.Close()
+	assert.NoError(t, err)
+}
+
+func TestCompactHistory(t *testing.T) {
+	logrus.Info("--- TestCompactHistory ---")
+	const publisherID = "device1"
+	const thing0ID = thingIDPrefix + "0"
+	ctx := context.Background()
+
+	svcConfig := config.NewHistoryConfig(testFolder)
+	svcConfig.CompactIntervalSec = 0
+	svcConfig.Retention = []history.EventRetention{
+		{Name: vocab.VocabTemperature, Compaction: []history.CompactionRule{
+			{AfterDays: 90, IntervalSec: 3600},
+			{AfterDays: 7, IntervalSec: 300},
+		}},
+		{Name: vocab.VocabSwitch, Compaction: []history.CompactionRule{{AfterDays: 7, IntervalSec: 3600}}},
+		{Name: vocab.VocabHumidity},
+	}
+	_ = os.RemoveAll(svcConfig.Directory)
+	store := cmd.NewBucketStore(testFolder, testClientID, HistoryStoreBackend)
+	err := store.Open()
+	require.NoError(t, err)
+	svc := service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+
+	// values of 10 and 100 days ago, aligned to the hour
+	base10 := time.Now().Add(-10 * 24 * time.Hour).Truncate(time.Hour)
+	base100 := time.Now().Add(-100 * 24 * time.Hour).Truncate(time.Hour)
+	newValue := func(name string, created time.Time, data string) thing.ThingValue {
+		return thing.ThingValue{PublisherID: publisherID, ThingID: thing0ID, ID: name,
+			Data: []byte(data), Created: created.Format(vocab.ISO8601Format)}
+	}
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, false)
+	err = addHist.AddEvents(ctx, []thing.ThingValue{
+		// 100 days ago: one value per hour
+		newValue(vocab.VocabTemperature, base100, "1"),
+		newValue(vocab.VocabTemperature, base100.Add(20*time.Minute), "3"),
+		newValue(vocab.VocabSwitch, base100.Add(time.Minute), "on"),
+		newValue(vocab.VocabSwitch, base100.Add(2*time.Minute), "off"),
+		newValue(vocab.VocabHumidity, base100.Add(10*time.Minute), "50"),
+		// 10 days ago: one value per 5 minutes
+		newValue(vocab.VocabTemperature, base10, "10"),
+		newValue(vocab.VocabTemperature, base10.Add(time.Minute), "20"),
+		newValue(vocab.VocabTemperature, base10.Add(2*time.Minute), "30"),
+		newValue(vocab.VocabTemperature, base10.Add(5*time.Minute+10*time.Second), "40"),
+		// recent values are not compacted
+		newValue(vocab.VocabTemperature, time.Now().Add(-time.Hour), "5"),
+	})
+	require.NoError(t, err)
+	addHist.Release()
+
+	nrRemoved, err := svc.CompactHistory()
+	require.NoError(t, err)
+	assert.Equal(t, 6, nrRemoved)
+
+	readHistory, _ := svc.CapReadHistory(ctx, testClientID)
+	cursor := readHistory.GetEventHistory(ctx, publisherID, thing0ID, vocab.VocabTemperature)
+	first, valid := cursor.First()
+	require.True(t, valid)
+	rest, _ := cursor.NextN(10)
+	cursor.Release()
+	values := append([]thing.ThingValue{first}, rest...)
+	require.Equal(t, 4, len(values))
+	assert.Equal(t, base100.Format(vocab.ISO8601Format), values[0].Created)
+	assert.Equal(t, "2", string(values[0].Data))
+	assert.Equal(t, base10.Format(vocab.ISO8601Format), values[1].Created)
+	assert.Equal(t, "20", string(values[1].Data))
+	assert.Equal(t, base10.Add(5*time.Minute).Format(vocab.ISO8601Format), values[2].Created)
+	assert.Equal(t, "40", string(values[2].Data))
+	assert.Equal(t, "5", string(values[3].Data))
+
+	// non-numeric values keep the last value
+	cursor = readHistory.GetEventHistory(ctx, publisherID, thing0ID, vocab.VocabSwitch)
+	first, valid = cursor.First()
+	require.True(t, valid)
+	assert.Equal(t, base100.Format(vocab.ISO8601Format), first.Created)
+	assert.Equal(t, "off", string(first.Data))
+	_, valid = cursor.Next()
+	assert.False(t, valid)
+	cursor.Release()
+	readHistory.Release()
+
+	// compacted values are not compacted again
+	nrRemoved, err = svc.CompactHistory()
+	require.NoError(t, err)
+	assert.Equal(t, 0, nrRemoved)
+
+	// a group that spans multiple compaction batches is compacted into one value
+	const nrGroupValues = 2*service.DefaultCompactBatchSize + 500
+	groupStart := base100.Add(2 * time.Hour)
+	groupValues := make([]thing.ThingValue, 0, nrGroupValues)
+	for i := 0; i < nrGroupValues; i++ {
+		groupValues = append(groupValues, newValue(vocab.VocabSwitch,
+			groupStart.Add(time.Duration(i)*time.Millisecond), "v"+strconv.Itoa(i)))
+	}
+	addHist, _ = svc.CapAddHistory(ctx, testClientID, true)
+	err = addHist.AddEvents(ctx, groupValues)
+	require.NoError(t, err)
+	addHist.Release()
+	nrRemoved, err = svc.CompactHistory()
+	require.NoError(t, err)
+	assert.Equal(t, nrGroupValues-1, nrRemoved)
+	readHistory, _ = svc.CapReadHistory(ctx, testClientID)
+	cursor = readHistory.GetEventHistory(ctx, publisherID, thing0ID, vocab.VocabSwitch)
+	last, valid := cursor.Last()
+	require.True(t, valid)
+	assert.Equal(t, groupStart.Format(vocab.ISO8601Format), last.Created)
+	assert.Equal(t, "v"+strconv.Itoa(nrGroupValues-1), string(last.Data))
+	cursor.Release()
+	readHistory.Release()
+
+	// compaction rules must have a positive age and interval
+	mr, _ := svc.CapManageRetention(ctx, testClientID)
+	err = mr.SetEventRetention(ctx, history.EventRetention{
+		Name: vocab.VocabHumidity, Compaction: []history.CompactionRule{{AfterDays: 0, IntervalSec: 60}}})
+	assert.Error(t, err)
+	mr.Release()
+
+	err = svc.Stop()
+	assert.NoError(t, err)
+	err = store.Close()
+	assert.NoError(t, err)
+}
+
+func TestPersistRetention(t *testing.T) {
+	logrus.Info("--- TestPersistRetention ---")
+	ctx := context.Background()
+
+	svcConfig := config.NewHistoryConfig(testFolder)
+	svcConfig.Retention = []history.EventRetention{
+		{Name: vocab.VocabTemperature},
+		{Name: vocab.VocabHumidity},
+	}
+	_ = os.RemoveAll(svcConfig.Directory)
+	store := cmd.NewBucketStore(testFolder, testClientID, HistoryStoreBackend)
+	err := store.Open()
+	require.NoError(t, err)
+	svc := service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+
+	// change the retention of the defaults and add a new one
+	mr, _ := svc.CapManageRetention(ctx, testClientID)
+	err = mr.SetEventRetention(ctx, history.EventRetention{Name: vocab.VocabTemperature, RetentionDays: 5})
+	assert.NoError(t, err)
+	err = mr.RemoveEventRetention(ctx, vocab.VocabHumidity)
+	assert.NoError(t, err)
+	err = mr.SetEventRetention(ctx, history.EventRetention{Name: "blob1"})
+	assert.NoError(t, err)
+	mr.Release()
+	err = svc.Stop()
+	assert.NoError(t, err)
+	err = store.Close()
+	assert.NoError(t, err)
+
+	// after a restart the changes should still apply
+	store = cmd.NewBucketStore(testFolder, testClientID, HistoryStoreBackend)
+	err = store.Open()
+	require.NoError(t, err)
+	svc = service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+
+	mr, _ = svc.CapManageRetention(ctx, testClientID)
+	retList, err := mr.GetEvents(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(retList))
+	ret, err := mr.GetEventRetention(ctx, vocab.VocabTemperature)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, ret.RetentionDays)
+	_, err = mr.GetEventRetention(ctx, vocab.VocabHumidity)
+	assert.Error(t, err)
+	_, err = mr.GetEventRetention(ctx, "blob1")
+	assert.NoError(t, err)
+	mr.Release()
+
+	err = svc.Stop()
+	assert.NoError(t, err)
+	err = store.Close()
+	assert.NoError(t, err)
+}
+
+func TestGetAggregate(t *testing.T) {
+	logrus.Info("--- TestGetAggregate ---")
+	const publisherID = "device1"
+	const thing1ID = "thing1"
+	ctx := context.Background()
+
+	svc, cancelFn := newHistoryService(useTestCapnp)
+	defer cancelFn()
+
+	// three hours of history, starting at the hour
+	startTime := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+	newValue := func(name string, offset time.Duration, data string) thing.ThingValue {
+		return thing.ThingValue{PublisherID: publisherID, ThingID: thing1ID, ID: name,
+			Data: []byte(data), Created: startTime.Add(offset).Format(vocab.ISO8601Format)}
+	}
+	values := []thing.ThingValue{
+		newValue(vocab.VocabTemperature, 10*time.Minute, "10"),
+		newValue(vocab.VocabTemperature, 20*time.Minute, "30"),
+		newValue(vocab.VocabTemperature, 30*time.Minute, "not a number"),
+		newValue(vocab.VocabTemperature, 40*time.Minute, "20"),
+		newValue(vocab.VocabHumidity, 45*time.Minute, "80"),
+		// the second hour has no values
+		newValue(vocab.VocabTemperature, 2*time.Hour+5*time.Minute, `"15.5"`),
+		// outside the range
+		newValue(vocab.VocabTemperature, 3*time.Hour+5*time.Minute, "40"),
+		// names can contain a '/'
+		newValue("sensor/temperature", 15*time.Minute, "5"),
+		newValue("sensor/temperature", 25*time.Minute, "7"),
+	}
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, true)
+	err := addHist.AddEvents(ctx, values)
+	require.NoError(t, err)
+	addHist.Release()
+
+	readHist, _ := svc.CapReadHistory(ctx, testClientID)
+	defer readHist.Release()
+	aggList, err := readHist.GetAggregate(ctx, publisherID, thing1ID, vocab.VocabTemperature,
+		startTime.Format(vocab.ISO8601Format), 3*3600, 3600)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(aggList))
+
+	assert.Equal(t, startTime.Format(vocab.ISO8601Format), aggList[0].StartTime)
+	assert.Equal(t, 3, aggList[0].Count)
+	assert.Equal(t, 10.0, aggList[0].Min)
+	assert.Equal(t, 30.0, aggList[0].Max)
+	assert.Equal(t, 20.0, aggList[0].Avg)
+	assert.Equal(t, 20.0, aggList[0].Last)
+
+	assert.Equal(t, startTime.Add(2*time.Hour).Format(vocab.ISO8601Format), aggList[1].StartTime)
+	assert.Equal(t, 1, aggList[1].Count)
+	assert.Equal(t, 15.5, aggList[1].Avg)
+
+	aggList, err = readHist.GetAggregate(ctx, publisherID, thing1ID, "sensor/temperature",
+		startTime.Format(vocab.ISO8601Format), 3*3600, 3600)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(aggList))
+	assert.Equal(t, 2, aggList[0].Count)
+	assert.Equal(t, 6.0, aggList[0].Avg)
+
+	// invalid intervals
+	_, err = readHist.GetAggregate(ctx, publisherID, thing1ID, vocab.VocabTemperature,
+		startTime.Format(vocab.ISO8601Format), 3600, 0)
+	assert.Error(t, err)
+	_, err = readHist.GetAggregate(ctx, publisherID, thing1ID, vocab.VocabTemperature,
+		startTime.Format(vocab.ISO8601Format), 3600*(history.MaxAggregateIntervals+1), 1)
+	assert.Error(t, err)
+}
+
+func TestRangeCursor(t *testing.T) {
+	logrus.Info("--- TestRangeCursor ---")
+	const publisherID = "device1"
+	const thing1ID = "thing1"
+	const actionSwitch = "switch"
+	ctx := context.Background()
+
+	svc, cancelFn := newHistoryService(useTestCapnp)
+	defer cancelFn()
+
+	startTime := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+	newValue := func(name string, offset time.Duration) thing.ThingValue {
+		return thing.ThingValue{PublisherID: publisherID, ThingID: thing1ID, ID: name,
+			Data: []byte("1"), Created: startTime.Add(offset).Format(vocab.ISO8601Format)}
+	}
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, true)
+	err := addHist.AddEvents(ctx, []thing.ThingValue{
+		newValue(vocab.VocabTemperature, 10*time.Minute),
+		newValue(vocab.VocabTemperature, 20*time.Minute),
+		newValue(vocab.VocabHumidity, 30*time.Minute),
+		newValue(vocab.VocabTemperature, 70*time.Minute),
+		// names can contain a '/'
+		newValue("sensor/temperature", 50*time.Minute),
+		// outside the range
+		newValue(vocab.VocabTemperature, 130*time.Minute),
+	})
+	require.NoError(t, err)
+	err = addHist.AddAction(ctx, newValue(actionSwitch, 40*time.Minute))
+	require.NoError(t, err)
+	addHist.Release()
+
+	readHist, _ := svc.CapReadHistory(ctx, testClientID)
+	defer readHist.Release()
+	historyRange := history.HistoryRange{
+		StartTime: startTime.Format(vocab.ISO8601Format),
+		EndTime:   startTime.Add(2 * time.Hour).Format(vocab.ISO8601Format),
+		Names:     []string{vocab.VocabTemperature},
+		ValueType: history.ValueTypeEvents,
+	}
+	cursor, totalEstimate, err := readHist.GetRangeHistory(ctx, publisherID, thing1ID, historyRange)
+	require.NoError(t, err)
+	assert.Equal(t, 3, totalEstimate)
+
+	// the cursor stops at the end of the range
+	tv, valid := cursor.First()
+	require.True(t, valid)
+	assert.Equal(t, startTime.Add(10*time.Minute).Format(vocab.ISO8601Format), tv.Created)
+	batch, _ := cursor.NextN(10)
+	require.Equal(t, 2, len(batch))
+	assert.Equal(t, startTime.Add(70*time.Minute).Format(vocab.ISO8601Format), batch[1].Created)
+	_, valid = cursor.Next()
+	assert.False(t, valid)
+	tv, valid = cursor.Last()
+	require.True(t, valid)
+	assert.Equal(t, startTime.Add(70*time.Minute).Format(vocab.ISO8601Format), tv.Created)
+	tv, valid = cursor.Prev()
+	require.True(t, valid)
+	assert.Equal(t, vocab.VocabTemperature, tv.ID)
+
+	// seek before the start of the range returns the first value in range
+	tv, valid = cursor.Seek(startTime.Add(-time.Hour).Format(vocab.ISO8601Format))
+	require.True(t, valid)
+	assert.Equal(t, startTime.Add(10*time.Minute).Format(vocab.ISO8601Format), tv.Created)
+	cursor.Release()
+
+	// only actions
+	historyRange.Names = nil
+	historyRange.ValueType = history.ValueTypeActions
+	cursor, totalEstimate, err = readHist.GetRangeHistory(ctx, publisherID, thing1ID, historyRange)
+	require.NoError(t, err)
+	assert.Equal(t, 1, totalEstimate)
+	tv, valid = cursor.First()
+	require.True(t, valid)
+	assert.Equal(t, actionSwitch, tv.ID)
+	cursor.Release()
+
+	// names that contain a '/'
+	historyRange.Names = []string{"sensor/temperature"}
+	historyRange.ValueType = history.ValueTypeEvents
+	cursor, totalEstimate, err = readHist.GetRangeHistory(ctx, publisherID, thing1ID, historyRange)
+	require.NoError(t, err)
+	assert.Equal(t, 1, totalEstimate)
+	tv, valid = cursor.First()
+	require.True(t, valid)
+	assert.Equal(t, "sensor/temperature", tv.ID)
+	cursor.Release()
+
+	// events and actions of all names
+	historyRange.Names = nil
+	historyRange.ValueType = history.ValueTypeAll
+	cursor, totalEstimate, err = readHist.GetRangeHistory(ctx, publisherID, thing1ID, historyRange)
+	require.NoError(t, err)
+	assert.Equal(t, 6, totalEstimate)
+	cursor.Release()
+
+	// invalid ranges
+	historyRange.ValueType = "blob"
+	_, _, err = readHist.GetRangeHistory(ctx, publisherID, thing1ID, historyRange)
+	assert.Error(t, err)
+	historyRange.ValueType = history.ValueTypeAll
+	historyRange.EndTime = historyRange.StartTime
+	_, _, err = readHist.GetRangeHistory(ctx, publisherID, thing1ID, historyRange)
+	assert.Error(t, err)
+}
+
+func TestRangeCursorInvalidKeys(t *testing.T) {
+	logrus.Info("--- TestRangeCursorInvalidKeys ---")
+	const publisherID = "device1"
+	const thing1ID = "thing1"
+	ctx := context.Background()
+
+	_ = os.RemoveAll(testFolder)
+	store := cmd.NewBucketStore(testFolder, testClientID, HistoryStoreBackend)
+	err := store.Open()
+	require.NoError(t, err)
+	defer store.Close()
+	svc := service.NewHistoryService(nil, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+	defer svc.Stop()
+
+	startTime := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+	newValue := func(offset time.Duration) thing.ThingValue {
+		return thing.ThingValue{PublisherID: publisherID, ThingID: thing1ID, ID: vocab.VocabTemperature,
+			Data: []byte("1"), Created: startTime.Add(offset).Format(vocab.ISO8601Format)}
+	}
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, true)
+	err = addHist.AddEvents(ctx, []thing.ThingValue{
+		newValue(10 * time.Minute), newValue(20 * time.Minute), newValue(30 * time.Minute)})
+	require.NoError(t, err)
+	addHist.Release()
+	// invalid keys inside the range and after the last value
+	bucket := store.GetBucket(publisherID + "/" + thing1ID)
+	invalidKey := strconv.FormatInt(startTime.Add(15*time.Minute).UnixMilli(), 10) + "/invalid"
+	err = bucket.SetMultiple(map[string][]byte{invalidKey: []byte("1"), "invalid": []byte("1")})
+	require.NoError(t, err)
+	_ = bucket.Close()
+
+	// iterating back skips the invalid keys like iterating forward
+	readHist, _ := svc.CapReadHistory(ctx, testClientID)
+	defer readHist.Release()
+	cursor, _, err := readHist.GetRangeHistory(ctx, publisherID, thing1ID,
+		history.HistoryRange{StartTime: startTime.Format(vocab.ISO8601Format)})
+	require.NoError(t, err)
+	defer cursor.Release()
+	tv, valid := cursor.Last()
+	require.True(t, valid)
+	assert.Equal(t, startTime.Add(30*time.Minute).Format(vocab.ISO8601Format), tv.Created)
+	values, _ := cursor.PrevN(10)
+	require.Equal(t, 2, len(values))
+	assert.Equal(t, startTime.Add(10*time.Minute).Format(vocab.ISO8601Format), values[1].Created)
+	tv, valid = cursor.First()
+	require.True(t, valid)
+	values, _ = cursor.NextN(10)
+	assert.Equal(t, 2, len(values))
+}
+
+func TestExportImport(t *testing.T) {
+	logrus.Info("--- TestExportImport ---")
+	const publisherID = "device1"
+	const thing1ID = thingIDPrefix + "0"
+	const thing2ID = thingIDPrefix + "1"
+	ctx := context.Background()
+
+	svc, cancelFn := newHistoryService(useTestCapnp)
+	defer cancelFn()
+
+	startTime := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+	newValue := func(thingID string, name string, offset time.Duration, data string) thing.ThingValue {
+		return thing.ThingValue{PublisherID: publisherID, ThingID: thingID, ID: name,
+			Data: []byte(data), Created: startTime.Add(offset).Format(vocab.ISO8601Format)}
+	}
+	values := []thing.ThingValue{
+		newValue(thing1ID, vocab.VocabTemperature, 10*time.Minute, "20.5"),
+		newValue(thing1ID, vocab.VocabHumidity, 20*time.Minute, `"with, comma and "quotes""`),
+		newValue(thing2ID, vocab.VocabTemperature, 30*time.Minute, "21"),
+		// outside the range
+		newValue(thing2ID, vocab.VocabTemperature, 150*time.Minute, "22"),
+	}
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, true)
+	err := addHist.AddEvents(ctx, values)
+	require.NoError(t, err)
+	addHist.Release()
+
+	readHist, _ := svc.CapReadHistory(ctx, testClientID)
+	defer readHist.Release()
+	thingAddrs := []string{publisherID + "/" + thing1ID, publisherID + "/" + thing2ID}
+	historyRange := history.HistoryRange{
+		StartTime: startTime.Format(vocab.ISO8601Format),
+		EndTime:   startTime.Add(2 * time.Hour).Format(vocab.ISO8601Format),
+	}
+
+	for _, format := range []string{exporter.FormatCSV, exporter.FormatNDJSON} {
+		buf := bytes.Buffer{}
+		count, err := exporter.ExportHistory(ctx, readHist, &buf, format, thingAddrs, historyRange)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		// import the export in a new thing
+		exported := strings.ReplaceAll(buf.String(), thing1ID, "imported-"+format)
+		addHist, _ = svc.CapAddHistory(ctx, testClientID, true)
+		nrRead, err := exporter.ImportHistory(ctx, addHist, strings.NewReader(exported), format)
+		addHist.Release()
+		require.NoError(t, err)
+		assert.Equal(t, 3, nrRead)
+
+		cursor := readHist.GetEventHistory(ctx, publisherID, "imported-"+format, "")
+		tv, valid := cursor.First()
+		require.True(t, valid)
+		assert.Equal(t, values[0].Created, tv.Created)
+		assert.Equal(t, values[0].Data, tv.Data)
+		tv, valid = cursor.Next()
+		require.True(t, valid)
+		assert.Equal(t, values[1].ID, tv.ID)
+		assert.Equal(t, values[1].Data, tv.Data)
+		_, valid = cursor.Next()
+		assert.False(t, valid)
+		cursor.Release()
+	}
+
+	// invalid format and content
+	_, err = exporter.ExportHistory(ctx, readHist, &bytes.Buffer{}, "xml", thingAddrs, historyRange)
+	assert.Error(t, err)
+	addHist, _ = svc.CapAddHistory(ctx, testClientID, true)
+	defer addHist.Release()
+	_, err = exporter.ImportHistory(ctx, addHist, strings.NewReader("a,b\n1,2\n"), exporter.FormatCSV)
+	assert.Error(t, err)
+	_, err = exporter.ImportHistory(ctx, addHist, strings.NewReader(`{"id":"temperature"}`), exporter.FormatNDJSON)
+	assert.Error(t, err)
+}
+
+// TestStopAfterFailedStart stops the service after the mongodb backend failed to open
+func TestStopAfterFailedStart(t *testing.T) {
+	logrus.Info("--- TestStopAfterFailedStart ---")
+
+	svcConfig := config.NewHistoryConfig(testFolder)
+	svcConfig.Backend = bucketstore.BackendMongoDB
+	svcConfig.MongoURL = "mongodb://127.0.0.1:1"
+	_ = os.RemoveAll(testFolder)
+	store := cmd.NewBucketStore(testFolder, testClientID, bucketstore.BackendPebble)
+	err := store.Open()
+	require.NoError(t, err)
+	defer store.Close()
+	svc := service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.Error(t, err)
+	assert.NotPanics(t, func() { _ = svc.Stop() })
+}
+
+// TestMongoBackend tests the history service with the mongodb backend.
+// This requires a local mongod and is skipped if none is available.
+func TestMongoBackend(t *testing.T) {
+	logrus.Info("--- TestMongoBackend ---")
+	const publisherID = "device1"
+	const thing1ID = "thing1"
+	ctx := context.Background()
+
+	svcConfig := config.NewHistoryConfig(testFolder)
+	svcConfig.Backend = bucketstore.BackendMongoDB
+	svcConfig.ServiceID = "test-history"
+	mongoClient, err := mongo.Connect(ctx,
+		options.Client().ApplyURI(svcConfig.MongoURL).SetServerSelectionTimeout(time.Second))
+	if err == nil {
+		err = mongoClient.Ping(ctx, nil)
+	}
+	if err != nil {
+		t.Skipf("mongodb is not available on '%s': %s", svcConfig.MongoURL, err)
+	}
+	defer mongoClient.Disconnect(ctx)
+	// start with an empty database
+	err = mongoClient.Database(svcConfig.ServiceID).Drop(ctx)
+	require.NoError(t, err)
+
+	_ = os.RemoveAll(testFolder)
+	store := cmd.NewBucketStore(testFolder, testClientID, bucketstore.BackendPebble)
+	err = store.Open()
+	require.NoError(t, err)
+	defer store.Close()
+	svc := service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+	defer svc.Stop()
+
+	startTime := time.Now().Truncate(time.Hour).Add(-3 * time.Hour)
+	newValue := func(name string, offset time.Duration, data string) thing.ThingValue {
+		return thing.ThingValue{PublisherID: publisherID, ThingID: thing1ID, ID: name,
+			Data: []byte(data), Created: startTime.Add(offset).Format(vocab.ISO8601Format)}
+	}
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, true)
+	err = addHist.AddEvents(ctx, []thing.ThingValue{
+		newValue(vocab.VocabTemperature, 10*time.Minute, "10"),
+		newValue(vocab.VocabTemperature, 20*time.Minute, "20"),
+		newValue(vocab.VocabHumidity, 30*time.Minute, "50"),
+		newValue(vocab.VocabTemperature, 70*time.Minute, "30"),
+		newValue(vocab.VocabTemperature, 130*time.Minute, "40"),
+		// expires with a 1 day retention
+		newValue(vocab.VocabTemperature, -72*time.Hour, "0"),
+	})
+	require.NoError(t, err)
+	err = addHist.AddAction(ctx, newValue("switch", 40*time.Minute, "on"))
+	require.NoError(t, err)
+	addHist.Release()
+
+	// range cursor
+	readHist, _ := svc.CapReadHistory(ctx, testClientID)
+	defer readHist.Release()
+	historyRange := history.HistoryRange{
+		StartTime: startTime.Format(vocab.ISO8601Format),
+		EndTime:   startTime.Add(2 * time.Hour).Format(vocab.ISO8601Format),
+		Names:     []string{vocab.VocabTemperature},
+		ValueType: history.ValueTypeEvents,
+	}
+	cursor, totalEstimate, err := readHist.GetRangeHistory(ctx, publisherID, thing1ID, historyRange)
+	require.NoError(t, err)
+	assert.Equal(t, 3, totalEstimate)
+	tv, valid := cursor.First()
+	require.True(t, valid)
+	assert.Equal(t, startTime.Add(10*time.Minute).Format(vocab.ISO8601Format), tv.Created)
+	assert.Equal(t, "10", string(tv.Data))
+	batch, _ := cursor.NextN(10)
+	require.Equal(t, 2, len(batch))
+	assert.Equal(t, "30", string(batch[1].Data))
+	_, valid = cursor.Next()
+	assert.False(t, valid)
+	tv, valid = cursor.Prev()
+	require.True(t, valid)
+	assert.Equal(t, "20", string(tv.Data))
+	tv, valid = cursor.Seek(startTime.Add(15 * time.Minute).Format(vocab.ISO8601Format))
+	require.True(t, valid)
+	assert.Equal(t, "20", string(tv.Data))
+	cursor.Release()
+
+	// all events and actions
+	cursor = readHist.GetEventHistory(ctx, publisherID, thing1ID, "")
+	tv, valid = cursor.Last()
+	require.True(t, valid)
+	assert.Equal(t, "40", string(tv.Data))
+	cursor.Release()
+	historyRange = history.HistoryRange{ValueType: history.ValueTypeActions}
+	_, totalEstimate, err = readHist.GetRangeHistory(ctx, publisherID, thing1ID, historyRange)
+	require.NoError(t, err)
+	assert.Equal(t, 1, totalEstimate)
+
+	// aggregate per hour
+	aggList, err := readHist.GetAggregate(ctx, publisherID, thing1ID, vocab.VocabTemperature,
+		startTime.Format(vocab.ISO8601Format), 3*3600, 3600)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(aggList))
+	assert.Equal(t, 2, aggList[0].Count)
+	assert.Equal(t, 10.0, aggList[0].Min)
+	assert.Equal(t, 20.0, aggList[0].Max)
+	assert.Equal(t, 15.0, aggList[0].Avg)
+	assert.Equal(t, 20.0, aggList[0].Last)
+
+	// the latest properties are still tracked
+	props := readHist.GetProperties(ctx, publisherID, thing1ID, []string{vocab.VocabHumidity})
+	require.Equal(t, 1, len(props))
+
+	// deleting by time from a time-series collection requires MongoDB 7.0
+	var buildInfo struct {
+		VersionArray []int `bson:"versionArray"`
+	}
+	err = mongoClient.Database("admin").RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&buildInfo)
+	if err == nil && len(buildInfo.VersionArray) > 0 && buildInfo.VersionArray[0] >= 7 {
+		mngRet, _ := svc.CapManageRetention(ctx, testClientID)
+		err = mngRet.SetEventRetention(ctx, history.EventRetention{Name: vocab.VocabTemperature, RetentionDays: 1})
+		require.NoError(t, err)
+		nrRemoved, err := svc.PurgeExpired()
+		require.NoError(t, err)
+		assert.Equal(t, 1, nrRemoved)
+	}
+	_, err = svc.CompactHistory()
+	assert.Error(t, err)
+}
+
+func TestRecordMigration(t *testing.T) {
+	logrus.Info("--- TestRecordMigration ---")
+	const publisherID = "device1"
+	const thing1ID = "thing1"
+	const nrLegacy = 5
+	ctx := context.Background()
+
+	svcConfig := config.NewHistoryConfig(testFolder)
+	_ = os.RemoveAll(svcConfig.Directory)
+	store := cmd.NewBucketStore(testFolder, testClientID, HistoryStoreBackend)
+	err := store.Open()
+	require.NoError(t, err)
+	defer store.Close()
+	svc := service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+	defer svc.Stop()
+
+	// add records using the legacy encoding which only stores the data
+	startTime := time.Now().Truncate(time.Hour).Add(-time.Hour)
+	bucket := store.GetBucket(publisherID + "/" + thing1ID)
+	for i := 0; i < nrLegacy; i++ {
+		key := fmt.Sprintf("%d/%s/e", startTime.Add(time.Duration(i)*time.Minute).UnixMilli(), vocab.VocabTemperature)
+		err = bucket.Set(key, []byte(strconv.Itoa(i)))
+		require.NoError(t, err)
+	}
+	_ = bucket.Close()
+
+	// add a value with nsec precision in another timezone
+	created := startTime.Add(30*time.Minute + 123456789).In(time.FixedZone("", 2*3600))
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, true)
+	err = addHist.AddEvent(ctx, thing.ThingValue{PublisherID: publisherID, ThingID: thing1ID,
+		ID: vocab.VocabHumidity, Data: []byte("50"), Created: created.Format(time.RFC3339Nano)})
+	require.NoError(t, err)
+	addHist.Release()
+
+	// legacy and new records are both readable
+	readHist, _ := svc.CapReadHistory(ctx, testClientID)
+	defer readHist.Release()
+	readAll := func() []thing.ThingValue {
+		cursor := readHist.GetEventHistory(ctx, publisherID, thing1ID, "")
+		defer cursor.Release()
+		tv, valid := cursor.First()
+		require.True(t, valid)
+		batch, _ := cursor.NextN(100)
+		return append([]thing.ThingValue{tv}, batch...)
+	}
+	before := readAll()
+	require.Equal(t, nrLegacy+1, len(before))
+	assert.Equal(t, "0", string(before[0].Data))
+	assert.Equal(t, startTime.Format(vocab.ISO8601Format), before[0].Created)
+	last := before[len(before)-1]
+	assert.Equal(t, "50", string(last.Data))
+	lastCreated, err := time.Parse("2006-01-02T15:04:05.999999999-0700", last.Created)
+	require.NoError(t, err)
+	assert.True(t, created.Equal(lastCreated))
+	_, zoneOffset := lastCreated.Zone()
+	assert.Equal(t, 2*3600, zoneOffset)
+
+	// migrate the legacy records in place
+	nrMigrated, err := svc.MigrateRecords()
+	require.NoError(t, err)
+	assert.Equal(t, nrLegacy, nrMigrated)
+	after := readAll()
+	assert.Equal(t, before, after)
+	nrMigrated, err = svc.MigrateRecords()
+	require.NoError(t, err)
+	assert.Equal(t, 0, nrMigrated)
+
+	// aggregation reads the migrated records
+	aggList, err := readHist.GetAggregate(ctx, publisherID, thing1ID, vocab.VocabTemperature,
+		startTime.Format(vocab.ISO8601Format), 3600, 3600)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(aggList))
+	assert.Equal(t, nrLegacy, aggList[0].Count)
+	assert.Equal(t, 4.0, aggList[0].Max)
+}
+
+func TestChangeFilter(t *testing.T) {
+	logrus.Info("--- TestChangeFilter ---")
+	const publisherID = "device1"
+	const thingID = "thing-changes"
+	ctx := context.Background()
+
+	svcConfig := config.NewHistoryConfig(testFolder)
+	svcConfig.Retention = []history.EventRetention{
+		{Name: vocab.VocabTemperature, Deadband: 0.5, HeartbeatSec: 600},
+		{Name: vocab.VocabSwitch, OnlyOnChange: true},
+		{Name: vocab.VocabHumidity},
+	}
+	_ = os.RemoveAll(svcConfig.Directory)
+	store := cmd.NewBucketStore(testFolder, testClientID, HistoryStoreBackend)
+	err := store.Open()
+	require.NoError(t, err)
+	svc := service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	newValue := func(name string, offset time.Duration, data string) thing.ThingValue {
+		return thing.ThingValue{PublisherID: publisherID, ThingID: thingID, ID: name,
+			Data: []byte(data), Created: base.Add(offset).Format(vocab.ISO8601Format)}
+	}
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, false)
+	err = addHist.AddEvents(ctx, []thing.ThingValue{
+		newValue(vocab.VocabTemperature, 0, "20"),
+		// within the deadband of the last recorded value
+		newValue(vocab.VocabTemperature, 10*time.Second, "20.2"),
+		newValue(vocab.VocabTemperature, 20*time.Second, "20.4"),
+		newValue(vocab.VocabTemperature, 30*time.Second, "20.5"),
+		newValue(vocab.VocabTemperature, 40*time.Second, "20.5"),
+		// heartbeat
+		newValue(vocab.VocabTemperature, 11*time.Minute, "20.5"),
+		newValue(vocab.VocabSwitch, 0, "on"),
+		newValue(vocab.VocabSwitch, time.Second, "on"),
+		newValue(vocab.VocabSwitch, 2*time.Second, "off"),
+		newValue(vocab.VocabSwitch, 3*time.Second, "off"),
+		// no change filter
+		newValue(vocab.VocabHumidity, 0, "50"),
+		newValue(vocab.VocabHumidity, time.Second, "50"),
+	})
+	require.NoError(t, err)
+	// an unchanged value is not an error
+	err = addHist.AddEvent(ctx, newValue(vocab.VocabTemperature, 12*time.Minute, "20.6"))
+	assert.NoError(t, err)
+	addHist.Release()
+
+	readHistory, _ := svc.CapReadHistory(ctx, testClientID)
+	readValues := func(name string) (values []string) {
+		cursor := readHistory.GetEventHistory(ctx, publisherID, thingID, name)
+		for tv, valid := cursor.First(); valid; tv, valid = cursor.Next() {
+			values = append(values, string(tv.Data))
+		}
+		cursor.Release()
+		return values
+	}
+	assert.Equal(t, []string{"20", "20.5", "20.5"}, readValues(vocab.VocabTemperature))
+	assert.Equal(t, []string{"on", "off"}, readValues(vocab.VocabSwitch))
+	assert.Equal(t, []string{"50", "50"}, readValues(vocab.VocabHumidity))
+
+	// the latest property is the last value, also if it isn't recorded
+	props := readHistory.GetProperties(ctx, publisherID, thingID, []string{vocab.VocabTemperature})
+	require.Equal(t, 1, len(props))
+	assert.Equal(t, base.Add(12*time.Minute).Format(vocab.ISO8601Format), props[0].Created)
+	assert.Equal(t, "20.6", string(props[0].Data))
+	readHistory.Release()
+
+	// deadband and heartbeat can't be negative
+	mr, _ := svc.CapManageRetention(ctx, testClientID)
+	err = mr.SetEventRetention(ctx, history.EventRetention{Name: vocab.VocabHumidity, Deadband: -1})
+	assert.Error(t, err)
+	mr.Release()
+
+	err = svc.Stop()
+	assert.NoError(t, err)
+	err = store.Close()
+	assert.NoError(t, err)
+}
+
+func TestFollowHistory(t *testing.T) {
+	logrus.Info("--- TestFollowHistory ---")
+	const publisherID = "device1"
+	const thingID = "thing-follow"
+	const nrValues = 200
+	ctx := context.Background()
+
+	svc, cancelFn := newHistoryService(useTestCapnp)
+	defer cancelFn()
+
+	startTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	newValue := func(name string, i int) thing.ThingValue {
+		return thing.ThingValue{PublisherID: publisherID, ThingID: thingID, ID: name,
+			Data:    []byte(strconv.Itoa(i)),
+			Created: startTime.Add(time.Duration(i) * time.Second).Format(vocab.ISO8601Format)}
+	}
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, true)
+	defer addHist.Release()
+	// history before following
+	for i := 0; i < nrValues/2; i++ {
+		err := addHist.AddEvent(ctx, newValue(vocab.VocabTemperature, i))
+		require.NoError(t, err)
+	}
+	err := addHist.AddEvent(ctx, newValue(vocab.VocabHumidity, nrValues))
+	require.NoError(t, err)
+
+	// values are added while the history is replayed
+	readHist, _ := svc.CapReadHistory(ctx, testClientID)
+	defer readHist.Release()
+	var rxMux sync.Mutex
+	received := make(map[string]int)
+	nrReceived := 0
+	addDone := make(chan bool)
+	go func() {
+		for i := nrValues / 2; i < nrValues; i++ {
+			_ = addHist.AddEvent(ctx, newValue(vocab.VocabTemperature, i))
+		}
+		addDone <- true
+	}()
+	historyRange := history.HistoryRange{
+		StartTime: startTime.Format(vocab.ISO8601Format),
+		Names:     []string{vocab.VocabTemperature},
+		ValueType: history.ValueTypeEvents,
+	}
+	follower, err := readHist.FollowHistory(ctx, publisherID, thingID, historyRange,
+		func(tv thing.ThingValue) {
+			rxMux.Lock()
+			received[string(tv.Data)]++
+			nrReceived++
+			rxMux.Unlock()
+		})
+	require.NoError(t, err)
+	<-addDone
+
+	waitForCount := func(count int) int {
+		for i := 0; i < 100; i++ {
+			rxMux.Lock()
+			n := nrReceived
+			rxMux.Unlock()
+			if n >= count {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		time.Sleep(10 * time.Millisecond)
+		rxMux.Lock()
+		defer rxMux.Unlock()
+		return nrReceived
+	}
+	// each value is received once
+	assert.Equal(t, nrValues, waitForCount(nrValues))
+	rxMux.Lock()
+	assert.Equal(t, nrValues, len(received))
+	for i := 0; i < nrValues; i++ {
+		assert.Equal(t, 1, received[strconv.Itoa(i)])
+	}
+	rxMux.Unlock()
+
+	// no more values after releasing the follower
+	follower.Release()
+	time.Sleep(10 * time.Millisecond)
+	err = addHist.AddEvent(ctx, newValue(vocab.VocabTemperature, nrValues+1))
+	require.NoError(t, err)
+	assert.Equal(t, nrValues, waitForCount(nrValues+1))
+
+	// invalid ranges are rejected
+	_, err = readHist.FollowHistory(ctx, publisherID, thingID,
+		history.HistoryRange{StartTime: "notatime"}, func(tv thing.ThingValue) {})
+	assert.Error(t, err)
+}
+
+// TestFollowOverflow tests the bounded queue of a follower and that its replay excludes compaction
+func TestFollowOverflow(t *testing.T) {
+	logrus.Info("--- TestFollowOverflow ---")
+	const publisherID = "device1"
+	const thingID = "thing-follow"
+	const queueSize = 10
+	ctx := context.Background()
+
+	svcConfig := config.NewHistoryConfig(testFolder)
+	svcConfig.CompactIntervalSec = 0
+	svcConfig.FollowQueueSize = queueSize
+	svcConfig.FollowOverflowPolicy = core.OverflowDropNewest
+	svcConfig.Retention = []history.EventRetention{
+		{Name: vocab.VocabTemperature, Compaction: []history.CompactionRule{{AfterDays: 7, IntervalSec: 3600}}},
+	}
+	_ = os.RemoveAll(svcConfig.Directory)
+	store := cmd.NewBucketStore(testFolder, testClientID, HistoryStoreBackend)
+	err := store.Open()
+	require.NoError(t, err)
+	defer store.Close()
+	svc := service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+	defer svc.Stop()
+
+	// 20 values of 10 days ago in the same hour are compacted into one
+	base10 := time.Now().Add(-10 * 24 * time.Hour).Truncate(time.Hour)
+	newValue := func(created time.Time, i int) thing.ThingValue {
+		return thing.ThingValue{PublisherID: publisherID, ThingID: thingID, ID: vocab.VocabTemperature,
+			Data: []byte(strconv.Itoa(i)), Created: created.Format(vocab.ISO8601Format)}
+	}
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, true)
+	defer addHist.Release()
+	for i := 0; i < 20; i++ {
+		err = addHist.AddEvent(ctx, newValue(base10.Add(time.Duration(i)*time.Minute), i))
+		require.NoError(t, err)
+	}
+
+	// the handler blocks during the replay
+	readHist, _ := svc.CapReadHistory(ctx, testClientID)
+	defer readHist.Release()
+	unblock := make(chan bool)
+	var rxMux sync.Mutex
+	nrReceived := 0
+	follower, err := readHist.FollowHistory(ctx, publisherID, thingID,
+		history.HistoryRange{StartTime: base10.Format(vocab.ISO8601Format)},
+		func(tv thing.ThingValue) {
+			<-unblock
+			rxMux.Lock()
+			nrReceived++
+			rxMux.Unlock()
+		})
+	require.NoError(t, err)
+
+	// the thing isn't compacted while its history is replayed
+	nrRemoved, err := svc.CompactHistory()
+	require.NoError(t, err)
+	assert.Equal(t, 0, nrRemoved)
+
+	// new values beyond the queue size are dropped
+	for i := 20; i < 50; i++ {
+		err = addHist.AddEvent(ctx, newValue(time.Now(), i))
+		require.NoError(t, err)
+	}
+	close(unblock)
+	for i := 0; i < 100; i++ {
+		rxMux.Lock()
+		n := nrReceived
+		rxMux.Unlock()
+		if n >= 20+queueSize {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	rxMux.Lock()
+	assert.Equal(t, 20+queueSize, nrReceived)
+	rxMux.Unlock()
+
+	// after the replay the thing is compacted
+	follower.Release()
+	nrRemoved, err = svc.CompactHistory()
+	require.NoError(t, err)
+	assert.Equal(t, 19, nrRemoved)
+}
+
+func TestFollowDisconnect(t *testing.T) {
+	logrus.Info("--- TestFollowDisconnect ---")
+	const publisherID = "device1"
+	const thingID = "thing-follow"
+	const queueSize = 5
+	ctx := context.Background()
+
+	svcConfig := config.NewHistoryConfig(testFolder)
+	svcConfig.CompactIntervalSec = 0
+	svcConfig.FollowQueueSize = queueSize
+	svcConfig.FollowOverflowPolicy = core.OverflowDisconnect
+	_ = os.RemoveAll(svcConfig.Directory)
+	store := cmd.NewBucketStore(testFolder, testClientID, HistoryStoreBackend)
+	err := store.Open()
+	require.NoError(t, err)
+	defer store.Close()
+	svc := service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+	defer svc.Stop()
+
+	newValue := func(i int) thing.ThingValue {
+		return thing.ThingValue{PublisherID: publisherID, ThingID: thingID, ID: vocab.VocabTemperature,
+			Data: []byte(strconv.Itoa(i)), Created: time.Now().Format(vocab.ISO8601Format)}
+	}
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, true)
+	defer addHist.Release()
+	err = addHist.AddEvent(ctx, newValue(0))
+	require.NoError(t, err)
+
+	// the handler blocks during the replay
+	readHist, _ := svc.CapReadHistory(ctx, testClientID)
+	defer readHist.Release()
+	unblock := make(chan bool)
+	closedChan := make(chan thing.ThingValue, 1)
+	follower, err := readHist.FollowHistory(ctx, publisherID, thingID, history.HistoryRange{},
+		func(tv thing.ThingValue) {
+			<-unblock
+			if tv.ID == history.EventNameFollowerClosed {
+				closedChan <- tv
+			}
+		})
+	require.NoError(t, err)
+	defer follower.Release()
+
+	// overflowing the queue releases the follower and tells its handler
+	for i := 1; i <= queueSize+1; i++ {
+		err = addHist.AddEvent(ctx, newValue(i))
+		require.NoError(t, err)
+	}
+	close(unblock)
+	select {
+	case tv := <-closedChan:
+		assert.Equal(t, history.ServiceName, tv.PublisherID)
+		assert.NotEmpty(t, tv.Data)
+	case <-time.After(time.Second):
+		assert.Fail(t, "follower handler wasn't told it was released")
+	}
+}
+
+func TestFollowAfterClockSetBack(t *testing.T) {
+	logrus.Info("--- TestFollowAfterClockSetBack ---")
+	const publisherID = "device1"
+	const thingID = "thing-follow"
+	ctx := context.Background()
+
+	svcConfig := config.NewHistoryConfig(testFolder)
+	svcConfig.CompactIntervalSec = 0
+	_ = os.RemoveAll(svcConfig.Directory)
+	store := cmd.NewBucketStore(testFolder, testClientID, HistoryStoreBackend)
+	err := store.Open()
+	require.NoError(t, err)
+	defer store.Close()
+	baseTime := time.Now().Add(-time.Minute)
+	newValue := func(i int) thing.ThingValue {
+		created := baseTime.Add(time.Duration(i) * time.Second)
+		return thing.ThingValue{PublisherID: publisherID, ThingID: thingID, ID: vocab.VocabTemperature,
+			Data: []byte(strconv.Itoa(i)), Created: created.Format(vocab.ISO8601Format)}
+	}
+
+	// the previous run had a clock that was an hour ahead, so it used higher sequence numbers
+	aheadSequence := uint64(time.Now().Add(time.Hour).UnixNano())
+	infoBucket := store.GetBucket(service.HistoryInfoBucketName)
+	err = infoBucket.Set(service.LastSequenceKey, []byte(strconv.FormatUint(aheadSequence, 10)))
+	require.NoError(t, err)
+	_ = infoBucket.Close()
+	svc := service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+	addHist, _ := svc.CapAddHistory(ctx, testClientID, true)
+	for i := 0; i < 3; i++ {
+		err = addHist.AddEvent(ctx, newValue(i))
+		require.NoError(t, err)
+	}
+	addHist.Release()
+	err = svc.Stop()
+	require.NoError(t, err)
+
+	// after the restart the values of the previous run are replayed and new values follow
+	svc = service.NewHistoryService(&svcConfig, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+	defer svc.Stop()
+	var rxMux sync.Mutex
+	received := make([]string, 0)
+	readHist, _ := svc.CapReadHistory(ctx, testClientID)
+	defer readHist.Release()
+	follower, err := readHist.FollowHistory(ctx, publisherID, thingID, history.HistoryRange{},
+		func(tv thing.ThingValue) {
+			rxMux.Lock()
+			received = append(received, string(tv.Data))
+			rxMux.Unlock()
+		})
+	require.NoError(t, err)
+	defer follower.Release()
+	addHist, _ = svc.CapAddHistory(ctx, testClientID, true)
+	defer addHist.Release()
+	for i := 3; i < 5; i++ {
+		err = addHist.AddEvent(ctx, newValue(i))
+		require.NoError(t, err)
+	}
+	for i := 0; i < 100; i++ {
+		rxMux.Lock()
+		n := len(received)
+		rxMux.Unlock()
+		if n >= 5 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	rxMux.Lock()
+	assert.Equal(t, []string{"0", "1", "2", "3", "4"}, received)
+	rxMux.Unlock()
+}
+
+func TestBackup(t *testing.T) {
+	logrus.Info("--- TestBackup ---")
+	const count = 1000
+	const publisherID = "device1"
+	const thing1ID = thingIDPrefix + "0"
+	var backupDir = path.Join(testFolder, "backup")
+	ctx := context.Background()
+	svc, closeFn := newHistoryService(useTestCapnp)
+	defer closeFn()
+	_ = addHistory(svc, count, 1, 3600*24*30)
+
+	readHistory, _ := svc.CapReadHistory(ctx, testClientID)
+	cursor := readHistory.GetEventHistory(ctx, publisherID, thing1ID, "")
+	lastItem, valid := cursor.Last()
+	require.True(t, valid)
+	cursor.Release()
+	readHistory.Release()
+
+	err := svc.Backup(ctx, backupDir)
+	require.NoError(t, err)
+
+	// the snapshot can be opened as a history store
+	store := cmd.NewBucketStore(backupDir, testClientID, HistoryStoreBackend)
+	err = store.Open()
+	require.NoError(t, err)
+	defer store.Close()
+	backupSvc := service.NewHistoryService(nil, store, nil)
+	err = backupSvc.Start()
+	require.NoError(t, err)
+	defer backupSvc.Stop()
+	readHistory, _ = backupSvc.CapReadHistory(ctx, testClientID)
+	cursor = readHistory.GetEventHistory(ctx, publisherID, thing1ID, "")
+	lastItem2, valid := cursor.Last()
+	assert.True(t, valid)
+	assert.Equal(t, lastItem.Created, lastItem2.Created)
+	assert.Equal(t, lastItem.Data, lastItem2.Data)
+	cursor.Release()
+	readHistory.Release()
+}
+
+func TestEncryptedHistory(t *testing.T) {
+	logrus.Infof("--- TestEncryptedHistory ---")
+	secretFile := path.Join(testFolder, "history.secret")
+	_ = os.RemoveAll(testFolder)
+	_ = os.MkdirAll(testFolder, 0700)
+	err := encrypted.GenerateSecretFile(secretFile)
+	require.NoError(t, err)
+	cfg := config.NewHistoryConfig(testFolder)
+	cfg.Backend = bucketstore.BackendKVBTree
+	cfg.Encryption.Enabled = true
+	cfg.Encryption.SecretFile = secretFile
+
+	// history works with encrypted values
+	store := service.NewHistoryBucketStore(&cfg)
+	err = store.Open()
+	require.NoError(t, err)
+	svc := service.NewHistoryService(&cfg, store, nil)
+	err = svc.Start()
+	require.NoError(t, err)
+	addHistory(svc, 100, 2, 3600)
+	readHistory, _ := svc.CapReadHistory(context.Background(), testClientID)
+	cursor := readHistory.GetEventHistory(context.Background(), "device1", thingIDPrefix+"0", "")
+	_, valid := cursor.First()
+	assert.True(t, valid)
+	cursor.Release()
+	readHistory.Release()
+	err = svc.Stop()
+	assert.NoError(t, err)
+	_ = store.Close()
+
+	// encrypted keys are refused as history relies on the key order
+	cfg.Encryption.EncryptKeys = true
+	store = service.NewHistoryBucketStore(&cfg)
+	err = store.Open()
+	require.NoError(t, err)
+	svc = service.NewHistoryService(&cfg, store, nil)
+	err = svc.Start()
+	assert.Error(t, err)
+	err = svc.Stop()
+	assert.NoError(t, err)
+	_ = store.Close()
+}
@@ -4,6 +4,11 @@ package history
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/araddon/dateparse"
 
 	"github.com/hiveot/hub/api/go/hubapi"
 	"github.com/hiveot/hub/api/go/vocab"
@@ -13,10 +18,98 @@ import (
 // ServiceName is the name of this service to connect to
 const ServiceName = hubapi.HistoryServiceName
 
+// EventNameFollowerClosed is the ID of the value that is passed to the handler of a history
+// follower when the service releases the follower, for example because the handler is too slow.
+// The data of the value holds the reason. No further values are passed to the handler.
+const EventNameFollowerClosed = "followerClosed"
+
 // EventNameProperties 'properties' is the name of the event that holds a JSON encoded map
 // with one or more property values of a thing.
 const EventNameProperties = vocab.WoTProperties
 
+// MaxAggregateIntervals is the maximum number of intervals of an aggregate query
+const MaxAggregateIntervals = 10000
+
+// AggregateValue holds the aggregate of the numeric values of an event in a time interval
+type AggregateValue struct {
+	// StartTime of the interval in ISO8601 format
+	StartTime string `json:"startTime"`
+	// Count is the number of numeric values in the interval
+	Count int `json:"count"`
+	// Min is the lowest value in the interval
+	Min float64 `json:"min"`
+	// Max is the highest value in the interval
+	Max float64 `json:"max"`
+	// Avg is the average of the values in the interval
+	Avg float64 `json:"avg"`
+	// Last is the most recent value in the interval
+	Last float64 `json:"last"`
+}
+
+// ParseNumber returns the numeric value of event data that is aggregated or compacted.
+// The number can be a JSON number or a JSON string containing a number.
+func ParseNumber(data []byte) (value float64, isNumber bool) {
+	text := strings.Trim(strings.TrimSpace(string(data)), `"`)
+	value, err := strconv.ParseFloat(text, 64)
+	return value, err == nil
+}
+
+// Value types of a history range
+const (
+	// ValueTypeAll includes both events and actions
+	ValueTypeAll = ""
+	// ValueTypeActions only includes actions
+	ValueTypeActions = hubapi.ValueTypeActions
+	// ValueTypeEvents only includes events
+	ValueTypeEvents = hubapi.ValueTypeEvents
+)
+
+// HistoryRange selects the values of a range cursor
+type HistoryRange struct {
+	// StartTime of the range in ISO8601 format, or "" to start at the beginning
+	StartTime string `json:"startTime,omitempty"`
+	// EndTime of the range in ISO8601 format, exclusive, or "" to not limit the end
+	EndTime string `json:"endTime,omitempty"`
+	// Names of the events or actions to include, or nil for all
+	Names []string `json:"names,omitempty"`
+	// ValueType is one of ValueTypeAll, ValueTypeActions or ValueTypeEvents
+	ValueType string `json:"valueType,omitempty"`
+}
+
+// ParseRange validates the history range and returns its start and end in msec since epoch,
+// and the value type as stored by the history backends: "a" for actions, "e" for events, or ""
+// for both. The start and end are 0 if not set.
+func ParseRange(historyRange HistoryRange) (startMsec int64, endMsec int64, valueType string, err error) {
+	if historyRange.StartTime != "" {
+		startTime, err := dateparse.ParseAny(historyRange.StartTime)
+		if err != nil {
+			return 0, 0, "", fmt.Errorf("invalid start time '%s': %w", historyRange.StartTime, err)
+		}
+		startMsec = startTime.UnixMilli()
+	}
+	if historyRange.EndTime != "" {
+		endTime, err := dateparse.ParseAny(historyRange.EndTime)
+		if err != nil {
+			return 0, 0, "", fmt.Errorf("invalid end time '%s': %w", historyRange.EndTime, err)
+		}
+		endMsec = endTime.UnixMilli()
+		if endMsec <= startMsec {
+			return 0, 0, "", fmt.Errorf("end time '%s' is not after the start time", historyRange.EndTime)
+		}
+	}
+	switch historyRange.ValueType {
+	case ValueTypeAll:
+		valueType = ""
+	case ValueTypeActions:
+		valueType = "a"
+	case ValueTypeEvents:
+		valueType = "e"
+	default:
+		return 0, 0, "", fmt.Errorf("invalid value type '%s'", historyRange.ValueType)
+	}
+	return startMsec, endMsec, valueType, nil
+}
+
 // EventRetention with a retention rule for an event (or action)
 type EventRetention struct {
 	// Name of the event to record
@@ -33,11 +126,42 @@ type EventRetention struct {
 
 	// RetentionDays sets the age of the event after which it can be removed. 0 for indefinitely (default)
 	RetentionDays int `yaml:"retentionDays"`
+
+	// Optional, downsampling of event values as they age. Default is no compaction.
+	// For example {7,300},{90,3600} keeps one value per 5 minutes after 7 days and
+	// one value per hour after 90 days.
+	Compaction []CompactionRule `yaml:"compaction"`
+
+	// Optional, only record the event if its value has changed since the last recorded value.
+	OnlyOnChange bool `yaml:"onlyOnChange"`
+
+	// Optional, only record numeric values that differ at least this amount from the last
+	// recorded value. A deadband implies OnlyOnChange. Default is 0 for no deadband.
+	Deadband float64 `yaml:"deadband"`
+
+	// Optional, record the value regardless of change once this many seconds have passed since
+	// the last recorded value. Only used with OnlyOnChange or Deadband. Default is 0 for no heartbeat.
+	HeartbeatSec int `yaml:"heartbeatSec"`
+}
+
+// CompactionRule replaces the event values in each interval by a single value once the
+// values are older than AfterDays. Numeric values are averaged. Otherwise the last value
+// in the interval is kept.
+type CompactionRule struct {
+	// AfterDays is the age in days after which the values are compacted
+	AfterDays int `yaml:"afterDays"`
+	// IntervalSec is the interval in seconds of the compacted values
+	IntervalSec int `yaml:"intervalSec"`
 }
 
 // IHistoryService defines the  capability to access the thing history service
 type IHistoryService interface {
 
+	// Backup writes a point-in-time snapshot of the history store into the given directory.
+	// The directory is located on the hub. History can be added during the backup.
+	// With the mongodb backend only the latest properties and retention rules are included.
+	Backup(ctx context.Context, directory string) error
+
 	// CapAddHistory provides the capability to add to the history of any Thing.
 	// This capability should only be provided to trusted services that capture events from a
 	// message bus or gateways to make them available. Events published on the internal pubsub
@@ -120,6 +244,12 @@ type IHistoryCursor interface {
 	Seek(isoTimestamp string) (thingValue thing.ThingValue, valid bool)
 }
 
+// IHistoryFollower is a live subscription to the history of a Thing
+type IHistoryFollower interface {
+	// Release stops following the history and releases its resources
+	Release()
+}
+
 // IManageRetention defines the capability to manage the events that are recorded
 type IManageRetention interface {
 
@@ -156,12 +286,54 @@ type IReadHistory interface {
 	//  name is the event to read
 	GetEventHistory(ctx context.Context, publisherID string, thingID string, name string) IHistoryCursor
 
+	// GetRangeHistory returns a cursor to iterate a time range of the history of the thing.
+	// The cursor does not move outside the range. First and Last return the first and last
+	// value in the range that matches the names and value type.
+	// The cursor MUST be released after use.
+	//
+	//  publisherID is the ID of the Thing's publisher
+	//  thingID is the ID of the thing whose history to read
+	//  historyRange with the time range, names and value type to iterate
+	//
+	// This returns the cursor and an estimate of the number of values in the range,
+	// or an error if the range is invalid.
+	GetRangeHistory(ctx context.Context, publisherID string, thingID string,
+		historyRange HistoryRange) (cursor IHistoryCursor, totalEstimate int, err error)
+
+	// GetAggregate returns the count, min, max, average and last value of an event for each
+	// interval in the time range. The event values must be numeric. Values that are not numeric
+	// are ignored and intervals without numeric values are omitted.
+	// This returns an error if the time range has more than MaxAggregateIntervals intervals.
+	//
+	//  publisherID is the ID of the Thing's publisher
+	//  thingID is the ID of the thing whose history to read
+	//  name is the event to aggregate
+	//  startTime is the start of the range in ISO8601 format
+	//  duration is the duration of the range in seconds
+	//  interval is the duration of each aggregate in seconds
+	GetAggregate(ctx context.Context, publisherID, thingID string, name string,
+		startTime string, duration int, interval int) ([]AggregateValue, error)
+
 	// GetProperties returns the latest values of a Thing.
+	// This includes event values that are not recorded because of the change filter of their retention rule.
 	//  publisherID is the ID of the Thing's publisher
 	//  thingID is the ID of the thing whose history to read
 	//  names is the list of properties or events to return. Use nil for all known properties.
 	GetProperties(ctx context.Context, publisherID string, thingID string, names []string) []thing.ThingValue
 
+	// FollowHistory replays the history of the thing from the start of the range and then
+	// passes values to the handler as they are added, without gaps or duplicates.
+	// Replayed values are passed in time order, followed by new values in the order they are added.
+	// The end time of the range is not used. The follower MUST be released after use.
+	// If the service releases the follower, an EventNameFollowerClosed value is passed to the handler.
+	//
+	//  publisherID is the ID of the Thing's publisher
+	//  thingID is the ID of the thing whose history to follow
+	//  historyRange with the start time, names and value type to follow
+	//  handler is invoked with each value
+	FollowHistory(ctx context.Context, publisherID string, thingID string,
+		historyRange HistoryRange, handler func(thingValue thing.ThingValue)) (IHistoryFollower, error)
+
 	// Info returns the history storage information of the thing
 	//Info(ctx context.Context) *bucketstore.BucketStoreInfo
 
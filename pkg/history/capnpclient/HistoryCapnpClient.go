@@ -17,6 +17,18 @@ type HistoryServiceCapnpClient struct {
 	capability hubapi.CapHistoryService // capnp client
 }
 
+// Backup writes a snapshot of the history store into a directory on the hub
+func (cl *HistoryServiceCapnpClient) Backup(ctx context.Context, directory string) error {
+	method, release := cl.capability.Backup(ctx,
+		func(params hubapi.CapHistoryService_backup_Params) error {
+			err2 := params.SetDirectory(directory)
+			return err2
+		})
+	defer release()
+	_, err := method.Struct()
+	return err
+}
+
 func (cl *HistoryServiceCapnpClient) CapAddHistory(
 	ctx context.Context, clientID string, ignoreRetention bool) (history.IAddHistory, error) {
 
@@ -0,0 +1,23 @@
+package capnpclient
+
+import (
+	"github.com/hiveot/hub/api/go/hubapi"
+)
+
+// HistoryFollowerCapnpClient provides a POGS wrapper around the capnp client API
+// This implements the IHistoryFollower interface
+type HistoryFollowerCapnpClient struct {
+	capability hubapi.CapHistoryFollower // capnp client
+}
+
+// Release the follower capability. This stops following the history.
+func (cl *HistoryFollowerCapnpClient) Release() {
+	cl.capability.Release()
+}
+
+// NewHistoryFollowerCapnpClient returns a history follower client using the capnp protocol
+// Intended for internal use.
+func NewHistoryFollowerCapnpClient(cap hubapi.CapHistoryFollower) *HistoryFollowerCapnpClient {
+	cl := &HistoryFollowerCapnpClient{capability: cap}
+	return cl
+}
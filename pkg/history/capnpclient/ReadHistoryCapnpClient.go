@@ -7,6 +7,8 @@ import (
 	"github.com/hiveot/hub/lib/caphelp"
 	"github.com/hiveot/hub/lib/thing"
 	"github.com/hiveot/hub/pkg/history"
+	"github.com/hiveot/hub/pkg/history/capserializer"
+	pubsubcapnp "github.com/hiveot/hub/pkg/pubsub/capnpclient"
 )
 
 // ReadHistoryCapnpClient capnp client for making RPC calls to read a thing's history
@@ -33,6 +35,72 @@ func (cl *ReadHistoryCapnpClient) GetEventHistory(
 	return nil
 }
 
+func (cl *ReadHistoryCapnpClient) GetRangeHistory(ctx context.Context, publisherID, thingID string,
+	historyRange history.HistoryRange) (cursor history.IHistoryCursor, totalEstimate int, err error) {
+
+	method, release := cl.capability.GetRangeHistory(ctx,
+		func(params hubapi.CapReadHistory_getRangeHistory_Params) error {
+			_ = params.SetPublisherID(publisherID)
+			_ = params.SetThingID(thingID)
+			err2 := params.SetHistoryRange(capserializer.MarshalHistoryRange(historyRange))
+			return err2
+		})
+	defer release()
+	resp, err := method.Struct()
+	if err == nil {
+		capCursor := resp.Cursor().AddRef()
+		cursor = NewHistoryCursorCapnpClient(capCursor)
+		totalEstimate = int(resp.TotalEstimate())
+	}
+	return cursor, totalEstimate, err
+}
+
+// FollowHistory replays the history of the thing and then passes new values to the handler
+// The handler is invoked from the RPC connection. Release the follower to stop following.
+func (cl *ReadHistoryCapnpClient) FollowHistory(ctx context.Context, publisherID, thingID string,
+	historyRange history.HistoryRange, handler func(thingValue thing.ThingValue)) (
+	follower history.IHistoryFollower, err error) {
+
+	method, release := cl.capability.FollowHistory(ctx,
+		func(params hubapi.CapReadHistory_followHistory_Params) error {
+			_ = params.SetPublisherID(publisherID)
+			_ = params.SetThingID(thingID)
+			_ = params.SetHistoryRange(capserializer.MarshalHistoryRange(historyRange))
+			handlerCapnp := pubsubcapnp.NewSubscriptionHandlerCapnpServer(handler)
+			err2 := params.SetHandler(handlerCapnp)
+			return err2
+		})
+	defer release()
+	resp, err := method.Struct()
+	if err == nil {
+		capFollower := resp.Follower().AddRef()
+		follower = NewHistoryFollowerCapnpClient(capFollower)
+	}
+	return follower, err
+}
+
+func (cl *ReadHistoryCapnpClient) GetAggregate(ctx context.Context, publisherID, thingID string, name string,
+	startTime string, duration int, interval int) (aggList []history.AggregateValue, err error) {
+
+	method, release := cl.capability.GetAggregate(ctx,
+		func(params hubapi.CapReadHistory_getAggregate_Params) error {
+			_ = params.SetPublisherID(publisherID)
+			_ = params.SetThingID(thingID)
+			_ = params.SetName(name)
+			params.SetDuration(int32(duration))
+			params.SetInterval(int32(interval))
+			err2 := params.SetStartTime(startTime)
+			return err2
+		})
+	defer release()
+	resp, err := method.Struct()
+	if err == nil {
+		capAggList, _ := resp.AggList()
+		aggList = capserializer.UnmarshalAggregateList(capAggList)
+	}
+	return aggList, err
+}
+
 func (cl *ReadHistoryCapnpClient) GetProperties(
 	ctx context.Context, publisherID, thingID string, names []string) (values []thing.ThingValue) {
 
@@ -0,0 +1,30 @@
+package capnpserver
+
+import (
+	"github.com/hiveot/hub/pkg/history"
+	pubsubcapnp "github.com/hiveot/hub/pkg/pubsub/capnpserver"
+)
+
+// HistoryFollowerCapnpServer is a capnproto RPC server for following the history store
+// Releasing the capability stops following.
+type HistoryFollowerCapnpServer struct {
+	svc history.IHistoryFollower
+	// the client's callback handler
+	handler *pubsubcapnp.SubscriptionHandlerCapnpClient
+}
+
+func (capsrv *HistoryFollowerCapnpServer) Shutdown() {
+	// Release on the client calls capnp Shutdown.
+	// Pass this to the server to stop following, then release the callback.
+	capsrv.svc.Release()
+	capsrv.handler.Release()
+}
+
+func NewHistoryFollowerCapnpServer(follower history.IHistoryFollower,
+	handler *pubsubcapnp.SubscriptionHandlerCapnpClient) *HistoryFollowerCapnpServer {
+	followerCapnpServer := &HistoryFollowerCapnpServer{
+		svc:     follower,
+		handler: handler,
+	}
+	return followerCapnpServer
+}
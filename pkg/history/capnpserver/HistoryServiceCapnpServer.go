@@ -18,6 +18,15 @@ type HistoryServiceCapnpServer struct {
 	svc history.IHistoryService
 }
 
+// Backup writes a snapshot of the history store into a directory on the hub
+func (capsrv *HistoryServiceCapnpServer) Backup(
+	ctx context.Context, call hubapi.CapHistoryService_backup) error {
+
+	directory, _ := call.Args().Directory()
+	err := capsrv.svc.Backup(ctx, directory)
+	return err
+}
+
 func (capsrv *HistoryServiceCapnpServer) CapAddHistory(
 	ctx context.Context, call hubapi.CapHistoryService_capAddHistory) error {
 	// create a client instance for adding history
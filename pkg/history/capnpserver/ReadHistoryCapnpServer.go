@@ -6,6 +6,8 @@ import (
 	"github.com/hiveot/hub/api/go/hubapi"
 	"github.com/hiveot/hub/lib/caphelp"
 	"github.com/hiveot/hub/pkg/history"
+	"github.com/hiveot/hub/pkg/history/capserializer"
+	pubsubcapnp "github.com/hiveot/hub/pkg/pubsub/capnpserver"
 )
 
 // ReadHistoryCapnpServer is a capnproto server adapter for the history server
@@ -36,6 +38,78 @@ func (capsrv *ReadHistoryCapnpServer) GetEventHistory(
 	return err
 }
 
+// GetRangeHistory returns a cursor to iterate a time range of the history of the thing
+// The cursor MUST be released after use.
+func (capsrv *ReadHistoryCapnpServer) GetRangeHistory(
+	ctx context.Context, call hubapi.CapReadHistory_getRangeHistory) error {
+
+	args := call.Args()
+	publisherID, _ := args.PublisherID()
+	thingID, _ := args.ThingID()
+	capRange, _ := args.HistoryRange()
+	historyRange := capserializer.UnmarshalHistoryRange(capRange)
+	cursor, totalEstimate, err := capsrv.svc.GetRangeHistory(ctx, publisherID, thingID, historyRange)
+	if err != nil {
+		return err
+	}
+	cursorSrv := NewHistoryCursorCapnpServer(cursor)
+	capnpCursorServer := hubapi.CapHistoryCursor_ServerToClient(cursorSrv)
+
+	res, err := call.AllocResults()
+	if err == nil {
+		res.SetTotalEstimate(int32(totalEstimate))
+		err = res.SetCursor(capnpCursorServer)
+	}
+	return err
+}
+
+// FollowHistory replays the history of the thing and then passes new values to the client's handler
+// Releasing the follower stops following.
+func (capsrv *ReadHistoryCapnpServer) FollowHistory(
+	ctx context.Context, call hubapi.CapReadHistory_followHistory) error {
+
+	args := call.Args()
+	publisherID, _ := args.PublisherID()
+	thingID, _ := args.ThingID()
+	capRange, _ := args.HistoryRange()
+	historyRange := capserializer.UnmarshalHistoryRange(capRange)
+	handler := pubsubcapnp.NewSubscriptionHandlerCapnpClient(args.Handler().AddRef())
+	follower, err := capsrv.svc.FollowHistory(ctx, publisherID, thingID, historyRange, handler.HandleValue)
+	if err != nil {
+		handler.Release()
+		return err
+	}
+	followerSrv := NewHistoryFollowerCapnpServer(follower, handler)
+	capnpFollowerServer := hubapi.CapHistoryFollower_ServerToClient(followerSrv)
+
+	res, err := call.AllocResults()
+	if err == nil {
+		err = res.SetFollower(capnpFollowerServer)
+	}
+	return err
+}
+
+// GetAggregate returns the aggregate of the numeric values of an event for each interval
+func (capsrv *ReadHistoryCapnpServer) GetAggregate(
+	ctx context.Context, call hubapi.CapReadHistory_getAggregate) error {
+
+	args := call.Args()
+	publisherID, _ := args.PublisherID()
+	thingID, _ := args.ThingID()
+	eventName, _ := args.Name()
+	startTime, _ := args.StartTime()
+	aggList, err := capsrv.svc.GetAggregate(ctx, publisherID, thingID, eventName,
+		startTime, int(args.Duration()), int(args.Interval()))
+	if err == nil {
+		res, err2 := call.AllocResults()
+		err = err2
+		if err == nil {
+			err = res.SetAggList(capserializer.MarshalAggregateList(aggList))
+		}
+	}
+	return err
+}
+
 // GetProperties returns the most recent property and event values of the Thing
 //
 //	names is the list of properties to return. Use "" to return all known properties.
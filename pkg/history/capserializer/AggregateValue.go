@@ -0,0 +1,53 @@
+package capserializer
+
+import (
+	"capnproto.org/go/capnp/v3"
+
+	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/pkg/history"
+)
+
+func MarshalAggregateList(aggList []history.AggregateValue) hubapi.AggregateValue_List {
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	capAggList, _ := hubapi.NewAggregateValue_List(seg, int32(len(aggList)))
+
+	for i := 0; i < len(aggList); i++ {
+		capAgg := MarshalAggregateValue(aggList[i])
+		_ = capAggList.Set(i, capAgg)
+	}
+	return capAggList
+}
+
+func MarshalAggregateValue(agg history.AggregateValue) (capAgg hubapi.AggregateValue) {
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	capAgg, _ = hubapi.NewAggregateValue(seg)
+	_ = capAgg.SetStartTime(agg.StartTime)
+	capAgg.SetCount(int32(agg.Count))
+	capAgg.SetMin(agg.Min)
+	capAgg.SetMax(agg.Max)
+	capAgg.SetAvg(agg.Avg)
+	capAgg.SetLast(agg.Last)
+	return capAgg
+}
+
+func UnmarshalAggregateList(capAggList hubapi.AggregateValue_List) []history.AggregateValue {
+	aggList := make([]history.AggregateValue, 0, capAggList.Len())
+	for i := 0; i < capAggList.Len(); i++ {
+		agg := UnmarshalAggregateValue(capAggList.At(i))
+		aggList = append(aggList, agg)
+	}
+	return aggList
+}
+
+func UnmarshalAggregateValue(capAgg hubapi.AggregateValue) history.AggregateValue {
+	startTime, _ := capAgg.StartTime()
+	agg := history.AggregateValue{
+		StartTime: startTime,
+		Count:     int(capAgg.Count()),
+		Min:       capAgg.Min(),
+		Max:       capAgg.Max(),
+		Avg:       capAgg.Avg(),
+		Last:      capAgg.Last(),
+	}
+	return agg
+}
@@ -29,9 +29,24 @@ func MarshalEventRetention(retention history.EventRetention) (capRet hubapi.Even
 	_ = capRet.SetPublishers(caphelp.MarshalStringList(retention.Publishers))
 	_ = capRet.SetThings(caphelp.MarshalStringList(retention.Things))
 	capRet.SetRetentionDays(int32(retention.RetentionDays))
+	_ = capRet.SetCompaction(MarshalCompactionList(retention.Compaction))
+	capRet.SetOnlyOnChange(retention.OnlyOnChange)
+	capRet.SetDeadband(retention.Deadband)
+	capRet.SetHeartbeatSec(int32(retention.HeartbeatSec))
 	return capRet
 }
 
+func MarshalCompactionList(rules []history.CompactionRule) hubapi.CompactionRule_List {
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	capRules, _ := hubapi.NewCompactionRule_List(seg, int32(len(rules)))
+	for i, rule := range rules {
+		capRule := capRules.At(i)
+		capRule.SetAfterDays(int32(rule.AfterDays))
+		capRule.SetIntervalSec(int32(rule.IntervalSec))
+	}
+	return capRules
+}
+
 func UnmarshalRetList(capRetList hubapi.EventRetention_List) []history.EventRetention {
 	retList := make([]history.EventRetention, 0, capRetList.Len())
 	for i := 0; i < capRetList.Len(); i++ {
@@ -47,6 +62,7 @@ func UnmarshalEventRetention(capRet hubapi.EventRetention) history.EventRetentio
 	capPub, _ := capRet.Publishers()
 	capThings, _ := capRet.Things()
 	capExcludes, _ := capRet.Exclude()
+	capCompaction, _ := capRet.Compaction()
 
 	ret := history.EventRetention{
 		Name:          name,
@@ -54,6 +70,25 @@ func UnmarshalEventRetention(capRet hubapi.EventRetention) history.EventRetentio
 		Things:        caphelp.UnmarshalStringList(capThings),
 		Exclude:       caphelp.UnmarshalStringList(capExcludes),
 		RetentionDays: int(capRet.RetentionDays()),
+		Compaction:    UnmarshalCompactionList(capCompaction),
+		OnlyOnChange:  capRet.OnlyOnChange(),
+		Deadband:      capRet.Deadband(),
+		HeartbeatSec:  int(capRet.HeartbeatSec()),
 	}
 	return ret
 }
+
+func UnmarshalCompactionList(capRules hubapi.CompactionRule_List) []history.CompactionRule {
+	if capRules.Len() == 0 {
+		return nil
+	}
+	rules := make([]history.CompactionRule, 0, capRules.Len())
+	for i := 0; i < capRules.Len(); i++ {
+		capRule := capRules.At(i)
+		rules = append(rules, history.CompactionRule{
+			AfterDays:   int(capRule.AfterDays()),
+			IntervalSec: int(capRule.IntervalSec()),
+		})
+	}
+	return rules
+}
@@ -0,0 +1,33 @@
+package capserializer
+
+import (
+	"capnproto.org/go/capnp/v3"
+
+	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/lib/caphelp"
+	"github.com/hiveot/hub/pkg/history"
+)
+
+func MarshalHistoryRange(historyRange history.HistoryRange) (capRange hubapi.HistoryRange) {
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	capRange, _ = hubapi.NewHistoryRange(seg)
+	_ = capRange.SetStartTime(historyRange.StartTime)
+	_ = capRange.SetEndTime(historyRange.EndTime)
+	_ = capRange.SetNames(caphelp.MarshalStringList(historyRange.Names))
+	_ = capRange.SetValueType(historyRange.ValueType)
+	return capRange
+}
+
+func UnmarshalHistoryRange(capRange hubapi.HistoryRange) history.HistoryRange {
+	startTime, _ := capRange.StartTime()
+	endTime, _ := capRange.EndTime()
+	capNames, _ := capRange.Names()
+	valueType, _ := capRange.ValueType()
+	historyRange := history.HistoryRange{
+		StartTime: startTime,
+		EndTime:   endTime,
+		Names:     caphelp.UnmarshalStringList(capNames),
+		ValueType: valueType,
+	}
+	return historyRange
+}
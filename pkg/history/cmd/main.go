@@ -9,7 +9,6 @@ import (
 	"github.com/hiveot/hub/lib/hubclient"
 	"github.com/hiveot/hub/lib/listener"
 	"github.com/hiveot/hub/lib/svcconfig"
-	"github.com/hiveot/hub/pkg/bucketstore/cmd"
 	"github.com/hiveot/hub/pkg/history"
 	"github.com/hiveot/hub/pkg/history/capnpserver"
 	"github.com/hiveot/hub/pkg/history/config"
@@ -24,6 +23,7 @@ func main() {
 	f, clientCert, caCert := svcconfig.SetupFolderConfig(history.ServiceName)
 	cfg := config.NewHistoryConfig(f.Stores)
 	_ = f.LoadConfig(&cfg)
+	cfg.Encryption.ResolveSecretFile(f.Certs)
 
 	// the service receives the events to store from pubsub. To obtain the pubsub capability
 	// connect to the resolver or gateway service.
@@ -37,7 +37,8 @@ func main() {
 	}
 
 	// the service uses the bucket store to store history
-	store := cmd.NewBucketStore(cfg.Directory, cfg.ServiceID, cfg.Backend)
+	// the mongodb backend only uses the bucket store for the latest properties and retention rules
+	store := service.NewHistoryBucketStore(&cfg)
 	err = store.Open()
 	if err != nil {
 		logrus.Panic("can't open history bucket store")
@@ -2,31 +2,84 @@ package config
 
 import (
 	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
+	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
 	"github.com/hiveot/hub/pkg/history"
+	"github.com/hiveot/hub/pkg/pubsub/core"
 )
 
+// DefaultPurgeIntervalSec is the default interval in seconds between removal of expired history values
+const DefaultPurgeIntervalSec = 3600
+
+// DefaultCompactIntervalSec is the default interval in seconds between compaction of history values
+const DefaultCompactIntervalSec = 3600
+
+// DefaultFollowQueueSize is the default maximum number of values queued for a follower of the history
+const DefaultFollowQueueSize = 1000
+
+// DefaultMongoURL is the URL of a local mongodb server, used with the mongodb backend
+const DefaultMongoURL = "mongodb://localhost:27017"
+
 // HistoryConfig with history store database configuration
 type HistoryConfig struct {
 	// Bucket store ID of the backend to store
 	// kvbtree, pebble (default), bbolt. See IBucketStore for details.
+	// mongodb stores the history in a MongoDB time-series collection. The latest properties
+	// and retention rules are then kept in a pebble store in Directory.
 	Backend string `yaml:"backend"`
 
+	// URL of the MongoDB server when using the mongodb backend. Default is DefaultMongoURL.
+	// The ServiceID is used as the database name.
+	MongoURL string `yaml:"mongoURL"`
+
 	// Bucket store location where to store the history
 	Directory string `yaml:"directory"`
 
+	// Policy for syncing the write-ahead log of the kvbtree backend to disk: 'always',
+	// 'interval' (default) or 'never'. See kvbtree.WALSyncAlways for details.
+	SyncPolicy string `yaml:"syncPolicy"`
+
 	// instance ID of the service, eg: "history".
 	ServiceID string `yaml:"serviceID"`
 
 	// Default retention from config by event name
 	Retention []history.EventRetention `yaml:"retention"`
+
+	// Interval in seconds between removal of values that exceed their retention days.
+	// 0 to disable. Default is DefaultPurgeIntervalSec.
+	PurgeIntervalSec int `yaml:"purgeIntervalSec"`
+
+	// Interval in seconds between compaction of values using the compaction rules of their
+	// event retention. 0 to disable. Default is DefaultCompactIntervalSec.
+	CompactIntervalSec int `yaml:"compactIntervalSec"`
+
+	// Max nr of new values queued for each follower of the history while it replays or handles
+	// values. Default is DefaultFollowQueueSize.
+	FollowQueueSize int `yaml:"followQueueSize"`
+
+	// Policy when the queue of a follower is full: dropOldest (default), dropNewest or disconnect.
+	// See core.OverflowXyz of the pubsub service for details.
+	FollowOverflowPolicy string `yaml:"followOverflowPolicy"`
+
+	// Encryption at rest of the bucket store. Default is disabled.
+	// The time-series collection of the mongodb backend is not encrypted.
+	// EncryptKeys is not supported as history queries rely on iterating keys in timestamp order.
+	Encryption encrypted.EncryptionConfig `yaml:"encryption"`
 }
 
 // NewHistoryConfig creates a new config with default values
 func NewHistoryConfig(storeDirectory string) HistoryConfig {
 	cfg := HistoryConfig{
-		Backend:   bucketstore.BackendPebble,
-		Directory: storeDirectory,
-		ServiceID: history.ServiceName,
+		Backend:            bucketstore.BackendPebble,
+		Directory:          storeDirectory,
+		SyncPolicy:         kvbtree.WALSyncInterval,
+		MongoURL:           DefaultMongoURL,
+		ServiceID:          history.ServiceName,
+		PurgeIntervalSec:   DefaultPurgeIntervalSec,
+		CompactIntervalSec: DefaultCompactIntervalSec,
+
+		FollowQueueSize:      DefaultFollowQueueSize,
+		FollowOverflowPolicy: core.OverflowDropOldest,
 	}
 	return cfg
 }
@@ -0,0 +1,134 @@
+// Package exporter exports and imports the Thing event history in CSV or JSON Lines format
+package exporter
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/history"
+)
+
+// Supported export formats
+const (
+	// FormatCSV writes a header line followed by a line per value with the CSVHeader columns
+	FormatCSV = "csv"
+	// FormatNDJSON writes a JSON encoded ThingValue per line
+	FormatNDJSON = "ndjson"
+)
+
+// CSVHeader holds the columns of the CSV format
+var CSVHeader = []string{"publisherID", "thingID", "name", "created", "data"}
+
+// DefaultBatchSize is the number of values that are read or added in a single call
+const DefaultBatchSize = 1000
+
+// ValidateFormat returns an error if the format is not supported
+func ValidateFormat(format string) error {
+	if format != FormatCSV && format != FormatNDJSON {
+		return fmt.Errorf("unsupported format '%s'. Expected '%s' or '%s'", format, FormatCSV, FormatNDJSON)
+	}
+	return nil
+}
+
+// valueWriter writes values in the export format
+type valueWriter struct {
+	csvWriter   *csv.Writer
+	jsonEncoder *json.Encoder
+}
+
+// write a single value
+func (vw *valueWriter) write(tv thing.ThingValue) error {
+	if vw.csvWriter != nil {
+		return vw.csvWriter.Write([]string{tv.PublisherID, tv.ThingID, tv.ID, tv.Created, string(tv.Data)})
+	}
+	// the encoder ends each value with a newline
+	return vw.jsonEncoder.Encode(tv)
+}
+
+// flush the buffered output
+func (vw *valueWriter) flush() error {
+	if vw.csvWriter != nil {
+		vw.csvWriter.Flush()
+		return vw.csvWriter.Error()
+	}
+	return nil
+}
+
+// exportThing writes the event history of a thing in the time range
+func exportThing(ctx context.Context, readHist history.IReadHistory, vw *valueWriter,
+	publisherID, thingID string, historyRange history.HistoryRange) (count int, err error) {
+
+	cursor, _, err := readHist.GetRangeHistory(ctx, publisherID, thingID, historyRange)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Release()
+	tv, valid := cursor.First()
+	if !valid {
+		return 0, nil
+	}
+	if err = vw.write(tv); err != nil {
+		return 0, err
+	}
+	count++
+	for itemsRemaining := true; itemsRemaining; {
+		var batch []thing.ThingValue
+		batch, itemsRemaining = cursor.NextN(DefaultBatchSize)
+		for _, tv = range batch {
+			if err = vw.write(tv); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if err = vw.flush(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// ExportHistory writes the event history of things in the given time range to the writer.
+// Only events are exported as actions cannot be imported through AddEvents.
+//
+//	readHist is the capability used to read the history
+//	w is the writer of the output
+//	format is FormatCSV or FormatNDJSON
+//	thingAddrs with the addresses of the things to export, eg publisherID/thingID
+//	historyRange with the time range and optional event names to export. The value type is ignored.
+//
+// This returns the number of exported values.
+func ExportHistory(ctx context.Context, readHist history.IReadHistory, w io.Writer,
+	format string, thingAddrs []string, historyRange history.HistoryRange) (count int, err error) {
+
+	if err = ValidateFormat(format); err != nil {
+		return 0, err
+	}
+	historyRange.ValueType = history.ValueTypeEvents
+	vw := &valueWriter{}
+	if format == FormatCSV {
+		vw.csvWriter = csv.NewWriter(w)
+		if err = vw.csvWriter.Write(CSVHeader); err != nil {
+			return 0, err
+		}
+	} else {
+		vw.jsonEncoder = json.NewEncoder(w)
+	}
+	for _, thingAddr := range thingAddrs {
+		parts := strings.Split(thingAddr, "/")
+		if len(parts) != 2 {
+			return count, fmt.Errorf("invalid thing address '%s'", thingAddr)
+		}
+		thingCount, err2 := exportThing(ctx, readHist, vw, parts[0], parts[1], historyRange)
+		count += thingCount
+		if err2 != nil {
+			return count, fmt.Errorf("export of '%s' failed: %w", thingAddr, err2)
+		}
+	}
+	err = vw.flush()
+	return count, err
+}
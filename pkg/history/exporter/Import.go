@@ -0,0 +1,120 @@
+package exporter
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/history"
+)
+
+// valueReader reads values in the import format
+type valueReader struct {
+	csvReader   *csv.Reader
+	jsonDecoder *json.Decoder
+	// line or record number used in error messages
+	recordNr int
+}
+
+// read the next value. This returns io.EOF when no values remain.
+func (vr *valueReader) read() (tv thing.ThingValue, err error) {
+	vr.recordNr++
+	if vr.csvReader != nil {
+		var record []string
+		record, err = vr.csvReader.Read()
+		if err != nil {
+			return tv, err
+		}
+		tv = thing.ThingValue{
+			PublisherID: record[0],
+			ThingID:     record[1],
+			ID:          record[2],
+			Created:     record[3],
+			Data:        []byte(record[4]),
+		}
+	} else {
+		err = vr.jsonDecoder.Decode(&tv)
+		if err != nil {
+			return tv, err
+		}
+	}
+	if tv.PublisherID == "" || tv.ThingID == "" || tv.ID == "" || tv.Created == "" {
+		err = fmt.Errorf("record %d is missing its publisherID, thingID, name or created time", vr.recordNr)
+	}
+	return tv, err
+}
+
+// newValueReader returns a reader of the format.
+// For CSV this reads and verifies the header.
+func newValueReader(r io.Reader, format string) (vr *valueReader, err error) {
+	vr = &valueReader{}
+	if format == FormatNDJSON {
+		vr.jsonDecoder = json.NewDecoder(r)
+		return vr, nil
+	}
+	vr.csvReader = csv.NewReader(r)
+	vr.csvReader.FieldsPerRecord = len(CSVHeader)
+	header, err := vr.csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing CSV header: %w", err)
+	}
+	for i, column := range CSVHeader {
+		if header[i] != column {
+			return nil, fmt.Errorf("unexpected CSV column '%s'. Expected '%s'", header[i], column)
+		}
+	}
+	vr.recordNr++
+	return vr, nil
+}
+
+// ImportHistory reads events in the export format and adds them to the history in batches.
+// Events are imported as-is, including their timestamp. Retention rules of the add capability apply.
+//
+//	addHist is the capability used to add the events
+//	r is the reader of the input
+//	format is FormatCSV or FormatNDJSON
+//
+// This returns the number of events that were read and passed to AddEvents. This can differ
+// from the number of stored events as AddEvents skips invalid events. A capability that applies
+// the retention rules also skips the events that the rules filter out. On error the events of
+// previous batches have been added.
+func ImportHistory(ctx context.Context, addHist history.IAddHistory, r io.Reader,
+	format string) (nrRead int, err error) {
+
+	if err = ValidateFormat(format); err != nil {
+		return 0, err
+	}
+	vr, err := newValueReader(r, format)
+	if err != nil {
+		return 0, err
+	}
+	batch := make([]thing.ThingValue, 0, DefaultBatchSize)
+	for {
+		tv, err2 := vr.read()
+		if errors.Is(err2, io.EOF) {
+			break
+		} else if err2 != nil {
+			return nrRead, fmt.Errorf("import failed: %w", err2)
+		}
+		batch = append(batch, tv)
+		if len(batch) >= DefaultBatchSize {
+			if err = addHist.AddEvents(ctx, batch); err != nil {
+				return nrRead, err
+			}
+			nrRead += len(batch)
+			// AddEvents requires that the values are not modified after the call
+			batch = make([]thing.ThingValue, 0, DefaultBatchSize)
+		}
+	}
+	if len(batch) > 0 {
+		if err = addHist.AddEvents(ctx, batch); err != nil {
+			return nrRead, err
+		}
+		nrRead += len(batch)
+	}
+	return nrRead, nil
+}
@@ -0,0 +1,119 @@
+package mongohs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/history"
+)
+
+// MongoAddHistory adds events and actions of any Thing to the time-series collection
+// This implements the IAddHistory interface
+type MongoAddHistory struct {
+	clientID string
+	store    *MongoHistoryStore
+	// optional retention rules to test the values against, nil to accept all values
+	retention history.IManageRetention
+	// onAddedValue is a callback to invoke after a value is added. Intended for tracking most recent values.
+	onAddedValue func(ev thing.ThingValue, isAction bool)
+	// optional change filter of the retention rules, nil to record all values
+	isChanged func(ev thing.ThingValue) bool
+}
+
+// validateValue checks the value has a thing address and name, and passes the retention rules
+func (svc *MongoAddHistory) validateValue(ctx context.Context, thingValue thing.ThingValue) error {
+	if thingValue.ThingID == "" || thingValue.PublisherID == "" {
+		return fmt.Errorf("missing publisher/thing address in value with name '%s'", thingValue.ID)
+	}
+	if thingValue.ID == "" {
+		return fmt.Errorf("missing name for event or action for thing '%s/%s'", thingValue.PublisherID, thingValue.ThingID)
+	}
+	if svc.retention != nil {
+		isValid, err := svc.retention.TestEvent(ctx, thingValue)
+		if !isValid || err != nil {
+			return fmt.Errorf("no retention for event '%s'", thingValue.ID)
+		}
+	}
+	return nil
+}
+
+// AddAction adds a Thing action to the action history
+func (svc *MongoAddHistory) AddAction(ctx context.Context, actionValue thing.ThingValue) error {
+	logrus.Infof("clientID=%s, thingID=%s, name=%s", svc.clientID, actionValue.ThingID, actionValue.ID)
+	// actions are not subject to the event retention rules
+	if actionValue.ThingID == "" || actionValue.PublisherID == "" || actionValue.ID == "" {
+		err := fmt.Errorf("missing publisher/thing address or name in action '%s'", actionValue.ID)
+		logrus.Info(err)
+		return err
+	}
+	err := svc.store.AddValues(ctx, []thing.ThingValue{actionValue}, true)
+	if err == nil && svc.onAddedValue != nil {
+		svc.onAddedValue(actionValue, true)
+	}
+	return err
+}
+
+// AddEvent adds an event to the event history
+// If the event has no created time, it will be set to 'now'
+func (svc *MongoAddHistory) AddEvent(ctx context.Context, eventValue thing.ThingValue) error {
+	return svc.AddEvents(ctx, []thing.ThingValue{eventValue})
+}
+
+// AddEvents provides a bulk-add of events to the event history
+// Events that are invalid are skipped.
+func (svc *MongoAddHistory) AddEvents(ctx context.Context, eventValues []thing.ThingValue) error {
+	logrus.Infof("clientID=%s, nrEvents=%d", svc.clientID, len(eventValues))
+	validValues := make([]thing.ThingValue, 0, len(eventValues))
+	for _, eventValue := range eventValues {
+		if err := svc.validateValue(ctx, eventValue); err != nil {
+			if len(eventValues) == 1 {
+				logrus.Info(err)
+				return err
+			}
+			continue
+		}
+		if svc.isChanged != nil && !svc.isChanged(eventValue) {
+			// the latest properties also hold values that aren't recorded
+			if svc.onAddedValue != nil {
+				svc.onAddedValue(eventValue, false)
+			}
+			continue
+		}
+		validValues = append(validValues, eventValue)
+	}
+	err := svc.store.AddValues(ctx, validValues, false)
+	if err == nil && svc.onAddedValue != nil {
+		for _, eventValue := range validValues {
+			svc.onAddedValue(eventValue, false)
+		}
+	}
+	return err
+}
+
+// Release the capability and its resources
+func (svc *MongoAddHistory) Release() {
+}
+
+// NewMongoAddHistory provides the capability to add values to the history in mongodb
+//
+//	clientID of the client using the capability
+//	store with the time-series collection
+//	retention is optional and used to apply constraints to the events to add
+//	onAddedValue is optional and invoked after the value is added
+//	isChanged is optional and tests if an event value passes the change filter of its retention rule
+func NewMongoAddHistory(clientID string, store *MongoHistoryStore,
+	retention history.IManageRetention, onAddedValue func(value thing.ThingValue, isAction bool),
+	isChanged func(value thing.ThingValue) bool) *MongoAddHistory {
+
+	svc := &MongoAddHistory{
+		clientID:     clientID,
+		store:        store,
+		retention:    retention,
+		onAddedValue: onAddedValue,
+		isChanged:    isChanged,
+	}
+	return svc
+}
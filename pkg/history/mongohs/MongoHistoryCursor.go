@@ -0,0 +1,216 @@
+package mongohs
+
+import (
+	"context"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/hiveot/hub/lib/thing"
+)
+
+// MongoHistoryCursor iterates the values of a Thing in the time-series collection.
+// The cursor can be limited to a time range, value names and value type.
+//
+// The cursor holds the timestamp and ID of the current value and queries the value(s) after
+// or before it on each move. This uses the series index and holds no server resources
+// between calls.
+type MongoHistoryCursor struct {
+	store *MongoHistoryStore
+	// filter with the Thing, time range, names and value type
+	filter bson.M
+	// start of the range in msec since epoch, or 0 to start at the beginning
+	startMsec int64
+	// the current position of the cursor
+	positioned bool
+	posTime    time.Time
+	posID      primitive.ObjectID
+}
+
+// find returns up to limit values from the given position in the given direction.
+// The cursor moves to the last value that is returned.
+//
+//	posFilter is the filter on the position, or nil to start at the first or last value
+//	ascending is the direction of the iteration
+func (hc *MongoHistoryCursor) find(posFilter bson.M, ascending bool, limit int64) []thing.ThingValue {
+	ctx := context.Background()
+	values := make([]thing.ThingValue, 0)
+	if hc.store.collection == nil {
+		return values
+	}
+	var filter interface{} = hc.filter
+	if posFilter != nil {
+		filter = bson.M{"$and": bson.A{hc.filter, posFilter}}
+	}
+	order := 1
+	if !ascending {
+		order = -1
+	}
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: TimeStampField, Value: order}, {Key: "_id", Value: order}}).
+		SetLimit(limit)
+	cursor, err := hc.store.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		logrus.Errorf("history query failed: %s", err)
+		return values
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var rec historyRecord
+		if err = cursor.Decode(&rec); err != nil {
+			logrus.Errorf("invalid history record: %s", err)
+			break
+		}
+		values = append(values, rec.toThingValue())
+		hc.positioned = true
+		hc.posTime = rec.Timestamp
+		hc.posID = rec.ID
+	}
+	return values
+}
+
+// afterPos returns the filter for values after the current position
+func (hc *MongoHistoryCursor) afterPos() bson.M {
+	if !hc.positioned {
+		return nil
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{TimeStampField: bson.M{"$gt": hc.posTime}},
+		bson.M{TimeStampField: hc.posTime, "_id": bson.M{"$gt": hc.posID}},
+	}}
+}
+
+// beforePos returns the filter for values before the current position
+func (hc *MongoHistoryCursor) beforePos() bson.M {
+	if !hc.positioned {
+		return nil
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{TimeStampField: bson.M{"$lt": hc.posTime}},
+		bson.M{TimeStampField: hc.posTime, "_id": bson.M{"$lt": hc.posID}},
+	}}
+}
+
+// first returns the first of the values, if any
+func first(values []thing.ThingValue) (thingValue thing.ThingValue, valid bool) {
+	if len(values) == 0 {
+		return thingValue, false
+	}
+	return values[0], true
+}
+
+// Count returns the number of values in the range of the cursor
+func (hc *MongoHistoryCursor) Count() int {
+	if hc.store.collection == nil {
+		return 0
+	}
+	count, err := hc.store.collection.CountDocuments(context.Background(), hc.filter)
+	if err != nil {
+		logrus.Errorf("history count failed: %s", err)
+	}
+	return int(count)
+}
+
+// First returns the oldest value in the range
+func (hc *MongoHistoryCursor) First() (thingValue thing.ThingValue, valid bool) {
+	return first(hc.find(nil, true, 1))
+}
+
+// Last returns the newest value in the range
+func (hc *MongoHistoryCursor) Last() (thingValue thing.ThingValue, valid bool) {
+	return first(hc.find(nil, false, 1))
+}
+
+// Next moves the cursor to the next value in the range
+// If the cursor isn't positioned this returns the first value.
+func (hc *MongoHistoryCursor) Next() (thingValue thing.ThingValue, valid bool) {
+	return first(hc.find(hc.afterPos(), true, 1))
+}
+
+// NextN moves the cursor to the next N values in the range
+func (hc *MongoHistoryCursor) NextN(steps uint) (values []thing.ThingValue, itemsRemaining bool) {
+	if steps == 0 {
+		return nil, false
+	}
+	values = hc.find(hc.afterPos(), true, int64(steps))
+	return values, len(values) > 0
+}
+
+// Prev moves the cursor to the previous value in the range
+// If the cursor isn't positioned this returns the last value.
+func (hc *MongoHistoryCursor) Prev() (thingValue thing.ThingValue, valid bool) {
+	return first(hc.find(hc.beforePos(), false, 1))
+}
+
+// PrevN moves the cursor to the previous N values in the range
+// The values are returned in reverse order, newest first.
+func (hc *MongoHistoryCursor) PrevN(steps uint) (values []thing.ThingValue, itemsRemaining bool) {
+	if steps == 0 {
+		return nil, false
+	}
+	values = hc.find(hc.beforePos(), false, int64(steps))
+	return values, len(values) > 0
+}
+
+// Release the cursor.
+// The cursor holds no server resources so this only resets its position.
+func (hc *MongoHistoryCursor) Release() {
+	hc.positioned = false
+}
+
+// Seek moves the cursor to the first value at or after the timestamp.
+// A timestamp before the start of the range seeks the start of the range.
+func (hc *MongoHistoryCursor) Seek(isoTimestamp string) (thingValue thing.ThingValue, valid bool) {
+	ts, err := dateparse.ParseAny(isoTimestamp)
+	if err != nil {
+		logrus.Infof("Seek using invalid timestamp '%s'", isoTimestamp)
+		return thingValue, false
+	}
+	if ts.UnixMilli() < hc.startMsec {
+		ts = time.UnixMilli(hc.startMsec)
+	}
+	return first(hc.find(bson.M{TimeStampField: bson.M{"$gte": ts}}, true, 1))
+}
+
+// NewMongoHistoryCursor creates a cursor that iterates a time range of the history of a Thing
+//
+//	store with the time-series collection
+//	publisherID, thingID is the address of the Thing
+//	startMsec is the start of the range in msec since epoch, or 0 for the beginning
+//	endMsec is the end of the range in msec since epoch, exclusive, or 0 for no end
+//	names is an optional filter on value names, nil for all names
+//	valueType of the values to include: "a" for actions, "e" for events, "" for both
+func NewMongoHistoryCursor(store *MongoHistoryStore, publisherID, thingID string,
+	startMsec int64, endMsec int64, names []string, valueType string) *MongoHistoryCursor {
+
+	filter := bson.M{
+		MetaField + ".publisherID": publisherID,
+		MetaField + ".thingID":     thingID,
+	}
+	if len(names) > 0 {
+		filter[MetaField+".name"] = bson.M{"$in": names}
+	}
+	if valueType != "" {
+		filter[MetaField+".type"] = valueType
+	}
+	timeFilter := bson.M{}
+	if startMsec > 0 {
+		timeFilter["$gte"] = time.UnixMilli(startMsec)
+	}
+	if endMsec > 0 {
+		timeFilter["$lt"] = time.UnixMilli(endMsec)
+	}
+	if len(timeFilter) > 0 {
+		filter[TimeStampField] = timeFilter
+	}
+	hc := &MongoHistoryCursor{
+		store:     store,
+		filter:    filter,
+		startMsec: startMsec,
+	}
+	return hc
+}
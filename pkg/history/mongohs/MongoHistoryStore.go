@@ -0,0 +1,336 @@
+// Package mongohs with the MongoDB time-series storage backend of the history service
+package mongohs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/hiveot/hub/api/go/vocab"
+	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/history"
+)
+
+// HistoryCollectionName is the name of the time-series collection that holds the history
+const HistoryCollectionName = "history"
+
+// TimeStampField is the time field of the time-series collection
+const TimeStampField = "timestamp"
+
+// MetaField is the meta field of the time-series collection
+const MetaField = "metadata"
+
+// Value types stored in the metadata
+const (
+	valueTypeAction = "a"
+	valueTypeEvent  = "e"
+)
+
+// connectTimeout is the max time to wait for the server on connect
+const connectTimeout = 3 * time.Second
+
+// historyMeta holds the indexed fields that identify the series of a value
+type historyMeta struct {
+	PublisherID string `bson:"publisherID"`
+	ThingID     string `bson:"thingID"`
+	Name        string `bson:"name"`
+	// valueTypeAction or valueTypeEvent
+	ValueType string `bson:"type"`
+}
+
+// historyRecord is the document of a value in the time-series collection
+type historyRecord struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty"`
+	Timestamp time.Time          `bson:"timestamp"`
+	Metadata  historyMeta        `bson:"metadata"`
+	Data      []byte             `bson:"data"`
+	// numeric value of the data, if the data is a number. Used in aggregation.
+	Number *float64 `bson:"number,omitempty"`
+}
+
+// toThingValue converts the record to a ThingValue
+func (rec *historyRecord) toThingValue() thing.ThingValue {
+	return thing.ThingValue{
+		PublisherID: rec.Metadata.PublisherID,
+		ThingID:     rec.Metadata.ThingID,
+		ID:          rec.Metadata.Name,
+		Data:        rec.Data,
+		Created:     time.UnixMilli(rec.Timestamp.UnixMilli()).Format(vocab.ISO8601Format),
+	}
+}
+
+// newHistoryRecord converts a ThingValue to a record
+// If the value has no valid created time the current time is used.
+func newHistoryRecord(thingValue thing.ThingValue, isAction bool) historyRecord {
+	ts := time.Now()
+	if thingValue.Created != "" {
+		created, err := dateparse.ParseAny(thingValue.Created)
+		if err != nil {
+			logrus.Infof("Invalid Created time '%s'. Using current time instead", thingValue.Created)
+		} else {
+			ts = created
+		}
+	}
+	rec := historyRecord{
+		Timestamp: ts,
+		Metadata: historyMeta{
+			PublisherID: thingValue.PublisherID,
+			ThingID:     thingValue.ThingID,
+			Name:        thingValue.ID,
+			ValueType:   valueTypeEvent,
+		},
+		Data: thingValue.Data,
+	}
+	if isAction {
+		rec.Metadata.ValueType = valueTypeAction
+	}
+	if value, isNumber := history.ParseNumber(thingValue.Data); isNumber {
+		rec.Number = &value
+	}
+	return rec
+}
+
+// MongoHistoryStore stores the history of Things in a MongoDB time-series collection.
+// The publisherID, thingID, name and value type are stored in the metadata of each value
+// and indexed together with the timestamp, so range queries and aggregation of a series
+// run in the database.
+type MongoHistoryStore struct {
+	// mongodb connection url
+	dbURL string
+	// name of the database
+	dbName string
+
+	// Client connection to the database server
+	mongoClient *mongo.Client
+	// the time-series collection
+	collection *mongo.Collection
+}
+
+// createCollection creates the time-series collection and its index, if it doesn't exist.
+func (srv *MongoHistoryStore) createCollection(ctx context.Context, db *mongo.Database) error {
+	names, err := db.ListCollectionNames(ctx, bson.M{"name": HistoryCollectionName})
+	if err != nil || len(names) > 0 {
+		return err
+	}
+	logrus.Infof("Creating the time-series collection '%s'", HistoryCollectionName)
+	// A granularity of minutes matches sensors that report a few times per hour or more.
+	tso := options.TimeSeries().
+		SetTimeField(TimeStampField).
+		SetMetaField(MetaField).
+		SetGranularity("minutes")
+	err = db.CreateCollection(ctx, HistoryCollectionName, options.CreateCollection().SetTimeSeriesOptions(tso))
+	if err != nil {
+		return err
+	}
+	// secondary index for range queries of a series
+	// https://www.mongodb.com/docs/manual/core/timeseries/timeseries-secondary-index/
+	seriesIndex := mongo.IndexModel{Keys: bson.D{
+		{Key: MetaField + ".publisherID", Value: 1},
+		{Key: MetaField + ".thingID", Value: 1},
+		{Key: MetaField + ".name", Value: 1},
+		{Key: TimeStampField, Value: 1},
+	}}
+	_, err = db.Collection(HistoryCollectionName).Indexes().CreateOne(ctx, seriesIndex)
+	return err
+}
+
+// AddValues adds events or actions to the history
+func (srv *MongoHistoryStore) AddValues(
+	ctx context.Context, thingValues []thing.ThingValue, isAction bool) error {
+
+	if len(thingValues) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, 0, len(thingValues))
+	for _, tv := range thingValues {
+		docs = append(docs, newHistoryRecord(tv, isAction))
+	}
+	_, err := srv.collection.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false))
+	return err
+}
+
+// GetAggregate returns the aggregate of the numeric values of an event for each interval
+// in the time range. The aggregation runs in the database.
+//
+//	publisherID, thingID is the address of the Thing
+//	name of the event to aggregate
+//	start of the range
+//	duration of the range
+//	interval of each aggregate
+func (srv *MongoHistoryStore) GetAggregate(ctx context.Context, publisherID, thingID string, name string,
+	start time.Time, duration time.Duration, interval time.Duration) ([]history.AggregateValue, error) {
+
+	end := start.Add(duration)
+	intervalMsec := interval.Milliseconds()
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			MetaField + ".publisherID": publisherID,
+			MetaField + ".thingID":     thingID,
+			MetaField + ".name":        name,
+			MetaField + ".type":        valueTypeEvent,
+			"number":                   bson.M{"$exists": true},
+			TimeStampField:             bson.M{"$gte": start, "$lt": end},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: TimeStampField, Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			// index of the interval
+			{Key: "_id", Value: bson.M{"$floor": bson.M{"$divide": bson.A{
+				bson.M{"$subtract": bson.A{"$" + TimeStampField, start}}, intervalMsec}}}},
+			{Key: "count", Value: bson.M{"$sum": 1}},
+			{Key: "min", Value: bson.M{"$min": "$number"}},
+			{Key: "max", Value: bson.M{"$max": "$number"}},
+			{Key: "avg", Value: bson.M{"$avg": "$number"}},
+			{Key: "last", Value: bson.M{"$last": "$number"}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+	cursor, err := srv.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	aggList := make([]history.AggregateValue, 0)
+	for cursor.Next(ctx) {
+		var result struct {
+			Index float64 `bson:"_id"`
+			Count int     `bson:"count"`
+			Min   float64 `bson:"min"`
+			Max   float64 `bson:"max"`
+			Avg   float64 `bson:"avg"`
+			Last  float64 `bson:"last"`
+		}
+		if err = cursor.Decode(&result); err != nil {
+			return aggList, err
+		}
+		startMsec := start.UnixMilli() + int64(result.Index)*intervalMsec
+		aggList = append(aggList, history.AggregateValue{
+			StartTime: time.UnixMilli(startMsec).Format(vocab.ISO8601Format),
+			Count:     result.Count,
+			Min:       result.Min,
+			Max:       result.Max,
+			Avg:       result.Avg,
+			Last:      result.Last,
+		})
+	}
+	return aggList, cursor.Err()
+}
+
+// getSeries returns the metadata of all series in the history
+func (srv *MongoHistoryStore) getSeries(ctx context.Context) ([]historyMeta, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$" + MetaField}}},
+	}
+	cursor, err := srv.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	seriesList := make([]historyMeta, 0)
+	for cursor.Next(ctx) {
+		var result struct {
+			Meta historyMeta `bson:"_id"`
+		}
+		if err = cursor.Decode(&result); err != nil {
+			return seriesList, err
+		}
+		seriesList = append(seriesList, result.Meta)
+	}
+	return seriesList, cursor.Err()
+}
+
+// PurgeExpired removes the values that have exceeded the retention days of their event.
+// Deleting by timestamp from a time-series collection requires MongoDB 7.0 or newer.
+//
+//	now is the reference time used to determine the age of the values
+//	getRetentionDays returns the retention days of a value, 0 to keep it indefinitely
+//
+// This returns the number of removed values and the last error encountered, if any.
+func (srv *MongoHistoryStore) PurgeExpired(ctx context.Context, now time.Time,
+	getRetentionDays func(publisherID, thingID, name string) int) (nrRemoved int, err error) {
+
+	seriesList, err := srv.getSeries(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, series := range seriesList {
+		retentionDays := getRetentionDays(series.PublisherID, series.ThingID, series.Name)
+		if retentionDays <= 0 {
+			continue
+		}
+		filter := bson.M{
+			MetaField:      series,
+			TimeStampField: bson.M{"$lt": now.AddDate(0, 0, -retentionDays)},
+		}
+		res, err2 := srv.collection.DeleteMany(ctx, filter)
+		if err2 != nil {
+			logrus.Errorf("failed purging '%s/%s/%s': %s",
+				series.PublisherID, series.ThingID, series.Name, err2)
+			err = err2
+			continue
+		}
+		nrRemoved += int(res.DeletedCount)
+	}
+	return nrRemoved, err
+}
+
+// Open connects to the database server and creates the collection if it doesn't exist.
+func (srv *MongoHistoryStore) Open() (err error) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancelFn()
+	logrus.Infof("Connecting to the mongodb database on '%s'", srv.dbURL)
+	if srv.mongoClient != nil {
+		return fmt.Errorf("history store '%s' is already open", srv.dbName)
+	}
+	clientOpts := options.Client().ApplyURI(srv.dbURL).SetServerSelectionTimeout(connectTimeout)
+	mongoClient, err := mongo.Connect(ctx, clientOpts)
+	if err == nil {
+		err = mongoClient.Ping(ctx, nil)
+	}
+	if err == nil {
+		db := mongoClient.Database(srv.dbName)
+		err = srv.createCollection(ctx, db)
+		srv.collection = db.Collection(HistoryCollectionName)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to open the history database on %s: %w", srv.dbURL, err)
+		logrus.Error(err)
+		if mongoClient != nil {
+			_ = mongoClient.Disconnect(context.Background())
+		}
+		return err
+	}
+	srv.mongoClient = mongoClient
+	return nil
+}
+
+// Close disconnects from the database server
+func (srv *MongoHistoryStore) Close() error {
+	logrus.Infof("Disconnecting from the database")
+	if srv.mongoClient == nil {
+		return nil
+	}
+	err := srv.mongoClient.Disconnect(context.Background())
+	srv.mongoClient = nil
+	srv.collection = nil
+	return err
+}
+
+// NewMongoHistoryStore creates a history store that uses a MongoDB time-series collection.
+// Call Open before use.
+//
+//	dbURL is the URL of the mongodb server, eg mongodb://localhost:27017
+//	dbName is the name of the database, eg the serviceID
+func NewMongoHistoryStore(dbURL string, dbName string) *MongoHistoryStore {
+	srv := &MongoHistoryStore{
+		dbURL:  dbURL,
+		dbName: dbName,
+	}
+	return srv
+}
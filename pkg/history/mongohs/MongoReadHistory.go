@@ -0,0 +1,100 @@
+package mongohs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/araddon/dateparse"
+
+	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/history"
+)
+
+// MongoReadHistory provides read access to the history of Things in mongodb
+// This implements the IReadHistory interface
+type MongoReadHistory struct {
+	clientID string
+	store    *MongoHistoryStore
+	// the latest properties are tracked by the service
+	getPropertiesFunc func(thingAddr string, names []string) []thing.ThingValue
+}
+
+// GetAggregate returns the aggregate of the numeric values of an event for each interval
+// in the time range.
+func (svc *MongoReadHistory) GetAggregate(ctx context.Context, publisherID, thingID string, name string,
+	startTime string, duration int, interval int) ([]history.AggregateValue, error) {
+
+	if duration <= 0 || interval <= 0 {
+		return nil, fmt.Errorf("duration and interval must be positive")
+	} else if duration/interval > history.MaxAggregateIntervals {
+		return nil, fmt.Errorf("time range has more than %d intervals", history.MaxAggregateIntervals)
+	}
+	start, err := dateparse.ParseAny(startTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time '%s': %w", startTime, err)
+	}
+	return svc.store.GetAggregate(ctx, publisherID, thingID, name,
+		start, time.Duration(duration)*time.Second, time.Duration(interval)*time.Second)
+}
+
+// FollowHistory is not supported with mongodb
+func (svc *MongoReadHistory) FollowHistory(_ context.Context, _ string, _ string,
+	_ history.HistoryRange, _ func(thingValue thing.ThingValue)) (history.IHistoryFollower, error) {
+	return nil, fmt.Errorf("following the history is not supported with mongodb")
+}
+
+// GetEventHistory provides a cursor to iterate the event history of the thing
+// name is used to filter on the event/action name. "" to iterate all events.
+func (svc *MongoReadHistory) GetEventHistory(_ context.Context,
+	publisherID string, thingID string, name string) history.IHistoryCursor {
+
+	var names []string
+	if name != "" {
+		names = []string{name}
+	}
+	return NewMongoHistoryCursor(svc.store, publisherID, thingID, 0, 0, names, "")
+}
+
+// GetRangeHistory provides a cursor to iterate a time range of the history of the thing
+func (svc *MongoReadHistory) GetRangeHistory(_ context.Context, publisherID string, thingID string,
+	historyRange history.HistoryRange) (cursor history.IHistoryCursor, totalEstimate int, err error) {
+
+	// the value type is stored as "a" or "e", like valueTypeAction and valueTypeEvent
+	startMsec, endMsec, valueType, err := history.ParseRange(historyRange)
+	if err != nil {
+		return nil, 0, err
+	}
+	historyCursor := NewMongoHistoryCursor(svc.store, publisherID, thingID,
+		startMsec, endMsec, historyRange.Names, valueType)
+	totalEstimate = historyCursor.Count()
+	return historyCursor, totalEstimate, nil
+}
+
+// GetProperties returns the most recent property and event values of the Thing
+func (svc *MongoReadHistory) GetProperties(_ context.Context,
+	publisherID string, thingID string, names []string) (values []thing.ThingValue) {
+	thingAddr := publisherID + "/" + thingID
+	values = svc.getPropertiesFunc(thingAddr, names)
+	return values
+}
+
+// Release the capability and its resources
+func (svc *MongoReadHistory) Release() {
+}
+
+// NewMongoReadHistory returns the capability to read the history of Things in mongodb
+//
+//	clientID of the client using the capability
+//	store with the time-series collection
+//	getPropertiesFunc returns the most recent property values of a Thing
+func NewMongoReadHistory(clientID string, store *MongoHistoryStore,
+	getPropertiesFunc func(thingAddr string, names []string) []thing.ThingValue) *MongoReadHistory {
+
+	svc := &MongoReadHistory{
+		clientID:          clientID,
+		store:             store,
+		getPropertiesFunc: getPropertiesFunc,
+	}
+	return svc
+}
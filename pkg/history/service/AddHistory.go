@@ -3,10 +3,11 @@ package service
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/araddon/dateparse"
 	"github.com/sirupsen/logrus"
 
 	"github.com/hiveot/hub/api/go/vocab"
@@ -24,34 +25,48 @@ type AddHistory struct {
 	onAddedValue func(ev thing.ThingValue, isAction bool)
 	//
 	retentionMgr *ManageRetention
+	// getProperties returns the last known values of a Thing. Used by the change filter after startup.
+	getProperties func(thingAddr string, names []string) []thing.ThingValue
+	// followers are passed the values as they are stored
+	followers *HistoryFollowers
 }
 
 // encode a ThingValue into a single key value pair
 // Encoding generates a key as: timestampMsec/name/a|e, where a|e indicates action or event
+// The value is a record in the current RecordVersion, see HistoryRecord.go.
 func (svc *AddHistory) encodeValue(thingValue thing.ThingValue, isAction bool) (key string, val []byte) {
-	var err error
-	ts := time.Now()
-	if thingValue.Created != "" {
-		ts, err = dateparse.ParseAny(thingValue.Created)
-		if err != nil {
-			logrus.Infof("Invalid Created time '%s'. Using current time instead", thingValue.Created)
-			ts = time.Now()
-		}
-	}
+	record := newRecord(thingValue, svc.followers.nextSequence())
 
 	// the index uses milliseconds for timestamp
-	timestamp := ts.UnixMilli()
+	timestamp := record.CreatedNsec / int64(time.Millisecond)
 	key = strconv.FormatInt(timestamp, 10) + "/" + thingValue.ID
 	if isAction {
 		key = key + "/a"
 	} else {
 		key = key + "/e"
 	}
-	// TODO: reorganize data to store. Remove duplication. Timestamp in msec since epoc
-	val = thingValue.Data
+	val = encodeRecord(record)
 	return key, val
 }
 
+// splitKey parses a storage key constructed by encodeValue into its timestamp, value name
+// and a|e suffix. Value names can contain '/' so the name is everything between the first
+// and the last separator.
+// This returns false if the key is not a history key.
+func splitKey(key string) (timestampMsec int64, name string, suffix string, valid bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) < 3 {
+		return 0, "", "", false
+	}
+	timestampMsec, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", "", false
+	}
+	name = strings.Join(parts[1:len(parts)-1], "/")
+	suffix = parts[len(parts)-1]
+	return timestampMsec, name, suffix, true
+}
+
 // AddAction adds a Thing action with the given name and value to the action history
 // value is json encoded. Optionally include a 'created' ISO8601 timestamp
 func (svc *AddHistory) AddAction(_ context.Context, actionValue thing.ThingValue) error {
@@ -61,11 +76,16 @@ func (svc *AddHistory) AddAction(_ context.Context, actionValue thing.ThingValue
 		logrus.Info(err)
 		return err
 	}
+	svc.followers.beginAdd()
+	defer svc.followers.endAdd()
 	key, val := svc.encodeValue(actionValue, true)
 	thingAddr := actionValue.PublisherID + "/" + actionValue.ThingID
 	bucket := svc.store.GetBucket(thingAddr)
 	err := bucket.Set(key, val)
 	_ = bucket.Close()
+	if err == nil {
+		svc.followers.notify(thingAddr, key, val)
+	}
 	if svc.onAddedValue != nil {
 		svc.onAddedValue(actionValue, true)
 	}
@@ -85,7 +105,17 @@ func (svc *AddHistory) AddEvent(ctx context.Context, eventValue thing.ThingValue
 		logrus.Info(err)
 		return err
 	}
+	if !svc.isChanged(eventValue) {
+		logrus.Debugf("skipping unchanged value of [%s %s] %s", eventValue.PublisherID, eventValue.ThingID, eventValue.ID)
+		// the latest properties also hold values that aren't recorded
+		if svc.onAddedValue != nil {
+			svc.onAddedValue(eventValue, false)
+		}
+		return nil
+	}
 
+	svc.followers.beginAdd()
+	defer svc.followers.endAdd()
 	key, val := svc.encodeValue(eventValue, false)
 	thingAddr := eventValue.PublisherID + "/" + eventValue.ThingID
 	bucket := svc.store.GetBucket(thingAddr)
@@ -94,6 +124,9 @@ func (svc *AddHistory) AddEvent(ctx context.Context, eventValue thing.ThingValue
 
 	err := bucket.Set(key, val)
 	_ = bucket.Close()
+	if err == nil {
+		svc.followers.notify(thingAddr, key, val)
+	}
 	if svc.onAddedValue != nil {
 		svc.onAddedValue(eventValue, false)
 	}
@@ -110,6 +143,9 @@ func (svc *AddHistory) AddEvents(ctx context.Context, eventValues []thing.ThingV
 		err = svc.AddEvent(ctx, eventValues[0])
 		return err
 	}
+	// followers are passed the values that are stored while holding the lock
+	svc.followers.beginAdd()
+	defer svc.followers.endAdd()
 	// encode events as K,V pair and group them by thingAddr
 	kvpairsByThingAddr := make(map[string]map[string][]byte)
 	for _, eventValue := range eventValues {
@@ -121,9 +157,11 @@ func (svc *AddHistory) AddEvents(ctx context.Context, eventValues []thing.ThingV
 			kvpairsByThingAddr[thingAddr] = kvpairs
 		}
 		if err := svc.validateValue(eventValue); err == nil {
-			key, value := svc.encodeValue(eventValue, false)
-			kvpairs[key] = value
-			// notify owner to update thing properties
+			if svc.isChanged(eventValue) {
+				key, value := svc.encodeValue(eventValue, false)
+				kvpairs[key] = value
+			}
+			// notify owner to update thing properties, also with values that aren't recorded
 			if svc.onAddedValue != nil {
 				svc.onAddedValue(eventValue, false)
 			}
@@ -132,12 +170,32 @@ func (svc *AddHistory) AddEvents(ctx context.Context, eventValues []thing.ThingV
 	// adding in bulk, opening and closing buckets only once for each thing address
 	for thingAddr, kvpairs := range kvpairsByThingAddr {
 		bucket := svc.store.GetBucket(thingAddr)
-		_ = bucket.SetMultiple(kvpairs)
-		err = bucket.Close()
+		err = bucket.SetMultiple(kvpairs)
+		_ = bucket.Close()
+		if err == nil {
+			// pass the values in time order
+			keys := make([]string, 0, len(kvpairs))
+			for key := range kvpairs {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				svc.followers.notify(thingAddr, key, kvpairs[key])
+			}
+		}
 	}
 	return nil
 }
 
+// isChanged tests if the event value is to be recorded under the change filter of its retention rule.
+// See ManageRetention.IsChanged.
+func (svc *AddHistory) isChanged(eventValue thing.ThingValue) bool {
+	if svc.retentionMgr == nil {
+		return true
+	}
+	return svc.retentionMgr.IsChanged(eventValue, svc.getProperties)
+}
+
 // Release the capability and its resources
 func (svc *AddHistory) Release() {
 
@@ -168,16 +226,22 @@ func (svc *AddHistory) validateValue(thingValue thing.ThingValue) error {
 //
 //	retentionMgr is optional and used to apply constraints to the events to add
 //	onAddedValue is optional and invoked after the value is added to the bucket.
+//	getProperties is optional and provides the last known values for the change filter of the retention rules.
+//	followers is optional and passes the stored values to the followers of the history.
 func NewAddHistory(
 	clientID string,
 	store bucketstore.IBucketStore,
 	retentionMgr *ManageRetention,
-	onAddedValue func(value thing.ThingValue, isAction bool)) *AddHistory {
+	onAddedValue func(value thing.ThingValue, isAction bool),
+	getProperties func(thingAddr string, names []string) []thing.ThingValue,
+	followers *HistoryFollowers) *AddHistory {
 	svc := &AddHistory{
-		clientID:     clientID,
-		store:        store,
-		retentionMgr: retentionMgr,
-		onAddedValue: onAddedValue,
+		clientID:      clientID,
+		store:         store,
+		retentionMgr:  retentionMgr,
+		onAddedValue:  onAddedValue,
+		getProperties: getProperties,
+		followers:     followers,
 	}
 
 	return svc
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/history"
+	"github.com/hiveot/hub/pkg/history/config"
+)
+
+// DefaultCompactInterval is the interval between compaction runs when no configuration is provided
+const DefaultCompactInterval = time.Duration(config.DefaultCompactIntervalSec) * time.Second
+
+// DefaultCompactBatchSize is the maximum number of records that are read in a single compaction batch
+const DefaultCompactBatchSize = 1000
+
+// compactGroup holds the values of an event in a compaction interval
+type compactGroup struct {
+	publisherID string
+	name        string
+	startMsec   int64
+	endMsec     int64
+	// keys of the values in the interval
+	keys []string
+	// sum of the values if all values are numeric
+	sum        float64
+	allNumeric bool
+	// the most recent value in the interval
+	last []byte
+}
+
+// add a value to the group
+func (grp *compactGroup) add(key string, data []byte) {
+	grp.keys = append(grp.keys, key)
+	// the data is only valid until the cursor is released
+	grp.last = append([]byte(nil), data...)
+	value, isNumber := history.ParseNumber(data)
+	grp.allNumeric = grp.allNumeric && isNumber
+	grp.sum += value
+}
+
+// compactedValue returns the key and record that replaces the values of the group
+// This returns an empty key if the group is already compacted.
+//
+//	sequence in which the compacted value is added
+func (grp *compactGroup) compactedValue(sequence uint64) (key string, record []byte) {
+	// key is constructed as  {timestamp}/{valueName}/{a|e}
+	key = strconv.FormatInt(grp.startMsec, 10) + "/" + grp.name + "/e"
+	if len(grp.keys) == 1 && grp.keys[0] == key {
+		return "", nil
+	}
+	var data []byte
+	if grp.allNumeric {
+		avg := grp.sum / float64(len(grp.keys))
+		data = []byte(strconv.FormatFloat(avg, 'f', -1, 64))
+	} else {
+		data = grp.last
+	}
+	ts := time.UnixMilli(grp.startMsec)
+	_, zoneOffset := ts.Zone()
+	record = encodeRecord(historyRecord{
+		CreatedNsec: ts.UnixNano(),
+		ZoneOffset:  zoneOffset,
+		Sequence:    sequence,
+		PublisherID: grp.publisherID,
+		Data:        data,
+	})
+	return key, record
+}
+
+// HistoryCompactor periodically downsamples the event values in the Thing history buckets
+// using the compaction rules of their event retention.
+//
+// The values of an event in each interval are replaced by a single value with the timestamp of
+// the start of the interval. Numeric values are averaged, otherwise the last value is kept.
+// Values are compacted once the whole interval is older than the age of the rule. When values
+// reach the age of the next rule, the compacted values are compacted again using the larger
+// interval. Actions are not compacted.
+//
+// Compacted values are not passed to the followers of the history. A Thing whose history is
+// being replayed is skipped and compacted in the next run.
+type HistoryCompactor struct {
+	// store with buckets for Things
+	store bucketstore.IBucketStore
+	// retention rules with the compaction rules to apply
+	retentionMgr *ManageRetention
+	// provides the addresses of Things with a history bucket
+	getThingAddrs func() []string
+	// followers whose replay of a Thing's history excludes its compaction
+	followers *HistoryFollowers
+	// interval between compaction runs. 0 to disable the background job
+	interval time.Duration
+	// max nr of records to read in a batch
+	batchSize int
+
+	// stop the background job
+	stopChan chan bool
+	// wait for the background job to end
+	jobWG sync.WaitGroup
+}
+
+// getMinCompactionMsec returns the shortest age of all compaction rules, in msec.
+// Values younger than this age are never compacted.
+// This returns 0 if none of the rules has compaction.
+func (svc *HistoryCompactor) getMinCompactionMsec() int64 {
+	var minDays = 0
+	svc.retentionMgr.retMux.RLock()
+	defer svc.retentionMgr.retMux.RUnlock()
+	for _, rule := range svc.retentionMgr.configuredRetentions {
+		for _, compaction := range rule.Compaction {
+			if compaction.AfterDays > 0 && (minDays == 0 || compaction.AfterDays < minDays) {
+				minDays = compaction.AfterDays
+			}
+		}
+	}
+	return int64(minDays) * msecPerDay
+}
+
+// getInterval returns the interval that applies to a value of the event, in msec.
+// This is the interval of the oldest compaction rule for which the whole interval containing
+// the value is older than the rule's age.
+// This returns 0 if the value is not compacted.
+func getInterval(compaction []history.CompactionRule, timestampMsec int64, nowMsec int64) int64 {
+	// compaction is sorted by age. Start with the oldest rule.
+	for i := len(compaction) - 1; i >= 0; i-- {
+		rule := compaction[i]
+		intervalMsec := int64(rule.IntervalSec) * 1000
+		if intervalMsec <= 0 {
+			continue
+		}
+		endMsec := timestampMsec - timestampMsec%intervalMsec + intervalMsec
+		if endMsec <= nowMsec-int64(rule.AfterDays)*msecPerDay {
+			return intervalMsec
+		}
+	}
+	return 0
+}
+
+// findGroups iterates the bucket from the given key and collects the values in compaction groups.
+// Groups are closed when the iteration passes their end time. Open groups are kept in
+// openGroups for the next batch. Each batch reads at most batchSize keys, including the keys
+// of values that are added to open groups or are skipped.
+// The cursor is released before returning so the bucket can be updated without holding a
+// read transaction.
+//
+//	startKey to seek or "" to start at the first key. The start key itself is skipped.
+//	openGroups with the groups by name and interval that are not yet closed
+//	untilMsec is the timestamp after which values are no longer considered for compaction
+//	nowMsec is the reference time to determine the age of values
+//
+// This returns the closed groups, the last key that was iterated, and done is true if the
+// iteration has completed.
+func (svc *HistoryCompactor) findGroups(bucket bucketstore.IBucket,
+	publisherID, thingID string, startKey string, openGroups map[string]*compactGroup,
+	untilMsec int64, nowMsec int64) (closedGroups []*compactGroup, lastKey string, done bool) {
+
+	closedGroups = make([]*compactGroup, 0)
+	nrKeys := 0
+	cursor := bucket.Cursor()
+	defer cursor.Release()
+
+	var k string
+	var v []byte
+	var valid bool
+	if startKey == "" {
+		k, v, valid = cursor.First()
+	} else {
+		k, v, valid = cursor.Seek(startKey)
+		if valid && k == startKey {
+			k, v, valid = cursor.Next()
+		}
+	}
+	for ; valid; k, v, valid = cursor.Next() {
+		if nrKeys >= svc.batchSize {
+			// the next batch continues after the last key
+			return closedGroups, lastKey, false
+		}
+		nrKeys++
+		lastKey = k
+		// key is constructed as  {timestamp}/{valueName}/{a|e}
+		timestampMsec, name, suffix, isKey := splitKey(k)
+		if !isKey || suffix != "e" {
+			// not a history event. Skip it.
+			continue
+		}
+		if timestampMsec >= untilMsec {
+			// the remaining values are all newer
+			return closedGroups, lastKey, true
+		}
+		// close the groups that end before this value
+		for groupID, grp := range openGroups {
+			if grp.endMsec <= timestampMsec {
+				closedGroups = append(closedGroups, grp)
+				delete(openGroups, groupID)
+			}
+		}
+		compaction := svc.retentionMgr.GetCompaction(publisherID, thingID, name)
+		intervalMsec := getInterval(compaction, timestampMsec, nowMsec)
+		if intervalMsec == 0 {
+			continue
+		}
+		startMsec := timestampMsec - timestampMsec%intervalMsec
+		groupID := fmt.Sprintf("%s/%d/%d", name, intervalMsec, startMsec)
+		grp, found := openGroups[groupID]
+		if !found {
+			grp = &compactGroup{
+				publisherID: publisherID,
+				name:        name,
+				startMsec:   startMsec,
+				endMsec:     startMsec + intervalMsec,
+				allNumeric:  true,
+			}
+			openGroups[groupID] = grp
+		}
+		grp.add(k, decodeData(v))
+	}
+	return closedGroups, lastKey, true
+}
+
+// compactGroups replaces the values of each group by its compacted value.
+// This returns the number of records that were removed.
+func (svc *HistoryCompactor) compactGroups(
+	bucket bucketstore.IBucket, groups []*compactGroup) (nrRemoved int, err error) {
+
+	for _, grp := range groups {
+		key, data := grp.compactedValue(svc.followers.nextSequence())
+		if key == "" {
+			continue
+		}
+		err = bucket.Set(key, data)
+		if err != nil {
+			return nrRemoved, err
+		}
+		for _, oldKey := range grp.keys {
+			if oldKey == key {
+				continue
+			}
+			err = bucket.Delete(oldKey)
+			if err != nil {
+				return nrRemoved, err
+			}
+			nrRemoved++
+		}
+	}
+	return nrRemoved, nil
+}
+
+// CompactThing compacts the event values in the history bucket of a Thing.
+// Values are compacted in batches.
+//
+//	thingAddr is the address of the thing, eg publisherID/thingID
+//	now is the reference time used to determine the age of the values
+//
+// This returns the number of records that were removed by compaction.
+// A Thing whose history is being replayed to a follower is not compacted.
+func (svc *HistoryCompactor) CompactThing(thingAddr string, now time.Time) (nrRemoved int, err error) {
+	parts := strings.Split(thingAddr, "/")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid thing address '%s'", thingAddr)
+	}
+	publisherID, thingID := parts[0], parts[1]
+	minCompactionMsec := svc.getMinCompactionMsec()
+	if minCompactionMsec == 0 {
+		// nothing to compact
+		return 0, nil
+	}
+	nowMsec := now.UnixMilli()
+	untilMsec := nowMsec - minCompactionMsec
+
+	if !svc.followers.beginCompact(thingAddr) {
+		logrus.Infof("history of '%s' is being replayed. Skipping its compaction.", thingAddr)
+		return 0, nil
+	}
+	defer svc.followers.endCompact(thingAddr)
+	bucket := svc.store.GetBucket(thingAddr)
+	defer bucket.Close()
+	openGroups := make(map[string]*compactGroup)
+	startKey := ""
+	for {
+		closedGroups, lastKey, done := svc.findGroups(
+			bucket, publisherID, thingID, startKey, openGroups, untilMsec, nowMsec)
+		if done {
+			// the remaining groups are complete
+			for _, grp := range openGroups {
+				closedGroups = append(closedGroups, grp)
+			}
+		}
+		nrGroupRemoved, err2 := svc.compactGroups(bucket, closedGroups)
+		nrRemoved += nrGroupRemoved
+		if err2 != nil {
+			logrus.Errorf("failed compacting history of '%s': %s", thingAddr, err2)
+			return nrRemoved, err2
+		}
+		if done {
+			break
+		}
+		startKey = lastKey
+	}
+	return nrRemoved, nil
+}
+
+// CompactAll compacts the history buckets of all Things.
+// This returns the number of records that were removed and the last error encountered, if any.
+func (svc *HistoryCompactor) CompactAll() (nrRemoved int, err error) {
+	now := time.Now()
+	thingAddrs := svc.getThingAddrs()
+	for _, thingAddr := range thingAddrs {
+		nrThingRemoved, err2 := svc.CompactThing(thingAddr, now)
+		nrRemoved += nrThingRemoved
+		if err2 != nil {
+			err = err2
+		}
+	}
+	logrus.Infof("compaction removed %d history records from %d things", nrRemoved, len(thingAddrs))
+	return nrRemoved, err
+}
+
+// Start the background job that periodically compacts the history.
+// This does nothing if no interval is set.
+func (svc *HistoryCompactor) Start() {
+	if svc.interval <= 0 {
+		logrus.Infof("compaction interval is not set. History will not be compacted.")
+		return
+	}
+	svc.stopChan = make(chan bool)
+	svc.jobWG.Add(1)
+	go func() {
+		defer svc.jobWG.Done()
+		ticker := time.NewTicker(svc.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-svc.stopChan:
+				return
+			case <-ticker.C:
+				_, _ = svc.CompactAll()
+			}
+		}
+	}()
+}
+
+// Stop the background job and wait until a running compaction has completed
+func (svc *HistoryCompactor) Stop() {
+	if svc.stopChan != nil {
+		close(svc.stopChan)
+		svc.jobWG.Wait()
+		svc.stopChan = nil
+	}
+}
+
+// NewHistoryCompactor creates a compactor that downsamples the values in the history store.
+//
+//	store with the Thing history buckets
+//	retentionMgr with the retention rules that hold the compaction rules
+//	getThingAddrs provides the addresses of the things whose buckets to compact
+//	followers is the registry of followers whose replays exclude compaction, or nil
+//	interval between compaction runs, 0 to only compact on demand
+func NewHistoryCompactor(
+	store bucketstore.IBucketStore,
+	retentionMgr *ManageRetention,
+	getThingAddrs func() []string,
+	followers *HistoryFollowers,
+	interval time.Duration) *HistoryCompactor {
+
+	svc := &HistoryCompactor{
+		store:         store,
+		retentionMgr:  retentionMgr,
+		getThingAddrs: getThingAddrs,
+		followers:     followers,
+		interval:      interval,
+		batchSize:     DefaultCompactBatchSize,
+	}
+	return svc
+}
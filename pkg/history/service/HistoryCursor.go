@@ -2,7 +2,6 @@ package service
 
 import (
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/araddon/dateparse"
@@ -14,160 +13,168 @@ import (
 )
 
 // The HistoryCursor is a bucket cursor that converts the raw stored value to a ThingValue object
+// The cursor can be limited to a time range, event names and values type.
 type HistoryCursor struct {
 	publisherID string
 	thingID     string
-	filterName  string                    // optional event name to filter on
-	bucket      bucketstore.IBucket       // bucket being iterator
-	bc          bucketstore.IBucketCursor // the iteration
+	// optional start of the range in msec since epoch. 0 to start at the beginning.
+	startMsec int64
+	// optional end of the range in msec since epoch, exclusive. 0 to not limit the end.
+	endMsec int64
+	// optional names to filter on. Empty to include all names.
+	names map[string]bool
+	// optional key suffix of the value type to filter on: "a" for actions, "e" for events, "" for both.
+	valueType string
+	bucket    bucketstore.IBucket       // bucket being iterator
+	bc        bucketstore.IBucketCursor // the iteration
 }
 
 // convert the storage key and raw data to a thing value object
 // this must match the encoding done in AddHistory
+// Legacy records only hold the data and use the msec timestamp of the key.
 // This returns the value, or nil if the key is invalid
 func (hc *HistoryCursor) decodeValue(key string, data []byte) (thingValue thing.ThingValue, valid bool) {
 	// key is constructed as  {timestamp}/{valueName}/{a|e}
-	parts := strings.Split(key, "/")
-	if len(parts) < 2 {
+	millisec, name, _, isKey := splitKey(key)
+	if !isKey {
 		return thingValue, false
 	}
-	millisec, _ := strconv.ParseInt(parts[0], 10, 64)
-	ts := time.UnixMilli(millisec)
-	timeIso8601 := ts.Format(vocab.ISO8601Format)
+	var timeIso8601 string
+	record := decodeRecord(data)
+	if record.Version == RecordVersionLegacy {
+		ts := time.UnixMilli(millisec)
+		timeIso8601 = ts.Format(vocab.ISO8601Format)
+	} else {
+		timeIso8601 = record.Created().Format(createdFormat)
+	}
 	thingValue = thing.ThingValue{
 		ThingID:     hc.thingID,
 		PublisherID: hc.publisherID,
-		ID:          parts[1],
-		Data:        data,
+		ID:          name,
+		Data:        record.Data,
 		Created:     timeIso8601,
 	}
 	return thingValue, true
 }
 
-// findNextName iterates the cursor until the next value containing 'name' is found and the
-// timestamp doesn't exceed untilTime.
-// A successive call with an increased timestamp should return the next batch of results. Intended
-// to iterated an hours/day/week at a time.
-// This returns the next value, or nil if the value was not found.
-//
-//	name is the event name to match
-//	until is the time not to exceed in the result. Intended to avoid unnecesary iteration in range queries
-func (hc *HistoryCursor) findNextName(name string, until time.Time) (thingValue thing.ThingValue, found bool) {
-	found = false
-	for {
-		k, v, valid := hc.bc.Next()
-		if !valid {
-			// key is invalid. This means we reached the end of cursor
+// matchKey parses the storage key and tests if it passes the name and value type filters.
+// This returns the timestamp of the key, and false if the key is invalid or doesn't match.
+func (hc *HistoryCursor) matchKey(key string) (timestampMsec int64, match bool) {
+	// key is constructed as  {timestamp}/{valueName}/{a|e}
+	timestampMsec, name, suffix, isKey := splitKey(key)
+	if !isKey {
+		// key exists but is invalid. skip this entry
+		return 0, false
+	}
+	if len(hc.names) > 0 && !hc.names[name] {
+		return timestampMsec, false
+	}
+	if hc.valueType != "" && hc.valueType != suffix {
+		return timestampMsec, false
+	}
+	return timestampMsec, true
+}
+
+// findNext iterates the cursor forward, starting with the given entry, until an entry is found
+// that matches the filter. This stops at the end of the range.
+// This returns the next value, or false if no value was found.
+func (hc *HistoryCursor) findNext(k string, v []byte, valid bool) (thingValue thing.ThingValue, found bool) {
+	for ; valid; k, v, valid = hc.bc.Next() {
+		timestampMsec, match := hc.matchKey(k)
+		if hc.endMsec > 0 && timestampMsec >= hc.endMsec {
+			// we passed the end of the range
+			// undo the last step so that a followup Prev returns the last value in the range
+			hc.bc.Prev()
 			return thingValue, false
 		}
-		// key is constructed as  {timestamp}/{valueName}/{a|e}
-		parts := strings.Split(k, "/")
-		if len(parts) != 3 {
-			// key exists but is invalid. skip this entry
-		} else {
-			// check timestamp and name must match
-			timestampmsec, _ := strconv.ParseInt(parts[0], 10, 64)
-			if timestampmsec > until.UnixMilli() {
-				// we passed the given time limit
-				// undo the last step so that followup requests with a new time limit can include this result
-				hc.bc.Prev()
-				return thingValue, false
-			}
-			if name == parts[1] {
-				// found a match. Decode and return it
-				thingValue, found = hc.decodeValue(k, v)
-				return
-			}
-			// name doesn't match. Skip this entry
+		if match {
+			return hc.decodeValue(k, v)
 		}
 	}
+	// key is invalid. This means we reached the end of cursor
+	return thingValue, false
 }
 
-// findPrevName iterates the cursor until the previous value containing 'name' is found and the
-// timestamp is not before 'until' time.
-// A successive call with an increased timestamp should return the next batch of results. Intended
-// to iterated an hours/day/week at a time.
-// This returns the previous value, or nil if the value was not found.
-//
-//	name is the event name to match
-//	until is the time not to exceed in the result. Intended to avoid unnecesary iteration in range queries
-func (hc *HistoryCursor) findPrevName(name string, until time.Time) (thingValue thing.ThingValue, found bool) {
-	found = false
-	for {
-		k, v, valid := hc.bc.Prev()
-		if !valid {
-			// key is invalid. This means we reached the beginning of cursor
+// findPrev iterates the cursor backwards, starting with the given entry, until an entry is found
+// that matches the filter. This stops at the start of the range.
+// This returns the previous value, or false if no value was found.
+func (hc *HistoryCursor) findPrev(k string, v []byte, valid bool) (thingValue thing.ThingValue, found bool) {
+	for ; valid; k, v, valid = hc.bc.Prev() {
+		timestampMsec, match := hc.matchKey(k)
+		if !match {
+			if _, _, _, isKey := splitKey(k); !isKey {
+				// key exists but is invalid. skip this entry like findNext does
+				continue
+			}
+		}
+		if timestampMsec < hc.startMsec {
+			// we passed the start of the range
+			// undo the last step so that a followup Next returns the first value in the range
+			hc.bc.Next()
 			return thingValue, false
 		}
-		// key is constructed as  {timestamp}/{valueName}/{a|e}
-		parts := strings.Split(k, "/")
-		if len(parts) != 3 {
-			// key exists but is invalid. skip this entry
-		} else {
-			// check timestamp and name must match
-			timestampmsec, _ := strconv.ParseInt(parts[0], 10, 64)
-			if timestampmsec < until.UnixMilli() {
-				// we passed the given time limit
-				// undo the last step so that followup requests with a new time limit can include this result
-				hc.bc.Next()
-				return thingValue, false
-			}
-			if name == parts[1] {
-				// found a match. Decode and return it
-				thingValue, found = hc.decodeValue(k, v)
-				return
-			}
-			// name doesn't match. Skip this entry
+		if match {
+			return hc.decodeValue(k, v)
 		}
 	}
+	// key is invalid. This means we reached the beginning of cursor
+	return thingValue, false
 }
 
-// First returns the oldest value in the history
-func (hc *HistoryCursor) First() (thingValue thing.ThingValue, valid bool) {
-	until := time.Now()
-	k, v, valid := hc.bc.First()
-	if !valid {
-		// bucket is empty
-		return thingValue, false
+// CountRange returns the number of values in the range that match the filter.
+// This iterates the keys without decoding the values. Values can be added or removed while
+// iterating, so the result is an estimate.
+func (hc *HistoryCursor) CountRange() int {
+	count := 0
+	cursor := hc.bucket.Cursor()
+	defer cursor.Release()
+	k, _, valid := cursor.Seek(strconv.FormatInt(hc.startMsec, 10))
+	for ; valid; k, _, valid = cursor.Next() {
+		timestampMsec, match := hc.matchKey(k)
+		if hc.endMsec > 0 && timestampMsec >= hc.endMsec {
+			break
+		} else if match {
+			count++
+		}
 	}
-	thingValue, valid = hc.decodeValue(k, v)
-	if valid && hc.filterName != "" && thingValue.ID != hc.filterName {
-		thingValue, valid = hc.findNextName(hc.filterName, until)
+	return count
+}
+
+// First returns the oldest value in the range
+func (hc *HistoryCursor) First() (thingValue thing.ThingValue, valid bool) {
+	var k string
+	var v []byte
+	if hc.startMsec > 0 {
+		k, v, valid = hc.bc.Seek(strconv.FormatInt(hc.startMsec, 10))
+	} else {
+		k, v, valid = hc.bc.First()
 	}
-	return thingValue, valid
+	return hc.findNext(k, v, valid)
 }
 
-// Last positions the cursor at the last key in the ordered list
+// Last positions the cursor at the last key in the range
 func (hc *HistoryCursor) Last() (thingValue thing.ThingValue, valid bool) {
-	// the beginning of time?
-	until := time.Time{}
-	k, v, valid := hc.bc.Last()
-	if !valid {
-		// bucket is empty
-		return thingValue, false
-	}
-	thingValue, valid = hc.decodeValue(k, v)
-	if valid && hc.filterName != "" && thingValue.ID != hc.filterName {
-		thingValue, valid = hc.findPrevName(hc.filterName, until)
+	var k string
+	var v []byte
+	valid = false
+	if hc.endMsec > 0 {
+		// the last key before the end of the range
+		_, _, valid = hc.bc.Seek(strconv.FormatInt(hc.endMsec, 10))
+		if valid {
+			k, v, valid = hc.bc.Prev()
+			return hc.findPrev(k, v, valid)
+		}
 	}
-	return thingValue, valid
+	// the end of the range is past the last key
+	k, v, valid = hc.bc.Last()
+	return hc.findPrev(k, v, valid)
 }
 
 // Next moves the cursor to the next key from the current cursor
 // First() or Seek must have been called first.
 func (hc *HistoryCursor) Next() (thingValue thing.ThingValue, valid bool) {
-	until := time.Now()
-	if hc.filterName != "" {
-		thingValue, valid = hc.findNextName(hc.filterName, until)
-		return thingValue, valid
-	}
 	k, v, valid := hc.bc.Next()
-	if !valid {
-		// at the end
-		return thingValue, false
-	}
-	thingValue, valid = hc.decodeValue(k, v)
-	return thingValue, valid
+	return hc.findNext(k, v, valid)
 }
 
 // NextN moves the cursor to the next N places from the current cursor
@@ -190,20 +197,8 @@ func (hc *HistoryCursor) NextN(steps uint) (values []thing.ThingValue, itemsRema
 // Prev moves the cursor to the previous key from the current cursor
 // Last() or Seek must have been called first.
 func (hc *HistoryCursor) Prev() (thingValue thing.ThingValue, valid bool) {
-	until := time.Time{}
-	if hc.filterName != "" {
-		thingValue, valid = hc.findPrevName(hc.filterName, until)
-		return thingValue, valid
-	}
-
 	k, v, valid := hc.bc.Prev()
-	if !valid {
-		// at begin
-		return thingValue, false
-	}
-
-	thingValue, valid = hc.decodeValue(k, v)
-	return thingValue, valid
+	return hc.findPrev(k, v, valid)
 }
 
 // PrevN moves the cursor back N places from the current cursor
@@ -232,9 +227,9 @@ func (hc *HistoryCursor) Release() {
 
 // Seek positions the cursor at the given searchKey and corresponding value.
 // If the key is not found, the next key is returned.
+// A timestamp before the start of the range seeks the start of the range.
 // cursor.Close must be invoked after use in order to close any read transactions.
 func (hc *HistoryCursor) Seek(isoTimestamp string) (thingValue thing.ThingValue, valid bool) {
-	until := time.Now()
 	ts, err := dateparse.ParseAny(isoTimestamp)
 	if err != nil {
 		logrus.Infof("Seek using invalid timestamp '%s'. Pub/ThingID='%s/%s'",
@@ -244,19 +239,13 @@ func (hc *HistoryCursor) Seek(isoTimestamp string) (thingValue thing.ThingValue,
 	}
 
 	timeMilli := ts.UnixMilli()
+	if timeMilli < hc.startMsec {
+		timeMilli = hc.startMsec
+	}
 	searchKey := strconv.FormatInt(timeMilli, 10) //+ "/" + thingValue.ID
 
 	k, v, valid := hc.bc.Seek(searchKey)
-	if !valid {
-		// empty bucket
-		return thingValue, false
-	}
-	thingValue, valid = hc.decodeValue(k, v)
-	if valid && hc.filterName != "" && thingValue.ID != hc.filterName {
-		thingValue, valid = hc.findNextName(hc.filterName, until)
-	}
-
-	return thingValue, valid
+	return hc.findNext(k, v, valid)
 }
 
 // NewHistoryCursor creates a new History Cursor for iterating the underlying bucket
@@ -265,15 +254,40 @@ func (hc *HistoryCursor) Seek(isoTimestamp string) (thingValue thing.ThingValue,
 //	filterName is an optional filter on value names, eg action, event, property or name
 //	bucketStore to get the iteration bucket from
 func NewHistoryCursor(publisherID, thingID string, filterName string, store bucketstore.IBucketStore) *HistoryCursor {
+	var names []string
+	if filterName != "" {
+		names = []string{filterName}
+	}
+	return NewHistoryRangeCursor(publisherID, thingID, 0, 0, names, "", store)
+}
+
+// NewHistoryRangeCursor creates a new History Cursor for iterating a time range of the underlying bucket
+//
+//	publisherID, thingID is the address the Thing can be reached at.
+//	startMsec is the start of the range in msec since epoch, or 0 for the beginning
+//	endMsec is the end of the range in msec since epoch, exclusive, or 0 for no end
+//	names is an optional filter on value names, nil for all names
+//	valueType is the key suffix of the values to include: "a" for actions, "e" for events, "" for both
+//	bucketStore to get the iteration bucket from
+func NewHistoryRangeCursor(publisherID, thingID string, startMsec int64, endMsec int64,
+	names []string, valueType string, store bucketstore.IBucketStore) *HistoryCursor {
+
 	thingAddr := publisherID + "/" + thingID
 	bucket := store.GetBucket(thingAddr)
 	bucketCursor := bucket.Cursor()
+	nameMap := make(map[string]bool)
+	for _, name := range names {
+		nameMap[name] = true
+	}
 	hc := &HistoryCursor{
 		publisherID: publisherID,
 		thingID:     thingID,
+		startMsec:   startMsec,
+		endMsec:     endMsec,
+		names:       nameMap,
+		valueType:   valueType,
 		bucket:      bucket,
 		bc:          bucketCursor,
-		filterName:  filterName,
 	}
 	return hc
 }
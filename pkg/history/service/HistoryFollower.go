@@ -0,0 +1,466 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/history"
+	"github.com/hiveot/hub/pkg/history/config"
+	"github.com/hiveot/hub/pkg/pubsub/core"
+)
+
+// DefaultReplayBatchSize is the maximum number of values that are read from the store in a single replay batch
+const DefaultReplayBatchSize = 100
+
+// HistoryFollower replays the history of a Thing and then passes values to a handler as they are added.
+//
+// The follower is registered before the replay starts, with the sequence number of the last
+// stored value. Values that are stored after registering are queued by the follower. The replay
+// skips values with a higher sequence number as they are passed from the queue. This way
+// values are neither missed nor passed twice.
+//
+// The queue is bounded. When it is full, values are handled according to the overflow policy
+// of the registry, like the subscriptions of the pubsub service. A follower that is released by
+// the overflow policy passes a history.EventNameFollowerClosed value to the handler.
+// This implements the IHistoryFollower interface.
+type HistoryFollower struct {
+	// filter with the Thing address, start of the range, names and value type.
+	// Only used to match and decode values, its bucket is not used.
+	filter HistoryCursor
+	store  bucketstore.IBucketStore
+	// the handler to pass the values to
+	handler func(thingValue thing.ThingValue)
+	// the registry the follower is registered with
+	followers *HistoryFollowers
+	// sequence of the last stored value when the follower was registered
+	startSequence uint64
+
+	mux sync.Mutex
+	// signal that values are queued or the follower is stopped
+	cond *sync.Cond
+	// values that were added after registering and not yet passed to the handler
+	queue   []thing.ThingValue
+	stopped bool
+	// reason the follower was released by the registry, nil if not released by the registry
+	closeReason error
+	// nr of values dropped because the queue was full
+	dropped uint64
+}
+
+// enqueue queues the stored value if it matches the filter
+//
+//	key of the stored record, constructed as {timestamp}/{valueName}/{a|e}
+//	raw is the stored record
+func (f *HistoryFollower) enqueue(key string, raw []byte) {
+	timestampMsec, match := f.filter.matchKey(key)
+	if !match || timestampMsec < f.filter.startMsec {
+		return
+	}
+	thingValue, valid := f.filter.decodeValue(key, raw)
+	if !valid {
+		return
+	}
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	if f.stopped {
+		return
+	}
+	if len(f.queue) < f.followers.queueSize {
+		f.queue = append(f.queue, thingValue)
+		f.cond.Signal()
+		return
+	}
+	f.dropped++
+	switch f.followers.overflowPolicy {
+	case core.OverflowDisconnect:
+		logrus.Warningf("follower of '%s/%s' is too slow. Releasing it.",
+			f.filter.publisherID, f.filter.thingID)
+		f.stopped = true
+		f.closeReason = fmt.Errorf("follower queue of '%s/%s' is full",
+			f.filter.publisherID, f.filter.thingID)
+		f.queue = nil
+		f.cond.Broadcast()
+		// notify holds the registry lock, so remove the follower after it is released
+		go f.followers.remove(f)
+		return
+	case core.OverflowDropNewest:
+	default:
+		// make room by removing the oldest value
+		f.queue = append(f.queue[1:], thingValue)
+	}
+	if f.dropped == 1 || f.dropped%1000 == 0 {
+		logrus.Warningf("follower of '%s/%s' is too slow. %d values dropped.",
+			f.filter.publisherID, f.filter.thingID, f.dropped)
+	}
+}
+
+// closed tells the handler that the follower was released by the registry.
+// This does nothing if the follower was released by its owner.
+func (f *HistoryFollower) closed() {
+	f.mux.Lock()
+	reason := f.closeReason
+	f.mux.Unlock()
+	if reason != nil {
+		tv := thing.NewThingValue(history.ServiceName, history.ServiceName,
+			history.EventNameFollowerClosed, []byte(reason.Error()))
+		f.handler(tv)
+	}
+}
+
+// isStopped returns true if the follower is released
+func (f *HistoryFollower) isStopped() bool {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+	return f.stopped
+}
+
+// replayBatch returns a batch of values from the store that were added before the follower was
+// registered. The bucket is closed before returning so the handler doesn't hold a read transaction.
+//
+//	startKey to seek or "" to start at the start of the range. The start key itself is skipped.
+//
+// This returns the values, the last key that was iterated, and done is true if the replay has completed.
+func (f *HistoryFollower) replayBatch(startKey string, batchSize int) (
+	values []thing.ThingValue, lastKey string, done bool) {
+
+	values = make([]thing.ThingValue, 0, batchSize)
+	bucket := f.store.GetBucket(f.filter.publisherID + "/" + f.filter.thingID)
+	defer bucket.Close()
+	cursor := bucket.Cursor()
+	defer cursor.Release()
+
+	var k string
+	var v []byte
+	var valid bool
+	if startKey == "" {
+		k, v, valid = cursor.Seek(strconv.FormatInt(f.filter.startMsec, 10))
+	} else {
+		k, v, valid = cursor.Seek(startKey)
+		if valid && k == startKey {
+			k, v, valid = cursor.Next()
+		}
+	}
+	for ; valid; k, v, valid = cursor.Next() {
+		lastKey = k
+		if _, match := f.filter.matchKey(k); !match {
+			continue
+		}
+		if decodeRecord(v).Sequence > f.startSequence {
+			// added after registering, this value is queued
+			continue
+		}
+		thingValue, isValid := f.filter.decodeValue(k, v)
+		if !isValid {
+			continue
+		}
+		// the data is only valid until the cursor is released
+		thingValue.Data = append([]byte(nil), thingValue.Data...)
+		values = append(values, thingValue)
+		if len(values) >= batchSize {
+			return values, lastKey, false
+		}
+	}
+	return values, lastKey, true
+}
+
+// replay passes the values that were stored before the follower was registered to the handler.
+// This returns false if the follower was released during the replay.
+func (f *HistoryFollower) replay() bool {
+	startKey := ""
+	for {
+		values, lastKey, done := f.replayBatch(startKey, DefaultReplayBatchSize)
+		for _, thingValue := range values {
+			if f.isStopped() {
+				return false
+			}
+			f.handler(thingValue)
+		}
+		if done {
+			return true
+		}
+		startKey = lastKey
+	}
+}
+
+// run replays the history and then passes the queued values to the handler until the follower is released
+func (f *HistoryFollower) run() {
+	completed := f.replay()
+	f.followers.endReplay(f.filter.publisherID + "/" + f.filter.thingID)
+	if !completed {
+		f.closed()
+		return
+	}
+	for {
+		f.mux.Lock()
+		for len(f.queue) == 0 && !f.stopped {
+			f.cond.Wait()
+		}
+		if f.stopped {
+			f.mux.Unlock()
+			f.closed()
+			return
+		}
+		values := f.queue
+		f.queue = nil
+		f.mux.Unlock()
+		for _, thingValue := range values {
+			f.handler(thingValue)
+		}
+	}
+}
+
+// Release stops following the history
+func (f *HistoryFollower) Release() {
+	f.mux.Lock()
+	stopped := f.stopped
+	f.stopped = true
+	f.queue = nil
+	f.cond.Broadcast()
+	f.mux.Unlock()
+	if !stopped {
+		f.followers.remove(f)
+	}
+}
+
+// NewHistoryFollower creates a follower of the history of a Thing.
+// Use HistoryFollowers.Follow to start following.
+//
+//	publisherID, thingID is the address the Thing can be reached at.
+//	startMsec is the start of the range in msec since epoch, or 0 for the beginning
+//	names is an optional filter on value names, nil for all names
+//	valueType is the key suffix of the values to include: "a" for actions, "e" for events, "" for both
+//	handler is invoked with each value
+//	store with the history buckets
+func NewHistoryFollower(publisherID, thingID string, startMsec int64, names []string, valueType string,
+	handler func(thingValue thing.ThingValue), store bucketstore.IBucketStore) *HistoryFollower {
+
+	nameMap := make(map[string]bool)
+	for _, name := range names {
+		nameMap[name] = true
+	}
+	f := &HistoryFollower{
+		filter: HistoryCursor{
+			publisherID: publisherID,
+			thingID:     thingID,
+			startMsec:   startMsec,
+			names:       nameMap,
+			valueType:   valueType,
+		},
+		store:   store,
+		handler: handler,
+	}
+	f.cond = sync.NewCond(&f.mux)
+	return f
+}
+
+// HistoryFollowers is the registry of followers of the history.
+// AddHistory holds the registry's read lock while it stores values and passes them to the
+// followers, so a follower is registered either before or after a value is stored.
+//
+// The compactor rewrites values without passing them to the followers. To avoid that a replay
+// misses the rewritten values, a Thing is not compacted while its history is replayed, and a
+// replay waits for a running compaction of the Thing to complete.
+type HistoryFollowers struct {
+	mux sync.RWMutex
+	// followers by thing address
+	followers map[string]map[*HistoryFollower]bool
+	// max nr of values queued for each follower
+	queueSize int
+	// policy when the queue of a follower is full, one of core.OverflowXyz
+	overflowPolicy string
+	// sequence number of the last added record
+	lastSequence atomic.Uint64
+
+	// replays and compactions in progress by thing address
+	replayMux  sync.Mutex
+	replayCond *sync.Cond
+	replaying  map[string]int
+	compacting map[string]bool
+}
+
+// beginCompact marks the Thing as being compacted.
+// This returns false if the history of the Thing is being replayed and can't be compacted.
+// endCompact must be called when the compaction has completed.
+func (hf *HistoryFollowers) beginCompact(thingAddr string) bool {
+	if hf == nil {
+		return true
+	}
+	hf.replayMux.Lock()
+	defer hf.replayMux.Unlock()
+	if hf.replaying[thingAddr] > 0 {
+		return false
+	}
+	hf.compacting[thingAddr] = true
+	return true
+}
+
+// endCompact ends the compaction of the Thing and resumes waiting replays
+func (hf *HistoryFollowers) endCompact(thingAddr string) {
+	if hf == nil {
+		return
+	}
+	hf.replayMux.Lock()
+	delete(hf.compacting, thingAddr)
+	hf.replayCond.Broadcast()
+	hf.replayMux.Unlock()
+}
+
+// beginReplay waits until a running compaction of the Thing has completed, and marks the
+// history of the Thing as being replayed. endReplay must be called when the replay has ended.
+func (hf *HistoryFollowers) beginReplay(thingAddr string) {
+	hf.replayMux.Lock()
+	defer hf.replayMux.Unlock()
+	for hf.compacting[thingAddr] {
+		hf.replayCond.Wait()
+	}
+	hf.replaying[thingAddr]++
+}
+
+// endReplay ends the replay of the history of the Thing
+func (hf *HistoryFollowers) endReplay(thingAddr string) {
+	hf.replayMux.Lock()
+	defer hf.replayMux.Unlock()
+	hf.replaying[thingAddr]--
+	if hf.replaying[thingAddr] <= 0 {
+		delete(hf.replaying, thingAddr)
+	}
+}
+
+// beginAdd locks the registry while values are stored.
+// endAdd must be called after the stored values are passed to notify.
+func (hf *HistoryFollowers) beginAdd() {
+	if hf != nil {
+		hf.mux.RLock()
+	}
+}
+
+// endAdd unlocks the registry after values are stored
+func (hf *HistoryFollowers) endAdd() {
+	if hf != nil {
+		hf.mux.RUnlock()
+	}
+}
+
+// notify passes a stored value to the followers of its Thing.
+// This must be called between beginAdd and endAdd.
+//
+//	thingAddr is the address of the thing, eg publisherID/thingID
+//	key of the stored record, constructed as {timestamp}/{valueName}/{a|e}
+//	raw is the stored record
+func (hf *HistoryFollowers) notify(thingAddr string, key string, raw []byte) {
+	if hf == nil {
+		return
+	}
+	for f := range hf.followers[thingAddr] {
+		f.enqueue(key, raw)
+	}
+}
+
+// Follow registers the follower and starts the replay.
+// If the Thing is being compacted, this waits until the compaction has completed.
+func (hf *HistoryFollowers) Follow(f *HistoryFollower) {
+	thingAddr := f.filter.publisherID + "/" + f.filter.thingID
+	hf.beginReplay(thingAddr)
+	hf.mux.Lock()
+	f.followers = hf
+	// values with a higher sequence are stored after registering
+	f.startSequence = hf.lastSequence.Load()
+	thingFollowers, found := hf.followers[thingAddr]
+	if !found {
+		thingFollowers = make(map[*HistoryFollower]bool)
+		hf.followers[thingAddr] = thingFollowers
+	}
+	thingFollowers[f] = true
+	hf.mux.Unlock()
+	go f.run()
+}
+
+// nextSequence returns the sequence number for a new record.
+// Without a registry there are no followers and the sequence is 0.
+func (hf *HistoryFollowers) nextSequence() uint64 {
+	if hf == nil {
+		return 0
+	}
+	return hf.lastSequence.Add(1)
+}
+
+// seedSequence raises the sequence number of the last added record to the given sequence
+// if it is lower. Returns the resulting sequence number of the last added record.
+func (hf *HistoryFollowers) seedSequence(sequence uint64) uint64 {
+	for {
+		last := hf.lastSequence.Load()
+		if sequence <= last {
+			return last
+		}
+		if hf.lastSequence.CompareAndSwap(last, sequence) {
+			return sequence
+		}
+	}
+}
+
+// remove the follower from the registry
+func (hf *HistoryFollowers) remove(f *HistoryFollower) {
+	if hf == nil {
+		return
+	}
+	thingAddr := f.filter.publisherID + "/" + f.filter.thingID
+	hf.mux.Lock()
+	defer hf.mux.Unlock()
+	delete(hf.followers[thingAddr], f)
+	if len(hf.followers[thingAddr]) == 0 {
+		delete(hf.followers, thingAddr)
+	}
+}
+
+// Stop releases all followers
+func (hf *HistoryFollowers) Stop() {
+	hf.mux.RLock()
+	followers := make([]*HistoryFollower, 0)
+	for _, thingFollowers := range hf.followers {
+		for f := range thingFollowers {
+			followers = append(followers, f)
+		}
+	}
+	hf.mux.RUnlock()
+	for _, f := range followers {
+		f.Release()
+	}
+}
+
+// NewHistoryFollowers creates a registry for followers of the history
+//
+//	queueSize is the max nr of values queued for each follower, 0 for DefaultFollowQueueSize
+//	overflowPolicy when a queue is full, one of core.OverflowDropOldest, OverflowDropNewest or
+//	OverflowDisconnect. Default is OverflowDropOldest.
+func NewHistoryFollowers(queueSize int, overflowPolicy string) *HistoryFollowers {
+	if queueSize <= 0 {
+		queueSize = config.DefaultFollowQueueSize
+	}
+	if overflowPolicy == "" {
+		overflowPolicy = core.OverflowDropOldest
+	} else if overflowPolicy != core.OverflowDropOldest &&
+		overflowPolicy != core.OverflowDropNewest &&
+		overflowPolicy != core.OverflowDisconnect {
+		logrus.Errorf("unknown overflow policy '%s'. Using '%s'", overflowPolicy, core.OverflowDropOldest)
+		overflowPolicy = core.OverflowDropOldest
+	}
+	hf := &HistoryFollowers{
+		followers:      make(map[string]map[*HistoryFollower]bool),
+		queueSize:      queueSize,
+		overflowPolicy: overflowPolicy,
+		replaying:      make(map[string]int),
+		compacting:     make(map[string]bool),
+	}
+	hf.replayCond = sync.NewCond(&hf.replayMux)
+	// sequence numbers start at the current time. The service raises it to the sequence
+	// reserved by the previous run, in case the clock is set back.
+	hf.lastSequence.Store(uint64(time.Now().UnixNano()))
+	return hf
+}
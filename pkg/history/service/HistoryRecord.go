@@ -0,0 +1,254 @@
+package service
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/araddon/dateparse"
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/bucketstore"
+)
+
+// History record encoding versions.
+//
+// Version 0 is the legacy encoding where the record only holds the value data and the
+// timestamp in msec and name are taken from the key.
+//
+// Version 1 is a compact binary encoding:
+//
+//	byte 0: recordMagic
+//	byte 1: version
+//	varint: created time in nsec since epoch
+//	varint: timezone offset of the created time in seconds east of UTC
+//	uvarint: sequence number
+//	uvarint: length of publisherID, followed by the publisherID
+//	remaining bytes: the value data
+const (
+	RecordVersionLegacy = 0
+	RecordVersion1      = 1
+	// RecordVersion is the version used to encode new records
+	RecordVersion = RecordVersion1
+)
+
+// DefaultMigrateBatchSize is the maximum number of records that are migrated in a single batch
+const DefaultMigrateBatchSize = 1000
+
+// recordMagic is the first byte of a versioned record. Legacy records hold JSON or text
+// encoded data which never starts with a zero byte.
+const recordMagic = 0x00
+
+// createdFormat is the ISO8601 format of the created time with up to nsec precision.
+// Values with msec precision have the same format as vocab.ISO8601Format.
+const createdFormat = "2006-01-02T15:04:05.999999999-0700"
+
+// historyRecord holds the stored fields of a history value
+type historyRecord struct {
+	// the encoding version the record was read from
+	Version int
+	// created time in nsec since epoch
+	CreatedNsec int64
+	// timezone offset of the created time in seconds east of UTC
+	ZoneOffset int
+	// sequence in which the service added the values. 0 for migrated values.
+	Sequence uint64
+	// publisher of the value
+	PublisherID string
+	// the value data
+	Data []byte
+}
+
+// Created returns the created time of the record in its original timezone
+func (rec *historyRecord) Created() time.Time {
+	return time.Unix(0, rec.CreatedNsec).In(time.FixedZone("", rec.ZoneOffset))
+}
+
+// encodeRecord encodes the record in the current record version
+func encodeRecord(rec historyRecord) []byte {
+	buf := make([]byte, 2, 4*binary.MaxVarintLen64+len(rec.PublisherID)+len(rec.Data))
+	buf[0] = recordMagic
+	buf[1] = RecordVersion1
+	buf = binary.AppendVarint(buf, rec.CreatedNsec)
+	buf = binary.AppendVarint(buf, int64(rec.ZoneOffset))
+	buf = binary.AppendUvarint(buf, rec.Sequence)
+	buf = binary.AppendUvarint(buf, uint64(len(rec.PublisherID)))
+	buf = append(buf, rec.PublisherID...)
+	buf = append(buf, rec.Data...)
+	return buf
+}
+
+// decodeRecord decodes a stored record.
+// Records that are not versioned are returned as a legacy record holding only the data.
+// The data of the record refers to the raw buffer.
+func decodeRecord(raw []byte) (rec historyRecord) {
+	if len(raw) < 2 || raw[0] != recordMagic || raw[1] != RecordVersion1 {
+		return historyRecord{Version: RecordVersionLegacy, Data: raw}
+	}
+	rec.Version = RecordVersion1
+	buf := raw[2:]
+	var n int
+	var zoneOffset int64
+	var idLen uint64
+	legacy := historyRecord{Version: RecordVersionLegacy, Data: raw}
+	if rec.CreatedNsec, n = binary.Varint(buf); n <= 0 {
+		return legacy
+	}
+	buf = buf[n:]
+	if zoneOffset, n = binary.Varint(buf); n <= 0 {
+		return legacy
+	}
+	rec.ZoneOffset = int(zoneOffset)
+	buf = buf[n:]
+	if rec.Sequence, n = binary.Uvarint(buf); n <= 0 {
+		return legacy
+	}
+	buf = buf[n:]
+	if idLen, n = binary.Uvarint(buf); n <= 0 || uint64(len(buf)-n) < idLen {
+		return legacy
+	}
+	buf = buf[n:]
+	rec.PublisherID = string(buf[:idLen])
+	rec.Data = buf[idLen:]
+	return rec
+}
+
+// decodeData returns the value data of a stored record of any version
+func decodeData(raw []byte) []byte {
+	return decodeRecord(raw).Data
+}
+
+// newRecord creates a record for a value.
+// If the value has no valid created time the current time is used.
+//
+//	thingValue to create the record for
+//	sequence in which the value is added
+func newRecord(thingValue thing.ThingValue, sequence uint64) historyRecord {
+	ts := time.Now()
+	if thingValue.Created != "" {
+		created, err := dateparse.ParseAny(thingValue.Created)
+		if err != nil {
+			logrus.Infof("Invalid Created time '%s'. Using current time instead", thingValue.Created)
+		} else {
+			ts = created
+		}
+	}
+	_, zoneOffset := ts.Zone()
+	return historyRecord{
+		CreatedNsec: ts.UnixNano(),
+		ZoneOffset:  zoneOffset,
+		Sequence:    sequence,
+		PublisherID: thingValue.PublisherID,
+		Data:        thingValue.Data,
+	}
+}
+
+// migrateRecord converts a legacy record to the current version.
+// This returns nil if the record doesn't need migration.
+//
+//	key of the record, constructed as {timestamp}/{valueName}/{a|e}
+//	raw is the stored record
+//	publisherID of the Thing whose bucket holds the record
+func migrateRecord(key string, raw []byte, publisherID string) ([]byte, error) {
+	rec := decodeRecord(raw)
+	if rec.Version == RecordVersion {
+		return nil, nil
+	}
+	parts := strings.Split(key, "/")
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("invalid history key '%s'", key)
+	}
+	timestampMsec, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp in history key '%s'", key)
+	}
+	ts := time.UnixMilli(timestampMsec)
+	_, zoneOffset := ts.Zone()
+	rec = historyRecord{
+		CreatedNsec: ts.UnixNano(),
+		ZoneOffset:  zoneOffset,
+		PublisherID: publisherID,
+		// the record is copied so the data remains valid after the cursor is released
+		Data: raw,
+	}
+	return encodeRecord(rec), nil
+}
+
+// migrateBatch iterates the bucket from the given key and returns a batch of legacy records
+// converted to the current version.
+// The cursor is released before returning so the batch can be written without holding a read transaction.
+//
+//	startKey to seek or "" to start at the first key. The start key itself is skipped.
+//
+// This returns the migrated records, the last key that was iterated, and done is true if the
+// iteration has completed.
+func migrateBatch(bucket bucketstore.IBucket, publisherID string, startKey string, batchSize int) (
+	migrated map[string][]byte, lastKey string, done bool) {
+
+	migrated = make(map[string][]byte)
+	cursor := bucket.Cursor()
+	defer cursor.Release()
+
+	var k string
+	var v []byte
+	var valid bool
+	if startKey == "" {
+		k, v, valid = cursor.First()
+	} else {
+		k, v, valid = cursor.Seek(startKey)
+		if valid && k == startKey {
+			k, v, valid = cursor.Next()
+		}
+	}
+	for ; valid; k, v, valid = cursor.Next() {
+		lastKey = k
+		record, err := migrateRecord(k, v, publisherID)
+		if err != nil {
+			logrus.Warningf("skipping record: %s", err)
+			continue
+		} else if record == nil {
+			continue
+		}
+		migrated[k] = record
+		if len(migrated) >= batchSize {
+			return migrated, lastKey, false
+		}
+	}
+	return migrated, lastKey, true
+}
+
+// MigrateThing converts the legacy records in the history bucket of a Thing to the
+// current record version. Records are migrated in batches.
+//
+//	store with the Thing history buckets
+//	thingAddr is the address of the thing, eg publisherID/thingID
+//
+// This returns the number of migrated records.
+func MigrateThing(store bucketstore.IBucketStore, thingAddr string) (nrMigrated int, err error) {
+	parts := strings.Split(thingAddr, "/")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid thing address '%s'", thingAddr)
+	}
+	bucket := store.GetBucket(thingAddr)
+	defer bucket.Close()
+	startKey := ""
+	for {
+		migrated, lastKey, done := migrateBatch(bucket, parts[0], startKey, DefaultMigrateBatchSize)
+		if len(migrated) > 0 {
+			err = bucket.SetMultiple(migrated)
+			if err != nil {
+				logrus.Errorf("failed migrating history of '%s': %s", thingAddr, err)
+				return nrMigrated, err
+			}
+			nrMigrated += len(migrated)
+		}
+		if done {
+			break
+		}
+		startKey = lastKey
+	}
+	return nrMigrated, nil
+}
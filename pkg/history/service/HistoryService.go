@@ -2,28 +2,63 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
+	"github.com/hiveot/hub/lib/thing"
 	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/bucketstore/cmd"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
+	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
 	"github.com/hiveot/hub/pkg/history"
 	"github.com/hiveot/hub/pkg/history/config"
+	"github.com/hiveot/hub/pkg/history/mongohs"
 	"github.com/hiveot/hub/pkg/pubsub"
 )
 
 const PropertiesBucketName = "properties"
 
+// HistoryInfoBucketName is the name of the bucket that holds the state of the history store
+const HistoryInfoBucketName = "historyInfo"
+
+// recordVersionKey is the key in the info bucket that holds the record version of the history buckets
+const recordVersionKey = "recordVersion"
+
+// LastSequenceKey is the key in the info bucket that holds the sequence number reserved for
+// the records added by the running service, or the last used sequence number after a stop.
+const LastSequenceKey = "lastSequence"
+
+// sequenceReserve is the amount of sequence numbers that is reserved on startup.
+// Sequence numbers start at the current time in nsec, so this is about 18 minutes worth.
+const sequenceReserve = 1 << 40
+
 // HistoryService provides storage for action and event history using the bucket store
 // Each Thing has a bucket with events and actions.
+// With the mongodb backend the history is stored in a MongoDB time-series collection instead.
 // This implements the IHistoryService interface
 type HistoryService struct {
 
 	// The history service bucket store with a bucket for each Thing
 	bucketStore bucketstore.IBucketStore
+	// The optional time-series store that holds the history instead of the bucket store
+	mongoStore *mongohs.MongoHistoryStore
 	// Storage of the latest properties of a thing
 	propsStore *LastPropertiesStore
+	// followers of the history that are passed new values as they are added
+	followers *HistoryFollowers
 	// handling of retention of pubsub events
 	retentionMgr *ManageRetention
+	// removal of values that exceed their retention period
+	retentionSweeper *RetentionSweeper
+	// interval between purging of expired values
+	purgeInterval time.Duration
+	// downsampling of aging values
+	compactor *HistoryCompactor
+	// interval between compaction of values
+	compactInterval time.Duration
 	// Instance ID of this service
 	serviceID string
 	// the pubsub service to subscribe to event
@@ -32,6 +67,19 @@ type HistoryService struct {
 	subEventHandler *PubSubEventHandler
 }
 
+// Backup writes a snapshot of the history bucket store into the given directory
+func (svc *HistoryService) Backup(_ context.Context, directory string) error {
+	logrus.Infof("writing backup of the history store to '%s'", directory)
+	if svc.mongoStore != nil {
+		logrus.Warningf("the mongodb history is not included in the backup. Use the mongodb tools instead.")
+	}
+	err := svc.bucketStore.Snapshot(directory)
+	if err != nil {
+		logrus.Errorf("backup failed: %s", err)
+	}
+	return err
+}
+
 // CapAddHistory provides the capability to add to the history of any Thing.
 // This capability should only be provided to trusted services that capture events from multiple sources
 // and can verify their authenticity.
@@ -40,14 +88,28 @@ func (svc *HistoryService) CapAddHistory(
 
 	logrus.Infof("clientID=%s", clientID)
 
+	return svc.newAddHistory(clientID, ignoreRetention), nil
+}
+
+// newAddHistory returns the capability to add history to the store of the configured backend
+func (svc *HistoryService) newAddHistory(clientID string, ignoreRetention bool) history.IAddHistory {
 	var retentionMgr *ManageRetention
 	if !ignoreRetention {
 		retentionMgr = svc.retentionMgr
 	}
-
-	historyUpdater := NewAddHistory(
-		clientID, svc.bucketStore, retentionMgr, svc.propsStore.HandleAddValue)
-	return historyUpdater, nil
+	if svc.mongoStore != nil {
+		var retention history.IManageRetention
+		var isChanged func(value thing.ThingValue) bool
+		if retentionMgr != nil {
+			retention = retentionMgr
+			isChanged = func(value thing.ThingValue) bool {
+				return retentionMgr.IsChanged(value, svc.propsStore.GetProperties)
+			}
+		}
+		return mongohs.NewMongoAddHistory(clientID, svc.mongoStore, retention, svc.propsStore.HandleAddValue, isChanged)
+	}
+	return NewAddHistory(clientID, svc.bucketStore, retentionMgr,
+		svc.propsStore.HandleAddValue, svc.propsStore.GetProperties, svc.followers)
 }
 
 // CapManageRetention returns the capability to manage the retention of events
@@ -69,24 +131,141 @@ func (svc *HistoryService) CapReadHistory(_ context.Context, clientID string) (
 	history.IReadHistory, error) {
 
 	logrus.Infof("clientID=%s", clientID)
+	if svc.mongoStore != nil {
+		return mongohs.NewMongoReadHistory(clientID, svc.mongoStore, svc.propsStore.GetProperties), nil
+	}
 	//thingAddr := publisherID + "/" + thingID
 	//bucket := svc.bucketStore.GetBucket(thingAddr)
-	readHistory := NewReadHistory(clientID, svc.bucketStore, svc.propsStore.GetProperties)
+	readHistory := NewReadHistory(clientID, svc.bucketStore, svc.propsStore.GetProperties, svc.followers)
 	return readHistory, nil
 }
 
+// MigrateRecords converts the legacy records in the history buckets of all Things to the
+// current record version. Legacy records remain readable so this is only needed once.
+// This is run on startup if the store hasn't been migrated yet.
+// Returns the number of migrated records.
+func (svc *HistoryService) MigrateRecords() (nrMigrated int, err error) {
+	if svc.mongoStore != nil {
+		return 0, nil
+	}
+	thingAddrs := svc.propsStore.GetThingAddresses()
+	for _, thingAddr := range thingAddrs {
+		nrThingMigrated, err2 := MigrateThing(svc.bucketStore, thingAddr)
+		nrMigrated += nrThingMigrated
+		if err2 != nil {
+			err = err2
+		}
+	}
+	logrus.Infof("migrated %d history records of %d things to record version %d",
+		nrMigrated, len(thingAddrs), RecordVersion)
+	if err == nil {
+		infoBucket := svc.bucketStore.GetBucket(HistoryInfoBucketName)
+		err = infoBucket.Set(recordVersionKey, []byte(strconv.Itoa(RecordVersion)))
+		_ = infoBucket.Close()
+	}
+	return nrMigrated, err
+}
+
+// migrateIfNeeded migrates the records if the store has an older record version
+func (svc *HistoryService) migrateIfNeeded() error {
+	infoBucket := svc.bucketStore.GetBucket(HistoryInfoBucketName)
+	raw, _ := infoBucket.Get(recordVersionKey)
+	_ = infoBucket.Close()
+	version, _ := strconv.Atoi(string(raw))
+	if version >= RecordVersion {
+		return nil
+	}
+	_, err := svc.MigrateRecords()
+	return err
+}
+
+// reserveSequences raises the sequence numbers of new records above those used by the previous
+// run and stores the sequence numbers reserved for this run. The previous run can have used
+// higher sequence numbers than the current time if the clock was set back since, or if it
+// was ahead, for example on a device without a realtime clock that hasn't synced yet.
+// Followers of the history rely on increasing sequence numbers to avoid duplicate values.
+func (svc *HistoryService) reserveSequences() error {
+	infoBucket := svc.bucketStore.GetBucket(HistoryInfoBucketName)
+	defer infoBucket.Close()
+	raw, _ := infoBucket.Get(LastSequenceKey)
+	reserved, _ := strconv.ParseUint(string(raw), 10, 64)
+	lastSequence := svc.followers.seedSequence(reserved)
+	return infoBucket.Set(LastSequenceKey, []byte(strconv.FormatUint(lastSequence+sequenceReserve, 10)))
+}
+
+// saveSequence stores the sequence number of the last added record
+func (svc *HistoryService) saveSequence() error {
+	infoBucket := svc.bucketStore.GetBucket(HistoryInfoBucketName)
+	defer infoBucket.Close()
+	lastSequence := svc.followers.lastSequence.Load()
+	return infoBucket.Set(LastSequenceKey, []byte(strconv.FormatUint(lastSequence, 10)))
+}
+
+// PurgeExpired removes the history values that have exceeded their retention period.
+// This is also run periodically in the background if a purge interval is configured.
+// Returns the number of removed records.
+func (svc *HistoryService) PurgeExpired() (nrRemoved int, err error) {
+	return svc.retentionSweeper.PurgeExpired()
+}
+
+// CompactHistory downsamples the history values using the compaction rules of their event.
+// This is also run periodically in the background if a compaction interval is configured.
+// Returns the number of removed records.
+func (svc *HistoryService) CompactHistory() (nrRemoved int, err error) {
+	if svc.compactor == nil {
+		return 0, fmt.Errorf("compaction is not supported by the mongodb backend")
+	}
+	return svc.compactor.CompactAll()
+}
+
 // Start using the history service
 // This will open the store and panic if the store cannot be opened.
 func (svc *HistoryService) Start() (err error) {
 	logrus.Infof("")
+	// history queries iterate the keys in timestamp order, which encrypted keys don't have
+	if encStore, ok := svc.bucketStore.(*encrypted.EncryptedStore); ok && encStore.KeysEncrypted() {
+		err = fmt.Errorf("the history store doesn't support encrypted keys. Disable encryptKeys")
+		logrus.Error(err)
+		return err
+	}
 	propsbucket := svc.bucketStore.GetBucket(PropertiesBucketName)
 	svc.propsStore = NewPropertiesStore(propsbucket)
 
+	if svc.mongoStore != nil {
+		err = svc.mongoStore.Open()
+		if err != nil {
+			return err
+		}
+	}
+
+	if svc.mongoStore == nil {
+		err = svc.migrateIfNeeded()
+		if err != nil {
+			logrus.Errorf("failed migrating history records: %s", err)
+		}
+		err = svc.reserveSequences()
+		if err != nil {
+			logrus.Errorf("failed reserving record sequence numbers: %s", err)
+			return err
+		}
+	}
+
 	err = svc.retentionMgr.Start()
+	if svc.mongoStore != nil {
+		svc.retentionSweeper = NewMongoRetentionSweeper(svc.mongoStore, svc.retentionMgr, svc.purgeInterval)
+		logrus.Infof("compaction is not supported by the mongodb backend. History will not be compacted.")
+	} else {
+		svc.retentionSweeper = NewRetentionSweeper(
+			svc.bucketStore, svc.retentionMgr, svc.propsStore.GetThingAddresses, svc.purgeInterval)
+		svc.compactor = NewHistoryCompactor(svc.bucketStore, svc.retentionMgr,
+			svc.propsStore.GetThingAddresses, svc.followers, svc.compactInterval)
+		svc.compactor.Start()
+	}
+	svc.retentionSweeper.Start()
 
 	// subscribe to events to add history
 	if err == nil && svc.servicePubSub != nil {
-		capAddEvent := NewAddHistory(svc.serviceID, svc.bucketStore, svc.retentionMgr, svc.propsStore.HandleAddValue)
+		capAddEvent := svc.newAddHistory(svc.serviceID, false)
 		svc.subEventHandler = NewSubEventHandler(svc.servicePubSub, capAddEvent)
 		err = svc.subEventHandler.Start()
 	}
@@ -97,17 +276,56 @@ func (svc *HistoryService) Start() (err error) {
 // Stop using the history service and release resources
 func (svc *HistoryService) Stop() error {
 	logrus.Infof("")
-	err := svc.propsStore.SaveChanges()
-	if err != nil {
-		logrus.Error(err)
+	var err error
+	// the service is also stopped after Start failed
+	if svc.propsStore != nil {
+		err = svc.propsStore.SaveChanges()
+		if err != nil {
+			logrus.Error(err)
+		}
 	}
-	svc.retentionMgr.Stop()
 	if svc.subEventHandler != nil {
 		svc.subEventHandler.Stop()
 	}
+	svc.followers.Stop()
+	if svc.retentionSweeper != nil {
+		svc.retentionSweeper.Stop()
+	}
+	if svc.compactor != nil {
+		svc.compactor.Stop()
+		// the compactor only runs with the bucket store backend
+		err2 := svc.saveSequence()
+		if err2 != nil {
+			logrus.Error(err2)
+		}
+	}
+	svc.retentionMgr.Stop()
+	if svc.mongoStore != nil {
+		_ = svc.mongoStore.Close()
+	}
 	return err
 }
 
+// NewHistoryBucketStore returns the bucket store for the history service using the configured backend.
+// The store is not yet opened. It is also used to restore the store from a backup.
+// The mongodb backend only uses the bucket store for the latest properties and retention rules, using pebble.
+// The store is wrapped in an encrypted store if encryption is enabled in the config.
+func NewHistoryBucketStore(cfg *config.HistoryConfig) bucketstore.IBucketStore {
+	storeBackend := cfg.Backend
+	if storeBackend == bucketstore.BackendMongoDB {
+		storeBackend = bucketstore.BackendPebble
+	}
+	serviceID := cfg.ServiceID
+	if serviceID == "" {
+		serviceID = history.ServiceName
+	}
+	store := cmd.NewBucketStore(cfg.Directory, serviceID, storeBackend)
+	if kvStore, ok := store.(*kvbtree.KVBTreeStore); ok && cfg.SyncPolicy != "" {
+		kvStore.SetSyncPolicy(cfg.SyncPolicy, 0)
+	}
+	return encrypted.NewStoreFromConfig(store, cfg.Encryption, serviceID)
+}
+
 // NewHistoryService creates a new instance for the history service using the given
 // storage bucket.
 //
@@ -118,21 +336,35 @@ func NewHistoryService(
 	config *config.HistoryConfig, store bucketstore.IBucketStore, sub pubsub.IServicePubSub) *HistoryService {
 
 	var retentionMgr *ManageRetention
+	var mongoStore *mongohs.MongoHistoryStore
 	serviceID := history.ServiceName
+	purgeInterval := DefaultPurgeInterval
+	compactInterval := DefaultCompactInterval
+	followers := NewHistoryFollowers(0, "")
 	if config != nil && config.ServiceID == "" {
 		config.ServiceID = history.ServiceName
 	}
 	if config != nil {
-		retentionMgr = NewManageRetention(config.Retention)
+		retentionMgr = NewManageRetention(config.Retention, store)
+		purgeInterval = time.Duration(config.PurgeIntervalSec) * time.Second
+		compactInterval = time.Duration(config.CompactIntervalSec) * time.Second
+		followers = NewHistoryFollowers(config.FollowQueueSize, config.FollowOverflowPolicy)
+		if config.Backend == bucketstore.BackendMongoDB {
+			mongoStore = mongohs.NewMongoHistoryStore(config.MongoURL, config.ServiceID)
+		}
 	} else {
-		retentionMgr = NewManageRetention(nil)
+		retentionMgr = NewManageRetention(nil, store)
 	}
 	svc := &HistoryService{
-		bucketStore:   store,
-		propsStore:    nil,
-		serviceID:     serviceID,
-		retentionMgr:  retentionMgr,
-		servicePubSub: sub,
+		bucketStore:     store,
+		mongoStore:      mongoStore,
+		followers:       followers,
+		propsStore:      nil,
+		serviceID:       serviceID,
+		retentionMgr:    retentionMgr,
+		purgeInterval:   purgeInterval,
+		compactInterval: compactInterval,
+		servicePubSub:   sub,
 	}
 	return svc
 }
@@ -1,15 +1,27 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/araddon/dateparse"
 	"github.com/sirupsen/logrus"
 
+	"github.com/hiveot/hub/api/go/vocab"
 	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/bucketstore"
 	"github.com/hiveot/hub/pkg/history"
 )
 
+// RetentionBucketName is the name of the bucket that holds the retention rules set through the API
+const RetentionBucketName = "retention"
+
 // test if ID exists in the array of strings
 // returns true if array is empty, eg no values to match
 func inArray(arr []string, id string) bool {
@@ -26,15 +38,34 @@ func inArray(arr []string, id string) bool {
 
 // ManageRetention provides the capability to retrieve and edit the event retention configuration.
 // This implements the IManageRetention interface.
+//
+// Retention rules set or removed through the API are persisted in the retention bucket.
+// On startup the rules are merged in the following order:
+//  1. The default rules from the configuration file.
+//  2. Rules set through the API replace defaults with the same event name.
+//  3. Rules removed through the API are removed, even if they exist in the configuration file.
 type ManageRetention struct {
 	// defaults from config file
 	defaultRetentions []history.EventRetention
 	// configuration set through API
 	configuredRetentions map[string]history.EventRetention
+	// the store with the retention bucket. nil to not persist changes
+	store bucketstore.IBucketStore
+	// bucket with persisted retention rules by event name. Removed rules have an empty value.
+	bucket bucketstore.IBucket
+	// mutex to protect the retention map
+	retMux sync.RWMutex
+	// last recorded value of events with a change filter by publisherID/thingID/name.
+	// This is the baseline of the change filter.
+	recorded map[string]thing.ThingValue
+	// mutex to protect the recorded values
+	recMux sync.Mutex
 }
 
 // GetEvents returns the event retention configuration
 func (svc *ManageRetention) GetEvents(_ context.Context) ([]history.EventRetention, error) {
+	svc.retMux.RLock()
+	defer svc.retMux.RUnlock()
 	retList := make([]history.EventRetention, 0, len(svc.configuredRetentions))
 	for _, ret := range svc.configuredRetentions {
 		retList = append(retList, ret)
@@ -51,6 +82,8 @@ func (svc *ManageRetention) GetEventRetention(
 	_ context.Context, eventName string) (ret history.EventRetention, err error) {
 
 	logrus.Infof("")
+	svc.retMux.RLock()
+	defer svc.retMux.RUnlock()
 	evRet, found := svc.configuredRetentions[eventName]
 	if !found {
 		err = fmt.Errorf("event '%s' not found in the retention list", eventName)
@@ -58,6 +91,52 @@ func (svc *ManageRetention) GetEventRetention(
 	return evRet, err
 }
 
+// getRule returns the retention rule of the event if it applies to the publisher thing.
+// This must be called while locked.
+func (svc *ManageRetention) getRule(publisherID, thingID, eventName string) (rule history.EventRetention, found bool) {
+	rule, found = svc.configuredRetentions[eventName]
+	if !found {
+		return rule, false
+	}
+	if !inArray(rule.Publishers, publisherID) ||
+		!inArray(rule.Things, thingID) ||
+		rule.Exclude != nil && len(rule.Exclude) > 0 && inArray(rule.Exclude, thingID) {
+		return rule, false
+	}
+	return rule, true
+}
+
+// GetCompaction returns the compaction rules of the event of the given publisher thing,
+// sorted by increasing age.
+// This returns nil if the event has no compaction rules, or the rule doesn't apply to the thing.
+func (svc *ManageRetention) GetCompaction(publisherID, thingID, eventName string) []history.CompactionRule {
+	svc.retMux.RLock()
+	defer svc.retMux.RUnlock()
+	rule, found := svc.getRule(publisherID, thingID, eventName)
+	if !found || len(rule.Compaction) == 0 {
+		return nil
+	}
+	compaction := make([]history.CompactionRule, len(rule.Compaction))
+	copy(compaction, rule.Compaction)
+	sort.Slice(compaction, func(i, j int) bool {
+		return compaction[i].AfterDays < compaction[j].AfterDays
+	})
+	return compaction
+}
+
+// GetRetentionDays returns the number of days to retain the event of the given publisher thing.
+// This returns 0 if the event has no retention rule, or the rule doesn't apply to the thing,
+// in which case the event is kept indefinitely.
+func (svc *ManageRetention) GetRetentionDays(publisherID, thingID, eventName string) int {
+	svc.retMux.RLock()
+	defer svc.retMux.RUnlock()
+	rule, found := svc.getRule(publisherID, thingID, eventName)
+	if !found {
+		return 0
+	}
+	return rule.RetentionDays
+}
+
 // Release the capability and its resources
 func (svc *ManageRetention) Release() {
 	// this is a singleton
@@ -66,33 +145,91 @@ func (svc *ManageRetention) Release() {
 // RemoveEventRetention removes the retention configuration of an event.
 func (svc *ManageRetention) RemoveEventRetention(_ context.Context, eventName string) error {
 	logrus.Infof("")
+	svc.retMux.Lock()
+	defer svc.retMux.Unlock()
 	delete(svc.configuredRetentions, eventName)
-	// TODO: save
-	return nil
+	// keep the removal so a default rule isn't restored on startup
+	err := svc.save(eventName, nil)
+	return err
 }
 
 // SetEventRetention configures the retention of a Thing event
 func (svc *ManageRetention) SetEventRetention(_ context.Context, eventRet history.EventRetention) error {
 	logrus.Infof("")
+	svc.retMux.Lock()
+	defer svc.retMux.Unlock()
+	if eventRet.Name == "" {
+		return fmt.Errorf("missing event name in retention rule")
+	}
+	for _, compaction := range eventRet.Compaction {
+		if compaction.AfterDays <= 0 || compaction.IntervalSec <= 0 {
+			return fmt.Errorf("compaction of event '%s' must have a positive age and interval", eventRet.Name)
+		}
+	}
+	if eventRet.Deadband < 0 || eventRet.HeartbeatSec < 0 {
+		return fmt.Errorf("deadband and heartbeat of event '%s' can't be negative", eventRet.Name)
+	}
 	svc.configuredRetentions[eventRet.Name] = eventRet
-	// TODO: save
-	return nil
+	err := svc.save(eventRet.Name, &eventRet)
+	return err
+}
+
+// save persists the retention rule of an event.
+// Use nil to persist the removal of the rule.
+func (svc *ManageRetention) save(eventName string, eventRet *history.EventRetention) (err error) {
+	if svc.bucket == nil {
+		return nil
+	}
+	var data = []byte{}
+	if eventRet != nil {
+		data, _ = json.Marshal(eventRet)
+	}
+	err = svc.bucket.Set(eventName, data)
+	if err != nil {
+		logrus.Errorf("failed saving retention of event '%s': %s", eventName, err)
+	}
+	return err
 }
 
 // Start the retention manager.
-// This loads the retention configuration
+// This loads the default retention configuration and merges the rules that were set through the API.
 func (svc *ManageRetention) Start() error {
+	svc.retMux.Lock()
+	defer svc.retMux.Unlock()
 	// load default config
 	for _, ret := range svc.defaultRetentions {
 		svc.configuredRetentions[ret.Name] = ret
 	}
-	// TODO: load configured retentions from state store
-
+	if svc.store == nil {
+		return nil
+	}
+	// load retentions set through the API. These override the defaults.
+	svc.bucket = svc.store.GetBucket(RetentionBucketName)
+	cursor := svc.bucket.Cursor()
+	defer cursor.Release()
+	for k, v, valid := cursor.First(); valid; k, v, valid = cursor.Next() {
+		if len(v) == 0 {
+			// the rule was removed
+			delete(svc.configuredRetentions, k)
+			continue
+		}
+		var ret history.EventRetention
+		err := json.Unmarshal(v, &ret)
+		if err != nil {
+			logrus.Errorf("stored retention of event '%s' can't be unmarshalled: %s. Ignored.", k, err)
+			continue
+		}
+		svc.configuredRetentions[k] = ret
+	}
 	return nil
 }
 
 // Stop using the retention manager
 func (svc *ManageRetention) Stop() {
+	if svc.bucket != nil {
+		_ = svc.bucket.Close()
+		svc.bucket = nil
+	}
 }
 
 // TestEvent tests if the event passes the retention filter rules
@@ -100,6 +237,8 @@ func (svc *ManageRetention) Stop() {
 // returns True if the event passes, false if rejected.
 func (svc *ManageRetention) TestEvent(_ context.Context, eventValue thing.ThingValue) (bool, error) {
 
+	svc.retMux.RLock()
+	defer svc.retMux.RUnlock()
 	rules := svc.configuredRetentions
 	// no rules, so accept everything
 	hasRetentionRules := rules != nil && len(rules) > 0
@@ -120,16 +259,100 @@ func (svc *ManageRetention) TestEvent(_ context.Context, eventValue thing.ThingV
 	return true, nil
 }
 
+// TestChange tests if the event value is to be recorded under the change filter of its retention rule.
+// Values pass if the rule has no change filter, if the value differs from the last recorded value
+// by at least the deadband, or if the heartbeat interval has passed since the last recorded value.
+// Non-numeric values, or a rule without deadband, pass on any change of the value.
+//
+//	eventValue is the value to test
+//	lastValue is the last recorded value of the event, or nil if none is known
+//
+// returns True if the event is to be recorded, false if it is filtered out.
+func (svc *ManageRetention) TestChange(eventValue thing.ThingValue, lastValue *thing.ThingValue) bool {
+	svc.retMux.RLock()
+	rule, found := svc.getRule(eventValue.PublisherID, eventValue.ThingID, eventValue.ID)
+	svc.retMux.RUnlock()
+	if !found || (!rule.OnlyOnChange && rule.Deadband <= 0) || lastValue == nil {
+		return true
+	}
+	if rule.HeartbeatSec > 0 {
+		created := time.Now()
+		if eventValue.Created != "" {
+			created, _ = dateparse.ParseAny(eventValue.Created)
+		}
+		lastCreated, err := dateparse.ParseAny(lastValue.Created)
+		if err != nil || created.Sub(lastCreated) >= time.Duration(rule.HeartbeatSec)*time.Second {
+			return true
+		}
+	}
+	if rule.Deadband > 0 {
+		value, isNumber := history.ParseNumber(eventValue.Data)
+		lastNumber, lastIsNumber := history.ParseNumber(lastValue.Data)
+		if isNumber && lastIsNumber {
+			return math.Abs(value-lastNumber) >= rule.Deadband
+		}
+	}
+	return !bytes.Equal(eventValue.Data, lastValue.Data)
+}
+
+// IsChanged tests if the event value is to be recorded under the change filter of its retention
+// rule, using TestChange. The value is compared against the last recorded value of the event.
+// If the value is to be recorded, it becomes the last recorded value.
+// Filtered values don't change the last recorded value, so small changes within the deadband
+// can't accumulate.
+//
+// The last recorded values are held in memory. Until a value of the event is recorded after
+// startup, the last known value of the event is used instead.
+//
+//	eventValue is the value to test
+//	getLastValues returns the last known values of a Thing, or nil to not use last known values
+//
+// returns True if the event is to be recorded, false if it is filtered out.
+func (svc *ManageRetention) IsChanged(eventValue thing.ThingValue,
+	getLastValues func(thingAddr string, names []string) []thing.ThingValue) bool {
+
+	svc.retMux.RLock()
+	rule, found := svc.getRule(eventValue.PublisherID, eventValue.ThingID, eventValue.ID)
+	svc.retMux.RUnlock()
+	if !found || (!rule.OnlyOnChange && rule.Deadband <= 0) {
+		return true
+	}
+	thingAddr := eventValue.PublisherID + "/" + eventValue.ThingID
+	eventAddr := thingAddr + "/" + eventValue.ID
+	svc.recMux.Lock()
+	defer svc.recMux.Unlock()
+	var lastValue *thing.ThingValue
+	if recorded, found := svc.recorded[eventAddr]; found {
+		lastValue = &recorded
+	} else if getLastValues != nil {
+		lastValues := getLastValues(thingAddr, []string{eventValue.ID})
+		if len(lastValues) > 0 {
+			lastValue = &lastValues[0]
+		}
+	}
+	if !svc.TestChange(eventValue, lastValue) {
+		return false
+	}
+	if eventValue.Created == "" {
+		eventValue.Created = time.Now().Format(vocab.ISO8601Format)
+	}
+	svc.recorded[eventAddr] = eventValue
+	return true
+}
+
 // NewManageRetention creates a new instance that implements IManageRetention
 //
 //	defaultConfig with events to retain or nil to use defaults
-func NewManageRetention(defaultConfig []history.EventRetention) *ManageRetention {
+//	store to persist changes to the retention rules in or nil to not persist changes
+func NewManageRetention(defaultConfig []history.EventRetention, store bucketstore.IBucketStore) *ManageRetention {
 	if defaultConfig == nil {
 		defaultConfig = make([]history.EventRetention, 0)
 	}
 	svc := &ManageRetention{
 		defaultRetentions:    defaultConfig,
 		configuredRetentions: make(map[string]history.EventRetention),
+		recorded:             make(map[string]thing.ThingValue),
+		store:                store,
 	}
 	return svc
 }
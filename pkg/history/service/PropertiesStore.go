@@ -89,6 +89,29 @@ func (srv *LastPropertiesStore) GetProperties(thingAddr string, names []string)
 	return propList
 }
 
+// GetThingAddresses returns the addresses of things whose properties are stored or cached.
+// This is the list of things that have history buckets.
+func (srv *LastPropertiesStore) GetThingAddresses() (thingAddrs []string) {
+	addrMap := make(map[string]bool)
+	cursor := srv.store.Cursor()
+	for k, _, valid := cursor.First(); valid; k, _, valid = cursor.Next() {
+		addrMap[k] = true
+	}
+	cursor.Release()
+
+	srv.cacheMux.RLock()
+	for thingAddr := range srv.cache {
+		addrMap[thingAddr] = true
+	}
+	srv.cacheMux.RUnlock()
+
+	thingAddrs = make([]string, 0, len(addrMap))
+	for thingAddr := range addrMap {
+		thingAddrs = append(thingAddrs, thingAddr)
+	}
+	return thingAddrs
+}
+
 // HandleAddValue is the handler of update to a thing's event/property values
 // used to update the properties cache.
 // isAction indicates the value is an action.
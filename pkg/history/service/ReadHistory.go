@@ -2,7 +2,13 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/araddon/dateparse"
+
+	"github.com/hiveot/hub/api/go/vocab"
 	"github.com/hiveot/hub/lib/thing"
 	"github.com/hiveot/hub/pkg/bucketstore"
 	"github.com/hiveot/hub/pkg/history"
@@ -22,6 +28,83 @@ type ReadHistory struct {
 	// The service implements the getPropertyValues function as it does the caching and
 	// provides concurrency control.
 	getPropertiesFunc GetPropertiesFunc
+
+	// registry of the followers of the history
+	followers *HistoryFollowers
+}
+
+// GetAggregate returns the aggregate of the numeric values of an event for each interval
+// in the time range.
+// This iterates the bucket directly to avoid decoding values that are not aggregated.
+func (svc *ReadHistory) GetAggregate(_ context.Context, publisherID, thingID string, name string,
+	startTime string, duration int, interval int) ([]history.AggregateValue, error) {
+
+	if duration <= 0 || interval <= 0 {
+		return nil, fmt.Errorf("duration and interval must be positive")
+	} else if duration/interval > history.MaxAggregateIntervals {
+		return nil, fmt.Errorf("time range has more than %d intervals", history.MaxAggregateIntervals)
+	}
+	start, err := dateparse.ParseAny(startTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start time '%s': %w", startTime, err)
+	}
+	startMsec := start.UnixMilli()
+	endMsec := startMsec + int64(duration)*1000
+	intervalMsec := int64(interval) * 1000
+
+	bucket := svc.bucketStore.GetBucket(publisherID + "/" + thingID)
+	defer bucket.Close()
+	cursor := bucket.Cursor()
+	defer cursor.Release()
+
+	aggList := make([]history.AggregateValue, 0)
+	var agg *history.AggregateValue
+	var sum float64
+	var aggIndex int64 = -1
+	// key is constructed as  {timestamp}/{valueName}/{a|e}
+	k, v, valid := cursor.Seek(strconv.FormatInt(startMsec, 10))
+	for ; valid; k, v, valid = cursor.Next() {
+		timestampMsec, keyName, suffix, isKey := splitKey(k)
+		if !isKey {
+			continue
+		}
+		if timestampMsec >= endMsec {
+			break
+		} else if keyName != name || suffix != "e" {
+			continue
+		}
+		value, isNumber := history.ParseNumber(decodeData(v))
+		if !isNumber {
+			continue
+		}
+		index := (timestampMsec - startMsec) / intervalMsec
+		if index != aggIndex {
+			if agg != nil {
+				agg.Avg = sum / float64(agg.Count)
+				aggList = append(aggList, *agg)
+			}
+			aggIndex = index
+			agg = &history.AggregateValue{
+				StartTime: time.UnixMilli(startMsec + index*intervalMsec).Format(vocab.ISO8601Format),
+				Min:       value,
+				Max:       value,
+			}
+			sum = 0
+		}
+		agg.Count++
+		sum += value
+		agg.Last = value
+		if value < agg.Min {
+			agg.Min = value
+		} else if value > agg.Max {
+			agg.Max = value
+		}
+	}
+	if agg != nil {
+		agg.Avg = sum / float64(agg.Count)
+		aggList = append(aggList, *agg)
+	}
+	return aggList, nil
 }
 
 // GetEventHistory provides a cursor to iterate the event history of the thing
@@ -36,6 +119,39 @@ func (svc *ReadHistory) GetEventHistory(_ context.Context,
 	return historyCursor
 }
 
+// FollowHistory replays the history of the thing from the start of the range and then passes
+// values to the handler as they are added.
+func (svc *ReadHistory) FollowHistory(_ context.Context, publisherID string, thingID string,
+	historyRange history.HistoryRange, handler func(thingValue thing.ThingValue)) (history.IHistoryFollower, error) {
+
+	// the end of the range is not used
+	historyRange.EndTime = ""
+	startMsec, _, valueType, err := history.ParseRange(historyRange)
+	if err != nil {
+		return nil, err
+	} else if svc.followers == nil {
+		return nil, fmt.Errorf("following the history is not supported")
+	}
+	follower := NewHistoryFollower(publisherID, thingID,
+		startMsec, historyRange.Names, valueType, handler, svc.bucketStore)
+	svc.followers.Follow(follower)
+	return follower, nil
+}
+
+// GetRangeHistory provides a cursor to iterate a time range of the history of the thing
+func (svc *ReadHistory) GetRangeHistory(_ context.Context, publisherID string, thingID string,
+	historyRange history.HistoryRange) (cursor history.IHistoryCursor, totalEstimate int, err error) {
+
+	startMsec, endMsec, valueType, err := history.ParseRange(historyRange)
+	if err != nil {
+		return nil, 0, err
+	}
+	historyCursor := NewHistoryRangeCursor(publisherID, thingID,
+		startMsec, endMsec, historyRange.Names, valueType, svc.bucketStore)
+	totalEstimate = historyCursor.CountRange()
+	return historyCursor, totalEstimate, nil
+}
+
 // GetProperties returns the most recent property and event values of the Thing
 // Latest Properties are tracked in a 'latest' record which holds a map of propertyName:ThingValue records
 //
@@ -57,11 +173,14 @@ func (svc *ReadHistory) Release() {
 //	publisherID, thingID is the address the thing can be reached at
 //	thingBucket is the bucket used to store history data
 //	gePropertiesFunc implements the aggregation of the Thing's most recent property values
-func NewReadHistory(clientID string, bucketStore bucketstore.IBucketStore, getPropertiesFunc GetPropertiesFunc) *ReadHistory {
+//	followers is the registry of followers of the history, or nil if following isn't supported
+func NewReadHistory(clientID string, bucketStore bucketstore.IBucketStore,
+	getPropertiesFunc GetPropertiesFunc, followers *HistoryFollowers) *ReadHistory {
 	svc := &ReadHistory{
 		clientID:          clientID,
 		bucketStore:       bucketStore,
 		getPropertiesFunc: getPropertiesFunc,
+		followers:         followers,
 	}
 	return svc
 }
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/history/config"
+	"github.com/hiveot/hub/pkg/history/mongohs"
+)
+
+// DefaultPurgeInterval is the interval between purging expired values when no configuration is provided
+const DefaultPurgeInterval = time.Duration(config.DefaultPurgeIntervalSec) * time.Second
+
+// DefaultPurgeBatchSize is the maximum number of records removed from a bucket in a single batch
+const DefaultPurgeBatchSize = 1000
+
+// msecPerDay is the number of milliseconds in a day as used in the history keys
+const msecPerDay = 24 * 3600 * 1000
+
+// RetentionSweeper periodically removes values from the Thing history buckets that
+// have exceeded the RetentionDays of their event retention rule.
+// Values without a retention rule, or with RetentionDays of 0, are kept indefinitely.
+type RetentionSweeper struct {
+	// store with buckets for Things
+	store bucketstore.IBucketStore
+	// retention rules to apply
+	retentionMgr *ManageRetention
+	// provides the addresses of Things with a history bucket
+	getThingAddrs func() []string
+	// interval between purge runs. 0 to disable the background job
+	interval time.Duration
+	// max nr of records to remove in a batch
+	batchSize int
+	// optional purge of a store that isn't a bucket store. nil to purge the Thing buckets.
+	purgeFunc func(now time.Time) (nrRemoved int, err error)
+
+	// stop the background job
+	stopChan chan bool
+	// wait for the background job to end
+	jobWG sync.WaitGroup
+}
+
+// getMinRetentionMsec returns the shortest non-zero retention period of all rules, in msec.
+// Values younger than this period are never expired.
+// This returns 0 if none of the rules has a retention period.
+func (svc *RetentionSweeper) getMinRetentionMsec() int64 {
+	var minDays = 0
+	svc.retentionMgr.retMux.RLock()
+	defer svc.retentionMgr.retMux.RUnlock()
+	for _, rule := range svc.retentionMgr.configuredRetentions {
+		if rule.RetentionDays > 0 && (minDays == 0 || rule.RetentionDays < minDays) {
+			minDays = rule.RetentionDays
+		}
+	}
+	return int64(minDays) * msecPerDay
+}
+
+// findExpired iterates the bucket from the given key and returns a batch with keys of expired values.
+// Iteration stops when the batch is full or when a value newer than untilMsec is reached.
+// The cursor is released before returning so the keys can be deleted without holding a read transaction.
+//
+//	startKey to seek or "" to start at the first key
+//	untilMsec is the timestamp after which values are no longer considered for expiry
+//	nowMsec is the reference time to determine the age of values
+//
+// This returns the expired keys, the last key that was iterated, and done is true if no more
+// expired keys remain.
+func (svc *RetentionSweeper) findExpired(bucket bucketstore.IBucket,
+	publisherID, thingID string, startKey string, untilMsec int64, nowMsec int64) (
+	expiredKeys []string, lastKey string, done bool) {
+
+	expiredKeys = make([]string, 0, svc.batchSize)
+	cursor := bucket.Cursor()
+	defer cursor.Release()
+
+	var k string
+	var valid bool
+	if startKey == "" {
+		k, _, valid = cursor.First()
+	} else {
+		k, _, valid = cursor.Seek(startKey)
+	}
+	for ; valid; k, _, valid = cursor.Next() {
+		lastKey = k
+		// key is constructed as  {timestamp}/{valueName}/{a|e}
+		timestampMsec, name, _, isKey := splitKey(k)
+		if !isKey {
+			// not a history value. Skip it.
+			continue
+		}
+		if timestampMsec >= untilMsec {
+			// the remaining values are all newer
+			return expiredKeys, lastKey, true
+		}
+		retentionDays := svc.retentionMgr.GetRetentionDays(publisherID, thingID, name)
+		if retentionDays > 0 && timestampMsec < nowMsec-int64(retentionDays)*msecPerDay {
+			expiredKeys = append(expiredKeys, k)
+			if len(expiredKeys) >= svc.batchSize {
+				return expiredKeys, lastKey, false
+			}
+		}
+	}
+	return expiredKeys, lastKey, true
+}
+
+// PurgeThing removes the expired values from the history bucket of a Thing.
+// Expired values are deleted in batches.
+//
+//	thingAddr is the address of the thing, eg publisherID/thingID
+//	now is the reference time used to determine the age of the values
+//
+// This returns the number of records that were removed.
+func (svc *RetentionSweeper) PurgeThing(thingAddr string, now time.Time) (nrRemoved int, err error) {
+	parts := strings.Split(thingAddr, "/")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid thing address '%s'", thingAddr)
+	}
+	publisherID, thingID := parts[0], parts[1]
+	minRetentionMsec := svc.getMinRetentionMsec()
+	if minRetentionMsec == 0 {
+		// nothing expires
+		return 0, nil
+	}
+	nowMsec := now.UnixMilli()
+	untilMsec := nowMsec - minRetentionMsec
+
+	bucket := svc.store.GetBucket(thingAddr)
+	defer bucket.Close()
+	startKey := ""
+	for {
+		expiredKeys, lastKey, done := svc.findExpired(
+			bucket, publisherID, thingID, startKey, untilMsec, nowMsec)
+
+		for _, key := range expiredKeys {
+			err = bucket.Delete(key)
+			if err != nil {
+				logrus.Errorf("failed deleting '%s' from '%s': %s", key, thingAddr, err)
+				return nrRemoved, err
+			}
+			nrRemoved++
+		}
+		if done {
+			break
+		}
+		// the last key of a full batch is deleted so seek continues with the next key
+		startKey = lastKey
+	}
+	return nrRemoved, nil
+}
+
+// PurgeExpired removes the expired values from the history buckets of all Things.
+// This returns the number of records that were removed and the last error encountered, if any.
+func (svc *RetentionSweeper) PurgeExpired() (nrRemoved int, err error) {
+	now := time.Now()
+	if svc.purgeFunc != nil {
+		nrRemoved, err = svc.purgeFunc(now)
+		logrus.Infof("removed %d expired history records", nrRemoved)
+		return nrRemoved, err
+	}
+	thingAddrs := svc.getThingAddrs()
+	for _, thingAddr := range thingAddrs {
+		nrThingRemoved, err2 := svc.PurgeThing(thingAddr, now)
+		nrRemoved += nrThingRemoved
+		if err2 != nil {
+			err = err2
+		}
+	}
+	logrus.Infof("removed %d expired history records from %d things", nrRemoved, len(thingAddrs))
+	return nrRemoved, err
+}
+
+// Start the background job that periodically purges expired values.
+// This does nothing if no interval is set.
+func (svc *RetentionSweeper) Start() {
+	if svc.interval <= 0 {
+		logrus.Infof("retention purge interval is not set. Expired values will not be removed.")
+		return
+	}
+	svc.stopChan = make(chan bool)
+	svc.jobWG.Add(1)
+	go func() {
+		defer svc.jobWG.Done()
+		ticker := time.NewTicker(svc.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-svc.stopChan:
+				return
+			case <-ticker.C:
+				_, _ = svc.PurgeExpired()
+			}
+		}
+	}()
+}
+
+// Stop the background job and wait until a running purge has completed
+func (svc *RetentionSweeper) Stop() {
+	if svc.stopChan != nil {
+		close(svc.stopChan)
+		svc.jobWG.Wait()
+		svc.stopChan = nil
+	}
+}
+
+// NewRetentionSweeper creates a sweeper that removes expired values from the history store.
+//
+//	store with the Thing history buckets
+//	retentionMgr with the retention rules
+//	getThingAddrs provides the addresses of the things whose buckets to sweep
+//	interval between purge runs, 0 to only purge on demand
+func NewRetentionSweeper(
+	store bucketstore.IBucketStore,
+	retentionMgr *ManageRetention,
+	getThingAddrs func() []string,
+	interval time.Duration) *RetentionSweeper {
+
+	svc := &RetentionSweeper{
+		store:         store,
+		retentionMgr:  retentionMgr,
+		getThingAddrs: getThingAddrs,
+		interval:      interval,
+		batchSize:     DefaultPurgeBatchSize,
+	}
+	return svc
+}
+
+// NewMongoRetentionSweeper creates a sweeper that removes expired values from the history in mongodb.
+//
+//	mongoStore with the history time-series collection
+//	retentionMgr with the retention rules
+//	interval between purge runs, 0 to only purge on demand
+func NewMongoRetentionSweeper(
+	mongoStore *mongohs.MongoHistoryStore,
+	retentionMgr *ManageRetention,
+	interval time.Duration) *RetentionSweeper {
+
+	svc := &RetentionSweeper{
+		retentionMgr: retentionMgr,
+		interval:     interval,
+		batchSize:    DefaultPurgeBatchSize,
+		purgeFunc: func(now time.Time) (int, error) {
+			return mongoStore.PurgeExpired(context.Background(), now, retentionMgr.GetRetentionDays)
+		},
+	}
+	return svc
+}
@@ -20,6 +20,10 @@ func (svc *PubSubEventHandler) Start() error {
 	ctx := context.Background()
 	err := svc.serviceSub.SubEvents(ctx, "", "", "",
 		func(eventValue thing.ThingValue) {
+			if eventValue.ID == pubsub.EventNameSubscriptionClosed {
+				logrus.Errorf("the pubsub service closed the event subscription: %s", eventValue.Data)
+				return
+			}
 			logrus.Infof("received event '%s'", eventValue.ID)
 
 			_ = svc.addHistory.AddEvent(ctx, eventValue)
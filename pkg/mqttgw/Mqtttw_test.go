@@ -12,8 +12,11 @@ import (
 	"github.com/hiveot/hub/lib/testenv"
 	"github.com/hiveot/hub/lib/thing"
 	"github.com/hiveot/hub/pkg/authn"
+	"github.com/hiveot/hub/pkg/authz"
+	service5 "github.com/hiveot/hub/pkg/authz/service"
 	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
 	"github.com/hiveot/hub/pkg/directory"
+	config3 "github.com/hiveot/hub/pkg/directory/config"
 	service3 "github.com/hiveot/hub/pkg/directory/service"
 	"github.com/hiveot/hub/pkg/history"
 	"github.com/hiveot/hub/pkg/history/config"
@@ -21,12 +24,14 @@ import (
 	"github.com/hiveot/hub/pkg/mqttgw/mqttclient"
 	"github.com/hiveot/hub/pkg/mqttgw/service"
 	"github.com/hiveot/hub/pkg/pubsub"
+	config2 "github.com/hiveot/hub/pkg/pubsub/config"
 	service2 "github.com/hiveot/hub/pkg/pubsub/service"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"os"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -36,6 +41,11 @@ const testUserID = "urn:user1"
 const testDeviceID = "urn:device1"
 const testPublisherID = "urn:pub1"
 const testThingID = "urn:thing1"
+const testThing2ID = "urn:thing2"
+const testViewerID = "urn:viewer1"
+const testOperatorID = "urn:operator1"
+const testGroupName = "mqttgroup"
+const testAclFile = "/tmp/test-mqttgw.acl"
 const testServiceID = "urn:service1"
 const testPassword = "test1"
 const testMqttTcpPort = 9331
@@ -69,6 +79,7 @@ func startService() (stopFn func()) {
 // setup a test environment with capabilities for pubsub, directory and history
 // This returns a stop function
 func startTestEnv() func() {
+	ctx := context.Background()
 	// setup the global resolver with dummies for required capabilities
 	dummyStore := kvbtree.NewKVStore("mqtttest", "")
 	_ = dummyStore.Open()
@@ -78,14 +89,28 @@ func startTestEnv() func() {
 	userAuthn, _ := dummyAuthn.CapUserAuthn(nil, testUserID)
 
 	// pubsub is in-memory
-	dummyPubSub := service2.NewPubSubService()
+	dummyPubSub := service2.NewPubSubService(config2.NewPubSubConfig(), nil)
 	_ = dummyPubSub.Start()
 	devicePubSub, _ := dummyPubSub.CapDevicePubSub(nil, testDeviceID)
 	userPubSub, _ := dummyPubSub.CapUserPubSub(nil, testUserID)
 	servicePubSub, _ := dummyPubSub.CapServicePubSub(nil, testServiceID)
 
+	// authz with a group containing thing1 of the device. thing2 is not in the group.
+	_ = os.Remove(testAclFile)
+	authzSvc := service5.NewAuthzService(testAclFile)
+	_ = authzSvc.Start(ctx)
+	manageAuthz, _ := authzSvc.CapManageAuthz(ctx, testServiceID)
+	_ = manageAuthz.AddThing(ctx, testDeviceID+"/"+testThingID, testGroupName)
+	_ = manageAuthz.SetClientRole(ctx, testDeviceID, testGroupName, authz.ClientRoleIotDevice)
+	_ = manageAuthz.SetClientRole(ctx, testUserID, testGroupName, authz.ClientRoleOperator)
+	_ = manageAuthz.SetClientRole(ctx, testViewerID, testGroupName, authz.ClientRoleViewer)
+	_ = manageAuthz.SetClientRole(ctx, testOperatorID, testGroupName, authz.ClientRoleOperator)
+	manageAuthz.Release()
+	verifyAuthz, _ := authzSvc.CapVerifyAuthz(ctx, testServiceID)
+
 	// use a directory and history using a dummy store
-	dummyDirSvc := service3.NewDirectoryService(directory.ServiceName, dummyStore, servicePubSub)
+	dirConfig := config3.NewDirectoryConfig()
+	dummyDirSvc := service3.NewDirectoryService(&dirConfig, dummyStore, servicePubSub)
 	_ = dummyDirSvc.Start()
 	readDir, _ := dummyDirSvc.CapReadDirectory(nil, testUserID)
 	dummyHistSvc := service4.NewHistoryService(nil, dummyStore, servicePubSub)
@@ -101,8 +126,11 @@ func startTestEnv() func() {
 	resolver.RegisterService[directory.IDirectory](dummyDirSvc)
 	resolver.RegisterService[directory.IReadDirectory](readDir)
 	resolver.RegisterService[history.IReadHistory](readHist)
+	resolver.RegisterService[authz.IVerifyAuthz](verifyAuthz)
 
 	return func() {
+		verifyAuthz.Release()
+		authzSvc.Stop()
 		_ = dummyStore.Close()
 		dummyDirSvc.Release()
 		_ = dummyHistSvc.Stop()
@@ -178,7 +206,7 @@ func TestPubSubAction(t *testing.T) {
 	// test
 	err = cl.SubAction(testThingID, "",
 		func(val thing.ThingValue) {
-			logrus.Infof("Received action: %s", val)
+			logrus.Infof("Received action: %v", val)
 			mux.Lock()
 			defer mux.Unlock()
 			action1Count++
@@ -229,7 +257,7 @@ func TestPubSubEvent(t *testing.T) {
 	// test
 	err = cl.SubEvent("", "", "",
 		func(val thing.ThingValue) {
-			logrus.Infof("Received event: %s", val)
+			logrus.Infof("Received event: %v", val)
 			mux.Lock()
 			defer mux.Unlock()
 			event1Count++
@@ -261,6 +289,129 @@ func TestPubSubEvent(t *testing.T) {
 	cl2.Disconnect()
 }
 
+// Devices can publish events and subscribe to actions of their Things that are in a group
+func TestACLDevice(t *testing.T) {
+	var mqttUrl = fmt.Sprintf("tls://127.0.0.1:%d", testMqttTcpPort)
+	var eventCount = int32(0)
+	var actionCount = int32(0)
+
+	stopFn := startService()
+	defer stopFn()
+
+	deviceClient := mqttclient.NewHubMqttClient()
+	err := deviceClient.Connect(mqttUrl, testDeviceID, "", testCerts.DeviceCert, testCerts.CaCert)
+	require.NoError(t, err)
+	defer deviceClient.Disconnect()
+	operatorClient := mqttclient.NewHubMqttClient()
+	err = operatorClient.Connect(mqttUrl, testOperatorID, testPassword, nil, testCerts.CaCert)
+	require.NoError(t, err)
+	defer operatorClient.Disconnect()
+
+	// the device receives actions for its thing
+	err = deviceClient.SubAction(testThingID, "", func(val thing.ThingValue) {
+		atomic.AddInt32(&actionCount, 1)
+	})
+	assert.NoError(t, err)
+	err = operatorClient.SubEvent(testDeviceID, "", "", func(val thing.ThingValue) {
+		assert.Equal(t, testThingID, val.ThingID)
+		atomic.AddInt32(&eventCount, 1)
+	})
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+
+	// the device can publish events of thing1 but not of thing2, which is not in the group
+	err = deviceClient.PubEvent(testThingID, "event1", []byte("value1"))
+	assert.NoError(t, err)
+	err = deviceClient.PubEvent(testThing2ID, "event1", []byte("value2"))
+	assert.NoError(t, err)
+	err = operatorClient.PubAction(testDeviceID, testThingID, "action1", []byte("1"))
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&eventCount))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&actionCount))
+}
+
+// Viewers can subscribe to events but not publish actions or events
+func TestACLViewer(t *testing.T) {
+	var mqttUrl = fmt.Sprintf("tls://127.0.0.1:%d", testMqttTcpPort)
+	var eventCount = int32(0)
+	var actionCount = int32(0)
+
+	stopFn := startService()
+	defer stopFn()
+
+	deviceClient := mqttclient.NewHubMqttClient()
+	err := deviceClient.Connect(mqttUrl, testDeviceID, "", testCerts.DeviceCert, testCerts.CaCert)
+	require.NoError(t, err)
+	defer deviceClient.Disconnect()
+	viewerClient := mqttclient.NewHubMqttClient()
+	err = viewerClient.Connect(mqttUrl, testViewerID, testPassword, nil, testCerts.CaCert)
+	require.NoError(t, err)
+	defer viewerClient.Disconnect()
+
+	err = deviceClient.SubAction("", "", func(val thing.ThingValue) {
+		atomic.AddInt32(&actionCount, 1)
+	})
+	assert.NoError(t, err)
+	err = viewerClient.SubEvent(testDeviceID, testThingID, "", func(val thing.ThingValue) {
+		atomic.AddInt32(&eventCount, 1)
+	})
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+
+	err = deviceClient.PubEvent(testThingID, "event1", []byte("value1"))
+	assert.NoError(t, err)
+	// the viewer is not allowed to control things or to publish events
+	err = viewerClient.PubAction(testDeviceID, testThingID, "action1", []byte("1"))
+	assert.NoError(t, err)
+	err = viewerClient.PubEvent(testThingID, "event1", []byte("fake"))
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&eventCount))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&actionCount))
+}
+
+// Operators can publish actions to Things in their group only
+func TestACLOperator(t *testing.T) {
+	var mqttUrl = fmt.Sprintf("tls://127.0.0.1:%d", testMqttTcpPort)
+	var actionCount = int32(0)
+
+	stopFn := startService()
+	defer stopFn()
+
+	deviceClient := mqttclient.NewHubMqttClient()
+	err := deviceClient.Connect(mqttUrl, testDeviceID, "", testCerts.DeviceCert, testCerts.CaCert)
+	require.NoError(t, err)
+	defer deviceClient.Disconnect()
+	operatorClient := mqttclient.NewHubMqttClient()
+	err = operatorClient.Connect(mqttUrl, testOperatorID, testPassword, nil, testCerts.CaCert)
+	require.NoError(t, err)
+	defer operatorClient.Disconnect()
+
+	err = deviceClient.SubAction("", "", func(val thing.ThingValue) {
+		assert.Equal(t, testThingID, val.ThingID)
+		atomic.AddInt32(&actionCount, 1)
+	})
+	assert.NoError(t, err)
+	// operators can't subscribe to actions of a device
+	err = operatorClient.SubAction(testThingID, "", func(val thing.ThingValue) {
+		assert.Fail(t, "operator should not receive actions")
+	})
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+
+	err = operatorClient.PubAction(testDeviceID, testThingID, "action1", []byte("1"))
+	assert.NoError(t, err)
+	// thing2 is not in the operator's group
+	err = operatorClient.PubAction(testDeviceID, testThing2ID, "action1", []byte("2"))
+	assert.NoError(t, err)
+
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&actionCount))
+}
+
 func TestUpdateReadDirectory(t *testing.T) {
 	var mqttUrl = fmt.Sprintf("tls://127.0.0.1:%d", testMqttTcpPort)
 	var completed []thing.ThingValue
@@ -126,6 +126,20 @@ func (cl *MqttGwClient) PubReadDirectory(publisherID string) error {
 	return token.Error()
 }
 
+// PubQueryDirectory requests thing TD documents that match the query from the directory service.
+//
+// The response is received the same way as that of PubReadDirectory.
+//
+//	req holds the optional publisherID, device type, title, property type, limit and offset
+//
+// This returns nil on success or an error on failure
+func (cl *MqttGwClient) PubQueryDirectory(req ReadDirectoryRequest) error {
+	topic := ReadDirectoryRequestTopic
+	payload, _ := json.Marshal(req)
+	token := cl.paho.Publish(topic, 1, false, payload)
+	return token.Error()
+}
+
 // PubReadHistory requests reading of thing values from the history service.
 //
 // To receive the values, clients should subscribe to history events using SubReadHistory.
@@ -156,6 +170,35 @@ func (cl *MqttGwClient) PubReadHistory(
 	return token.Error()
 }
 
+// PubReadAggregate requests the aggregate of an event's numeric values from the history service.
+//
+// To receive the aggregates, clients should subscribe using SubReadAggregate.
+//
+//	publisherID is the publisher of the thing
+//	thingID is the thing whose history to aggregate
+//	name is the name of the event to aggregate
+//	startTime is optional ISO8601 time or "" for 24 hours ago
+//	duration is the number of seconds to aggregate or 0 for 3600*24
+//	interval is the number of seconds of each aggregate or 0 for 3600
+//
+// This returns nil on success or an error on failure
+func (cl *MqttGwClient) PubReadAggregate(
+	publisherID, thingID string, name string, startTime string, duration int, interval int) error {
+
+	req := ReadAggregateRequest{
+		PublisherID: publisherID,
+		ThingID:     thingID,
+		Name:        name,
+		StartTime:   startTime,
+		Duration:    duration,
+		Interval:    interval,
+	}
+	payload, _ := json.Marshal(req)
+	topic := ReadAggregateRequestTopic
+	token := cl.paho.Publish(topic, 1, false, payload)
+	return token.Error()
+}
+
 // PubReadLatest publishes the requests the latest property/event values of a thing
 //
 // To receive the values, clients should subscribe to history events using SubLatest.
@@ -201,6 +244,24 @@ func (cl *MqttGwClient) SubReadDirectory(cb func(*ReadDirectoryResponse)) error
 	return token.Error()
 }
 
+// SubReadAggregate subscribes to the response of PubReadAggregate
+//
+//	cb is the callback invoked when a response is received
+func (cl *MqttGwClient) SubReadAggregate(cb func(*ReadAggregateResponse)) error {
+	topic := ReadAggregateResponseTopic
+	token := cl.paho.Subscribe(topic, 1,
+		func(client pahomqtt.Client, message pahomqtt.Message) {
+			resp := ReadAggregateResponse{}
+			err := json.Unmarshal(message.Payload(), &resp)
+			if err != nil {
+				logrus.Errorf("response on topic '%s' is unexpected json: %s", topic, err)
+			} else {
+				cb(&resp)
+			}
+		})
+	return token.Error()
+}
+
 // SubReadHistory subscribes to the response of PubReadHistory
 //
 //	cb is the callback invoked when a response is received
@@ -1,11 +1,17 @@
 package mqttclient
 
-import "github.com/hiveot/hub/lib/thing"
+import (
+	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/history"
+)
 
 type ReadDirectoryRequest struct {
-	PublisherID string `json:"publisherID,omitempty"`
-	Limit       uint   `json:"limit,omitempty"`
-	Offset      uint   `json:"offset,omitempty"`
+	PublisherID  string `json:"publisherID,omitempty"`
+	DeviceType   string `json:"deviceType,omitempty"`
+	Title        string `json:"title,omitempty"`
+	PropertyType string `json:"propertyType,omitempty"`
+	Limit        uint   `json:"limit,omitempty"`
+	Offset       uint   `json:"offset,omitempty"`
 }
 
 type ReadDirectoryResponse struct {
@@ -29,6 +35,22 @@ type ReadHistoryResponse struct {
 	ThingID        string             `json:"thingID"`
 	Values         []thing.ThingValue `json:"history"`
 }
+type ReadAggregateRequest struct {
+	PublisherID string `json:"publisherID,omitempty"`
+	ThingID     string `json:"thingID,omitempty"`
+	Name        string `json:"name,omitempty"`
+	StartTime   string `json:"startTime,omitempty"`
+	Duration    int    `json:"duration,omitempty"`
+	Interval    int    `json:"interval,omitempty"`
+}
+
+type ReadAggregateResponse struct {
+	Name        string                   `json:"name"`
+	PublisherID string                   `json:"publisherID"`
+	ThingID     string                   `json:"thingID"`
+	Values      []history.AggregateValue `json:"aggregates"`
+}
+
 type ReadLatestRequest struct {
 	PublisherID string `json:"publisherID,omitempty"`
 	ThingID     string `json:"thingID,omitempty"`
@@ -18,6 +18,8 @@ const (
 	ReadHistoryResponseTopic   = HistoryTopicPrefix + "/event/history"
 	ReadLatestRequestTopic     = HistoryTopicPrefix + "/action/latest"
 	ReadLatestResponseTopic    = HistoryTopicPrefix + "/event/latest"
+	ReadAggregateRequestTopic  = HistoryTopicPrefix + "/action/aggregate"
+	ReadAggregateResponseTopic = HistoryTopicPrefix + "/event/aggregate"
 )
 
 // IsThingsTopic test if the given topic is a thing pub/sub topic
@@ -37,8 +37,8 @@ func (m2dir *Mqtt2Directory) Release() {
 
 // handleReadDirectory reads the directory using request parameters from payload and writes a response
 //
-//	payload contains optional filter parameters for publisherID and limit.
-//	The default limit is 1000
+//	payload contains optional filter parameters for publisherID, device type, title, property type,
+//	limit and offset. The default limit is 1000
 func (m2dir *Mqtt2Directory) handleReadDirectory(payload []byte) (err error) {
 	req := mqttclient.ReadDirectoryRequest{Limit: 1000}
 
@@ -48,8 +48,22 @@ func (m2dir *Mqtt2Directory) handleReadDirectory(payload []byte) (err error) {
 	if err != nil {
 		err = fmt.Errorf("directory request invalid parameters: %w", err)
 	} else {
-		cursor := m2dir.getReadDirectory().Cursor(context.Background())
-		values, itemsRemaining := cursor.NextN(req.Limit)
+		query := directory.TDQuery{
+			PublisherID:  req.PublisherID,
+			DeviceType:   req.DeviceType,
+			Title:        req.Title,
+			PropertyType: req.PropertyType,
+		}
+		// request one more to determine if items remain
+		values, err2 := m2dir.getReadDirectory().QueryTDs(
+			context.Background(), query, int(req.Limit)+1, int(req.Offset))
+		if err2 != nil {
+			return err2
+		}
+		itemsRemaining := len(values) > int(req.Limit)
+		if itemsRemaining {
+			values = values[:req.Limit]
+		}
 		resp := mqttclient.ReadDirectoryResponse{
 			TDs:            values,
 			ItemsRemaining: itemsRemaining,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"github.com/araddon/dateparse"
 	"github.com/hiveot/hub/api/go/vocab"
 	"github.com/hiveot/hub/lib/resolver"
 	"github.com/hiveot/hub/lib/thing"
@@ -39,6 +40,9 @@ func (m2hist *Mqtt2History) getReadHistory() history.IReadHistory {
 func (m2hist *Mqtt2History) handleReadHistory(payload []byte) (err error) {
 	req := mqttclient.ReadHistoryRequest{}
 	err = json.Unmarshal(payload, &req)
+	if err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
 	if req.StartTime == "" {
 		ago := time.Now().Add(-time.Hour * 24)
 		req.StartTime = ago.Format(vocab.ISO8601Format)
@@ -46,16 +50,31 @@ func (m2hist *Mqtt2History) handleReadHistory(payload []byte) (err error) {
 	if req.Duration == 0 {
 		req.Duration = 24 * 3600
 	}
+	startTime, err := dateparse.ParseAny(req.StartTime)
+	if err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	historyRange := history.HistoryRange{
+		StartTime: req.StartTime,
+		EndTime:   startTime.Add(time.Duration(req.Duration) * time.Second).Format(vocab.ISO8601Format),
+	}
+	if req.Name != "" {
+		historyRange.Names = []string{req.Name}
+	}
 
 	rh := m2hist.getReadHistory()
-	cursor := rh.GetEventHistory(context.Background(), req.PublisherID, req.ThingID, req.Name)
-	val1, isValid := cursor.Seek(req.StartTime)
+	cursor, _, err := rh.GetRangeHistory(context.Background(), req.PublisherID, req.ThingID, historyRange)
+	if err != nil {
+		return err
+	}
+	defer cursor.Release()
+	val1, isValid := cursor.First()
 	results := []thing.ThingValue{val1}
 	if isValid {
 		var resp = mqttclient.ReadHistoryResponse{}
 		batch, itemsRemaining := cursor.NextN(uint(req.Limit))
 
-		// todo: truncate on duration
+		// the range cursor stops at the end of the duration
 		results = append(results, batch...)
 		//
 		resp.Name = req.Name
@@ -70,6 +89,42 @@ func (m2hist *Mqtt2History) handleReadHistory(payload []byte) (err error) {
 	return err
 }
 
+// handleReadAggregate reads the aggregate of an event's history and writes a response
+//
+//	payload contains the ReadAggregateRequest. The default range is the last 24 hours in
+//	intervals of an hour.
+func (m2hist *Mqtt2History) handleReadAggregate(payload []byte) (err error) {
+	req := mqttclient.ReadAggregateRequest{}
+	err = json.Unmarshal(payload, &req)
+	if err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	if req.StartTime == "" {
+		ago := time.Now().Add(-time.Hour * 24)
+		req.StartTime = ago.Format(vocab.ISO8601Format)
+	}
+	if req.Duration == 0 {
+		req.Duration = 24 * 3600
+	}
+	if req.Interval == 0 {
+		req.Interval = 3600
+	}
+	rh := m2hist.getReadHistory()
+	aggList, err := rh.GetAggregate(context.Background(),
+		req.PublisherID, req.ThingID, req.Name, req.StartTime, req.Duration, req.Interval)
+	if err == nil {
+		var resp = mqttclient.ReadAggregateResponse{
+			Name:        req.Name,
+			PublisherID: req.PublisherID,
+			ThingID:     req.ThingID,
+			Values:      aggList,
+		}
+		respJson, _ := json.Marshal(resp)
+		err = m2hist.writer.Write(mqttclient.ReadAggregateResponseTopic, respJson)
+	}
+	return err
+}
+
 func (m2hist *Mqtt2History) handleReadLatest(payload []byte) (err error) {
 	req := mqttclient.ReadLatestRequest{}
 	err = json.Unmarshal(payload, &req)
@@ -100,12 +155,17 @@ func (m2hist *Mqtt2History) handleReadLatest(payload []byte) (err error) {
 //
 //	read latest: services/history/action/latest
 //	reply:  services/history/event/latest
+//
+//	read aggregate: services/history/action/aggregate
+//	reply:  services/history/event/aggregate
 func (m2hist *Mqtt2History) HandleHistoryRequest(topic string, payload []byte) (err error) {
 
 	if topic == mqttclient.ReadHistoryRequestTopic {
 		err = m2hist.handleReadHistory(payload)
 	} else if topic == mqttclient.ReadLatestRequestTopic {
 		err = m2hist.handleReadLatest(payload)
+	} else if topic == mqttclient.ReadAggregateRequestTopic {
+		err = m2hist.handleReadAggregate(payload)
 	}
 	return err
 }
@@ -105,17 +105,17 @@ func (m2pubsub *Mqtt2PubSub) HandleSubscribe(mqttTopic string, payload []byte) e
 			mqttTopic, m2pubsub.clientID, err)
 	}
 
-	// TODO: authorization
+	// authorization is verified by the session ACL check before subscribing
 
 	// pass the subscription to the pubsub service and the resulting subscription messages to the mqttgw client.
 	if msgType == hubapi.MessageTypeEvent {
 		err = m2pubsub.getUserPubSub().SubEvent(context.Background(), pubID, thingID, name,
 			func(event thing.ThingValue) {
-				mqttTopic = mqttclient.MakeEventTopic(pubID, thingID, name)
+				evTopic := mqttclient.MakeEventTopic(pubID, thingID, name)
 				evJson, _ := json.Marshal(event)
-				err = m2pubsub.writer.Write(mqttTopic, evJson)
-				if err != nil {
-					logrus.Errorf("Failed to publish received event to mqttgw bus on topic '%s': %s", mqttTopic, err)
+				err2 := m2pubsub.writer.Write(evTopic, evJson)
+				if err2 != nil {
+					logrus.Errorf("Failed to publish received event to mqttgw bus on topic '%s': %s", evTopic, err2)
 				}
 			})
 		return err
@@ -125,11 +125,11 @@ func (m2pubsub *Mqtt2PubSub) HandleSubscribe(mqttTopic string, payload []byte) e
 		}
 		err = m2pubsub.getDevicePubSub().SubAction(context.Background(), thingID, name,
 			func(thingAction thing.ThingValue) {
-				mqttTopic = mqttclient.MakeActionTopic(pubID, thingID, name)
+				actionTopic := mqttclient.MakeActionTopic(pubID, thingID, name)
 				actionJson, _ := json.Marshal(thingAction)
-				err = m2pubsub.writer.Write(mqttTopic, actionJson)
-				if err != nil {
-					logrus.Errorf("Failed to publish received action to mqttgw bus on topic '%s': %s", mqttTopic, err)
+				err2 := m2pubsub.writer.Write(actionTopic, actionJson)
+				if err2 != nil {
+					logrus.Errorf("Failed to publish received action to mqttgw bus on topic '%s': %s", actionTopic, err2)
 				}
 			})
 		return err
@@ -31,13 +31,17 @@ func (hook *GatewayHook) OnAuthPacket(cl *mqtt.Client, pk packets.Packet) (packe
 	return pk, nil
 }
 
-// OnACLCheck returns true/allowed for all checks.
+// OnACLCheck returns true if the client of the session is allowed to publish or subscribe to the topic.
+// See MqttSession.HasPermission for the rules.
 func (hook *GatewayHook) OnACLCheck(cl *mqtt.Client, topic string, write bool) bool {
-	// TODO
-	_ = cl
-	_ = topic
-	_ = write
-	return true
+	hook.sessionMutex.RLock()
+	session, found := hook.sessions[cl.ID]
+	hook.sessionMutex.RUnlock()
+	if !found {
+		logrus.Warningf("missing session for mqttgw client connection %s", cl.ID)
+		return false
+	}
+	return session.HasPermission(topic, write)
 }
 
 // OnConnect creates a new mqttgw session
@@ -66,11 +70,11 @@ func (hook *GatewayHook) OnConnectAuthenticate(cl *mqtt.Client, pk packets.Packe
 		return false
 	}
 	// check for client cert auth
-	peerCert := listener.GetPeerCert(cl.Net.Conn)
+	peerCert, _, ou, _ := listener.GetPeerCert(cl.Net.Conn)
 	if peerCert == nil {
 		err = session.LoginWithPassword(clientID, password)
 	} else {
-		err = session.LoginWithCert(clientID, peerCert)
+		err = session.LoginWithCert(clientID, peerCert, ou)
 	}
 	if err != nil {
 		logrus.Warningf("invalid login attempt as '%s'", clientID)
@@ -115,6 +119,10 @@ func (hook *GatewayHook) OnSubscribe(cl *mqtt.Client, pk packets.Packet) (pkx pa
 	session := hook.sessions[cl.ID]
 	if session != nil && len(pk.Filters) > 0 {
 		mqttTopic := pk.Filters[0].Filter
+		// the broker checks the ACL after this hook, so only pass authorized subscriptions to the hub
+		if !session.HasPermission(mqttTopic, false) {
+			return pk
+		}
 		err = session.OnSubscribe(cl, mqttTopic, pk.Payload)
 		if err != nil {
 			err = fmt.Errorf("unable to subscribe to topic %s: %w", mqttTopic, err)
@@ -5,6 +5,8 @@ import (
 	"crypto/x509"
 	"fmt"
 	"github.com/hiveot/hub/lib/resolver"
+	"github.com/hiveot/hub/pkg/authz"
+	capnpclient5 "github.com/hiveot/hub/pkg/authz/capnpclient"
 	"github.com/hiveot/hub/pkg/directory"
 	capnpclient3 "github.com/hiveot/hub/pkg/directory/capnpclient"
 	"github.com/hiveot/hub/pkg/gateway"
@@ -110,6 +112,7 @@ func NewMqttGatewayService() *MqttService {
 	resolver.RegisterCapnpMarshaller[pubsub.IUserPubSub](capnpclient2.NewUserPubSubCapnpClient, "")
 	resolver.RegisterCapnpMarshaller[directory.IReadDirectory](capnpclient3.NewReadDirectoryCapnpClient, "")
 	resolver.RegisterCapnpMarshaller[history.IReadHistory](capnpclient4.NewReadHistoryCapnpClient, "")
+	resolver.RegisterCapnpMarshaller[authz.IVerifyAuthz](capnpclient5.NewVerifyAuthzCapnpClient, "")
 
 	svc := &MqttService{
 		sessionMutex: sync.RWMutex{},
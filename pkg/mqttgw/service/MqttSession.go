@@ -1,17 +1,31 @@
 package service
 
 import (
+	"context"
 	"crypto/x509"
 	"fmt"
+	"github.com/hiveot/hub/api/go/hubapi"
 	"github.com/hiveot/hub/lib/resolver"
 	"github.com/hiveot/hub/pkg/authn"
+	"github.com/hiveot/hub/pkg/authz"
 	"github.com/hiveot/hub/pkg/gateway"
 	"github.com/hiveot/hub/pkg/mqttgw/mqttclient"
 	"github.com/mochi-co/mqtt/v2"
 	"github.com/sirupsen/logrus"
 	"strings"
+	"sync"
+	"time"
 )
 
+// PermissionsCacheValidity is the duration the permissions of the session client are cached
+const PermissionsCacheValidity = time.Minute
+
+// cached permissions of the session client for a Thing
+type cachedPermissions struct {
+	permissions []string
+	expiry      time.Time
+}
+
 // MqttSession manages a MQTT client session with the HiveOT gateway
 // It is created by the mochi hook on a new incoming connection.
 // This session establishes a gateway session on startup and releases it on disconnect.
@@ -21,6 +35,15 @@ type MqttSession struct {
 	gwClient     gateway.IGatewaySession
 	refreshToken string
 
+	// the authenticated client ID and auth type, eg hubapi.AuthTypeUser, AuthTypeIotDevice, ...
+	clientID string
+	authType string
+	// authz capability to verify permissions is loaded on first use
+	verifyAuthz authz.IVerifyAuthz
+	// permissions of the client by thing address
+	permissions map[string]cachedPermissions
+	permMutex   sync.Mutex
+
 	userAuthn authn.IUserAuthn
 	m2dir     *Mqtt2Directory
 	m2hist    *Mqtt2History
@@ -35,20 +58,148 @@ func (session *MqttSession) OnDisconnect() {
 	if session.gwClient != nil {
 		session.gwClient.Release()
 	}
+	if session.verifyAuthz != nil {
+		session.verifyAuthz.Release()
+	}
+}
+
+// getPermissions returns the permissions of the session client for a Thing.
+// Permissions are obtained from the authz service and cached for PermissionsCacheValidity.
+func (session *MqttSession) getPermissions(thingAddr string) ([]string, error) {
+	session.permMutex.Lock()
+	defer session.permMutex.Unlock()
+
+	cached, found := session.permissions[thingAddr]
+	if found && time.Now().Before(cached.expiry) {
+		return cached.permissions, nil
+	}
+	if session.verifyAuthz == nil {
+		session.verifyAuthz = resolver.GetCapability[authz.IVerifyAuthz]()
+		if session.verifyAuthz == nil {
+			return nil, fmt.Errorf("authz service is not available")
+		}
+	}
+	permissions, err := session.verifyAuthz.GetPermissions(context.Background(), session.clientID, thingAddr)
+	if err != nil {
+		return nil, err
+	}
+	session.permissions[thingAddr] = cachedPermissions{
+		permissions: permissions,
+		expiry:      time.Now().Add(PermissionsCacheValidity),
+	}
+	return permissions, nil
+}
+
+// HasPermission verifies if the session client is allowed to publish or subscribe to a topic.
+//
+// Things topics have the format things/{publisherID}/{thingID}/{msgType}/{name}:
+//   - publishing an event requires permPubEvent, or permPubTD for the TD event. The
+//     publisherID must be the client itself.
+//   - publishing an action requires permEmitAction.
+//   - subscribing to events requires permReadEvent, or permReadTD for the TD event.
+//   - subscribing to actions requires permReadAction. The publisherID must be the client itself.
+//   - subscriptions with a wildcard publisherID or thingID are allowed. The pubsub service
+//     only passes the messages of Things the client has permission for.
+//
+// Hub services are allowed all topics. Directory and history service topics are allowed for
+// all authenticated clients, as these requests are verified by the services themselves.
+//
+//	mqttTopic is the topic to publish or subscribe to
+//	write is true for publishing and false for subscribing
+func (session *MqttSession) HasPermission(mqttTopic string, write bool) bool {
+	if session.clientID == "" || session.authType == "" {
+		logrus.Warningf("client is not authenticated")
+		return false
+	} else if session.authType == hubapi.AuthTypeService {
+		return true
+	} else if !mqttclient.IsThingsTopic(mqttTopic) {
+		return mqttclient.IsDirectoryTopic(mqttTopic) || mqttclient.IsHistoryTopic(mqttTopic)
+	}
+	pubID, thingID, msgType, name, err := mqttclient.SplitThingsTopic(mqttTopic)
+	if err != nil {
+		logrus.Warning(err)
+		return false
+	}
+	isWildcard := func(id string) bool {
+		return id == "+" || id == "#"
+	}
+	var permission string
+	if msgType == mqttclient.MessageTypeEvent {
+		if write {
+			permission = authz.PermPubEvent
+			if name == hubapi.EventNameTD {
+				permission = authz.PermPubTD
+			}
+			if pubID != session.clientID {
+				logrus.Warningf("client '%s' can't publish events for publisher '%s'", session.clientID, pubID)
+				return false
+			}
+		} else {
+			permission = authz.PermReadEvent
+			if name == hubapi.EventNameTD {
+				permission = authz.PermReadTD
+			}
+		}
+	} else if msgType == mqttclient.MessageTypeAction {
+		if write {
+			permission = authz.PermEmitAction
+		} else {
+			permission = authz.PermReadAction
+			if pubID != session.clientID {
+				logrus.Warningf("client '%s' can't subscribe to actions of publisher '%s'", session.clientID, pubID)
+				return false
+			}
+		}
+	} else {
+		logrus.Warningf("unsupported message type in topic '%s'", mqttTopic)
+		return false
+	}
+	if isWildcard(pubID) || isWildcard(thingID) {
+		// messages of wildcard subscriptions are filtered by the pubsub service
+		// publishing to wildcards is not allowed
+		return !write
+	}
+	thingAddr := pubID + "/" + thingID
+	permissions, err := session.getPermissions(thingAddr)
+	if err != nil {
+		logrus.Errorf("unable to verify permissions of client '%s' for '%s': %s",
+			session.clientID, thingAddr, err)
+		return false
+	}
+	for _, perm := range permissions {
+		if perm == permission {
+			return true
+		}
+	}
+	logrus.Warningf("client '%s' with auth type '%s' is denied '%s' on topic '%s'",
+		session.clientID, session.authType, permission, mqttTopic)
+	return false
 }
 
 // LoginWithPassword to the resolver session, most likely the gateway
 // This requires that the resolver client is connected to the resolver service.
+// On success the session is authenticated as a user.
 func (session *MqttSession) LoginWithPassword(loginID, password string) error {
-	//session.clientID = loginID
-	err := resolver.Login(loginID, password)
+	err := resolver.LoginWithPassword(loginID, password)
+	if err == nil {
+		session.clientID = loginID
+		session.authType = hubapi.AuthTypeUser
+	}
 	return err
 }
 
 // LoginWithCert login to the resolver session using a client certificate
-func (session *MqttSession) LoginWithCert(loginID string, peerCert *x509.Certificate) error {
-	//session.clientID = loginID
+// On success the session is authenticated with the auth type of the certificate OU.
+//
+//	loginID is the client ID of the certificate
+//	peerCert is the client certificate
+//	authType is the certificate OU, eg hubapi.AuthTypeIotDevice, AuthTypeService or AuthTypeUser
+func (session *MqttSession) LoginWithCert(loginID string, peerCert *x509.Certificate, authType string) error {
 	err := resolver.LoginWithCert(loginID, peerCert)
+	if err == nil {
+		session.clientID = loginID
+		session.authType = authType
+	}
 	return err
 }
 
@@ -114,8 +265,9 @@ func NewMqttSession(client *mqtt.Client) (session *MqttSession, err error) {
 	clientID := string(client.Properties.Username)
 	writer := NewMqttClientWriter(client)
 	session = &MqttSession{
-		mqttClient: client,
-		gwClient:   nil, //gwClient,
+		mqttClient:  client,
+		gwClient:    nil, //gwClient,
+		permissions: make(map[string]cachedPermissions),
 		// FIXME: get the login ID
 		m2dir:    NewMqtt2Directory(clientID, writer),
 		m2hist:   NewMqtt2History(clientID, writer),
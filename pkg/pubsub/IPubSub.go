@@ -10,6 +10,12 @@ import (
 
 const ServiceName = hubapi.PubsubServiceName
 
+// EventNameSubscriptionClosed is the ID of the value that is passed to a subscription handler
+// when the pubsub service closes the subscription, for example because the subscriber is too
+// slow. The value is published by the pubsub service and its data holds the reason.
+// No further values are passed to the handler.
+const EventNameSubscriptionClosed = "subscriptionClosed"
+
 // A note on pubsub addressing:
 // Any IoT device or service that publishes Thing events and listens for actions is a gateway
 // for those Things. A gateway can host just one or multiple Things.
@@ -31,9 +37,44 @@ const ServiceName = hubapi.PubsubServiceName
 //
 // * Valid publisherIDs and thingIDs must start with "urn:" and contain only alphanum or ":_-." characters.
 // * The character "+" is a wildcard characters for that part of the address.
+// * The character "#" at the end of a subscription address is a wildcard for all remaining parts.
 // * Publishers listen for actions on the address {publisherID}/+/action/+
 // * Publishers publish events on the address {publisherID}/{thingID}/event/{name}
 
+// ActionStatus holds the status of an action request.
+// The status is one of hubapi.ActionStatusPending, ActionStatusDelivered, ActionStatusCompleted
+// or ActionStatusFailed.
+type ActionStatus struct {
+	// ID of the action request
+	RequestID string `json:"requestID"`
+	// Publisher of the Thing the action is for
+	PublisherID string `json:"publisherID"`
+	// ID of the Thing the action is for
+	ThingID string `json:"thingID"`
+	// ID of the action in the Thing's TD action map
+	ActionID string `json:"actionID"`
+	// Status of the request
+	Status string `json:"status"`
+	// Error with the reason the action has failed
+	Error string `json:"error,omitempty"`
+}
+
+// SubscriptionStats contains the delivery counters of a subscription
+type SubscriptionStats struct {
+	// ID of the subscription
+	SubscriptionID string `json:"subscriptionID"`
+	// ID of the client that subscribed
+	SubscriberID string `json:"subscriberID"`
+	// Topic subscribed to
+	Topic string `json:"topic"`
+	// Pending is the number of messages waiting in the queue
+	Pending int `json:"pending"`
+	// Delivered is the number of messages passed to the subscriber's handler
+	Delivered uint64 `json:"delivered"`
+	// Dropped is the number of messages dropped due to a full queue
+	Dropped uint64 `json:"dropped"`
+}
+
 // The IPubSubService interface provides a high level API to publish and subscribe actions and events
 type IPubSubService interface {
 
@@ -59,6 +100,10 @@ type IPubSubService interface {
 	//
 	//  userID is the login ID of an authenticated user and is used as the publisherID
 	CapUserPubSub(ctx context.Context, userID string) (IUserPubSub, error)
+
+	// GetSubscriptionStats returns the delivery counters of all subscriptions.
+	// Intended to identify slow subscribers that drop messages.
+	GetSubscriptionStats(ctx context.Context) ([]SubscriptionStats, error)
 }
 
 // IDevicePubSub available to an IoT device
@@ -79,6 +124,15 @@ type IDevicePubSub interface {
 	//  value is the serialized event value, or nil if the event has no value
 	PubEvent(ctx context.Context, thingID, eventID string, value []byte) (err error)
 
+	// PubActionStatus publishes the status of an action request back to the user that made the request.
+	// Actions that expect a status reply have a RequestID. The request ends with the
+	// completed or failed status.
+	//
+	//  requestID of the action request as provided in the action ThingValue
+	//  status is one of hubapi.ActionStatusXyz values
+	//  errMsg with the reason when the action has failed, or "" otherwise
+	PubActionStatus(ctx context.Context, requestID string, status string, errMsg string) (err error)
+
 	// Release the capability and end subscriptions
 	Release()
 
@@ -91,6 +145,8 @@ type IDevicePubSub interface {
 	// * the key in the properties map of the TD when requesting a change to a single property
 	//   the data provided is the serialized new value.
 	//
+	// Queued action requests for the things that have not yet expired are delivered after subscribing.
+	//
 	//  thingID is the ID of the Thing whose action to subscribe to, or "" for all things managed by this device.
 	//  actionID is the action ID, the key in TD action map. Use "" to subscribe to all actions including properties
 	//  handler will be invoked when an action is received for this device
@@ -108,6 +164,15 @@ type IServicePubSub interface {
 	// IUserPubSub allows services to consume other things
 	IUserPubSub
 
+	// ClearRetained removes the retained value of an event of a Thing from any publisher.
+	// New subscribers no longer receive it. This is intended for removing the TD of a Thing
+	// that no longer exists.
+	//
+	//  publisherID is the ID of the publisher of the Thing
+	//  thingID is the ID of the Thing whose retained event to remove
+	//  eventID of the retained event, eg hubapi.EventNameTD
+	ClearRetained(ctx context.Context, publisherID, thingID string, eventID string) (err error)
+
 	// SubActions subscribes to actions aimed at things from any publisher.
 	//
 	// This is intended for services that track actions aimed at other devices or services,
@@ -151,11 +216,33 @@ type IUserPubSub interface {
 	// This returns an error if the action request could not be delivered.
 	PubAction(ctx context.Context, publisherID, thingID, actionID string, data []byte) (err error)
 
+	// PubActionRequest publishes an action request for a Thing that is queued when the device is offline.
+	//
+	// The action is queued until the device subscribes to the action, or until the expiry time is
+	// reached, after which the request has failed. The device can reply with the status of the action
+	// using the RequestID of the action.
+	//
+	//  publisherID is the ID of the device or service that is publishing the thing
+	//  thingID is the ID of the Thing whose action is being requested
+	//  actionID is the action ID as defined in the Thing's TD actions map
+	//  data is the serialized value of the action
+	//  expiry is the time in seconds since epoch until which the action is queued. 0 to not queue.
+	//  statusHandler is invoked with status updates of the request. nil to ignore.
+	//
+	// This returns the status of the request containing the requestID. The status is pending if the
+	// action is queued, delivered if the device is online, or failed if the device is offline and
+	// the action was not queued.
+	PubActionRequest(ctx context.Context, publisherID, thingID, actionID string, data []byte,
+		expiry int64, statusHandler func(status ActionStatus)) (status ActionStatus, err error)
+
 	// SubEvent subscribes to events from a thing
 	//
 	// It is not allowed to subscribe to all events of all things of all publishers.
 	// A thingID or an eventID must be provided or this will return an error.
 	//
+	// The last value of retained events, like the TD and properties, is passed to the
+	// handler immediately after subscribing.
+	//
 	//  publisherID is the ID of the device or service that is publishing the thing event.
 	//  thingID is the ID of the Thing whose event is published.
 	//  eventID of the event. Use "" to subscribe to all events of a thing.
@@ -2,28 +2,39 @@ package pubsub_test
 
 import (
 	"context"
+	"github.com/hiveot/hub/api/go/hubapi"
 	"github.com/hiveot/hub/lib/hubclient"
 	"net"
 	"os"
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/hiveot/hub/lib/logging"
 	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/authz"
 	"github.com/hiveot/hub/pkg/pubsub"
 	"github.com/hiveot/hub/pkg/pubsub/capnpclient"
 	"github.com/hiveot/hub/pkg/pubsub/capnpserver"
+	"github.com/hiveot/hub/pkg/pubsub/config"
+	"github.com/hiveot/hub/pkg/pubsub/core"
 	"github.com/hiveot/hub/pkg/pubsub/service"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const testAddress = "/tmp/pubsub_test.socket"
 const testUseCapnp = true
 
 func startService(useCapnp bool) (pubsub.IPubSubService, func()) {
-	svc := service.NewPubSubService()
+	return startServiceWithConfig(config.NewPubSubConfig(), useCapnp)
+}
+
+func startServiceWithConfig(cfg config.PubSubConfig, useCapnp bool) (pubsub.IPubSubService, func()) {
+	svc := service.NewPubSubService(cfg, nil)
 	err := svc.Start()
 	if err != nil {
 		logrus.Panicf("not happy: %s", err)
@@ -74,9 +85,9 @@ func TestPubSubAction(t *testing.T) {
 	const thing1ID = "urn:thing1"
 	const actionName1 = "action1"
 	const actionName2 = "action2"
-	var deviceAction = 0
-	var serviceAction = 0
-	var wildcardAction = 0
+	var deviceAction = int32(0)
+	var serviceAction = int32(0)
+	var wildcardAction = int32(0)
 
 	ctx := context.Background()
 	svc, stopFn := startService(testUseCapnp)
@@ -87,29 +98,33 @@ func TestPubSubAction(t *testing.T) {
 
 	// test subscription of a single action by both service and device
 	err := devicePS.SubAction(ctx, thing1ID, actionName1, func(val thing.ThingValue) {
-		deviceAction++
+		atomic.AddInt32(&deviceAction, 1)
 	})
 	assert.NoError(t, err)
 	err = servicePS.SubActions(ctx, publisherID, thing1ID, actionName1, func(val thing.ThingValue) {
-		serviceAction++
+		atomic.AddInt32(&serviceAction, 1)
 	})
 	action1Msg := []byte("action1")
 	err = servicePS.PubAction(ctx, publisherID, thing1ID, actionName1, action1Msg)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, deviceAction)
-	assert.Equal(t, 1, serviceAction)
+	// delivery is asynchronous
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&deviceAction))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&serviceAction))
 
 	// test subscription of a wildcards action
 	err = devicePS.SubAction(ctx, "+", "+", func(val thing.ThingValue) {
-		wildcardAction++
+		atomic.AddInt32(&wildcardAction, 1)
 	})
 	assert.NoError(t, err)
 	action2Msg := []byte("more of action")
 	err = servicePS.PubAction(ctx, publisherID, thing1ID, actionName2, action2Msg)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, deviceAction)
-	assert.Equal(t, 1, serviceAction)
-	assert.Equal(t, 1, wildcardAction)
+	// delivery is asynchronous
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&deviceAction))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&serviceAction))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&wildcardAction))
 
 	devicePS.Release()
 	servicePS.Release()
@@ -145,3 +160,541 @@ func TestPubSubEvent(t *testing.T) {
 	userPS.Release()
 	assert.NoError(t, err)
 }
+
+func TestSubscriptionStats(t *testing.T) {
+	const publisher1ID = "urn:device1"
+	const thing1ID = "urn:thing1"
+	const user1ID = "urn:user"
+	const event1Name = "event1"
+
+	ctx := context.Background()
+	svc, stopFn := startService(testUseCapnp)
+	defer stopFn()
+
+	devicePS, _ := svc.CapDevicePubSub(ctx, publisher1ID)
+	userPS, _ := svc.CapUserPubSub(ctx, user1ID)
+
+	err := userPS.SubEvent(ctx, publisher1ID, thing1ID, event1Name, func(val thing.ThingValue) {})
+	require.NoError(t, err)
+	err = devicePS.PubEvent(ctx, thing1ID, event1Name, []byte("event one"))
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+
+	statsList, err := svc.GetSubscriptionStats(ctx)
+	require.NoError(t, err)
+	var found bool
+	for _, stats := range statsList {
+		if stats.SubscriberID == user1ID {
+			found = true
+			assert.NotEmpty(t, stats.SubscriptionID)
+			assert.Contains(t, stats.Topic, event1Name)
+			assert.Equal(t, uint64(1), stats.Delivered)
+			assert.Equal(t, uint64(0), stats.Dropped)
+		}
+	}
+	assert.True(t, found, "missing stats of the user subscription")
+
+	devicePS.Release()
+	userPS.Release()
+}
+
+// a subscription that is closed by the disconnect overflow policy notifies its subscriber
+func TestSubscriptionClosed(t *testing.T) {
+	const publisher1ID = "urn:device1"
+	const thing1ID = "urn:thing1"
+	const user1ID = "urn:user"
+	const event1Name = "event1"
+	var closedValues = make([]thing.ThingValue, 0)
+	var nrReceived int
+	var rxMux sync.Mutex
+
+	ctx := context.Background()
+	cfg := config.NewPubSubConfig()
+	cfg.QueueSize = 5
+	cfg.OverflowPolicy = core.OverflowDisconnect
+	svc, stopFn := startServiceWithConfig(cfg, testUseCapnp)
+	defer stopFn()
+
+	devicePS, _ := svc.CapDevicePubSub(ctx, publisher1ID)
+	userPS, _ := svc.CapUserPubSub(ctx, user1ID)
+
+	blockChan := make(chan bool)
+	err := userPS.SubEvent(ctx, publisher1ID, thing1ID, event1Name, func(val thing.ThingValue) {
+		rxMux.Lock()
+		defer rxMux.Unlock()
+		if val.ID == pubsub.EventNameSubscriptionClosed {
+			closedValues = append(closedValues, val)
+			return
+		}
+		rxMux.Unlock()
+		<-blockChan
+		rxMux.Lock()
+		nrReceived++
+	})
+	require.NoError(t, err)
+
+	// the first event blocks the handler and the next overflow the queue
+	for i := 0; i < 2*cfg.QueueSize; i++ {
+		err = devicePS.PubEvent(ctx, thing1ID, event1Name, []byte(strconv.Itoa(i)))
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+	}
+	statsList, err := svc.GetSubscriptionStats(ctx)
+	require.NoError(t, err)
+	for _, stats := range statsList {
+		assert.NotEqual(t, user1ID, stats.SubscriberID, "subscription is not closed")
+	}
+	close(blockChan)
+	time.Sleep(time.Millisecond * 10)
+
+	// the subscriber is told its subscription was closed and receives no further events
+	rxMux.Lock()
+	require.Equal(t, 1, len(closedValues))
+	assert.Equal(t, pubsub.ServiceName, closedValues[0].PublisherID)
+	assert.NotEmpty(t, closedValues[0].Data)
+	assert.Equal(t, 1, nrReceived)
+	rxMux.Unlock()
+	err = devicePS.PubEvent(ctx, thing1ID, event1Name, []byte("after close"))
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	rxMux.Lock()
+	assert.Equal(t, 1, nrReceived)
+	rxMux.Unlock()
+
+	devicePS.Release()
+	userPS.Release()
+}
+
+func TestRetainedEvents(t *testing.T) {
+	const publisher1ID = "urn:device1"
+	const thing1ID = "urn:thing1"
+	const user1ID = "urn:user"
+	const event1Name = "event1"
+	var received = make(map[string]string)
+	var rxMux sync.Mutex
+
+	ctx := context.Background()
+	svc, stopFn := startService(testUseCapnp)
+	defer stopFn()
+
+	devicePS, _ := svc.CapDevicePubSub(ctx, publisher1ID)
+	userPS, _ := svc.CapUserPubSub(ctx, user1ID)
+	defer devicePS.Release()
+	defer userPS.Release()
+
+	// the TD and properties events are retained, other events are not
+	err := devicePS.PubEvent(ctx, thing1ID, hubapi.EventNameTD, []byte("td1"))
+	assert.NoError(t, err)
+	err = devicePS.PubEvent(ctx, thing1ID, hubapi.EventNameTD, []byte("td2"))
+	assert.NoError(t, err)
+	err = devicePS.PubEvent(ctx, thing1ID, hubapi.EventNameProperties, []byte("props"))
+	assert.NoError(t, err)
+	err = devicePS.PubEvent(ctx, thing1ID, event1Name, []byte("event one"))
+	assert.NoError(t, err)
+
+	// a late subscriber receives the last retained values on subscribe
+	err = userPS.SubEvent(ctx, "", thing1ID, "", func(val thing.ThingValue) {
+		rxMux.Lock()
+		defer rxMux.Unlock()
+		received[val.ID] = string(val.Data)
+	})
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	rxMux.Lock()
+	assert.Equal(t, 2, len(received))
+	assert.Equal(t, "td2", received[hubapi.EventNameTD])
+	assert.Equal(t, "props", received[hubapi.EventNameProperties])
+	rxMux.Unlock()
+
+	// new events are received as usual
+	err = devicePS.PubEvent(ctx, thing1ID, event1Name, []byte("event two"))
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	rxMux.Lock()
+	assert.Equal(t, "event two", received[event1Name])
+	rxMux.Unlock()
+}
+
+func TestQueuedActions(t *testing.T) {
+	const publisher1ID = "urn:device1"
+	const thing1ID = "urn:thing1"
+	const user1ID = "urn:user"
+	const action1Name = "action1"
+	var statusList = make([]pubsub.ActionStatus, 0)
+	var actions = make([]thing.ThingValue, 0)
+	var rxMux sync.Mutex
+	statusHandler := func(status pubsub.ActionStatus) {
+		rxMux.Lock()
+		defer rxMux.Unlock()
+		statusList = append(statusList, status)
+	}
+
+	ctx := context.Background()
+	svc, stopFn := startService(testUseCapnp)
+	defer stopFn()
+
+	userPS, _ := svc.CapUserPubSub(ctx, user1ID)
+	defer userPS.Release()
+
+	// an action without expiry is not queued while the device is offline
+	status, err := userPS.PubActionRequest(ctx, publisher1ID, thing1ID, action1Name, []byte("1"), 0, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, hubapi.ActionStatusFailed, status.Status)
+
+	// an action with expiry is queued
+	expiry := time.Now().Add(time.Minute).Unix()
+	status, err = userPS.PubActionRequest(ctx, publisher1ID, thing1ID, action1Name, []byte("2"), expiry, statusHandler)
+	assert.NoError(t, err)
+	assert.Equal(t, hubapi.ActionStatusPending, status.Status)
+	assert.NotEmpty(t, status.RequestID)
+	requestID := status.RequestID
+
+	// the device receives the queued action after it subscribes
+	devicePS, _ := svc.CapDevicePubSub(ctx, publisher1ID)
+	err = devicePS.SubAction(ctx, thing1ID, "", func(action thing.ThingValue) {
+		rxMux.Lock()
+		defer rxMux.Unlock()
+		actions = append(actions, action)
+	})
+	assert.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	rxMux.Lock()
+	require.Equal(t, 1, len(actions))
+	assert.Equal(t, "2", string(actions[0].Data))
+	assert.Equal(t, requestID, actions[0].RequestID)
+	assert.Equal(t, expiry, actions[0].Expiry)
+	require.Equal(t, 1, len(statusList))
+	assert.Equal(t, hubapi.ActionStatusDelivered, statusList[0].Status)
+	rxMux.Unlock()
+
+	// the device status reply flows back to the user
+	err = devicePS.PubActionStatus(ctx, requestID, hubapi.ActionStatusCompleted, "")
+	assert.NoError(t, err)
+	rxMux.Lock()
+	require.Equal(t, 2, len(statusList))
+	assert.Equal(t, hubapi.ActionStatusCompleted, statusList[1].Status)
+	assert.Equal(t, requestID, statusList[1].RequestID)
+	assert.Equal(t, action1Name, statusList[1].ActionID)
+	rxMux.Unlock()
+
+	// a completed request has ended
+	err = devicePS.PubActionStatus(ctx, requestID, hubapi.ActionStatusCompleted, "")
+	assert.Error(t, err)
+
+	// the device is online so the action is delivered immediately
+	status, err = userPS.PubActionRequest(ctx, publisher1ID, thing1ID, action1Name, []byte("3"), 0, statusHandler)
+	assert.NoError(t, err)
+	assert.Equal(t, hubapi.ActionStatusDelivered, status.Status)
+	// other devices cannot reply to the request
+	device2PS, _ := svc.CapDevicePubSub(ctx, "urn:device2")
+	err = device2PS.PubActionStatus(ctx, status.RequestID, hubapi.ActionStatusCompleted, "")
+	assert.Error(t, err)
+	device2PS.Release()
+	err = devicePS.PubActionStatus(ctx, status.RequestID, hubapi.ActionStatusFailed, "not today")
+	assert.NoError(t, err)
+	rxMux.Lock()
+	require.Equal(t, 3, len(statusList))
+	assert.Equal(t, hubapi.ActionStatusFailed, statusList[2].Status)
+	assert.Equal(t, "not today", statusList[2].Error)
+	rxMux.Unlock()
+
+	// after the device is released, actions are queued again
+	devicePS.Release()
+	status, err = userPS.PubActionRequest(ctx, publisher1ID, thing1ID, action1Name, []byte("4"), expiry, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, hubapi.ActionStatusPending, status.Status)
+}
+
+func TestActionQueueExpiry(t *testing.T) {
+	const publisher1ID = "urn:device1"
+	var statusList = make([]pubsub.ActionStatus, 0)
+	psc := core.NewPubSubCore(core.DefaultQueueSize, core.OverflowDropOldest)
+	aq := service.NewActionQueue(psc, 2, time.Minute)
+	statusHandler := func(status pubsub.ActionStatus) {
+		statusList = append(statusList, status)
+	}
+	tv := thing.NewThingValue(publisher1ID, "thing1", "action1", []byte("1"))
+	tv.Expiry = time.Now().Add(time.Minute).Unix()
+
+	status, err := aq.PubAction(tv, statusHandler)
+	assert.NoError(t, err)
+	assert.Equal(t, hubapi.ActionStatusPending, status.Status)
+	_, err = aq.PubAction(tv, statusHandler)
+	assert.NoError(t, err)
+	// the queue of the device is full
+	_, err = aq.PubAction(tv, statusHandler)
+	assert.Error(t, err)
+
+	// nothing has expired yet
+	nrRemoved := aq.PurgeExpired(time.Now())
+	assert.Equal(t, 0, nrRemoved)
+	// expired queued actions have failed
+	nrRemoved = aq.PurgeExpired(time.Now().Add(time.Hour))
+	assert.Equal(t, 2, nrRemoved)
+	require.Equal(t, 2, len(statusList))
+	assert.Equal(t, hubapi.ActionStatusFailed, statusList[0].Status)
+
+	// expired actions are not delivered to a new subscriber
+	aq.AddSubscriber("sub1", publisher1ID, "", "")
+	assert.Equal(t, 2, len(statusList))
+	_ = psc.Stop()
+}
+
+// dummy authz verification with permissions by clientID and thing address
+type dummyVerifyAuthz struct {
+	permissions map[string][]string
+}
+
+func (d *dummyVerifyAuthz) Release() {}
+func (d *dummyVerifyAuthz) GetPermissions(
+	_ context.Context, clientID, thingAddr string) ([]string, error) {
+	return d.permissions[clientID+"@"+thingAddr], nil
+}
+
+func TestPubSubAuthz(t *testing.T) {
+	const publisher1ID = "urn:device1"
+	const thing1ID = "urn:thing1"
+	const thing2ID = "urn:thing2"
+	const user1ID = "urn:user"
+	const event1Name = "event1"
+	var event1Count = int32(0)
+	var tdCount = int32(0)
+	thing1Addr := publisher1ID + "/" + thing1ID
+	thing2Addr := publisher1ID + "/" + thing2ID
+
+	// the device can publish events for thing1 and thing2 but only the TD of thing1.
+	// the user can read events of thing1 only and emit actions to thing2 only.
+	verifyAuthz := &dummyVerifyAuthz{permissions: map[string][]string{
+		publisher1ID + "@" + thing1Addr: {authz.PermPubEvent, authz.PermPubTD},
+		publisher1ID + "@" + thing2Addr: {authz.PermPubEvent, authz.PermReadAction},
+		user1ID + "@" + thing1Addr:      {authz.PermReadEvent},
+		user1ID + "@" + thing2Addr:      {authz.PermEmitAction},
+	}}
+	ctx := context.Background()
+	svc := service.NewPubSubService(config.NewPubSubConfig(), verifyAuthz)
+	err := svc.Start()
+	assert.NoError(t, err)
+	defer svc.Stop()
+
+	devicePS, _ := svc.CapDevicePubSub(ctx, publisher1ID)
+	userPS, _ := svc.CapUserPubSub(ctx, user1ID)
+	defer devicePS.Release()
+	defer userPS.Release()
+
+	// subscribing to a thing without read permission fails
+	err = userPS.SubEvent(ctx, publisher1ID, thing2ID, event1Name, func(val thing.ThingValue) {})
+	assert.Error(t, err)
+	err = userPS.SubEvent(ctx, publisher1ID, thing1ID, hubapi.EventNameTD, func(val thing.ThingValue) {})
+	assert.Error(t, err)
+	err = devicePS.SubAction(ctx, thing1ID, "", func(val thing.ThingValue) {})
+	assert.Error(t, err)
+	err = devicePS.SubAction(ctx, thing2ID, "", func(val thing.ThingValue) {})
+	assert.NoError(t, err)
+
+	// wildcard subscriptions only receive events the user is allowed to read
+	err = userPS.SubEvent(ctx, "", "", event1Name, func(val thing.ThingValue) {
+		assert.Equal(t, thing1ID, val.ThingID)
+		atomic.AddInt32(&event1Count, 1)
+	})
+	assert.NoError(t, err)
+	err = userPS.SubEvent(ctx, "", "", hubapi.EventNameTD, func(val thing.ThingValue) {
+		atomic.AddInt32(&tdCount, 1)
+	})
+	assert.NoError(t, err)
+
+	err = devicePS.PubEvent(ctx, thing1ID, event1Name, []byte("event one"))
+	assert.NoError(t, err)
+	err = devicePS.PubEvent(ctx, thing2ID, event1Name, []byte("event two"))
+	assert.NoError(t, err)
+	err = devicePS.PubEvent(ctx, thing1ID, hubapi.EventNameTD, []byte("{}"))
+	assert.NoError(t, err)
+	// publishing a TD without permission fails
+	err = devicePS.PubEvent(ctx, thing2ID, hubapi.EventNameTD, []byte("{}"))
+	assert.Error(t, err)
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&event1Count))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&tdCount))
+
+	// actions can only be emitted with permission
+	err = userPS.PubAction(ctx, publisher1ID, thing1ID, "action1", []byte("1"))
+	assert.Error(t, err)
+	err = userPS.PubAction(ctx, publisher1ID, thing2ID, "action1", []byte("1"))
+	assert.NoError(t, err)
+}
+
+func TestCoreWildcards(t *testing.T) {
+	var received = make(map[string]int)
+	var rxMux sync.Mutex
+	psc := core.NewPubSubCore(core.DefaultQueueSize, core.OverflowDropOldest)
+	subscribe := func(topic string) string {
+		subID, err := psc.Subscribe("test", topic, func(_ string, _ []byte) {
+			rxMux.Lock()
+			received[topic]++
+			rxMux.Unlock()
+		}, nil)
+		assert.NoError(t, err)
+		return subID
+	}
+	nrReceived := func(topic string) int {
+		rxMux.Lock()
+		defer rxMux.Unlock()
+		return received[topic]
+	}
+	subscribe("things/pub1/thing1/event/temperature")
+	subscribe("things/+/thing1/event/+")
+	subscribe("things/pub1/#")
+	allID := subscribe("#")
+
+	// '#' is only allowed at the end of the topic
+	_, err := psc.Subscribe("test", "things/#/thing1", func(_ string, _ []byte) {}, nil)
+	assert.Error(t, err)
+
+	psc.Publish("things/pub1/thing1/event/temperature", []byte("21"))
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, 1, nrReceived("things/pub1/thing1/event/temperature"))
+	assert.Equal(t, 1, nrReceived("things/+/thing1/event/+"))
+	assert.Equal(t, 1, nrReceived("things/pub1/#"))
+	assert.Equal(t, 1, nrReceived("#"))
+
+	// multi-level wildcard also matches the parent level
+	psc.Publish("things/pub1", []byte("hi"))
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, 2, nrReceived("things/pub1/#"))
+	assert.Equal(t, 1, nrReceived("things/+/thing1/event/+"))
+
+	// no more messages after unsubscribe
+	err = psc.Unsubscribe([]string{allID})
+	assert.NoError(t, err)
+	psc.Publish("things/pub2/thing2/event/temperature", []byte("22"))
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, 2, nrReceived("#"))
+	assert.Equal(t, 1, nrReceived("things/+/thing1/event/+"))
+
+	// remaining subscriptions are reported on stop
+	err = psc.Stop()
+	assert.Error(t, err)
+}
+
+// a slow subscriber must not block the publisher and loses messages according to the overflow policy
+func TestCoreOverflow(t *testing.T) {
+	const queueSize = 10
+	const topic = "things/pub1/thing1/event/temperature"
+
+	for _, policy := range []string{core.OverflowDropOldest, core.OverflowDropNewest, core.OverflowDisconnect} {
+		var lastReceived string
+		var rxMux sync.Mutex
+		psc := core.NewPubSubCore(queueSize, policy)
+		blockChan := make(chan bool)
+		subID, err := psc.Subscribe("test", topic, func(_ string, msg []byte) {
+			<-blockChan
+			rxMux.Lock()
+			lastReceived = string(msg)
+			rxMux.Unlock()
+		}, nil)
+		require.NoError(t, err)
+
+		// the first message is taken by the handler, the next fill the queue
+		psc.Publish(topic, []byte("0"))
+		time.Sleep(time.Millisecond * 10)
+		t0 := time.Now()
+		for i := 1; i < 2*queueSize; i++ {
+			psc.Publish(topic, []byte(strconv.Itoa(i)))
+		}
+		assert.Less(t, time.Since(t0), time.Second, "publisher is blocked")
+
+		stats := psc.GetStats()
+		if policy == core.OverflowDisconnect {
+			// the subscription is removed
+			assert.Equal(t, 0, len(stats))
+			close(blockChan)
+			_ = psc.Unsubscribe([]string{subID})
+			continue
+		}
+		require.Equal(t, 1, len(stats))
+		assert.Equal(t, subID, stats[0].SubscriptionID)
+		assert.Equal(t, queueSize, stats[0].Pending)
+		assert.Equal(t, uint64(queueSize-1), stats[0].Dropped)
+
+		// let the handler complete
+		close(blockChan)
+		time.Sleep(time.Millisecond * 10)
+		stats = psc.GetStats()
+		assert.Equal(t, uint64(queueSize+1), stats[0].Delivered)
+		assert.Equal(t, 0, stats[0].Pending)
+		rxMux.Lock()
+		if policy == core.OverflowDropOldest {
+			assert.Equal(t, strconv.Itoa(2*queueSize-1), lastReceived)
+		} else {
+			assert.Equal(t, strconv.Itoa(queueSize), lastReceived)
+		}
+		rxMux.Unlock()
+		_ = psc.Unsubscribe([]string{subID})
+		err = psc.Stop()
+		assert.NoError(t, err)
+	}
+}
+
+func TestCoreRetained(t *testing.T) {
+	var received = make([]string, 0)
+	var rxMux sync.Mutex
+	psc := core.NewPubSubCore(core.DefaultQueueSize, core.OverflowDropOldest)
+	handler := func(topic string, _ []byte) {
+		rxMux.Lock()
+		received = append(received, topic)
+		rxMux.Unlock()
+	}
+	psc.PublishRetained("things/pub1/thing1/event/td", []byte("td1"))
+	psc.PublishRetained("things/pub1/thing2/event/td", []byte("td2"))
+	psc.PublishRetained("things/pub2/thing3/event/td", []byte("td3"))
+	// an empty payload removes the retained message
+	psc.PublishRetained("things/pub2/thing3/event/td", nil)
+
+	subID, err := psc.Subscribe("test", "things/pub1/#", handler, nil)
+	require.NoError(t, err)
+	subID2, err := psc.Subscribe("test", "things/+/+/event/td", handler, nil)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+	rxMux.Lock()
+	assert.Equal(t, 4, len(received))
+	rxMux.Unlock()
+
+	err = psc.Unsubscribe([]string{subID, subID2})
+	assert.NoError(t, err)
+	err = psc.Stop()
+	assert.NoError(t, err)
+}
+
+func TestCoreRetainedQueueSize(t *testing.T) {
+	const queueSize = 2
+	const nrRetained = 10
+	var nrReceived atomic.Int32
+	unblock := make(chan bool)
+	psc := core.NewPubSubCore(queueSize, core.OverflowDropNewest)
+	for i := 0; i < nrRetained; i++ {
+		psc.PublishRetained("things/pub1/thing"+strconv.Itoa(i)+"/event/td", []byte("td"))
+	}
+	// the handler blocks on the first retained message
+	subID, err := psc.Subscribe("test", "things/pub1/+/event/#", func(topic string, _ []byte) {
+		<-unblock
+		nrReceived.Add(1)
+	}, nil)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond * 10)
+
+	// the retained messages don't take up room in the queue
+	for i := 0; i < 5; i++ {
+		psc.Publish("things/pub1/thing1/event/event1", []byte("event"))
+	}
+	stats := psc.GetStats()
+	require.Equal(t, 1, len(stats))
+	assert.Equal(t, queueSize, stats[0].Pending)
+	assert.Equal(t, uint64(5-queueSize), stats[0].Dropped)
+	close(unblock)
+	time.Sleep(time.Millisecond * 10)
+	assert.Equal(t, int32(nrRetained+queueSize), nrReceived.Load())
+
+	err = psc.Unsubscribe([]string{subID})
+	assert.NoError(t, err)
+	err = psc.Stop()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,33 @@
+package capnpclient
+
+import (
+	"context"
+
+	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/pkg/pubsub"
+	"github.com/hiveot/hub/pkg/pubsub/capserializer"
+)
+
+// ActionStatusHandlerCapnpServer is the capnp server callback for action status updates.
+// Like the SubscriptionHandlerCapnpServer this lives on the client side of the RPC connection.
+// This implements the hubapi.CapActionStatusHandler interface
+type ActionStatusHandlerCapnpServer struct {
+	handler func(pubsub.ActionStatus)
+}
+
+// HandleStatus is a Capnp Server method that invokes the client provided callback
+// This unmarshals the ActionStatus and passes it to the callback
+func (capsrv *ActionStatusHandlerCapnpServer) HandleStatus(
+	_ context.Context, call hubapi.CapActionStatusHandler_handleStatus) error {
+	args := call.Args()
+	statusCapnp, _ := args.Status()
+	status := capserializer.UnmarshalActionStatus(statusCapnp)
+	capsrv.handler(status)
+	return nil
+}
+
+func NewActionStatusHandlerCapnpServer(handler func(pubsub.ActionStatus)) hubapi.CapActionStatusHandler {
+	capsrv := &ActionStatusHandlerCapnpServer{handler: handler}
+	capability := hubapi.CapActionStatusHandler_ServerToClient(capsrv)
+	return capability
+}
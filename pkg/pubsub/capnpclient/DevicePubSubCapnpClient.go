@@ -28,6 +28,22 @@ func (cl *DevicePubSubCapnpClient) PubEvent(
 	return err
 }
 
+// PubActionStatus publishes the status of an action request back to the user that made the request.
+func (cl *DevicePubSubCapnpClient) PubActionStatus(
+	ctx context.Context, requestID string, status string, errMsg string) (err error) {
+
+	method, release := cl.capability.PubActionStatus(ctx,
+		func(params hubapi.CapDevicePubSub_pubActionStatus_Params) error {
+			_ = params.SetRequestID(requestID)
+			_ = params.SetStatus(status)
+			err = params.SetError(errMsg)
+			return err
+		})
+	defer release()
+	_, err = method.Struct()
+	return err
+}
+
 // Release the capability and end subscriptions
 func (cl *DevicePubSubCapnpClient) Release() {
 	cl.capability.Release()
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hiveot/hub/api/go/hubapi"
 	"github.com/hiveot/hub/pkg/pubsub"
+	"github.com/hiveot/hub/pkg/pubsub/capserializer"
 )
 
 // PubSubCapnpClient is the capnp client for the pubsub service
@@ -59,6 +60,21 @@ func (cl *PubSubCapnpClient) CapUserPubSub(
 	return userCl, err
 }
 
+// GetSubscriptionStats returns the delivery counters of all subscriptions
+func (cl *PubSubCapnpClient) GetSubscriptionStats(
+	ctx context.Context) (stats []pubsub.SubscriptionStats, err error) {
+
+	method, release := cl.capability.GetSubscriptionStats(ctx, nil)
+	defer release()
+	resp, err := method.Struct()
+	if err == nil {
+		statsListCapnp, err2 := resp.Stats()
+		err = err2
+		stats = capserializer.UnmarshalSubscriptionStatsList(statsListCapnp)
+	}
+	return stats, err
+}
+
 // Release stops the client and frees its resources
 // If the rpc connection was made on instantiation, it will be closed.
 func (cl *PubSubCapnpClient) Release() {
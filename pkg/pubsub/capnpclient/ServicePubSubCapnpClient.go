@@ -15,6 +15,21 @@ type ServicePubSubCapnpClient struct {
 	capability hubapi.CapServicePubSub
 }
 
+func (cl *ServicePubSubCapnpClient) ClearRetained(
+	ctx context.Context, publisherID, thingID, eventID string) (err error) {
+
+	method, release := cl.capability.ClearRetained(ctx,
+		func(params hubapi.CapServicePubSub_clearRetained_Params) error {
+			_ = params.SetPublisherID(publisherID)
+			_ = params.SetThingID(thingID)
+			err = params.SetEventID(eventID)
+			return err
+		})
+	defer release()
+	_, err = method.Struct()
+	return err
+}
+
 func (cl *ServicePubSubCapnpClient) SubActions(
 	ctx context.Context, publisherID, thingID, actionID string,
 	handler func(thing.ThingValue)) (err error) {
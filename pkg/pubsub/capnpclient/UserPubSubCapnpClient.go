@@ -5,6 +5,8 @@ import (
 
 	"github.com/hiveot/hub/api/go/hubapi"
 	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/pubsub"
+	"github.com/hiveot/hub/pkg/pubsub/capserializer"
 )
 
 // UserPubSubCapnpClient is the capnp RPC client for user pubsub capabilities
@@ -29,6 +31,35 @@ func (cl *UserPubSubCapnpClient) PubAction(
 	return err
 }
 
+// PubActionRequest publishes an action request that is queued when the device is offline.
+// Status updates of the request are passed to the statusHandler.
+func (cl *UserPubSubCapnpClient) PubActionRequest(
+	ctx context.Context, publisherID, thingID, actionID string, value []byte,
+	expiry int64, statusHandler func(pubsub.ActionStatus)) (status pubsub.ActionStatus, err error) {
+
+	method, release := cl.capability.PubActionRequest(ctx,
+		func(params hubapi.CapUserPubSub_pubActionRequest_Params) error {
+			_ = params.SetPublisherID(publisherID)
+			_ = params.SetThingID(thingID)
+			_ = params.SetActionID(actionID)
+			params.SetExpiry(expiry)
+			if statusHandler != nil {
+				handlerCapnp := NewActionStatusHandlerCapnpServer(statusHandler)
+				_ = params.SetHandler(handlerCapnp)
+			}
+			err = params.SetValue(value)
+			return err
+		})
+	defer release()
+	resp, err := method.Struct()
+	if err == nil {
+		statusCapnp, err2 := resp.Status()
+		err = err2
+		status = capserializer.UnmarshalActionStatus(statusCapnp)
+	}
+	return status, err
+}
+
 // Release the capability and end subscriptions
 func (cl *UserPubSubCapnpClient) Release() {
 	cl.capability.Release()
@@ -0,0 +1,47 @@
+package capnpserver
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/pkg/pubsub"
+	"github.com/hiveot/hub/pkg/pubsub/capserializer"
+)
+
+// ActionStatusHandlerCapnpClient provides the client side of the action status callback.
+// This is called by the server to pass status updates of an action request to the requester.
+type ActionStatusHandlerCapnpClient struct {
+	// the capnp generated handler of the action status callback api
+	handlerCapnp hubapi.CapActionStatusHandler
+}
+
+// HandleStatus invokes the remote callback handler with the given status
+func (cl *ActionStatusHandlerCapnpClient) HandleStatus(status pubsub.ActionStatus) {
+	ctx := context.Background()
+	method, release := cl.handlerCapnp.HandleStatus(ctx,
+		func(params hubapi.CapActionStatusHandler_handleStatus_Params) error {
+			statusCapnp := capserializer.MarshalActionStatus(status)
+			err := params.SetStatus(statusCapnp)
+			return err
+		})
+	_, err := method.Struct()
+	if err != nil {
+		logrus.Errorf("failed invoking action status callback: %s", err)
+	}
+	release()
+}
+
+func (cl *ActionStatusHandlerCapnpClient) Release() {
+	cl.handlerCapnp.Release()
+}
+
+// NewActionStatusHandlerCapnpClient returns a POGS client instance of the capnp action status handler
+// that invokes the remote handler over capnp RPC.
+func NewActionStatusHandlerCapnpClient(handlerCapnp hubapi.CapActionStatusHandler) *ActionStatusHandlerCapnpClient {
+	cl := &ActionStatusHandlerCapnpClient{
+		handlerCapnp: handlerCapnp,
+	}
+	return cl
+}
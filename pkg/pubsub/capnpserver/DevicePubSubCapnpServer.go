@@ -24,6 +24,17 @@ func (capsrv *DevicePubSubCapnpServer) PubEvent(
 	return err
 }
 
+func (capsrv *DevicePubSubCapnpServer) PubActionStatus(
+	ctx context.Context, call hubapi.CapDevicePubSub_pubActionStatus) error {
+
+	args := call.Args()
+	requestID, _ := args.RequestID()
+	status, _ := args.Status()
+	errMsg, _ := args.Error()
+	err := capsrv.svc.PubActionStatus(ctx, requestID, status, errMsg)
+	return err
+}
+
 func (capsrv *DevicePubSubCapnpServer) SubAction(
 	ctx context.Context, call hubapi.CapDevicePubSub_subAction) error {
 	args := call.Args()
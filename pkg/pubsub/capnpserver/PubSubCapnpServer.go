@@ -6,6 +6,7 @@ import (
 
 	"github.com/hiveot/hub/api/go/hubapi"
 	"github.com/hiveot/hub/pkg/pubsub"
+	"github.com/hiveot/hub/pkg/pubsub/capserializer"
 	"github.com/hiveot/hub/pkg/resolver/capprovider"
 )
 
@@ -64,6 +65,19 @@ func (capsrv *PubSubCapnpServer) CapUserPubSub(
 	return err
 }
 
+// GetSubscriptionStats returns the delivery counters of all subscriptions
+func (capsrv *PubSubCapnpServer) GetSubscriptionStats(
+	ctx context.Context, call hubapi.CapPubSubService_getSubscriptionStats) error {
+
+	stats, err := capsrv.svc.GetSubscriptionStats(ctx)
+	if err == nil {
+		resp, _ := call.AllocResults()
+		statsCapnp := capserializer.MarshalSubscriptionStatsList(stats)
+		err = resp.SetStats(statsCapnp)
+	}
+	return err
+}
+
 //
 //// Release the service and free its resources
 //func (srv *PubSubCapnpServer) Release() error {
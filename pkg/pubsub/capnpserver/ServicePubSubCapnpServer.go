@@ -15,6 +15,16 @@ type ServicePubSubCapnpServer struct {
 	svc pubsub.IServicePubSub
 }
 
+func (capsrv *ServicePubSubCapnpServer) ClearRetained(
+	ctx context.Context, call hubapi.CapServicePubSub_clearRetained) error {
+	args := call.Args()
+	publisherID, _ := args.PublisherID()
+	thingID, _ := args.ThingID()
+	eventID, _ := args.EventID()
+	err := capsrv.svc.ClearRetained(ctx, publisherID, thingID, eventID)
+	return err
+}
+
 func (capsrv *ServicePubSubCapnpServer) SubActions(
 	ctx context.Context, call hubapi.CapServicePubSub_subActions) error {
 	args := call.Args()
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hiveot/hub/api/go/hubapi"
 	"github.com/hiveot/hub/pkg/pubsub"
+	"github.com/hiveot/hub/pkg/pubsub/capserializer"
 )
 
 // UserPubSubCapnpServer provides the capnp RPC server for user pubsub services.
@@ -25,6 +26,32 @@ func (capsrv *UserPubSubCapnpServer) PubAction(
 	return err
 }
 
+func (capsrv *UserPubSubCapnpServer) PubActionRequest(
+	ctx context.Context, call hubapi.CapUserPubSub_pubActionRequest) error {
+
+	args := call.Args()
+	thingID, _ := args.ThingID()
+	publisherID, _ := args.PublisherID()
+	actionID, _ := args.ActionID()
+	value, _ := args.Value()
+	expiry := args.Expiry()
+	var statusHandler func(pubsub.ActionStatus)
+	if args.HasHandler() {
+		handlerClient := NewActionStatusHandlerCapnpClient(args.Handler().AddRef())
+		statusHandler = handlerClient.HandleStatus
+	}
+	status, err := capsrv.svc.PubActionRequest(
+		ctx, publisherID, thingID, actionID, value, expiry, statusHandler)
+	if err == nil {
+		res, err2 := call.AllocResults()
+		err = err2
+		if err == nil {
+			err = res.SetStatus(capserializer.MarshalActionStatus(status))
+		}
+	}
+	return err
+}
+
 func (capsrv *UserPubSubCapnpServer) SubEvent(
 	ctx context.Context, call hubapi.CapUserPubSub_subEvent) error {
 	args := call.Args()
@@ -0,0 +1,36 @@
+package capserializer
+
+import (
+	"capnproto.org/go/capnp/v3"
+
+	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/pkg/pubsub"
+)
+
+// UnmarshalActionStatus deserializes an ActionStatus object from a capnp message
+func UnmarshalActionStatus(statusCapnp hubapi.ActionStatus) pubsub.ActionStatus {
+	// errors are ignored. If these fails then there are bigger problems
+	statusPOGS := pubsub.ActionStatus{}
+	statusPOGS.RequestID, _ = statusCapnp.RequestID()
+	statusPOGS.PublisherID, _ = statusCapnp.PublisherID()
+	statusPOGS.ThingID, _ = statusCapnp.ThingID()
+	statusPOGS.ActionID, _ = statusCapnp.ActionID()
+	statusPOGS.Status, _ = statusCapnp.Status()
+	statusPOGS.Error, _ = statusCapnp.Error()
+	return statusPOGS
+}
+
+// MarshalActionStatus serializes an ActionStatus object to a capnp message
+func MarshalActionStatus(statusPOGS pubsub.ActionStatus) hubapi.ActionStatus {
+	// errors are ignored. If these fail then there are bigger problems
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	statusCapnp, _ := hubapi.NewActionStatus(seg)
+
+	_ = statusCapnp.SetRequestID(statusPOGS.RequestID)
+	_ = statusCapnp.SetPublisherID(statusPOGS.PublisherID)
+	_ = statusCapnp.SetThingID(statusPOGS.ThingID)
+	_ = statusCapnp.SetActionID(statusPOGS.ActionID)
+	_ = statusCapnp.SetStatus(statusPOGS.Status)
+	_ = statusCapnp.SetError(statusPOGS.Error)
+	return statusCapnp
+}
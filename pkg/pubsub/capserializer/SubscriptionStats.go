@@ -0,0 +1,44 @@
+package capserializer
+
+import (
+	"capnproto.org/go/capnp/v3"
+
+	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/pkg/pubsub"
+)
+
+// MarshalSubscriptionStatsList serializes a list of SubscriptionStats objects to a capnp message
+func MarshalSubscriptionStatsList(statsList []pubsub.SubscriptionStats) (
+	statsListCapnp hubapi.SubscriptionStats_List) {
+
+	_, seg, _ := capnp.NewMessage(capnp.SingleSegment(nil))
+	statsListCapnp, _ = hubapi.NewSubscriptionStats_List(seg, int32(len(statsList)))
+	for i, stats := range statsList {
+		statsCapnp := statsListCapnp.At(i)
+		_ = statsCapnp.SetSubscriptionID(stats.SubscriptionID)
+		_ = statsCapnp.SetSubscriberID(stats.SubscriberID)
+		_ = statsCapnp.SetTopic(stats.Topic)
+		statsCapnp.SetPending(int64(stats.Pending))
+		statsCapnp.SetDelivered(stats.Delivered)
+		statsCapnp.SetDropped(stats.Dropped)
+	}
+	return statsListCapnp
+}
+
+// UnmarshalSubscriptionStatsList deserializes a list of SubscriptionStats objects from a capnp message
+func UnmarshalSubscriptionStatsList(statsListCapnp hubapi.SubscriptionStats_List) []pubsub.SubscriptionStats {
+	statsList := make([]pubsub.SubscriptionStats, 0, statsListCapnp.Len())
+	for i := 0; i < statsListCapnp.Len(); i++ {
+		statsCapnp := statsListCapnp.At(i)
+		// errors are ignored. If these fail then there are bigger problems
+		stats := pubsub.SubscriptionStats{}
+		stats.SubscriptionID, _ = statsCapnp.SubscriptionID()
+		stats.SubscriberID, _ = statsCapnp.SubscriberID()
+		stats.Topic, _ = statsCapnp.Topic()
+		stats.Pending = int(statsCapnp.Pending())
+		stats.Delivered = statsCapnp.Delivered()
+		stats.Dropped = statsCapnp.Dropped()
+		statsList = append(statsList, stats)
+	}
+	return statsList
+}
@@ -4,18 +4,36 @@ import (
 	"context"
 	"net"
 
+	"github.com/hiveot/hub/lib/hubclient"
 	"github.com/hiveot/hub/lib/listener"
 	"github.com/hiveot/hub/lib/svcconfig"
+	"github.com/hiveot/hub/pkg/authz/capnpclient"
 	"github.com/hiveot/hub/pkg/pubsub"
 	"github.com/hiveot/hub/pkg/pubsub/capnpserver"
+	"github.com/hiveot/hub/pkg/pubsub/config"
 	"github.com/hiveot/hub/pkg/pubsub/service"
 )
 
 // Connect the history store service
 func main() {
-	f, _, _ := svcconfig.SetupFolderConfig(pubsub.ServiceName)
+	ctx := context.Background()
+	f, clientCert, caCert := svcconfig.SetupFolderConfig(pubsub.ServiceName)
+	cfg := config.NewPubSubConfig()
+	_ = f.LoadConfig(&cfg)
 
-	svc := service.NewPubSubService()
+	// the authz service verifies the permissions of devices and users
+	fullUrl := hubclient.LocateHub("", 0)
+	capClient, err := hubclient.ConnectWithCapnpTCP(fullUrl, clientCert, caCert)
+	if err != nil {
+		panic("can't connect to the hub: " + err.Error())
+	}
+	authzClient := capnpclient.NewAuthzCapnpClient(capClient)
+	verifyAuthz, err := authzClient.CapVerifyAuthz(ctx, pubsub.ServiceName)
+	if err != nil {
+		panic("can't obtain the authz verify capability: " + err.Error())
+	}
+
+	svc := service.NewPubSubService(cfg, verifyAuthz)
 
 	listener.RunService(pubsub.ServiceName, f.SocketPath,
 		func(ctx context.Context, lis net.Listener) error {
@@ -26,6 +44,8 @@ func main() {
 		}, func() error {
 			// shutdown
 			err := svc.Stop()
+			verifyAuthz.Release()
+			authzClient.Release()
 			return err
 		})
 
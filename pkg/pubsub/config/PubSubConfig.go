@@ -0,0 +1,48 @@
+package config
+
+import (
+	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/pkg/pubsub/core"
+)
+
+// DefaultMaxQueuedActions is the default max nr of queued actions for each device
+const DefaultMaxQueuedActions = 100
+
+// DefaultActionStatusTimeoutSec is the default time a delivered action request waits for a status reply
+const DefaultActionStatusTimeoutSec = 300
+
+// PubSubConfig with the pubsub service configuration
+type PubSubConfig struct {
+	// Max nr of messages queued for delivery to each subscriber.
+	// Default is core.DefaultQueueSize.
+	QueueSize int `yaml:"queueSize"`
+
+	// Policy when the queue of a subscriber is full: dropOldest (default), dropNewest or disconnect.
+	// See core.OverflowXyz for details.
+	OverflowPolicy string `yaml:"overflowPolicy"`
+
+	// Names of events whose last value is retained and passed to new subscribers.
+	// Default is the TD and properties events. Use an empty list to disable.
+	RetainedEvents []string `yaml:"retainedEvents"`
+
+	// Max nr of action requests queued for each offline device.
+	// Default is DefaultMaxQueuedActions.
+	MaxQueuedActions int `yaml:"maxQueuedActions"`
+
+	// Time in seconds a delivered action request waits for a status reply from the device.
+	// Default is DefaultActionStatusTimeoutSec.
+	ActionStatusTimeoutSec int `yaml:"actionStatusTimeout"`
+}
+
+// NewPubSubConfig creates a new config with default values
+func NewPubSubConfig() PubSubConfig {
+	cfg := PubSubConfig{
+		QueueSize:      core.DefaultQueueSize,
+		OverflowPolicy: core.OverflowDropOldest,
+		RetainedEvents: []string{hubapi.EventNameTD, hubapi.EventNameProperties},
+
+		MaxQueuedActions:       DefaultMaxQueuedActions,
+		ActionStatusTimeoutSec: DefaultActionStatusTimeoutSec,
+	}
+	return cfg
+}
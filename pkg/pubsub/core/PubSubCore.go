@@ -4,66 +4,375 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/pkg/pubsub"
 )
 
+// WildcardSingle is the topic wildcard that matches a single topic level
+const WildcardSingle = "+"
+
+// WildcardMulti is the topic wildcard that matches all remaining topic levels.
+// It must be the last level of a subscription topic.
+const WildcardMulti = "#"
+
+// DefaultQueueSize is the default maximum number of messages queued for delivery to a subscriber
+const DefaultQueueSize = 100
+
+// Overflow policies for messages published to a subscriber whose queue is full
+const (
+	// OverflowDropOldest removes the oldest queued message to make room for the new message
+	OverflowDropOldest = "dropOldest"
+	// OverflowDropNewest drops the new message
+	OverflowDropNewest = "dropNewest"
+	// OverflowDisconnect drops the new message and closes the subscription.
+	// The subscriber is notified through the onClosed handler of the subscription.
+	OverflowDisconnect = "disconnect"
+)
+
+// queued message
+type message struct {
+	topic   string
+	payload []byte
+}
+
 type Subscription struct {
 	topic   string
 	parts   []string
 	handler func(topic string, message []byte)
 	id      string
+	// ID of the client that subscribed
+	subscriberID string
+	// handler invoked when the subscription is closed by the core, or nil to ignore
+	onClosed func(subscriptionID string, reason error)
+	// reason the core closed the subscription. Set before stopChan is closed.
+	closeReason error
+
+	// retained messages to deliver before the queued messages. Only used by the delivery goroutine.
+	retained []message
+	// messages waiting to be delivered by the delivery goroutine
+	queue chan message
+	// stop the delivery goroutine
+	stopChan chan bool
+	// delivery counters
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
 }
 
-// return true if the given topic parts match the subscription
-func (sub *Subscription) match(parts []string) bool {
-	if len(sub.parts) != len(parts) {
-		return false
+// deliver passes the retained messages and then the queued messages to the handler until
+// the subscription is stopped.
+// If the core closed the subscription then the onClosed handler is invoked last, so it is
+// never called concurrently with the message handler.
+func (sub *Subscription) deliver() {
+	for _, msg := range sub.retained {
+		select {
+		case <-sub.stopChan:
+			sub.stopped()
+			return
+		default:
+		}
+		sub.handler(msg.topic, msg.payload)
+		sub.delivered.Add(1)
 	}
-	for i, subPart := range sub.parts {
-		if subPart != "+" {
-			if subPart != parts[i] {
-				return false
+	sub.retained = nil
+	for {
+		select {
+		case <-sub.stopChan:
+			sub.stopped()
+			return
+		case msg := <-sub.queue:
+			// messages that are still queued after a stop are discarded
+			select {
+			case <-sub.stopChan:
+				sub.stopped()
+				return
+			default:
 			}
+			sub.handler(msg.topic, msg.payload)
+			sub.delivered.Add(1)
+		}
+	}
+}
+
+// stopped invokes the onClosed handler if the core closed the subscription
+func (sub *Subscription) stopped() {
+	if sub.closeReason != nil && sub.onClosed != nil {
+		sub.onClosed(sub.id, sub.closeReason)
+	}
+}
+
+// enqueue a message for delivery without blocking.
+// If the queue is full the message is handled according to the overflow policy.
+// This returns false if the message was dropped.
+func (sub *Subscription) enqueue(msg message, overflowPolicy string) bool {
+	for {
+		select {
+		case sub.queue <- msg:
+			return true
+		default:
+		}
+		if overflowPolicy != OverflowDropOldest {
+			sub.dropped.Add(1)
+			return false
+		}
+		// make room by removing the oldest message
+		select {
+		case <-sub.queue:
+			sub.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+// stats returns the delivery counters of the subscription
+func (sub *Subscription) stats() pubsub.SubscriptionStats {
+	return pubsub.SubscriptionStats{
+		SubscriptionID: sub.id,
+		SubscriberID:   sub.subscriberID,
+		Topic:          sub.topic,
+		Pending:        len(sub.queue),
+		Delivered:      sub.delivered.Load(),
+		Dropped:        sub.dropped.Load(),
+	}
+}
+
+// topicNode is a node in the subscription trie with a level of the subscription topics
+type topicNode struct {
+	// child nodes by the name of the next topic level, including wildcards
+	children map[string]*topicNode
+	// subscriptions whose topic ends at this node
+	subs []*Subscription
+}
+
+// add a subscription to the trie starting at this node
+func (node *topicNode) add(sub *Subscription) {
+	for _, part := range sub.parts {
+		child, found := node.children[part]
+		if !found {
+			child = newTopicNode()
+			node.children[part] = child
+		}
+		node = child
+	}
+	node.subs = append(node.subs, sub)
+}
+
+// collect the subscriptions that match the topic parts, starting at this node
+func (node *topicNode) match(parts []string, subs []*Subscription) []*Subscription {
+	// a multi-level wildcard also matches the parent level, eg 'things/#' matches 'things'
+	if multi, found := node.children[WildcardMulti]; found {
+		subs = append(subs, multi.subs...)
+	}
+	if len(parts) == 0 {
+		return append(subs, node.subs...)
+	}
+	if child, found := node.children[parts[0]]; found {
+		subs = child.match(parts[1:], subs)
+	}
+	if single, found := node.children[WildcardSingle]; found {
+		subs = single.match(parts[1:], subs)
+	}
+	return subs
+}
+
+// remove a subscription from the trie starting at this node and prune empty nodes.
+// parts are the remaining topic parts of the subscription.
+// This returns true if the node no longer has subscriptions or children.
+func (node *topicNode) remove(sub *Subscription, parts []string) (isEmpty bool) {
+	if len(parts) == 0 {
+		for i, s := range node.subs {
+			if s == sub {
+				node.subs = append(node.subs[:i], node.subs[i+1:]...)
+				break
+			}
+		}
+	} else if child, found := node.children[parts[0]]; found {
+		if child.remove(sub, parts[1:]) {
+			delete(node.children, parts[0])
+		}
+	}
+	return len(node.subs) == 0 && len(node.children) == 0
+}
+
+func newTopicNode() *topicNode {
+	return &topicNode{children: make(map[string]*topicNode)}
+}
+
+// retainedNode is a node in the trie of retained messages with a level of their topics
+type retainedNode struct {
+	// child nodes by the name of the next topic level
+	children map[string]*retainedNode
+	// retained message whose topic ends at this node, nil if none
+	payload []byte
+}
+
+// set the retained message of the topic parts, starting at this node
+func (node *retainedNode) set(parts []string, payload []byte) {
+	for _, part := range parts {
+		child, found := node.children[part]
+		if !found {
+			child = newRetainedNode()
+			node.children[part] = child
 		}
+		node = child
 	}
-	return true
+	node.payload = payload
+}
+
+// collect the retained messages that match the subscription topic parts, starting at this node
+//
+//	topic of this node, "" for the root
+//	parts are the remaining subscription topic parts, which can contain wildcards
+func (node *retainedNode) match(topic string, parts []string, msgs []message) []message {
+	if len(parts) == 0 {
+		if node.payload != nil {
+			msgs = append(msgs, message{topic: topic, payload: node.payload})
+		}
+		return msgs
+	}
+	switch parts[0] {
+	case WildcardMulti:
+		// a multi-level wildcard also matches the parent level, eg 'things/#' matches 'things'
+		msgs = node.match(topic, nil, msgs)
+		for name, child := range node.children {
+			msgs = child.match(childTopic(topic, name), parts, msgs)
+		}
+	case WildcardSingle:
+		for name, child := range node.children {
+			msgs = child.match(childTopic(topic, name), parts[1:], msgs)
+		}
+	default:
+		if child, found := node.children[parts[0]]; found {
+			msgs = child.match(childTopic(topic, parts[0]), parts[1:], msgs)
+		}
+	}
+	return msgs
+}
+
+// remove the retained message of the topic parts, starting at this node, and prune empty nodes.
+// This returns true if the node no longer has a message or children.
+func (node *retainedNode) remove(parts []string) (isEmpty bool) {
+	if len(parts) == 0 {
+		node.payload = nil
+	} else if child, found := node.children[parts[0]]; found {
+		if child.remove(parts[1:]) {
+			delete(node.children, parts[0])
+		}
+	}
+	return node.payload == nil && len(node.children) == 0
+}
+
+// childTopic returns the topic of a child node
+func childTopic(topic string, name string) string {
+	if topic == "" {
+		return name
+	}
+	return topic + "/" + name
+}
+
+func newRetainedNode() *retainedNode {
+	return &retainedNode{children: make(map[string]*retainedNode)}
 }
 
 // PubSubCore performs the actual publishing and subscription management
+// Each subscription has its own bounded message queue and delivery goroutine, so a slow
+// subscriber does not block publishers or other subscribers.
 type PubSubCore struct {
-	// list of subscribers
-	subscribers []*Subscription
-	submux      sync.RWMutex
+	// trie of subscriptions by topic level
+	root *topicNode
+	// subscriptions by subscription ID
+	subscribers map[string]*Subscription
+	// trie of the last retained message by topic level
+	retained *retainedNode
+	submux   sync.RWMutex
+
+	// max nr of messages queued for each subscription
+	queueSize int
+	// policy when a subscription queue is full. See OverflowXyz
+	overflowPolicy string
 }
 
 // find the subscribers to a topic
 // topic must be a full topic without wildcards
 func (psc *PubSubCore) findSubscribers(topic string) (subs []*Subscription) {
 	subs = make([]*Subscription, 0)
-	// how many subscribers are expected? A few dozen, hundreds?
-	// Right now a simple iteration is good enough.
 	parts := strings.Split(topic, "/")
+	subs = psc.root.match(parts, subs)
+	return subs
+}
+
+// GetStats returns the delivery counters of all subscriptions
+func (psc *PubSubCore) GetStats() []pubsub.SubscriptionStats {
+	psc.submux.RLock()
+	defer psc.submux.RUnlock()
+	stats := make([]pubsub.SubscriptionStats, 0, len(psc.subscribers))
 	for _, sub := range psc.subscribers {
-		if sub.match(parts) {
-			subs = append(subs, sub)
-		}
+		stats = append(stats, sub.stats())
 	}
-	return subs
+	return stats
 }
 
 // Publish the topic to subscribers
-func (psc *PubSubCore) Publish(topic string, message []byte) {
+// The message is queued for delivery to each subscriber and this returns immediately.
+// Messages to a subscriber whose queue is full are handled according to the overflow policy.
+func (psc *PubSubCore) Publish(topic string, payload []byte) {
 	psc.submux.RLock()
 	subs := psc.findSubscribers(topic)
 	psc.submux.RUnlock()
+	psc.deliver(subs, message{topic: topic, payload: payload})
+}
+
+// PublishRetained publishes the topic to subscribers and retains the message for new subscribers.
+// Subscribers that subscribe to a matching topic afterward receive the retained message on subscribe.
+// Publishing an empty payload removes the retained message without publishing it.
+func (psc *PubSubCore) PublishRetained(topic string, payload []byte) {
+	psc.submux.Lock()
+	if len(payload) == 0 {
+		psc.retained.remove(strings.Split(topic, "/"))
+		psc.submux.Unlock()
+		return
+	}
+	psc.retained.set(strings.Split(topic, "/"), payload)
+	subs := psc.findSubscribers(topic)
+	psc.submux.Unlock()
+	psc.deliver(subs, message{topic: topic, payload: payload})
+}
+
+// deliver queues the message for delivery to the subscribers
+func (psc *PubSubCore) deliver(subs []*Subscription, msg message) {
 	//logrus.Infof("publisherID='%s'; topic=%v; %d subscribers", publisherID, topic, len(subs))
 	for _, sub := range subs {
-		sub.handler(topic, message)
+		if !sub.enqueue(msg, psc.overflowPolicy) {
+			dropped := sub.dropped.Load()
+			if psc.overflowPolicy == OverflowDisconnect {
+				logrus.Warningf("subscription '%s' of subscriber '%s' to '%s' is too slow. Disconnecting it.",
+					sub.id, sub.subscriberID, sub.topic)
+				psc.close(sub, fmt.Errorf("subscription to '%s' is closed as the subscriber is too slow", sub.topic))
+			} else if dropped == 1 || dropped%1000 == 0 {
+				logrus.Warningf("subscription '%s' of subscriber '%s' to '%s' is too slow. %d messages dropped.",
+					sub.id, sub.subscriberID, sub.topic, dropped)
+			}
+		}
 	}
 }
 
+// close removes a subscription and passes the reason to its onClosed handler.
+// This does nothing if the subscription was already removed.
+func (psc *PubSubCore) close(sub *Subscription, reason error) {
+	psc.submux.Lock()
+	defer psc.submux.Unlock()
+	if _, found := psc.subscribers[sub.id]; !found {
+		return
+	}
+	delete(psc.subscribers, sub.id)
+	psc.root.remove(sub, sub.parts)
+	sub.closeReason = reason
+	close(sub.stopChan)
+}
+
 // Start a new core
 func (psc *PubSubCore) Start() (err error) {
 	return nil
@@ -75,55 +384,78 @@ func (psc *PubSubCore) Stop() (err error) {
 	if len(psc.subscribers) > 0 {
 		err = fmt.Errorf("%d subscriptions are not released. Releasing them now", len(psc.subscribers))
 		logrus.Error(err)
-		psc.subscribers = make([]*Subscription, 0)
+		for _, sub := range psc.subscribers {
+			close(sub.stopChan)
+		}
+		psc.subscribers = make(map[string]*Subscription)
+		psc.root = newTopicNode()
 	}
+	psc.retained = newRetainedNode()
 	psc.submux.Unlock()
 	return err
 }
 
 // Subscribe to a topic
 //
-//	topic is the topic to subscribe to. The use of '+' wildcard is supported
+//	subscriberID is the ID of the client that subscribes, used in stats and logging
+//	topic is the topic to subscribe to. The use of '+' wildcard is supported for a single level
+//	and a trailing '#' wildcard for all remaining levels.
 //	handler is the callback to invoke when a message is received
+//	onClosed is invoked after the core closed the subscription, for example due to the
+//	OverflowDisconnect policy, with the reason. It is not invoked on Unsubscribe. nil to ignore.
 //
+// Retained messages of matching topics are passed to the handler immediately, before newer
+// messages. They are not counted against the queue size.
 // This returns a subscription ID, used to unsubscribe
 func (psc *PubSubCore) Subscribe(
-	topic string,
-	handler func(topic string, message []byte)) (subscriptionID string, err error) {
+	subscriberID string, topic string,
+	handler func(topic string, message []byte),
+	onClosed func(subscriptionID string, reason error)) (subscriptionID string, err error) {
 
+	parts := strings.Split(topic, "/")
+	for i, part := range parts {
+		if part == WildcardMulti && i != len(parts)-1 {
+			err = fmt.Errorf("wildcard '%s' must be at the end of topic '%s'", WildcardMulti, topic)
+			logrus.Error(err)
+			return "", err
+		}
+	}
 	sub := &Subscription{
-		topic:   topic,
-		parts:   strings.Split(topic, "/"),
-		handler: handler,
-		id:      uuid.NewString(),
+		topic:    topic,
+		parts:    parts,
+		handler:  handler,
+		id:       uuid.NewString(),
+		stopChan: make(chan bool),
+
+		subscriberID: subscriberID,
+		onClosed:     onClosed,
+		queue:        make(chan message, psc.queueSize),
 	}
 	psc.submux.Lock()
-	psc.subscribers = append(psc.subscribers, sub)
+	// retained messages are collected before newer messages can be published to the subscription
+	sub.retained = psc.retained.match("", parts, nil)
+	psc.subscribers[sub.id] = sub
+	psc.root.add(sub)
 	psc.submux.Unlock()
+	go sub.deliver()
 	//logrus.Infof("topic=%v. => subscriptionID=%s", topic, sub.id)
 
 	return sub.id, nil
 }
 
 // Unsubscribe from one or more topics
+// Messages that are still queued for the subscriptions are discarded.
 //
 //	subscriptionIDs as provided during subscribe
 func (psc *PubSubCore) Unsubscribe(subscriptionIDs []string) error {
 	psc.submux.Lock()
 	//logrus.Infof("ids=%v", subscriptionIDs)
 	for _, subscriptionID := range subscriptionIDs {
-
-		for i, sub := range psc.subscribers {
-			if sub.id == subscriptionID {
-				if len(psc.subscribers) > i+1 {
-					// slow but keeps subscriptions in order
-					psc.subscribers = append(psc.subscribers[:i], psc.subscribers[i+1:]...)
-				} else {
-					// remove the last one
-					psc.subscribers = psc.subscribers[:len(psc.subscribers)-1]
-				}
-				break
-			}
+		sub, found := psc.subscribers[subscriptionID]
+		if found {
+			delete(psc.subscribers, subscriptionID)
+			psc.root.remove(sub, sub.parts)
+			close(sub.stopChan)
 		}
 	}
 	psc.submux.Unlock()
@@ -131,9 +463,28 @@ func (psc *PubSubCore) Unsubscribe(subscriptionIDs []string) error {
 }
 
 // NewPubSubCore creates a new instance of the pubsub core
-func NewPubSubCore() *PubSubCore {
+//
+//	queueSize is the max nr of messages queued for each subscription. 0 for DefaultQueueSize
+//	overflowPolicy when a queue is full, one of OverflowDropOldest, OverflowDropNewest or
+//	OverflowDisconnect. "" for OverflowDropOldest.
+func NewPubSubCore(queueSize int, overflowPolicy string) *PubSubCore {
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	if overflowPolicy == "" {
+		overflowPolicy = OverflowDropOldest
+	} else if overflowPolicy != OverflowDropOldest &&
+		overflowPolicy != OverflowDropNewest &&
+		overflowPolicy != OverflowDisconnect {
+		logrus.Errorf("unknown overflow policy '%s'. Using '%s'", overflowPolicy, OverflowDropOldest)
+		overflowPolicy = OverflowDropOldest
+	}
 	psc := PubSubCore{
-		subscribers: make([]*Subscription, 0),
+		root:           newTopicNode(),
+		subscribers:    make(map[string]*Subscription),
+		retained:       newRetainedNode(),
+		queueSize:      queueSize,
+		overflowPolicy: overflowPolicy,
 	}
 	return &psc
 }
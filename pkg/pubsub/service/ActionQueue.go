@@ -0,0 +1,332 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/api/go/hubapi"
+	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/pubsub"
+	"github.com/hiveot/hub/pkg/pubsub/core"
+)
+
+// actionPurgeInterval is the interval between removing expired action requests
+const actionPurgeInterval = time.Second
+
+// actionRequest is an action request that is queued or waits for a status reply
+type actionRequest struct {
+	// the action value with its requestID and expiry
+	action thing.ThingValue
+	// the action has been passed to the device
+	delivered bool
+	// time after which the request is removed
+	deadline time.Time
+	// handler of status updates, or nil to ignore
+	statusHandler func(pubsub.ActionStatus)
+}
+
+// makeStatus returns the status of the request
+func (req *actionRequest) makeStatus(status string, errMsg string) pubsub.ActionStatus {
+	return pubsub.ActionStatus{
+		RequestID:   req.action.RequestID,
+		PublisherID: req.action.PublisherID,
+		ThingID:     req.action.ThingID,
+		ActionID:    req.action.ID,
+		Status:      status,
+		Error:       errMsg,
+	}
+}
+
+// actionFilter of an action subscription of a device
+type actionFilter struct {
+	publisherID string
+	thingID     string
+	actionID    string
+}
+
+// matches returns true if the action is accepted by the subscription
+func (f actionFilter) matches(action thing.ThingValue) bool {
+	return f.publisherID == action.PublisherID &&
+		(isWildcard(f.thingID) || f.thingID == action.ThingID) &&
+		(isWildcard(f.actionID) || f.actionID == action.ID)
+}
+
+// statusUpdate is a status change to pass to the handler of a request
+type statusUpdate struct {
+	handler func(pubsub.ActionStatus)
+	status  pubsub.ActionStatus
+}
+
+// ActionQueue holds action requests for devices that are offline and tracks the status of
+// requests until the device has replied.
+//
+// A device is online for an action when it has an action subscription that matches it.
+// Actions for offline devices are queued until the device subscribes or the action expires.
+// Status updates are passed to the handler of the request after the queue is unlocked, so
+// handlers can make calls to the pubsub service.
+type ActionQueue struct {
+	// core used to publish the actions
+	core *core.PubSubCore
+	// max nr of queued actions of each device
+	maxQueued int
+	// time a delivered request waits for a status reply
+	statusTimeout time.Duration
+
+	// action subscriptions of online devices by subscriptionID
+	subscribers map[string]actionFilter
+	// requests that are queued or wait for a status reply, by requestID
+	requests map[string]*actionRequest
+	// queued requests of each publisher in order of publication
+	queued map[string][]*actionRequest
+	mux    sync.Mutex
+
+	// stop the background job
+	stopChan chan bool
+	// wait for the background job to end
+	jobWG sync.WaitGroup
+}
+
+// isOnline returns true if a device has subscribed to the action
+func (aq *ActionQueue) isOnline(action thing.ThingValue) bool {
+	for _, filter := range aq.subscribers {
+		if filter.matches(action) {
+			return true
+		}
+	}
+	return false
+}
+
+// deliver publishes the action of the request to the subscribers and waits for a status reply.
+// This must be called while locked.
+func (aq *ActionQueue) deliver(req *actionRequest) {
+	topic := MakeThingTopic(req.action.PublisherID, req.action.ThingID, hubapi.MessageTypeAction, req.action.ID)
+	message, _ := json.Marshal(req.action)
+	aq.core.Publish(topic, message)
+	req.delivered = true
+	req.deadline = time.Now().Add(aq.statusTimeout)
+}
+
+// notify passes the status updates to the request handlers.
+// This must be called while not locked.
+func (aq *ActionQueue) notify(updates []statusUpdate) {
+	for _, update := range updates {
+		if update.handler != nil {
+			update.handler(update.status)
+		}
+	}
+}
+
+// AddSubscriber registers an action subscription of a device and delivers the queued actions
+// that match the subscription.
+//
+//	subscriptionID of the core subscription of the device
+//	publisherID of the device
+//	thingID of the subscription, or "" for all things of the device
+//	actionID of the subscription, or "" for all actions
+func (aq *ActionQueue) AddSubscriber(subscriptionID string, publisherID, thingID, actionID string) {
+	filter := actionFilter{publisherID: publisherID, thingID: thingID, actionID: actionID}
+	updates := make([]statusUpdate, 0)
+	nowSec := time.Now().Unix()
+
+	aq.mux.Lock()
+	aq.subscribers[subscriptionID] = filter
+	queue := aq.queued[publisherID]
+	remaining := make([]*actionRequest, 0, len(queue))
+	for _, req := range queue {
+		// expired requests are left for the purge
+		if filter.matches(req.action) && req.action.Expiry > nowSec {
+			aq.deliver(req)
+			updates = append(updates, statusUpdate{
+				handler: req.statusHandler,
+				status:  req.makeStatus(hubapi.ActionStatusDelivered, ""),
+			})
+		} else {
+			remaining = append(remaining, req)
+		}
+	}
+	if len(remaining) > 0 {
+		aq.queued[publisherID] = remaining
+	} else {
+		delete(aq.queued, publisherID)
+	}
+	aq.mux.Unlock()
+
+	if len(updates) > 0 {
+		logrus.Infof("delivered %d queued actions to '%s'", len(updates), publisherID)
+	}
+	aq.notify(updates)
+}
+
+// PubAction publishes the action to the device, or queues it when the device is offline and the
+// action has not yet expired. This assigns a new requestID to the action.
+//
+//	action to publish, with an optional expiry time until which it is queued
+//	statusHandler is invoked with status updates of the request, or nil to ignore
+//
+// This returns the status of the request, or an error if the queue of the device is full.
+func (aq *ActionQueue) PubAction(
+	action thing.ThingValue, statusHandler func(pubsub.ActionStatus)) (pubsub.ActionStatus, error) {
+
+	action.RequestID = uuid.NewString()
+	req := &actionRequest{action: action, statusHandler: statusHandler}
+
+	aq.mux.Lock()
+	defer aq.mux.Unlock()
+
+	if aq.isOnline(action) {
+		aq.deliver(req)
+		aq.requests[action.RequestID] = req
+		return req.makeStatus(hubapi.ActionStatusDelivered, ""), nil
+	}
+	if action.Expiry <= time.Now().Unix() {
+		return req.makeStatus(hubapi.ActionStatusFailed, "device is offline"), nil
+	}
+	queue := aq.queued[action.PublisherID]
+	if len(queue) >= aq.maxQueued {
+		err := fmt.Errorf("action queue of publisher '%s' is full", action.PublisherID)
+		logrus.Warning(err)
+		return req.makeStatus(hubapi.ActionStatusFailed, err.Error()), err
+	}
+	req.deadline = time.Unix(action.Expiry, 0)
+	aq.queued[action.PublisherID] = append(queue, req)
+	aq.requests[action.RequestID] = req
+	return req.makeStatus(hubapi.ActionStatusPending, ""), nil
+}
+
+// PurgeExpired removes the requests whose deadline has passed.
+// Queued requests that expire before they are delivered have failed. Delivered requests that
+// have not received a final status reply are removed without notification.
+//
+// This returns the number of removed requests.
+func (aq *ActionQueue) PurgeExpired(now time.Time) int {
+	updates := make([]statusUpdate, 0)
+	nrRemoved := 0
+
+	aq.mux.Lock()
+	for requestID, req := range aq.requests {
+		if now.Before(req.deadline) {
+			continue
+		}
+		delete(aq.requests, requestID)
+		nrRemoved++
+		if !req.delivered {
+			updates = append(updates, statusUpdate{
+				handler: req.statusHandler,
+				status:  req.makeStatus(hubapi.ActionStatusFailed, "action expired before it was delivered"),
+			})
+		}
+	}
+	// remove the expired requests from the queues
+	for publisherID, queue := range aq.queued {
+		remaining := make([]*actionRequest, 0, len(queue))
+		for _, req := range queue {
+			if _, found := aq.requests[req.action.RequestID]; found {
+				remaining = append(remaining, req)
+			}
+		}
+		if len(remaining) > 0 {
+			aq.queued[publisherID] = remaining
+		} else {
+			delete(aq.queued, publisherID)
+		}
+	}
+	aq.mux.Unlock()
+
+	aq.notify(updates)
+	return nrRemoved
+}
+
+// RemoveSubscribers removes the action subscriptions of a device.
+// Actions for the device are queued again when it has no more matching subscriptions.
+func (aq *ActionQueue) RemoveSubscribers(subscriptionIDs []string) {
+	aq.mux.Lock()
+	defer aq.mux.Unlock()
+	for _, subscriptionID := range subscriptionIDs {
+		delete(aq.subscribers, subscriptionID)
+	}
+}
+
+// SetStatus passes the status reply of the device to the handler of the request.
+// The request ends with the completed or failed status.
+//
+//	publisherID of the device replying. Must be the publisher the action was sent to.
+//	requestID of the action request
+//	status is one of the hubapi.ActionStatusXyz values
+//	errMsg with the reason the action failed
+func (aq *ActionQueue) SetStatus(publisherID, requestID string, status string, errMsg string) error {
+	switch status {
+	case hubapi.ActionStatusPending, hubapi.ActionStatusDelivered,
+		hubapi.ActionStatusCompleted, hubapi.ActionStatusFailed:
+	default:
+		return fmt.Errorf("invalid action status '%s'", status)
+	}
+	aq.mux.Lock()
+	req, found := aq.requests[requestID]
+	if !found {
+		aq.mux.Unlock()
+		return fmt.Errorf("unknown or expired action request '%s'", requestID)
+	} else if req.action.PublisherID != publisherID {
+		aq.mux.Unlock()
+		return fmt.Errorf("action request '%s' is not for publisher '%s'", requestID, publisherID)
+	}
+	if status == hubapi.ActionStatusCompleted || status == hubapi.ActionStatusFailed {
+		delete(aq.requests, requestID)
+	} else {
+		req.deadline = time.Now().Add(aq.statusTimeout)
+	}
+	update := statusUpdate{handler: req.statusHandler, status: req.makeStatus(status, errMsg)}
+	aq.mux.Unlock()
+
+	aq.notify([]statusUpdate{update})
+	return nil
+}
+
+// Start the background job that removes expired requests
+func (aq *ActionQueue) Start() {
+	aq.stopChan = make(chan bool)
+	aq.jobWG.Add(1)
+	go func() {
+		defer aq.jobWG.Done()
+		ticker := time.NewTicker(actionPurgeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-aq.stopChan:
+				return
+			case now := <-ticker.C:
+				_ = aq.PurgeExpired(now)
+			}
+		}
+	}()
+}
+
+// Stop the background job
+func (aq *ActionQueue) Stop() {
+	if aq.stopChan != nil {
+		close(aq.stopChan)
+		aq.jobWG.Wait()
+		aq.stopChan = nil
+	}
+}
+
+// NewActionQueue creates a queue for action requests to offline devices.
+//
+//	core is used to publish the actions to the devices
+//	maxQueued is the max nr of queued actions for each device
+//	statusTimeout is the time a delivered request waits for a status reply
+func NewActionQueue(core *core.PubSubCore, maxQueued int, statusTimeout time.Duration) *ActionQueue {
+	aq := &ActionQueue{
+		core:          core,
+		maxQueued:     maxQueued,
+		statusTimeout: statusTimeout,
+		subscribers:   make(map[string]actionFilter),
+		requests:      make(map[string]*actionRequest),
+		queued:        make(map[string][]*actionRequest),
+	}
+	return aq
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/hiveot/hub/api/go/hubapi"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/hiveot/hub/lib/caphelp"
 
 	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/authz"
 	"github.com/hiveot/hub/pkg/pubsub/core"
 )
 
@@ -22,55 +24,123 @@ type DevicePubSub struct {
 	publisherID string
 	// core is the pubsub engine
 	core *core.PubSubCore
+	// verify permissions of the device
+	pubSubAuthz *PubSubAuthz
+	// names of events whose last value is retained
+	retainedEvents map[string]bool
+	// queue of actions for offline devices
+	actionQueue *ActionQueue
 	// subscriptionIDs from the core to be released with the capability
 	subscriptionIDs []string
+	subMutex        sync.Mutex
 }
 
 // PubEvent publishes the given thing event. The payload is an event value as per TD.
+// Publishing a TD requires the PermPubTD permission, other events require PermPubEvent.
+// The last value of retained events, like the TD, is passed to new subscribers.
 func (svc *DevicePubSub) PubEvent(
-	_ context.Context, thingID, eventID string, value []byte) (err error) {
+	ctx context.Context, thingID, eventID string, value []byte) (err error) {
 
 	//logrus.Infof("publisherID=%s, thingID=%s, name=%s", svc.publisherID, thingID, eventID)
+	permission := authz.PermPubEvent
+	if eventID == hubapi.EventNameTD {
+		permission = authz.PermPubTD
+	}
+	err = svc.pubSubAuthz.HasPermission(ctx, svc.publisherID, thingID, permission)
+	if err != nil {
+		return err
+	}
 
 	tv := thing.NewThingValue(svc.publisherID, thingID, eventID, caphelp.Clone(value))
 	// note that marshal will copy the value so its buffer can be reused
 	tvSerialized, _ := json.Marshal(tv)
 	topic := MakeThingTopic(svc.publisherID, thingID, hubapi.MessageTypeEvent, eventID)
-	go svc.core.Publish(topic, tvSerialized)
+	// publish does not block as messages are queued for each subscriber
+	if svc.retainedEvents[eventID] {
+		svc.core.PublishRetained(topic, tvSerialized)
+	} else {
+		svc.core.Publish(topic, tvSerialized)
+	}
 	return
 }
 
+// PubActionStatus passes the status of an action request to the user that made the request.
+// Only the device the action was sent to can reply with its status.
+func (svc *DevicePubSub) PubActionStatus(
+	_ context.Context, requestID string, status string, errMsg string) (err error) {
+
+	logrus.Infof("publisherID=%s, requestID=%s, status=%s", svc.publisherID, requestID, status)
+	err = svc.actionQueue.SetStatus(svc.publisherID, requestID, status, errMsg)
+	if err != nil {
+		logrus.Warning(err)
+	}
+	return err
+}
+
 // SubAction subscribes to messages for the given thingID and action name
+// This requires the PermReadAction permission for the Thing. When subscribing to all things
+// the permission is verified for each received action.
+// Queued actions that match the subscription are delivered after subscribing.
 //
 //	thingID and actionID are optional. Use "" to receive actions for all things or names.
 func (svc *DevicePubSub) SubAction(
-	_ context.Context, thingID string, actionID string,
+	ctx context.Context, thingID string, actionID string,
 	handler func(thing.ThingValue)) (err error) {
 
 	logrus.Infof("publisherID=%s, thingID=%s, actionName=%s",
 		svc.publisherID, thingID, actionID)
+	isWildcardThing := isWildcard(thingID)
+	if !isWildcardThing {
+		err = svc.pubSubAuthz.HasPermission(ctx, svc.publisherID, thingID, authz.PermReadAction)
+		if err != nil {
+			return err
+		}
+	}
 
 	topic := MakeThingTopic(svc.publisherID, thingID, hubapi.MessageTypeAction, actionID)
-	subscriptionID, err := svc.core.Subscribe(topic,
+	// the lock is held until the ID is tracked, in case the core closes the subscription right away
+	svc.subMutex.Lock()
+	defer svc.subMutex.Unlock()
+	subscriptionID, err := svc.core.Subscribe(svc.publisherID, topic,
 		func(topic string, message []byte) {
 			msgValue := thing.ThingValue{}
-			err = json.Unmarshal(message, &msgValue)
-			if err != nil {
-				logrus.Error(err)
+			err2 := json.Unmarshal(message, &msgValue)
+			if err2 != nil {
+				logrus.Error(err2)
+			}
+			if isWildcardThing {
+				err2 = svc.pubSubAuthz.HasPermission(context.Background(),
+					msgValue.PublisherID, msgValue.ThingID, authz.PermReadAction)
+				if err2 != nil {
+					return
+				}
 			}
 			// Do not pass properties configuration action messages
 			if msgValue.ID != vocab.WoTProperties {
 				handler(msgValue)
 			}
-		})
+		},
+		newClosedHandler(svc.publisherID, svc.removeSubscription, handler))
 	if err == nil {
 		svc.subscriptionIDs = append(svc.subscriptionIDs, subscriptionID)
+		svc.actionQueue.AddSubscriber(subscriptionID, svc.publisherID, thingID, actionID)
 	}
 	return err
 }
 
+// removeSubscription removes a subscription that was closed by the core
+func (svc *DevicePubSub) removeSubscription(subscriptionID string) {
+	svc.subMutex.Lock()
+	defer svc.subMutex.Unlock()
+	svc.actionQueue.RemoveSubscribers([]string{subscriptionID})
+	svc.subscriptionIDs = removeSubscriptionID(svc.subscriptionIDs, subscriptionID)
+}
+
 // Release the capability and end subscriptions
 func (svc *DevicePubSub) Release() {
+	svc.subMutex.Lock()
+	defer svc.subMutex.Unlock()
+	svc.actionQueue.RemoveSubscribers(svc.subscriptionIDs)
 	err := svc.core.Unsubscribe(svc.subscriptionIDs)
 
 	if err != nil {
@@ -83,10 +153,17 @@ func (svc *DevicePubSub) Release() {
 //
 //	publisherID is the thingID of the IoT device doing the publishing
 //	core is the core pubsub that is used for publishing and subscribing
-func NewDevicePubSub(publisherID string, core *core.PubSubCore) *DevicePubSub {
+//	pubSubAuthz verifies the permissions of the device
+//	retainedEvents contains the names of events whose last value is retained for new subscribers
+//	actionQueue holds the actions for the device while it is offline
+func NewDevicePubSub(publisherID string, core *core.PubSubCore,
+	pubSubAuthz *PubSubAuthz, retainedEvents map[string]bool, actionQueue *ActionQueue) *DevicePubSub {
 	deviceCap := &DevicePubSub{
 		publisherID:     publisherID,
 		core:            core,
+		pubSubAuthz:     pubSubAuthz,
+		retainedEvents:  retainedEvents,
+		actionQueue:     actionQueue,
 		subscriptionIDs: make([]string, 0),
 	}
 	return deviceCap
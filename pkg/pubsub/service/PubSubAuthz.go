@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/pkg/authz"
+	"github.com/hiveot/hub/pkg/pubsub/core"
+)
+
+// permissionsCacheValidity is the time the permissions of a client for a Thing are cached
+const permissionsCacheValidity = time.Minute
+
+// cached permissions of a client for a Thing
+type cachedPermissions struct {
+	permissions []string
+	expiry      time.Time
+}
+
+// isWildcard returns true if any of the given IDs is empty or a wildcard.
+// Permissions for wildcard subscriptions are verified for each message.
+func isWildcard(ids ...string) bool {
+	for _, id := range ids {
+		if id == "" || id == core.WildcardSingle || id == core.WildcardMulti {
+			return true
+		}
+	}
+	return false
+}
+
+// PubSubAuthz verifies the permissions of a client for publishing and subscribing to Things.
+// Permissions obtained from the authz service are cached for a short period to avoid a
+// round-trip for each message delivered to wildcard subscriptions.
+type PubSubAuthz struct {
+	// the client whose permissions to verify
+	clientID string
+	// the authz capability to verify permissions with. nil to allow everything.
+	verifyAuthz authz.IVerifyAuthz
+	// permissions by thing address
+	cache    map[string]cachedPermissions
+	cacheMux sync.Mutex
+}
+
+// getPermissions returns the permissions of the client for the thing address
+func (svc *PubSubAuthz) getPermissions(ctx context.Context, thingAddr string) ([]string, error) {
+	svc.cacheMux.Lock()
+	defer svc.cacheMux.Unlock()
+
+	cached, found := svc.cache[thingAddr]
+	if found && time.Now().Before(cached.expiry) {
+		return cached.permissions, nil
+	}
+	permissions, err := svc.verifyAuthz.GetPermissions(ctx, svc.clientID, thingAddr)
+	if err != nil {
+		return nil, err
+	}
+	svc.cache[thingAddr] = cachedPermissions{
+		permissions: permissions,
+		expiry:      time.Now().Add(permissionsCacheValidity),
+	}
+	return permissions, nil
+}
+
+// HasPermission verifies if the client has the permission for the Thing of the publisher.
+// This returns nil if permission is granted, or an error if permission is denied.
+//
+//	publisherID of the Thing
+//	thingID of the Thing
+//	permission to verify, eg authz.PermEmitAction, authz.PermReadEvent, ...
+func (svc *PubSubAuthz) HasPermission(
+	ctx context.Context, publisherID, thingID string, permission string) error {
+
+	if svc.verifyAuthz == nil {
+		return nil
+	}
+	thingAddr := publisherID + "/" + thingID
+	permissions, err := svc.getPermissions(ctx, thingAddr)
+	if err != nil {
+		err = fmt.Errorf("unable to verify permission '%s' of client '%s' for thing '%s': %w",
+			permission, svc.clientID, thingAddr, err)
+		logrus.Error(err)
+		return err
+	}
+	for _, perm := range permissions {
+		if perm == permission {
+			return nil
+		}
+	}
+	err = fmt.Errorf("client '%s' is denied permission '%s' for thing '%s'",
+		svc.clientID, permission, thingAddr)
+	logrus.Warning(err)
+	return err
+}
+
+// NewPubSubAuthz creates a verifier of client permissions.
+//
+//	clientID is the ID of the device, service or user whose permissions to verify
+//	verifyAuthz is the authz service capability to verify permissions, or nil to allow everything
+func NewPubSubAuthz(clientID string, verifyAuthz authz.IVerifyAuthz) *PubSubAuthz {
+	svc := &PubSubAuthz{
+		clientID:    clientID,
+		verifyAuthz: verifyAuthz,
+		cache:       make(map[string]cachedPermissions),
+	}
+	return svc
+}
@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hiveot/hub/pkg/authz"
 	"github.com/hiveot/hub/pkg/pubsub"
+	"github.com/hiveot/hub/pkg/pubsub/config"
 	"github.com/hiveot/hub/pkg/pubsub/core"
 )
 
@@ -12,8 +15,15 @@ import (
 // This implements the IPubSubService interface
 //
 // This service main task is to issue capabilities to devices, services and end-users
+// Publish and subscribe requests of devices and end-users are verified with the authz service.
 type PubSubService struct {
 	core *core.PubSubCore
+	// capability to verify authorization of devices and users. nil to not verify.
+	verifyAuthz authz.IVerifyAuthz
+	// names of events whose last value is retained
+	retainedEvents map[string]bool
+	// queue of actions for offline devices
+	actionQueue *ActionQueue
 }
 
 // CapDevicePubSub provides the capability to pub/sub thing information as an IoT device.
@@ -23,7 +33,7 @@ func (svc *PubSubService) CapDevicePubSub(_ context.Context, deviceID string) (p
 	if deviceID == "" {
 		return nil, fmt.Errorf("missing deviceID")
 	}
-	devicePubSub := NewDevicePubSub(deviceID, svc.core)
+	devicePubSub := NewDevicePubSub(deviceID, svc.core, NewPubSubAuthz(deviceID, svc.verifyAuthz), svc.retainedEvents, svc.actionQueue)
 	return devicePubSub, nil
 }
 
@@ -34,7 +44,7 @@ func (svc *PubSubService) CapServicePubSub(_ context.Context, serviceID string)
 	if serviceID == "" {
 		return nil, fmt.Errorf("missing serviceID")
 	}
-	servicePubSub := NewServicePubSub(serviceID, svc.core)
+	servicePubSub := NewServicePubSub(serviceID, svc.core, svc.retainedEvents, svc.actionQueue)
 	return servicePubSub, nil
 }
 
@@ -47,10 +57,16 @@ func (svc *PubSubService) CapUserPubSub(_ context.Context, userID string) (pubsu
 	if userID == "" {
 		return nil, fmt.Errorf("missing userID")
 	}
-	userPubSub := NewUserPubSub(userID, svc.core)
+	userPubSub := NewUserPubSub(userID, svc.core, NewPubSubAuthz(userID, svc.verifyAuthz), svc.actionQueue)
 	return userPubSub, nil
 }
 
+// GetSubscriptionStats returns the delivery counters of all subscriptions.
+// Intended to identify slow subscribers that drop messages.
+func (svc *PubSubService) GetSubscriptionStats(_ context.Context) ([]pubsub.SubscriptionStats, error) {
+	return svc.core.GetStats(), nil
+}
+
 // Release the service and free its resources
 //func (svc *PubSubService) Release() error {
 //	err := svc.core.Stop()
@@ -59,19 +75,33 @@ func (svc *PubSubService) CapUserPubSub(_ context.Context, userID string) (pubsu
 
 func (svc *PubSubService) Start() error {
 	err := svc.core.Start()
+	svc.actionQueue.Start()
 	return err
 }
 func (svc *PubSubService) Stop() error {
+	svc.actionQueue.Stop()
 	err := svc.core.Stop()
 	return err
 }
 
 // NewPubSubService creates a new instance of the pubsub
 // returns an error if start fails
-func NewPubSubService() *PubSubService {
-	pubsubCore := core.NewPubSubCore()
+//
+//	cfg is the service configuration with the subscriber queue, retained event and action queue settings
+//	verifyAuthz is the capability to verify authorization of devices and users. nil to allow all.
+func NewPubSubService(cfg config.PubSubConfig, verifyAuthz authz.IVerifyAuthz) *PubSubService {
+	pubsubCore := core.NewPubSubCore(cfg.QueueSize, cfg.OverflowPolicy)
+	retainedEvents := make(map[string]bool)
+	for _, eventName := range cfg.RetainedEvents {
+		retainedEvents[eventName] = true
+	}
+	actionQueue := NewActionQueue(pubsubCore,
+		cfg.MaxQueuedActions, time.Duration(cfg.ActionStatusTimeoutSec)*time.Second)
 	svc := &PubSubService{
-		core: pubsubCore,
+		core:           pubsubCore,
+		verifyAuthz:    verifyAuthz,
+		retainedEvents: retainedEvents,
+		actionQueue:    actionQueue,
 	}
 	return svc
 }
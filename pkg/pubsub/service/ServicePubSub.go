@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/hiveot/hub/api/go/hubapi"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 
@@ -13,12 +14,25 @@ import (
 
 // ServicePubSub provides the capability to pub/sub for services
 // This embeds the device and user pubsub capabilities
+// Hub services are trusted and can publish and subscribe to all Things without authorization.
 type ServicePubSub struct {
 	DevicePubSub
 	UserPubSub
 	serviceID       string
 	core            *core.PubSubCore
 	subscriptionIDs []string
+	subMutex        sync.Mutex
+}
+
+// ClearRetained removes the retained value of an event of a Thing from any publisher
+func (svc *ServicePubSub) ClearRetained(
+	_ context.Context, publisherID, thingID, eventID string) (err error) {
+
+	logrus.Infof("publisherID=%s, thingID=%s, eventID=%s", publisherID, thingID, eventID)
+	topic := MakeThingTopic(publisherID, thingID, hubapi.MessageTypeEvent, eventID)
+	// an empty payload removes the retained message
+	svc.core.PublishRetained(topic, nil)
+	return nil
 }
 
 // SubActions subscribe to all actions aimed at things
@@ -35,7 +49,10 @@ func (svc *ServicePubSub) SubActions(
 
 	logrus.Infof("publisherID=%s, thingID=%s, actionID=%s", publisherID, thingID, actionID)
 	subTopic := MakeThingTopic(publisherID, thingID, hubapi.MessageTypeAction, actionID)
-	subID, err := svc.core.Subscribe(subTopic,
+	// the lock is held until the ID is tracked, in case the core closes the subscription right away
+	svc.subMutex.Lock()
+	defer svc.subMutex.Unlock()
+	subID, err := svc.core.Subscribe(svc.serviceID, subTopic,
 		func(topic string, message []byte) {
 			// FIXME: capnp serialization of messageValue?
 			msgValue := thing.ThingValue{}
@@ -44,7 +61,8 @@ func (svc *ServicePubSub) SubActions(
 				logrus.Error(err)
 			}
 			handler(msgValue)
-		})
+		},
+		newClosedHandler(svc.serviceID, svc.removeSubscription, handler))
 	if err == nil {
 		svc.subscriptionIDs = append(svc.subscriptionIDs, subID)
 	}
@@ -58,7 +76,10 @@ func (svc *ServicePubSub) SubEvents(
 
 	logrus.Infof("publisherID=%s, thingID=%s, eventID=%s", publisherID, thingID, eventID)
 	subTopic := MakeThingTopic(publisherID, thingID, hubapi.MessageTypeEvent, eventID)
-	subID, err := svc.core.Subscribe(subTopic,
+	// the lock is held until the ID is tracked, in case the core closes the subscription right away
+	svc.subMutex.Lock()
+	defer svc.subMutex.Unlock()
+	subID, err := svc.core.Subscribe(svc.serviceID, subTopic,
 		func(topic string, message []byte) {
 			msgValue := thing.ThingValue{}
 			err := json.Unmarshal(message, &msgValue)
@@ -66,31 +87,49 @@ func (svc *ServicePubSub) SubEvents(
 				logrus.Error(err)
 			}
 			handler(msgValue)
-		})
+		},
+		newClosedHandler(svc.serviceID, svc.removeSubscription, handler))
 	if err == nil {
 		svc.subscriptionIDs = append(svc.subscriptionIDs, subID)
 	}
 	return err
 }
 
+// removeSubscription removes a subscription that was closed by the core
+func (svc *ServicePubSub) removeSubscription(subscriptionID string) {
+	svc.subMutex.Lock()
+	svc.subscriptionIDs = removeSubscriptionID(svc.subscriptionIDs, subscriptionID)
+	svc.subMutex.Unlock()
+}
+
 // Release the capability and end subscriptions
 func (svc *ServicePubSub) Release() {
+	svc.subMutex.Lock()
 	_ = svc.core.Unsubscribe(svc.subscriptionIDs)
 	svc.subscriptionIDs = nil
+	svc.subMutex.Unlock()
 	svc.DevicePubSub.Release()
 	svc.UserPubSub.Release()
 }
 
-func NewServicePubSub(serviceID string, core *core.PubSubCore) *ServicePubSub {
+func NewServicePubSub(serviceID string, core *core.PubSubCore,
+	retainedEvents map[string]bool, actionQueue *ActionQueue) *ServicePubSub {
+	// services are trusted
+	pubSubAuthz := NewPubSubAuthz(serviceID, nil)
 	servicePubSub := &ServicePubSub{
 		UserPubSub: UserPubSub{
 			userID:          serviceID,
 			core:            core,
+			pubSubAuthz:     pubSubAuthz,
+			actionQueue:     actionQueue,
 			subscriptionIDs: make([]string, 0),
 		},
 		DevicePubSub: DevicePubSub{
 			publisherID:     serviceID,
 			core:            core,
+			pubSubAuthz:     pubSubAuthz,
+			retainedEvents:  retainedEvents,
+			actionQueue:     actionQueue,
 			subscriptionIDs: make([]string, 0),
 		},
 		serviceID:       serviceID,
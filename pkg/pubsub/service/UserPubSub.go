@@ -10,22 +10,39 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/authz"
+	"github.com/hiveot/hub/pkg/pubsub"
 	"github.com/hiveot/hub/pkg/pubsub/core"
 )
 
+// readEventPermission returns the permission needed to read the event with the given ID
+func readEventPermission(eventID string) string {
+	if eventID == hubapi.EventNameTD {
+		return authz.PermReadTD
+	}
+	return authz.PermReadEvent
+}
+
 // UserPubSub provides the capability to pub/sub for end-users
 type UserPubSub struct {
 	userID          string
 	core            *core.PubSubCore
+	pubSubAuthz     *PubSubAuthz
+	actionQueue     *ActionQueue
 	subscriptionIDs []string
 	subMutex        sync.RWMutex
 }
 
 // PubAction publishes an action by the user to a thing
+// This requires the PermEmitAction permission for the Thing.
 func (cap *UserPubSub) PubAction(
-	_ context.Context, publisherID, thingID, actionID string, value []byte) (err error) {
+	ctx context.Context, publisherID, thingID, actionID string, value []byte) (err error) {
 
 	logrus.Infof("userID=%s, thingID=%s, actionName=%s", cap.userID, thingID, actionID)
+	err = cap.pubSubAuthz.HasPermission(ctx, publisherID, thingID, authz.PermEmitAction)
+	if err != nil {
+		return err
+	}
 
 	topic := MakeThingTopic(publisherID, thingID, hubapi.MessageTypeAction, actionID)
 	tv := thing.NewThingValue(publisherID, thingID, actionID, value)
@@ -35,42 +52,85 @@ func (cap *UserPubSub) PubAction(
 	return
 }
 
+// PubActionRequest publishes an action by the user to a thing and queues it if the device is offline.
+// This requires the PermEmitAction permission for the Thing.
+func (cap *UserPubSub) PubActionRequest(
+	ctx context.Context, publisherID, thingID, actionID string, value []byte,
+	expiry int64, statusHandler func(status pubsub.ActionStatus)) (status pubsub.ActionStatus, err error) {
+
+	logrus.Infof("userID=%s, thingID=%s, actionName=%s, expiry=%d", cap.userID, thingID, actionID, expiry)
+	err = cap.pubSubAuthz.HasPermission(ctx, publisherID, thingID, authz.PermEmitAction)
+	if err != nil {
+		return status, err
+	}
+	tv := thing.NewThingValue(publisherID, thingID, actionID, value)
+	tv.Expiry = expiry
+	return cap.actionQueue.PubAction(tv, statusHandler)
+}
+
 // SubEvent creates a topic for receiving events.
 // Either a thingID or eventID must be provided.
 //
+// This requires the PermReadEvent permission for the Thing, or PermReadTD for the TD event.
+// When subscribing to multiple publishers, things or events, the permission is verified for each received event.
+//
 //	publisherID publisher of the event. Use "" to subscribe to all publishers
 //	thingID of the publisher event. Use "" to subscribe to events from all Things
 //	eventID of the event. Use "" to subscribe to all events of a thing
-func (cap *UserPubSub) SubEvent(_ context.Context, publisherID, thingID, eventID string,
+func (cap *UserPubSub) SubEvent(ctx context.Context, publisherID, thingID, eventID string,
 	handler func(thing.ThingValue)) error {
 
 	// it is not allowed to subscribe to all events of all things. Pick one or the other.
 	if thingID == "" && eventID == "" {
 		return fmt.Errorf("a thingID or eventID must be provided")
 	}
+	// permissions of wildcard subscriptions are verified for each message
+	filterMessages := isWildcard(publisherID, thingID, eventID)
+	if !isWildcard(publisherID, thingID) {
+		err := cap.pubSubAuthz.HasPermission(ctx, publisherID, thingID, readEventPermission(eventID))
+		if err != nil {
+			return err
+		}
+	}
 
 	//logrus.Infof("userID=%s, thingID=%s, eventID=%s", cap.userID, thingID, eventID)
 	subTopic := MakeThingTopic(publisherID, thingID, hubapi.MessageTypeEvent, eventID)
 
-	subID, err := cap.core.Subscribe(subTopic,
+	// the lock is held until the ID is tracked, in case the core closes the subscription right away
+	cap.subMutex.Lock()
+	defer cap.subMutex.Unlock()
+	subID, err := cap.core.Subscribe(cap.userID, subTopic,
 		func(topic string, message []byte) {
 			msgValue := thing.ThingValue{}
 			err := json.Unmarshal(message, &msgValue)
 			if err != nil {
 				logrus.Error(err)
 			}
+			if filterMessages {
+				err = cap.pubSubAuthz.HasPermission(context.Background(),
+					msgValue.PublisherID, msgValue.ThingID, readEventPermission(msgValue.ID))
+				if err != nil {
+					return
+				}
+			}
 			handler(msgValue)
-		})
+		},
+		newClosedHandler(cap.userID, cap.removeSubscription, handler))
 
 	// track the subscriptions to be able to unsubscribe
 	if err == nil {
-		cap.subMutex.Lock()
 		cap.subscriptionIDs = append(cap.subscriptionIDs, subID)
-		cap.subMutex.Unlock()
 	}
 	return err
 }
 
+// removeSubscription removes a subscription that was closed by the core
+func (cap *UserPubSub) removeSubscription(subscriptionID string) {
+	cap.subMutex.Lock()
+	cap.subscriptionIDs = removeSubscriptionID(cap.subscriptionIDs, subscriptionID)
+	cap.subMutex.Unlock()
+}
+
 // Release the capability and end subscriptions
 func (cap *UserPubSub) Release() {
 	cap.subMutex.Lock()
@@ -79,10 +139,19 @@ func (cap *UserPubSub) Release() {
 	cap.subMutex.Unlock()
 }
 
-func NewUserPubSub(userID string, core *core.PubSubCore) *UserPubSub {
+// NewUserPubSub provides the capability for an end-user to publish actions and subscribe to events
+//
+//	userID is the login ID of the user
+//	core is the core pubsub that is used for publishing and subscribing
+//	pubSubAuthz verifies the permissions of the user
+//	actionQueue queues the action requests for offline devices
+func NewUserPubSub(userID string, core *core.PubSubCore,
+	pubSubAuthz *PubSubAuthz, actionQueue *ActionQueue) *UserPubSub {
 	userPubSub := &UserPubSub{
 		userID:          userID,
 		core:            core,
+		pubSubAuthz:     pubSubAuthz,
+		actionQueue:     actionQueue,
 		subscriptionIDs: make([]string, 0),
 	}
 	return userPubSub
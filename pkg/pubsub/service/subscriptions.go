@@ -0,0 +1,37 @@
+package service
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/hiveot/hub/lib/thing"
+	"github.com/hiveot/hub/pkg/pubsub"
+)
+
+// removeSubscriptionID returns the subscription IDs without the given ID
+func removeSubscriptionID(subscriptionIDs []string, subscriptionID string) []string {
+	for i, id := range subscriptionIDs {
+		if id == subscriptionID {
+			return append(subscriptionIDs[:i], subscriptionIDs[i+1:]...)
+		}
+	}
+	return subscriptionIDs
+}
+
+// newClosedHandler returns the handler of a subscription that is closed by the core.
+// It removes the subscription from the session and tells the client by passing a
+// pubsub.EventNameSubscriptionClosed value to the subscription handler.
+//
+//	clientID is the ID of the session client, used in logging
+//	remove removes the subscription ID from the session
+//	handler is the subscription handler of the client
+func newClosedHandler(clientID string, remove func(subscriptionID string),
+	handler func(thing.ThingValue)) func(subscriptionID string, reason error) {
+
+	return func(subscriptionID string, reason error) {
+		logrus.Warningf("clientID=%s: %s", clientID, reason)
+		remove(subscriptionID)
+		tv := thing.NewThingValue(pubsub.ServiceName, pubsub.ServiceName,
+			pubsub.EventNameSubscriptionClosed, []byte(reason.Error()))
+		handler(tv)
+	}
+}
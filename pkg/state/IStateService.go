@@ -32,6 +32,10 @@ const ServiceName = hubapi.StateServiceName
 // * Document size: less than 100KB per document (tbd)
 // * Number of documents per client: 10000 (tbd)
 type IStateService interface {
+	// Backup writes a point-in-time snapshot of all client stores into the given directory.
+	// The directory is located on the hub. Clients can continue to use their store during the backup.
+	// Use the service Restore method to restore the snapshot while the service isn't running.
+	Backup(ctx context.Context, directory string) error
 
 	// GetStores the names of available stores for use in application state
 	//GetStores(ctx context.Context) []string
@@ -67,6 +71,16 @@ type IManageStateStore interface {
 // IClientState defines the capability for reading and writing state values in a storage bucket
 type IClientState interface {
 
+	// Begin starts a transaction for making atomic changes to the client bucket.
+	// Other writes to the bucket wait until the transaction is committed or rolled back.
+	// returns nil if communication with the service fails
+	Begin(ctx context.Context) (tx bucketstore.IBucketTx, err error)
+
+	// CompareAndSet replaces the value of the key if its current value equals oldValue.
+	// Use nil for oldValue to only set the key if it doesn't exist.
+	// This returns swapped is false if the current value differs.
+	CompareAndSet(ctx context.Context, key string, oldValue []byte, newValue []byte) (swapped bool, err error)
+
 	// Cursor creates a new cursor for iterating the content of the client bucket
 	// cursor.Close must be called after use to release any read transactions
 	// returns nil if communication with the service fails
@@ -75,6 +89,10 @@ type IClientState interface {
 	// Delete removes the key-value pair from the state store
 	Delete(ctx context.Context, key string) (err error)
 
+	// DeleteBucket removes a bucket and all its keys from the client's store
+	// Other capabilities that use the bucket must be released first.
+	DeleteBucket(ctx context.Context, bucketID string) (err error)
+
 	// Get returns the document for the given key
 	// Returns an error if the key doesn't exist
 	Get(ctx context.Context, key string) (value []byte, err error)
@@ -82,9 +100,24 @@ type IClientState interface {
 	// GetMultiple returns a batch of documents with the given keys
 	GetMultiple(ctx context.Context, keys []string) (docs map[string][]byte, err error)
 
+	// Info returns the information of the client's store
+	Info(ctx context.Context) (info *bucketstore.BucketStoreInfo)
+
 	// Keys returns a list of document keys in the store
 	//Keys(ctx context.Context) (keys []string, err error)
 
+	// ListBuckets returns the IDs of the buckets in the client's store
+	ListBuckets(ctx context.Context) (bucketIDs []string, err error)
+
+	// PrefixCursor creates a new cursor for iterating the keys that start with the given prefix
+	// returns nil if communication with the service fails
+	PrefixCursor(ctx context.Context, prefix string) (cursor bucketstore.IBucketCursor)
+
+	// RangeCursor creates a new cursor for iterating the keys in the range [startKey, endKey)
+	// Use "" for an open start or end of the range.
+	// returns nil if communication with the service fails
+	RangeCursor(ctx context.Context, startKey string, endKey string) (cursor bucketstore.IBucketCursor)
+
 	// Set sets a document with the given key
 	// This takes ownership of value
 	Set(ctx context.Context, key string, value []byte) error
@@ -2,9 +2,11 @@ package state_test
 
 import (
 	"context"
+	"encoding/base64"
 	"github.com/hiveot/hub/lib/hubclient"
 	"net"
 	"os"
+	"path"
 	"syscall"
 	"testing"
 	"time"
@@ -15,6 +17,8 @@ import (
 
 	"github.com/hiveot/hub/lib/logging"
 	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
+	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
 	"github.com/hiveot/hub/pkg/state"
 	"github.com/hiveot/hub/pkg/state/capnpclient"
 	"github.com/hiveot/hub/pkg/state/capnpserver"
@@ -286,3 +290,280 @@ func TestCursor(t *testing.T) {
 	clientState.Release()
 
 }
+
+func TestPrefixRangeCursor(t *testing.T) {
+	logrus.Infof("--- TestPrefixRangeCursor ---")
+	const clientID1 = "test-client1"
+	const appID = "test-app"
+	data := map[string][]byte{
+		"a1": []byte("value a1"),
+		"b1": []byte("value b1"),
+		"b2": []byte("value b2"),
+		"c1": []byte("value c1"),
+	}
+
+	ctx := context.Background()
+	svc, stopFn, err := startStateService(testUseCapnp)
+	require.NoError(t, err)
+	defer stopFn()
+	clientState, _ := svc.CapClientState(ctx, clientID1, appID)
+	err = clientState.SetMultiple(ctx, data)
+	assert.NoError(t, err)
+
+	cursor := clientState.PrefixCursor(ctx, "b")
+	require.NotNil(t, cursor)
+	k, v, valid := cursor.First()
+	assert.True(t, valid)
+	assert.Equal(t, "b1", k)
+	assert.Equal(t, data["b1"], v)
+	docs, itemsRemaining := cursor.NextN(10)
+	assert.False(t, itemsRemaining)
+	assert.Equal(t, 1, len(docs))
+	cursor.Release()
+
+	cursor = clientState.RangeCursor(ctx, "a1", "c1")
+	require.NotNil(t, cursor)
+	k, _, valid = cursor.Last()
+	assert.True(t, valid)
+	assert.Equal(t, "b2", k)
+	cursor.Release()
+
+	clientState.Release()
+}
+
+func TestListDeleteBuckets(t *testing.T) {
+	logrus.Infof("--- TestListDeleteBuckets ---")
+	const clientID1 = "test-client1"
+	const appID1 = "test-app1"
+	const appID2 = "test-app2"
+	const key1 = "key1"
+	var val1 = []byte("value 1")
+
+	ctx := context.Background()
+	svc, stopFn, err := startStateService(testUseCapnp)
+	require.NoError(t, err)
+	defer stopFn()
+	clientState1, _ := svc.CapClientState(ctx, clientID1, appID1)
+	err = clientState1.Set(ctx, key1, val1)
+	assert.NoError(t, err)
+	clientState2, _ := svc.CapClientState(ctx, clientID1, appID2)
+	err = clientState2.Set(ctx, key1, val1)
+	assert.NoError(t, err)
+	clientState2.Release()
+
+	bucketIDs, err := clientState1.ListBuckets(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{appID1, appID2}, bucketIDs)
+
+	info := clientState1.Info(ctx)
+	require.NotNil(t, info)
+	assert.Equal(t, clientID1, info.Id)
+	assert.Equal(t, backend, info.Engine)
+	assert.Equal(t, int64(2), info.NrRecords)
+
+	err = clientState1.DeleteBucket(ctx, appID2)
+	assert.NoError(t, err)
+	bucketIDs, err = clientState1.ListBuckets(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{appID1}, bucketIDs)
+
+	clientState1.Release()
+}
+
+func TestTransaction(t *testing.T) {
+	logrus.Infof("--- TestTransaction ---")
+	const clientID1 = "test-client1"
+	const appID = "test-app"
+	const key1 = "key1"
+	const key2 = "key2"
+	var val1 = []byte("value 1")
+	var val2 = []byte("value 2")
+
+	ctx := context.Background()
+	svc, stopFn, err := startStateService(testUseCapnp)
+	require.NoError(t, err)
+	defer stopFn()
+	clientState, _ := svc.CapClientState(ctx, clientID1, appID)
+	require.NotNil(t, clientState)
+	defer clientState.Release()
+	err = clientState.Set(ctx, key1, val1)
+	require.NoError(t, err)
+
+	// changes of a rolled back transaction are discarded
+	tx, err := clientState.Begin(ctx)
+	require.NoError(t, err)
+	err = tx.Set(key2, val2)
+	assert.NoError(t, err)
+	val, err := tx.Get(key2)
+	assert.NoError(t, err)
+	assert.Equal(t, val2, val)
+	err = tx.Rollback()
+	assert.NoError(t, err)
+	val, _ = clientState.Get(ctx, key2)
+	assert.Nil(t, val)
+
+	// changes of a committed transaction are applied together
+	tx, err = clientState.Begin(ctx)
+	require.NoError(t, err)
+	err = tx.Set(key2, val2)
+	assert.NoError(t, err)
+	err = tx.Delete(key1)
+	assert.NoError(t, err)
+	val, err = tx.Get(key1)
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+	err = tx.Commit()
+	assert.NoError(t, err)
+	err = tx.Set(key1, val1)
+	assert.ErrorIs(t, err, bucketstore.ErrTxEnded)
+	val, _ = clientState.Get(ctx, key2)
+	assert.Equal(t, val2, val)
+	val, _ = clientState.Get(ctx, key1)
+	assert.Nil(t, val)
+
+	// compare and set
+	swapped, err := clientState.CompareAndSet(ctx, key1, nil, val1)
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+	swapped, err = clientState.CompareAndSet(ctx, key1, val2, val2)
+	assert.NoError(t, err)
+	assert.False(t, swapped)
+	swapped, err = clientState.CompareAndSet(ctx, key1, val1, val2)
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+	val, _ = clientState.Get(ctx, key1)
+	assert.Equal(t, val2, val)
+}
+
+func TestBackupRestore(t *testing.T) {
+	logrus.Infof("--- TestBackupRestore ---")
+	const clientID1 = "test-client1"
+	const clientID2 = "test-client2"
+	const appID = "test-app"
+	const key1 = "key1"
+	var val1 = []byte("value 1")
+	var val2 = []byte("value 2")
+	const backupDir = storeDir + "-backup"
+	const restoreDir = storeDir + "-restore"
+	_ = os.RemoveAll(backupDir)
+	_ = os.RemoveAll(restoreDir)
+
+	ctx := context.Background()
+	svc, stopFn, err := startStateService(testUseCapnp)
+	require.NoError(t, err)
+	defer stopFn()
+	// client1 has an open store while client2's store is closed
+	clientState1, _ := svc.CapClientState(ctx, clientID1, appID)
+	err = clientState1.Set(ctx, key1, val1)
+	assert.NoError(t, err)
+	clientState2, _ := svc.CapClientState(ctx, clientID2, appID)
+	err = clientState2.Set(ctx, key1, val1)
+	assert.NoError(t, err)
+	clientState2.Release()
+	time.Sleep(time.Millisecond * 10)
+
+	err = svc.Backup(ctx, backupDir)
+	require.NoError(t, err)
+	// changes after the backup are not included
+	err = clientState1.Set(ctx, key1, val2)
+	assert.NoError(t, err)
+	clientState1.Release()
+
+	// restore into a new service location
+	cfg := config.NewStateConfig(restoreDir)
+	restoreSvc := service.NewStateStoreService(cfg)
+	err = restoreSvc.Restore(backupDir)
+	require.NoError(t, err)
+	err = restoreSvc.Start(ctx)
+	require.NoError(t, err)
+	// a running service can't be restored
+	err = restoreSvc.Restore(backupDir)
+	assert.Error(t, err)
+
+	for _, clientID := range []string{clientID1, clientID2} {
+		clientState, err := restoreSvc.CapClientState(ctx, clientID, appID)
+		require.NoError(t, err)
+		val, err := clientState.Get(ctx, key1)
+		assert.NoError(t, err)
+		assert.Equal(t, val1, val)
+		clientState.Release()
+	}
+	err = restoreSvc.Stop()
+	assert.NoError(t, err)
+
+	// a backup without stores can't be restored
+	err = service.NewStateStoreService(cfg).Restore("/not/a/backup")
+	assert.Error(t, err)
+}
+
+func TestEncryptedState(t *testing.T) {
+	logrus.Infof("--- TestEncryptedState ---")
+	const clientID1 = "test-client1"
+	const appID = "test-app"
+	const key1 = "key1"
+	var val1 = []byte("secret value 1")
+	ctx := context.Background()
+
+	_ = os.RemoveAll(storeDir)
+	_ = os.MkdirAll(storeDir, 0700)
+	cfg := config.NewStateConfig(storeDir)
+	cfg.Encryption.Enabled = true
+	cfg.Encryption.SecretFile = "test.secret"
+	cfg.Encryption.ResolveSecretFile(storeDir)
+	err := encrypted.GenerateSecretFile(cfg.Encryption.SecretFile)
+	require.NoError(t, err)
+	stateSvc := service.NewStateStoreService(cfg)
+	err = stateSvc.Start(ctx)
+	require.NoError(t, err)
+
+	clientState, err := stateSvc.CapClientState(ctx, clientID1, appID)
+	require.NoError(t, err)
+	err = clientState.Set(ctx, key1, val1)
+	require.NoError(t, err)
+	val2, err := clientState.Get(ctx, key1)
+	require.NoError(t, err)
+	assert.Equal(t, val1, val2)
+	clientState.Release()
+	_ = stateSvc.Stop()
+
+	// the value is encrypted in the store file
+	data, err := os.ReadFile(path.Join(storeDir, clientID1+".json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret value")
+	assert.NotContains(t, string(data), base64.StdEncoding.EncodeToString(val1))
+
+	// the store can't be opened without the secret
+	_ = os.Remove(cfg.Encryption.SecretFile)
+	stateSvc = service.NewStateStoreService(cfg)
+	err = stateSvc.Start(ctx)
+	require.NoError(t, err)
+	_, err = stateSvc.CapClientState(ctx, clientID1, appID)
+	assert.Error(t, err)
+	_ = stateSvc.Stop()
+}
+
+func TestSyncPolicyConfig(t *testing.T) {
+	logrus.Infof("--- TestSyncPolicyConfig ---")
+	ctx := context.Background()
+	_ = os.RemoveAll(storeDir)
+	cfg := config.NewStateConfig(storeDir)
+	cfg.SyncPolicy = kvbtree.WALSyncAlways
+	stateSvc := service.NewStateStoreService(cfg)
+	err := stateSvc.Start(ctx)
+	require.NoError(t, err)
+	clientState, err := stateSvc.CapClientState(ctx, "client1", "app1")
+	require.NoError(t, err)
+	err = clientState.Set(ctx, "key1", []byte("value1"))
+	assert.NoError(t, err)
+	clientState.Release()
+	_ = stateSvc.Stop()
+
+	// an unknown sync policy is refused
+	cfg.SyncPolicy = "sometimes"
+	stateSvc = service.NewStateStoreService(cfg)
+	err = stateSvc.Start(ctx)
+	require.NoError(t, err)
+	_, err = stateSvc.CapClientState(ctx, "client2", "app1")
+	assert.Error(t, err)
+	_ = stateSvc.Stop()
+}
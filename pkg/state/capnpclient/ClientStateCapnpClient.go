@@ -16,6 +16,38 @@ type ClientStateCapnpClient struct {
 	capability hubapi.CapClientState // capnp client of the state store
 }
 
+// Begin starts a transaction on the bucket
+func (cl *ClientStateCapnpClient) Begin(ctx context.Context) (tx bucketstore.IBucketTx, err error) {
+	method, release := cl.capability.Begin(ctx, nil)
+	defer release()
+	res, err := method.Struct()
+	if err == nil {
+		capability := res.Cap().AddRef()
+		tx = capnpclient.NewBucketTxCapnpClient(capability)
+	}
+	return tx, err
+}
+
+// CompareAndSet replaces the value of the key if its current value equals oldValue
+func (cl *ClientStateCapnpClient) CompareAndSet(
+	ctx context.Context, key string, oldValue []byte, newValue []byte) (swapped bool, err error) {
+
+	method, release := cl.capability.CompareAndSet(ctx,
+		func(params hubapi.CapClientState_compareAndSet_Params) error {
+			err2 := params.SetKey(key)
+			params.SetOldValueExists(oldValue != nil)
+			_ = params.SetOldValue(oldValue)
+			_ = params.SetNewValue(newValue)
+			return err2
+		})
+	defer release()
+	resp, err := method.Struct()
+	if err == nil {
+		swapped = resp.Swapped()
+	}
+	return swapped, err
+}
+
 // Cursor returns an iterator for the bucket
 func (cl *ClientStateCapnpClient) Cursor(
 	ctx context.Context) (cursor bucketstore.IBucketCursor) {
@@ -48,6 +80,18 @@ func (cl *ClientStateCapnpClient) Delete(ctx context.Context, key string) (err e
 	return err
 }
 
+// DeleteBucket removes a bucket from the client's store
+func (cl *ClientStateCapnpClient) DeleteBucket(ctx context.Context, bucketID string) (err error) {
+	method, release := cl.capability.DeleteBucket(ctx,
+		func(params hubapi.CapClientState_deleteBucket_Params) error {
+			err = params.SetBucketID(bucketID)
+			return err
+		})
+	defer release()
+	_, err = method.Struct()
+	return err
+}
+
 // Get reads the state
 func (cl *ClientStateCapnpClient) Get(ctx context.Context, key string) ([]byte, error) {
 	var err error
@@ -88,6 +132,73 @@ func (cl *ClientStateCapnpClient) GetMultiple(
 	return docs, err
 }
 
+// Info returns the information of the client's store
+func (cl *ClientStateCapnpClient) Info(ctx context.Context) (info *bucketstore.BucketStoreInfo) {
+	info = &bucketstore.BucketStoreInfo{DataSize: -1, NrRecords: -1}
+	method, release := cl.capability.Info(ctx, nil)
+	defer release()
+	resp, err := method.Struct()
+	if err == nil {
+		infoCapnp, _ := resp.Info()
+		info.DataSize = infoCapnp.DataSize()
+		info.Engine, _ = infoCapnp.Engine()
+		info.Id, _ = infoCapnp.Id()
+		info.NrRecords = infoCapnp.NrRecords()
+	}
+	return info
+}
+
+// ListBuckets returns the IDs of the buckets in the client's store
+func (cl *ClientStateCapnpClient) ListBuckets(ctx context.Context) (bucketIDs []string, err error) {
+	method, release := cl.capability.ListBuckets(ctx, nil)
+	defer release()
+	resp, err := method.Struct()
+	if err == nil {
+		bucketIDsCapnp, err2 := resp.BucketIDs()
+		err = err2
+		bucketIDs = caphelp.UnmarshalStringList(bucketIDsCapnp)
+	}
+	return bucketIDs, err
+}
+
+// PrefixCursor returns an iterator for the keys with the given prefix
+func (cl *ClientStateCapnpClient) PrefixCursor(
+	ctx context.Context, prefix string) (cursor bucketstore.IBucketCursor) {
+
+	method, release := cl.capability.PrefixCursor(ctx,
+		func(params hubapi.CapClientState_prefixCursor_Params) error {
+			return params.SetPrefix(prefix)
+		})
+	defer release()
+	res, err := method.Struct()
+	if err == nil {
+		capability := res.Cap().AddRef()
+		cursor = capnpclient.NewBucketCursorCapnpClient(capability)
+	}
+	return cursor
+}
+
+// RangeCursor returns an iterator for the keys in the range [startKey, endKey)
+func (cl *ClientStateCapnpClient) RangeCursor(
+	ctx context.Context, startKey string, endKey string) (cursor bucketstore.IBucketCursor) {
+
+	method, release := cl.capability.RangeCursor(ctx,
+		func(params hubapi.CapClientState_rangeCursor_Params) error {
+			err := params.SetStartKey(startKey)
+			if err == nil {
+				err = params.SetEndKey(endKey)
+			}
+			return err
+		})
+	defer release()
+	res, err := method.Struct()
+	if err == nil {
+		capability := res.Cap().AddRef()
+		cursor = capnpclient.NewBucketCursorCapnpClient(capability)
+	}
+	return cursor
+}
+
 // Release the capability and commit changes
 func (cl *ClientStateCapnpClient) Release() {
 	cl.capability.Release()
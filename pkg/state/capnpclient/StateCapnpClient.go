@@ -17,6 +17,18 @@ type StateCapnpClient struct {
 	capability hubapi.CapState // capnp client of the state store
 }
 
+// Backup writes a snapshot of the client stores into a directory on the hub
+func (cl *StateCapnpClient) Backup(ctx context.Context, directory string) error {
+	method, release := cl.capability.Backup(ctx,
+		func(params hubapi.CapState_backup_Params) error {
+			err2 := params.SetDirectory(directory)
+			return err2
+		})
+	defer release()
+	_, err := method.Struct()
+	return err
+}
+
 func (cl *StateCapnpClient) CapClientState(
 	ctx context.Context, clientID string, appID string) (state.IClientState, error) {
 
@@ -16,6 +16,47 @@ type ClientStateCapnpServer struct {
 	srv state.IClientState
 }
 
+// Begin returns the capability of a transaction on the bucket to the client
+func (capsrv *ClientStateCapnpServer) Begin(
+	ctx context.Context, call hubapi.CapClientState_begin) error {
+
+	tx, err := capsrv.srv.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	bucketTxCapnpServer := capnpserver.NewBucketTxCapnpServer(tx)
+	capability := hubapi.CapBucketTx_ServerToClient(bucketTxCapnpServer)
+	res, err := call.AllocResults()
+	if err == nil {
+		err = res.SetCap(capability)
+	}
+	return err
+}
+
+// CompareAndSet replaces the value of the key if its current value equals the old value
+func (capsrv *ClientStateCapnpServer) CompareAndSet(
+	ctx context.Context, call hubapi.CapClientState_compareAndSet) error {
+	args := call.Args()
+	key, _ := args.Key()
+	var oldValue []byte
+	if args.OldValueExists() {
+		oldValue, _ = args.OldValue()
+		if oldValue == nil {
+			oldValue = []byte{}
+		}
+	}
+	newValue, _ := args.NewValue()
+	swapped, err := capsrv.srv.CompareAndSet(ctx, key, oldValue, newValue)
+	if err == nil {
+		res, err2 := call.AllocResults()
+		err = err2
+		if err == nil {
+			res.SetSwapped(swapped)
+		}
+	}
+	return err
+}
+
 // Cursor returns the capability to iterate the bucket to the client
 func (capsrv *ClientStateCapnpServer) Cursor(
 	ctx context.Context, call hubapi.CapClientState_cursor) error {
@@ -44,6 +85,14 @@ func (capsrv *ClientStateCapnpServer) Delete(
 	return err
 }
 
+func (capsrv *ClientStateCapnpServer) DeleteBucket(
+	ctx context.Context, call hubapi.CapClientState_deleteBucket) error {
+	args := call.Args()
+	bucketID, _ := args.BucketID()
+	err := capsrv.srv.DeleteBucket(ctx, bucketID)
+	return err
+}
+
 func (capsrv *ClientStateCapnpServer) Get(
 	ctx context.Context, call hubapi.CapClientState_get) error {
 	args := call.Args()
@@ -74,6 +123,68 @@ func (capsrv *ClientStateCapnpServer) GetMultiple(
 	return err
 }
 
+// Info returns the client's store information
+func (capsrv *ClientStateCapnpServer) Info(
+	ctx context.Context, call hubapi.CapClientState_info) error {
+	storeInfo := capsrv.srv.Info(ctx)
+	res, err := call.AllocResults()
+	if err == nil {
+		infoCapnp, err2 := res.NewInfo()
+		err = err2
+		if err == nil {
+			infoCapnp.SetDataSize(storeInfo.DataSize)
+			_ = infoCapnp.SetEngine(storeInfo.Engine)
+			_ = infoCapnp.SetId(storeInfo.Id)
+			infoCapnp.SetNrRecords(storeInfo.NrRecords)
+		}
+	}
+	return err
+}
+
+func (capsrv *ClientStateCapnpServer) ListBuckets(
+	ctx context.Context, call hubapi.CapClientState_listBuckets) error {
+	bucketIDs, err := capsrv.srv.ListBuckets(ctx)
+	if err == nil {
+		res, err2 := call.AllocResults()
+		err = err2
+		if err == nil {
+			err = res.SetBucketIDs(caphelp.MarshalStringList(bucketIDs))
+		}
+	}
+	return err
+}
+
+// PrefixCursor returns the capability to iterate the keys with a prefix
+func (capsrv *ClientStateCapnpServer) PrefixCursor(
+	ctx context.Context, call hubapi.CapClientState_prefixCursor) error {
+	args := call.Args()
+	prefix, _ := args.Prefix()
+	pogoCursor := capsrv.srv.PrefixCursor(ctx, prefix)
+	bucketCursorCapnpServer := capnpserver.NewBucketCursorCapnpServer(pogoCursor)
+	capability := hubapi.CapBucketCursor_ServerToClient(bucketCursorCapnpServer)
+	res, err := call.AllocResults()
+	if err == nil {
+		err = res.SetCap(capability)
+	}
+	return err
+}
+
+// RangeCursor returns the capability to iterate the keys in a range
+func (capsrv *ClientStateCapnpServer) RangeCursor(
+	ctx context.Context, call hubapi.CapClientState_rangeCursor) error {
+	args := call.Args()
+	startKey, _ := args.StartKey()
+	endKey, _ := args.EndKey()
+	pogoCursor := capsrv.srv.RangeCursor(ctx, startKey, endKey)
+	bucketCursorCapnpServer := capnpserver.NewBucketCursorCapnpServer(pogoCursor)
+	capability := hubapi.CapBucketCursor_ServerToClient(bucketCursorCapnpServer)
+	res, err := call.AllocResults()
+	if err == nil {
+		err = res.SetCap(capability)
+	}
+	return err
+}
+
 func (capsrv *ClientStateCapnpServer) Set(
 	ctx context.Context, call hubapi.CapClientState_set) error {
 	args := call.Args()
@@ -18,6 +18,15 @@ type StateStoreCapnpServer struct {
 	svc state.IStateService
 }
 
+// Backup writes a snapshot of the client stores into a directory on the hub
+func (capsrv *StateStoreCapnpServer) Backup(
+	ctx context.Context, call hubapi.CapState_backup) error {
+
+	directory, _ := call.Args().Directory()
+	err := capsrv.svc.Backup(ctx, directory)
+	return err
+}
+
 // CapClientState returns a capnp server instance for accessing client state
 // this wraps the POGS server with a capnp binding for client application state access
 func (capsrv *StateStoreCapnpServer) CapClientState(
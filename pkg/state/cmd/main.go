@@ -20,6 +20,7 @@ func main() {
 	var cfg = config.NewStateConfig(f.Stores)
 	_ = f.LoadConfig(&cfg)
 	cfg.Backend = bucketstore.BackendKVBTree
+	cfg.Encryption.ResolveSecretFile(f.Certs)
 
 	svc := statekvstore.NewStateStoreService(cfg)
 
@@ -1,6 +1,10 @@
 package config
 
-import "github.com/hiveot/hub/pkg/bucketstore"
+import (
+	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
+	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
+)
 
 // StateConfig holds the configuration of the state service
 type StateConfig struct {
@@ -10,6 +14,13 @@ type StateConfig struct {
 	// Directory where DB files and folders are stored
 	StoreDirectory string `yaml:"storeDirectory"`
 
+	// Policy for syncing the write-ahead log of the stores to disk: 'always', 'interval' (default)
+	// or 'never'. See kvbtree.WALSyncAlways for details.
+	SyncPolicy string `yaml:"syncPolicy"`
+
+	// Encryption at rest of the client stores. Default is disabled.
+	Encryption encrypted.EncryptionConfig `yaml:"encryption"`
+
 	// Constraints on storing state for services
 	//Services struct {
 	//	MaxKeys      int `yaml:"maxKeys"`
@@ -28,6 +39,7 @@ func NewStateConfig(storeDirectory string) StateConfig {
 	sc := StateConfig{}
 	sc.Backend = bucketstore.BackendKVBTree
 	sc.StoreDirectory = storeDirectory
+	sc.SyncPolicy = kvbtree.WALSyncInterval
 	//sc.Services.MaxKeys = 100
 	//sc.Services.MaxValueSize = 100000
 	//sc.Users.MaxKeys = 100
@@ -15,6 +15,8 @@ import (
 type ClientState struct {
 	// The underlying persistence bucket that is concurrent safe
 	bucket bucketstore.IBucket
+	// The client's store that holds the bucket
+	store bucketstore.IBucketStore
 	// The client whose state to store
 	clientID string
 	// The bucket to store state into. Can be application ID or other
@@ -23,6 +25,18 @@ type ClientState struct {
 	onReleaseCB func(clientID string)
 }
 
+// Begin starts a transaction on the bucket
+func (svc *ClientState) Begin(_ context.Context) (tx bucketstore.IBucketTx, err error) {
+	return svc.bucket.Begin()
+}
+
+// CompareAndSet replaces the value of the key if its current value equals oldValue
+func (svc *ClientState) CompareAndSet(
+	_ context.Context, key string, oldValue []byte, newValue []byte) (swapped bool, err error) {
+	logrus.Infof("key=%s", key)
+	return svc.bucket.CompareAndSet(key, oldValue, newValue)
+}
+
 // Cursor provides an iterator cursor for the bucket
 func (svc *ClientState) Cursor(_ context.Context) (cursor bucketstore.IBucketCursor) {
 	cursor = svc.bucket.Cursor()
@@ -36,6 +50,13 @@ func (svc *ClientState) Delete(_ context.Context, key string) (err error) {
 	return err
 }
 
+// DeleteBucket removes a bucket from the client's store
+func (svc *ClientState) DeleteBucket(_ context.Context, bucketID string) (err error) {
+	logrus.Infof("bucketID=%s", bucketID)
+	err = svc.store.DeleteBucket(bucketID)
+	return err
+}
+
 // Get returns the document for the given key
 // The document can be any text.
 func (svc *ClientState) Get(_ context.Context, key string) (value []byte, err error) {
@@ -53,6 +74,29 @@ func (svc *ClientState) GetMultiple(
 	return docs, err
 }
 
+// Info returns the information of the client's store
+func (svc *ClientState) Info(_ context.Context) (info *bucketstore.BucketStoreInfo) {
+	return svc.store.Info()
+}
+
+// ListBuckets returns the IDs of the buckets in the client's store
+func (svc *ClientState) ListBuckets(_ context.Context) (bucketIDs []string, err error) {
+	return svc.store.ListBuckets()
+}
+
+// PrefixCursor provides an iterator cursor for the keys with the given prefix
+func (svc *ClientState) PrefixCursor(_ context.Context, prefix string) (cursor bucketstore.IBucketCursor) {
+	cursor = svc.bucket.PrefixCursor(prefix)
+	return cursor
+}
+
+// RangeCursor provides an iterator cursor for the keys in the range [startKey, endKey)
+func (svc *ClientState) RangeCursor(
+	_ context.Context, startKey string, endKey string) (cursor bucketstore.IBucketCursor) {
+	cursor = svc.bucket.RangeCursor(startKey, endKey)
+	return cursor
+}
+
 // Release capability and the bucket
 // This invokes the callback after closing the bucket
 func (svc *ClientState) Release() {
@@ -86,14 +130,16 @@ func (svc *ClientState) SetMultiple(_ context.Context, docs map[string][]byte) (
 //
 //	clientID
 //	bucketID
+//	store that holds the bucket
 //	bucket to store data in
 //	onRelease callback to invoke when the client is released by its protocol binding
 func NewClientState(
 	clientID string, bucketID string,
-	bucket bucketstore.IBucket,
+	store bucketstore.IBucketStore, bucket bucketstore.IBucket,
 	onReleaseCB func(clientID string)) state.IClientState {
 	cl := &ClientState{
 		bucket:      bucket,
+		store:       store,
 		clientID:    clientID,
 		bucketID:    bucketID,
 		onReleaseCB: onReleaseCB,
@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 
 	"github.com/hiveot/hub/pkg/bucketstore"
+	"github.com/hiveot/hub/pkg/bucketstore/encrypted"
 	"github.com/hiveot/hub/pkg/bucketstore/kvbtree"
 	"github.com/hiveot/hub/pkg/state"
 	"github.com/hiveot/hub/pkg/state/config"
@@ -33,6 +36,36 @@ type StateService struct {
 	mux     sync.Mutex
 }
 
+// Backup writes a snapshot of all client stores into the given directory.
+// Open stores write a snapshot while closed stores are copied.
+func (srv *StateService) Backup(_ context.Context, directory string) error {
+	logrus.Infof("writing backup of the state stores to '%s'", directory)
+	// prevent stores from opening or closing during the backup
+	srv.mux.Lock()
+	defer srv.mux.Unlock()
+	storeFiles, err := filepath.Glob(path.Join(srv.cfg.StoreDirectory, "*.json"))
+	if err == nil {
+		err = os.MkdirAll(directory, 0700)
+	}
+	for _, storeFile := range storeFiles {
+		if err != nil {
+			break
+		}
+		fileName := path.Base(storeFile)
+		clientID := strings.TrimSuffix(fileName, ".json")
+		clientStore := srv.clientStores[clientID]
+		if clientStore != nil {
+			err = clientStore.Snapshot(directory)
+		} else {
+			err = bucketstore.CopyFile(storeFile, path.Join(directory, fileName))
+		}
+	}
+	if err != nil {
+		logrus.Errorf("backup failed: %s", err)
+	}
+	return err
+}
+
 // CapClientState returns a new instance of the capability to store client state in a bucket.
 // This opens a store for the client if one doesn't yet exist.
 func (srv *StateService) CapClientState(
@@ -53,7 +86,11 @@ func (srv *StateService) CapClientState(
 		//if srv.cfg.Backend == config.StateBackendKVStore {
 		logrus.Infof("opening kv store for client '%s' bucket '%s", clientID, bucketID)
 		storePath := path.Join(srv.cfg.StoreDirectory, clientID+".json")
-		clientStore = kvbtree.NewKVStore(clientID, storePath)
+		kvStore := kvbtree.NewKVStore(clientID, storePath)
+		if srv.cfg.SyncPolicy != "" {
+			kvStore.SetSyncPolicy(srv.cfg.SyncPolicy, 0)
+		}
+		clientStore = encrypted.NewStoreFromConfig(kvStore, srv.cfg.Encryption, state.ServiceName)
 		//} else if srv.cfg.Backend == config.StateBackendBBolt {
 		//	logrus.Infof("opening boltDB store for client '%s' bucket '%s", clientID, bucketID)
 		//	storePath := path.Join(srv.cfg.StoreDirectory, clientID+".boltdb")
@@ -64,9 +101,10 @@ func (srv *StateService) CapClientState(
 		//	clientStore = pebble.NewPebbleStore(clientID, storePath)
 		//}
 		err := clientStore.Open()
-		if err == nil {
-			srv.clientStores[clientID] = clientStore
+		if err != nil {
+			return nil, err
 		}
+		srv.clientStores[clientID] = clientStore
 	}
 	// multiple bucket instances use the same store
 	// refCount keeps track how many buckets are outstanding for this client.
@@ -74,7 +112,7 @@ func (srv *StateService) CapClientState(
 	refCount++
 	srv.clientRefs[clientID] = refCount
 	bucket := clientStore.GetBucket(bucketID)
-	capability := NewClientState(clientID, bucketID, bucket, srv.onClientReleased)
+	capability := NewClientState(clientID, bucketID, clientStore, bucket, srv.onClientReleased)
 	return capability, nil
 }
 
@@ -102,6 +140,29 @@ func (srv *StateService) onClientReleased(clientID string) {
 	}
 }
 
+// Restore the client stores from a backup made with Backup.
+// The service must not be running. Client stores that are not in the backup are left as is.
+func (srv *StateService) Restore(directory string) error {
+	if srv.running {
+		return fmt.Errorf("state service must be stopped before it can be restored")
+	}
+	logrus.Infof("restoring the state stores from '%s'", directory)
+	snapshotFiles, err := filepath.Glob(path.Join(directory, "*.json"))
+	if err == nil && len(snapshotFiles) == 0 {
+		err = fmt.Errorf("no state stores found in '%s'", directory)
+	}
+	for _, snapshotFile := range snapshotFiles {
+		if err != nil {
+			break
+		}
+		fileName := path.Base(snapshotFile)
+		clientID := strings.TrimSuffix(fileName, ".json")
+		storePath := path.Join(srv.cfg.StoreDirectory, fileName)
+		err = kvbtree.NewKVStore(clientID, storePath).Restore(directory)
+	}
+	return err
+}
+
 // Start the state service
 // Ensure the stores location exists and is writable
 func (srv *StateService) Start(_ context.Context) error {